@@ -536,7 +536,8 @@ func (c *ServiceContainer) getMostResolvedServices(limit int) []map[string]inter
 }
 
 // resolveService resolves a concrete service implementation.
-// Handles both function-based and direct instance bindings.
+// Handles both function-based and direct instance bindings; factories of the
+// form func() (interface{}, error) report their error from Make.
 func (c *ServiceContainer) resolveService(concrete interface{}) (interface{}, error) {
 	// If it's a function, call it to get the instance
 	if fn, ok := concrete.(func() interface{}); ok {
@@ -547,6 +548,17 @@ func (c *ServiceContainer) resolveService(concrete interface{}) (interface{}, er
 		return instance, nil
 	}
 
+	if fn, ok := concrete.(func() (interface{}, error)); ok {
+		instance, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if instance == nil {
+			return nil, fmt.Errorf("service factory returned nil")
+		}
+		return instance, nil
+	}
+
 	// Return the concrete instance directly
 	return concrete, nil
 }
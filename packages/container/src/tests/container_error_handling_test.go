@@ -43,3 +43,33 @@ func TestContainerErrorHandling(t *testing.T) {
 		}
 	}
 }
+
+// TestContainerFactoryErrors tests factories returning an error
+func TestContainerFactoryErrors(t *testing.T) {
+	c := container.New()
+	failure := errors.New("disk not configured")
+
+	c.Bind("failing.factory", func() (interface{}, error) {
+		return nil, failure
+	})
+	c.Singleton("failing.singleton", func() (interface{}, error) {
+		return nil, failure
+	})
+
+	for _, key := range []string{"failing.factory", "failing.singleton"} {
+		resolved, err := c.Make(key)
+		if !errors.Is(err, failure) {
+			t.Errorf("Expected the factory error for %s, got %v", key, err)
+		}
+		if resolved != nil {
+			t.Errorf("Expected no instance for %s, got %v", key, resolved)
+		}
+	}
+
+	c.Bind("working.factory", func() (interface{}, error) {
+		return "disk", nil
+	})
+	if resolved, err := c.Make("working.factory"); err != nil || resolved != "disk" {
+		t.Errorf("Expected 'disk', got %v (%v)", resolved, err)
+	}
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"govel/exceptions/core"
+	httpExceptions "govel/exceptions/http"
+	"govel/new/auth/access"
+	"govel/new/auth/users"
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+type post struct {
+	ID       int
+	AuthorID int
+}
+
+type postPolicy struct{}
+
+func (postPolicy) Before(user authInterfaces.AuthenticatableInterface, ability string) *access.Response {
+	if user.(users.GenericUser)["role"] == "admin" && ability != "publish" {
+		response := access.Allow("Admins may do anything.")
+		return &response
+	}
+	return nil
+}
+
+func (postPolicy) Update(user users.GenericUser, p *post) access.Response {
+	return access.AllowIf(user["id"] == p.AuthorID, "You do not own this post.")
+}
+
+func (postPolicy) Create(user authInterfaces.AuthenticatableInterface, p *post) bool {
+	return user.(users.GenericUser)["verified"] == true
+}
+
+func (postPolicy) Delete(user users.GenericUser, p *post) access.Response {
+	return access.DenyAsNotFound()
+}
+
+func TestGate_AbilitiesAndHooks(t *testing.T) {
+	gate := access.NewGate().Define("edit-settings", func(user authInterfaces.AuthenticatableInterface, arguments ...interface{}) access.Response {
+		return access.AllowIf(user.(users.GenericUser)["role"] == "admin", "Only admins may edit settings.")
+	})
+
+	admin := users.GenericUser{"id": 1, "role": "admin"}
+	member := users.GenericUser{"id": 2, "role": "member"}
+
+	if !gate.ForUser(admin).Allows("edit-settings") {
+		t.Error("Expected admins to edit settings")
+	}
+	response := gate.ForUser(member).Inspect("edit-settings")
+	if response.Allowed() || response.Message() != "Only admins may edit settings." || response.DecidedBy() != "ability" {
+		t.Errorf("Unexpected decision: %+v", response)
+	}
+	if gate.Allows("edit-settings") {
+		t.Error("Expected guests to be denied")
+	}
+	if response := gate.ForUser(admin).Inspect("undefined"); response.Allowed() || response.DecidedBy() != "default" {
+		t.Errorf("Expected undefined abilities to be denied by default, got %+v", response)
+	}
+
+	gate.After(func(user authInterfaces.AuthenticatableInterface, ability string, result *access.Response, arguments []interface{}) *access.Response {
+		response := access.Allow()
+		return &response
+	})
+	if response := gate.ForUser(member).Inspect("undefined"); !response.Allowed() || response.DecidedBy() != "after" {
+		t.Errorf("Expected after hooks to decide undecided checks, got %+v", response)
+	}
+	if gate.ForUser(member).Allows("edit-settings") {
+		t.Error("Expected after hooks not to override decisions")
+	}
+
+	gate.Before(func(user authInterfaces.AuthenticatableInterface, ability string, arguments []interface{}) *access.Response {
+		if user.GetAuthIdentifier() == 2 {
+			response := access.Deny("Suspended.")
+			return &response
+		}
+		return nil
+	})
+	if response := gate.ForUser(member).Inspect("undefined"); response.Allowed() || response.Message() != "Suspended." || response.DecidedBy() != "before" {
+		t.Errorf("Expected before hooks to decide first, got %+v", response)
+	}
+	if !gate.ForUser(admin).Check([]string{"edit-settings"}) || gate.ForUser(member).Any([]string{"edit-settings", "undefined"}) {
+		t.Error("Unexpected Check/Any result")
+	}
+}
+
+func TestGate_Policies(t *testing.T) {
+	gate := access.NewGate().Policy(&post{}, postPolicy{})
+
+	author := users.GenericUser{"id": 7}
+	other := users.GenericUser{"id": 8, "verified": true}
+	admin := users.GenericUser{"id": 1, "role": "admin"}
+	owned := &post{ID: 1, AuthorID: 7}
+
+	if !gate.ForUser(author).Allows("update", owned) {
+		t.Error("Expected authors to update their posts")
+	}
+	response := gate.ForUser(other).Inspect("update", owned)
+	if response.Allowed() || response.Message() != "You do not own this post." || response.DecidedBy() != "policy postPolicy.Update" {
+		t.Errorf("Unexpected policy decision: %+v", response)
+	}
+	if response := gate.ForUser(admin).Inspect("update", owned); !response.Allowed() || response.DecidedBy() != "policy postPolicy.Before" {
+		t.Errorf("Expected the policy Before method to allow admins, got %+v", response)
+	}
+
+	// Abilities without an instance pass a nil pointer to select the policy
+	if !gate.ForUser(other).Allows("create", (*post)(nil)) || gate.ForUser(author).Allows("create", (*post)(nil)) {
+		t.Error("Expected bool policy methods to decide create")
+	}
+	if gate.ForUser(author).Allows("view-any", owned) {
+		t.Error("Expected abilities without a policy method to be denied")
+	}
+}
+
+func TestGate_Authorize(t *testing.T) {
+	gate := access.NewGate().Policy(&post{}, postPolicy{})
+	owned := &post{ID: 1, AuthorID: 7}
+
+	if err := gate.ForUser(users.GenericUser{"id": 7}).Authorize("update", owned); err != nil {
+		t.Errorf("Expected authorization, got %v", err)
+	}
+
+	err := gate.ForUser(users.GenericUser{"id": 8}).Authorize("update", owned)
+	var forbidden *httpExceptions.ForbiddenException
+	if !errors.As(err, &forbidden) || forbidden.GetStatusCode() != http.StatusForbidden || forbidden.GetMessage() != "You do not own this post." {
+		t.Errorf("Expected a ForbiddenException with the denial message, got %v", err)
+	}
+
+	err = gate.ForUser(users.GenericUser{"id": 7}).Authorize("delete", owned)
+	var notFound *httpExceptions.NotFoundException
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected a NotFoundException, got %T", err)
+	}
+
+	err = access.DenyWithStatus(http.StatusPaymentRequired, "Upgrade your plan.").Authorize()
+	var exception *core.Exception
+	if !errors.As(err, &exception) || exception.GetStatusCode() != http.StatusPaymentRequired {
+		t.Errorf("Expected a 402 exception, got %v", err)
+	}
+}
+
+func TestGate_RouteModels(t *testing.T) {
+	req := newRequest(nil)
+	owned := &post{ID: 1, AuthorID: 7}
+	access.BindRouteModel(req, "post", owned)
+
+	if access.RouteModel(req, "post") != owned || access.RouteModel(req, "comment") != nil {
+		t.Error("Expected bound route models to be returned by parameter")
+	}
+}
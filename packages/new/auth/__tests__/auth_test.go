@@ -0,0 +1,287 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	auth "govel/new/auth"
+	"govel/new/auth/users"
+	session "govel/new/session"
+	"govel/new/session/handlers"
+	"govel/new/session/stores"
+	types "govel/types"
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// fakeHasher "hashes" by prefixing the value
+type fakeHasher struct{}
+
+func (fakeHasher) Info(hashedValue string) types.HashInfo { return types.HashInfo{} }
+func (fakeHasher) Make(value string, options map[string]interface{}) (string, error) {
+	return "hashed:" + value, nil
+}
+func (fakeHasher) Check(value, hashedValue string, options map[string]interface{}) bool {
+	return hashedValue == "hashed:"+value
+}
+func (fakeHasher) NeedsRehash(hashedValue string, options map[string]interface{}) bool { return false }
+func (fakeHasher) IsHashed(value string) bool                                          { return strings.HasPrefix(value, "hashed:") }
+func (fakeHasher) VerifyConfiguration(value string) bool                               { return true }
+
+// fakeRequest implements the RequestInterface of guards
+type fakeRequest struct {
+	values  map[string]interface{}
+	cookies map[string]string
+	input   map[string]string
+	bearer  string
+}
+
+func newRequest(store *stores.Store) *fakeRequest {
+	req := &fakeRequest{
+		values:  make(map[string]interface{}),
+		cookies: make(map[string]string),
+		input:   make(map[string]string),
+	}
+	if store != nil {
+		req.values[session.RequestContextKey] = store
+	}
+	return req
+}
+
+func (r *fakeRequest) GetContext(key string) interface{}        { return r.values[key] }
+func (r *fakeRequest) SetContext(key string, value interface{}) { r.values[key] = value }
+func (r *fakeRequest) Cookie(name string, defaultValue ...string) string {
+	return r.cookies[name]
+}
+func (r *fakeRequest) Header(key string, defaultValue ...string) string { return "" }
+func (r *fakeRequest) Bearer() string                                   { return r.bearer }
+func (r *fakeRequest) Input(key string, defaultValue ...string) string {
+	return r.input[key]
+}
+
+func startSession(t *testing.T, handler *handlers.ArrayHandler, id ...string) *stores.Store {
+	t.Helper()
+	store := stores.NewStore("govel-session", handler, id...)
+	if err := store.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return store
+}
+
+func newManager() (*auth.AuthManager, *users.MemoryUserProvider) {
+	provider := users.NewMemoryUserProvider(fakeHasher{},
+		users.GenericUser{"id": 1, "email": "alice@example.com", "password": "hashed:secret", "api_token": "alice-token"},
+		users.GenericUser{"id": 2, "email": "bob@example.com", "password": "hashed:hunter2"},
+	)
+	manager := auth.New(fakeHasher{}).
+		RegisterProvider("users", provider).
+		AddGuard("web", map[string]interface{}{"driver": "session", "provider": "users"}).
+		AddGuard("api", map[string]interface{}{"driver": "token", "provider": "users"})
+	return manager, provider
+}
+
+func statefulGuard(t *testing.T, manager *auth.AuthManager) authInterfaces.StatefulGuardInterface {
+	t.Helper()
+	guard, err := manager.Guard()
+	if err != nil {
+		t.Fatalf("Guard failed: %v", err)
+	}
+	stateful, ok := guard.(authInterfaces.StatefulGuardInterface)
+	if !ok {
+		t.Fatalf("Expected a stateful guard, got %T", guard)
+	}
+	return stateful
+}
+
+func TestSessionGuard_AttemptLoginAndLogout(t *testing.T) {
+	manager, _ := newManager()
+	guard := statefulGuard(t, manager)
+	handler := handlers.NewArrayHandler(time.Hour)
+
+	store := startSession(t, handler)
+	req := newRequest(store)
+	if guard.Check(req) {
+		t.Fatal("Expected a guest before logging in")
+	}
+
+	ok, err := guard.Attempt(req, map[string]interface{}{"email": "alice@example.com", "password": "wrong"}, false)
+	if err != nil || ok {
+		t.Fatalf("Expected a wrong password to fail, got %v, %v", ok, err)
+	}
+	if guard.Validate(req, map[string]interface{}{"email": "nobody@example.com", "password": "secret"}) {
+		t.Error("Expected unknown users to fail validation")
+	}
+
+	oldID := store.GetID()
+	ok, err = guard.Attempt(req, map[string]interface{}{"email": "alice@example.com", "password": "secret"}, false)
+	if err != nil || !ok {
+		t.Fatalf("Expected valid credentials to log in, got %v, %v", ok, err)
+	}
+	if store.GetID() == oldID {
+		t.Error("Expected the session ID to be regenerated on login")
+	}
+	if id := guard.ID(req); id != 1 {
+		t.Errorf("Expected user 1, got %v", id)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The next request finds the user in the session
+	next := newRequest(startSession(t, handler, store.GetID()))
+	if user := guard.User(next); user == nil || user.GetAuthIdentifier() != 1 {
+		t.Fatalf("Expected the session to authenticate user 1, got %v", user)
+	}
+	if guard.ViaRemember(next) {
+		t.Error("Expected the user to come from the session, not the cookie")
+	}
+
+	if err := guard.Logout(next); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	if guard.Check(next) {
+		t.Error("Expected a guest after logging out")
+	}
+}
+
+func TestSessionGuard_RememberMeCookie(t *testing.T) {
+	manager, provider := newManager()
+	guard := statefulGuard(t, manager)
+	handler := handlers.NewArrayHandler(time.Hour)
+
+	req := newRequest(startSession(t, handler))
+	if _, err := guard.LoginUsingID(req, 2, true); err != nil {
+		t.Fatalf("LoginUsingID failed: %v", err)
+	}
+
+	cookies := auth.QueuedCookies(req)
+	if len(cookies) != 1 || cookies[0].Name != "remember_web" || !cookies[0].HttpOnly {
+		t.Fatalf("Expected an HttpOnly remember_web cookie, got %v", cookies)
+	}
+	if cookies[0].SameSite != http.SameSiteLaxMode || cookies[0].MaxAge <= 0 {
+		t.Errorf("Expected a persistent SameSite=Lax cookie, got %+v", cookies[0])
+	}
+
+	// A fresh session is authenticated by the cookie alone and gets a new
+	// ID, so a session ID fixed by an attacker is not authenticated
+	store := startSession(t, handler)
+	fixedID := store.GetID()
+	next := newRequest(store)
+	next.cookies["remember_web"] = cookies[0].Value
+	if user := guard.User(next); user == nil || user.GetAuthIdentifier() != 2 {
+		t.Fatalf("Expected the cookie to authenticate user 2, got %v", user)
+	}
+	if !guard.ViaRemember(next) {
+		t.Error("Expected ViaRemember after a cookie login")
+	}
+	if store.GetID() == fixedID {
+		t.Error("Expected a remember-me login to regenerate the session ID")
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if guard.Check(newRequest(startSession(t, handler, fixedID))) {
+		t.Error("Expected the fixed session ID not to be authenticated")
+	}
+
+	// Logging out cycles the token, so the old cookie stops working
+	if err := guard.Logout(next); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	forget := auth.QueuedCookies(next)
+	if len(forget) != 1 || forget[0].MaxAge >= 0 {
+		t.Errorf("Expected the cookie to be forgotten, got %v", forget)
+	}
+
+	stolen := newRequest(startSession(t, handler))
+	stolen.cookies["remember_web"] = cookies[0].Value
+	if guard.Check(stolen) {
+		t.Error("Expected the old cookie to be rejected after logout")
+	}
+
+	// Changing the password also invalidates the cookie
+	user, _ := provider.RetrieveByID(context.Background(), 2)
+	fresh := newRequest(startSession(t, handler))
+	if err := guard.Login(fresh, user, true); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	value := auth.QueuedCookies(fresh)[0].Value
+	user.(users.GenericUser)["password"] = "hashed:changed"
+
+	changed := newRequest(startSession(t, handler))
+	changed.cookies["remember_web"] = value
+	if guard.Check(changed) {
+		t.Error("Expected the cookie to be rejected after a password change")
+	}
+}
+
+func TestSessionGuard_RequiresSession(t *testing.T) {
+	manager, _ := newManager()
+	guard := statefulGuard(t, manager)
+
+	_, err := guard.Attempt(newRequest(nil), map[string]interface{}{"email": "alice@example.com", "password": "secret"}, false)
+	if err != auth.ErrSessionNotStarted {
+		t.Errorf("Expected ErrSessionNotStarted, got %v", err)
+	}
+	if !guard.Once(newRequest(nil), map[string]interface{}{"email": "alice@example.com", "password": "secret"}) {
+		t.Error("Expected Once to authenticate without a session")
+	}
+}
+
+func TestTokenGuard_InputAndBearer(t *testing.T) {
+	manager, _ := newManager()
+	guard, err := manager.Guard("api")
+	if err != nil {
+		t.Fatalf("Guard failed: %v", err)
+	}
+
+	bearer := newRequest(nil)
+	bearer.bearer = "alice-token"
+	if id := guard.ID(bearer); id != 1 {
+		t.Errorf("Expected the bearer token to authenticate user 1, got %v", id)
+	}
+
+	input := newRequest(nil)
+	input.input["api_token"] = "alice-token"
+	if !guard.Check(input) {
+		t.Error("Expected the api_token input to authenticate")
+	}
+
+	if guard.Check(newRequest(nil)) {
+		t.Error("Expected requests without a token to be guests")
+	}
+	if guard.Validate(newRequest(nil), map[string]interface{}{"api_token": "wrong"}) {
+		t.Error("Expected an unknown token to fail validation")
+	}
+}
+
+func TestAuthManager_Configuration(t *testing.T) {
+	manager, _ := newManager()
+
+	if manager.GetDefaultDriver() != "web" {
+		t.Errorf("Expected the first guard to be the default, got %q", manager.GetDefaultDriver())
+	}
+	if _, err := manager.Guard("admin"); err == nil {
+		t.Error("Expected undefined guards to fail")
+	}
+
+	manager.AddGuard("legacy", map[string]interface{}{"driver": "session", "provider": "missing"})
+	if _, err := manager.Guard("legacy"); err == nil {
+		t.Error("Expected guards with undefined providers to fail")
+	}
+
+	first, _ := manager.Guard("web")
+	second, _ := manager.Guard("web")
+	if first != second {
+		t.Error("Expected guards to be cached")
+	}
+
+	req := newRequest(nil)
+	req.bearer = "alice-token"
+	manager.UseGuard(req, "api")
+	if !manager.Check(req) {
+		t.Error("Expected the request guard to be used")
+	}
+}
@@ -0,0 +1,347 @@
+package tests
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	auth "govel/new/auth"
+	"govel/new/auth/jwt"
+	authInterfaces "govel/types/interfaces/auth"
+	configInterfaces "govel/types/interfaces/config"
+)
+
+func hmacKey(t *testing.T, id string, algorithm string) *jwt.Key {
+	t.Helper()
+	key, err := jwt.NewHMACKey(id, algorithm, []byte("secret-"+id))
+	if err != nil {
+		t.Fatalf("NewHMACKey failed: %v", err)
+	}
+	return key
+}
+
+func TestJWT_SignAndVerifyAlgorithms(t *testing.T) {
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	rsaKey, err := jwt.NewRSAKey("rsa", jwt.RS256, rsaPrivate)
+	if err != nil {
+		t.Fatalf("NewRSAKey failed: %v", err)
+	}
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	keys := []*jwt.Key{
+		hmacKey(t, "hs256", jwt.HS256),
+		hmacKey(t, "hs384", jwt.HS384),
+		hmacKey(t, "hs512", jwt.HS512),
+		rsaKey,
+		jwt.NewEdDSAKey("ed", edPrivate),
+	}
+
+	for _, key := range keys {
+		t.Run(key.Algorithm, func(t *testing.T) {
+			set := jwt.NewKeySet(key)
+			token, err := jwt.Sign(key, jwt.Claims{"sub": "42"})
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+
+			claims, err := jwt.Verify(set, token)
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if claims.Subject() != "42" {
+				t.Errorf("Expected sub 42, got %q", claims.Subject())
+			}
+
+			segments := strings.Split(token, ".")
+			tampered, _ := base64.RawURLEncoding.DecodeString(segments[1])
+			tampered = []byte(strings.Replace(string(tampered), "42", "43", 1))
+			forged := segments[0] + "." + base64.RawURLEncoding.EncodeToString(tampered) + "." + segments[2]
+			if _, err := jwt.Verify(set, forged); !errors.Is(err, jwt.ErrInvalidSignature) {
+				t.Errorf("Expected a tampered payload to fail, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJWT_PEMKeysAndAlgorithmConfusion(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(rand.Reader)
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(private)
+	publicDER, _ := x509.MarshalPKIXPublicKey(public)
+
+	signing, err := jwt.ParsePrivateKeyPEM("ed", jwt.EdDSA, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyPEM failed: %v", err)
+	}
+	verifying, err := jwt.ParsePublicKeyPEM("ed", jwt.EdDSA, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatalf("ParsePublicKeyPEM failed: %v", err)
+	}
+	if verifying.CanSign() {
+		t.Error("Expected public keys to be verification only")
+	}
+
+	token, _ := jwt.Sign(signing, jwt.Claims{"sub": "1"})
+	if _, err := jwt.Verify(jwt.NewKeySet(verifying), token); err != nil {
+		t.Errorf("Expected the public key to verify, got %v", err)
+	}
+
+	// A token claiming HS256 under the EdDSA kid must not verify
+	forger, _ := jwt.NewHMACKey("ed", jwt.HS256, publicDER)
+	forged, _ := jwt.Sign(forger, jwt.Claims{"sub": "1"})
+	if _, err := jwt.Verify(jwt.NewKeySet(verifying), forged); !errors.Is(err, jwt.ErrInvalidSignature) {
+		t.Errorf("Expected algorithm confusion to fail, got %v", err)
+	}
+}
+
+func TestJWT_KeyRotation(t *testing.T) {
+	old := hmacKey(t, "2025", jwt.HS256)
+	manager := jwt.NewManager(jwt.NewKeySet(old), nil, jwt.Options{})
+	ctx := context.Background()
+
+	before, err := manager.Issue(ctx, "1", nil)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	manager.Keys().Rotate(hmacKey(t, "2026", jwt.HS512))
+	after, _ := manager.Issue(ctx, "1", nil)
+
+	for _, token := range []string{before.AccessToken, after.AccessToken} {
+		if _, err := manager.Parse(ctx, token); err != nil {
+			t.Errorf("Expected tokens of both keys to verify, got %v", err)
+		}
+	}
+
+	manager.Keys().Remove("2025")
+	if _, err := manager.Parse(ctx, before.AccessToken); !errors.Is(err, jwt.ErrUnknownKey) {
+		t.Errorf("Expected removed keys to be rejected, got %v", err)
+	}
+}
+
+func TestJWT_ClaimValidation(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	validator := jwt.Validator{
+		Issuer:         "govel",
+		Audience:       "api",
+		Leeway:         30 * time.Second,
+		RequiredClaims: []string{"sub", "exp"},
+		Now:            func() time.Time { return now },
+	}
+
+	valid := jwt.Claims{"sub": "1", "iss": "govel", "aud": []interface{}{"web", "api"}, "exp": now.Unix() + 60, "nbf": now.Unix()}
+	cases := map[string]struct {
+		change func(jwt.Claims)
+		err    error
+	}{
+		"valid":             {func(jwt.Claims) {}, nil},
+		"expired in leeway": {func(c jwt.Claims) { c["exp"] = now.Unix() - 20 }, nil},
+		"expired":           {func(c jwt.Claims) { c["exp"] = now.Unix() - 31 }, jwt.ErrTokenExpired},
+		"nbf in leeway":     {func(c jwt.Claims) { c["nbf"] = now.Unix() + 20 }, nil},
+		"not yet valid":     {func(c jwt.Claims) { c["nbf"] = now.Unix() + 31 }, jwt.ErrTokenNotYetValid},
+		"wrong issuer":      {func(c jwt.Claims) { c["iss"] = "other" }, jwt.ErrInvalidIssuer},
+		"wrong audience":    {func(c jwt.Claims) { c["aud"] = "web" }, jwt.ErrInvalidAudience},
+		"missing claim":     {func(c jwt.Claims) { delete(c, "sub") }, jwt.ErrMissingClaim},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims := jwt.Claims{}
+			for key, value := range valid {
+				claims[key] = value
+			}
+			tc.change(claims)
+
+			if err := validator.Validate(claims); !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestJWT_RefreshRotationAndReuseDetection(t *testing.T) {
+	manager := jwt.NewManager(jwt.NewKeySet(hmacKey(t, "k1", jwt.HS256)), jwt.NewMemoryStore(), jwt.Options{Issuer: "govel"})
+	ctx := context.Background()
+
+	first, err := manager.Issue(ctx, "1", jwt.Claims{"role": "admin"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if _, err := manager.Refresh(ctx, first.AccessToken); !errors.Is(err, jwt.ErrInvalidTokenType) {
+		t.Errorf("Expected access tokens to be rejected by Refresh, got %v", err)
+	}
+
+	second, err := manager.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	claims, err := manager.Parse(ctx, second.AccessToken)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if claims.Subject() != "1" || claims.String("role") != "admin" {
+		t.Errorf("Expected subject and custom claims to carry over, got %v", claims)
+	}
+
+	// Replaying the rotated refresh token revokes the whole family
+	if _, err := manager.Refresh(ctx, first.RefreshToken); !errors.Is(err, jwt.ErrRefreshTokenReused) {
+		t.Fatalf("Expected reuse detection, got %v", err)
+	}
+	if _, err := manager.Parse(ctx, second.AccessToken); !errors.Is(err, jwt.ErrTokenRevoked) {
+		t.Errorf("Expected the family's access token to be revoked, got %v", err)
+	}
+	if _, err := manager.Refresh(ctx, second.RefreshToken); !errors.Is(err, jwt.ErrTokenRevoked) {
+		t.Errorf("Expected the family's refresh token to be revoked, got %v", err)
+	}
+
+	// Other logins are unaffected
+	other, _ := manager.Issue(ctx, "1", nil)
+	if _, err := manager.Parse(ctx, other.AccessToken); err != nil {
+		t.Errorf("Expected other families to stay valid, got %v", err)
+	}
+	if err := manager.Revoke(ctx, other.AccessToken); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if _, err := manager.Parse(ctx, other.AccessToken); !errors.Is(err, jwt.ErrTokenRevoked) {
+		t.Errorf("Expected a revoked token to be rejected, got %v", err)
+	}
+}
+
+func TestJWT_Guard(t *testing.T) {
+	manager, _ := newManager()
+	tokens := jwt.NewManager(jwt.NewKeySet(hmacKey(t, "k1", jwt.HS256)), nil, jwt.Options{})
+	manager.
+		Extend("jwt", func(manager *auth.AuthManager, name string, config map[string]interface{}) (authInterfaces.GuardInterface, error) {
+			provider, err := manager.CreateUserProvider("users")
+			if err != nil {
+				return nil, err
+			}
+			return jwt.NewGuard(name, provider, tokens), nil
+		}).
+		AddGuard("jwt", map[string]interface{}{"driver": "jwt", "provider": "users"})
+
+	resolved, err := manager.Guard("jwt")
+	if err != nil {
+		t.Fatalf("Guard failed: %v", err)
+	}
+	guard := resolved.(*jwt.Guard)
+
+	if pair, err := guard.Attempt(newRequest(nil), map[string]interface{}{"email": "bob@example.com", "password": "wrong"}); err != nil || pair != nil {
+		t.Fatalf("Expected wrong credentials to fail, got %v, %v", pair, err)
+	}
+	pair, err := guard.Attempt(newRequest(nil), map[string]interface{}{"email": "bob@example.com", "password": "hunter2"})
+	if err != nil || pair == nil {
+		t.Fatalf("Attempt failed: %v", err)
+	}
+
+	req := newRequest(nil)
+	req.bearer = pair.AccessToken
+	if id := guard.ID(req); id != 2 {
+		t.Fatalf("Expected the access token to authenticate user 2, got %v", id)
+	}
+
+	if err := guard.Logout(req); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	again := newRequest(nil)
+	again.cookies["jwt_token"] = pair.AccessToken
+	if guard.Check(again) {
+		t.Error("Expected the access token to be revoked after logout")
+	}
+	if _, err := guard.Refresh(again, pair.RefreshToken); !errors.Is(err, jwt.ErrTokenRevoked) {
+		t.Errorf("Expected the refresh token to be revoked after logout, got %v", err)
+	}
+}
+
+// jwtConfig is a ConfigInterface serving fixed jwt.* values
+type jwtConfig struct {
+	configInterfaces.ConfigInterface
+	values map[string]interface{}
+}
+
+func (c jwtConfig) Get(key string) (interface{}, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c jwtConfig) GetString(key string, defaultValue ...string) string {
+	if value, ok := c.values[key].(string); ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+func TestJWT_KeySetFromConfigRequiresDistinctKeyIDs(t *testing.T) {
+	previous := func(kids ...string) []interface{} {
+		keys := make([]interface{}, len(kids))
+		for i, kid := range kids {
+			keys[i] = map[string]interface{}{"kid": kid, "secret": "old-" + kid}
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name     string
+		keyID    string
+		previous []interface{}
+		valid    bool
+	}{
+		{name: "single key without kid", valid: true},
+		{name: "distinct kids", keyID: "2026", previous: previous("2025", "2024"), valid: true},
+		{name: "signing key without kid", previous: previous("2025")},
+		{name: "previous key without kid", keyID: "2026", previous: previous("")},
+		{name: "previous key reusing the signing kid", keyID: "2026", previous: previous("2026")},
+		{name: "duplicate previous kids", keyID: "2026", previous: previous("2025", "2025")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{"jwt.secret": "current", "jwt.key_id": tt.keyID}
+			if tt.previous != nil {
+				values["jwt.previous_keys"] = tt.previous
+			}
+
+			keys, err := jwt.KeySetFromConfig(jwtConfig{values: values})
+			if !tt.valid {
+				if err == nil {
+					t.Fatal("Expected the configuration to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KeySetFromConfig failed: %v", err)
+			}
+			if signing := keys.SigningKey(); signing.ID != tt.keyID {
+				t.Errorf("Expected the signing key %q, got %q", tt.keyID, signing.ID)
+			}
+		})
+	}
+}
+
+func TestJWT_KeySetAddKeepsTheSigningKey(t *testing.T) {
+	signing := hmacKey(t, "2026", jwt.HS256)
+	keys := jwt.NewKeySet(signing).Add(hmacKey(t, "2026", jwt.HS512))
+
+	if keys.SigningKey() != signing {
+		t.Fatal("Expected Add not to replace the signing key")
+	}
+	if key, _ := keys.Key("2026"); key != signing {
+		t.Error("Expected the signing kid to resolve to the signing key")
+	}
+}
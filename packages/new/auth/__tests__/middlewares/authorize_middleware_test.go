@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	auth "govel/new/auth"
+	"govel/new/auth/access"
+	"govel/new/auth/middlewares"
+	"govel/new/session/handlers"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// routeRequest is a web request matched to a route with parameters
+type routeRequest struct {
+	*webRequest
+	params map[string]string
+}
+
+func (r *routeRequest) Param(name string) string { return r.params[name] }
+
+// loggedIn returns a route request authenticated as the user with the id
+func loggedIn(t *testing.T, manager *auth.AuthManager, id int, params map[string]string) *routeRequest {
+	t.Helper()
+	req := &routeRequest{webRequest: newWebRequest(startSession(t, handlers.NewArrayHandler(time.Hour))), params: params}
+	if _, err := statefulGuard(t, manager).LoginUsingID(req, id, false); err != nil {
+		t.Fatalf("LoginUsingID failed: %v", err)
+	}
+	return req
+}
+
+func TestAuthorizeMiddleware_PassesBoundRouteModelToPolicies(t *testing.T) {
+	manager, _ := newManager()
+	gate := access.NewGate().Policy(&post{}, postPolicy{})
+	middleware := middlewares.NewAuthorizeMiddleware(manager, gate, "can:update,post")
+
+	tests := []struct {
+		name    string
+		userID  int
+		json    bool
+		status  int
+		message string
+	}{
+		{name: "author", userID: 1, status: http.StatusOK},
+		{name: "other user", userID: 2, status: http.StatusForbidden, message: "You do not own this post."},
+		{name: "other user as json", userID: 2, json: true, status: http.StatusForbidden, message: "You do not own this post."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := loggedIn(t, manager, tt.userID, map[string]string{"post": "5"})
+			req.wantsJSON = tt.json
+			access.BindRouteModel(req, "post", &post{ID: 5, AuthorID: 1})
+
+			calls := 0
+			resp := concrete(t, middleware.Handle(req, okHandler(&calls)))
+
+			if resp.StatusCode() != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, resp.StatusCode())
+			}
+			if tt.status == http.StatusOK {
+				if calls != 1 {
+					t.Error("Expected the author to reach the handler")
+				}
+				return
+			}
+			if calls != 0 {
+				t.Error("Expected denied users not to reach the handler")
+			}
+
+			message := string(resp.Body())
+			if tt.json {
+				var payload map[string]string
+				if err := json.Unmarshal(resp.Body(), &payload); err != nil {
+					t.Fatalf("Expected a JSON body, got %q", resp.Body())
+				}
+				message = payload["message"]
+			}
+			if message != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, message)
+			}
+		})
+	}
+}
+
+func TestAuthorizeMiddleware_PassesRawParametersWithoutBoundModels(t *testing.T) {
+	manager, _ := newManager()
+	var received []interface{}
+	gate := access.NewGate().Define("view-report", func(user authInterfaces.AuthenticatableInterface, arguments ...interface{}) access.Response {
+		received = arguments
+		return access.Deny()
+	})
+
+	req := loggedIn(t, manager, 1, map[string]string{"report": "2024"})
+	calls := 0
+	resp := concrete(t, middlewares.NewAuthorizeMiddleware(manager, gate, "view-report, report, scope").Handle(req, okHandler(&calls)))
+
+	if len(received) != 2 || received[0] != "2024" || received[1] != "scope" {
+		t.Errorf("Expected the raw parameter and the literal, got %v", received)
+	}
+	if calls != 0 || resp.StatusCode() != http.StatusForbidden || string(resp.Body()) != "This action is unauthorized." {
+		t.Errorf("Expected a 403 Forbidden, got %d %q", resp.StatusCode(), resp.Body())
+	}
+}
+
+// Compile-time check that route requests reach the middleware as web requests
+var _ webserverInterfaces.RequestInterface = (*routeRequest)(nil)
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	auth "govel/new/auth"
+	"govel/new/auth/access"
+	"govel/new/auth/users"
+	"govel/new/session/handlers"
+	"govel/new/session/stores"
+	types "govel/types"
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// The middleware tests build against the webserver package, so they live
+// apart from the guard tests; these helpers mirror the ones in ../
+
+// fakeHasher "hashes" by prefixing the value
+type fakeHasher struct{}
+
+func (fakeHasher) Info(hashedValue string) types.HashInfo { return types.HashInfo{} }
+func (fakeHasher) Make(value string, options map[string]interface{}) (string, error) {
+	return "hashed:" + value, nil
+}
+func (fakeHasher) Check(value, hashedValue string, options map[string]interface{}) bool {
+	return hashedValue == "hashed:"+value
+}
+func (fakeHasher) NeedsRehash(hashedValue string, options map[string]interface{}) bool { return false }
+func (fakeHasher) IsHashed(value string) bool                                          { return strings.HasPrefix(value, "hashed:") }
+func (fakeHasher) VerifyConfiguration(value string) bool                               { return true }
+
+func startSession(t *testing.T, handler *handlers.ArrayHandler, id ...string) *stores.Store {
+	t.Helper()
+	store := stores.NewStore("govel-session", handler, id...)
+	if err := store.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return store
+}
+
+func newManager() (*auth.AuthManager, *users.MemoryUserProvider) {
+	provider := users.NewMemoryUserProvider(fakeHasher{},
+		users.GenericUser{"id": 1, "email": "alice@example.com", "password": "hashed:secret", "api_token": "alice-token"},
+		users.GenericUser{"id": 2, "email": "bob@example.com", "password": "hashed:hunter2"},
+	)
+	manager := auth.New(fakeHasher{}).
+		RegisterProvider("users", provider).
+		AddGuard("web", map[string]interface{}{"driver": "session", "provider": "users"}).
+		AddGuard("api", map[string]interface{}{"driver": "token", "provider": "users"})
+	return manager, provider
+}
+
+func statefulGuard(t *testing.T, manager *auth.AuthManager) authInterfaces.StatefulGuardInterface {
+	t.Helper()
+	guard, err := manager.Guard()
+	if err != nil {
+		t.Fatalf("Guard failed: %v", err)
+	}
+	stateful, ok := guard.(authInterfaces.StatefulGuardInterface)
+	if !ok {
+		t.Fatalf("Expected a stateful guard, got %T", guard)
+	}
+	return stateful
+}
+
+type post struct {
+	ID       int
+	AuthorID int
+}
+
+type postPolicy struct{}
+
+func (postPolicy) Update(user users.GenericUser, p *post) access.Response {
+	return access.AllowIf(user["id"] == p.AuthorID, "You do not own this post.")
+}
@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	auth "govel/new/auth"
+	"govel/new/auth/middlewares"
+	session "govel/new/session"
+	"govel/new/session/handlers"
+	"govel/new/session/stores"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	"govel/new/webserver/mocks"
+)
+
+// webRequest is a webserver request carrying a session, cookies and
+// content negotiation flags; it shadows the mock methods whose
+// signatures drifted
+type webRequest struct {
+	mocks.RequestMock
+	values    map[string]interface{}
+	cookies   map[string]string
+	method    string
+	url       string
+	wantsJSON bool
+	ajax      bool
+}
+
+func newWebRequest(store *stores.Store) *webRequest {
+	req := &webRequest{
+		values:  make(map[string]interface{}),
+		cookies: make(map[string]string),
+		method:  http.MethodGet,
+		url:     "/dashboard",
+	}
+	if store != nil {
+		req.values[session.RequestContextKey] = store
+	}
+	return req
+}
+
+func (r *webRequest) GetContext(key string) interface{}        { return r.values[key] }
+func (r *webRequest) SetContext(key string, value interface{}) { r.values[key] = value }
+func (r *webRequest) Cookie(name string, defaultValue ...string) string {
+	return r.cookies[name]
+}
+func (r *webRequest) Method() string             { return r.method }
+func (r *webRequest) FullURL() string            { return r.url }
+func (r *webRequest) WantsJson() bool            { return r.wantsJSON }
+func (r *webRequest) IsAjax() bool               { return r.ajax }
+func (r *webRequest) BodyReader() io.Reader      { return nil }
+func (r *webRequest) IfModifiedSince() time.Time { return time.Time{} }
+func (r *webRequest) File(key string) (*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *webRequest) Files(key string) ([]*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *webRequest) AllFiles() (map[string][]*multipart.FileHeader, error) {
+	return nil, nil
+}
+
+// handlerFunc adapts a function to a webserver handler
+type handlerFunc func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface
+
+func (f handlerFunc) Handle(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+	return f(req)
+}
+
+// okHandler counts its calls and returns 200
+func okHandler(calls *int) webserverInterfaces.HandlerInterface {
+	return handlerFunc(func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+		*calls++
+		return webserver.NewResponse().Text("ok")
+	})
+}
+
+// concrete returns the concrete response produced by a middleware
+func concrete(t *testing.T, resp webserverInterfaces.ResponseInterface) *webserver.Response {
+	t.Helper()
+	response, ok := resp.(*webserver.Response)
+	if !ok {
+		t.Fatalf("Expected a *webserver.Response, got %T", resp)
+	}
+	return response
+}
+
+// responseCookie returns the named cookie of a response, or nil
+func responseCookie(resp *webserver.Response, name string) *http.Cookie {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestAuthenticateMiddleware_RejectsGuests(t *testing.T) {
+	manager, _ := newManager()
+	middleware := middlewares.NewAuthenticateMiddleware(manager, "/signin")
+
+	tests := []struct {
+		name      string
+		wantsJSON bool
+		ajax      bool
+		status    int
+	}{
+		{name: "browser", status: http.StatusFound},
+		{name: "json", wantsJSON: true, status: http.StatusUnauthorized},
+		{name: "ajax", ajax: true, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := startSession(t, handlers.NewArrayHandler(time.Hour))
+			req := newWebRequest(store)
+			req.wantsJSON, req.ajax = tt.wantsJSON, tt.ajax
+
+			calls := 0
+			resp := concrete(t, middleware.Handle(req, okHandler(&calls)))
+
+			if calls != 0 {
+				t.Error("Expected guests not to reach the handler")
+			}
+			if resp.StatusCode() != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, resp.StatusCode())
+			}
+			if tt.status == http.StatusFound {
+				if location := resp.HeadersMap()["Location"]; location != "/signin" {
+					t.Errorf("Expected a redirect to /signin, got %q", location)
+				}
+				if intended := store.GetString("url.intended"); intended != "/dashboard" {
+					t.Errorf("Expected the intended URL to be stored, got %q", intended)
+				}
+			} else if store.Has("url.intended") {
+				t.Error("Expected JSON requests not to store an intended URL")
+			}
+		})
+	}
+}
+
+func TestAuthenticateMiddleware_DoesNotStoreIntendedURLOfPosts(t *testing.T) {
+	manager, _ := newManager()
+	store := startSession(t, handlers.NewArrayHandler(time.Hour))
+	req := newWebRequest(store)
+	req.method = http.MethodPost
+
+	calls := 0
+	middlewares.NewAuthenticateMiddleware(manager, "").Handle(req, okHandler(&calls))
+
+	if store.Has("url.intended") {
+		t.Error("Expected only GET requests to be remembered")
+	}
+}
+
+func TestAuthenticateMiddleware_PassesAuthenticatedUsers(t *testing.T) {
+	manager, _ := newManager()
+	guard := statefulGuard(t, manager)
+	req := newWebRequest(startSession(t, handlers.NewArrayHandler(time.Hour)))
+	if _, err := guard.LoginUsingID(req, 1, false); err != nil {
+		t.Fatalf("LoginUsingID failed: %v", err)
+	}
+
+	calls := 0
+	resp := concrete(t, middlewares.NewAuthenticateMiddleware(manager, "", "api", "web").Handle(req, okHandler(&calls)))
+
+	if calls != 1 || resp.StatusCode() != http.StatusOK {
+		t.Fatalf("Expected the request to pass, got %d calls and status %d", calls, resp.StatusCode())
+	}
+}
+
+func TestAuthenticateMiddleware_AttachesQueuedRememberCookie(t *testing.T) {
+	manager, _ := newManager()
+	guard := statefulGuard(t, manager)
+	handler := handlers.NewArrayHandler(time.Hour)
+
+	login := newWebRequest(startSession(t, handler))
+	if _, err := guard.LoginUsingID(login, 2, true); err != nil {
+		t.Fatalf("LoginUsingID failed: %v", err)
+	}
+
+	// A new session authenticated by the cookie logs out in the handler
+	req := newWebRequest(startSession(t, handler))
+	req.cookies["remember_web"] = queuedCookie(t, login, "remember_web").Value
+	logout := handlerFunc(func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+		if err := guard.Logout(req); err != nil {
+			t.Fatalf("Logout failed: %v", err)
+		}
+		return webserver.NewResponse().Redirect("/", http.StatusFound)
+	})
+	resp := concrete(t, middlewares.NewAuthenticateMiddleware(manager, "").Handle(req, logout))
+
+	cookie := responseCookie(resp, "remember_web")
+	if cookie == nil || cookie.MaxAge >= 0 {
+		t.Fatalf("Expected the forget cookie on the response, got %v", resp.Cookies())
+	}
+}
+
+// queuedCookie returns the named cookie queued on a request
+func queuedCookie(t *testing.T, req *webRequest, name string) *http.Cookie {
+	t.Helper()
+	for _, cookie := range auth.QueuedCookies(req) {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	t.Fatalf("Expected the %s cookie to be queued", name)
+	return nil
+}
+
+func TestRedirectIfAuthenticatedMiddleware(t *testing.T) {
+	manager, _ := newManager()
+	guard := statefulGuard(t, manager)
+	handler := handlers.NewArrayHandler(time.Hour)
+	middleware := middlewares.NewRedirectIfAuthenticatedMiddleware(manager, "/home")
+
+	// Guests reach the login handler, whose remember-me cookie is attached
+	guest := newWebRequest(startSession(t, handler))
+	calls := 0
+	login := handlerFunc(func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+		calls++
+		if _, err := guard.LoginUsingID(req, 2, true); err != nil {
+			t.Fatalf("LoginUsingID failed: %v", err)
+		}
+		return webserver.NewResponse().Redirect("/home", http.StatusFound)
+	})
+	resp := concrete(t, middleware.Handle(guest, login))
+
+	if calls != 1 {
+		t.Fatal("Expected guests to reach the handler")
+	}
+	if cookie := responseCookie(resp, "remember_web"); cookie == nil || cookie.Value == "" || cookie.MaxAge <= 0 {
+		t.Fatalf("Expected the remember-me cookie on the response, got %v", resp.Cookies())
+	}
+
+	// Authenticated users are sent away
+	resp = concrete(t, middleware.Handle(guest, okHandler(&calls)))
+	if calls != 1 {
+		t.Error("Expected authenticated users not to reach the handler")
+	}
+	if resp.StatusCode() != http.StatusFound || resp.HeadersMap()["Location"] != "/home" {
+		t.Errorf("Expected a redirect to /home, got %d %v", resp.StatusCode(), resp.HeadersMap())
+	}
+}
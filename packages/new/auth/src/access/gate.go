@@ -0,0 +1,352 @@
+// Package access authorizes users with Laravel-style gates and policies.
+//
+// Abilities are closures or methods of a policy registered per model type:
+//
+//	gate := access.NewGate().
+//		Define("manage-settings", func(user authInterfaces.AuthenticatableInterface, arguments ...interface{}) access.Response {
+//			return access.AllowIf(user.(*User).IsAdmin, "Only admins manage settings.")
+//		}).
+//		Policy(&Post{}, &PostPolicy{})
+//
+//	err := gate.ForUser(user).Authorize("update", post) // calls PostPolicy.Update(user, post)
+package access
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// Ability decides an ability for a user and the check's arguments
+type Ability func(user authInterfaces.AuthenticatableInterface, arguments ...interface{}) Response
+
+// BeforeHook runs before abilities; a non-nil response decides the check
+type BeforeHook func(user authInterfaces.AuthenticatableInterface, ability string, arguments []interface{}) *Response
+
+// AfterHook runs after abilities with their result, nil when nothing
+// decided; its response is used only when nothing decided
+type AfterHook func(user authInterfaces.AuthenticatableInterface, ability string, result *Response, arguments []interface{}) *Response
+
+// PolicyBefore is implemented by policies that decide all of their
+// abilities for some users, such as administrators
+type PolicyBefore interface {
+	Before(user authInterfaces.AuthenticatableInterface, ability string) *Response
+}
+
+// registry holds the definitions shared by a gate and its user gates
+type registry struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// abilities are the defined abilities by name
+	abilities map[string]Ability
+
+	// policies are the policies by model struct type
+	policies map[reflect.Type]interface{}
+
+	// before are the hooks run before abilities
+	before []BeforeHook
+
+	// after are the hooks run after abilities
+	after []AfterHook
+}
+
+// Gate checks abilities for one user. NewGate returns the guest gate;
+// ForUser returns a gate for a user sharing the same definitions.
+//
+// Guests are denied every ability without running hooks, abilities or
+// policies. Policies take precedence over abilities of the same name.
+type Gate struct {
+	// registry holds the definitions
+	registry *registry
+
+	// user is the user checked, nil for guests
+	user authInterfaces.AuthenticatableInterface
+}
+
+// NewGate creates a gate without definitions
+func NewGate() *Gate {
+	return &Gate{registry: &registry{
+		abilities: make(map[string]Ability),
+		policies:  make(map[reflect.Type]interface{}),
+	}}
+}
+
+// ForUser returns a gate checking abilities for the user
+func (g *Gate) ForUser(user authInterfaces.AuthenticatableInterface) *Gate {
+	return &Gate{registry: g.registry, user: user}
+}
+
+// User returns the user checked by the gate, nil for guests
+func (g *Gate) User() authInterfaces.AuthenticatableInterface {
+	return g.user
+}
+
+// Define defines an ability
+func (g *Gate) Define(ability string, callback Ability) *Gate {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.registry.abilities[ability] = callback
+	return g
+}
+
+// Policy registers the policy of a model type. Checks whose first
+// argument is a model of that type, or a nil pointer to it, call the
+// policy method named after the ability: "update" calls Update and
+// "view-any" or "viewAny" calls ViewAny.
+//
+// Policy methods take the user (as AuthenticatableInterface or the
+// application's user type) followed by the check's arguments, and return
+// a Response or a bool.
+func (g *Gate) Policy(model interface{}, policy interface{}) *Gate {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.registry.policies[structType(reflect.TypeOf(model))] = policy
+	return g
+}
+
+// GetPolicyFor returns the policy of the model's type, or nil
+func (g *Gate) GetPolicyFor(model interface{}) interface{} {
+	if model == nil {
+		return nil
+	}
+	g.registry.mu.RLock()
+	defer g.registry.mu.RUnlock()
+	return g.registry.policies[structType(reflect.TypeOf(model))]
+}
+
+// Before registers a hook run before every check
+func (g *Gate) Before(hook BeforeHook) *Gate {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.registry.before = append(g.registry.before, hook)
+	return g
+}
+
+// After registers a hook run after every check
+func (g *Gate) After(hook AfterHook) *Gate {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.registry.after = append(g.registry.after, hook)
+	return g
+}
+
+// Has reports whether the ability is defined
+func (g *Gate) Has(ability string) bool {
+	g.registry.mu.RLock()
+	defer g.registry.mu.RUnlock()
+	_, ok := g.registry.abilities[ability]
+	return ok
+}
+
+// Abilities returns the names of the defined abilities, sorted
+func (g *Gate) Abilities() []string {
+	g.registry.mu.RLock()
+	defer g.registry.mu.RUnlock()
+
+	names := make([]string, 0, len(g.registry.abilities))
+	for name := range g.registry.abilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Allows reports whether the user may perform the ability
+func (g *Gate) Allows(ability string, arguments ...interface{}) bool {
+	return g.Inspect(ability, arguments...).Allowed()
+}
+
+// Denies reports whether the user may not perform the ability
+func (g *Gate) Denies(ability string, arguments ...interface{}) bool {
+	return !g.Allows(ability, arguments...)
+}
+
+// Check reports whether the user may perform all of the abilities
+func (g *Gate) Check(abilities []string, arguments ...interface{}) bool {
+	for _, ability := range abilities {
+		if !g.Allows(ability, arguments...) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether the user may perform any of the abilities
+func (g *Gate) Any(abilities []string, arguments ...interface{}) bool {
+	for _, ability := range abilities {
+		if g.Allows(ability, arguments...) {
+			return true
+		}
+	}
+	return false
+}
+
+// None reports whether the user may perform none of the abilities
+func (g *Gate) None(abilities []string, arguments ...interface{}) bool {
+	return !g.Any(abilities, arguments...)
+}
+
+// Authorize returns an HTTP exception carrying the denial message when the
+// user may not perform the ability
+func (g *Gate) Authorize(ability string, arguments ...interface{}) error {
+	return g.Inspect(ability, arguments...).Authorize()
+}
+
+// Inspect returns the decision for the ability with its reason;
+// Response.DecidedBy names the hook, ability or policy method that decided
+func (g *Gate) Inspect(ability string, arguments ...interface{}) Response {
+	if g.user == nil {
+		return decided(Deny(), "guest")
+	}
+
+	g.registry.mu.RLock()
+	before := append([]BeforeHook(nil), g.registry.before...)
+	after := append([]AfterHook(nil), g.registry.after...)
+	callback := g.registry.abilities[ability]
+	g.registry.mu.RUnlock()
+
+	var result *Response
+	for _, hook := range before {
+		if response := hook(g.user, ability, arguments); response != nil {
+			result = decidedPointer(*response, "before")
+			break
+		}
+	}
+
+	if result == nil && len(arguments) > 0 {
+		result = g.callPolicy(ability, arguments)
+	}
+	if result == nil && callback != nil {
+		result = decidedPointer(callback(g.user, arguments...), "ability")
+	}
+
+	for _, hook := range after {
+		if response := hook(g.user, ability, result, arguments); response != nil && result == nil {
+			result = decidedPointer(*response, "after")
+		}
+	}
+
+	if result == nil {
+		return decided(Deny(), "default")
+	}
+	return *result
+}
+
+// callPolicy decides the ability with the policy of the first argument,
+// returning nil when there is no policy or policy method
+func (g *Gate) callPolicy(ability string, arguments []interface{}) *Response {
+	policy := g.GetPolicyFor(arguments[0])
+	if policy == nil {
+		return nil
+	}
+	policyName := structType(reflect.TypeOf(policy)).Name()
+
+	if before, ok := policy.(PolicyBefore); ok {
+		if response := before.Before(g.user, ability); response != nil {
+			return decidedPointer(*response, "policy "+policyName+".Before")
+		}
+	}
+
+	name := methodName(ability)
+	method := reflect.ValueOf(policy).MethodByName(name)
+	if !method.IsValid() {
+		return nil
+	}
+	source := "policy " + policyName + "." + name
+
+	inputs, err := policyInputs(method.Type(), g.user, arguments)
+	if err != nil {
+		return decidedPointer(Deny(err.Error()), source)
+	}
+
+	outputs := method.Call(inputs)
+	if len(outputs) != 1 {
+		return decidedPointer(Deny(fmt.Sprintf("%s must return a Response or a bool", name)), source)
+	}
+	switch value := outputs[0].Interface().(type) {
+	case Response:
+		return decidedPointer(value, source)
+	case *Response:
+		if value == nil {
+			return nil
+		}
+		return decidedPointer(*value, source)
+	case bool:
+		return decidedPointer(AllowIf(value), source)
+	default:
+		return decidedPointer(Deny(fmt.Sprintf("%s must return a Response or a bool", name)), source)
+	}
+}
+
+// policyInputs converts the user and arguments to the parameters of a
+// policy method; missing or nil arguments become zero values
+func policyInputs(methodType reflect.Type, user authInterfaces.AuthenticatableInterface, arguments []interface{}) ([]reflect.Value, error) {
+	if methodType.NumIn() == 0 || methodType.IsVariadic() {
+		return nil, fmt.Errorf("policy methods must take the user followed by fixed parameters")
+	}
+
+	values := append([]interface{}{user}, arguments...)
+	inputs := make([]reflect.Value, methodType.NumIn())
+	for idx := range inputs {
+		paramType := methodType.In(idx)
+		if idx >= len(values) || values[idx] == nil {
+			inputs[idx] = reflect.Zero(paramType)
+			continue
+		}
+
+		value := reflect.ValueOf(values[idx])
+		if !value.Type().AssignableTo(paramType) {
+			return nil, fmt.Errorf("policy parameter %d expects %s, got %s", idx+1, paramType, value.Type())
+		}
+		inputs[idx] = value
+	}
+	return inputs, nil
+}
+
+// structType strips pointers from a type
+func structType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// methodName converts an ability such as "view-any", "view_any" or
+// "viewAny" to a method name
+func methodName(ability string) string {
+	var builder strings.Builder
+	upper := true
+	for _, char := range ability {
+		if char == '-' || char == '_' || char == ' ' || char == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			char = unicode.ToUpper(char)
+			upper = false
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// decided records what made a decision
+func decided(response Response, source string) Response {
+	response.decidedBy = source
+	return response
+}
+
+// decidedPointer records what made a decision and returns it as a pointer
+func decidedPointer(response Response, source string) *Response {
+	response = decided(response, source)
+	return &response
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.GateInterface = (*Gate)(nil)
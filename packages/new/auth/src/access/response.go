@@ -0,0 +1,117 @@
+package access
+
+import (
+	"net/http"
+
+	"govel/exceptions/core"
+	httpExceptions "govel/exceptions/http"
+)
+
+// DefaultDenyMessage is the message of denials without one
+const DefaultDenyMessage = "This action is unauthorized."
+
+// Response is the decision of an ability check
+type Response struct {
+	// allowed reports whether the ability was granted
+	allowed bool
+
+	// message explains the decision
+	message string
+
+	// status is the HTTP status of a denial, 403 when zero
+	status int
+
+	// decidedBy names the step that made the decision, for Inspect
+	decidedBy string
+}
+
+// Allow grants the ability
+func Allow(message ...string) Response {
+	return Response{allowed: true, message: firstOr(message, "")}
+}
+
+// Deny refuses the ability with an optional message
+func Deny(message ...string) Response {
+	return Response{message: firstOr(message, DefaultDenyMessage)}
+}
+
+// DenyWithStatus refuses the ability with an HTTP status other than 403
+func DenyWithStatus(status int, message ...string) Response {
+	return Response{message: firstOr(message, DefaultDenyMessage), status: status}
+}
+
+// DenyAsNotFound refuses the ability with a 404, hiding that the resource exists
+func DenyAsNotFound(message ...string) Response {
+	return DenyWithStatus(http.StatusNotFound, firstOr(message, "Not Found"))
+}
+
+// AllowIf grants the ability when the condition holds, otherwise denies it
+// with the message
+func AllowIf(condition bool, message ...string) Response {
+	if condition {
+		return Allow()
+	}
+	return Deny(message...)
+}
+
+// DenyIf denies the ability with the message when the condition holds
+func DenyIf(condition bool, message ...string) Response {
+	if condition {
+		return Deny(message...)
+	}
+	return Allow()
+}
+
+// Allowed reports whether the ability was granted
+func (r Response) Allowed() bool {
+	return r.allowed
+}
+
+// Denied reports whether the ability was refused
+func (r Response) Denied() bool {
+	return !r.allowed
+}
+
+// Message returns the reason of the decision
+func (r Response) Message() string {
+	return r.message
+}
+
+// Status returns the HTTP status of a denial
+func (r Response) Status() int {
+	if r.status == 0 {
+		return http.StatusForbidden
+	}
+	return r.status
+}
+
+// DecidedBy names what made the decision: "before", "ability", "policy
+// PostPolicy.Update", "after" or "default"
+func (r Response) DecidedBy() string {
+	return r.decidedBy
+}
+
+// Authorize returns nil when allowed, otherwise a ForbiddenException, a
+// NotFoundException or an exception with the denial status
+func (r Response) Authorize() error {
+	if r.allowed {
+		return nil
+	}
+
+	switch r.Status() {
+	case http.StatusForbidden:
+		return httpExceptions.NewForbiddenException(r.message)
+	case http.StatusNotFound:
+		return httpExceptions.NewNotFoundException(r.message)
+	default:
+		return core.NewException(r.message, r.Status())
+	}
+}
+
+// firstOr returns the first value or the fallback
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
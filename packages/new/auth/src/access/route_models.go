@@ -0,0 +1,20 @@
+package access
+
+import (
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// routeModelPrefix prefixes the request context keys of bound route models
+const routeModelPrefix = "__route_model_"
+
+// BindRouteModel exposes the model resolved for a route parameter, so the
+// "can" middleware passes it to policies. Route model binding calls it
+// after loading the model of "{post}" in "/posts/{post}".
+func BindRouteModel(req authInterfaces.RequestInterface, parameter string, model interface{}) {
+	req.SetContext(routeModelPrefix+parameter, model)
+}
+
+// RouteModel returns the model bound to a route parameter, or nil
+func RouteModel(req authInterfaces.RequestInterface, parameter string) interface{} {
+	return req.GetContext(routeModelPrefix + parameter)
+}
@@ -0,0 +1,414 @@
+// Package auth provides Laravel-style authentication for GoVel.
+//
+// Guards are configured under auth.guards and resolved by name:
+//
+//	guard, err := manager.Guard("web")
+//	ok, err := guard.(authInterfaces.StatefulGuardInterface).Attempt(req, map[string]interface{}{
+//		"email":    req.Input("email"),
+//		"password": req.Input("password"),
+//	}, true)
+//
+// The "session" driver keeps the user in the session and supports
+// "remember me" cookies; the "token" driver authenticates stateless API
+// requests. Guards retrieve users through the user providers configured
+// under auth.providers, checking passwords with the hashing package.
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"govel/new/auth/users"
+	authInterfaces "govel/types/interfaces/auth"
+	configInterfaces "govel/types/interfaces/config"
+	databaseInterfaces "govel/types/interfaces/database"
+	hashingInterfaces "govel/types/interfaces/hashing"
+)
+
+// requestGuardKey is the request context key of the guard chosen by the
+// auth middleware
+const requestGuardKey = "__auth_guard"
+
+// ConnectionResolver returns the named database connection, the default
+// connection for ""
+type ConnectionResolver func(name string) (databaseInterfaces.DatabaseInterface, error)
+
+// GuardCreator creates a guard of a custom driver
+type GuardCreator func(manager *AuthManager, name string, config map[string]interface{}) (authInterfaces.GuardInterface, error)
+
+// ProviderCreator creates a user provider of a custom driver
+type ProviderCreator func(manager *AuthManager, config map[string]interface{}) (authInterfaces.UserProviderInterface, error)
+
+// AuthManager resolves and caches named guards and user providers.
+//
+// Key features:
+//   - "session" and "token" guard drivers, plus custom drivers through Extend
+//   - "database" and "memory" user providers, plus custom ones through
+//     ExtendProvider and RegisterProvider
+//   - Per-request guard selection for the auth middleware
+type AuthManager struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// config provides auth.* settings, nil for standalone managers
+	config configInterfaces.ConfigInterface
+
+	// hasher checks passwords
+	hasher hashingInterfaces.HasherInterface
+
+	// resolver provides connections to database user providers
+	resolver ConnectionResolver
+
+	// defaultGuard overrides auth.defaults.guard when set
+	defaultGuard string
+
+	// guardConfigs holds guards added through AddGuard
+	guardConfigs map[string]map[string]interface{}
+
+	// providerConfigs holds providers added through AddProvider
+	providerConfigs map[string]map[string]interface{}
+
+	// guards caches resolved guards by name
+	guards map[string]authInterfaces.GuardInterface
+
+	// providers caches resolved user providers by name
+	providers map[string]authInterfaces.UserProviderInterface
+
+	// guardCreators are the custom guard drivers
+	guardCreators map[string]GuardCreator
+
+	// providerCreators are the custom user provider drivers
+	providerCreators map[string]ProviderCreator
+}
+
+// New creates a standalone auth manager configured through AddGuard and
+// AddProvider.
+//
+// Example:
+//
+//	manager := auth.New(hasher).
+//		RegisterProvider("users", users.NewMemoryUserProvider(hasher, alice)).
+//		AddGuard("web", map[string]interface{}{"driver": "session", "provider": "users"})
+func New(hasher hashingInterfaces.HasherInterface) *AuthManager {
+	return &AuthManager{
+		hasher:           hasher,
+		guardConfigs:     make(map[string]map[string]interface{}),
+		providerConfigs:  make(map[string]map[string]interface{}),
+		guards:           make(map[string]authInterfaces.GuardInterface),
+		providers:        make(map[string]authInterfaces.UserProviderInterface),
+		guardCreators:    make(map[string]GuardCreator),
+		providerCreators: make(map[string]ProviderCreator),
+	}
+}
+
+// NewAuthManager creates an auth manager reading the auth.* configuration
+func NewAuthManager(config configInterfaces.ConfigInterface, hasher hashingInterfaces.HasherInterface) *AuthManager {
+	manager := New(hasher)
+	manager.config = config
+	return manager
+}
+
+// SetConnectionResolver sets the resolver of database user providers
+func (m *AuthManager) SetConnectionResolver(resolver ConnectionResolver) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolver = resolver
+	return m
+}
+
+// Hasher returns the password hasher
+func (m *AuthManager) Hasher() hashingInterfaces.HasherInterface {
+	return m.hasher
+}
+
+// AddGuard registers a guard configuration, taking precedence over
+// auth.guards. The first added guard becomes the default of standalone
+// managers.
+func (m *AuthManager) AddGuard(name string, config map[string]interface{}) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.guardConfigs[name] = config
+	delete(m.guards, name)
+	if m.config == nil && m.defaultGuard == "" {
+		m.defaultGuard = name
+	}
+	return m
+}
+
+// AddProvider registers a user provider configuration, taking precedence
+// over auth.providers
+func (m *AuthManager) AddProvider(name string, config map[string]interface{}) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.providerConfigs[name] = config
+	delete(m.providers, name)
+	return m
+}
+
+// RegisterProvider registers a ready user provider under a name, such as
+// a provider backed by the application's user model
+func (m *AuthManager) RegisterProvider(name string, provider authInterfaces.UserProviderInterface) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.providers[name] = provider
+	return m
+}
+
+// Extend registers a custom guard driver
+func (m *AuthManager) Extend(driver string, creator GuardCreator) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.guardCreators[driver] = creator
+	return m
+}
+
+// ExtendProvider registers a custom user provider driver
+func (m *AuthManager) ExtendProvider(driver string, creator ProviderCreator) *AuthManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.providerCreators[driver] = creator
+	return m
+}
+
+// Guard implements AuthInterface
+func (m *AuthManager) Guard(name ...string) (authInterfaces.GuardInterface, error) {
+	guardName := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		guardName = name[0]
+	}
+
+	m.mu.RLock()
+	guard, exists := m.guards[guardName]
+	m.mu.RUnlock()
+	if exists {
+		return guard, nil
+	}
+
+	config, err := m.guardConfig(guardName)
+	if err != nil {
+		return nil, err
+	}
+	guard, err = m.createGuard(guardName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, exists := m.guards[guardName]; exists {
+		return existing, nil
+	}
+	m.guards[guardName] = guard
+	return guard, nil
+}
+
+// ShouldUse implements AuthInterface
+func (m *AuthManager) ShouldUse(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultGuard = name
+}
+
+// GetDefaultDriver implements AuthInterface
+func (m *AuthManager) GetDefaultDriver() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.defaultGuard != "" {
+		return m.defaultGuard
+	}
+	if m.config != nil {
+		return m.config.GetString("auth.defaults.guard", "web")
+	}
+	return "web"
+}
+
+// UseGuard records the guard that authenticated the request, so User and
+// Check use it for the rest of the request
+func (m *AuthManager) UseGuard(req authInterfaces.RequestInterface, name string) {
+	req.SetContext(requestGuardKey, name)
+}
+
+// RequestGuard returns the guard of the request: the one recorded by
+// UseGuard, otherwise the default guard
+func (m *AuthManager) RequestGuard(req authInterfaces.RequestInterface) (authInterfaces.GuardInterface, error) {
+	name, _ := req.GetContext(requestGuardKey).(string)
+	return m.Guard(name)
+}
+
+// User returns the authenticated user of the request guard, or nil
+func (m *AuthManager) User(req authInterfaces.RequestInterface) authInterfaces.AuthenticatableInterface {
+	guard, err := m.RequestGuard(req)
+	if err != nil {
+		return nil
+	}
+	return guard.User(req)
+}
+
+// Check reports whether the request guard has an authenticated user
+func (m *AuthManager) Check(req authInterfaces.RequestInterface) bool {
+	return m.User(req) != nil
+}
+
+// CreateUserProvider returns the named user provider, creating it on first use
+func (m *AuthManager) CreateUserProvider(name string) (authInterfaces.UserProviderInterface, error) {
+	m.mu.RLock()
+	provider, exists := m.providers[name]
+	m.mu.RUnlock()
+	if exists {
+		return provider, nil
+	}
+
+	config, err := m.providerConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := stringOption(config, "driver", "")
+	m.mu.RLock()
+	creator, custom := m.providerCreators[driver]
+	resolver := m.resolver
+	m.mu.RUnlock()
+
+	switch {
+	case custom:
+		provider, err = creator(m, config)
+	case driver == "database":
+		if resolver == nil {
+			return nil, fmt.Errorf("auth: user provider [%s] needs a database connection resolver", name)
+		}
+		connection, resolveErr := resolver(stringOption(config, "connection", ""))
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		provider = users.NewDatabaseUserProvider(connection, stringOption(config, "table", "users"), m.hasher)
+	case driver == "memory":
+		provider = users.NewMemoryUserProvider(m.hasher)
+	case driver == "eloquent":
+		return nil, fmt.Errorf("auth: user provider [%s] uses the eloquent driver; register the model's provider with RegisterProvider", name)
+	default:
+		return nil, &ProviderNotDefinedError{Name: name, Driver: driver}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, exists := m.providers[name]; exists {
+		return existing, nil
+	}
+	m.providers[name] = provider
+	return provider, nil
+}
+
+// createGuard creates a guard from its configuration
+func (m *AuthManager) createGuard(name string, config map[string]interface{}) (authInterfaces.GuardInterface, error) {
+	driver := stringOption(config, "driver", "")
+
+	m.mu.RLock()
+	creator, custom := m.guardCreators[driver]
+	m.mu.RUnlock()
+	if custom {
+		return creator(m, name, config)
+	}
+
+	provider, err := m.CreateUserProvider(stringOption(config, "provider", "users"))
+	if err != nil {
+		return nil, err
+	}
+
+	switch driver {
+	case "session":
+		guard := NewSessionGuard(name, provider)
+		if minutes := intOption(config, "remember", 0); minutes > 0 {
+			guard.SetRememberDuration(time.Duration(minutes) * time.Minute)
+		}
+		if secure, ok := config["secure"].(bool); ok {
+			guard.SetSecure(secure)
+		}
+		return guard, nil
+	case "token":
+		guard := NewTokenGuard(name, provider).
+			SetInputKey(stringOption(config, "input_key", "api_token")).
+			SetStorageKey(stringOption(config, "storage_key", "api_token"))
+		if hash, ok := config["hash"].(bool); ok {
+			guard.SetHash(hash)
+		}
+		return guard, nil
+	default:
+		return nil, fmt.Errorf("auth: driver [%s] for guard [%s] is not supported", driver, name)
+	}
+}
+
+// guardConfig returns the configuration of a guard
+func (m *AuthManager) guardConfig(name string) (map[string]interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if config, exists := m.guardConfigs[name]; exists {
+		return config, nil
+	}
+	if config := m.configMap("auth.guards." + name); config != nil {
+		return config, nil
+	}
+	return nil, &GuardNotDefinedError{Name: name}
+}
+
+// providerConfig returns the configuration of a user provider
+func (m *AuthManager) providerConfig(name string) (map[string]interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if config, exists := m.providerConfigs[name]; exists {
+		return config, nil
+	}
+	if config := m.configMap("auth.providers." + name); config != nil {
+		return config, nil
+	}
+	return nil, &ProviderNotDefinedError{Name: name}
+}
+
+// configMap returns a map from the configuration. The caller must hold mu.
+func (m *AuthManager) configMap(key string) map[string]interface{} {
+	if m.config == nil {
+		return nil
+	}
+	if value, exists := m.config.Get(key); exists {
+		if config, ok := value.(map[string]interface{}); ok {
+			return config
+		}
+	}
+	return nil
+}
+
+// stringOption returns a string option or the fallback
+func stringOption(config map[string]interface{}, key string, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// intOption returns an integer option or the fallback
+func intOption(config map[string]interface{}, key string, fallback int) int {
+	switch value := config[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	default:
+		return fallback
+	}
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.AuthInterface = (*AuthManager)(nil)
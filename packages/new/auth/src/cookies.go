@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"net/http"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// queuedCookiesKey is the request context key of cookies queued by guards
+const queuedCookiesKey = "__auth_cookies"
+
+// QueueCookie queues a cookie for the response of the request. Guards
+// queue "remember me" cookies; the auth middlewares attach them.
+func QueueCookie(req authInterfaces.RequestInterface, cookie *http.Cookie) {
+	queued := QueuedCookies(req)
+	for idx, existing := range queued {
+		if existing.Name == cookie.Name {
+			queued[idx] = cookie
+			return
+		}
+	}
+	req.SetContext(queuedCookiesKey, append(queued, cookie))
+}
+
+// QueuedCookies returns the cookies queued for the response of the request
+func QueuedCookies(req authInterfaces.RequestInterface) []*http.Cookie {
+	if queued, ok := req.GetContext(queuedCookiesKey).([]*http.Cookie); ok {
+		return queued
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSessionNotStarted is returned by session guards when the request has
+// no session; the StartSession middleware must run first
+var ErrSessionNotStarted = errors.New("auth: session store not set on request")
+
+// GuardNotDefinedError is returned for guards missing from auth.guards
+type GuardNotDefinedError struct {
+	// Name is the guard name
+	Name string
+}
+
+// Error implements error
+func (e *GuardNotDefinedError) Error() string {
+	return fmt.Sprintf("auth guard [%s] is not defined", e.Name)
+}
+
+// ProviderNotDefinedError is returned for user providers missing from
+// auth.providers or configured with an unsupported driver
+type ProviderNotDefinedError struct {
+	// Name is the provider name
+	Name string
+
+	// Driver is the configured driver, empty when the provider is missing
+	Driver string
+}
+
+// Error implements error
+func (e *ProviderNotDefinedError) Error() string {
+	if e.Driver == "" {
+		return fmt.Sprintf("auth user provider [%s] is not defined", e.Name)
+	}
+	return fmt.Sprintf("auth driver [%s] for user provider [%s] is not supported", e.Driver, e.Name)
+}
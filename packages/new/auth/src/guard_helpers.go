@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// guardState is the authentication state of a guard for one request
+type guardState struct {
+	// user is the authenticated user, nil for guests
+	user authInterfaces.AuthenticatableInterface
+
+	// resolved reports whether the user has been looked up
+	resolved bool
+
+	// loggedOut reports whether the user logged out during the request
+	loggedOut bool
+
+	// viaRemember reports whether the "remember me" cookie authenticated the user
+	viaRemember bool
+}
+
+// stateOf returns the request state of the named guard, creating it
+func stateOf(req authInterfaces.RequestInterface, name string) *guardState {
+	key := "__auth_" + name
+	if state, ok := req.GetContext(key).(*guardState); ok {
+		return state
+	}
+	state := &guardState{}
+	req.SetContext(key, state)
+	return state
+}
+
+// randomToken returns a random hexadecimal token of the length
+func randomToken(length int) string {
+	bytes := make([]byte, (length+1)/2)
+	if _, err := rand.Read(bytes); err != nil {
+		panic("auth: failed to generate random token: " + err.Error())
+	}
+	return hex.EncodeToString(bytes)[:length]
+}
+
+// toString formats an identifier for cookies and token lookups
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,127 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Registered and private claim names
+const (
+	ClaimIssuer    = "iss"
+	ClaimSubject   = "sub"
+	ClaimAudience  = "aud"
+	ClaimExpiresAt = "exp"
+	ClaimNotBefore = "nbf"
+	ClaimIssuedAt  = "iat"
+	ClaimID        = "jti"
+
+	// ClaimType is "access" or "refresh"
+	ClaimType = "typ"
+
+	// ClaimFamily links the access and refresh tokens descending from one login
+	ClaimFamily = "fam"
+)
+
+// Token types
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// Claims are the payload of a token. Numeric claims decode as json.Number.
+type Claims map[string]interface{}
+
+// String returns a string claim, "" when missing
+func (c Claims) String(name string) string {
+	switch value := c[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Subject returns the sub claim
+func (c Claims) Subject() string {
+	return c.String(ClaimSubject)
+}
+
+// ID returns the jti claim
+func (c Claims) ID() string {
+	return c.String(ClaimID)
+}
+
+// Type returns the typ claim
+func (c Claims) Type() string {
+	return c.String(ClaimType)
+}
+
+// Family returns the fam claim
+func (c Claims) Family() string {
+	return c.String(ClaimFamily)
+}
+
+// Audience returns the aud claim, which may be a string or an array
+func (c Claims) Audience() []string {
+	switch value := c[ClaimAudience].(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		audience := make([]string, 0, len(value))
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				audience = append(audience, text)
+			}
+		}
+		return audience
+	default:
+		return nil
+	}
+}
+
+// Time returns a NumericDate claim, reporting whether it is present
+func (c Claims) Time(name string) (time.Time, bool) {
+	var seconds float64
+	switch value := c[name].(type) {
+	case json.Number:
+		parsed, err := value.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		seconds = parsed
+	case float64:
+		seconds = value
+	case int64:
+		seconds = float64(value)
+	case int:
+		seconds = float64(value)
+	case time.Time:
+		return value, true
+	default:
+		return time.Time{}, false
+	}
+
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*1e9)), true
+}
+
+// ExpiresAt returns the exp claim, the zero time when missing
+func (c Claims) ExpiresAt() time.Time {
+	expiresAt, _ := c.Time(ClaimExpiresAt)
+	return expiresAt
+}
+
+// clone returns a shallow copy of the claims
+func (c Claims) clone() Claims {
+	copied := make(Claims, len(c))
+	for name, value := range c {
+		copied[name] = value
+	}
+	return copied
+}
@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	configInterfaces "govel/types/interfaces/config"
+)
+
+// NewManagerFromConfig creates a token manager from the jwt.* configuration
+func NewManagerFromConfig(config configInterfaces.ConfigInterface, store Store) (*Manager, error) {
+	keys, err := KeySetFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	options := Options{
+		Issuer:           config.GetString("jwt.claims.issuer"),
+		Audience:         config.GetString("jwt.claims.audience"),
+		AccessTTL:        time.Duration(config.GetInt("jwt.access_token_ttl", 15)) * time.Minute,
+		RefreshTTL:       time.Duration(config.GetInt("jwt.refresh_token_ttl", 20160)) * time.Minute,
+		Leeway:           time.Duration(config.GetInt("jwt.leeway", 0)) * time.Second,
+		RequiredClaims:   config.GetStringSlice("jwt.required_claims"),
+		DisableBlacklist: !config.GetBool("jwt.storage.blacklist.enabled", true),
+	}
+	return NewManager(keys, store, options), nil
+}
+
+// KeySetFromConfig builds the key set from jwt.algorithm, jwt.key_id, the
+// secret or key files of the algorithm, and jwt.previous_keys. With
+// previous keys, every key needs a distinct kid so tokens select theirs.
+func KeySetFromConfig(config configInterfaces.ConfigInterface) (*KeySet, error) {
+	algorithm := config.GetString("jwt.algorithm", HS256)
+	section := "jwt.rsa"
+	if algorithm == EdDSA {
+		section = "jwt.eddsa"
+	}
+
+	signing, err := keyFromOptions(map[string]interface{}{
+		"kid":              config.GetString("jwt.key_id"),
+		"algorithm":        algorithm,
+		"secret":           config.GetString("jwt.secret"),
+		"private_key_path": config.GetString(section + ".private_key_path"),
+		"public_key_path":  config.GetString(section + ".public_key_path"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := NewKeySet(signing)
+	previous, _ := config.Get("jwt.previous_keys")
+	options := optionList(previous)
+	if len(options) > 0 && signing.ID == "" {
+		return nil, fmt.Errorf("jwt: jwt.key_id is required when jwt.previous_keys are configured")
+	}
+
+	seen := map[string]bool{signing.ID: true}
+	for i, option := range options {
+		key, err := keyFromOptions(option)
+		if err != nil {
+			return nil, err
+		}
+		if key.ID == "" {
+			return nil, fmt.Errorf("jwt: jwt.previous_keys.%d has no kid", i)
+		}
+		if seen[key.ID] {
+			return nil, fmt.Errorf("jwt: jwt.previous_keys.%d reuses the kid %q", i, key.ID)
+		}
+		seen[key.ID] = true
+		keys.Add(key)
+	}
+	return keys, nil
+}
+
+// keyFromOptions builds a key from "kid", "algorithm" and "secret" or
+// "private_key_path"/"public_key_path" options
+func keyFromOptions(options map[string]interface{}) (*Key, error) {
+	id, _ := options["kid"].(string)
+	algorithm, _ := options["algorithm"].(string)
+	if algorithm == "" {
+		algorithm = HS256
+	}
+
+	switch algorithm {
+	case HS256, HS384, HS512:
+		secret, _ := options["secret"].(string)
+		return NewHMACKey(id, algorithm, []byte(secret))
+	case RS256, RS384, RS512, EdDSA:
+		if path, _ := options["private_key_path"].(string); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("jwt: failed to read private key: %w", err)
+			}
+			return ParsePrivateKeyPEM(id, algorithm, data)
+		}
+		if path, _ := options["public_key_path"].(string); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("jwt: failed to read public key: %w", err)
+			}
+			return ParsePublicKeyPEM(id, algorithm, data)
+		}
+		return nil, fmt.Errorf("jwt: %s key %q needs a private or public key path", algorithm, id)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+// optionList converts a configured list of maps
+func optionList(value interface{}) []map[string]interface{} {
+	switch list := value.(type) {
+	case []map[string]interface{}:
+		return list
+	case []interface{}:
+		options := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if option, ok := item.(map[string]interface{}); ok {
+				options = append(options, option)
+			}
+		}
+		return options
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package jwt
+
+import (
+	"context"
+	"time"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// DatabaseStore keeps entries in a table with a unique string "token_key"
+// column and an integer "expires_at" column holding a Unix timestamp
+type DatabaseStore struct {
+	// connection queries the table
+	connection databaseInterfaces.DatabaseInterface
+
+	// table holds the entries
+	table string
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewDatabaseStore creates a database store; table defaults to "jwt_tokens"
+func NewDatabaseStore(connection databaseInterfaces.DatabaseInterface, table string) *DatabaseStore {
+	if table == "" {
+		table = "jwt_tokens"
+	}
+	return &DatabaseStore{connection: connection, table: table, now: time.Now}
+}
+
+// Add implements Store. The unique key makes concurrent adds fail for all
+// but one caller.
+func (s *DatabaseStore) Add(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	if _, err := s.connection.Table(s.table).
+		Where("token_key", "=", key).
+		Where("expires_at", "<=", s.now().Unix()).
+		DeleteContext(ctx); err != nil {
+		return false, err
+	}
+
+	_, err := s.connection.Table(s.table).InsertContext(ctx, map[string]interface{}{
+		"token_key":  key,
+		"expires_at": expiresAt.Unix(),
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if exists, lookupErr := s.Has(ctx, key); lookupErr == nil && exists {
+		return false, nil
+	}
+	return false, err
+}
+
+// Has implements Store
+func (s *DatabaseStore) Has(ctx context.Context, key string) (bool, error) {
+	return s.connection.Table(s.table).
+		Where("token_key", "=", key).
+		Where("expires_at", ">", s.now().Unix()).
+		ExistsContext(ctx)
+}
+
+// Cleanup removes expired entries
+func (s *DatabaseStore) Cleanup(ctx context.Context) error {
+	_, err := s.connection.Table(s.table).Where("expires_at", "<=", s.now().Unix()).DeleteContext(ctx)
+	return err
+}
+
+// Compile-time interface compliance check
+var _ Store = (*DatabaseStore)(nil)
@@ -0,0 +1,43 @@
+package jwt
+
+import "errors"
+
+// Errors returned when parsing and validating tokens
+var (
+	// ErrMalformedToken is returned for tokens that are not three base64url segments of JSON
+	ErrMalformedToken = errors.New("jwt: malformed token")
+
+	// ErrUnsupportedAlgorithm is returned for algorithms other than HS256/384/512, RS256/384/512 and EdDSA
+	ErrUnsupportedAlgorithm = errors.New("jwt: unsupported algorithm")
+
+	// ErrUnknownKey is returned when no key matches the kid of the token
+	ErrUnknownKey = errors.New("jwt: unknown signing key")
+
+	// ErrInvalidSignature is returned when the signature does not verify
+	ErrInvalidSignature = errors.New("jwt: invalid signature")
+
+	// ErrTokenExpired is returned for tokens past their exp claim
+	ErrTokenExpired = errors.New("jwt: token has expired")
+
+	// ErrTokenNotYetValid is returned for tokens before their nbf or iat claim
+	ErrTokenNotYetValid = errors.New("jwt: token is not valid yet")
+
+	// ErrInvalidIssuer is returned when the iss claim does not match
+	ErrInvalidIssuer = errors.New("jwt: invalid issuer")
+
+	// ErrInvalidAudience is returned when the aud claim does not contain the audience
+	ErrInvalidAudience = errors.New("jwt: invalid audience")
+
+	// ErrMissingClaim is returned when a required claim is absent
+	ErrMissingClaim = errors.New("jwt: missing required claim")
+
+	// ErrInvalidTokenType is returned when an access token is used as a refresh token or the reverse
+	ErrInvalidTokenType = errors.New("jwt: invalid token type")
+
+	// ErrTokenRevoked is returned for blacklisted tokens and token families
+	ErrTokenRevoked = errors.New("jwt: token has been revoked")
+
+	// ErrRefreshTokenReused is returned when a rotated refresh token is
+	// presented again; the whole token family is revoked
+	ErrRefreshTokenReused = errors.New("jwt: refresh token reuse detected")
+)
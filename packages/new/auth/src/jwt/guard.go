@@ -0,0 +1,212 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// guardState is the authentication state of a JWT guard for one request
+type guardState struct {
+	// user is the authenticated user, nil for guests
+	user authInterfaces.AuthenticatableInterface
+
+	// claims are the claims of the access token
+	claims Claims
+
+	// resolved reports whether the token has been parsed
+	resolved bool
+}
+
+// Guard authenticates stateless requests with JWT access tokens, read from
+// the Authorization header, then the "token" input, then the "jwt_token"
+// cookie. The sub claim holds the user identifier.
+type Guard struct {
+	// name is the guard name
+	name string
+
+	// provider retrieves users
+	provider authInterfaces.UserProviderInterface
+
+	// manager issues and parses tokens
+	manager *Manager
+
+	// inputKey is the request input holding the token
+	inputKey string
+
+	// cookieName is the cookie holding the token
+	cookieName string
+}
+
+// NewGuard creates a JWT guard
+func NewGuard(name string, provider authInterfaces.UserProviderInterface, manager *Manager) *Guard {
+	return &Guard{
+		name:       name,
+		provider:   provider,
+		manager:    manager,
+		inputKey:   "token",
+		cookieName: "jwt_token",
+	}
+}
+
+// SetInputKey sets the request input holding the token
+func (g *Guard) SetInputKey(key string) *Guard {
+	g.inputKey = key
+	return g
+}
+
+// SetCookieName sets the cookie holding the token
+func (g *Guard) SetCookieName(name string) *Guard {
+	g.cookieName = name
+	return g
+}
+
+// Manager returns the token manager
+func (g *Guard) Manager() *Manager {
+	return g.manager
+}
+
+// GetName implements GuardInterface
+func (g *Guard) GetName() string {
+	return g.name
+}
+
+// Check implements GuardInterface
+func (g *Guard) Check(req authInterfaces.RequestInterface) bool {
+	return g.User(req) != nil
+}
+
+// Guest implements GuardInterface
+func (g *Guard) Guest(req authInterfaces.RequestInterface) bool {
+	return !g.Check(req)
+}
+
+// User implements GuardInterface
+func (g *Guard) User(req authInterfaces.RequestInterface) authInterfaces.AuthenticatableInterface {
+	state := g.state(req)
+	if state.resolved {
+		return state.user
+	}
+	state.resolved = true
+
+	token := g.TokenForRequest(req)
+	if token == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	claims, err := g.manager.Parse(ctx, token)
+	if err != nil {
+		return nil
+	}
+	user, err := g.provider.RetrieveByID(ctx, claims.Subject())
+	if err != nil || user == nil {
+		return nil
+	}
+
+	state.user = user
+	state.claims = claims
+	return user
+}
+
+// ID implements GuardInterface
+func (g *Guard) ID(req authInterfaces.RequestInterface) interface{} {
+	if user := g.User(req); user != nil {
+		return user.GetAuthIdentifier()
+	}
+	return nil
+}
+
+// Validate implements GuardInterface
+func (g *Guard) Validate(req authInterfaces.RequestInterface, credentials map[string]interface{}) bool {
+	user, err := g.retrieve(credentials)
+	return err == nil && user != nil
+}
+
+// SetUser implements GuardInterface
+func (g *Guard) SetUser(req authInterfaces.RequestInterface, user authInterfaces.AuthenticatableInterface) {
+	state := g.state(req)
+	state.user = user
+	state.resolved = true
+}
+
+// Claims returns the claims of the request's access token, nil for guests
+func (g *Guard) Claims(req authInterfaces.RequestInterface) Claims {
+	g.User(req)
+	return g.state(req).claims
+}
+
+// Attempt issues a token pair when the credentials are valid; it returns
+// nil and no error for invalid credentials
+func (g *Guard) Attempt(req authInterfaces.RequestInterface, credentials map[string]interface{}) (*TokenPair, error) {
+	user, err := g.retrieve(credentials)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return g.Login(req, user)
+}
+
+// Login issues a token pair for the user and authenticates the request
+func (g *Guard) Login(req authInterfaces.RequestInterface, user authInterfaces.AuthenticatableInterface) (*TokenPair, error) {
+	pair, err := g.manager.Issue(context.Background(), fmt.Sprint(user.GetAuthIdentifier()), nil)
+	if err != nil {
+		return nil, err
+	}
+	g.SetUser(req, user)
+	return pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (g *Guard) Refresh(req authInterfaces.RequestInterface, refreshToken string) (*TokenPair, error) {
+	return g.manager.Refresh(context.Background(), refreshToken)
+}
+
+// Logout revokes every token of the request's login, including its
+// refresh token
+func (g *Guard) Logout(req authInterfaces.RequestInterface) error {
+	claims := g.Claims(req)
+	state := g.state(req)
+	state.user = nil
+	state.claims = nil
+	if claims == nil {
+		return nil
+	}
+
+	return g.manager.RevokeFamily(context.Background(), claims.Family())
+}
+
+// TokenForRequest returns the access token of the request, "" when missing
+func (g *Guard) TokenForRequest(req authInterfaces.RequestInterface) string {
+	if token := req.Bearer(); token != "" {
+		return token
+	}
+	if token := req.Input(g.inputKey); token != "" {
+		return token
+	}
+	return strings.TrimSpace(req.Cookie(g.cookieName))
+}
+
+// retrieve returns the user of valid credentials, nil otherwise
+func (g *Guard) retrieve(credentials map[string]interface{}) (authInterfaces.AuthenticatableInterface, error) {
+	user, err := g.provider.RetrieveByCredentials(context.Background(), credentials)
+	if err != nil || user == nil || !g.provider.ValidateCredentials(user, credentials) {
+		return nil, err
+	}
+	return user, nil
+}
+
+// state returns the request state of the guard, creating it
+func (g *Guard) state(req authInterfaces.RequestInterface) *guardState {
+	key := "__auth_" + g.name
+	if state, ok := req.GetContext(key).(*guardState); ok {
+		return state
+	}
+	state := &guardState{}
+	req.SetContext(key, state)
+	return state
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.GuardInterface = (*Guard)(nil)
@@ -0,0 +1,299 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"sync"
+)
+
+// Supported signing algorithms
+const (
+	HS256 = "HS256"
+	HS384 = "HS384"
+	HS512 = "HS512"
+	RS256 = "RS256"
+	RS384 = "RS384"
+	RS512 = "RS512"
+	EdDSA = "EdDSA"
+)
+
+// Key signs and verifies tokens with one algorithm. Keys built from a
+// public key only verify.
+type Key struct {
+	// ID is the kid header of tokens signed with the key
+	ID string
+
+	// Algorithm is one of the supported algorithms
+	Algorithm string
+
+	// secret is the HMAC secret
+	secret []byte
+
+	// private signs RSA and EdDSA tokens, nil for verification-only keys
+	private crypto.Signer
+
+	// public verifies RSA and EdDSA tokens
+	public crypto.PublicKey
+}
+
+// NewHMACKey creates an HS256, HS384 or HS512 key
+func NewHMACKey(id string, algorithm string, secret []byte) (*Key, error) {
+	if hashOf(algorithm) == nil || algorithm[:2] != "HS" {
+		return nil, fmt.Errorf("%w: %s is not an HMAC algorithm", ErrUnsupportedAlgorithm, algorithm)
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("jwt: HMAC key %q has an empty secret", id)
+	}
+	return &Key{ID: id, Algorithm: algorithm, secret: secret}, nil
+}
+
+// NewRSAKey creates an RS256, RS384 or RS512 signing key
+func NewRSAKey(id string, algorithm string, private *rsa.PrivateKey) (*Key, error) {
+	if hashOf(algorithm) == nil || algorithm[:2] != "RS" {
+		return nil, fmt.Errorf("%w: %s is not an RSA algorithm", ErrUnsupportedAlgorithm, algorithm)
+	}
+	return &Key{ID: id, Algorithm: algorithm, private: private, public: &private.PublicKey}, nil
+}
+
+// NewRSAPublicKey creates a verification-only RSA key
+func NewRSAPublicKey(id string, algorithm string, public *rsa.PublicKey) (*Key, error) {
+	if hashOf(algorithm) == nil || algorithm[:2] != "RS" {
+		return nil, fmt.Errorf("%w: %s is not an RSA algorithm", ErrUnsupportedAlgorithm, algorithm)
+	}
+	return &Key{ID: id, Algorithm: algorithm, public: public}, nil
+}
+
+// NewEdDSAKey creates an Ed25519 signing key
+func NewEdDSAKey(id string, private ed25519.PrivateKey) *Key {
+	return &Key{ID: id, Algorithm: EdDSA, private: private, public: private.Public()}
+}
+
+// NewEdDSAPublicKey creates a verification-only Ed25519 key
+func NewEdDSAPublicKey(id string, public ed25519.PublicKey) *Key {
+	return &Key{ID: id, Algorithm: EdDSA, public: public}
+}
+
+// ParsePrivateKeyPEM parses a PKCS#1 or PKCS#8 RSA or Ed25519 private key
+// into a key for the algorithm
+func ParsePrivateKeyPEM(id string, algorithm string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: no PEM data in private key %q", id)
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to parse private key %q: %w", id, err)
+	}
+
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		return NewRSAKey(id, algorithm, private)
+	case ed25519.PrivateKey:
+		if algorithm != EdDSA {
+			return nil, fmt.Errorf("%w: Ed25519 keys sign EdDSA, not %s", ErrUnsupportedAlgorithm, algorithm)
+		}
+		return NewEdDSAKey(id, private), nil
+	default:
+		return nil, fmt.Errorf("jwt: unsupported private key type %T", parsed)
+	}
+}
+
+// ParsePublicKeyPEM parses a PKIX or PKCS#1 RSA or Ed25519 public key into
+// a verification-only key for the algorithm
+func ParsePublicKeyPEM(id string, algorithm string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: no PEM data in public key %q", id)
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PUBLIC KEY" {
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to parse public key %q: %w", id, err)
+	}
+
+	switch public := parsed.(type) {
+	case *rsa.PublicKey:
+		return NewRSAPublicKey(id, algorithm, public)
+	case ed25519.PublicKey:
+		if algorithm != EdDSA {
+			return nil, fmt.Errorf("%w: Ed25519 keys verify EdDSA, not %s", ErrUnsupportedAlgorithm, algorithm)
+		}
+		return NewEdDSAPublicKey(id, public), nil
+	default:
+		return nil, fmt.Errorf("jwt: unsupported public key type %T", parsed)
+	}
+}
+
+// CanSign reports whether the key holds a secret or private key
+func (k *Key) CanSign() bool {
+	return k.secret != nil || k.private != nil
+}
+
+// sign signs the signing input
+func (k *Key) sign(input []byte) ([]byte, error) {
+	switch k.Algorithm {
+	case HS256, HS384, HS512:
+		mac := hmac.New(hashOf(k.Algorithm), k.secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case RS256, RS384, RS512:
+		if k.private == nil {
+			return nil, fmt.Errorf("jwt: key %q cannot sign", k.ID)
+		}
+		digest, hashID := digestOf(k.Algorithm, input)
+		return k.private.Sign(rand.Reader, digest, hashID)
+	case EdDSA:
+		if k.private == nil {
+			return nil, fmt.Errorf("jwt: key %q cannot sign", k.ID)
+		}
+		return k.private.Sign(rand.Reader, input, crypto.Hash(0))
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// verify checks the signature of the signing input
+func (k *Key) verify(input []byte, signature []byte) bool {
+	switch k.Algorithm {
+	case HS256, HS384, HS512:
+		mac := hmac.New(hashOf(k.Algorithm), k.secret)
+		mac.Write(input)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case RS256, RS384, RS512:
+		public, ok := k.public.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+		digest, hashID := digestOf(k.Algorithm, input)
+		return rsa.VerifyPKCS1v15(public, hashID, digest, signature) == nil
+	case EdDSA:
+		public, ok := k.public.(ed25519.PublicKey)
+		return ok && ed25519.Verify(public, input, signature)
+	default:
+		return false
+	}
+}
+
+// hashOf returns the hash constructor of an HMAC or RSA algorithm
+func hashOf(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case HS256, RS256:
+		return sha256.New
+	case HS384, RS384:
+		return sha512.New384
+	case HS512, RS512:
+		return sha512.New
+	default:
+		return nil
+	}
+}
+
+// digestOf hashes the input for an RSA algorithm
+func digestOf(algorithm string, input []byte) ([]byte, crypto.Hash) {
+	hasher := hashOf(algorithm)()
+	hasher.Write(input)
+
+	switch algorithm {
+	case RS384:
+		return hasher.Sum(nil), crypto.SHA384
+	case RS512:
+		return hasher.Sum(nil), crypto.SHA512
+	default:
+		return hasher.Sum(nil), crypto.SHA256
+	}
+}
+
+// KeySet holds the signing key and the keys accepted for verification.
+// Rotating keys keeps the previous keys so tokens they signed stay valid
+// until they expire.
+type KeySet struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// keys indexes keys by ID
+	keys map[string]*Key
+
+	// signing is the ID of the key signing new tokens
+	signing string
+}
+
+// NewKeySet creates a key set signing with the first key
+func NewKeySet(signing *Key, verification ...*Key) *KeySet {
+	set := &KeySet{keys: make(map[string]*Key)}
+	for _, key := range verification {
+		set.keys[key.ID] = key
+	}
+	set.keys[signing.ID] = signing
+	set.signing = signing.ID
+	return set
+}
+
+// Add adds a verification key. A key with the ID of the signing key is
+// ignored; Rotate replaces the signing key.
+func (s *KeySet) Add(key *Key) *KeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.ID != s.signing {
+		s.keys[key.ID] = key
+	}
+	return s
+}
+
+// Rotate makes the key sign new tokens, keeping the previous keys for verification
+func (s *KeySet) Rotate(key *Key) *KeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.ID] = key
+	s.signing = key.ID
+	return s
+}
+
+// Remove stops accepting tokens signed with the key
+func (s *KeySet) Remove(id string) *KeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.signing {
+		delete(s.keys, id)
+	}
+	return s
+}
+
+// SigningKey returns the key signing new tokens
+func (s *KeySet) SigningKey() *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[s.signing]
+}
+
+// Key returns the key with the ID, the signing key for tokens without kid
+func (s *KeySet) Key(id string) (*Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if id == "" {
+		id = s.signing
+	}
+	key, ok := s.keys[id]
+	return key, ok
+}
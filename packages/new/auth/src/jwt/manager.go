@@ -0,0 +1,264 @@
+// Package jwt issues and verifies JSON Web Tokens for stateless API
+// authentication.
+//
+// Tokens are signed with HS256/384/512, RS256/384/512 or EdDSA keys from a
+// KeySet; the kid header selects the verification key, so signing keys can
+// be rotated without invalidating live tokens. Logins issue an access and
+// refresh token pair. Refreshing rotates the refresh token, and presenting
+// a rotated refresh token again revokes every token of that login:
+//
+//	pair, err := manager.Issue(ctx, "42", nil)
+//	claims, err := manager.Parse(ctx, pair.AccessToken)
+//	pair, err = manager.Refresh(ctx, pair.RefreshToken)
+//	err = manager.Revoke(ctx, pair.AccessToken)
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Options configure a Manager
+type Options struct {
+	// Issuer is the iss claim of issued tokens, checked when parsing
+	Issuer string
+
+	// Audience is the aud claim of issued tokens, checked when parsing
+	Audience string
+
+	// AccessTTL is the lifetime of access tokens, 15 minutes by default
+	AccessTTL time.Duration
+
+	// RefreshTTL is the lifetime of refresh tokens, two weeks by default
+	RefreshTTL time.Duration
+
+	// Leeway tolerates clock skew when checking exp, nbf and iat
+	Leeway time.Duration
+
+	// RequiredClaims must be present in parsed tokens
+	RequiredClaims []string
+
+	// DisableBlacklist skips revocation checks; Revoke and refresh token
+	// reuse detection then have no effect
+	DisableBlacklist bool
+}
+
+// TokenPair is the result of a login or refresh
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Manager issues, parses, refreshes and revokes tokens
+type Manager struct {
+	// keys sign and verify tokens
+	keys *KeySet
+
+	// store records revocations and rotated refresh tokens
+	store Store
+
+	// options configure issued tokens and validation
+	options Options
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewManager creates a token manager. A nil store keeps revocations in memory.
+func NewManager(keys *KeySet, store Store, options Options) *Manager {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	if options.AccessTTL <= 0 {
+		options.AccessTTL = 15 * time.Minute
+	}
+	if options.RefreshTTL <= 0 {
+		options.RefreshTTL = 14 * 24 * time.Hour
+	}
+	return &Manager{keys: keys, store: store, options: options, now: time.Now}
+}
+
+// Keys returns the key set, for rotation
+func (m *Manager) Keys() *KeySet {
+	return m.keys
+}
+
+// SetClock replaces the clock, for tests
+func (m *Manager) SetClock(now func() time.Time) *Manager {
+	m.now = now
+	return m
+}
+
+// Issue issues a token pair for the subject, starting a new token family.
+// The custom claims are copied into both tokens.
+func (m *Manager) Issue(ctx context.Context, subject string, custom Claims) (*TokenPair, error) {
+	return m.issue(subject, newID(), custom)
+}
+
+// Parse verifies and validates an access token
+func (m *Manager) Parse(ctx context.Context, token string) (Claims, error) {
+	return m.parse(ctx, token, AccessToken)
+}
+
+// Refresh exchanges a refresh token for a new pair of the same family. The
+// refresh token can be used once: presenting it again is treated as theft
+// and revokes the whole family, including the pair issued in exchange.
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := m.parse(ctx, refreshToken, RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.options.DisableBlacklist {
+		rotated, err := m.store.Add(ctx, "rotated:"+claims.ID(), m.expiry(claims))
+		if err != nil {
+			return nil, err
+		}
+		if !rotated {
+			if err := m.RevokeFamily(ctx, claims.Family()); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+	}
+
+	custom := claims.clone()
+	for _, name := range []string{ClaimIssuer, ClaimSubject, ClaimAudience, ClaimExpiresAt, ClaimNotBefore, ClaimIssuedAt, ClaimID, ClaimType, ClaimFamily} {
+		delete(custom, name)
+	}
+	return m.issue(claims.Subject(), claims.Family(), custom)
+}
+
+// Revoke blacklists a token until it expires
+func (m *Manager) Revoke(ctx context.Context, token string) error {
+	claims, err := m.verify(token)
+	if err != nil {
+		return err
+	}
+	_, err = m.store.Add(ctx, "revoked:"+claims.ID(), m.expiry(claims))
+	return err
+}
+
+// RevokeFamily blacklists every token descending from one login
+func (m *Manager) RevokeFamily(ctx context.Context, family string) error {
+	if family == "" {
+		return nil
+	}
+	_, err := m.store.Add(ctx, "family:"+family, m.now().Add(m.options.RefreshTTL+m.options.Leeway))
+	return err
+}
+
+// issue signs an access and refresh token of the family
+func (m *Manager) issue(subject string, family string, custom Claims) (*TokenPair, error) {
+	now := m.now()
+	accessToken, err := m.sign(subject, family, AccessToken, now, m.options.AccessTTL, custom)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := m.sign(subject, family, RefreshToken, now, m.options.RefreshTTL, custom)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(m.options.AccessTTL.Seconds()),
+	}, nil
+}
+
+// sign signs one token
+func (m *Manager) sign(subject string, family string, tokenType string, now time.Time, ttl time.Duration, custom Claims) (string, error) {
+	claims := make(Claims, len(custom)+9)
+	for name, value := range custom {
+		claims[name] = value
+	}
+
+	claims[ClaimSubject] = subject
+	claims[ClaimIssuedAt] = now.Unix()
+	claims[ClaimNotBefore] = now.Unix()
+	claims[ClaimExpiresAt] = now.Add(ttl).Unix()
+	claims[ClaimID] = newID()
+	claims[ClaimType] = tokenType
+	claims[ClaimFamily] = family
+	if m.options.Issuer != "" {
+		claims[ClaimIssuer] = m.options.Issuer
+	}
+	if m.options.Audience != "" {
+		claims[ClaimAudience] = m.options.Audience
+	}
+
+	key := m.keys.SigningKey()
+	if key == nil || !key.CanSign() {
+		return "", fmt.Errorf("jwt: no signing key configured")
+	}
+	return Sign(key, claims)
+}
+
+// verify checks the signature and claims of a token
+func (m *Manager) verify(token string) (Claims, error) {
+	claims, err := Verify(m.keys, token)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := Validator{
+		Issuer:         m.options.Issuer,
+		Audience:       m.options.Audience,
+		Leeway:         m.options.Leeway,
+		RequiredClaims: m.options.RequiredClaims,
+		Now:            m.now,
+	}
+	if err := validator.Validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// parse verifies a token of the type and checks that it is not revoked
+func (m *Manager) parse(ctx context.Context, token string, tokenType string) (Claims, error) {
+	claims, err := m.verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type() != tokenType {
+		return nil, ErrInvalidTokenType
+	}
+	if m.options.DisableBlacklist {
+		return claims, nil
+	}
+
+	for _, key := range []string{"revoked:" + claims.ID(), "family:" + claims.Family()} {
+		revoked, err := m.store.Has(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+	return claims, nil
+}
+
+// expiry returns when a store entry for the token can be dropped
+func (m *Manager) expiry(claims Claims) time.Time {
+	if expiresAt, ok := claims.Time(ClaimExpiresAt); ok {
+		return expiresAt.Add(m.options.Leeway)
+	}
+	return m.now().Add(m.options.RefreshTTL + m.options.Leeway)
+}
+
+// newID returns a random token ID
+func newID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		panic("jwt: failed to generate token ID: " + err.Error())
+	}
+	return hex.EncodeToString(bytes)
+}
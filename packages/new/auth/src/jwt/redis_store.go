@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// RedisStore keeps entries in Redis with SET NX and an expiry
+type RedisStore struct {
+	// redis runs the commands
+	redis redisInterfaces.RedisInterface
+
+	// prefix namespaces the keys
+	prefix string
+}
+
+// NewRedisStore creates a Redis store; prefix defaults to "jwt_tokens:"
+func NewRedisStore(redis redisInterfaces.RedisInterface, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "jwt_tokens:"
+	}
+	return &RedisStore{redis: redis, prefix: prefix}
+}
+
+// Add implements Store
+func (s *RedisStore) Add(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	seconds := int64(math.Ceil(time.Until(expiresAt).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	reply, err := s.redis.Command(ctx, "SET", s.prefix+key, "1", "EX", seconds, "NX")
+	if err != nil {
+		return false, fmt.Errorf("jwt: failed to store %s: %w", key, err)
+	}
+	// SET NX replies nil when the key exists
+	return reply != nil, nil
+}
+
+// Has implements Store
+func (s *RedisStore) Has(ctx context.Context, key string) (bool, error) {
+	reply, err := s.redis.Command(ctx, "EXISTS", s.prefix+key)
+	if err != nil {
+		return false, fmt.Errorf("jwt: failed to look up %s: %w", key, err)
+	}
+	count, _ := reply.(int64)
+	return count > 0, nil
+}
+
+// Compile-time interface compliance check
+var _ Store = (*RedisStore)(nil)
@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store records revoked token IDs and families, and refresh tokens that
+// have been rotated. Entries only need to outlive the tokens they reject.
+type Store interface {
+	// Add records the key until expiresAt, reporting false when it was
+	// already recorded. Adding must be atomic so two concurrent refreshes
+	// cannot both rotate the same refresh token.
+	Add(ctx context.Context, key string, expiresAt time.Time) (bool, error)
+
+	// Has reports whether the key is recorded
+	Has(ctx context.Context, key string) (bool, error)
+}
+
+// MemoryStore keeps entries in memory; revocations are lost on restart and
+// not shared between processes
+type MemoryStore struct {
+	// mu guards entries
+	mu sync.Mutex
+
+	// entries maps keys to their expiry
+	entries map[string]time.Time
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]time.Time), now: time.Now}
+}
+
+// Add implements Store
+func (s *MemoryStore) Add(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.entries[key]; ok && s.now().Before(existing) {
+		return false, nil
+	}
+	s.entries[key] = expiresAt
+	return true, nil
+}
+
+// Has implements Store
+func (s *MemoryStore) Has(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.entries[key]
+	return ok && s.now().Before(expiresAt), nil
+}
+
+// Cleanup removes expired entries, returning how many were removed
+func (s *MemoryStore) Cleanup() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	now := s.now()
+	for key, expiresAt := range s.entries {
+		if !now.Before(expiresAt) {
+			delete(s.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Compile-time interface compliance check
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// header is the JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	Type      string `json:"typ"`
+}
+
+// Sign encodes and signs the claims with the key
+func Sign(key *Key, claims Claims) (string, error) {
+	encodedHeader, err := encodeSegment(header{Algorithm: key.Algorithm, KeyID: key.ID, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := encodedHeader + "." + encodedClaims
+	signature, err := key.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the signature of a token with the key set and returns its
+// claims. The alg header must match the key of the kid header, so a token
+// cannot downgrade to "none" or verify an RSA public key as an HMAC secret.
+// Claims are not validated; see Validator.
+func Verify(keys *KeySet, token string) (Claims, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var head header
+	if err := decodeSegment(segments[0], &head); err != nil {
+		return nil, err
+	}
+
+	key, ok := keys.Key(head.KeyID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, head.KeyID)
+	}
+	if head.Algorithm != key.Algorithm {
+		return nil, fmt.Errorf("%w: token uses %q, key %q uses %s", ErrInvalidSignature, head.Algorithm, key.ID, key.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !key.verify([]byte(segments[0]+"."+segments[1]), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decodeSegment(segments[1], &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// encodeSegment encodes a value as base64url JSON
+func encodeSegment(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("jwt: failed to encode token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// decodeSegment decodes a base64url JSON segment, keeping numbers exact
+func decodeSegment(segment string, target interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformedToken
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(target); err != nil {
+		return ErrMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package jwt
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validator checks the registered claims of verified tokens
+type Validator struct {
+	// Issuer must equal the iss claim when set
+	Issuer string
+
+	// Audience must be one of the aud claim values when set
+	Audience string
+
+	// Leeway tolerates clock skew when checking exp, nbf and iat
+	Leeway time.Duration
+
+	// RequiredClaims must be present
+	RequiredClaims []string
+
+	// Now returns the current time, time.Now when nil
+	Now func() time.Time
+}
+
+// Validate checks the claims
+func (v *Validator) Validate(claims Claims) error {
+	for _, name := range v.RequiredClaims {
+		if _, ok := claims[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrMissingClaim, name)
+		}
+	}
+
+	now := v.now()
+	if expiresAt, ok := claims.Time(ClaimExpiresAt); ok && now.After(expiresAt.Add(v.Leeway)) {
+		return ErrTokenExpired
+	}
+	if notBefore, ok := claims.Time(ClaimNotBefore); ok && now.Add(v.Leeway).Before(notBefore) {
+		return ErrTokenNotYetValid
+	}
+	if issuedAt, ok := claims.Time(ClaimIssuedAt); ok && now.Add(v.Leeway).Before(issuedAt) {
+		return ErrTokenNotYetValid
+	}
+
+	if v.Issuer != "" && claims.String(ClaimIssuer) != v.Issuer {
+		return ErrInvalidIssuer
+	}
+	if v.Audience != "" && !contains(claims.Audience(), v.Audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+// now returns the current time
+func (v *Validator) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+	return time.Now()
+}
+
+// contains reports whether the values contain the value
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Package middlewares provides the auth and guest middlewares of the auth package.
+package middlewares
+
+import (
+	"net/http"
+
+	auth "govel/new/auth"
+	session "govel/new/session"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// AuthenticateMiddleware rejects requests without an authenticated user,
+// like Laravel's "auth" middleware. JSON and AJAX requests receive a 401;
+// browser requests are redirected to the login page and the intended URL
+// is stored in the session under "url.intended".
+//
+// The first guard with an authenticated user becomes the request guard of
+// the manager. Must run after StartSessionMiddleware.
+type AuthenticateMiddleware struct {
+	webserver.BaseMiddleware
+
+	// manager resolves the guards
+	manager *auth.AuthManager
+
+	// redirectTo is the login page of browser requests
+	redirectTo string
+
+	// guards are the guards to check, the default guard when empty
+	guards []string
+}
+
+// NewAuthenticateMiddleware creates a new authentication middleware.
+//
+// Parameters:
+//   - manager: Auth manager resolving the guards
+//   - redirectTo: Login page browser requests are redirected to
+//   - guards: Guards to check in order, the default guard when omitted
+func NewAuthenticateMiddleware(manager *auth.AuthManager, redirectTo string, guards ...string) *AuthenticateMiddleware {
+	if redirectTo == "" {
+		redirectTo = "/login"
+	}
+	if len(guards) == 0 {
+		guards = []string{""}
+	}
+
+	return &AuthenticateMiddleware{
+		manager:    manager,
+		redirectTo: redirectTo,
+		guards:     guards,
+	}
+}
+
+// Handle authenticates the request or rejects it.
+func (m *AuthenticateMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	for _, name := range m.guards {
+		guard, err := m.manager.Guard(name)
+		if err != nil {
+			return webserver.NewResponse().Status(http.StatusInternalServerError).Json(map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		if guard.Check(req) {
+			m.manager.UseGuard(req, guard.GetName())
+			return withQueuedCookies(req, next.Handle(req))
+		}
+	}
+
+	return withQueuedCookies(req, m.unauthenticated(req))
+}
+
+// unauthenticated returns the response of requests without a user.
+func (m *AuthenticateMiddleware) unauthenticated(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+	if req.WantsJson() || req.IsAjax() {
+		return webserver.NewResponse().Status(http.StatusUnauthorized).Json(map[string]interface{}{
+			"message": "Unauthenticated.",
+		})
+	}
+
+	if store := session.FromRequest(req); store != nil && req.Method() == http.MethodGet {
+		store.Put("url.intended", req.FullURL())
+	}
+	return webserver.NewResponse().Redirect(m.redirectTo, http.StatusFound)
+}
+
+// Priority returns the middleware priority; it must run after StartSession.
+func (m *AuthenticateMiddleware) Priority() int {
+	return 15
+}
+
+// withQueuedCookies attaches the cookies queued by guards to the response.
+func withQueuedCookies(req webserverInterfaces.RequestInterface, resp webserverInterfaces.ResponseInterface) webserverInterfaces.ResponseInterface {
+	for _, cookie := range auth.QueuedCookies(req) {
+		resp.Cookie(cookie)
+	}
+	return resp
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*AuthenticateMiddleware)(nil)
@@ -0,0 +1,100 @@
+package middlewares
+
+import (
+	"strings"
+
+	httpExceptions "govel/exceptions/http"
+	exceptionInterfaces "govel/exceptions/interfaces"
+	auth "govel/new/auth"
+	"govel/new/auth/access"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// AuthorizeMiddleware checks an ability for the authenticated user, like
+// Laravel's "can:ability,param" middleware. Each parameter names a route
+// parameter: its bound model (see access.BindRouteModel) is passed to the
+// gate, otherwise its raw value, otherwise the parameter itself.
+//
+// Denials answer with the status and message of the exception raised by the
+// gate response (a 403 ForbiddenException by default), as JSON for JSON and
+// AJAX requests. Must run after AuthenticateMiddleware.
+type AuthorizeMiddleware struct {
+	webserver.BaseMiddleware
+
+	// manager resolves the user of the request
+	manager *auth.AuthManager
+
+	// gate checks the ability
+	gate *access.Gate
+
+	// ability is the ability to check
+	ability string
+
+	// parameters are the route parameters passed to the gate
+	parameters []string
+}
+
+// NewAuthorizeMiddleware creates a new authorization middleware.
+//
+// Parameters:
+//   - manager: Auth manager resolving the user of the request
+//   - gate: Gate checking the ability
+//   - definition: "ability,param,...", optionally prefixed with "can:"
+//
+// Example:
+//
+//	router.Put("/posts/{post}", controller.Update).
+//		WithMiddleware(middlewares.NewAuthorizeMiddleware(manager, gate, "can:update,post"))
+func NewAuthorizeMiddleware(manager *auth.AuthManager, gate *access.Gate, definition string) *AuthorizeMiddleware {
+	parts := strings.Split(strings.TrimPrefix(definition, "can:"), ",")
+	for idx := range parts {
+		parts[idx] = strings.TrimSpace(parts[idx])
+	}
+
+	return &AuthorizeMiddleware{
+		manager:    manager,
+		gate:       gate,
+		ability:    parts[0],
+		parameters: parts[1:],
+	}
+}
+
+// Handle checks the ability and rejects unauthorized requests.
+func (m *AuthorizeMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	arguments := make([]interface{}, 0, len(m.parameters))
+	for _, parameter := range m.parameters {
+		if model := access.RouteModel(req, parameter); model != nil {
+			arguments = append(arguments, model)
+		} else if value := req.Param(parameter); value != "" {
+			arguments = append(arguments, value)
+		} else {
+			arguments = append(arguments, parameter)
+		}
+	}
+
+	err := m.gate.ForUser(m.manager.User(req)).Inspect(m.ability, arguments...).Authorize()
+	if err == nil {
+		return next.Handle(req)
+	}
+
+	exception, ok := err.(exceptionInterfaces.HTTPable)
+	if !ok {
+		exception = httpExceptions.NewForbiddenException(err.Error())
+	}
+
+	if req.WantsJson() || req.IsAjax() {
+		return webserver.NewResponse().Status(exception.GetStatusCode()).Json(map[string]interface{}{
+			"message": exception.GetMessage(),
+		})
+	}
+	return webserver.NewResponse().Status(exception.GetStatusCode()).Text(exception.GetMessage())
+}
+
+// Priority returns the middleware priority; it must run after Authenticate.
+func (m *AuthorizeMiddleware) Priority() int {
+	return 16
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*AuthorizeMiddleware)(nil)
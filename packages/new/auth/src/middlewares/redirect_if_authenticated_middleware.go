@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+
+	auth "govel/new/auth"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// RedirectIfAuthenticatedMiddleware sends authenticated users away from
+// guest-only pages such as the login form, like Laravel's "guest" middleware.
+type RedirectIfAuthenticatedMiddleware struct {
+	webserver.BaseMiddleware
+
+	// manager resolves the guards
+	manager *auth.AuthManager
+
+	// redirectTo is where authenticated users are sent
+	redirectTo string
+
+	// guards are the guards to check, the default guard when empty
+	guards []string
+}
+
+// NewRedirectIfAuthenticatedMiddleware creates a new guest middleware.
+//
+// Parameters:
+//   - manager: Auth manager resolving the guards
+//   - redirectTo: Location authenticated users are redirected to
+//   - guards: Guards to check, the default guard when omitted
+func NewRedirectIfAuthenticatedMiddleware(manager *auth.AuthManager, redirectTo string, guards ...string) *RedirectIfAuthenticatedMiddleware {
+	if redirectTo == "" {
+		redirectTo = "/"
+	}
+	if len(guards) == 0 {
+		guards = []string{""}
+	}
+
+	return &RedirectIfAuthenticatedMiddleware{
+		manager:    manager,
+		redirectTo: redirectTo,
+		guards:     guards,
+	}
+}
+
+// Handle redirects authenticated users and lets guests through.
+func (m *RedirectIfAuthenticatedMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	for _, name := range m.guards {
+		guard, err := m.manager.Guard(name)
+		if err == nil && guard.Check(req) {
+			return withQueuedCookies(req, webserver.NewResponse().Redirect(m.redirectTo, http.StatusFound))
+		}
+	}
+	return withQueuedCookies(req, next.Handle(req))
+}
+
+// Priority returns the middleware priority; it must run after StartSession.
+func (m *RedirectIfAuthenticatedMiddleware) Priority() int {
+	return 15
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*RedirectIfAuthenticatedMiddleware)(nil)
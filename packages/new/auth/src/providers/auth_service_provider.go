@@ -0,0 +1,197 @@
+// Package providers contains service provider implementations for the auth package.
+// Service providers are responsible for registering auth services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	auth "govel/new/auth"
+	"govel/new/auth/access"
+	"govel/new/auth/jwt"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	authInterfaces "govel/types/interfaces/auth"
+	configInterfaces "govel/types/interfaces/config"
+	databaseInterfaces "govel/types/interfaces/database"
+	hashingInterfaces "govel/types/interfaces/hashing"
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// databaseResolver is implemented by the database manager
+type databaseResolver interface {
+	Database(name ...string) (databaseInterfaces.DatabaseInterface, error)
+}
+
+// AuthServiceProvider implements a Laravel-compatible service provider
+// for the auth package.
+//
+// Services registered:
+//   - AUTH_TOKEN / AUTH_INTERFACE_TOKEN / AUTH_MANAGER_TOKEN / AUTH_FACTORY_TOKEN:
+//     Singleton AuthManager reading auth.* from "config", checking passwords
+//     with HASHING_TOKEN and resolving database user providers through
+//     DATABASE_MANAGER_TOKEN when bound. Guards with the "jwt" driver use a
+//     token manager configured by jwt.*, revoking tokens in memory, Redis
+//     (REDIS_TOKEN) or the database as set by jwt.storage.driver
+//   - GATE_TOKEN: Singleton guest Gate; call ForUser to check a user's abilities
+type AuthServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewAuthServiceProvider creates a new AuthServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewAuthServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register auth services:", err)
+//	}
+func NewAuthServiceProvider() *AuthServiceProvider {
+	return &AuthServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all auth services in the dependency injection container.
+func (p *AuthServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager      *auth.AuthManager
+		managerMutex sync.Mutex
+	)
+	managerFactory := func() (interface{}, error) {
+		managerMutex.Lock()
+		defer managerMutex.Unlock()
+
+		if manager != nil {
+			return manager, nil
+		}
+
+		config, err := application.Make("config")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config from container: %w", err)
+		}
+		configInterface, ok := config.(configInterfaces.ConfigInterface)
+		if !ok {
+			return nil, fmt.Errorf("config service does not implement ConfigInterface, got %T", config)
+		}
+
+		var hasher hashingInterfaces.HasherInterface
+		if application.IsBound(hashingInterfaces.HASHING_TOKEN) {
+			if resolved, err := application.Make(hashingInterfaces.HASHING_TOKEN); err == nil {
+				hasher, _ = resolved.(hashingInterfaces.HasherInterface)
+			}
+		}
+		manager = auth.NewAuthManager(configInterface, hasher)
+
+		resolver := func(name string) (databaseInterfaces.DatabaseInterface, error) {
+			resolved, err := application.Make(databaseInterfaces.DATABASE_MANAGER_TOKEN)
+			if err != nil {
+				return nil, err
+			}
+			databaseManager, ok := resolved.(databaseResolver)
+			if !ok {
+				return nil, fmt.Errorf("auth: database manager %T cannot resolve connections", resolved)
+			}
+			return databaseManager.Database(name)
+		}
+		if application.IsBound(databaseInterfaces.DATABASE_MANAGER_TOKEN) {
+			manager.SetConnectionResolver(resolver)
+		}
+		manager.Extend("jwt", jwtGuardCreator(application, configInterface, resolver))
+		return manager, nil
+	}
+
+	for _, token := range []interface{}{
+		authInterfaces.AUTH_TOKEN,
+		authInterfaces.AUTH_INTERFACE_TOKEN,
+		authInterfaces.AUTH_MANAGER_TOKEN,
+		authInterfaces.AUTH_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, managerFactory); err != nil {
+			return fmt.Errorf("failed to bind auth manager: %w", err)
+		}
+	}
+
+	gate := access.NewGate()
+	if err := application.Singleton(authInterfaces.GATE_TOKEN, func() interface{} {
+		return gate
+	}); err != nil {
+		return fmt.Errorf("failed to bind gate: %w", err)
+	}
+
+	return nil
+}
+
+// jwtGuardCreator creates JWT guards sharing one token manager, created on first use
+func jwtGuardCreator(application applicationInterfaces.ApplicationInterface, config configInterfaces.ConfigInterface, resolver auth.ConnectionResolver) auth.GuardCreator {
+	var once sync.Once
+	var tokens *jwt.Manager
+	var tokensErr error
+
+	return func(manager *auth.AuthManager, name string, options map[string]interface{}) (authInterfaces.GuardInterface, error) {
+		once.Do(func() {
+			var store jwt.Store
+			switch driver := config.GetString("jwt.storage.driver", "memory"); driver {
+			case "memory":
+				store = jwt.NewMemoryStore()
+			case "redis":
+				resolved, err := application.Make(redisInterfaces.REDIS_TOKEN)
+				if err != nil {
+					tokensErr = fmt.Errorf("auth: jwt redis storage needs REDIS_TOKEN: %w", err)
+					return
+				}
+				redis, ok := resolved.(redisInterfaces.RedisInterface)
+				if !ok {
+					tokensErr = fmt.Errorf("auth: redis service %T does not implement RedisInterface", resolved)
+					return
+				}
+				store = jwt.NewRedisStore(redis, config.GetString("jwt.storage.prefix"))
+			case "database":
+				connection, err := resolver(config.GetString("jwt.storage.connection"))
+				if err != nil {
+					tokensErr = err
+					return
+				}
+				store = jwt.NewDatabaseStore(connection, config.GetString("jwt.storage.table"))
+			default:
+				tokensErr = fmt.Errorf("auth: jwt storage driver [%s] is not supported", driver)
+				return
+			}
+			tokens, tokensErr = jwt.NewManagerFromConfig(config, store)
+		})
+		if tokensErr != nil {
+			return nil, tokensErr
+		}
+
+		providerName, _ := options["provider"].(string)
+		if providerName == "" {
+			providerName = "users"
+		}
+		provider, err := manager.CreateUserProvider(providerName)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwt.NewGuard(name, provider, tokens).
+			SetInputKey(config.GetString("jwt.request.input_key", "token")).
+			SetCookieName(config.GetString("jwt.request.cookie_name", "jwt_token")), nil
+	}
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *AuthServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		authInterfaces.AUTH_TOKEN,
+		authInterfaces.AUTH_INTERFACE_TOKEN,
+		authInterfaces.AUTH_MANAGER_TOKEN,
+		authInterfaces.AUTH_FACTORY_TOKEN,
+		authInterfaces.GATE_TOKEN,
+	}
+}
@@ -0,0 +1,354 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	session "govel/new/session"
+	sessionAuthInterfaces "govel/new/session/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	authInterfaces "govel/types/interfaces/auth"
+	sessionInterfaces "govel/types/interfaces/session"
+)
+
+// DefaultRememberDuration is how long "remember me" cookies last
+const DefaultRememberDuration = 5 * 365 * 24 * time.Hour
+
+// SessionGuard keeps the authenticated user in the session, like Laravel's
+// SessionGuard. Users logged in with "remember me" also receive a
+// long-lived cookie that logs them back in once their session expires.
+//
+// The guard itself is shared; the user of each request is kept in the
+// request context, so the StartSession middleware must run first.
+type SessionGuard struct {
+	// name is the guard name
+	name string
+
+	// provider retrieves users
+	provider authInterfaces.UserProviderInterface
+
+	// rememberDuration is the lifetime of "remember me" cookies
+	rememberDuration time.Duration
+
+	// secure marks "remember me" cookies as HTTPS only
+	secure bool
+}
+
+// NewSessionGuard creates a session guard
+//
+// Example:
+//
+//	guard := auth.NewSessionGuard("web", users.NewMemoryUserProvider(hasher))
+//	ok, err := guard.Attempt(req, map[string]interface{}{
+//		"email":    req.Input("email"),
+//		"password": req.Input("password"),
+//	}, req.Input("remember") == "on")
+func NewSessionGuard(name string, provider authInterfaces.UserProviderInterface) *SessionGuard {
+	return &SessionGuard{
+		name:             name,
+		provider:         provider,
+		rememberDuration: DefaultRememberDuration,
+	}
+}
+
+// SetRememberDuration sets the lifetime of "remember me" cookies
+func (g *SessionGuard) SetRememberDuration(duration time.Duration) *SessionGuard {
+	g.rememberDuration = duration
+	return g
+}
+
+// SetSecure marks "remember me" cookies as HTTPS only
+func (g *SessionGuard) SetSecure(secure bool) *SessionGuard {
+	g.secure = secure
+	return g
+}
+
+// GetName implements GuardInterface
+func (g *SessionGuard) GetName() string {
+	return g.name
+}
+
+// GetProvider returns the user provider of the guard
+func (g *SessionGuard) GetProvider() authInterfaces.UserProviderInterface {
+	return g.provider
+}
+
+// Check implements GuardInterface
+func (g *SessionGuard) Check(req authInterfaces.RequestInterface) bool {
+	return g.User(req) != nil
+}
+
+// Guest implements GuardInterface
+func (g *SessionGuard) Guest(req authInterfaces.RequestInterface) bool {
+	return !g.Check(req)
+}
+
+// User implements GuardInterface. The user is looked up once per request,
+// from the session first and then from the "remember me" cookie.
+func (g *SessionGuard) User(req authInterfaces.RequestInterface) authInterfaces.AuthenticatableInterface {
+	state := stateOf(req, g.name)
+	if state.loggedOut || state.resolved {
+		return state.user
+	}
+	state.resolved = true
+
+	ctx := context.Background()
+	store := sessionOf(req)
+	if store != nil {
+		if id := store.Get(g.SessionKey()); id != nil {
+			if user, err := g.provider.RetrieveByID(ctx, id); err == nil && user != nil {
+				state.user = user
+				return user
+			}
+		}
+	}
+
+	if user := g.userFromRecaller(ctx, req); user != nil {
+		// Like Login, regenerate the session ID to prevent session fixation;
+		// the cookie is ignored when the session cannot be migrated
+		if store != nil {
+			store.Put(g.SessionKey(), user.GetAuthIdentifier())
+			if err := store.Migrate(true); err != nil {
+				store.Remove(g.SessionKey())
+				return nil
+			}
+		}
+		state.user = user
+		state.viaRemember = true
+	}
+	return state.user
+}
+
+// ID implements GuardInterface
+func (g *SessionGuard) ID(req authInterfaces.RequestInterface) interface{} {
+	if user := g.User(req); user != nil {
+		return user.GetAuthIdentifier()
+	}
+	return nil
+}
+
+// Validate implements GuardInterface
+func (g *SessionGuard) Validate(req authInterfaces.RequestInterface, credentials map[string]interface{}) bool {
+	user, err := g.provider.RetrieveByCredentials(context.Background(), credentials)
+	return err == nil && user != nil && g.provider.ValidateCredentials(user, credentials)
+}
+
+// SetUser implements GuardInterface
+func (g *SessionGuard) SetUser(req authInterfaces.RequestInterface, user authInterfaces.AuthenticatableInterface) {
+	state := stateOf(req, g.name)
+	state.user = user
+	state.resolved = true
+	state.loggedOut = false
+}
+
+// Attempt implements StatefulGuardInterface
+func (g *SessionGuard) Attempt(req authInterfaces.RequestInterface, credentials map[string]interface{}, remember bool) (bool, error) {
+	user, err := g.provider.RetrieveByCredentials(context.Background(), credentials)
+	if err != nil {
+		return false, err
+	}
+	if user == nil || !g.provider.ValidateCredentials(user, credentials) {
+		return false, nil
+	}
+	return true, g.Login(req, user, remember)
+}
+
+// Once implements StatefulGuardInterface
+func (g *SessionGuard) Once(req authInterfaces.RequestInterface, credentials map[string]interface{}) bool {
+	user, err := g.provider.RetrieveByCredentials(context.Background(), credentials)
+	if err != nil || user == nil || !g.provider.ValidateCredentials(user, credentials) {
+		return false
+	}
+	g.SetUser(req, user)
+	return true
+}
+
+// Login implements StatefulGuardInterface. The session ID is regenerated to
+// prevent session fixation.
+func (g *SessionGuard) Login(req authInterfaces.RequestInterface, user authInterfaces.AuthenticatableInterface, remember bool) error {
+	store := sessionOf(req)
+	if store == nil {
+		return ErrSessionNotStarted
+	}
+
+	store.Put(g.SessionKey(), user.GetAuthIdentifier())
+	if err := store.Migrate(true); err != nil {
+		return err
+	}
+
+	if remember {
+		if user.GetRememberToken() == "" {
+			if err := g.cycleRememberToken(user); err != nil {
+				return err
+			}
+		}
+		QueueCookie(req, g.rememberCookie(user))
+	}
+
+	g.SetUser(req, user)
+	return nil
+}
+
+// LoginUsingID implements StatefulGuardInterface. It returns a nil user when
+// no user has the identifier.
+func (g *SessionGuard) LoginUsingID(req authInterfaces.RequestInterface, id interface{}, remember bool) (authInterfaces.AuthenticatableInterface, error) {
+	user, err := g.provider.RetrieveByID(context.Background(), id)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return user, g.Login(req, user, remember)
+}
+
+// ViaRemember implements StatefulGuardInterface
+func (g *SessionGuard) ViaRemember(req authInterfaces.RequestInterface) bool {
+	g.User(req)
+	return stateOf(req, g.name).viaRemember
+}
+
+// Logout implements StatefulGuardInterface. The remember token is cycled so
+// "remember me" cookies stolen before logging out stop working.
+func (g *SessionGuard) Logout(req authInterfaces.RequestInterface) error {
+	user := g.User(req)
+	g.clearUserData(req)
+
+	if user != nil && user.GetRememberToken() != "" {
+		if err := g.cycleRememberToken(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogoutCurrentDevice logs the user out of the current session only,
+// leaving the remember token of other devices valid
+func (g *SessionGuard) LogoutCurrentDevice(req authInterfaces.RequestInterface) error {
+	g.clearUserData(req)
+	return nil
+}
+
+// SessionKey returns the session key holding the user identifier
+func (g *SessionGuard) SessionKey() string {
+	return "login_" + g.name
+}
+
+// RememberCookieName returns the name of the "remember me" cookie
+func (g *SessionGuard) RememberCookieName() string {
+	return "remember_" + g.name
+}
+
+// SessionAuthenticator adapts the guard to the AuthenticateSession
+// middleware of the session package
+func (g *SessionGuard) SessionAuthenticator() sessionAuthInterfaces.AuthenticatesSessionsInterface {
+	return sessionAuthenticator{guard: g}
+}
+
+// clearUserData forgets the user in the session, cookie and request
+func (g *SessionGuard) clearUserData(req authInterfaces.RequestInterface) {
+	if store := sessionOf(req); store != nil {
+		store.Remove(g.SessionKey())
+	}
+	if req.Cookie(g.RememberCookieName()) != "" {
+		QueueCookie(req, &http.Cookie{
+			Name:     g.RememberCookieName(),
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   g.secure,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+
+	state := stateOf(req, g.name)
+	state.user = nil
+	state.resolved = true
+	state.loggedOut = true
+	state.viaRemember = false
+}
+
+// cycleRememberToken stores a new remember token for the user
+func (g *SessionGuard) cycleRememberToken(user authInterfaces.AuthenticatableInterface) error {
+	return g.provider.UpdateRememberToken(context.Background(), user, randomToken(60))
+}
+
+// rememberCookie returns the "remember me" cookie of the user, holding
+// "id|token|digest of the password hash" so password changes invalidate it
+func (g *SessionGuard) rememberCookie(user authInterfaces.AuthenticatableInterface) *http.Cookie {
+	value := strings.Join([]string{
+		toString(user.GetAuthIdentifier()),
+		user.GetRememberToken(),
+		passwordDigest(user.GetAuthPassword()),
+	}, "|")
+
+	return &http.Cookie{
+		Name:     g.RememberCookieName(),
+		Value:    value,
+		Path:     "/",
+		Expires:  time.Now().Add(g.rememberDuration),
+		MaxAge:   int(g.rememberDuration.Seconds()),
+		HttpOnly: true,
+		Secure:   g.secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// userFromRecaller returns the user of a valid "remember me" cookie
+func (g *SessionGuard) userFromRecaller(ctx context.Context, req authInterfaces.RequestInterface) authInterfaces.AuthenticatableInterface {
+	parts := strings.Split(req.Cookie(g.RememberCookieName()), "|")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+
+	user, err := g.provider.RetrieveByToken(ctx, parts[0], parts[1])
+	if err != nil || user == nil {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[2]), []byte(passwordDigest(user.GetAuthPassword()))) != 1 {
+		return nil
+	}
+	return user
+}
+
+// sessionOf returns the session started for the request, or nil
+func sessionOf(req authInterfaces.RequestInterface) sessionInterfaces.SessionInterface {
+	store, _ := req.GetContext(session.RequestContextKey).(sessionInterfaces.SessionInterface)
+	return store
+}
+
+// passwordDigest returns the hex SHA-256 digest of a password hash
+func passwordDigest(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:])
+}
+
+// sessionAuthenticator implements AuthenticatesSessionsInterface for a
+// session guard
+type sessionAuthenticator struct {
+	guard *SessionGuard
+}
+
+// GetName implements AuthenticatesSessionsInterface
+func (a sessionAuthenticator) GetName() string {
+	return a.guard.GetName()
+}
+
+// User implements AuthenticatesSessionsInterface
+func (a sessionAuthenticator) User(req webserverInterfaces.RequestInterface) sessionAuthInterfaces.AuthenticatableInterface {
+	if user := a.guard.User(req); user != nil {
+		return user
+	}
+	return nil
+}
+
+// LogoutCurrentDevice implements AuthenticatesSessionsInterface
+func (a sessionAuthenticator) LogoutCurrentDevice(req webserverInterfaces.RequestInterface) error {
+	return a.guard.LogoutCurrentDevice(req)
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.StatefulGuardInterface = (*SessionGuard)(nil)
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// TokenGuard authenticates stateless API requests by a token column, like
+// Laravel's TokenGuard. The token is read from the "api_token" input or
+// the bearer token of the Authorization header.
+type TokenGuard struct {
+	// name is the guard name
+	name string
+
+	// provider retrieves users
+	provider authInterfaces.UserProviderInterface
+
+	// inputKey is the request input holding the token
+	inputKey string
+
+	// storageKey is the user column holding the token
+	storageKey string
+
+	// hash compares the SHA-256 digest of the token with the stored value
+	hash bool
+}
+
+// NewTokenGuard creates a token guard using the "api_token" input and column
+func NewTokenGuard(name string, provider authInterfaces.UserProviderInterface) *TokenGuard {
+	return &TokenGuard{
+		name:       name,
+		provider:   provider,
+		inputKey:   "api_token",
+		storageKey: "api_token",
+	}
+}
+
+// SetInputKey sets the request input holding the token
+func (g *TokenGuard) SetInputKey(key string) *TokenGuard {
+	g.inputKey = key
+	return g
+}
+
+// SetStorageKey sets the user column holding the token
+func (g *TokenGuard) SetStorageKey(key string) *TokenGuard {
+	g.storageKey = key
+	return g
+}
+
+// SetHash stores tokens as SHA-256 digests
+func (g *TokenGuard) SetHash(hash bool) *TokenGuard {
+	g.hash = hash
+	return g
+}
+
+// GetName implements GuardInterface
+func (g *TokenGuard) GetName() string {
+	return g.name
+}
+
+// GetProvider returns the user provider of the guard
+func (g *TokenGuard) GetProvider() authInterfaces.UserProviderInterface {
+	return g.provider
+}
+
+// Check implements GuardInterface
+func (g *TokenGuard) Check(req authInterfaces.RequestInterface) bool {
+	return g.User(req) != nil
+}
+
+// Guest implements GuardInterface
+func (g *TokenGuard) Guest(req authInterfaces.RequestInterface) bool {
+	return !g.Check(req)
+}
+
+// User implements GuardInterface
+func (g *TokenGuard) User(req authInterfaces.RequestInterface) authInterfaces.AuthenticatableInterface {
+	state := stateOf(req, g.name)
+	if state.resolved {
+		return state.user
+	}
+	state.resolved = true
+	state.user = g.retrieve(g.TokenForRequest(req))
+	return state.user
+}
+
+// ID implements GuardInterface
+func (g *TokenGuard) ID(req authInterfaces.RequestInterface) interface{} {
+	if user := g.User(req); user != nil {
+		return user.GetAuthIdentifier()
+	}
+	return nil
+}
+
+// Validate implements GuardInterface; the credentials hold the token under
+// the input key
+func (g *TokenGuard) Validate(req authInterfaces.RequestInterface, credentials map[string]interface{}) bool {
+	token, _ := credentials[g.inputKey].(string)
+	return g.retrieve(token) != nil
+}
+
+// SetUser implements GuardInterface
+func (g *TokenGuard) SetUser(req authInterfaces.RequestInterface, user authInterfaces.AuthenticatableInterface) {
+	state := stateOf(req, g.name)
+	state.user = user
+	state.resolved = true
+}
+
+// TokenForRequest returns the token of the request, "" when missing
+func (g *TokenGuard) TokenForRequest(req authInterfaces.RequestInterface) string {
+	if token := req.Input(g.inputKey); token != "" {
+		return token
+	}
+	return req.Bearer()
+}
+
+// retrieve returns the user owning the token
+func (g *TokenGuard) retrieve(token string) authInterfaces.AuthenticatableInterface {
+	if token == "" {
+		return nil
+	}
+	if g.hash {
+		sum := sha256.Sum256([]byte(token))
+		token = hex.EncodeToString(sum[:])
+	}
+
+	user, err := g.provider.RetrieveByCredentials(context.Background(), map[string]interface{}{g.storageKey: token})
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.GuardInterface = (*TokenGuard)(nil)
@@ -0,0 +1,147 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	authInterfaces "govel/types/interfaces/auth"
+	databaseInterfaces "govel/types/interfaces/database"
+	hashingInterfaces "govel/types/interfaces/hashing"
+)
+
+// DatabaseUserProvider retrieves GenericUser rows from a table
+type DatabaseUserProvider struct {
+	// connection queries the table
+	connection databaseInterfaces.DatabaseInterface
+
+	// table holds the users
+	table string
+
+	// hasher checks passwords
+	hasher hashingInterfaces.HasherInterface
+}
+
+// NewDatabaseUserProvider creates a provider over the users table
+func NewDatabaseUserProvider(connection databaseInterfaces.DatabaseInterface, table string, hasher hashingInterfaces.HasherInterface) *DatabaseUserProvider {
+	return &DatabaseUserProvider{connection: connection, table: table, hasher: hasher}
+}
+
+// RetrieveByID implements UserProviderInterface
+func (p *DatabaseUserProvider) RetrieveByID(ctx context.Context, identifier interface{}) (authInterfaces.AuthenticatableInterface, error) {
+	return p.first(ctx, p.connection.Table(p.table).Where("id", "=", identifier))
+}
+
+// RetrieveByToken implements UserProviderInterface
+func (p *DatabaseUserProvider) RetrieveByToken(ctx context.Context, identifier interface{}, token string) (authInterfaces.AuthenticatableInterface, error) {
+	user, err := p.RetrieveByID(ctx, identifier)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if remember := user.GetRememberToken(); remember == "" || !hashEquals(remember, token) {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// UpdateRememberToken implements UserProviderInterface
+func (p *DatabaseUserProvider) UpdateRememberToken(ctx context.Context, user authInterfaces.AuthenticatableInterface, token string) error {
+	_, err := p.connection.Table(p.table).
+		Where(user.GetAuthIdentifierName(), "=", user.GetAuthIdentifier()).
+		UpdateContext(ctx, map[string]interface{}{user.GetRememberTokenName(): token})
+	if err != nil {
+		return err
+	}
+	user.SetRememberToken(token)
+	return nil
+}
+
+// RetrieveByCredentials implements UserProviderInterface. Every credential
+// except the password becomes a condition; slices match any of their values.
+func (p *DatabaseUserProvider) RetrieveByCredentials(ctx context.Context, credentials map[string]interface{}) (authInterfaces.AuthenticatableInterface, error) {
+	query := p.connection.Table(p.table)
+	conditions := 0
+	for column, value := range credentials {
+		if strings.Contains(column, "password") {
+			continue
+		}
+		conditions++
+		if values, ok := sliceOf(value); ok {
+			query = query.WhereIn(column, values)
+		} else {
+			query = query.Where(column, "=", value)
+		}
+	}
+	if conditions == 0 {
+		return nil, nil
+	}
+	return p.first(ctx, query)
+}
+
+// ValidateCredentials implements UserProviderInterface
+func (p *DatabaseUserProvider) ValidateCredentials(user authInterfaces.AuthenticatableInterface, credentials map[string]interface{}) bool {
+	return checkPassword(p.hasher, user, credentials)
+}
+
+// first returns the first row of the query as a GenericUser
+func (p *DatabaseUserProvider) first(ctx context.Context, query databaseInterfaces.QueryBuilderInterface) (authInterfaces.AuthenticatableInterface, error) {
+	rows, err := query.Limit(1).GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	user, err := scanUser(rows)
+	if err != nil {
+		return nil, err
+	}
+	return user, rows.Err()
+}
+
+// scanUser scans the current row into a GenericUser
+func scanUser(rows *sql.Rows) (GenericUser, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for idx := range values {
+		pointers[idx] = &values[idx]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, fmt.Errorf("auth: failed to scan user: %w", err)
+	}
+
+	user := make(GenericUser, len(columns))
+	for idx, column := range columns {
+		if bytes, ok := values[idx].([]byte); ok {
+			user[column] = string(bytes)
+		} else {
+			user[column] = values[idx]
+		}
+	}
+	return user, nil
+}
+
+// sliceOf converts slice credentials to []interface{}
+func sliceOf(value interface{}) ([]interface{}, bool) {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice || reflected.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	values := make([]interface{}, reflected.Len())
+	for idx := range values {
+		values[idx] = reflected.Index(idx).Interface()
+	}
+	return values, true
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.UserProviderInterface = (*DatabaseUserProvider)(nil)
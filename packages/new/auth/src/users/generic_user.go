@@ -0,0 +1,60 @@
+// Package users provides the user providers of the auth guards: a
+// database provider over the query builder and an in-memory provider.
+package users
+
+import (
+	"fmt"
+
+	authInterfaces "govel/types/interfaces/auth"
+)
+
+// GenericUser is a user backed by its column values, as returned by the
+// database and memory providers
+type GenericUser map[string]interface{}
+
+// GetAuthIdentifierName implements AuthenticatableInterface
+func (u GenericUser) GetAuthIdentifierName() string {
+	return "id"
+}
+
+// GetAuthIdentifier implements AuthenticatableInterface
+func (u GenericUser) GetAuthIdentifier() interface{} {
+	return u[u.GetAuthIdentifierName()]
+}
+
+// GetAuthPassword implements AuthenticatableInterface
+func (u GenericUser) GetAuthPassword() string {
+	return u.String("password")
+}
+
+// GetRememberToken implements AuthenticatableInterface
+func (u GenericUser) GetRememberToken() string {
+	return u.String(u.GetRememberTokenName())
+}
+
+// SetRememberToken implements AuthenticatableInterface
+func (u GenericUser) SetRememberToken(token string) {
+	u[u.GetRememberTokenName()] = token
+}
+
+// GetRememberTokenName implements AuthenticatableInterface
+func (u GenericUser) GetRememberTokenName() string {
+	return "remember_token"
+}
+
+// String returns a column value as a string, "" when missing or nil
+func (u GenericUser) String(column string) string {
+	switch value := u[column].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.AuthenticatableInterface = GenericUser(nil)
@@ -0,0 +1,146 @@
+package users
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"sync"
+
+	authInterfaces "govel/types/interfaces/auth"
+	hashingInterfaces "govel/types/interfaces/hashing"
+)
+
+// MemoryUserProvider keeps users in memory, for tests and small tools.
+// Passwords are stored hashed with the provider's hasher.
+type MemoryUserProvider struct {
+	// mu guards users
+	mu sync.RWMutex
+
+	// users are the known users in insertion order
+	users []GenericUser
+
+	// hasher checks passwords
+	hasher hashingInterfaces.HasherInterface
+}
+
+// NewMemoryUserProvider creates a provider holding the users
+func NewMemoryUserProvider(hasher hashingInterfaces.HasherInterface, users ...GenericUser) *MemoryUserProvider {
+	return &MemoryUserProvider{hasher: hasher, users: users}
+}
+
+// Add adds a user
+func (p *MemoryUserProvider) Add(user GenericUser) *MemoryUserProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users = append(p.users, user)
+	return p
+}
+
+// RetrieveByID implements UserProviderInterface
+func (p *MemoryUserProvider) RetrieveByID(ctx context.Context, identifier interface{}) (authInterfaces.AuthenticatableInterface, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, user := range p.users {
+		if sameValue(user.GetAuthIdentifier(), identifier) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// RetrieveByToken implements UserProviderInterface
+func (p *MemoryUserProvider) RetrieveByToken(ctx context.Context, identifier interface{}, token string) (authInterfaces.AuthenticatableInterface, error) {
+	user, _ := p.RetrieveByID(ctx, identifier)
+	if user == nil {
+		return nil, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if remember := user.GetRememberToken(); remember == "" || !hashEquals(remember, token) {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// UpdateRememberToken implements UserProviderInterface
+func (p *MemoryUserProvider) UpdateRememberToken(ctx context.Context, user authInterfaces.AuthenticatableInterface, token string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, stored := range p.users {
+		if sameValue(stored.GetAuthIdentifier(), user.GetAuthIdentifier()) {
+			stored.SetRememberToken(token)
+		}
+	}
+	user.SetRememberToken(token)
+	return nil
+}
+
+// RetrieveByCredentials implements UserProviderInterface
+func (p *MemoryUserProvider) RetrieveByCredentials(ctx context.Context, credentials map[string]interface{}) (authInterfaces.AuthenticatableInterface, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, user := range p.users {
+		matches, conditions := true, 0
+		for column, value := range credentials {
+			if strings.Contains(column, "password") {
+				continue
+			}
+			conditions++
+			if values, ok := sliceOf(value); ok {
+				matches = matches && containsValue(values, user[column])
+			} else {
+				matches = matches && sameValue(user[column], value)
+			}
+		}
+		if matches && conditions > 0 {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// ValidateCredentials implements UserProviderInterface
+func (p *MemoryUserProvider) ValidateCredentials(user authInterfaces.AuthenticatableInterface, credentials map[string]interface{}) bool {
+	return checkPassword(p.hasher, user, credentials)
+}
+
+// checkPassword checks the "password" credential against the user's hash
+func checkPassword(hasher hashingInterfaces.HasherInterface, user authInterfaces.AuthenticatableInterface, credentials map[string]interface{}) bool {
+	password, ok := credentials["password"].(string)
+	if !ok || hasher == nil || user.GetAuthPassword() == "" {
+		return false
+	}
+	return hasher.Check(password, user.GetAuthPassword(), nil)
+}
+
+// hashEquals compares secrets in constant time
+func hashEquals(known string, given string) bool {
+	return subtle.ConstantTimeCompare([]byte(known), []byte(given)) == 1
+}
+
+// sameValue compares values by their string form, so ids read from
+// sessions and cookies match numeric columns
+func sameValue(left interface{}, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+	return fmt.Sprint(left) == fmt.Sprint(right)
+}
+
+// containsValue reports whether one of the values equals the value
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if sameValue(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile-time interface compliance check
+var _ authInterfaces.UserProviderInterface = (*MemoryUserProvider)(nil)
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"govel/new/bus/middleware"
+	"govel/new/redis/connections"
+)
+
+type sendReport struct{ team string }
+
+type syncInvoices struct{ account string }
+
+func (c syncInvoices) ThrottleKey() string { return "invoices:" + c.account }
+
+// run handles the command and counts the executions of next
+func run(m *middleware.ThrottleMiddleware, command interface{}, executions *int) (interface{}, error) {
+	return m.Handle(context.Background(), command, func(ctx context.Context, command interface{}) (interface{}, error) {
+		*executions++
+		return "done", nil
+	})
+}
+
+func TestThrottleMiddleware_ReleasesCommandsOverTheLimit(t *testing.T) {
+	throttle := middleware.NewThrottleMiddleware(connections.NewFakeConnection("default"), 2, time.Minute)
+	executions := 0
+
+	for i := 0; i < 2; i++ {
+		result, err := run(throttle, sendReport{team: "a"}, &executions)
+		if err != nil || result != "done" {
+			t.Fatalf("Expected execution %d to run, got %v (%v)", i+1, result, err)
+		}
+	}
+
+	result, err := run(throttle, sendReport{team: "a"}, &executions)
+	var throttled *middleware.ThrottledError
+	if !errors.As(err, &throttled) {
+		t.Fatalf("Expected a ThrottledError, got %v (%v)", result, err)
+	}
+	if executions != 2 {
+		t.Errorf("Expected the throttled command not to run, got %d executions", executions)
+	}
+	if throttled.RetryAfter <= 0 || throttled.RetryAfter > time.Minute {
+		t.Errorf("Expected a retry within the window, got %v", throttled.RetryAfter)
+	}
+	if throttled.Key != "throttle:tests.sendReport" {
+		t.Errorf("Unexpected key %q", throttled.Key)
+	}
+}
+
+func TestThrottleMiddleware_PointerAndValueCommandsShareAKey(t *testing.T) {
+	throttle := middleware.NewThrottleMiddleware(connections.NewFakeConnection("default"), 1, time.Minute)
+	executions := 0
+
+	if _, err := run(throttle, sendReport{}, &executions); err != nil {
+		t.Fatalf("Expected the first command to run: %v", err)
+	}
+
+	var throttled *middleware.ThrottledError
+	if _, err := run(throttle, &sendReport{}, &executions); !errors.As(err, &throttled) {
+		t.Fatalf("Expected *sendReport to share the sendReport limit, got %v", err)
+	}
+	if throttled.Key != "throttle:tests.sendReport" {
+		t.Errorf("Unexpected key %q", throttled.Key)
+	}
+}
+
+func TestThrottleMiddleware_Keys(t *testing.T) {
+	connection := connections.NewFakeConnection("default")
+	executions := 0
+
+	keyed := middleware.NewThrottleMiddleware(connection, 1, time.Minute)
+	for _, account := range []string{"acme", "globex"} {
+		if _, err := run(keyed, syncInvoices{account: account}, &executions); err != nil {
+			t.Fatalf("Expected each ThrottleKey to have its own limit: %v", err)
+		}
+	}
+	var throttled *middleware.ThrottledError
+	if _, err := run(keyed, syncInvoices{account: "acme"}, &executions); !errors.As(err, &throttled) || throttled.Key != "throttle:invoices:acme" {
+		t.Fatalf("Expected the acme key to be throttled, got %v", err)
+	}
+
+	resolved := middleware.NewThrottleMiddleware(connection, 1, time.Minute).
+		WithPrefix("jobs:").
+		By(func(command interface{}) string { return "reports" })
+	if _, err := run(resolved, sendReport{team: "a"}, &executions); err != nil {
+		t.Fatalf("Expected the first report to run: %v", err)
+	}
+	if _, err := run(resolved, sendReport{team: "b"}, &executions); !errors.As(err, &throttled) || throttled.Key != "jobs:reports" {
+		t.Fatalf("Expected the resolved key to be throttled, got %v", err)
+	}
+}
+
+func TestThrottleMiddleware_BlockWaitsForTheNextWindow(t *testing.T) {
+	throttle := middleware.NewThrottleMiddleware(connections.NewFakeConnection("default"), 1, 50*time.Millisecond).
+		Block(time.Second)
+	executions := 0
+
+	started := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := run(throttle, sendReport{}, &executions); err != nil {
+			t.Fatalf("Expected blocking to wait for a slot: %v", err)
+		}
+	}
+	if executions != 2 {
+		t.Errorf("Expected 2 executions, got %d", executions)
+	}
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the second command to wait for the next window, took %v", elapsed)
+	}
+}
+
+func TestThrottleMiddleware_PassesCommandErrorsThrough(t *testing.T) {
+	throttle := middleware.NewThrottleMiddleware(connections.NewFakeConnection("default"), 1, time.Minute)
+	failure := errors.New("smtp down")
+
+	_, err := throttle.Handle(context.Background(), sendReport{}, func(ctx context.Context, command interface{}) (interface{}, error) {
+		return nil, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the command error, got %v", err)
+	}
+}
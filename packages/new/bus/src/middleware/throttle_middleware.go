@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"govel/new/bus/interfaces"
+	redisExceptions "govel/new/redis/exceptions"
+	redisInterfaces "govel/new/redis/interfaces"
+	"govel/new/redis/limiters"
+)
+
+// ThrottleKeyed is implemented by commands that choose their own throttle key.
+// Commands sharing a key share the same rate limit.
+type ThrottleKeyed interface {
+	ThrottleKey() string
+}
+
+// ThrottledError is returned when a command exceeded its rate limit.
+// Queue workers should release the job and retry it after RetryAfter.
+type ThrottledError struct {
+	// Key is the throttle key that was exhausted
+	Key string
+
+	// RetryAfter is the time until the current window ends
+	RetryAfter time.Duration
+}
+
+// Error returns the error message.
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("command throttled [%s], retry after %v", e.Key, e.RetryAfter)
+}
+
+// ThrottleMiddleware rate limits command and job execution across workers
+// using a Redis duration limiter (see redis/limiters).
+//
+// Commands are keyed by ThrottleKey() when implemented, otherwise by their
+// type with pointers dereferenced, so every worker sharing the Redis
+// connection shares the limit whether it dispatches Cmd or *Cmd.
+//
+// Example:
+//
+//	dispatcher.PipeThrough([]interfaces.MiddlewarePipe{
+//		middleware.NewThrottleMiddleware(connection, 10, time.Minute),
+//	})
+type ThrottleMiddleware struct {
+	// connection is the Redis connection holding the limiter state
+	connection redisInterfaces.ConnectionInterface
+
+	// maxAttempts is the number of executions allowed per window
+	maxAttempts int
+
+	// decay is the window length
+	decay time.Duration
+
+	// block is how long to wait for a slot before giving up; zero fails fast
+	block time.Duration
+
+	// prefix is prepended to every throttle key
+	prefix string
+
+	// keyResolver overrides the key derived from the command
+	keyResolver func(command interface{}) string
+}
+
+// NewThrottleMiddleware creates a new throttle middleware.
+//
+// Parameters:
+//   - connection: Redis connection shared by all workers
+//   - maxAttempts: Executions allowed per window and key
+//   - decay: Window length
+func NewThrottleMiddleware(connection redisInterfaces.ConnectionInterface, maxAttempts int, decay time.Duration) *ThrottleMiddleware {
+	return &ThrottleMiddleware{
+		connection:  connection,
+		maxAttempts: maxAttempts,
+		decay:       decay,
+		prefix:      "throttle:",
+	}
+}
+
+// Block makes the middleware wait up to timeout for a slot instead of
+// failing immediately.
+func (m *ThrottleMiddleware) Block(timeout time.Duration) *ThrottleMiddleware {
+	m.block = timeout
+	return m
+}
+
+// By sets a function deriving the throttle key from the command.
+func (m *ThrottleMiddleware) By(resolver func(command interface{}) string) *ThrottleMiddleware {
+	m.keyResolver = resolver
+	return m
+}
+
+// WithPrefix sets the prefix prepended to every throttle key.
+func (m *ThrottleMiddleware) WithPrefix(prefix string) *ThrottleMiddleware {
+	m.prefix = prefix
+	return m
+}
+
+// Handle runs the command if a slot is available in the current window.
+// Returns a *ThrottledError otherwise.
+func (m *ThrottleMiddleware) Handle(ctx context.Context, command interface{}, next func(ctx context.Context, command interface{}) (interface{}, error)) (interface{}, error) {
+	key := m.prefix + m.key(command)
+	limiter := limiters.NewDurationLimiter(m.connection, key, m.maxAttempts, m.decay)
+
+	var result interface{}
+	err := limiter.Block(ctx, m.block, func() error {
+		var err error
+		result, err = next(ctx, command)
+		return err
+	}, m.sleep())
+
+	var timeout *redisExceptions.LimiterTimeoutException
+	if errors.As(err, &timeout) {
+		retryAfter := time.Until(limiter.DecaysAt())
+		if retryAfter < 0 {
+			retryAfter = 0
+		}
+		return nil, &ThrottledError{Key: key, RetryAfter: retryAfter}
+	}
+
+	return result, err
+}
+
+// key returns the throttle key of a command.
+func (m *ThrottleMiddleware) key(command interface{}) string {
+	if m.keyResolver != nil {
+		return m.keyResolver(command)
+	}
+	if keyed, ok := command.(ThrottleKeyed); ok {
+		return keyed.ThrottleKey()
+	}
+
+	commandType := reflect.TypeOf(command)
+	for commandType != nil && commandType.Kind() == reflect.Ptr {
+		commandType = commandType.Elem()
+	}
+	return fmt.Sprint(commandType)
+}
+
+// sleep returns the delay between attempts while blocking.
+func (m *ThrottleMiddleware) sleep() time.Duration {
+	if m.block > 0 && m.block < 750*time.Millisecond {
+		return m.block / 4
+	}
+	return 750 * time.Millisecond
+}
+
+// Compile-time interface compliance check
+var _ interfaces.MiddlewarePipe = (*ThrottleMiddleware)(nil)
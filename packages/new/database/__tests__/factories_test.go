@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"govel/new/database/connections"
+	"govel/new/database/factories"
+	"govel/new/database/faker"
+	"govel/new/database/schema"
+	"govel/support/carbon"
+)
+
+type factoryUser struct {
+	ID        int64
+	Name      string
+	Email     string
+	Votes     int
+	CreatedAt *carbon.Carbon
+	Note      string `db:"-"`
+}
+
+type factoryPost struct {
+	ID     int64
+	UserID int64
+	Title  string
+}
+
+func newFactoryConnection(t *testing.T) *connections.Connection {
+	t.Helper()
+
+	connection := newSQLiteConnection(t)
+	builder := connection.Schema()
+	if err := builder.Drop("users"); err != nil {
+		t.Fatal(err)
+	}
+	err := builder.Create("users", func(t *schema.Blueprint) {
+		t.ID()
+		t.String("name")
+		t.String("email").Unique()
+		t.Integer("votes").Default(0)
+		t.Timestamp("created_at").Nullable()
+	})
+	if err == nil {
+		err = builder.Create("posts", func(t *schema.Blueprint) {
+			t.ID()
+			t.ForeignID("user_id").Constrained()
+			t.String("title")
+		})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return connection
+}
+
+func userFactory(connection *connections.Connection) *factories.Factory[factoryUser] {
+	return factories.NewFactory(func(f *faker.Faker) factoryUser {
+		return factoryUser{
+			Name:      f.Name(),
+			Email:     f.UUID() + "@example.com",
+			CreatedAt: f.DateTimeThisYear(),
+		}
+	}).Table("users").Connection(connection)
+}
+
+func TestFaker_SeededIsDeterministic(t *testing.T) {
+	first, second := faker.New(42), faker.New(42)
+	for idx := 0; idx < 20; idx++ {
+		if a, b := first.Name()+first.Email()+first.Address()+first.Sentence(), second.Name()+second.Email()+second.Address()+second.Sentence(); a != b {
+			t.Fatalf("seeded fakers diverged: %q != %q", a, b)
+		}
+	}
+
+	generator := faker.New(7)
+	if text := generator.Text(80); len(text) > 80 || !strings.HasSuffix(text, ".") {
+		t.Fatalf("unexpected text %q", text)
+	}
+	start, end := carbon.Parse("2020-01-01"), carbon.Parse("2020-12-31")
+	if date := generator.DateTimeBetween(start, end); date.Lt(start) || date.Gt(end) {
+		t.Fatalf("date %s outside range", date)
+	}
+	if uuid := generator.UUID(); len(uuid) != 36 || uuid[14] != '4' {
+		t.Fatalf("invalid uuid %q", uuid)
+	}
+}
+
+func TestFactory_MakeStatesAndSequences(t *testing.T) {
+	users := userFactory(nil).Faker(faker.New(1)).Count(4).
+		State(func(u *factoryUser, f *faker.Faker) { u.Votes = 10 }).
+		Sequence(
+			func(u *factoryUser, f *faker.Faker) { u.Name = "even" },
+			func(u *factoryUser, f *faker.Faker) { u.Name = "odd" },
+		).Make()
+
+	if len(users) != 4 {
+		t.Fatalf("expected 4 users, got %d", len(users))
+	}
+	for idx, user := range users {
+		expected := []string{"even", "odd"}[idx%2]
+		if user.Name != expected || user.Votes != 10 || user.ID != 0 {
+			t.Fatalf("user %d = %+v", idx, user)
+		}
+	}
+
+	again := userFactory(nil).Faker(faker.New(1)).MakeOne()
+	if again.Email != userFactory(nil).Faker(faker.New(1)).MakeOne().Email {
+		t.Fatal("seeded factories should make the same model")
+	}
+
+	if _, err := userFactory(nil).Create(); err == nil {
+		t.Fatal("expected Create without a connection to fail")
+	}
+}
+
+func TestFactory_CreateWithRelationships(t *testing.T) {
+	connection := newFactoryConnection(t)
+	users := userFactory(connection)
+	posts := factories.NewFactory(func(f *faker.Faker) factoryPost {
+		return factoryPost{Title: f.Sentence(3)}
+	}).Table("posts").Connection(connection)
+
+	created, err := factories.Has(users.Count(2), posts.Count(3), func(post *factoryPost, user *factoryUser) {
+		post.UserID = user.ID
+	}).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created[0].ID != 1 || created[1].ID != 2 {
+		t.Fatalf("IDs were not filled: %+v", created)
+	}
+	if count, _ := connection.Table("posts").Where("user_id", "=", 2).Count(); count != 3 {
+		t.Fatalf("expected 3 posts for user 2, got %d", count)
+	}
+
+	post, err := factories.For(posts, users, func(post *factoryPost, user *factoryUser) {
+		post.UserID = user.ID
+	}).CreateOne()
+	if err != nil || post.UserID != 3 {
+		t.Fatalf("For created %+v (%v)", post, err)
+	}
+
+	var createdAt string
+	if err := connection.QueryRow("select created_at from users where id = 3").Scan(&createdAt); err != nil || createdAt == "" {
+		t.Fatalf("created_at not stored: %q (%v)", createdAt, err)
+	}
+}
+
+func TestFactory_Persist(t *testing.T) {
+	var saved []string
+	models, err := userFactory(nil).Count(2).Persist(func(ctx context.Context, user *factoryUser) error {
+		saved = append(saved, user.Email)
+		return nil
+	}).Create()
+	if err != nil || len(models) != 2 || len(saved) != 2 {
+		t.Fatalf("Persist saved %v (%v)", saved, err)
+	}
+}
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"govel/new/database/grammars"
+	"govel/new/database/query"
+)
+
+func newBuilder(t *testing.T, driver string, prefix string) *query.Builder {
+	t.Helper()
+	grammar, err := grammars.New(driver, prefix)
+	if err != nil {
+		t.Fatalf("grammar failed: %v", err)
+	}
+	return query.NewBuilder(nil, grammar)
+}
+
+func TestGrammar_SelectPlaceholdersAndQuoting(t *testing.T) {
+	cases := map[string]string{
+		"sqlite": `select "app_u"."name", count(*) as total from "app_users" as "app_u" inner join "app_posts" on "app_posts"."user_id" = "app_u"."id" ` +
+			`where "app_u"."votes" > ? and "app_u"."status" in (?, ?) or "app_u"."email" is null group by "app_u"."name" order by "app_u"."name" desc limit 10 offset 5`,
+		"mysql": "select `app_u`.`name`, count(*) as total from `app_users` as `app_u` inner join `app_posts` on `app_posts`.`user_id` = `app_u`.`id` " +
+			"where `app_u`.`votes` > ? and `app_u`.`status` in (?, ?) or `app_u`.`email` is null group by `app_u`.`name` order by `app_u`.`name` desc limit 10 offset 5",
+		"pgsql": `select "app_u"."name", count(*) as total from "app_users" as "app_u" inner join "app_posts" on "app_posts"."user_id" = "app_u"."id" ` +
+			`where "app_u"."votes" > $1 and "app_u"."status" in ($2, $3) or "app_u"."email" is null group by "app_u"."name" order by "app_u"."name" desc limit 10 offset 5`,
+	}
+
+	for driver, expected := range cases {
+		builder := newBuilder(t, driver, "app_")
+		builder.Select("u.name")
+		builder.SelectRaw("count(*) as total").
+			From("users as u").
+			Join("posts", "posts.user_id", "=", "u.id").
+			Where("u.votes", ">", 100).
+			WhereIn("u.status", []interface{}{"active", "pending"}).
+			OrWhere("u.email", "=", nil).
+			GroupBy("u.name").
+			OrderBy("u.name", "DESC").
+			Limit(10).
+			Offset(5)
+
+		sql, bindings, err := builder.ToSQL()
+		if err != nil {
+			t.Fatalf("%s: ToSQL failed: %v", driver, err)
+		}
+		if sql != expected {
+			t.Errorf("%s:\nexpected %s\n     got %s", driver, expected, sql)
+		}
+		if !reflect.DeepEqual(bindings, []interface{}{100, "active", "pending"}) {
+			t.Errorf("%s: unexpected bindings %v", driver, bindings)
+		}
+	}
+}
+
+func TestGrammar_OffsetWithoutLimit(t *testing.T) {
+	expected := map[string]string{
+		"sqlite": `select * from "users" limit -1 offset 3`,
+		"mysql":  "select * from `users` limit 18446744073709551615 offset 3",
+		"pgsql":  `select * from "users" offset 3`,
+	}
+	for driver, sql := range expected {
+		got, _, _ := newBuilder(t, driver, "").From("users").Offset(3).ToSQL()
+		if got != sql {
+			t.Errorf("%s: expected %s, got %s", driver, sql, got)
+		}
+	}
+}
+
+func TestGrammar_Upsert(t *testing.T) {
+	rows := []map[string]interface{}{{"email": "a@example.com", "name": "A"}}
+
+	expected := map[string]string{
+		"sqlite": `insert into "users" ("email", "name") values (?, ?) on conflict ("email") do update set "name" = "excluded"."name"`,
+		"mysql":  "insert into `users` (`email`, `name`) values (?, ?) on duplicate key update `name` = values(`name`)",
+		"pgsql":  `insert into "users" ("email", "name") values ($1, $2) on conflict ("email") do update set "name" = "excluded"."name"`,
+	}
+	for driver, sql := range expected {
+		builder := newBuilder(t, driver, "")
+		builder.From("users")
+		got, bindings := builder.Grammar().CompileUpsert(builder.Components(), rows, []string{"email"}, []string{"name"})
+		if got != sql {
+			t.Errorf("%s:\nexpected %s\n     got %s", driver, sql, got)
+		}
+		if len(bindings) != 2 {
+			t.Errorf("%s: expected 2 bindings, got %v", driver, bindings)
+		}
+	}
+}
+
+func TestGrammar_UpdateReturningAndRawWhere(t *testing.T) {
+	builder := newBuilder(t, "pgsql", "")
+	builder.From("users").Where("id", "=", 1).WhereRaw("lower(name) = ? and note <> '?'", "ann")
+
+	sql, bindings := builder.Grammar().CompileUpdate(builder.Components(), map[string]interface{}{
+		"name":  "Ann",
+		"votes": query.Raw(`"votes" + 1`),
+	})
+	sql += " " + builder.Grammar().CompileReturning([]string{"id", "name"})
+
+	expected := `update "users" set "name" = $1, "votes" = "votes" + 1 where "id" = $2 and lower(name) = $3 and note <> '?' returning "id", "name"`
+	if sql != expected {
+		t.Errorf("expected %s\n     got %s", expected, sql)
+	}
+	if !reflect.DeepEqual(bindings, []interface{}{"Ann", 1, "ann"}) {
+		t.Errorf("unexpected bindings %v", bindings)
+	}
+}
+
+func TestGrammar_QuoteValue(t *testing.T) {
+	mysql, _ := grammars.New("mysql", "")
+	pgsql, _ := grammars.New("pgsql", "")
+
+	if got := mysql.QuoteValue(`it's \ here`); got != `'it''s \\ here'` {
+		t.Errorf("unexpected mysql quoting %s", got)
+	}
+	if got := pgsql.QuoteValue(true); got != "true" {
+		t.Errorf("unexpected pgsql boolean %s", got)
+	}
+	if got := mysql.QuoteValue(nil); got != "null" {
+		t.Errorf("unexpected null %s", got)
+	}
+}
+
+func TestGrammar_UnknownOperatorsNeverReachTheSQL(t *testing.T) {
+	injection := "= 1 or 1 = 1; --"
+
+	expected := map[string]string{
+		"sqlite": `select * from "users" inner join "posts" on "posts"."user_id" = "= 1 or 1 = 1; --" ` +
+			`where "name" = ? or "email" like ? group by "name" having "name" = ?`,
+		"mysql": "select * from `users` inner join `posts` on `posts`.`user_id` = `= 1 or 1 = 1; --` " +
+			"where `name` = ? or `email` like ? group by `name` having `name` = ?",
+		"pgsql": `select * from "users" inner join "posts" on "posts"."user_id" = "= 1 or 1 = 1; --" ` +
+			`where "name" = $1 or "email" like $2 group by "name" having "name" = $3`,
+	}
+
+	for driver, sql := range expected {
+		builder := newBuilder(t, driver, "")
+		builder.From("users").
+			Join("posts", "posts.user_id", injection, "users.id").
+			Where("name", injection, "ann").
+			OrWhere("email", "LIKE", "%@example.com").
+			GroupBy("name").
+			Having("name", injection, "ann")
+
+		got, bindings, err := builder.ToSQL()
+		if err != nil {
+			t.Fatalf("%s: ToSQL failed: %v", driver, err)
+		}
+		if got != sql {
+			t.Errorf("%s:\nexpected %s\n     got %s", driver, sql, got)
+		}
+		if !reflect.DeepEqual(bindings, []interface{}{injection, "%@example.com", injection}) {
+			t.Errorf("%s: expected unknown operators to be bound as values, got %v", driver, bindings)
+		}
+	}
+
+	// Hand-built components are checked by the grammar as well
+	builder := newBuilder(t, "sqlite", "")
+	builder.From("users")
+	builder.Components().Wheres = append(builder.Components().Wheres, query.Where{
+		Type: query.WhereBasic, Boolean: query.BooleanAnd, Column: "id", Operator: "= 1 --", Value: 1,
+	})
+	if got, _, _ := builder.ToSQL(); got != `select * from "users" where "id" = ?` {
+		t.Errorf("unexpected SQL for a hand-built condition: %s", got)
+	}
+}
+
+func TestGrammar_DialectOperators(t *testing.T) {
+	cases := []struct {
+		driver    string
+		operator  string
+		supported bool
+	}{
+		{"pgsql", "ilike", true},
+		{"pgsql", "is distinct from", true},
+		{"mysql", "sounds like", true},
+		{"mysql", "<=>", true},
+		{"sqlite", "glob", true},
+		{"sqlite", "ilike", false},
+		{"mysql", "ilike", false},
+		{"pgsql", "sounds like", false},
+	}
+
+	for _, tt := range cases {
+		sql, _, _ := newBuilder(t, tt.driver, "").From("users").Where("name", strings.ToUpper(tt.operator), "ann").ToSQL()
+		if supported := strings.Contains(sql, " "+tt.operator+" "); supported != tt.supported {
+			t.Errorf("%s: expected %q supported=%v, got %s", tt.driver, tt.operator, tt.supported, sql)
+		}
+	}
+}
+
+func TestGrammar_QuotesEveryIdentifier(t *testing.T) {
+	builder := newBuilder(t, "sqlite", "")
+	builder.Select(`name) from secrets; --`, "users.*").
+		From(`users(x)`).
+		OrderBy(`id"); drop table users; --`, "asc")
+
+	sql, _, _ := builder.ToSQL()
+	expected := `select "name) from secrets; --", "users".* from "users(x)" order by "id""); drop table users; --" asc`
+	if sql != expected {
+		t.Errorf("expected %s\n     got %s", expected, sql)
+	}
+
+	grammar := builder.Grammar()
+	if got := grammar.Wrap("count(*)"); got != `"count(*)"` {
+		t.Errorf("expected parentheses to be quoted, got %s", got)
+	}
+	if got := grammar.WrapTable("users (select 1)"); got != `"users (select 1)"` {
+		t.Errorf("expected parentheses to be quoted, got %s", got)
+	}
+}
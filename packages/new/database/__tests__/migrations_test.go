@@ -0,0 +1,266 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"govel/new/database/migrations"
+	"govel/new/database/schema"
+)
+
+func newMigrationRegistry(t *testing.T) *migrations.Registry {
+	t.Helper()
+
+	registry := migrations.NewRegistry()
+	add := func(name string, migration migrations.Migration) {
+		if err := registry.Add(name, migration); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	add("2024_01_01_000000_create_users_table", migrations.New(
+		func(s *schema.Builder) error {
+			return s.Create("users", func(t *schema.Blueprint) {
+				t.ID()
+				t.String("email").Unique()
+				t.Timestamps()
+			})
+		},
+		func(s *schema.Builder) error { return s.DropIfExists("users") },
+	))
+	add("2024_01_02_000000_create_posts_table", migrations.New(
+		func(s *schema.Builder) error {
+			return s.Create("posts", func(t *schema.Blueprint) {
+				t.ID()
+				t.ForeignID("user_id").Constrained().CascadeOnDelete()
+				t.String("title")
+			})
+		},
+		func(s *schema.Builder) error { return s.DropIfExists("posts") },
+	))
+	return registry
+}
+
+func TestMigrator_RunRollbackAndStatus(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+	connection.Schema().Drop("users")
+
+	registry := newMigrationRegistry(t)
+	migrator := migrations.NewMigrator(connection, registry, "schema_migrations")
+
+	ran, err := migrator.Run(ctx, migrations.Options{Step: true})
+	if err != nil || len(ran) != 2 {
+		t.Fatalf("Run returned %v (%v)", ran, err)
+	}
+
+	if ok, _ := connection.Schema().HasColumns("posts", "id", "user_id", "title"); !ok {
+		t.Fatal("posts table was not created")
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[0].Ran || statuses[0].Batch != 1 || statuses[1].Batch != 2 {
+		t.Fatalf("unexpected status %+v", statuses)
+	}
+
+	if ran, _ := migrator.Run(ctx, migrations.Options{}); len(ran) != 0 {
+		t.Fatalf("expected nothing to run, ran %v", ran)
+	}
+
+	reverted, err := migrator.Rollback(ctx, migrations.Options{})
+	if err != nil || len(reverted) != 1 || reverted[0] != "2024_01_02_000000_create_posts_table" {
+		t.Fatalf("Rollback returned %v (%v)", reverted, err)
+	}
+	if exists, _ := connection.Schema().HasTable("posts"); exists {
+		t.Fatal("posts table should be dropped")
+	}
+
+	// A new run puts the pending migration in batch 2 again
+	if _, err := migrator.Run(ctx, migrations.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if reverted, _ := migrator.Rollback(ctx, migrations.Options{Steps: 2}); len(reverted) != 2 {
+		t.Fatalf("expected two rolled back migrations, got %v", reverted)
+	}
+}
+
+func TestMigrator_FailedMigrationRollsBack(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+
+	registry := migrations.NewRegistry()
+	registry.Add("2024_01_01_000000_broken", migrations.New(func(s *schema.Builder) error {
+		if err := s.Create("widgets", func(t *schema.Blueprint) { t.ID() }); err != nil {
+			return err
+		}
+		return s.Statement("not valid sql")
+	}, nil))
+
+	migrator := migrations.NewMigrator(connection, registry, "")
+	if _, err := migrator.Run(ctx, migrations.Options{}); err == nil {
+		t.Fatal("expected the migration to fail")
+	}
+	if exists, _ := connection.Schema().HasTable("widgets"); exists {
+		t.Fatal("the failed migration should have been rolled back")
+	}
+	if statuses, _ := migrator.Status(ctx); statuses[0].Ran {
+		t.Fatal("the failed migration should not be recorded")
+	}
+}
+
+func TestMigrationCommands_PretendAndFresh(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+	migrator := migrations.NewMigrator(connection, newMigrationRegistry(t), "")
+
+	var output bytes.Buffer
+	if err := migrations.Execute(ctx, migrator, []string{"migrate", "--pretend"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), `2024_01_01_000000_create_users_table: create table "users"`) {
+		t.Fatalf("pretend output missing SQL:\n%s", output.String())
+	}
+	if exists, _ := connection.Schema().HasTable("migrations"); exists {
+		t.Fatal("pretend must not create the migrations table")
+	}
+
+	output.Reset()
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:fresh"}, &output); err != nil {
+		t.Fatalf("fresh failed: %v\n%s", err, output.String())
+	}
+
+	output.Reset()
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:status"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(output.String(), "Yes") != 2 {
+		t.Fatalf("unexpected status output:\n%s", output.String())
+	}
+
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:unknown"}, &output); err == nil {
+		t.Fatal("expected an error for an unknown command")
+	}
+}
+
+func TestMigrationCommands_FreshRequiresForceInProduction(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+	migrator := migrations.NewMigrator(connection, newMigrationRegistry(t), "").SetEnvironment("production")
+
+	var output bytes.Buffer
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:fresh", "--force"}, &output); err != nil {
+		t.Fatalf("expected --force to run the command, got %v", err)
+	}
+	if _, err := connection.Table("users").Insert(map[string]interface{}{"email": "ann@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := migrations.Execute(ctx, migrator, []string{"migrate:fresh"}, &output)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected fresh to be refused in production, got %v", err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 1 {
+		t.Fatal("the refused command must not drop the tables")
+	}
+
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:fresh", "--pretend"}, &output); err != nil {
+		t.Fatalf("expected pretended runs to be allowed, got %v", err)
+	}
+	if err := migrations.Execute(ctx, migrator, []string{"migrate:fresh", "--force"}, &output); err != nil {
+		t.Fatalf("expected --force to run the command, got %v", err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 0 {
+		t.Fatal("expected --force to recreate the tables")
+	}
+}
+
+func TestMigrationCommands_DestructiveCommandsRequireForceInProduction(t *testing.T) {
+	ctx := context.Background()
+	migrator := migrations.NewMigrator(newSQLiteConnection(t), newMigrationRegistry(t), "").SetEnvironment("production")
+
+	ran := func() int {
+		t.Helper()
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		count := 0
+		for _, status := range statuses {
+			if status.Ran {
+				count++
+			}
+		}
+		return count
+	}
+
+	steps := []struct {
+		command string
+		ran     int
+	}{
+		{command: "migrate", ran: 2},
+		{command: "migrate:rollback", ran: 0},
+		{command: "migrate", ran: 2},
+		{command: "migrate:reset", ran: 0},
+	}
+
+	// Start without tables, the connection comes with its own users table
+	var output bytes.Buffer
+	for _, args := range [][]string{{"migrate:fresh", "--force"}, {"migrate:reset", "--force"}} {
+		if err := migrations.Execute(ctx, migrator, args, &output); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, step := range steps {
+		before := ran()
+		err := migrations.Execute(ctx, migrator, []string{step.command}, &output)
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Fatalf("expected %s to be refused in production, got %v", step.command, err)
+		}
+		if ran() != before {
+			t.Fatalf("the refused %s must not change the migrations", step.command)
+		}
+
+		if err := migrations.Execute(ctx, migrator, []string{step.command, "--pretend"}, &output); err != nil {
+			t.Fatalf("expected a pretended %s to be allowed, got %v", step.command, err)
+		}
+		if err := migrations.Execute(ctx, migrator, []string{step.command, "--force"}, &output); err != nil {
+			t.Fatalf("expected --force to run %s, got %v", step.command, err)
+		}
+		if ran() != step.ran {
+			t.Fatalf("expected %d migrations to have run after %s, got %d", step.ran, step.command, ran())
+		}
+	}
+}
+
+func TestMakeMigration(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	file, err := migrations.MakeMigration(directory, "add_votes_to_users_table", "", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(file) != "2024_03_04_050607_add_votes_to_users_table.go" {
+		t.Fatalf("unexpected file %s", file)
+	}
+
+	source, _ := os.ReadFile(file)
+	for _, want := range []string{"package migrations", `migrations.Register("2024_03_04_050607_add_votes_to_users_table"`, `s.Table("users"`} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, source)
+		}
+	}
+
+	if _, err := migrations.MakeMigration(directory, "Bad Name", "", "", now); err == nil {
+		t.Fatal("expected invalid names to be rejected")
+	}
+}
@@ -0,0 +1,327 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"govel/new/database/connections"
+	"govel/new/database/orm"
+	"govel/new/database/schema"
+)
+
+type ormAuthor struct {
+	ID       int64
+	Name     string
+	Articles []*ormArticle `orm:"hasMany,foreignKey:author_id"`
+	orm.Timestamps
+}
+
+func (ormAuthor) TableName() string { return "authors" }
+
+type ormArticle struct {
+	ID       int64
+	AuthorID int64 `db:"author_id"`
+	Title    string
+	Author   *ormAuthor   `orm:"belongsTo"`
+	Comments []ormComment `orm:"hasMany,foreignKey:article_id"`
+	Tags     []*ormTag    `orm:"belongsToMany,table:article_tag,foreignPivotKey:article_id,relatedPivotKey:tag_id"`
+	orm.Timestamps
+	orm.SoftDeletes
+}
+
+func (ormArticle) TableName() string { return "articles" }
+
+type ormComment struct {
+	ID        int64
+	ArticleID int64 `db:"article_id"`
+	Body      string
+	orm.SoftDeletes
+}
+
+func (ormComment) TableName() string { return "comments" }
+
+type ormTag struct {
+	ID   int64
+	Name string
+}
+
+func (ormTag) TableName() string { return "tags" }
+
+func newORMConnection(t *testing.T) (*connections.Connection, *orm.Manager) {
+	t.Helper()
+	connection := newSQLiteConnection(t)
+	builder := connection.Schema()
+
+	tables := map[string]func(*schema.Blueprint){
+		"authors": func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name")
+			table.Timestamps()
+		},
+		"articles": func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("author_id")
+			table.String("title")
+			table.Timestamps()
+			table.SoftDeletes()
+		},
+		"comments": func(table *schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("article_id")
+			table.Text("body")
+			table.SoftDeletes()
+		},
+		"tags": func(table *schema.Blueprint) {
+			table.ID()
+			table.String("name")
+		},
+		"article_tag": func(table *schema.Blueprint) {
+			table.UnsignedBigInteger("article_id")
+			table.UnsignedBigInteger("tag_id")
+		},
+	}
+	for name, callback := range tables {
+		if err := builder.Create(name, callback); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+
+	manager := orm.NewManager(nil)
+	return connection, manager
+}
+
+func TestORM_SaveFindAndTimestamps(t *testing.T) {
+	connection, manager := newORMConnection(t)
+	authors := orm.NewModel[ormAuthor]().On(connection).Using(manager)
+
+	if authors.GetTable() != "authors" {
+		t.Fatalf("unexpected table %q", authors.GetTable())
+	}
+
+	author := &ormAuthor{Name: "Ann"}
+	if err := authors.Save(author); err != nil {
+		t.Fatal(err)
+	}
+	if author.ID == 0 || author.CreatedAt == nil || author.UpdatedAt == nil {
+		t.Fatalf("expected id and timestamps to be filled, got %+v", author)
+	}
+
+	author.Name = "Anne"
+	if err := authors.Save(author); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := authors.Find(author.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name != "Anne" || found.CreatedAt == nil {
+		t.Fatalf("unexpected model %+v", found)
+	}
+
+	if _, err := authors.Find(999); !errors.Is(err, orm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+
+	first, err := authors.Where("name", "=", "Anne").First()
+	if err != nil || first.ID != author.ID {
+		t.Fatalf("unexpected first %+v: %v", first, err)
+	}
+}
+
+func TestORM_SoftDeletesAndRestore(t *testing.T) {
+	connection, manager := newORMConnection(t)
+	articles := orm.NewModel[ormArticle]().On(connection).Using(manager)
+
+	kept := &ormArticle{AuthorID: 1, Title: "Kept"}
+	removed := &ormArticle{AuthorID: 1, Title: "Removed"}
+	for _, article := range []*ormArticle{kept, removed} {
+		if err := articles.Create(article); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := articles.Delete(removed); err != nil {
+		t.Fatal(err)
+	}
+	if !removed.Trashed() {
+		t.Fatal("expected the model to be marked deleted")
+	}
+
+	if count, _ := articles.Count(); count != 1 {
+		t.Fatalf("expected 1 visible article, got %d", count)
+	}
+	if count, _ := articles.WithTrashed().Count(); count != 2 {
+		t.Fatalf("expected 2 articles with trashed, got %d", count)
+	}
+	trashed, err := articles.OnlyTrashed().Get()
+	if err != nil || len(trashed) != 1 || trashed[0].Title != "Removed" {
+		t.Fatalf("unexpected trashed articles %v: %v", trashed, err)
+	}
+
+	if err := articles.Restore(removed); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := articles.Count(); count != 2 {
+		t.Fatalf("expected 2 articles after restore, got %d", count)
+	}
+
+	if err := articles.ForceDelete(removed); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := articles.WithTrashed().Count(); count != 1 {
+		t.Fatalf("expected 1 article after force delete, got %d", count)
+	}
+}
+
+func TestORM_Paginate(t *testing.T) {
+	connection, manager := newORMConnection(t)
+	tags := orm.NewModel[ormTag]().On(connection).Using(manager)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := tags.Create(&ormTag{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := tags.OrderBy("name", "asc").Paginate(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 5 || page.LastPage != 3 || len(page.Items) != 2 || page.Items[0].Name != "c" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if !page.HasMorePages() || page.OnFirstPage() {
+		t.Fatal("expected a middle page")
+	}
+}
+
+func TestORM_EagerLoading(t *testing.T) {
+	connection, manager := newORMConnection(t)
+	authors := orm.NewModel[ormAuthor]().On(connection).Using(manager)
+	articles := orm.NewModel[ormArticle]().On(connection).Using(manager)
+	comments := orm.NewModel[ormComment]().On(connection).Using(manager)
+	tags := orm.NewModel[ormTag]().On(connection).Using(manager)
+
+	ann := &ormAuthor{Name: "Ann"}
+	bob := &ormAuthor{Name: "Bob"}
+	for _, author := range []*ormAuthor{ann, bob} {
+		if err := authors.Create(author); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := &ormArticle{AuthorID: ann.ID, Title: "First"}
+	second := &ormArticle{AuthorID: ann.ID, Title: "Second"}
+	for _, article := range []*ormArticle{first, second} {
+		if err := articles.Create(article); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hidden := &ormComment{ArticleID: first.ID, Body: "hidden"}
+	for _, comment := range []*ormComment{{ArticleID: first.ID, Body: "nice"}, {ArticleID: first.ID, Body: "great"}, hidden} {
+		if err := comments.Create(comment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := comments.Delete(hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	golang := &ormTag{Name: "go"}
+	sql := &ormTag{Name: "sql"}
+	for _, tag := range []*ormTag{golang, sql} {
+		if err := tags.Create(tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := articles.Attach(first, "Tags", golang.ID, sql.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := articles.Attach(second, "tags", golang.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := authors.OrderBy("id", "asc").With("articles.comments", "articles.tags").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 || len(loaded[0].Articles) != 2 || len(loaded[1].Articles) != 0 {
+		t.Fatalf("unexpected articles %+v", loaded)
+	}
+
+	article := loaded[0].Articles[0]
+	if len(article.Comments) != 2 || article.Comments[0].Body != "nice" {
+		t.Fatalf("expected 2 visible comments, got %+v", article.Comments)
+	}
+	if len(article.Tags) != 2 || len(loaded[0].Articles[1].Tags) != 1 {
+		t.Fatalf("unexpected tags %+v / %+v", article.Tags, loaded[0].Articles[1].Tags)
+	}
+
+	withAuthor, err := articles.With("author").Find(second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withAuthor.Author == nil || withAuthor.Author.Name != "Ann" {
+		t.Fatalf("expected the author to be loaded, got %+v", withAuthor.Author)
+	}
+
+	if err := articles.Detach(first, "tags", sql.ID); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := []*ormArticle{first}
+	if err := articles.Load(reloaded, "tags"); err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Tags) != 1 || first.Tags[0].Name != "go" {
+		t.Fatalf("unexpected tags after detach %+v", first.Tags)
+	}
+
+	if _, err := authors.With("unknown").Get(); err == nil {
+		t.Fatal("expected an error for an unknown relation")
+	}
+}
+
+func TestORM_Events(t *testing.T) {
+	connection, manager := newORMConnection(t)
+	authors := orm.NewModel[ormAuthor]().On(connection).Using(manager)
+
+	var events []string
+	manager.Listen("*", func(event string, model interface{}) error {
+		events = append(events, event)
+		return nil
+	})
+	manager.Listen("creating: authors", func(event string, model interface{}) error {
+		if model.(*ormAuthor).Name == "" {
+			return errors.New("name required")
+		}
+		return nil
+	})
+
+	if err := authors.Create(&ormAuthor{}); err == nil || err.Error() != "name required" {
+		t.Fatalf("expected creating to cancel the insert, got %v", err)
+	}
+	if count, _ := authors.Count(); count != 0 {
+		t.Fatalf("expected no author, got %d", count)
+	}
+
+	events = nil
+	author := &ormAuthor{Name: "Ann"}
+	if err := authors.Create(author); err != nil {
+		t.Fatal(err)
+	}
+	if err := authors.Delete(author); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"saving: authors", "creating: authors", "created: authors", "saved: authors", "deleting: authors", "deleted: authors"}
+	if len(events) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, events)
+	}
+	for idx := range expected {
+		if events[idx] != expected[idx] {
+			t.Fatalf("expected events %v, got %v", expected, events)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"govel/new/database/grammars"
+	"govel/new/database/schema"
+)
+
+func newSchemaBuilder(t *testing.T, driver string, prefix string) *schema.Builder {
+	t.Helper()
+
+	grammar, err := grammars.New(driver, prefix)
+	if err != nil {
+		t.Fatalf("grammar: %v", err)
+	}
+	builder, err := schema.NewBuilder(nil, grammar)
+	if err != nil {
+		t.Fatalf("schema builder: %v", err)
+	}
+	return builder
+}
+
+func createUsers(t *schema.Blueprint) {
+	t.ID()
+	t.String("email").Unique()
+	t.Boolean("active").Default(true)
+	t.Timestamps()
+}
+
+func TestSchema_CreatePerDialect(t *testing.T) {
+	cases := map[string][]string{
+		"sqlite": {
+			`create table "users" ("id" integer primary key autoincrement not null, "email" varchar not null, "active" tinyint(1) not null default 1, "created_at" datetime null, "updated_at" datetime null)`,
+			`create unique index "users_email_unique" on "users" ("email")`,
+		},
+		"mysql": {
+			"create table `users` (`id` bigint unsigned not null auto_increment primary key, `email` varchar(255) not null, `active` tinyint(1) not null default 1, `created_at` timestamp null, `updated_at` timestamp null)",
+			"create unique index `users_email_unique` on `users` (`email`)",
+		},
+		"pgsql": {
+			`create table "users" ("id" bigserial not null primary key, "email" varchar(255) not null, "active" boolean not null default true, "created_at" timestamp(0) without time zone null, "updated_at" timestamp(0) without time zone null)`,
+			`create unique index "users_email_unique" on "users" ("email")`,
+		},
+	}
+
+	for driver, expected := range cases {
+		statements, err := newSchemaBuilder(t, driver, "").ToSQL("users", true, createUsers)
+		if err != nil {
+			t.Fatalf("%s: %v", driver, err)
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("%s:\n got  %q\n want %q", driver, statements, expected)
+		}
+	}
+}
+
+func TestSchema_ForeignKeysAndPrefix(t *testing.T) {
+	statements, err := newSchemaBuilder(t, "pgsql", "app_").ToSQL("posts", true, func(t *schema.Blueprint) {
+		t.ID()
+		t.ForeignID("user_id").Constrained().CascadeOnDelete()
+		t.Enum("status", []string{"draft", "published"}).Default("draft")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `create table "app_posts" ("id" bigserial not null primary key, "user_id" bigint not null, ` +
+		`"status" varchar(255) check ("status" in ('draft', 'published')) not null default 'draft', ` +
+		`constraint "app_posts_user_id_foreign" foreign key ("user_id") references "app_users" ("id") on delete cascade)`
+	if len(statements) != 1 || statements[0] != expected {
+		t.Fatalf("unexpected statements:\n%q", statements)
+	}
+}
+
+func TestSchema_AlterTable(t *testing.T) {
+	alter := func(t *schema.Blueprint) {
+		t.Integer("votes").Unsigned().Default(0)
+		t.RenameColumn("name", "full_name")
+		t.DropUnique("email")
+		t.DropColumn("legacy")
+	}
+
+	mysql, err := newSchemaBuilder(t, "mysql", "").ToSQL("users", false, alter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"alter table `users` add column `votes` int unsigned not null default 0",
+		"alter table `users` rename column `name` to `full_name`",
+		"alter table `users` drop index `users_email_unique`",
+		"alter table `users` drop column `legacy`",
+	}
+	if !reflect.DeepEqual(mysql, expected) {
+		t.Fatalf("mysql:\n got  %q\n want %q", mysql, expected)
+	}
+
+	sqlite, err := newSchemaBuilder(t, "sqlite", "").ToSQL("users", false, alter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqlite[2] != `drop index "users_email_unique"` {
+		t.Fatalf("sqlite drop unique: %q", sqlite[2])
+	}
+
+	if _, err := newSchemaBuilder(t, "sqlite", "").ToSQL("posts", false, func(t *schema.Blueprint) {
+		t.ForeignID("user_id").Constrained()
+	}); err == nil {
+		t.Fatal("expected sqlite to reject foreign keys on existing tables")
+	}
+}
+
+func TestSchema_Rename(t *testing.T) {
+	builder := newSchemaBuilder(t, "mysql", "").Pretend()
+	if err := builder.Rename("users", "members"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.DropIfExists("members"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"rename table `users` to `members`", "drop table if exists `members`"}
+	if !reflect.DeepEqual(builder.Statements(), expected) {
+		t.Fatalf("got %q", builder.Statements())
+	}
+}
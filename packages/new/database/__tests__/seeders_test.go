@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"govel/new/database/seeders"
+)
+
+type votesSeeder struct{}
+
+func (votesSeeder) Run(ctx context.Context, runner *seeders.Runner) error {
+	_, err := runner.Connection().Table("users").InsertContext(ctx, map[string]interface{}{
+		"email": "seeded@example.com",
+		"votes": 5,
+	})
+	return err
+}
+
+type rootSeeder struct{}
+
+func (rootSeeder) Run(ctx context.Context, runner *seeders.Runner) error {
+	return runner.Call(ctx, votesSeeder{})
+}
+
+func TestSeeders_CallAndCommand(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+
+	registry := seeders.NewRegistry()
+	registry.Add(seeders.DefaultSeeder, rootSeeder{})
+	registry.Add("VotesSeeder", votesSeeder{})
+	runner := seeders.NewRunner(connection, registry)
+
+	var output bytes.Buffer
+	if err := seeders.Execute(ctx, runner, []string{"db:seed"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"Seeding: DatabaseSeeder", "Seeding: VotesSeeder", "completed successfully"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Fatalf("output lacks %q:\n%s", expected, output.String())
+		}
+	}
+	if count, _ := connection.Table("users").Count(); count != 1 {
+		t.Fatalf("expected 1 seeded user, got %d", count)
+	}
+
+	err := seeders.Execute(ctx, runner, []string{"db:seed", "--class=VotesSeeder"}, &output)
+	if err == nil || !strings.Contains(err.Error(), "UNIQUE") {
+		t.Fatalf("expected the duplicate email to fail, got %v", err)
+	}
+
+	if err := runner.Call(ctx, "MissingSeeder"); err == nil {
+		t.Fatal("expected unknown seeders to fail")
+	}
+}
+
+func TestSeeders_CommandRequiresForceInProduction(t *testing.T) {
+	ctx := context.Background()
+	connection := newSQLiteConnection(t)
+
+	registry := seeders.NewRegistry()
+	registry.Add(seeders.DefaultSeeder, votesSeeder{})
+	runner := seeders.NewRunner(connection, registry).SetEnvironment("production")
+
+	var output bytes.Buffer
+	err := seeders.Execute(ctx, runner, []string{"db:seed"}, &output)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected db:seed to be refused in production, got %v", err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 0 {
+		t.Fatal("the refused command must not seed")
+	}
+
+	if err := seeders.Execute(ctx, runner, []string{"db:seed", "--force"}, &output); err != nil {
+		t.Fatalf("expected --force to run the command, got %v", err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 1 {
+		t.Fatalf("expected 1 seeded user, got %d", count)
+	}
+}
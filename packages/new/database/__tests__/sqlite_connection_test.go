@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	database "govel/new/database"
+	"govel/new/database/connections"
+	"govel/new/database/query"
+)
+
+func newSQLiteConnection(t *testing.T) *connections.Connection {
+	t.Helper()
+
+	manager := database.New().AddConnection("default", map[string]interface{}{
+		"driver":                  "sqlite",
+		"database":                ":memory:",
+		"foreign_key_constraints": true,
+	})
+	t.Cleanup(func() { manager.Close() })
+
+	connection, err := manager.Connection()
+	if err != nil {
+		t.Fatalf("connection failed: %v", err)
+	}
+
+	_, err = connection.Exec(`create table users (
+		id integer primary key autoincrement,
+		email varchar(255) not null unique,
+		name varchar(255),
+		votes integer not null default 0
+	)`)
+	if err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	return connection
+}
+
+func TestSQLite_InsertSelectUpdateDelete(t *testing.T) {
+	connection := newSQLiteConnection(t)
+	users := func() *query.Builder { return connection.Table("users").(*query.Builder) }
+
+	id, err := users().InsertGetID(map[string]interface{}{"email": "ann@example.com", "name": "Ann", "votes": 3})
+	if err != nil || id != 1 {
+		t.Fatalf("InsertGetID returned %d (%v)", id, err)
+	}
+
+	_, err = users().InsertBatch([]map[string]interface{}{
+		{"email": "bob@example.com", "name": "Bob", "votes": 7},
+		{"email": "cid@example.com", "name": nil, "votes": 1},
+	})
+	if err != nil {
+		t.Fatalf("InsertBatch failed: %v", err)
+	}
+
+	count, err := users().Where("votes", ">", 2).Count()
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 users, got %d (%v)", count, err)
+	}
+
+	var name string
+	if err := users().Select("name").WhereNull("name").OrWhere("votes", ">=", 7).OrderBy("votes", "desc").First().Scan(&name); err != nil || name != "Bob" {
+		t.Fatalf("expected Bob, got %q (%v)", name, err)
+	}
+
+	exists, err := users().Where("email", "=", "nobody@example.com").Exists()
+	if err != nil || exists {
+		t.Fatalf("expected no match, got %v (%v)", exists, err)
+	}
+
+	if _, err := connection.Update("users", map[string]interface{}{"votes": query.Raw(`"votes" + 1`)}, map[string]interface{}{"name": "Ann"}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if affected, _ := connection.GetRowsAffected(); affected != 1 {
+		t.Fatalf("expected 1 affected row, got %d", affected)
+	}
+
+	rows, err := users().Where("id", "=", 1).UpdateReturning(map[string]interface{}{"name": "Anne"}, "name", "votes")
+	if err != nil {
+		t.Fatalf("UpdateReturning failed: %v", err)
+	}
+	var votes int
+	if !rows.Next() || rows.Scan(&name, &votes) != nil || name != "Anne" || votes != 4 {
+		t.Fatalf("unexpected returning row %q %d", name, votes)
+	}
+	rows.Close()
+
+	if _, err := connection.Delete("users", map[string]interface{}{"email": "cid@example.com"}); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if count, _ := users().Count(); count != 2 {
+		t.Fatalf("expected 2 remaining users, got %d", count)
+	}
+}
+
+func TestSQLite_Upsert(t *testing.T) {
+	connection := newSQLiteConnection(t)
+	users := connection.Table("users")
+
+	rows := []map[string]interface{}{{"email": "ann@example.com", "name": "Ann", "votes": 1}}
+	if _, err := users.Upsert(rows, []string{"email"}, nil); err != nil {
+		t.Fatalf("first upsert failed: %v", err)
+	}
+
+	rows[0]["votes"] = 9
+	if _, err := connection.Table("users").Upsert(rows, []string{"email"}, []string{"votes"}); err != nil {
+		t.Fatalf("second upsert failed: %v", err)
+	}
+
+	var votes int
+	if err := connection.Table("users").Select("votes").First().Scan(&votes); err != nil || votes != 9 {
+		t.Fatalf("expected 9 votes, got %d (%v)", votes, err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 1 {
+		t.Fatalf("expected a single row, got %d", count)
+	}
+}
+
+func TestSQLite_TransactionRollsBack(t *testing.T) {
+	connection := newSQLiteConnection(t)
+	failure := errors.New("boom")
+
+	err := connection.Transaction(func(tx *sql.Tx) error {
+		if _, err := connection.TableTx(tx, "users").Insert(map[string]interface{}{"email": "tx@example.com"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if count, _ := connection.Table("users").Count(); count != 0 {
+		t.Fatalf("expected rollback, got %d rows", count)
+	}
+}
+
+func TestSQLite_Schema(t *testing.T) {
+	connection := newSQLiteConnection(t)
+
+	exists, err := connection.TableExists("users")
+	if err != nil || !exists {
+		t.Fatalf("expected users table, got %v (%v)", exists, err)
+	}
+	if names, _ := connection.GetTableNames(); len(names) != 1 || names[0] != "users" {
+		t.Fatalf("unexpected tables %v", names)
+	}
+
+	columns, err := connection.GetColumns("users")
+	if err != nil || len(columns) != 4 {
+		t.Fatalf("expected 4 columns, got %v (%v)", columns, err)
+	}
+	if !columns[0].IsPrimaryKey || !columns[0].IsAutoIncrement {
+		t.Errorf("expected id to be an auto incrementing primary key: %+v", columns[0])
+	}
+	if !columns[1].IsUnique || columns[1].Nullable {
+		t.Errorf("expected email to be unique and required: %+v", columns[1])
+	}
+	if columns[3].Default != "0" {
+		t.Errorf("expected votes default 0, got %v", columns[3].Default)
+	}
+}
+
+func TestSQLite_ReadWriteSplitting(t *testing.T) {
+	dir := t.TempDir()
+	manager := database.New().AddConnection("split", map[string]interface{}{
+		"driver": "sqlite",
+		"sticky": true,
+		"read":   map[string]interface{}{"database": filepath.Join(dir, "read.sqlite")},
+		"write":  map[string]interface{}{"database": filepath.Join(dir, "write.sqlite")},
+	})
+	defer manager.Close()
+
+	connection, err := manager.Connection("split")
+	if err != nil {
+		t.Fatalf("connection failed: %v", err)
+	}
+	if connection.ReadConnection() == connection.Connection() {
+		t.Fatal("expected separate read and write pools")
+	}
+
+	if _, err := connection.ReadConnection().Exec("create table items (name text)"); err != nil {
+		t.Fatalf("read schema failed: %v", err)
+	}
+	if count, err := connection.Table("items").Count(); err != nil || count != 0 {
+		t.Fatalf("expected read pool before writes, got %d (%v)", count, err)
+	}
+
+	if _, err := connection.Exec("create table items (name text, extra text)"); err != nil {
+		t.Fatalf("write schema failed: %v", err)
+	}
+	if _, err := connection.Table("items").Insert(map[string]interface{}{"name": "a", "extra": "b"}); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	if count, err := connection.Table("items").Count(); err != nil || count != 1 {
+		t.Fatalf("expected sticky read from write pool, got %d (%v)", count, err)
+	}
+}
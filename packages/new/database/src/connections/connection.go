@@ -0,0 +1,673 @@
+// Package connections implements DatabaseInterface on top of database/sql
+// pools, with optional read/write splitting.
+package connections
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"govel/new/database/grammars"
+	"govel/new/database/query"
+	"govel/new/database/schema"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// ErrNoResult is returned by GetLastInsertID and GetRowsAffected before
+// any statement was executed
+var ErrNoResult = errors.New("database: no statement has been executed")
+
+// Connection is a named database connection.
+//
+// Selects run on the read pool and statements on the write pool. With
+// the "sticky" option, selects switch to the write pool once the
+// connection has written, so reads observe their own writes.
+type Connection struct {
+	// mu guards the fields below it
+	mu sync.RWMutex
+
+	// name is the configured connection name
+	name string
+
+	// config is the connection configuration
+	config map[string]interface{}
+
+	// driver is the GoVel driver name
+	driver string
+
+	// writeDB is the pool used for statements
+	writeDB *sql.DB
+
+	// readDB is the pool used for selects, the write pool without a read section
+	readDB *sql.DB
+
+	// grammar compiles builder queries
+	grammar query.Grammar
+
+	// sticky routes reads to the write pool after a write
+	sticky bool
+
+	// recordsModified is set once a statement has run
+	recordsModified bool
+
+	// lastResult is the result of the last executed statement
+	lastResult sql.Result
+}
+
+// NewConnection opens the pools of a connection from its config
+func NewConnection(name string, config map[string]interface{}) (*Connection, error) {
+	writeConfig := mergeConfig(config, sectionOf(config, "write"))
+	writeDB, err := Open(writeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	readDB := writeDB
+	if read := sectionOf(config, "read"); read != nil {
+		if readDB, err = Open(mergeConfig(config, read)); err != nil {
+			writeDB.Close()
+			return nil, err
+		}
+	}
+
+	connection, err := NewConnectionFromDB(name, config, writeDB, readDB)
+	if err != nil {
+		writeDB.Close()
+		if readDB != writeDB {
+			readDB.Close()
+		}
+		return nil, err
+	}
+	return connection, nil
+}
+
+// NewConnectionFromDB creates a connection over existing pools. A nil
+// read pool uses the write pool for selects.
+func NewConnectionFromDB(name string, config map[string]interface{}, writeDB *sql.DB, readDB *sql.DB) (*Connection, error) {
+	driver := NormalizeDriver(toString(config["driver"]))
+	grammar, err := grammars.New(driver, toString(config["prefix"]))
+	if err != nil {
+		return nil, err
+	}
+
+	if readDB == nil {
+		readDB = writeDB
+	}
+
+	return &Connection{
+		name:    name,
+		config:  config,
+		driver:  driver,
+		writeDB: writeDB,
+		readDB:  readDB,
+		grammar: grammar,
+		sticky:  toBool(config["sticky"]),
+	}, nil
+}
+
+// GetName returns the connection name
+func (c *Connection) GetName() string {
+	return c.name
+}
+
+// String describes the connection for logs
+func (c *Connection) String() string {
+	return fmt.Sprintf("%s (%s)", c.name, c.driver)
+}
+
+// Grammar returns the grammar of the connection
+func (c *Connection) Grammar() query.Grammar {
+	return c.grammar
+}
+
+// Connection returns the write pool
+func (c *Connection) Connection() *sql.DB {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.writeDB
+}
+
+// DB returns the write pool
+func (c *Connection) DB() *sql.DB {
+	return c.Connection()
+}
+
+// ReadConnection returns the pool used for selects
+func (c *Connection) ReadConnection() *sql.DB {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.readPool()
+}
+
+// SetConnection replaces both pools with the given pool
+func (c *Connection) SetConnection(conn *sql.DB) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeDB = conn
+	c.readDB = conn
+}
+
+// Close closes the pools of the connection
+func (c *Connection) Close() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	err := c.writeDB.Close()
+	if c.readDB != c.writeDB {
+		err = errors.Join(err, c.readDB.Close())
+	}
+	return err
+}
+
+// Ping verifies the database connection is alive
+func (c *Connection) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+// PingContext verifies the write and read pools are alive
+func (c *Connection) PingContext(ctx context.Context) error {
+	c.mu.RLock()
+	writeDB, readDB := c.writeDB, c.readDB
+	c.mu.RUnlock()
+
+	if err := writeDB.PingContext(ctx); err != nil {
+		return err
+	}
+	if readDB != writeDB {
+		return readDB.PingContext(ctx)
+	}
+	return nil
+}
+
+// DatabaseName returns the configured database name
+func (c *Connection) DatabaseName() string {
+	return toString(c.config["database"])
+}
+
+// DriverName returns the GoVel driver name: sqlite, mysql, mariadb or pgsql
+func (c *Connection) DriverName() string {
+	return c.driver
+}
+
+// Exec executes a statement on the write pool
+func (c *Connection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext executes a statement on the write pool with context
+func (c *Connection) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := c.Connection().ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.recordsModified = true
+	c.lastResult = result
+	c.mu.Unlock()
+	return result, nil
+}
+
+// Query executes a select on the read pool
+func (c *Connection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a select on the read pool with context
+func (c *Connection) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.ReadConnection().QueryContext(ctx, query, args...)
+}
+
+// QueryRow executes a select returning at most one row on the read pool
+func (c *Connection) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes a select returning at most one row on the read pool with context
+func (c *Connection) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.ReadConnection().QueryRowContext(ctx, query, args...)
+}
+
+// WriteQueryContext executes a query on the write pool, for selects that
+// must observe recent writes and statements with RETURNING clauses
+func (c *Connection) WriteQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := c.Connection().QueryContext(ctx, query, args...)
+	if err == nil {
+		c.markModified()
+	}
+	return rows, err
+}
+
+// WriteQueryRowContext executes a single row query on the write pool
+func (c *Connection) WriteQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.markModified()
+	return c.Connection().QueryRowContext(ctx, query, args...)
+}
+
+// Prepare creates a prepared statement on the write pool
+func (c *Connection) Prepare(query string) (*sql.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext creates a prepared statement on the write pool with context
+func (c *Connection) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.Connection().PrepareContext(ctx, query)
+}
+
+// Begin starts a new transaction on the write pool
+func (c *Connection) Begin() (*sql.Tx, error) {
+	return c.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a new transaction on the write pool with options
+func (c *Connection) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return c.Connection().BeginTx(ctx, opts)
+}
+
+// Transaction executes a function within a transaction
+func (c *Connection) Transaction(fn func(*sql.Tx) error) error {
+	return c.TransactionContext(context.Background(), nil, fn)
+}
+
+// TransactionContext executes a function within a transaction, committing
+// when it returns nil and rolling back on errors and panics
+func (c *Connection) TransactionContext(ctx context.Context, opts *sql.TxOptions, fn func(*sql.Tx) error) (err error) {
+	tx, err := c.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	c.markModified()
+	return nil
+}
+
+// Table returns a new query builder for the table
+func (c *Connection) Table(name string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().From(name)
+}
+
+// TableTx returns a new query builder for the table running inside the transaction
+func (c *Connection) TableTx(tx *sql.Tx, name string) databaseInterfaces.QueryBuilderInterface {
+	return query.NewBuilder(&txExecutor{tx: tx}, c.grammar).From(name)
+}
+
+// Builder returns a new query builder without a table
+func (c *Connection) Builder() *query.Builder {
+	return query.NewBuilder(c, c.grammar)
+}
+
+// Schema returns a schema builder running on the write pool
+func (c *Connection) Schema() *schema.Builder {
+	// The grammar was created for a supported driver, so this cannot fail
+	builder, _ := schema.NewBuilder(c.DB(), c.grammar)
+	return builder
+}
+
+// Select starts a SELECT query
+func (c *Connection) Select(columns ...string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Select(columns...)
+}
+
+// From starts a query on the table
+func (c *Connection) From(table string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().From(table)
+}
+
+// Where starts a query with a WHERE condition
+func (c *Connection) Where(column string, operator string, value interface{}) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Where(column, operator, value)
+}
+
+// WhereIn starts a query with a WHERE IN condition
+func (c *Connection) WhereIn(column string, values []interface{}) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().WhereIn(column, values)
+}
+
+// Join starts a query with an INNER JOIN
+func (c *Connection) Join(table string, first string, operator string, second string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Join(table, first, operator, second)
+}
+
+// LeftJoin starts a query with a LEFT JOIN
+func (c *Connection) LeftJoin(table string, first string, operator string, second string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().LeftJoin(table, first, operator, second)
+}
+
+// OrderBy starts a query with an ORDER BY clause
+func (c *Connection) OrderBy(column string, direction string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().OrderBy(column, direction)
+}
+
+// GroupBy starts a query with a GROUP BY clause
+func (c *Connection) GroupBy(columns ...string) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().GroupBy(columns...)
+}
+
+// Having starts a query with a HAVING clause
+func (c *Connection) Having(column string, operator string, value interface{}) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Having(column, operator, value)
+}
+
+// Limit starts a query with a LIMIT clause
+func (c *Connection) Limit(count int) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Limit(count)
+}
+
+// Offset starts a query with an OFFSET clause
+func (c *Connection) Offset(count int) databaseInterfaces.QueryBuilderInterface {
+	return c.Builder().Offset(count)
+}
+
+// Insert inserts a new record
+func (c *Connection) Insert(table string, data map[string]interface{}) (sql.Result, error) {
+	return c.InsertContext(context.Background(), table, data)
+}
+
+// InsertContext inserts a new record with context
+func (c *Connection) InsertContext(ctx context.Context, table string, data map[string]interface{}) (sql.Result, error) {
+	return c.Table(table).InsertContext(ctx, data)
+}
+
+// Update updates the records matching all where columns
+func (c *Connection) Update(table string, data map[string]interface{}, where map[string]interface{}) (sql.Result, error) {
+	return c.UpdateContext(context.Background(), table, data, where)
+}
+
+// UpdateContext updates the records matching all where columns with context
+func (c *Connection) UpdateContext(ctx context.Context, table string, data map[string]interface{}, where map[string]interface{}) (sql.Result, error) {
+	return applyWhere(c.Table(table), where).UpdateContext(ctx, data)
+}
+
+// Delete deletes the records matching all where columns
+func (c *Connection) Delete(table string, where map[string]interface{}) (sql.Result, error) {
+	return c.DeleteContext(context.Background(), table, where)
+}
+
+// DeleteContext deletes the records matching all where columns with context
+func (c *Connection) DeleteContext(ctx context.Context, table string, where map[string]interface{}) (sql.Result, error) {
+	return applyWhere(c.Table(table), where).DeleteContext(ctx)
+}
+
+// TableExists checks if a table exists
+func (c *Connection) TableExists(name string) (bool, error) {
+	return c.TableExistsContext(context.Background(), name)
+}
+
+// TableExistsContext checks if a table exists with context
+func (c *Connection) TableExistsContext(ctx context.Context, name string) (bool, error) {
+	query, bindings := c.grammar.CompileTableExists(name)
+
+	var count int64
+	if err := c.Connection().QueryRowContext(ctx, query, bindings...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetTableNames returns all table names in the database
+func (c *Connection) GetTableNames() ([]string, error) {
+	return c.GetTableNamesContext(context.Background())
+}
+
+// GetTableNamesContext returns all table names with context
+func (c *Connection) GetTableNamesContext(ctx context.Context) ([]string, error) {
+	rows, err := c.Connection().QueryContext(ctx, c.grammar.CompileTables())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// GetColumns returns column information for a table
+func (c *Connection) GetColumns(table string) ([]databaseInterfaces.ColumnInfo, error) {
+	return c.GetColumnsContext(context.Background(), table)
+}
+
+// GetColumnsContext returns column information for a table with context
+func (c *Connection) GetColumnsContext(ctx context.Context, table string) ([]databaseInterfaces.ColumnInfo, error) {
+	query, bindings := c.grammar.CompileColumns(table)
+	rows, err := c.Connection().QueryContext(ctx, query, bindings...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []databaseInterfaces.ColumnInfo
+	for rows.Next() {
+		var (
+			column       databaseInterfaces.ColumnInfo
+			defaultValue sql.NullString
+			maxLength    sql.NullInt64
+			precision    sql.NullInt64
+			scale        sql.NullInt64
+		)
+		err := rows.Scan(&column.Name, &column.Type, &column.Nullable, &defaultValue,
+			&maxLength, &precision, &scale, &column.IsPrimaryKey, &column.IsUnique, &column.IsAutoIncrement)
+		if err != nil {
+			return nil, err
+		}
+
+		if defaultValue.Valid {
+			column.Default = defaultValue.String
+		}
+		column.MaxLength = int(maxLength.Int64)
+		column.Precision = int(precision.Int64)
+		column.Scale = int(scale.Int64)
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
+
+// Quote quotes an identifier (table name, column name, etc.)
+func (c *Connection) Quote(identifier string) string {
+	return c.grammar.Wrap(identifier)
+}
+
+// QuoteValue quotes a value for safe inclusion in SQL
+func (c *Connection) QuoteValue(value interface{}) string {
+	return c.grammar.QuoteValue(value)
+}
+
+// Escape escapes special characters in a string
+func (c *Connection) Escape(value string) string {
+	return c.grammar.Escape(value)
+}
+
+// GetLastInsertID returns the last insert ID of the last statement
+func (c *Connection) GetLastInsertID() (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lastResult == nil {
+		return 0, ErrNoResult
+	}
+	return c.lastResult.LastInsertId()
+}
+
+// GetRowsAffected returns the rows affected by the last statement
+func (c *Connection) GetRowsAffected() (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lastResult == nil {
+		return 0, ErrNoResult
+	}
+	return c.lastResult.RowsAffected()
+}
+
+// Stats returns statistics of the write pool
+func (c *Connection) Stats() sql.DBStats {
+	return c.Connection().Stats()
+}
+
+// SetMaxOpenConns sets the maximum number of open connections of both pools
+func (c *Connection) SetMaxOpenConns(n int) {
+	for _, db := range c.pools() {
+		db.SetMaxOpenConns(n)
+	}
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections of both pools
+func (c *Connection) SetMaxIdleConns(n int) {
+	for _, db := range c.pools() {
+		db.SetMaxIdleConns(n)
+	}
+}
+
+// SetConnMaxLifetime sets the maximum connection lifetime of both pools.
+// It accepts a time.Duration or a number of seconds.
+func (c *Connection) SetConnMaxLifetime(d interface{}) {
+	var lifetime time.Duration
+	switch typed := d.(type) {
+	case time.Duration:
+		lifetime = typed
+	default:
+		lifetime = time.Duration(toInt(typed)) * time.Second
+	}
+
+	for _, db := range c.pools() {
+		db.SetConnMaxLifetime(lifetime)
+	}
+}
+
+// GetConfig returns the connection configuration
+func (c *Connection) GetConfig() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config
+}
+
+// SetConfig sets the connection configuration. Pools are not reopened.
+func (c *Connection) SetConfig(config map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = config
+	c.sticky = toBool(config["sticky"])
+}
+
+// GetConnectionInfo returns connection information
+func (c *Connection) GetConnectionInfo() databaseInterfaces.ConnectionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	config := mergeConfig(c.config, sectionOf(c.config, "write"))
+	stats := c.writeDB.Stats()
+	return databaseInterfaces.ConnectionInfo{
+		Driver:          c.driver,
+		Host:            toString(config["host"]),
+		Port:            toInt(config["port"]),
+		Database:        toString(config["database"]),
+		Username:        toString(config["username"]),
+		Charset:         toString(config["charset"]),
+		Collation:       toString(config["collation"]),
+		Timezone:        toString(config["timezone"]),
+		MaxConnections:  stats.MaxOpenConnections,
+		IdleConnections: stats.Idle,
+	}
+}
+
+// readPool returns the pool for selects, the caller must hold mu
+func (c *Connection) readPool() *sql.DB {
+	if c.sticky && c.recordsModified {
+		return c.writeDB
+	}
+	return c.readDB
+}
+
+// markModified records that the connection has written
+func (c *Connection) markModified() {
+	c.mu.Lock()
+	c.recordsModified = true
+	c.mu.Unlock()
+}
+
+// pools returns the distinct pools of the connection
+func (c *Connection) pools() []*sql.DB {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.readDB == c.writeDB {
+		return []*sql.DB{c.writeDB}
+	}
+	return []*sql.DB{c.writeDB, c.readDB}
+}
+
+// sectionOf returns the read or write section of a config
+func sectionOf(config map[string]interface{}, name string) map[string]interface{} {
+	section, _ := config[name].(map[string]interface{})
+	return section
+}
+
+// applyWhere adds equality conditions for the where map in a stable order
+func applyWhere(builder databaseInterfaces.QueryBuilderInterface, where map[string]interface{}) databaseInterfaces.QueryBuilderInterface {
+	columns := make([]string, 0, len(where))
+	for column := range where {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		builder = builder.Where(column, "=", where[column])
+	}
+	return builder
+}
+
+// txExecutor runs builder statements inside a transaction
+type txExecutor struct {
+	tx *sql.Tx
+}
+
+func (e *txExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return e.tx.ExecContext(ctx, query, args...)
+}
+
+func (e *txExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return e.tx.QueryContext(ctx, query, args...)
+}
+
+func (e *txExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return e.tx.QueryRowContext(ctx, query, args...)
+}
+
+func (e *txExecutor) WriteQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return e.tx.QueryContext(ctx, query, args...)
+}
+
+func (e *txExecutor) WriteQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return e.tx.QueryRowContext(ctx, query, args...)
+}
+
+// Compile-time interface compliance checks
+var (
+	_ databaseInterfaces.DatabaseInterface = (*Connection)(nil)
+	_ query.Executor                       = (*Connection)(nil)
+	_ query.Executor                       = (*txExecutor)(nil)
+)
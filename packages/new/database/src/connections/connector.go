@@ -0,0 +1,362 @@
+package connections
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sqlDriverCandidates lists the database/sql driver names tried for every
+// GoVel driver, in order of preference
+var sqlDriverCandidates = map[string][]string{
+	"sqlite":  {"sqlite3", "sqlite"},
+	"mysql":   {"mysql"},
+	"mariadb": {"mysql"},
+	"pgsql":   {"pgx", "postgres"},
+}
+
+// NormalizeDriver maps driver aliases to sqlite, mysql, mariadb or pgsql
+func NormalizeDriver(driver string) string {
+	switch strings.ToLower(driver) {
+	case "sqlite3":
+		return "sqlite"
+	case "postgres", "postgresql":
+		return "pgsql"
+	default:
+		return strings.ToLower(driver)
+	}
+}
+
+// SQLDriverName returns the registered database/sql driver for the
+// connection config. The "sql_driver" option selects one explicitly,
+// otherwise the first registered candidate for the driver is used.
+func SQLDriverName(config map[string]interface{}) (string, error) {
+	if name := toString(config["sql_driver"]); name != "" {
+		return name, nil
+	}
+
+	driver := NormalizeDriver(toString(config["driver"]))
+	candidates, ok := sqlDriverCandidates[driver]
+	if !ok {
+		return "", fmt.Errorf("database: unsupported driver %q", driver)
+	}
+
+	registered := map[string]bool{}
+	for _, name := range sql.Drivers() {
+		registered[name] = true
+	}
+	for _, name := range candidates {
+		if registered[name] {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("database: no database/sql driver registered for %q, import one providing %s",
+		driver, strings.Join(candidates, " or "))
+}
+
+// DataSourceName builds the DSN of the connection config. A non-empty
+// "url" option is used verbatim.
+func DataSourceName(config map[string]interface{}) (string, error) {
+	if dsn := toString(config["url"]); dsn != "" {
+		return dsn, nil
+	}
+
+	switch NormalizeDriver(toString(config["driver"])) {
+	case "sqlite":
+		database := toString(config["database"])
+		if database == "" {
+			return "", fmt.Errorf("database: sqlite connection requires a database path")
+		}
+		return database, nil
+	case "mysql", "mariadb":
+		return mysqlDSN(config), nil
+	case "pgsql":
+		return postgresDSN(config), nil
+	default:
+		return "", fmt.Errorf("database: unsupported driver %q", toString(config["driver"]))
+	}
+}
+
+// mysqlDSN builds a go-sql-driver/mysql DSN
+func mysqlDSN(config map[string]interface{}) string {
+	address := "tcp(" + toString(config["host"]) + ":" + toString(config["port"]) + ")"
+	if socket := toString(config["unix_socket"]); socket != "" {
+		address = "unix(" + socket + ")"
+	}
+
+	credentials := toString(config["username"])
+	if password := toString(config["password"]); password != "" {
+		credentials += ":" + password
+	}
+
+	params := url.Values{}
+	params.Set("parseTime", "true")
+	if charset := toString(config["charset"]); charset != "" {
+		params.Set("charset", charset)
+	}
+	if collation := toString(config["collation"]); collation != "" {
+		params.Set("collation", collation)
+	}
+	if options, ok := config["options"].(map[string]interface{}); ok {
+		for key, value := range options {
+			params.Set(key, toString(value))
+		}
+	}
+
+	return credentials + "@" + address + "/" + toString(config["database"]) + "?" + params.Encode()
+}
+
+// postgresDSN builds a keyword/value DSN understood by lib/pq and pgx
+func postgresDSN(config map[string]interface{}) string {
+	settings := map[string]string{
+		"host":            toString(config["host"]),
+		"port":            toString(config["port"]),
+		"dbname":          toString(config["database"]),
+		"user":            toString(config["username"]),
+		"password":        toString(config["password"]),
+		"sslmode":         toString(config["sslmode"]),
+		"search_path":     toString(config["search_path"]),
+		"client_encoding": toString(config["charset"]),
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key, value := range settings {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := strings.ReplaceAll(settings[key], `\`, `\\`)
+		value = strings.ReplaceAll(value, "'", `\'`)
+		parts = append(parts, key+"='"+value+"'")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Open opens a pool for the connection config and applies pool and
+// SQLite pragma options
+func Open(config map[string]interface{}) (*sql.DB, error) {
+	driverName, err := SQLDriverName(config)
+	if err != nil {
+		return nil, err
+	}
+	dsn, err := DataSourceName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var db *sql.DB
+	if NormalizeDriver(toString(config["driver"])) == "sqlite" {
+		db, err = openSQLite(driverName, dsn, config)
+	} else {
+		db, err = sql.Open(driverName, dsn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database: failed to open connection: %w", err)
+	}
+
+	if value, ok := config["max_open_connections"]; ok {
+		db.SetMaxOpenConns(toInt(value))
+	}
+	if value, ok := config["max_idle_connections"]; ok {
+		db.SetMaxIdleConns(toInt(value))
+	}
+
+	return db, nil
+}
+
+// openSQLite opens a SQLite pool applying the configured pragmas to every
+// new connection. In-memory databases are limited to a single connection
+// so they survive between queries.
+func openSQLite(driverName, dsn string, config map[string]interface{}) (*sql.DB, error) {
+	probe, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	sqlDriver := probe.Driver()
+	probe.Close()
+
+	connector := &pragmaConnector{
+		driver:  sqlDriver,
+		dsn:     dsn,
+		pragmas: sqlitePragmas(config),
+	}
+	if driverContext, ok := sqlDriver.(driver.DriverContext); ok {
+		if connector.connector, err = driverContext.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	db := sql.OpenDB(connector)
+	if dsn == ":memory:" || strings.Contains(dsn, "mode=memory") {
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+	}
+	return db, nil
+}
+
+// sqlitePragmas returns the PRAGMA statements of the config
+func sqlitePragmas(config map[string]interface{}) []string {
+	pragmas := []string{}
+	if value, ok := config["foreign_key_constraints"]; ok && value != nil {
+		if toBool(value) {
+			pragmas = append(pragmas, "PRAGMA foreign_keys = ON")
+		} else {
+			pragmas = append(pragmas, "PRAGMA foreign_keys = OFF")
+		}
+	}
+	if value := toInt(config["busy_timeout"]); value > 0 {
+		pragmas = append(pragmas, "PRAGMA busy_timeout = "+strconv.Itoa(value))
+	}
+	if value := toString(config["journal_mode"]); value != "" {
+		pragmas = append(pragmas, "PRAGMA journal_mode = "+sanitizePragma(value))
+	}
+	if value := toString(config["synchronous"]); value != "" {
+		pragmas = append(pragmas, "PRAGMA synchronous = "+sanitizePragma(value))
+	}
+	return pragmas
+}
+
+// pragmaConnector runs PRAGMA statements on every new driver connection
+type pragmaConnector struct {
+	driver    driver.Driver
+	connector driver.Connector
+	dsn       string
+	pragmas   []string
+}
+
+// Connect opens a driver connection and applies the pragmas
+func (c *pragmaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	var (
+		conn driver.Conn
+		err  error
+	)
+	if c.connector != nil {
+		conn, err = c.connector.Connect(ctx)
+	} else {
+		conn, err = c.driver.Open(c.dsn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pragma := range c.pragmas {
+		if err := execDriver(ctx, conn, pragma); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("database: failed to apply %s: %w", pragma, err)
+		}
+	}
+	return conn, nil
+}
+
+// Driver returns the underlying driver
+func (c *pragmaConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// execDriver executes a statement without arguments on a driver connection
+func execDriver(ctx context.Context, conn driver.Conn, statement string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, statement, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+
+	stmt, err := conn.Prepare(statement)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(nil)
+	return err
+}
+
+// sanitizePragma keeps only letters of a pragma keyword value
+func sanitizePragma(value string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// mergeConfig returns the base config overridden by the read or write
+// section. Host lists are resolved to a random host.
+func mergeConfig(base map[string]interface{}, section map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for key, value := range base {
+		if key != "read" && key != "write" {
+			merged[key] = value
+		}
+	}
+	for key, value := range section {
+		merged[key] = value
+	}
+
+	switch hosts := merged["host"].(type) {
+	case []string:
+		if len(hosts) > 0 {
+			merged["host"] = hosts[rand.Intn(len(hosts))]
+		}
+	case []interface{}:
+		if len(hosts) > 0 {
+			merged["host"] = hosts[rand.Intn(len(hosts))]
+		}
+	}
+	return merged
+}
+
+func toString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func toInt(value interface{}) int {
+	switch typed := value.(type) {
+	case int:
+		return typed
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case string:
+		parsed, _ := strconv.Atoi(typed)
+		return parsed
+	default:
+		return 0
+	}
+}
+
+func toBool(value interface{}) bool {
+	switch typed := value.(type) {
+	case bool:
+		return typed
+	case string:
+		parsed, _ := strconv.ParseBool(typed)
+		return parsed
+	case int:
+		return typed != 0
+	default:
+		return false
+	}
+}
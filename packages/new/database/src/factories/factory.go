@@ -0,0 +1,282 @@
+// Package factories builds and persists models with fake attributes.
+//
+// A Factory wraps a definition returning a model with default values and
+// is refined with states and sequences before making or creating models:
+//
+//	users := factories.NewFactory(func(f *faker.Faker) User {
+//		return User{Name: f.Name(), Email: f.SafeEmail()}
+//	}).Table("users").Connection(connection)
+//
+//	admins, err := users.Count(3).State(func(u *User, f *faker.Faker) {
+//		u.Admin = true
+//	}).Create()
+//
+// Factories are immutable; every method returns a modified copy.
+package factories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"govel/new/database/faker"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// ErrNoPersistence is returned by Create when the factory has neither a
+// table and connection nor a persist function
+var ErrNoPersistence = errors.New("factories: no table, connection or persist function configured")
+
+// Definition returns a model with default attribute values
+type Definition[T any] func(f *faker.Faker) T
+
+// State modifies a made model
+type State[T any] func(model *T, f *faker.Faker)
+
+// Callback runs for every made or created model
+type Callback[T any] func(ctx context.Context, model *T) error
+
+// Factory makes and creates models of type T
+type Factory[T any] struct {
+	// definition returns the default model
+	definition Definition[T]
+
+	// count is the number of models to make
+	count int
+
+	// states are applied to every model in order
+	states []State[T]
+
+	// sequences hold states applied round robin by model index
+	sequences [][]State[T]
+
+	// faker generates the fake attributes
+	faker *faker.Faker
+
+	// table and connection persist models through the query builder
+	table      string
+	connection databaseInterfaces.DatabaseInterface
+
+	// persist overrides the query builder persistence
+	persist Callback[T]
+
+	// afterMaking runs after a model is made
+	afterMaking []Callback[T]
+
+	// beforeCreating runs before a model is persisted, e.g. to create parents
+	beforeCreating []Callback[T]
+
+	// afterCreating runs after a model is persisted, e.g. to create children
+	afterCreating []Callback[T]
+}
+
+// NewFactory creates a factory making a single model from the definition
+func NewFactory[T any](definition Definition[T]) *Factory[T] {
+	return &Factory[T]{definition: definition, count: 1}
+}
+
+// Count sets the number of models to make or create
+func (f *Factory[T]) Count(count int) *Factory[T] {
+	clone := f.clone()
+	clone.count = count
+	return clone
+}
+
+// State applies a state to every model
+func (f *Factory[T]) State(state State[T]) *Factory[T] {
+	clone := f.clone()
+	clone.states = append(clone.states, state)
+	return clone
+}
+
+// Sequence applies the states in turn: the first model gets the first
+// state, the second model the second and so on, wrapping around
+func (f *Factory[T]) Sequence(states ...State[T]) *Factory[T] {
+	if len(states) == 0 {
+		return f
+	}
+	clone := f.clone()
+	clone.sequences = append(clone.sequences, states)
+	return clone
+}
+
+// Faker sets the faker, e.g. a seeded one for deterministic tests
+func (f *Factory[T]) Faker(generator *faker.Faker) *Factory[T] {
+	clone := f.clone()
+	clone.faker = generator
+	return clone
+}
+
+// Table sets the table Create inserts into
+func (f *Factory[T]) Table(table string) *Factory[T] {
+	clone := f.clone()
+	clone.table = table
+	return clone
+}
+
+// Connection sets the connection Create inserts with
+func (f *Factory[T]) Connection(connection databaseInterfaces.DatabaseInterface) *Factory[T] {
+	clone := f.clone()
+	clone.connection = connection
+	// A nil *Connection must not count as a configured connection
+	if value := reflect.ValueOf(connection); value.Kind() == reflect.Pointer && value.IsNil() {
+		clone.connection = nil
+	}
+	return clone
+}
+
+// Persist sets a function persisting models instead of the query builder
+func (f *Factory[T]) Persist(persist Callback[T]) *Factory[T] {
+	clone := f.clone()
+	clone.persist = persist
+	return clone
+}
+
+// AfterMaking adds a callback running after every made model
+func (f *Factory[T]) AfterMaking(callback Callback[T]) *Factory[T] {
+	clone := f.clone()
+	clone.afterMaking = append(clone.afterMaking, callback)
+	return clone
+}
+
+// BeforeCreating adds a callback running before every model is persisted
+func (f *Factory[T]) BeforeCreating(callback Callback[T]) *Factory[T] {
+	clone := f.clone()
+	clone.beforeCreating = append(clone.beforeCreating, callback)
+	return clone
+}
+
+// AfterCreating adds a callback running after every persisted model
+func (f *Factory[T]) AfterCreating(callback Callback[T]) *Factory[T] {
+	clone := f.clone()
+	clone.afterCreating = append(clone.afterCreating, callback)
+	return clone
+}
+
+// Make makes the models without persisting them
+func (f *Factory[T]) Make() []T {
+	models, _ := f.MakeContext(context.Background())
+	return models
+}
+
+// MakeContext makes the models, returning the first AfterMaking error
+func (f *Factory[T]) MakeContext(ctx context.Context) ([]T, error) {
+	models := make([]T, f.count)
+	for idx := range models {
+		model, err := f.makeOne(ctx, idx)
+		if err != nil {
+			return models[:idx], err
+		}
+		models[idx] = model
+	}
+	return models, nil
+}
+
+// MakeOne makes a single model regardless of Count
+func (f *Factory[T]) MakeOne() T {
+	return f.Count(1).Make()[0]
+}
+
+// Create makes and persists the models
+func (f *Factory[T]) Create() ([]T, error) {
+	return f.CreateContext(context.Background())
+}
+
+// CreateContext makes and persists the models
+func (f *Factory[T]) CreateContext(ctx context.Context) ([]T, error) {
+	if f.persist == nil && (f.table == "" || f.connection == nil) {
+		return nil, ErrNoPersistence
+	}
+
+	models := make([]T, 0, f.count)
+	for idx := 0; idx < f.count; idx++ {
+		model, err := f.makeOne(ctx, idx)
+		if err != nil {
+			return models, err
+		}
+		if err := f.createModel(ctx, &model); err != nil {
+			return models, err
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// CreateOne makes and persists a single model regardless of Count
+func (f *Factory[T]) CreateOne() (T, error) {
+	return f.CreateOneContext(context.Background())
+}
+
+// CreateOneContext makes and persists a single model regardless of Count
+func (f *Factory[T]) CreateOneContext(ctx context.Context) (T, error) {
+	models, err := f.Count(1).CreateContext(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return models[0], nil
+}
+
+// makeOne makes the model at the index
+func (f *Factory[T]) makeOne(ctx context.Context, index int) (T, error) {
+	generator := f.fakerOrDefault()
+	model := f.definition(generator)
+	for _, state := range f.states {
+		state(&model, generator)
+	}
+	for _, sequence := range f.sequences {
+		sequence[index%len(sequence)](&model, generator)
+	}
+	for _, callback := range f.afterMaking {
+		if err := callback(ctx, &model); err != nil {
+			return model, err
+		}
+	}
+	return model, nil
+}
+
+// createModel persists a made model with its callbacks
+func (f *Factory[T]) createModel(ctx context.Context, model *T) error {
+	for _, callback := range f.beforeCreating {
+		if err := callback(ctx, model); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if f.persist != nil {
+		err = f.persist(ctx, model)
+	} else {
+		err = insertModel(ctx, f.connection, f.table, model)
+	}
+	if err != nil {
+		return fmt.Errorf("factories: failed to create %T: %w", *model, err)
+	}
+
+	for _, callback := range f.afterCreating {
+		if err := callback(ctx, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakerOrDefault returns the configured faker or the shared one
+func (f *Factory[T]) fakerOrDefault() *faker.Faker {
+	if f.faker != nil {
+		return f.faker
+	}
+	return faker.Default()
+}
+
+// clone copies the factory so fluent calls never modify the receiver
+func (f *Factory[T]) clone() *Factory[T] {
+	clone := *f
+	clone.states = append([]State[T](nil), f.states...)
+	clone.sequences = append([][]State[T](nil), f.sequences...)
+	clone.afterMaking = append([]Callback[T](nil), f.afterMaking...)
+	clone.beforeCreating = append([]Callback[T](nil), f.beforeCreating...)
+	clone.afterCreating = append([]Callback[T](nil), f.afterCreating...)
+	return &clone
+}
@@ -0,0 +1,134 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// insertModel inserts a struct model into the table.
+//
+// Exported fields map to columns named by their `db` tag or the snake case
+// field name; `db:"-"` skips a field. A zero integer "id" column is left
+// to the database and filled with the inserted ID.
+func insertModel(ctx context.Context, connection databaseInterfaces.DatabaseInterface, table string, model interface{}) error {
+	value := reflect.ValueOf(model)
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Map {
+		attributes, ok := value.Interface().(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unsupported map type %T", value.Interface())
+		}
+		_, err := connection.Table(table).InsertContext(ctx, attributes)
+		return err
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported model type %T", model)
+	}
+
+	attributes := make(map[string]interface{})
+	var id reflect.Value
+	for _, field := range columnFields(value.Type()) {
+		fieldValue := value.FieldByIndex(field.index)
+		if field.column == "id" && isInteger(fieldValue.Kind()) {
+			if fieldValue.IsZero() {
+				id = fieldValue
+				continue
+			}
+		}
+		attributes[field.column] = fieldValue.Interface()
+	}
+
+	if !id.IsValid() {
+		_, err := connection.Table(table).InsertContext(ctx, attributes)
+		return err
+	}
+
+	inserted, err := connection.Table(table).InsertGetIDContext(ctx, attributes)
+	if err != nil {
+		return err
+	}
+	if id.CanSet() {
+		switch {
+		case id.CanInt():
+			id.SetInt(inserted)
+		case id.CanUint():
+			id.SetUint(uint64(inserted))
+		}
+	}
+	return nil
+}
+
+// columnField is an exported struct field mapped to a column
+type columnField struct {
+	column string
+	index  []int
+}
+
+// columnFields returns the column mapped fields of a struct type,
+// flattening embedded structs without a tag
+func columnFields(structType reflect.Type) []columnField {
+	var fields []columnField
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			for _, embedded := range columnFields(field.Type) {
+				embedded.index = append([]int{idx}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = snakeCase(field.Name)
+		}
+		fields = append(fields, columnField{column: column, index: []int{idx}})
+	}
+	return fields
+}
+
+// isInteger reports whether the kind is a signed or unsigned integer
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// snakeCase converts a Go field name such as "UserID" to "user_id"
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for idx, char := range runes {
+		if unicode.IsUpper(char) {
+			previousLower := idx > 0 && unicode.IsLower(runes[idx-1])
+			nextLower := idx > 0 && idx+1 < len(runes) && unicode.IsLower(runes[idx+1]) && unicode.IsUpper(runes[idx-1])
+			if previousLower || nextLower {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(char))
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
@@ -0,0 +1,47 @@
+package factories
+
+import (
+	"context"
+
+	"govel/new/database/faker"
+)
+
+// For creates a parent with the parent factory before every created model
+// and links them, like a belongs-to relationship:
+//
+//	posts := factories.For(postFactory, userFactory, func(post *Post, user *User) {
+//		post.UserID = user.ID
+//	})
+func For[T any, P any](factory *Factory[T], parent *Factory[P], link func(model *T, parent *P)) *Factory[T] {
+	return factory.BeforeCreating(func(ctx context.Context, model *T) error {
+		created, err := parent.CreateOneContext(ctx)
+		if err != nil {
+			return err
+		}
+		link(model, &created)
+		return nil
+	})
+}
+
+// ForModel links every made model to an existing parent
+func ForModel[T any, P any](factory *Factory[T], parent *P, link func(model *T, parent *P)) *Factory[T] {
+	return factory.AfterMaking(func(ctx context.Context, model *T) error {
+		link(model, parent)
+		return nil
+	})
+}
+
+// Has creates children with the child factory after every created model
+// and links them, like a has-many relationship:
+//
+//	users := factories.Has(userFactory, postFactory.Count(3), func(post *Post, user *User) {
+//		post.UserID = user.ID
+//	})
+func Has[T any, C any](factory *Factory[T], children *Factory[C], link func(child *C, parent *T)) *Factory[T] {
+	return factory.AfterCreating(func(ctx context.Context, model *T) error {
+		_, err := children.State(func(child *C, _ *faker.Faker) {
+			link(child, model)
+		}).CreateContext(ctx)
+		return err
+	})
+}
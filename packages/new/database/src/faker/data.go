@@ -0,0 +1,60 @@
+package faker
+
+// Word lists used by the generators. They are intentionally small and
+// locale neutral; tests relying on them should seed the faker.
+var (
+	firstNames = []string{
+		"Aaron", "Abigail", "Adam", "Alice", "Amelia", "Andrew", "Anna", "Benjamin",
+		"Charlotte", "Chloe", "Daniel", "David", "Elena", "Eli", "Emily", "Emma",
+		"Ethan", "Grace", "Hannah", "Henry", "Isaac", "Isabella", "Jack", "James",
+		"Julia", "Leo", "Liam", "Lucas", "Maria", "Mason", "Mia", "Noah",
+		"Olivia", "Oscar", "Ruby", "Samuel", "Sofia", "Thomas", "William", "Zoe",
+	}
+
+	lastNames = []string{
+		"Adams", "Allen", "Baker", "Brown", "Campbell", "Carter", "Clark", "Collins",
+		"Davis", "Evans", "Garcia", "Green", "Hall", "Harris", "Hill", "Jackson",
+		"Johnson", "King", "Lee", "Lewis", "Martin", "Miller", "Mitchell", "Moore",
+		"Nelson", "Parker", "Roberts", "Robinson", "Scott", "Smith", "Taylor", "Thomas",
+		"Thompson", "Turner", "Walker", "White", "Williams", "Wilson", "Wright", "Young",
+	}
+
+	companySuffixes = []string{"Inc", "LLC", "Ltd", "Group", "and Sons", "PLC"}
+
+	freeEmailDomains = []string{"gmail.com", "yahoo.com", "hotmail.com", "outlook.com"}
+
+	safeEmailDomains = []string{"example.com", "example.org", "example.net"}
+
+	topLevelDomains = []string{"com", "net", "org", "io", "dev"}
+
+	streetSuffixes = []string{"Street", "Avenue", "Road", "Lane", "Drive", "Court", "Way", "Place"}
+
+	cities = []string{
+		"Springfield", "Riverside", "Fairview", "Franklin", "Greenville", "Bristol",
+		"Clinton", "Georgetown", "Salem", "Madison", "Oakland", "Ashland",
+		"Dover", "Milton", "Newport", "Oxford", "Burlington", "Jackson",
+	}
+
+	states = []string{
+		"Alabama", "Arizona", "California", "Colorado", "Florida", "Georgia",
+		"Illinois", "Maine", "Michigan", "Nevada", "New York", "Ohio",
+		"Oregon", "Texas", "Utah", "Vermont", "Virginia", "Washington",
+	}
+
+	countries = []string{
+		"Argentina", "Australia", "Brazil", "Canada", "Egypt", "France", "Germany",
+		"India", "Italy", "Japan", "Kenya", "Mexico", "Netherlands", "Norway",
+		"Portugal", "Spain", "Sweden", "United Kingdom", "United States",
+	}
+
+	loremWords = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+		"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et",
+		"dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam", "quis",
+		"nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip", "ex", "ea",
+		"commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+		"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint",
+		"occaecat", "cupidatat", "non", "proident", "sunt", "culpa", "qui", "officia",
+		"deserunt", "mollit", "anim", "id", "est", "laborum",
+	}
+)
@@ -0,0 +1,417 @@
+// Package faker generates fake data for model factories and tests.
+//
+// A Faker draws from its own random source, so seeding it makes the
+// generated values deterministic:
+//
+//	f := faker.New(42)
+//	f.Name()  // always the same name for seed 42
+//	f.Email()
+//	f.DateTimeBetween(carbon.Now().SubYear(), carbon.Now())
+package faker
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"govel/support/carbon"
+)
+
+// Faker generates fake data. It is safe for concurrent use.
+type Faker struct {
+	// mu guards rand
+	mu sync.Mutex
+
+	// rand is the random source of every generator
+	rand *rand.Rand
+}
+
+// New creates a faker, seeded with the given seed or the current time
+func New(seed ...int64) *Faker {
+	value := time.Now().UnixNano()
+	if len(seed) > 0 {
+		value = seed[0]
+	}
+	return &Faker{rand: rand.New(rand.NewSource(value))}
+}
+
+// defaultFaker backs Default
+var (
+	defaultFaker   = New()
+	defaultFakerMu sync.RWMutex
+)
+
+// Default returns the shared faker used by factories without their own
+func Default() *Faker {
+	defaultFakerMu.RLock()
+	defer defaultFakerMu.RUnlock()
+	return defaultFaker
+}
+
+// Seed reseeds the shared faker, making factories deterministic
+func Seed(seed int64) {
+	defaultFakerMu.Lock()
+	defer defaultFakerMu.Unlock()
+	defaultFaker = New(seed)
+}
+
+// Seed reseeds the faker
+func (f *Faker) Seed(seed int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rand.Seed(seed)
+}
+
+// IntBetween returns a random integer in [min, max]
+func (f *Faker) IntBetween(min int, max int) int {
+	if max <= min {
+		return min
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return min + f.rand.Intn(max-min+1)
+}
+
+// Int64Between returns a random 64-bit integer in [min, max]
+func (f *Faker) Int64Between(min int64, max int64) int64 {
+	if max <= min {
+		return min
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return min + f.rand.Int63n(max-min+1)
+}
+
+// FloatBetween returns a random float in [min, max) rounded to the decimals
+func (f *Faker) FloatBetween(min float64, max float64, decimals int) float64 {
+	f.mu.Lock()
+	value := min + f.rand.Float64()*(max-min)
+	f.mu.Unlock()
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	return rounded
+}
+
+// Digit returns a random digit
+func (f *Faker) Digit() int {
+	return f.IntBetween(0, 9)
+}
+
+// Bool returns true with the given percentage chance, 50 by default
+func (f *Faker) Bool(chanceOfTrue ...int) bool {
+	chance := 50
+	if len(chanceOfTrue) > 0 {
+		chance = chanceOfTrue[0]
+	}
+	return f.IntBetween(1, 100) <= chance
+}
+
+// Element returns a random element of the values
+func (f *Faker) Element(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[f.IntBetween(0, len(values)-1)]
+}
+
+// Shuffle returns the values in random order
+func (f *Faker) Shuffle(values []string) []string {
+	shuffled := append([]string(nil), values...)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled
+}
+
+// Numerify replaces every "#" in the format with a random digit
+func (f *Faker) Numerify(format string) string {
+	var builder strings.Builder
+	for _, char := range format {
+		if char == '#' {
+			builder.WriteByte(byte('0' + f.Digit()))
+		} else {
+			builder.WriteRune(char)
+		}
+	}
+	return builder.String()
+}
+
+// Lexify replaces every "?" in the format with a random lowercase letter
+func (f *Faker) Lexify(format string) string {
+	var builder strings.Builder
+	for _, char := range format {
+		if char == '?' {
+			builder.WriteByte(byte('a' + f.IntBetween(0, 25)))
+		} else {
+			builder.WriteRune(char)
+		}
+	}
+	return builder.String()
+}
+
+// FirstName returns a random first name
+func (f *Faker) FirstName() string {
+	return f.Element(firstNames)
+}
+
+// LastName returns a random last name
+func (f *Faker) LastName() string {
+	return f.Element(lastNames)
+}
+
+// Name returns a random full name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a random user name such as "emma.clark42"
+func (f *Faker) Username() string {
+	return strings.ToLower(f.FirstName()+"."+f.LastName()) + strconv.Itoa(f.IntBetween(1, 99))
+}
+
+// Email returns a random address at a free email provider
+func (f *Faker) Email() string {
+	return f.Username() + "@" + f.Element(freeEmailDomains)
+}
+
+// SafeEmail returns a random address at a reserved example domain
+func (f *Faker) SafeEmail() string {
+	return f.Username() + "@" + f.Element(safeEmailDomains)
+}
+
+// DomainName returns a random domain name
+func (f *Faker) DomainName() string {
+	return strings.ToLower(f.LastName()) + "." + f.Element(topLevelDomains)
+}
+
+// URL returns a random https URL
+func (f *Faker) URL() string {
+	return "https://www." + f.DomainName() + "/" + f.Word()
+}
+
+// IPv4 returns a random IPv4 address
+func (f *Faker) IPv4() string {
+	return fmt.Sprintf("%d.%d.%d.%d", f.IntBetween(1, 254), f.IntBetween(0, 255), f.IntBetween(0, 255), f.IntBetween(1, 254))
+}
+
+// Password returns a random password of the given length, 12 by default
+func (f *Faker) Password(length ...int) string {
+	size := 12
+	if len(length) > 0 && length[0] > 0 {
+		size = length[0]
+	}
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*"
+	password := make([]byte, size)
+	for idx := range password {
+		password[idx] = alphabet[f.IntBetween(0, len(alphabet)-1)]
+	}
+	return string(password)
+}
+
+// UUID returns a random version 4 UUID drawn from the faker's source
+func (f *Faker) UUID() string {
+	var bytes [16]byte
+	f.mu.Lock()
+	f.rand.Read(bytes[:])
+	f.mu.Unlock()
+	bytes[6] = bytes[6]&0x0f | 0x40
+	bytes[8] = bytes[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:16])
+}
+
+// HexColor returns a random color such as "#1a2b3c"
+func (f *Faker) HexColor() string {
+	return fmt.Sprintf("#%06x", f.IntBetween(0, 0xffffff))
+}
+
+// PhoneNumber returns a random phone number
+func (f *Faker) PhoneNumber() string {
+	return f.Numerify("+1-###-###-####")
+}
+
+// Company returns a random company name
+func (f *Faker) Company() string {
+	return f.LastName() + " " + f.Element(companySuffixes)
+}
+
+// StreetAddress returns a random street address
+func (f *Faker) StreetAddress() string {
+	return strconv.Itoa(f.IntBetween(1, 9999)) + " " + f.LastName() + " " + f.Element(streetSuffixes)
+}
+
+// City returns a random city
+func (f *Faker) City() string {
+	return f.Element(cities)
+}
+
+// State returns a random state
+func (f *Faker) State() string {
+	return f.Element(states)
+}
+
+// Postcode returns a random five digit postcode
+func (f *Faker) Postcode() string {
+	return f.Numerify("#####")
+}
+
+// Country returns a random country
+func (f *Faker) Country() string {
+	return f.Element(countries)
+}
+
+// Address returns a random single line address
+func (f *Faker) Address() string {
+	return f.StreetAddress() + ", " + f.City() + ", " + f.State() + " " + f.Postcode()
+}
+
+// Latitude returns a random latitude
+func (f *Faker) Latitude() float64 {
+	return f.FloatBetween(-90, 90, 6)
+}
+
+// Longitude returns a random longitude
+func (f *Faker) Longitude() float64 {
+	return f.FloatBetween(-180, 180, 6)
+}
+
+// Word returns a random lorem word
+func (f *Faker) Word() string {
+	return f.Element(loremWords)
+}
+
+// Words returns count random lorem words
+func (f *Faker) Words(count int) []string {
+	words := make([]string, count)
+	for idx := range words {
+		words[idx] = f.Word()
+	}
+	return words
+}
+
+// Sentence returns a sentence of about the given number of words, six by default
+func (f *Faker) Sentence(words ...int) string {
+	count := 6
+	if len(words) > 0 && words[0] > 0 {
+		count = words[0]
+	}
+	// Vary the length by up to 40% like Faker's variable sentences
+	count = f.IntBetween(count-count*2/5, count+count*2/5)
+	if count < 1 {
+		count = 1
+	}
+	return capitalize(strings.Join(f.Words(count), " ")) + "."
+}
+
+// Sentences returns count sentences
+func (f *Faker) Sentences(count int) []string {
+	sentences := make([]string, count)
+	for idx := range sentences {
+		sentences[idx] = f.Sentence()
+	}
+	return sentences
+}
+
+// Paragraph returns a paragraph of about the given number of sentences, three by default
+func (f *Faker) Paragraph(sentences ...int) string {
+	count := 3
+	if len(sentences) > 0 && sentences[0] > 0 {
+		count = sentences[0]
+	}
+	return strings.Join(f.Sentences(f.IntBetween(count, count+count/2)), " ")
+}
+
+// Paragraphs returns count paragraphs
+func (f *Faker) Paragraphs(count int) []string {
+	paragraphs := make([]string, count)
+	for idx := range paragraphs {
+		paragraphs[idx] = f.Paragraph()
+	}
+	return paragraphs
+}
+
+// Text returns sentences up to maxChars characters long, 200 by default
+func (f *Faker) Text(maxChars ...int) string {
+	limit := 200
+	if len(maxChars) > 0 && maxChars[0] > 0 {
+		limit = maxChars[0]
+	}
+
+	text := ""
+	for {
+		sentence := f.Sentence()
+		next := sentence
+		if text != "" {
+			next = text + " " + sentence
+		}
+		if len(next) > limit {
+			break
+		}
+		text = next
+	}
+	if text == "" {
+		text = strings.TrimSpace(f.Sentence()[:limit])
+	}
+	return text
+}
+
+// Slug returns a random slug of the given number of words, three by default
+func (f *Faker) Slug(words ...int) string {
+	count := 3
+	if len(words) > 0 && words[0] > 0 {
+		count = words[0]
+	}
+	return strings.Join(f.Words(count), "-")
+}
+
+// DateTimeBetween returns a random moment between start and end
+func (f *Faker) DateTimeBetween(start *carbon.Carbon, end *carbon.Carbon) *carbon.Carbon {
+	from, to := start.Timestamp(), end.Timestamp()
+	if to < from {
+		from, to = to, from
+	}
+	return carbon.CreateFromTimestamp(f.Int64Between(from, to), start.Timezone())
+}
+
+// DateTime returns a random moment between the Unix epoch and now
+func (f *Faker) DateTime() *carbon.Carbon {
+	return f.DateTimeBetween(carbon.EpochValue(), carbon.Now())
+}
+
+// DateTimeThisYear returns a random moment within the last year
+func (f *Faker) DateTimeThisYear() *carbon.Carbon {
+	return f.DateTimeBetween(carbon.Now().SubYear(), carbon.Now())
+}
+
+// PastDate returns a random moment in the last given number of days, 30 by default
+func (f *Faker) PastDate(days ...int) *carbon.Carbon {
+	return f.DateTimeBetween(carbon.Now().SubDays(daysOrDefault(days)), carbon.Now())
+}
+
+// FutureDate returns a random moment in the next given number of days, 30 by default
+func (f *Faker) FutureDate(days ...int) *carbon.Carbon {
+	return f.DateTimeBetween(carbon.Now(), carbon.Now().AddDays(daysOrDefault(days)))
+}
+
+// Date returns a random date between the Unix epoch and today as "2006-01-02"
+func (f *Faker) Date() string {
+	return f.DateTime().ToDateString()
+}
+
+// daysOrDefault returns the first value or 30
+func daysOrDefault(days []int) int {
+	if len(days) > 0 && days[0] > 0 {
+		return days[0]
+	}
+	return 30
+}
+
+// capitalize upper-cases the first letter
+func capitalize(value string) string {
+	for idx, char := range value {
+		return string(unicode.ToUpper(char)) + value[idx+len(string(char)):]
+	}
+	return value
+}
@@ -0,0 +1,188 @@
+package grammars
+
+import (
+	"strconv"
+	"strings"
+
+	"govel/new/database/query"
+)
+
+// compiler accumulates bindings while a single statement is compiled, so
+// numbered placeholders always match the binding order
+type compiler struct {
+	grammar  *Grammar
+	bindings []interface{}
+}
+
+func (g *Grammar) newCompiler() *compiler {
+	return &compiler{grammar: g}
+}
+
+// parameter binds the value and returns its placeholder. Expressions are
+// inlined instead of bound.
+func (c *compiler) parameter(value interface{}) string {
+	if expression, ok := value.(query.Expression); ok {
+		return expression.Value
+	}
+	c.bindings = append(c.bindings, value)
+	return c.grammar.Parameter(len(c.bindings))
+}
+
+// parameterize binds a list of values
+func (c *compiler) parameterize(values []interface{}) string {
+	placeholders := make([]string, len(values))
+	for idx, value := range values {
+		placeholders[idx] = c.parameter(value)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// compileSelect compiles a SELECT statement
+func (c *compiler) compileSelect(components *query.Components) string {
+	g := c.grammar
+
+	columns := "*"
+	if len(components.Columns) > 0 {
+		wrapped := make([]string, len(components.Columns))
+		for idx, column := range components.Columns {
+			wrapped[idx] = g.wrapColumn(column)
+		}
+		columns = strings.Join(wrapped, ", ")
+	}
+
+	sql := "select "
+	if components.Distinct {
+		sql += "distinct "
+	}
+	sql += columns + " from " + g.WrapTable(components.Table)
+
+	for _, join := range components.Joins {
+		operator, second := join.Operator, join.Second
+		if !g.IsOperator(operator) {
+			// Components built by hand get the Builder fallback as well
+			operator, second = "=", operator
+		}
+		sql += " " + join.Type + " join " + g.WrapTable(join.Table) +
+			" on " + g.Wrap(join.First) + " " + operator + " " + g.Wrap(second)
+	}
+
+	if wheres := c.compileWheres(components.Wheres, "where"); wheres != "" {
+		sql += " " + wheres
+	}
+	if len(components.Groups) > 0 {
+		sql += " group by " + g.columnize(components.Groups)
+	}
+	if havings := c.compileWheres(components.Havings, "having"); havings != "" {
+		sql += " " + havings
+	}
+	if orders := g.compileOrders(components.Orders); orders != "" {
+		sql += " " + orders
+	}
+
+	if components.Limit != query.NoLimit {
+		sql += " limit " + strconv.Itoa(components.Limit)
+	} else if components.Offset > 0 && g.offsetWithoutLimit != "" {
+		sql += " limit " + g.offsetWithoutLimit
+	}
+	if components.Offset > 0 {
+		sql += " offset " + strconv.Itoa(components.Offset)
+	}
+
+	return sql
+}
+
+// compileInsert compiles an INSERT, taking the columns from the first row
+func (c *compiler) compileInsert(components *query.Components, rows []map[string]interface{}) string {
+	g := c.grammar
+	columns := query.SortedColumns(rows[0])
+
+	values := make([]string, len(rows))
+	for idx, row := range rows {
+		placeholders := make([]string, len(columns))
+		for position, column := range columns {
+			placeholders[position] = c.parameter(row[column])
+		}
+		values[idx] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	return "insert into " + g.WrapTable(components.Table) +
+		" (" + g.columnize(columns) + ") values " + strings.Join(values, ", ")
+}
+
+// compileWheres compiles WHERE or HAVING conditions behind the keyword
+func (c *compiler) compileWheres(wheres []query.Where, keyword string) string {
+	if len(wheres) == 0 {
+		return ""
+	}
+
+	var sql strings.Builder
+	sql.WriteString(keyword)
+	for idx, where := range wheres {
+		if idx > 0 {
+			sql.WriteString(" " + where.Boolean)
+		}
+		sql.WriteString(" " + c.compileWhere(where))
+	}
+	return sql.String()
+}
+
+// compileWhere compiles a single condition
+func (c *compiler) compileWhere(where query.Where) string {
+	g := c.grammar
+	column := g.Wrap(where.Column)
+
+	switch where.Type {
+	case query.WhereIn:
+		if len(where.Values) == 0 {
+			return "0 = 1"
+		}
+		return column + " in (" + c.parameterize(where.Values) + ")"
+	case query.WhereNotIn:
+		if len(where.Values) == 0 {
+			return "1 = 1"
+		}
+		return column + " not in (" + c.parameterize(where.Values) + ")"
+	case query.WhereNull:
+		return column + " is null"
+	case query.WhereNotNull:
+		return column + " is not null"
+	case query.WhereBetween:
+		return column + " between " + c.parameter(where.Values[0]) + " and " + c.parameter(where.Values[1])
+	case query.WhereRawType:
+		return c.compileRaw(where.SQL, where.Values)
+	default:
+		if !g.IsOperator(where.Operator) {
+			// Components built by hand get the Builder fallback as well
+			return column + " = " + c.parameter(where.Operator)
+		}
+		return column + " " + where.Operator + " " + c.parameter(where.Value)
+	}
+}
+
+// compileRaw replaces "?" placeholders outside of quoted strings with the
+// dialect placeholders of the bindings
+func (c *compiler) compileRaw(sql string, bindings []interface{}) string {
+	var (
+		result strings.Builder
+		quote  rune
+		next   int
+	)
+
+	for _, char := range sql {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"' || char == '`':
+			quote = char
+		case char == '?' && next < len(bindings):
+			result.WriteString(c.parameter(bindings[next]))
+			next++
+			continue
+		}
+		result.WriteRune(char)
+	}
+
+	return result.String()
+}
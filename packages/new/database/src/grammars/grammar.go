@@ -0,0 +1,338 @@
+// Package grammars contains the SQL dialects used by the query builder.
+//
+// The base Grammar compiles standard SQL; SQLiteGrammar, MySQLGrammar
+// and PostgresGrammar configure placeholders and quoting and add
+// upserts and schema queries for their dialect.
+package grammars
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"govel/new/database/query"
+)
+
+// Grammar compiles query components into SQL. Dialects embed it and
+// adjust its options.
+type Grammar struct {
+	// driver is the GoVel driver name
+	driver string
+
+	// prefix is prepended to every table name
+	prefix string
+
+	// openQuote and closeQuote surround identifiers
+	openQuote  string
+	closeQuote string
+
+	// numberedParameters selects $1, $2... instead of ?
+	numberedParameters bool
+
+	// offsetWithoutLimit is the LIMIT emitted when only an offset is set
+	offsetWithoutLimit string
+
+	// limitedWrites enables ORDER BY and LIMIT on UPDATE and DELETE
+	limitedWrites bool
+
+	// returning enables RETURNING clauses
+	returning bool
+
+	// booleanLiterals quotes booleans as true/false instead of 1/0
+	booleanLiterals bool
+
+	// escapeBackslashes doubles backslashes in quoted strings
+	escapeBackslashes bool
+
+	// operators lists the dialect operators accepted besides the standard ones
+	operators []string
+}
+
+// standardOperators are the comparison operators accepted by every dialect
+var standardOperators = []string{
+	"=", "<", ">", "<=", ">=", "<>", "!=", "like", "not like", "&", "|", "<<", ">>",
+}
+
+// New creates the grammar of a GoVel driver: sqlite, mysql, mariadb or pgsql
+func New(driver string, prefix string) (query.Grammar, error) {
+	switch strings.ToLower(driver) {
+	case "sqlite", "sqlite3":
+		return NewSQLiteGrammar(prefix), nil
+	case "mysql", "mariadb":
+		grammar := NewMySQLGrammar(prefix)
+		grammar.driver = strings.ToLower(driver)
+		return grammar, nil
+	case "pgsql", "postgres", "postgresql":
+		return NewPostgresGrammar(prefix), nil
+	default:
+		return nil, fmt.Errorf("database: unsupported driver %q", driver)
+	}
+}
+
+// Driver returns the GoVel driver name of the dialect
+func (g *Grammar) Driver() string {
+	return g.driver
+}
+
+// Prefix returns the table prefix
+func (g *Grammar) Prefix() string {
+	return g.prefix
+}
+
+// Parameter returns the placeholder for the binding at the 1-based index
+func (g *Grammar) Parameter(index int) string {
+	if g.numberedParameters {
+		return "$" + strconv.Itoa(index)
+	}
+	return "?"
+}
+
+// SupportsReturning reports whether RETURNING clauses are available
+func (g *Grammar) SupportsReturning() bool {
+	return g.returning
+}
+
+// IsOperator reports whether the lowercase operator is a comparison
+// operator of the dialect. Anything else must never reach the SQL.
+func (g *Grammar) IsOperator(operator string) bool {
+	for _, operators := range [][]string{standardOperators, g.operators} {
+		for _, candidate := range operators {
+			if candidate == operator {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Wrap quotes an identifier. "*" is left as is and "column as alias" is
+// supported; raw SQL must be passed as a query.Expression instead.
+func (g *Grammar) Wrap(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "*" {
+		return value
+	}
+
+	if column, alias, ok := splitAlias(value); ok {
+		return g.Wrap(column) + " as " + g.wrapSegment(alias)
+	}
+
+	segments := strings.Split(value, ".")
+	for idx, segment := range segments {
+		if idx == len(segments)-2 {
+			segment = g.prefix + segment
+		}
+		segments[idx] = g.wrapSegment(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// WrapTable quotes a table name and applies the table prefix to the
+// table and its alias
+func (g *Grammar) WrapTable(table string) string {
+	table = strings.TrimSpace(table)
+
+	// Aliases are prefixed as well, so qualified columns resolve through Wrap
+	if name, alias, ok := splitAlias(table); ok {
+		return g.WrapTable(name) + " as " + g.wrapSegment(g.prefix+alias)
+	}
+
+	segments := strings.Split(table, ".")
+	segments[len(segments)-1] = g.prefix + segments[len(segments)-1]
+	for idx, segment := range segments {
+		segments[idx] = g.wrapSegment(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// CompileSelect compiles a SELECT statement
+func (g *Grammar) CompileSelect(components *query.Components) (string, []interface{}) {
+	c := g.newCompiler()
+	return c.compileSelect(components), c.bindings
+}
+
+// CompileCount compiles a count of the matching rows, wrapping grouped,
+// distinct or limited queries in a subquery
+func (g *Grammar) CompileCount(components *query.Components) (string, []interface{}) {
+	c := g.newCompiler()
+
+	if len(components.Groups) > 0 || components.Distinct || components.Limit != query.NoLimit || components.Offset > 0 {
+		inner := c.compileSelect(components)
+		return "select count(*) as aggregate from (" + inner + ") as " + g.wrapSegment("temp_table"), c.bindings
+	}
+
+	counted := components.Clone()
+	counted.Columns = []interface{}{query.Raw("count(*) as aggregate")}
+	counted.Orders = nil
+	return c.compileSelect(counted), c.bindings
+}
+
+// CompileExists compiles an EXISTS check of the query
+func (g *Grammar) CompileExists(components *query.Components) (string, []interface{}) {
+	c := g.newCompiler()
+	inner := c.compileSelect(components)
+	return "select exists(" + inner + ") as " + g.wrapSegment("exists"), c.bindings
+}
+
+// CompileInsert compiles an INSERT of one or more rows
+func (g *Grammar) CompileInsert(components *query.Components, rows []map[string]interface{}) (string, []interface{}) {
+	c := g.newCompiler()
+	return c.compileInsert(components, rows), c.bindings
+}
+
+// CompileUpsert compiles an insert that updates conflicting rows using
+// ON CONFLICT, as supported by SQLite and PostgreSQL
+func (g *Grammar) CompileUpsert(components *query.Components, rows []map[string]interface{}, uniqueBy []string, update []string) (string, []interface{}) {
+	c := g.newCompiler()
+	sql := c.compileInsert(components, rows)
+
+	sets := make([]string, 0, len(update))
+	for _, column := range update {
+		sets = append(sets, g.Wrap(column)+" = "+g.wrapSegment("excluded")+"."+g.Wrap(column))
+	}
+
+	sql += " on conflict (" + g.columnize(uniqueBy) + ") do "
+	if len(sets) == 0 {
+		return sql + "nothing", c.bindings
+	}
+	return sql + "update set " + strings.Join(sets, ", "), c.bindings
+}
+
+// CompileUpdate compiles an UPDATE of the matching rows
+func (g *Grammar) CompileUpdate(components *query.Components, values map[string]interface{}) (string, []interface{}) {
+	c := g.newCompiler()
+
+	columns := query.SortedColumns(values)
+	sets := make([]string, 0, len(columns))
+	for _, column := range columns {
+		sets = append(sets, g.Wrap(column)+" = "+c.parameter(values[column]))
+	}
+
+	sql := "update " + g.WrapTable(components.Table) + " set " + strings.Join(sets, ", ")
+	if wheres := c.compileWheres(components.Wheres, "where"); wheres != "" {
+		sql += " " + wheres
+	}
+	return sql + g.compileWriteLimits(components), c.bindings
+}
+
+// CompileDelete compiles a DELETE of the matching rows
+func (g *Grammar) CompileDelete(components *query.Components) (string, []interface{}) {
+	c := g.newCompiler()
+
+	sql := "delete from " + g.WrapTable(components.Table)
+	if wheres := c.compileWheres(components.Wheres, "where"); wheres != "" {
+		sql += " " + wheres
+	}
+	return sql + g.compileWriteLimits(components), c.bindings
+}
+
+// CompileReturning compiles a RETURNING clause
+func (g *Grammar) CompileReturning(columns []string) string {
+	return "returning " + g.columnize(columns)
+}
+
+// QuoteValue quotes a value for safe inclusion in SQL
+func (g *Grammar) QuoteValue(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case query.Expression:
+		return typed.Value
+	case bool:
+		if g.booleanLiterals {
+			return strconv.FormatBool(typed)
+		}
+		if typed {
+			return "1"
+		}
+		return "0"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(typed)
+	case []byte:
+		return "'" + g.Escape(string(typed)) + "'"
+	case time.Time:
+		return "'" + typed.Format("2006-01-02 15:04:05") + "'"
+	case string:
+		return "'" + g.Escape(typed) + "'"
+	default:
+		return "'" + g.Escape(fmt.Sprint(typed)) + "'"
+	}
+}
+
+// Escape escapes a string for inclusion in a quoted literal
+func (g *Grammar) Escape(value string) string {
+	if g.escapeBackslashes {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// wrapSegment quotes a single identifier segment
+func (g *Grammar) wrapSegment(segment string) string {
+	if segment == "*" {
+		return segment
+	}
+	return g.openQuote + strings.ReplaceAll(segment, g.closeQuote, g.closeQuote+g.closeQuote) + g.closeQuote
+}
+
+// wrapColumn quotes a selected column, inlining expressions
+func (g *Grammar) wrapColumn(column interface{}) string {
+	switch typed := column.(type) {
+	case query.Expression:
+		return typed.Value
+	case string:
+		return g.Wrap(typed)
+	default:
+		return g.Wrap(fmt.Sprint(typed))
+	}
+}
+
+// columnize wraps and joins a list of columns
+func (g *Grammar) columnize(columns []string) string {
+	wrapped := make([]string, len(columns))
+	for idx, column := range columns {
+		wrapped[idx] = g.Wrap(column)
+	}
+	return strings.Join(wrapped, ", ")
+}
+
+// compileWriteLimits compiles ORDER BY and LIMIT for UPDATE and DELETE on
+// dialects supporting them
+func (g *Grammar) compileWriteLimits(components *query.Components) string {
+	if !g.limitedWrites {
+		return ""
+	}
+
+	sql := ""
+	if orders := g.compileOrders(components.Orders); orders != "" {
+		sql += " " + orders
+	}
+	if components.Limit != query.NoLimit {
+		sql += " limit " + strconv.Itoa(components.Limit)
+	}
+	return sql
+}
+
+// compileOrders compiles the ORDER BY clause
+func (g *Grammar) compileOrders(orders []query.Order) string {
+	if len(orders) == 0 {
+		return ""
+	}
+
+	compiled := make([]string, len(orders))
+	for idx, order := range orders {
+		compiled[idx] = g.Wrap(order.Column) + " " + order.Direction
+	}
+	return "order by " + strings.Join(compiled, ", ")
+}
+
+// splitAlias splits "name as alias", matching "as" case-insensitively
+func splitAlias(value string) (string, string, bool) {
+	lower := strings.ToLower(value)
+	index := strings.LastIndex(lower, " as ")
+	if index < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(value[:index]), strings.TrimSpace(value[index+4:]), true
+}
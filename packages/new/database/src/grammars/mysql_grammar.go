@@ -0,0 +1,71 @@
+package grammars
+
+import (
+	"strings"
+
+	"govel/new/database/query"
+)
+
+// MySQLGrammar compiles SQL for MySQL and MariaDB
+type MySQLGrammar struct {
+	*Grammar
+}
+
+// NewMySQLGrammar creates a new MySQL grammar
+func NewMySQLGrammar(prefix string) *MySQLGrammar {
+	return &MySQLGrammar{Grammar: &Grammar{
+		driver:             "mysql",
+		prefix:             prefix,
+		openQuote:          "`",
+		closeQuote:         "`",
+		offsetWithoutLimit: "18446744073709551615",
+		limitedWrites:      true,
+		escapeBackslashes:  true,
+		operators: []string{
+			"<=>", "^", "like binary", "not like binary", "rlike", "not rlike",
+			"regexp", "not regexp", "sounds like",
+		},
+	}}
+}
+
+// CompileUpsert compiles an insert with ON DUPLICATE KEY UPDATE. MySQL
+// resolves conflicts on any unique index, so uniqueBy is not part of the SQL.
+func (g *MySQLGrammar) CompileUpsert(components *query.Components, rows []map[string]interface{}, uniqueBy []string, update []string) (string, []interface{}) {
+	c := g.newCompiler()
+	sql := c.compileInsert(components, rows)
+
+	if len(update) == 0 {
+		// Updating a unique column to itself turns the insert into a no-op
+		update = uniqueBy[:1]
+	}
+
+	sets := make([]string, 0, len(update))
+	for _, column := range update {
+		sets = append(sets, g.Wrap(column)+" = values("+g.Wrap(column)+")")
+	}
+	return sql + " on duplicate key update " + strings.Join(sets, ", "), c.bindings
+}
+
+// CompileTableExists returns a query counting tables with the given name
+func (g *MySQLGrammar) CompileTableExists(table string) (string, []interface{}) {
+	return "select count(*) from information_schema.tables where table_schema = database() " +
+		"and table_name = ? and table_type = 'BASE TABLE'", []interface{}{g.prefix + table}
+}
+
+// CompileTables returns a query selecting the table names of the current schema
+func (g *MySQLGrammar) CompileTables() string {
+	return "select table_name from information_schema.tables where table_schema = database() " +
+		"and table_type = 'BASE TABLE' order by table_name"
+}
+
+// CompileColumns returns a query describing the columns of the table
+func (g *MySQLGrammar) CompileColumns(table string) (string, []interface{}) {
+	sql := "select column_name, column_type, is_nullable = 'YES', column_default, " +
+		"coalesce(character_maximum_length, 0), coalesce(numeric_precision, 0), coalesce(numeric_scale, 0), " +
+		"column_key = 'PRI', column_key = 'UNI', extra like '%auto_increment%' " +
+		"from information_schema.columns where table_schema = database() and table_name = ? " +
+		"order by ordinal_position"
+	return sql, []interface{}{g.prefix + table}
+}
+
+var _ query.Grammar = (*MySQLGrammar)(nil)
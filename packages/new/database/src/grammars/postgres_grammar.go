@@ -0,0 +1,58 @@
+package grammars
+
+import "govel/new/database/query"
+
+// PostgresGrammar compiles SQL for PostgreSQL
+type PostgresGrammar struct {
+	*Grammar
+}
+
+// NewPostgresGrammar creates a new PostgreSQL grammar
+func NewPostgresGrammar(prefix string) *PostgresGrammar {
+	return &PostgresGrammar{Grammar: &Grammar{
+		driver:             "pgsql",
+		prefix:             prefix,
+		openQuote:          `"`,
+		closeQuote:         `"`,
+		numberedParameters: true,
+		returning:          true,
+		booleanLiterals:    true,
+		operators: []string{
+			"ilike", "not ilike", "~", "~*", "!~", "!~*", "similar to", "not similar to",
+			"~~*", "!~~*", "#", "&&", "@>", "<@", "?", "?|", "?&", "||", "-", "@?", "@@", "#-",
+			"is distinct from", "is not distinct from",
+		},
+	}}
+}
+
+// CompileTableExists returns a query counting tables with the given name
+func (g *PostgresGrammar) CompileTableExists(table string) (string, []interface{}) {
+	return "select count(*) from information_schema.tables where table_schema = current_schema() " +
+		"and table_name = $1 and table_type = 'BASE TABLE'", []interface{}{g.prefix + table}
+}
+
+// CompileTables returns a query selecting the table names of the current schema
+func (g *PostgresGrammar) CompileTables() string {
+	return "select table_name from information_schema.tables where table_schema = current_schema() " +
+		"and table_type = 'BASE TABLE' order by table_name"
+}
+
+// CompileColumns returns a query describing the columns of the table
+func (g *PostgresGrammar) CompileColumns(table string) (string, []interface{}) {
+	sql := "select c.column_name, c.data_type, c.is_nullable = 'YES', c.column_default, " +
+		"coalesce(c.character_maximum_length, 0), coalesce(c.numeric_precision, 0), coalesce(c.numeric_scale, 0), " +
+		"exists (select 1 from information_schema.table_constraints tc " +
+		"join information_schema.key_column_usage k on k.constraint_name = tc.constraint_name and k.table_schema = tc.table_schema " +
+		"where tc.table_schema = c.table_schema and tc.table_name = c.table_name and k.column_name = c.column_name " +
+		"and tc.constraint_type = 'PRIMARY KEY'), " +
+		"exists (select 1 from information_schema.table_constraints tc " +
+		"join information_schema.key_column_usage k on k.constraint_name = tc.constraint_name and k.table_schema = tc.table_schema " +
+		"where tc.table_schema = c.table_schema and tc.table_name = c.table_name and k.column_name = c.column_name " +
+		"and tc.constraint_type = 'UNIQUE'), " +
+		"c.is_identity = 'YES' or coalesce(c.column_default, '') like 'nextval(%' " +
+		"from information_schema.columns c where c.table_schema = current_schema() and c.table_name = $1 " +
+		"order by c.ordinal_position"
+	return sql, []interface{}{g.prefix + table}
+}
+
+var _ query.Grammar = (*PostgresGrammar)(nil)
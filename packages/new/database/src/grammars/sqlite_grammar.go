@@ -0,0 +1,43 @@
+package grammars
+
+import "govel/new/database/query"
+
+// SQLiteGrammar compiles SQL for SQLite 3.35 or later
+type SQLiteGrammar struct {
+	*Grammar
+}
+
+// NewSQLiteGrammar creates a new SQLite grammar
+func NewSQLiteGrammar(prefix string) *SQLiteGrammar {
+	return &SQLiteGrammar{Grammar: &Grammar{
+		driver:             "sqlite",
+		prefix:             prefix,
+		openQuote:          `"`,
+		closeQuote:         `"`,
+		offsetWithoutLimit: "-1",
+		returning:          true,
+		operators:          []string{"glob", "not glob", "regexp", "not regexp"},
+	}}
+}
+
+// CompileTableExists returns a query counting tables with the given name
+func (g *SQLiteGrammar) CompileTableExists(table string) (string, []interface{}) {
+	return "select count(*) from sqlite_master where type = 'table' and name = ?", []interface{}{g.prefix + table}
+}
+
+// CompileTables returns a query selecting the user table names
+func (g *SQLiteGrammar) CompileTables() string {
+	return "select name from sqlite_master where type = 'table' and name not like 'sqlite_%' order by name"
+}
+
+// CompileColumns returns a query describing the columns of the table
+func (g *SQLiteGrammar) CompileColumns(table string) (string, []interface{}) {
+	sql := `select p.name, p.type, p."notnull" = 0, p.dflt_value, 0, 0, 0, p.pk > 0, ` +
+		`exists (select 1 from pragma_index_list(?) as il, pragma_index_info(il.name) as ii ` +
+		`where il."unique" = 1 and il.origin != 'pk' and ii.name = p.name), ` +
+		`p.pk > 0 and lower(p.type) = 'integer' ` +
+		`from pragma_table_info(?) as p order by p.cid`
+	return sql, []interface{}{g.prefix + table, g.prefix + table}
+}
+
+var _ query.Grammar = (*SQLiteGrammar)(nil)
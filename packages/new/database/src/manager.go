@@ -0,0 +1,281 @@
+// Package database provides Laravel-style database connection management
+// for GoVel.
+//
+// Connections are configured under database.connections and resolved by
+// name:
+//
+//	connection, err := manager.Connection("sqlite")
+//	rows, err := connection.Table("users").Where("active", "=", true).Get()
+//
+// Tables are created with the schema builder of a connection and changed
+// over time with registered migrations:
+//
+//	migrator, err := manager.Migrator()
+//	err = migrations.Execute(ctx, migrator, []string{"migrate", "--pretend"}, os.Stdout)
+//
+// Every connection implements DatabaseInterface over database/sql. The
+// application imports the database/sql driver it needs, such as
+// github.com/mattn/go-sqlite3, github.com/go-sql-driver/mysql or
+// github.com/jackc/pgx/v5/stdlib.
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"govel/new/database/connections"
+	"govel/new/database/migrations"
+	"govel/new/database/schema"
+	"govel/new/database/seeders"
+	configInterfaces "govel/types/interfaces/config"
+	containerInterfaces "govel/types/interfaces/container"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// DatabaseManager resolves and caches named database connections.
+//
+// Key features:
+//   - Named connections from database.connections and AddConnection
+//   - Read/write splitting with "read", "write" and "sticky" options
+//   - SQLite, MySQL/MariaDB and PostgreSQL grammars
+type DatabaseManager struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// config provides the database settings, nil for standalone managers
+	config configInterfaces.ConfigInterface
+
+	// configurations holds connections added through AddConnection
+	configurations map[string]map[string]interface{}
+
+	// defaultConnection overrides database.default when set
+	defaultConnection string
+
+	// connections caches resolved connections by name
+	connections map[string]*connections.Connection
+}
+
+// New creates a standalone database manager configured through AddConnection.
+//
+// Example:
+//
+//	manager := database.New().AddConnection("default", map[string]interface{}{
+//		"driver":   "sqlite",
+//		"database": ":memory:",
+//	})
+func New() *DatabaseManager {
+	return &DatabaseManager{
+		configurations: make(map[string]map[string]interface{}),
+		connections:    make(map[string]*connections.Connection),
+	}
+}
+
+// NewDatabaseManager creates a new database manager.
+//
+// The container must provide a "config" binding implementing ConfigInterface.
+func NewDatabaseManager(container containerInterfaces.ContainerInterface) *DatabaseManager {
+	config, err := container.Make("config")
+	if err != nil {
+		panic(fmt.Sprintf("Failed to resolve config from container: %v", err))
+	}
+
+	configInterface, ok := config.(configInterfaces.ConfigInterface)
+	if !ok {
+		panic(fmt.Sprintf("Config service does not implement ConfigInterface, got %T", config))
+	}
+
+	manager := New()
+	manager.config = configInterface
+	return manager
+}
+
+// AddConnection registers a connection configuration, taking precedence
+// over database.connections. The first added connection becomes the
+// default of standalone managers.
+func (m *DatabaseManager) AddConnection(name string, config map[string]interface{}) *DatabaseManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.configurations[name] = config
+	if m.config == nil && m.defaultConnection == "" {
+		m.defaultConnection = name
+	}
+	return m
+}
+
+// Connection returns the named connection, resolving it on first use.
+// If no name is provided, the default connection is used.
+func (m *DatabaseManager) Connection(name ...string) (*connections.Connection, error) {
+	connectionName := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connectionName = name[0]
+	}
+
+	m.mu.RLock()
+	connection, exists := m.connections[connectionName]
+	m.mu.RUnlock()
+	if exists {
+		return connection, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if connection, exists := m.connections[connectionName]; exists {
+		return connection, nil
+	}
+
+	config, err := m.configuration(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err = connections.NewConnection(connectionName, config)
+	if err != nil {
+		return nil, fmt.Errorf("database: failed to connect [%s]: %w", connectionName, err)
+	}
+
+	m.connections[connectionName] = connection
+	return connection, nil
+}
+
+// Database returns the named connection as DatabaseInterface
+func (m *DatabaseManager) Database(name ...string) (databaseInterfaces.DatabaseInterface, error) {
+	connection, err := m.Connection(name...)
+	if err != nil {
+		return nil, err
+	}
+	return connection, nil
+}
+
+// Table returns a query builder for the table on the default connection
+func (m *DatabaseManager) Table(table string) (databaseInterfaces.QueryBuilderInterface, error) {
+	connection, err := m.Connection()
+	if err != nil {
+		return nil, err
+	}
+	return connection.Table(table), nil
+}
+
+// Schema returns a schema builder for the named or default connection
+func (m *DatabaseManager) Schema(name ...string) (*schema.Builder, error) {
+	connection, err := m.Connection(name...)
+	if err != nil {
+		return nil, err
+	}
+	return connection.Schema(), nil
+}
+
+// Migrator returns a migrator for the named or default connection using
+// the default registry and the database.migrations.table bookkeeping table
+func (m *DatabaseManager) Migrator(name ...string) (*migrations.Migrator, error) {
+	connection, err := m.Connection(name...)
+	if err != nil {
+		return nil, err
+	}
+
+	table, environment := migrations.DefaultTable, ""
+	if m.config != nil {
+		table = m.config.GetString("database.migrations.table", migrations.DefaultTable)
+		environment = m.config.GetString("app.env", "production")
+	}
+	return migrations.NewMigrator(connection, migrations.DefaultRegistry, table).SetEnvironment(environment), nil
+}
+
+// Seeder returns a seeder runner for the named or default connection
+// using the default registry
+func (m *DatabaseManager) Seeder(name ...string) (*seeders.Runner, error) {
+	connection, err := m.Connection(name...)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := ""
+	if m.config != nil {
+		environment = m.config.GetString("app.env", "production")
+	}
+	return seeders.NewRunner(connection, seeders.DefaultRegistry).SetEnvironment(environment), nil
+}
+
+// Connections returns the resolved connections
+func (m *DatabaseManager) Connections() map[string]*connections.Connection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	resolved := make(map[string]*connections.Connection, len(m.connections))
+	for name, connection := range m.connections {
+		resolved[name] = connection
+	}
+	return resolved
+}
+
+// Purge closes and forgets the named connections, or all when none are given
+func (m *DatabaseManager) Purge(name ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := name
+	if len(names) == 0 {
+		for connectionName := range m.connections {
+			names = append(names, connectionName)
+		}
+		sort.Strings(names)
+	}
+
+	var errs []error
+	for _, connectionName := range names {
+		if connection, exists := m.connections[connectionName]; exists {
+			if err := connection.Close(); err != nil {
+				errs = append(errs, err)
+			}
+			delete(m.connections, connectionName)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every resolved connection
+func (m *DatabaseManager) Close() error {
+	return m.Purge()
+}
+
+// GetDefaultConnection returns the default connection name
+func (m *DatabaseManager) GetDefaultConnection() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.defaultConnection != "" {
+		return m.defaultConnection
+	}
+	if m.config != nil {
+		return m.config.GetString("database.default", "sqlite")
+	}
+	return "default"
+}
+
+// SetDefaultConnection sets the default connection name
+func (m *DatabaseManager) SetDefaultConnection(name string) *DatabaseManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultConnection = name
+	return m
+}
+
+// configuration returns the config of a connection. The caller must hold mu.
+func (m *DatabaseManager) configuration(name string) (map[string]interface{}, error) {
+	if config, exists := m.configurations[name]; exists {
+		return config, nil
+	}
+
+	if m.config != nil {
+		if value, exists := m.config.Get("database.connections." + name); exists {
+			if config, ok := value.(map[string]interface{}); ok {
+				return config, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("database: connection [%s] not configured", name)
+}
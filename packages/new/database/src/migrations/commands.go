@@ -0,0 +1,280 @@
+package migrations
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Command is a migration console command such as "migrate:rollback"
+type Command struct {
+	// Name is the command name
+	Name string
+
+	// Description is shown in the command listing
+	Description string
+
+	// Run executes the command with its arguments, without the name
+	Run func(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error
+}
+
+// Commands returns the migration commands
+func Commands() []Command {
+	return []Command{
+		{Name: "migrate", Description: "Run the pending migrations", Run: runMigrate},
+		{Name: "migrate:rollback", Description: "Roll back the last batch of migrations", Run: runRollback},
+		{Name: "migrate:reset", Description: "Roll back all migrations", Run: runReset},
+		{Name: "migrate:fresh", Description: "Drop all tables and run every migration", Run: runFresh},
+		{Name: "migrate:status", Description: "Show the status of every migration", Run: runStatus},
+		{Name: "make:migration", Description: "Create a new migration file", Run: runMakeMigration},
+	}
+}
+
+// Execute runs the command named by the first argument, e.g.
+// Execute(ctx, migrator, []string{"migrate:rollback", "--step=2"}, os.Stdout)
+func Execute(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	if len(args) == 0 {
+		return usage(output)
+	}
+	for _, command := range Commands() {
+		if command.Name == args[0] {
+			return command.Run(ctx, migrator, args[1:], output)
+		}
+	}
+	usage(output)
+	return fmt.Errorf("migrations: unknown command %q", args[0])
+}
+
+// usage lists the commands
+func usage(output io.Writer) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Available commands:")
+	for _, command := range Commands() {
+		fmt.Fprintf(writer, "  %s\t%s\n", command.Name, command.Description)
+	}
+	return writer.Flush()
+}
+
+// newFlagSet creates the flag set of a command writing errors to output
+func newFlagSet(name string, output io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	return flags
+}
+
+func runMigrate(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	var options Options
+	var force bool
+	flags := newFlagSet("migrate", output)
+	flags.BoolVar(&options.Pretend, "pretend", false, "print the SQL instead of running it")
+	flags.BoolVar(&options.Step, "step", false, "run every migration in its own batch")
+	flags.BoolVar(&force, "force", false, "run the command in production")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := confirmToProceed("migrate", migrator, options, force); err != nil {
+		return err
+	}
+
+	_, err := migrator.SetOutput(output).Run(ctx, options)
+	return err
+}
+
+func runRollback(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	var options Options
+	var force bool
+	flags := newFlagSet("migrate:rollback", output)
+	flags.BoolVar(&options.Pretend, "pretend", false, "print the SQL instead of running it")
+	flags.IntVar(&options.Steps, "step", 0, "number of migrations to roll back instead of the last batch")
+	flags.BoolVar(&force, "force", false, "run the command in production")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := confirmToProceed("migrate:rollback", migrator, options, force); err != nil {
+		return err
+	}
+
+	_, err := migrator.SetOutput(output).Rollback(ctx, options)
+	return err
+}
+
+func runReset(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	var options Options
+	var force bool
+	flags := newFlagSet("migrate:reset", output)
+	flags.BoolVar(&options.Pretend, "pretend", false, "print the SQL instead of running it")
+	flags.BoolVar(&force, "force", false, "run the command in production")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := confirmToProceed("migrate:reset", migrator, options, force); err != nil {
+		return err
+	}
+
+	_, err := migrator.SetOutput(output).Reset(ctx, options)
+	return err
+}
+
+func runFresh(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	var options Options
+	var force bool
+	flags := newFlagSet("migrate:fresh", output)
+	flags.BoolVar(&options.Pretend, "pretend", false, "print the SQL instead of running it")
+	flags.BoolVar(&options.Step, "step", false, "run every migration in its own batch")
+	flags.BoolVar(&force, "force", false, "run the command in production")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := confirmToProceed("migrate:fresh", migrator, options, force); err != nil {
+		return err
+	}
+
+	_, err := migrator.SetOutput(output).Fresh(ctx, options)
+	return err
+}
+
+// confirmToProceed guards a destructive migration command. Pretended runs
+// are always allowed since they do not touch the database.
+func confirmToProceed(name string, migrator *Migrator, options Options, force bool) error {
+	if options.Pretend {
+		return nil
+	}
+	return ConfirmToProceed(name, migrator.GetEnvironment(), force)
+}
+
+// ConfirmToProceed refuses to run the named destructive command in
+// production unless --force is given, like Laravel's ConfirmableTrait.
+// db:seed shares it with the migration commands.
+func ConfirmToProceed(command string, environment string, force bool) error {
+	if force || environment != "production" {
+		return nil
+	}
+	return fmt.Errorf("%s: refusing to run in production without --force", command)
+}
+
+func runStatus(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	if err := newFlagSet("migrate:status", output).Parse(args); err != nil {
+		return err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		fmt.Fprintln(output, "No migrations found")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Ran?\tBatch\tMigration")
+	for _, status := range statuses {
+		if status.Ran {
+			fmt.Fprintf(writer, "Yes\t%d\t%s\n", status.Batch, status.Migration)
+		} else {
+			fmt.Fprintf(writer, "No\t\t%s\n", status.Migration)
+		}
+	}
+	return writer.Flush()
+}
+
+// migrationNamePattern restricts migration names to snake case
+var migrationNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// createTablePattern and alterTablePattern guess the table from the name
+var (
+	createTablePattern = regexp.MustCompile(`^create_(\w+?)_table$`)
+	alterTablePattern  = regexp.MustCompile(`_(?:to|from|in)_(\w+?)_table$`)
+)
+
+func runMakeMigration(ctx context.Context, migrator *Migrator, args []string, output io.Writer) error {
+	flags := newFlagSet("make:migration", output)
+	path := flags.String("path", filepath.Join("database", "migrations"), "directory of the migration files")
+	create := flags.String("create", "", "table created by the migration")
+	table := flags.String("table", "", "table altered by the migration")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("migrations: make:migration expects a single name, e.g. create_users_table")
+	}
+
+	file, err := MakeMigration(*path, flags.Arg(0), *create, *table, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "Created migration: %s\n", file)
+	return nil
+}
+
+// MakeMigration writes a migration file named after the timestamp and the
+// snake case name into the directory and returns its path. The table is
+// guessed from names like "create_users_table" or "add_votes_to_users_table"
+// unless create or table is given.
+func MakeMigration(directory string, name string, create string, table string, now time.Time) (string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return "", fmt.Errorf("migrations: invalid migration name %q, use snake case", name)
+	}
+
+	if create == "" && table == "" {
+		if match := createTablePattern.FindStringSubmatch(name); match != nil {
+			create = match[1]
+		} else if match := alterTablePattern.FindStringSubmatch(name); match != nil {
+			table = match[1]
+		}
+	}
+
+	fullName := now.Format("2006_01_02_150405") + "_" + name
+	pkg := strings.ReplaceAll(filepath.Base(filepath.Clean(directory)), "-", "_")
+	if pkg == "." || pkg == string(filepath.Separator) {
+		pkg = "migrations"
+	}
+
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return "", err
+	}
+	file := filepath.Join(directory, fullName+".go")
+	if _, err := os.Stat(file); err == nil {
+		return "", fmt.Errorf("migrations: %s already exists", file)
+	}
+	return file, os.WriteFile(file, []byte(renderStub(pkg, fullName, create, table)), 0o644)
+}
+
+// renderStub renders the source of a migration file
+func renderStub(pkg string, name string, create string, table string) string {
+	up, down := "\t\t\treturn nil", "\t\t\treturn nil"
+	switch {
+	case create != "":
+		up = fmt.Sprintf("\t\t\treturn s.Create(%q, func(t *schema.Blueprint) {\n\t\t\t\tt.ID()\n\t\t\t\tt.Timestamps()\n\t\t\t})", create)
+		down = fmt.Sprintf("\t\t\treturn s.DropIfExists(%q)", create)
+	case table != "":
+		up = fmt.Sprintf("\t\t\treturn s.Table(%q, func(t *schema.Blueprint) {\n\t\t\t\t//\n\t\t\t})", table)
+		down = up
+	}
+
+	return fmt.Sprintf(`package %s
+
+import (
+	"govel/new/database/migrations"
+	"govel/new/database/schema"
+)
+
+func init() {
+	migrations.Register(%q, migrations.New(
+		func(s *schema.Builder) error {
+%s
+		},
+		func(s *schema.Builder) error {
+%s
+		},
+	))
+}
+`, pkg, name, up, down)
+}
@@ -0,0 +1,62 @@
+// Package migrations runs versioned schema changes.
+//
+// Migrations are Go values registered under a sortable name, usually from
+// an init function of a generated file:
+//
+//	func init() {
+//		migrations.Register("2024_01_01_000000_create_users_table", migrations.New(
+//			func(s *schema.Builder) error {
+//				return s.Create("users", func(t *schema.Blueprint) {
+//					t.ID()
+//					t.String("email").Unique()
+//					t.Timestamps()
+//				})
+//			},
+//			func(s *schema.Builder) error {
+//				return s.DropIfExists("users")
+//			},
+//		))
+//	}
+//
+// The Migrator records every migration it runs in a bookkeeping table
+// together with a batch number, so a rollback reverts the last batch.
+package migrations
+
+import "govel/new/database/schema"
+
+// Migration is a reversible schema change
+type Migration interface {
+	// Up applies the migration
+	Up(schema *schema.Builder) error
+
+	// Down reverts the migration
+	Down(schema *schema.Builder) error
+}
+
+// funcMigration adapts a pair of functions to Migration
+type funcMigration struct {
+	up   func(schema *schema.Builder) error
+	down func(schema *schema.Builder) error
+}
+
+// New creates a migration from its up and down functions; a nil down
+// function makes the migration irreversible but harmless to roll back
+func New(up func(schema *schema.Builder) error, down func(schema *schema.Builder) error) Migration {
+	return &funcMigration{up: up, down: down}
+}
+
+// Up applies the migration
+func (m *funcMigration) Up(schema *schema.Builder) error {
+	if m.up == nil {
+		return nil
+	}
+	return m.up(schema)
+}
+
+// Down reverts the migration
+func (m *funcMigration) Down(schema *schema.Builder) error {
+	if m.down == nil {
+		return nil
+	}
+	return m.down(schema)
+}
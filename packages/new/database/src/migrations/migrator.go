@@ -0,0 +1,328 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"govel/new/database/connections"
+	"govel/new/database/schema"
+)
+
+// Options controls a migrator run
+type Options struct {
+	// Pretend prints the SQL of the migrations instead of running them
+	Pretend bool
+
+	// Step gives every migration of a run its own batch, so each can be
+	// rolled back individually
+	Step bool
+
+	// Steps rolls back that many migrations instead of the last batch
+	Steps int
+}
+
+// Status describes a registered migration
+type Status struct {
+	Migration string
+	Ran       bool
+	Batch     int
+}
+
+// Migrator runs and reverts registered migrations on a connection
+type Migrator struct {
+	// connection is the migrated connection
+	connection *connections.Connection
+
+	// registry holds the known migrations
+	registry *Registry
+
+	// repository reads and writes the bookkeeping table
+	repository *Repository
+
+	// output receives progress lines and pretended SQL
+	output io.Writer
+
+	// environment is the application environment, destructive commands
+	// require --force in production
+	environment string
+}
+
+// NewMigrator creates a migrator recording its runs in the table, or in
+// "migrations" when the table is empty
+func NewMigrator(connection *connections.Connection, registry *Registry, table string) *Migrator {
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	return &Migrator{
+		connection: connection,
+		registry:   registry,
+		repository: NewRepository(connection, table),
+		output:     io.Discard,
+	}
+}
+
+// SetOutput sets the writer receiving progress lines and pretended SQL
+func (m *Migrator) SetOutput(output io.Writer) *Migrator {
+	if output == nil {
+		output = io.Discard
+	}
+	m.output = output
+	return m
+}
+
+// SetEnvironment sets the application environment, such as "production"
+func (m *Migrator) SetEnvironment(environment string) *Migrator {
+	m.environment = environment
+	return m
+}
+
+// GetEnvironment returns the application environment
+func (m *Migrator) GetEnvironment() string {
+	return m.environment
+}
+
+// GetRepository returns the bookkeeping repository
+func (m *Migrator) GetRepository() *Repository {
+	return m.repository
+}
+
+// GetRegistry returns the migration registry
+func (m *Migrator) GetRegistry() *Registry {
+	return m.registry
+}
+
+// Run runs the pending migrations in a new batch and returns their names
+func (m *Migrator) Run(ctx context.Context, options Options) ([]string, error) {
+	ran, batch, err := m.ranMigrations(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, name := range m.registry.Names() {
+		if _, done := ran[name]; !done {
+			pending = append(pending, name)
+		}
+	}
+	return m.runPending(ctx, pending, batch+1, options)
+}
+
+// Rollback reverts the last batch, or the last options.Steps migrations,
+// and returns the reverted names
+func (m *Migrator) Rollback(ctx context.Context, options Options) ([]string, error) {
+	if exists, err := m.repository.Exists(ctx); err != nil || !exists {
+		return nil, err
+	}
+
+	var records []Record
+	var err error
+	if options.Steps > 0 {
+		records, err = m.repository.GetMigrations(ctx, options.Steps)
+	} else {
+		records, err = m.repository.GetLast(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.rollbackRecords(ctx, records, options)
+}
+
+// Reset reverts every ran migration and returns the reverted names
+func (m *Migrator) Reset(ctx context.Context, options Options) ([]string, error) {
+	if exists, err := m.repository.Exists(ctx); err != nil || !exists {
+		return nil, err
+	}
+
+	records, err := m.repository.GetRan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for left, right := 0, len(records)-1; left < right; left, right = left+1, right-1 {
+		records[left], records[right] = records[right], records[left]
+	}
+	return m.rollbackRecords(ctx, records, options)
+}
+
+// Fresh drops every table of the database and runs all migrations
+func (m *Migrator) Fresh(ctx context.Context, options Options) ([]string, error) {
+	builder := m.schema(ctx, options.Pretend)
+	if err := builder.DropAllTables(); err != nil {
+		return nil, err
+	}
+	if options.Pretend {
+		m.printStatements("drop all tables", builder.Statements())
+		return m.runPending(ctx, m.registry.Names(), 1, options)
+	}
+	fmt.Fprintln(m.output, "Dropped all tables")
+	return m.Run(ctx, options)
+}
+
+// Status returns every registered migration with its batch when it ran
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	ran, _, err := m.ranMigrations(ctx, Options{Pretend: true})
+	if err != nil {
+		return nil, err
+	}
+
+	names := m.registry.Names()
+	statuses := make([]Status, 0, len(names))
+	for _, name := range names {
+		batch, done := ran[name]
+		statuses = append(statuses, Status{Migration: name, Ran: done, Batch: batch})
+	}
+	return statuses, nil
+}
+
+// ranMigrations returns the batch of every ran migration and the last
+// batch number, creating the bookkeeping table unless pretending
+func (m *Migrator) ranMigrations(ctx context.Context, options Options) (map[string]int, int, error) {
+	exists, err := m.repository.Exists(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !exists {
+		if options.Pretend {
+			return map[string]int{}, 0, nil
+		}
+		if err := m.repository.Create(ctx); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	records, err := m.repository.GetRan(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	ran := make(map[string]int, len(records))
+	last := 0
+	for _, record := range records {
+		ran[record.Migration] = record.Batch
+		if record.Batch > last {
+			last = record.Batch
+		}
+	}
+	return ran, last, nil
+}
+
+// runPending runs the named migrations starting at the batch number
+func (m *Migrator) runPending(ctx context.Context, names []string, batch int, options Options) ([]string, error) {
+	if len(names) == 0 {
+		fmt.Fprintln(m.output, "Nothing to migrate")
+		return nil, nil
+	}
+
+	var ran []string
+	for _, name := range names {
+		migration, _ := m.registry.Get(name)
+		if err := m.runMigration(ctx, name, migration, batch, true, options.Pretend); err != nil {
+			return ran, err
+		}
+		ran = append(ran, name)
+		if options.Step {
+			batch++
+		}
+	}
+	return ran, nil
+}
+
+// rollbackRecords reverts the recorded migrations in the given order
+func (m *Migrator) rollbackRecords(ctx context.Context, records []Record, options Options) ([]string, error) {
+	if len(records) == 0 {
+		fmt.Fprintln(m.output, "Nothing to rollback")
+		return nil, nil
+	}
+
+	var reverted []string
+	for _, record := range records {
+		migration, ok := m.registry.Get(record.Migration)
+		if !ok {
+			fmt.Fprintf(m.output, "Migration not found: %s\n", record.Migration)
+			continue
+		}
+		if err := m.runMigration(ctx, record.Migration, migration, record.Batch, false, options.Pretend); err != nil {
+			return reverted, err
+		}
+		reverted = append(reverted, record.Migration)
+	}
+	return reverted, nil
+}
+
+// runMigration applies or reverts a migration and updates the bookkeeping
+// table. On dialects with transactional DDL both happen in one transaction.
+func (m *Migrator) runMigration(ctx context.Context, name string, migration Migration, batch int, up bool, pretend bool) error {
+	method := migration.Down
+	if up {
+		method = migration.Up
+	}
+
+	if pretend {
+		builder := m.schema(ctx, true)
+		if err := method(builder); err != nil {
+			return fmt.Errorf("migrations: %s: %w", name, err)
+		}
+		m.printStatements(name, builder.Statements())
+		return nil
+	}
+
+	verb, done := "Rolling back", "Rolled back"
+	if up {
+		verb, done = "Migrating", "Migrated"
+	}
+	fmt.Fprintf(m.output, "%s: %s\n", verb, name)
+	started := time.Now()
+
+	record := func(tx *sql.Tx) error {
+		if up {
+			return m.repository.Log(ctx, tx, name, batch)
+		}
+		return m.repository.Delete(ctx, tx, name)
+	}
+
+	var err error
+	if m.transactionalDDL() {
+		err = m.connection.TransactionContext(ctx, nil, func(tx *sql.Tx) error {
+			if err := method(m.schema(ctx, false).WithExecutor(tx)); err != nil {
+				return err
+			}
+			return record(tx)
+		})
+	} else if err = method(m.schema(ctx, false)); err == nil {
+		err = record(nil)
+	}
+	if err != nil {
+		return fmt.Errorf("migrations: %s: %w", name, err)
+	}
+
+	fmt.Fprintf(m.output, "%s:  %s (%s)\n", done, name, time.Since(started).Round(time.Microsecond))
+	return nil
+}
+
+// schema returns a schema builder for the connection
+func (m *Migrator) schema(ctx context.Context, pretend bool) *schema.Builder {
+	builder := m.connection.Schema().WithContext(ctx)
+	if pretend {
+		return builder.Pretend()
+	}
+	return builder
+}
+
+// transactionalDDL reports whether schema changes can be rolled back;
+// MySQL commits implicitly on DDL statements
+func (m *Migrator) transactionalDDL() bool {
+	switch m.connection.Grammar().Driver() {
+	case "sqlite", "pgsql":
+		return true
+	default:
+		return false
+	}
+}
+
+// printStatements writes pretended statements prefixed with their origin
+func (m *Migrator) printStatements(name string, statements []string) {
+	for _, statement := range statements {
+		fmt.Fprintf(m.output, "%s: %s\n", name, statement)
+	}
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultRegistry holds the migrations added with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds a migration to the default registry. It panics when the
+// name is registered twice, like database/sql.Register.
+func Register(name string, migration Migration) {
+	if err := DefaultRegistry.Add(name, migration); err != nil {
+		panic(err)
+	}
+}
+
+// Registry holds migrations by name. Names sort in execution order, so
+// they are conventionally prefixed with a timestamp.
+type Registry struct {
+	// mu guards migrations
+	mu sync.RWMutex
+
+	// migrations maps names to migrations
+	migrations map[string]Migration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{migrations: make(map[string]Migration)}
+}
+
+// Add registers a migration under a unique name
+func (r *Registry) Add(name string, migration Migration) error {
+	if name == "" || migration == nil {
+		return fmt.Errorf("migrations: a migration needs a name and a value")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.migrations[name]; exists {
+		return fmt.Errorf("migrations: migration %q is already registered", name)
+	}
+	r.migrations[name] = migration
+	return nil
+}
+
+// Get returns a migration by name
+func (r *Registry) Get(name string) (Migration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	migration, ok := r.migrations[name]
+	return migration, ok
+}
+
+// Names returns the registered names in execution order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.migrations))
+	for name := range r.migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"govel/new/database/connections"
+	"govel/new/database/schema"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// DefaultTable is the bookkeeping table used when none is configured
+const DefaultTable = "migrations"
+
+// Record is a row of the bookkeeping table
+type Record struct {
+	Migration string
+	Batch     int
+}
+
+// Repository reads and writes the bookkeeping table
+type Repository struct {
+	// connection holds the bookkeeping table
+	connection *connections.Connection
+
+	// table is the bookkeeping table name
+	table string
+}
+
+// NewRepository creates a repository for the table on the connection
+func NewRepository(connection *connections.Connection, table string) *Repository {
+	if table == "" {
+		table = DefaultTable
+	}
+	return &Repository{connection: connection, table: table}
+}
+
+// GetTable returns the bookkeeping table name
+func (r *Repository) GetTable() string {
+	return r.table
+}
+
+// Exists reports whether the bookkeeping table exists
+func (r *Repository) Exists(ctx context.Context) (bool, error) {
+	return r.connection.Schema().WithContext(ctx).HasTable(r.table)
+}
+
+// Create creates the bookkeeping table
+func (r *Repository) Create(ctx context.Context) error {
+	return r.connection.Schema().WithContext(ctx).Create(r.table, func(table *schema.Blueprint) {
+		table.Increments("id")
+		table.String("migration")
+		table.Integer("batch")
+	})
+}
+
+// GetRan returns every ran migration ordered by batch and name
+func (r *Repository) GetRan(ctx context.Context) ([]Record, error) {
+	return r.records(ctx, r.query().OrderBy("batch", "asc").OrderBy("migration", "asc"))
+}
+
+// GetLast returns the migrations of the last batch, newest first
+func (r *Repository) GetLast(ctx context.Context) ([]Record, error) {
+	batch, err := r.GetLastBatchNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.records(ctx, r.query().Where("batch", "=", batch).OrderBy("migration", "desc"))
+}
+
+// GetMigrations returns the last ran migrations, newest first
+func (r *Repository) GetMigrations(ctx context.Context, steps int) ([]Record, error) {
+	return r.records(ctx, r.query().Where("batch", ">=", 1).
+		OrderBy("batch", "desc").OrderBy("migration", "desc").Limit(steps))
+}
+
+// GetLastBatchNumber returns the highest batch number, zero before the first run
+func (r *Repository) GetLastBatchNumber(ctx context.Context) (int, error) {
+	var batch int
+	err := r.query().Select("batch").OrderBy("batch", "desc").FirstContext(ctx).Scan(&batch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return batch, err
+}
+
+// Log records a ran migration, inside the transaction when one is given
+func (r *Repository) Log(ctx context.Context, tx *sql.Tx, migration string, batch int) error {
+	_, err := r.queryTx(tx).InsertContext(ctx, map[string]interface{}{
+		"migration": migration,
+		"batch":     batch,
+	})
+	return err
+}
+
+// Delete removes a rolled back migration, inside the transaction when one is given
+func (r *Repository) Delete(ctx context.Context, tx *sql.Tx, migration string) error {
+	_, err := r.queryTx(tx).Where("migration", "=", migration).DeleteContext(ctx)
+	return err
+}
+
+// query returns a builder for the bookkeeping table on the write pool
+func (r *Repository) query() databaseInterfaces.QueryBuilderInterface {
+	return r.connection.Table(r.table).UseWriteConnection()
+}
+
+// queryTx returns a builder for the bookkeeping table inside the transaction
+func (r *Repository) queryTx(tx *sql.Tx) databaseInterfaces.QueryBuilderInterface {
+	if tx == nil {
+		return r.query()
+	}
+	return r.connection.TableTx(tx, r.table)
+}
+
+// records runs a query selecting migration and batch
+func (r *Repository) records(ctx context.Context, builder databaseInterfaces.QueryBuilderInterface) ([]Record, error) {
+	rows, err := builder.Select("migration", "batch").GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.Migration, &record.Batch); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,85 @@
+package orm
+
+import (
+	"strings"
+	"sync"
+)
+
+// Model events, dispatched as "<event>: <table>" such as "creating: users".
+// An error returned for one of the "-ing" events cancels the operation.
+const (
+	EventRetrieved = "retrieved"
+	EventCreating  = "creating"
+	EventCreated   = "created"
+	EventUpdating  = "updating"
+	EventUpdated   = "updated"
+	EventSaving    = "saving"
+	EventSaved     = "saved"
+	EventDeleting  = "deleting"
+	EventDeleted   = "deleted"
+	EventRestoring = "restoring"
+	EventRestored  = "restored"
+)
+
+// Dispatcher receives model events
+type Dispatcher interface {
+	// Dispatch notifies the listeners of the event; the model is a pointer
+	// to the model struct
+	Dispatch(event string, model interface{}) error
+}
+
+// Listener handles a model event
+type Listener func(event string, model interface{}) error
+
+// EventDispatcher is an in-process Dispatcher
+type EventDispatcher struct {
+	// mu guards listeners
+	mu sync.RWMutex
+
+	// listeners maps event patterns to listeners
+	listeners map[string][]Listener
+}
+
+// NewEventDispatcher creates a dispatcher without listeners
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{listeners: make(map[string][]Listener)}
+}
+
+// Listen registers a listener. The pattern is a full event name such as
+// "creating: users", an event for every model such as "creating", or "*".
+func (d *EventDispatcher) Listen(pattern string, listener Listener) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.listeners[pattern] = append(d.listeners[pattern], listener)
+}
+
+// Forget removes the listeners of a pattern
+func (d *EventDispatcher) Forget(pattern string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.listeners, pattern)
+}
+
+// Dispatch calls the listeners of the event, stopping at the first error
+func (d *EventDispatcher) Dispatch(event string, model interface{}) error {
+	name, _, _ := strings.Cut(event, ":")
+
+	d.mu.RLock()
+	var listeners []Listener
+	for _, pattern := range []string{event, name, "*"} {
+		listeners = append(listeners, d.listeners[pattern]...)
+	}
+	d.mu.RUnlock()
+
+	for _, listener := range listeners {
+		if err := listener(event, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventName returns the dispatched name of a model event for a table
+func EventName(event string, table string) string {
+	return event + ": " + table
+}
@@ -0,0 +1,109 @@
+package orm
+
+import (
+	"fmt"
+	"sync"
+
+	databaseInterfaces "govel/types/interfaces/database"
+	ormInterfaces "govel/types/interfaces/orm"
+)
+
+// ConnectionResolver resolves a connection by name, the default for ""
+type ConnectionResolver func(name string) (databaseInterfaces.DatabaseInterface, error)
+
+// Manager provides the connections and the event dispatcher of models
+type Manager struct {
+	// mu guards the fields below
+	mu sync.RWMutex
+
+	// resolver resolves model connections
+	resolver ConnectionResolver
+
+	// dispatcher receives model events
+	dispatcher Dispatcher
+}
+
+// NewManager creates a manager resolving connections with the resolver
+// and dispatching events to a new EventDispatcher
+func NewManager(resolver ConnectionResolver) *Manager {
+	return &Manager{resolver: resolver, dispatcher: NewEventDispatcher()}
+}
+
+// Connection resolves the named connection, or the default one
+func (m *Manager) Connection(name ...string) (databaseInterfaces.DatabaseInterface, error) {
+	m.mu.RLock()
+	resolver := m.resolver
+	m.mu.RUnlock()
+
+	if resolver == nil {
+		return nil, fmt.Errorf("orm: no connection resolver configured")
+	}
+	connectionName := ""
+	if len(name) > 0 {
+		connectionName = name[0]
+	}
+	return resolver(connectionName)
+}
+
+// SetConnectionResolver replaces the connection resolver
+func (m *Manager) SetConnectionResolver(resolver ConnectionResolver) *Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolver = resolver
+	return m
+}
+
+// Dispatcher returns the event dispatcher
+func (m *Manager) Dispatcher() Dispatcher {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.dispatcher
+}
+
+// SetDispatcher replaces the event dispatcher; nil disables model events
+func (m *Manager) SetDispatcher(dispatcher Dispatcher) *Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dispatcher = dispatcher
+	return m
+}
+
+// Listen registers a model event listener when the dispatcher is an
+// EventDispatcher, see EventDispatcher.Listen
+func (m *Manager) Listen(pattern string, listener func(event string, model interface{}) error) {
+	if dispatcher, ok := m.Dispatcher().(*EventDispatcher); ok {
+		dispatcher.Listen(pattern, listener)
+	}
+}
+
+// dispatch sends an event when a dispatcher is configured
+func (m *Manager) dispatch(event string, table string, model interface{}) error {
+	dispatcher := m.Dispatcher()
+	if dispatcher == nil {
+		return nil
+	}
+	return dispatcher.Dispatch(EventName(event, table), model)
+}
+
+var (
+	// defaultManager is used by models without an explicit manager
+	defaultManager   = NewManager(nil)
+	defaultManagerMu sync.RWMutex
+)
+
+// Default returns the manager used by models
+func Default() *Manager {
+	defaultManagerMu.RLock()
+	defer defaultManagerMu.RUnlock()
+	return defaultManager
+}
+
+// SetDefault replaces the manager used by models
+func SetDefault(manager *Manager) {
+	defaultManagerMu.Lock()
+	defer defaultManagerMu.Unlock()
+	defaultManager = manager
+}
+
+// Compile-time interface compliance check
+var _ ormInterfaces.OrmInterface = (*Manager)(nil)
@@ -0,0 +1,333 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"govel/support/str"
+)
+
+// Relation kinds declared with the orm struct tag
+const (
+	RelationHasOne        = "hasOne"
+	RelationHasMany       = "hasMany"
+	RelationBelongsTo     = "belongsTo"
+	RelationBelongsToMany = "belongsToMany"
+)
+
+// Conventional column names
+const (
+	CreatedAtColumn = "created_at"
+	UpdatedAtColumn = "updated_at"
+	DeletedAtColumn = "deleted_at"
+)
+
+// TableNamer overrides the table of a model, which defaults to the
+// plural snake case type name ("BlogPost" uses "blog_posts")
+type TableNamer interface {
+	TableName() string
+}
+
+// ConnectionNamer selects the connection of a model instead of the default
+type ConnectionNamer interface {
+	ConnectionName() string
+}
+
+// field is a struct field mapped to a column
+type field struct {
+	// column is the column name
+	column string
+
+	// index is the reflect field index path, through embedded structs
+	index []int
+
+	// fieldType is the Go type of the field
+	fieldType reflect.Type
+}
+
+// relation is a struct field declaring a relationship
+type relation struct {
+	// name is the field name
+	name string
+
+	// kind is one of the Relation constants
+	kind string
+
+	// index is the reflect field index
+	index []int
+
+	// related is the struct type of the related model
+	related reflect.Type
+
+	// many reports whether the field is a slice
+	many bool
+
+	// foreignKey and localKey join the models. For hasOne/hasMany the
+	// foreign key lives on the related model, for belongsTo on this one.
+	foreignKey string
+	localKey   string
+
+	// pivot options of belongsToMany relations
+	pivotTable      string
+	foreignPivotKey string
+	relatedPivotKey string
+}
+
+// metadata describes how a struct type maps to a table
+type metadata struct {
+	// structType is the model struct type
+	structType reflect.Type
+
+	// table is the table name
+	table string
+
+	// connection is the connection name, empty for the default
+	connection string
+
+	// fields are the mapped columns in declaration order
+	fields []field
+
+	// columns indexes fields by column
+	columns map[string]*field
+
+	// primaryKey is the primary key field
+	primaryKey *field
+
+	// incrementing reports whether the database assigns the primary key
+	incrementing bool
+
+	// relations indexes relations by lower-cased field name
+	relations map[string]*relation
+}
+
+// metadataCache caches metadata per struct type
+var metadataCache sync.Map
+
+// metadataOf returns the cached metadata of a struct type
+func metadataOf(structType reflect.Type) (*metadata, error) {
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if cached, ok := metadataCache.Load(structType); ok {
+		return cached.(*metadata), nil
+	}
+
+	meta, err := buildMetadata(structType)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := metadataCache.LoadOrStore(structType, meta)
+	return actual.(*metadata), nil
+}
+
+// buildMetadata reads the struct tags of a model type.
+//
+// Columns come from the `db` tag or the snake case field name; `db:"-"`
+// skips a field. The `orm` tag marks the primary key (`orm:"primaryKey"`,
+// defaulting to the "id" column) and declares relations such as
+// `orm:"hasMany,foreignKey:author_id"`.
+func buildMetadata(structType reflect.Type) (*metadata, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("orm: model must be a struct, got %s", structType)
+	}
+
+	meta := &metadata{
+		structType:   structType,
+		table:        str.Plural(snakeCase(structType.Name())),
+		columns:      make(map[string]*field),
+		relations:    make(map[string]*relation),
+		incrementing: true,
+	}
+
+	instance := reflect.New(structType).Interface()
+	if namer, ok := instance.(TableNamer); ok {
+		meta.table = namer.TableName()
+	}
+	if namer, ok := instance.(ConnectionNamer); ok {
+		meta.connection = namer.ConnectionName()
+	}
+
+	var primaryKey string
+	if err := meta.collect(structType, nil, &primaryKey); err != nil {
+		return nil, err
+	}
+
+	for idx := range meta.fields {
+		meta.columns[meta.fields[idx].column] = &meta.fields[idx]
+	}
+	if primaryKey == "" {
+		primaryKey = "id"
+	}
+	meta.primaryKey = meta.columns[primaryKey]
+	if meta.primaryKey != nil && !isIntegerKind(meta.primaryKey.fieldType.Kind()) {
+		meta.incrementing = false
+	}
+	return meta, nil
+}
+
+// collect adds the fields and relations of a struct, recursing into
+// untagged embedded structs such as Timestamps
+func (m *metadata) collect(structType reflect.Type, parent []int, primaryKey *string) error {
+	for idx := 0; idx < structType.NumField(); idx++ {
+		structField := structType.Field(idx)
+		if !structField.IsExported() {
+			continue
+		}
+		index := append(append([]int(nil), parent...), idx)
+
+		column := strings.Split(structField.Tag.Get("db"), ",")[0]
+		options := parseOptions(structField.Tag.Get("orm"))
+		if column == "-" {
+			continue
+		}
+
+		if kind := options["kind"]; kind != "" && kind != "primaryKey" {
+			rel, err := m.newRelation(structField, index, kind, options)
+			if err != nil {
+				return err
+			}
+			m.relations[strings.ToLower(structField.Name)] = rel
+			continue
+		}
+
+		if structField.Anonymous && column == "" && structField.Type.Kind() == reflect.Struct {
+			if err := m.collect(structField.Type, index, primaryKey); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if column == "" {
+			column = snakeCase(structField.Name)
+		}
+		if options["kind"] == "primaryKey" {
+			*primaryKey = column
+		}
+		m.fields = append(m.fields, field{column: column, index: index, fieldType: structField.Type})
+	}
+	return nil
+}
+
+// newRelation builds a relation with Laravel's key conventions
+func (m *metadata) newRelation(structField reflect.StructField, index []int, kind string, options map[string]string) (*relation, error) {
+	rel := &relation{name: structField.Name, kind: kind, index: index}
+
+	related := structField.Type
+	if related.Kind() == reflect.Slice {
+		rel.many = true
+		related = related.Elem()
+	}
+	for related.Kind() == reflect.Pointer {
+		related = related.Elem()
+	}
+	if related.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("orm: relation %s.%s must reference a struct", m.structType.Name(), structField.Name)
+	}
+	rel.related = related
+
+	owner := snakeCase(m.structType.Name())
+	target := snakeCase(related.Name())
+	switch kind {
+	case RelationHasOne, RelationHasMany:
+		rel.foreignKey = optionOr(options, "foreignKey", owner+"_id")
+		rel.localKey = optionOr(options, "localKey", "id")
+	case RelationBelongsTo:
+		rel.foreignKey = optionOr(options, "foreignKey", snakeCase(structField.Name)+"_id")
+		rel.localKey = optionOr(options, "ownerKey", "id")
+	case RelationBelongsToMany:
+		pair := []string{owner, target}
+		if pair[1] < pair[0] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		rel.pivotTable = optionOr(options, "table", pair[0]+"_"+pair[1])
+		rel.foreignPivotKey = optionOr(options, "foreignPivotKey", owner+"_id")
+		rel.relatedPivotKey = optionOr(options, "relatedPivotKey", target+"_id")
+		rel.localKey = optionOr(options, "parentKey", "id")
+		rel.foreignKey = optionOr(options, "relatedKey", "id")
+	default:
+		return nil, fmt.Errorf("orm: unknown relation %q on %s.%s", kind, m.structType.Name(), structField.Name)
+	}
+
+	if (kind == RelationHasOne || kind == RelationBelongsTo) && rel.many {
+		return nil, fmt.Errorf("orm: %s relation %s.%s must be a pointer", kind, m.structType.Name(), structField.Name)
+	}
+	if (kind == RelationHasMany || kind == RelationBelongsToMany) && !rel.many {
+		return nil, fmt.Errorf("orm: %s relation %s.%s must be a slice", kind, m.structType.Name(), structField.Name)
+	}
+	return rel, nil
+}
+
+// hasColumn reports whether the column is mapped
+func (m *metadata) hasColumn(column string) bool {
+	_, ok := m.columns[column]
+	return ok
+}
+
+// softDeletes reports whether the model has a deleted_at column
+func (m *metadata) softDeletes() bool {
+	return m.hasColumn(DeletedAtColumn)
+}
+
+// qualify prefixes a column with the table
+func (m *metadata) qualify(column string) string {
+	return m.table + "." + column
+}
+
+// parseOptions parses an orm tag such as "hasMany,foreignKey:author_id";
+// the first bare option is stored as "kind"
+func parseOptions(tag string) map[string]string {
+	options := make(map[string]string)
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if key, value, ok := strings.Cut(part, ":"); ok {
+			options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		} else if options["kind"] == "" {
+			options["kind"] = part
+		}
+	}
+	return options
+}
+
+// optionOr returns an option or the fallback
+func optionOr(options map[string]string, key string, fallback string) string {
+	if value := options[key]; value != "" {
+		return value
+	}
+	return fallback
+}
+
+// isIntegerKind reports whether the kind is a signed or unsigned integer
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// snakeCase converts a Go name such as "UserID" or "BlogPost" to snake case
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for idx, char := range runes {
+		if unicode.IsUpper(char) {
+			previousLower := idx > 0 && unicode.IsLower(runes[idx-1])
+			nextLower := idx > 0 && idx+1 < len(runes) && unicode.IsLower(runes[idx+1]) && unicode.IsUpper(runes[idx-1])
+			if previousLower || nextLower {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(char))
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
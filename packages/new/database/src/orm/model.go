@@ -0,0 +1,493 @@
+// Package orm maps Go structs to tables in an active-record style.
+//
+// A Model[T] describes a struct type through its tags and runs queries
+// returning *T values:
+//
+//	type User struct {
+//		ID    int64
+//		Name  string
+//		Email string
+//		Posts []*Post `orm:"hasMany"`
+//		orm.Timestamps
+//		orm.SoftDeletes
+//	}
+//
+//	users := orm.NewModel[User]()
+//	user, err := users.Find(1)
+//	active, err := users.Where("votes", ">", 100).With("posts.comments").Get()
+//	err = users.Save(&User{Name: "Ann"})
+//
+// Fields map to columns by their `db` tag or snake case name. Models with
+// created_at/updated_at columns are timestamped, models with a deleted_at
+// column are soft deleted. Relations are declared with the `orm` tag:
+// hasOne, hasMany, belongsTo and belongsToMany, with optional foreignKey,
+// localKey, ownerKey, table, foreignPivotKey and relatedPivotKey options.
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"govel/support/carbon"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// ErrRecordNotFound is returned when a query finds no model
+var ErrRecordNotFound = errors.New("orm: record not found")
+
+// ErrNoPrimaryKey is returned when saving or deleting a model without a
+// primary key field
+var ErrNoPrimaryKey = errors.New("orm: model has no primary key")
+
+// Timestamps adds the created_at and updated_at columns to a model
+type Timestamps struct {
+	CreatedAt *time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// SoftDeletes adds the deleted_at column to a model, so deletes only mark
+// the row and queries exclude marked rows
+type SoftDeletes struct {
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// Trashed reports whether the model is soft deleted
+func (s SoftDeletes) Trashed() bool {
+	return s.DeletedAt != nil
+}
+
+// Model runs queries and persistence for the struct type T
+type Model[T any] struct {
+	// meta describes the mapping of T
+	meta *metadata
+
+	// err is the metadata error, reported by every operation
+	err error
+
+	// manager resolves connections and dispatches events, Default() when nil
+	manager *Manager
+
+	// connection overrides the resolved connection
+	connection databaseInterfaces.DatabaseInterface
+
+	// ctx is used by every query
+	ctx context.Context
+}
+
+// NewModel creates the model of a struct type
+func NewModel[T any]() *Model[T] {
+	meta, err := metadataOf(reflect.TypeOf((*T)(nil)).Elem())
+	return &Model[T]{meta: meta, err: err, ctx: context.Background()}
+}
+
+// On returns a copy of the model using the connection
+func (m *Model[T]) On(connection databaseInterfaces.DatabaseInterface) *Model[T] {
+	clone := *m
+	clone.connection = connection
+	return &clone
+}
+
+// Using returns a copy of the model using the manager
+func (m *Model[T]) Using(manager *Manager) *Model[T] {
+	clone := *m
+	clone.manager = manager
+	return &clone
+}
+
+// WithContext returns a copy of the model running queries with the context
+func (m *Model[T]) WithContext(ctx context.Context) *Model[T] {
+	clone := *m
+	clone.ctx = ctx
+	return &clone
+}
+
+// GetTable returns the table of the model
+func (m *Model[T]) GetTable() string {
+	if m.meta == nil {
+		return ""
+	}
+	return m.meta.table
+}
+
+// Query starts a query on the model table
+func (m *Model[T]) Query() *Query[T] {
+	return newQuery(m)
+}
+
+// Where starts a query with a WHERE condition
+func (m *Model[T]) Where(column string, operator string, value interface{}) *Query[T] {
+	return m.Query().Where(column, operator, value)
+}
+
+// WhereIn starts a query with a WHERE IN condition
+func (m *Model[T]) WhereIn(column string, values []interface{}) *Query[T] {
+	return m.Query().WhereIn(column, values)
+}
+
+// OrderBy starts an ordered query
+func (m *Model[T]) OrderBy(column string, direction string) *Query[T] {
+	return m.Query().OrderBy(column, direction)
+}
+
+// With starts a query eager loading the relations, e.g. "posts.comments"
+func (m *Model[T]) With(relations ...string) *Query[T] {
+	return m.Query().With(relations...)
+}
+
+// WithTrashed starts a query including soft deleted models
+func (m *Model[T]) WithTrashed() *Query[T] {
+	return m.Query().WithTrashed()
+}
+
+// OnlyTrashed starts a query returning only soft deleted models
+func (m *Model[T]) OnlyTrashed() *Query[T] {
+	return m.Query().OnlyTrashed()
+}
+
+// Find returns the model with the primary key or ErrRecordNotFound
+func (m *Model[T]) Find(id interface{}) (*T, error) {
+	return m.Query().Find(id)
+}
+
+// FindMany returns the models with the primary keys
+func (m *Model[T]) FindMany(ids ...interface{}) ([]*T, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.meta.primaryKey == nil {
+		return nil, ErrNoPrimaryKey
+	}
+	return m.Query().WhereIn(m.meta.qualify(m.meta.primaryKey.column), ids).Get()
+}
+
+// First returns the first model or ErrRecordNotFound
+func (m *Model[T]) First() (*T, error) {
+	return m.Query().First()
+}
+
+// All returns every model
+func (m *Model[T]) All() ([]*T, error) {
+	return m.Query().Get()
+}
+
+// Count counts the models
+func (m *Model[T]) Count() (int64, error) {
+	return m.Query().Count()
+}
+
+// Paginate returns a page of models
+func (m *Model[T]) Paginate(page int, perPage int) (*Paginator[T], error) {
+	return m.Query().Paginate(page, perPage)
+}
+
+// Load eager loads relations onto already retrieved models
+func (m *Model[T]) Load(models []*T, relations ...string) error {
+	if m.err != nil {
+		return m.err
+	}
+	connection, err := m.resolveConnection()
+	if err != nil {
+		return err
+	}
+
+	values := make([]reflect.Value, len(models))
+	for idx, model := range models {
+		values[idx] = reflect.ValueOf(model)
+	}
+	return loadRelations(m.ctx, connection, m.getManager(), m.meta, values, parseRelations(relations))
+}
+
+// Save inserts a model with a zero primary key and updates it otherwise
+func (m *Model[T]) Save(model *T) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.meta.primaryKey == nil {
+		return ErrNoPrimaryKey
+	}
+	if fieldOf(reflect.ValueOf(model), m.meta.primaryKey).IsZero() || !m.meta.incrementing && !m.exists(model) {
+		return m.insert(model)
+	}
+	return m.update(model)
+}
+
+// Create inserts a model
+func (m *Model[T]) Create(model *T) error {
+	if m.err != nil {
+		return m.err
+	}
+	return m.insert(model)
+}
+
+// Delete deletes a model, or marks it deleted when it uses soft deletes
+func (m *Model[T]) Delete(model *T) error {
+	return m.delete(model, false)
+}
+
+// ForceDelete deletes a model even when it uses soft deletes
+func (m *Model[T]) ForceDelete(model *T) error {
+	return m.delete(model, true)
+}
+
+// Restore clears the deleted_at column of a soft deleted model
+func (m *Model[T]) Restore(model *T) error {
+	if m.err != nil {
+		return m.err
+	}
+	if !m.meta.softDeletes() {
+		return fmt.Errorf("orm: %s does not use soft deletes", m.meta.table)
+	}
+
+	if err := m.dispatch(EventRestoring, model); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(model)
+	if err := assign(fieldOf(value, m.meta.columns[DeletedAtColumn]), nil); err != nil {
+		return err
+	}
+	if err := m.updateColumns(model, map[string]interface{}{DeletedAtColumn: nil}); err != nil {
+		return err
+	}
+	return m.dispatch(EventRestored, model)
+}
+
+// Attach inserts pivot rows linking a model to related primary keys
+// through a belongsToMany relation
+func (m *Model[T]) Attach(model *T, relationName string, ids ...interface{}) error {
+	rel, connection, parentKey, err := m.pivotRelation(model, relationName)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, map[string]interface{}{rel.foreignPivotKey: parentKey, rel.relatedPivotKey: id})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	_, err = connection.Table(rel.pivotTable).InsertBatchContext(m.ctx, rows)
+	return err
+}
+
+// Detach deletes the pivot rows linking a model to the related primary
+// keys, or to every related model when no keys are given
+func (m *Model[T]) Detach(model *T, relationName string, ids ...interface{}) error {
+	rel, connection, parentKey, err := m.pivotRelation(model, relationName)
+	if err != nil {
+		return err
+	}
+
+	query := connection.Table(rel.pivotTable).Where(rel.foreignPivotKey, "=", parentKey)
+	if len(ids) > 0 {
+		query = query.WhereIn(rel.relatedPivotKey, ids)
+	}
+	_, err = query.DeleteContext(m.ctx)
+	return err
+}
+
+// insert inserts a model, filling an incrementing primary key
+func (m *Model[T]) insert(model *T) error {
+	connection, err := m.resolveConnection()
+	if err != nil {
+		return err
+	}
+	if err := m.dispatch(EventSaving, model); err != nil {
+		return err
+	}
+	if err := m.dispatch(EventCreating, model); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(model)
+	now := m.now()
+	if created, ok := m.meta.columns[CreatedAtColumn]; ok && fieldOf(value, created).IsZero() {
+		if err := assign(fieldOf(value, created), now); err != nil {
+			return err
+		}
+	}
+	if updated, ok := m.meta.columns[UpdatedAtColumn]; ok {
+		if err := assign(fieldOf(value, updated), now); err != nil {
+			return err
+		}
+	}
+
+	attributes := make(map[string]interface{}, len(m.meta.fields))
+	autoKey := false
+	for idx := range m.meta.fields {
+		mapped := &m.meta.fields[idx]
+		if mapped == m.meta.primaryKey && m.meta.incrementing && fieldOf(value, mapped).IsZero() {
+			autoKey = true
+			continue
+		}
+		attributes[mapped.column] = columnValue(value, mapped)
+	}
+
+	query := connection.Table(m.meta.table)
+	if autoKey {
+		id, err := query.InsertGetIDContext(m.ctx, attributes)
+		if err != nil {
+			return err
+		}
+		if err := assign(fieldOf(value, m.meta.primaryKey), id); err != nil {
+			return err
+		}
+	} else if _, err := query.InsertContext(m.ctx, attributes); err != nil {
+		return err
+	}
+
+	if err := m.dispatch(EventCreated, model); err != nil {
+		return err
+	}
+	return m.dispatch(EventSaved, model)
+}
+
+// update writes every column of a model
+func (m *Model[T]) update(model *T) error {
+	if err := m.dispatch(EventSaving, model); err != nil {
+		return err
+	}
+	if err := m.dispatch(EventUpdating, model); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(model)
+	if updated, ok := m.meta.columns[UpdatedAtColumn]; ok {
+		if err := assign(fieldOf(value, updated), m.now()); err != nil {
+			return err
+		}
+	}
+
+	attributes := make(map[string]interface{}, len(m.meta.fields))
+	for idx := range m.meta.fields {
+		mapped := &m.meta.fields[idx]
+		if mapped != m.meta.primaryKey {
+			attributes[mapped.column] = columnValue(value, mapped)
+		}
+	}
+	if err := m.updateColumns(model, attributes); err != nil {
+		return err
+	}
+
+	if err := m.dispatch(EventUpdated, model); err != nil {
+		return err
+	}
+	return m.dispatch(EventSaved, model)
+}
+
+// delete deletes or soft deletes a model
+func (m *Model[T]) delete(model *T, force bool) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.meta.primaryKey == nil {
+		return ErrNoPrimaryKey
+	}
+	if err := m.dispatch(EventDeleting, model); err != nil {
+		return err
+	}
+
+	if m.meta.softDeletes() && !force {
+		value := reflect.ValueOf(model)
+		now := m.now()
+		if err := assign(fieldOf(value, m.meta.columns[DeletedAtColumn]), now); err != nil {
+			return err
+		}
+		columns := map[string]interface{}{DeletedAtColumn: now}
+		if updated, ok := m.meta.columns[UpdatedAtColumn]; ok {
+			if err := assign(fieldOf(value, updated), now); err != nil {
+				return err
+			}
+			columns[UpdatedAtColumn] = now
+		}
+		if err := m.updateColumns(model, columns); err != nil {
+			return err
+		}
+	} else {
+		connection, err := m.resolveConnection()
+		if err != nil {
+			return err
+		}
+		key := columnValue(reflect.ValueOf(model), m.meta.primaryKey)
+		if _, err := connection.Table(m.meta.table).Where(m.meta.primaryKey.column, "=", key).DeleteContext(m.ctx); err != nil {
+			return err
+		}
+	}
+
+	return m.dispatch(EventDeleted, model)
+}
+
+// updateColumns updates columns of the row of a model
+func (m *Model[T]) updateColumns(model *T, columns map[string]interface{}) error {
+	connection, err := m.resolveConnection()
+	if err != nil {
+		return err
+	}
+	key := columnValue(reflect.ValueOf(model), m.meta.primaryKey)
+	_, err = connection.Table(m.meta.table).Where(m.meta.primaryKey.column, "=", key).UpdateContext(m.ctx, columns)
+	return err
+}
+
+// exists reports whether a row with the model's primary key exists
+func (m *Model[T]) exists(model *T) bool {
+	connection, err := m.resolveConnection()
+	if err != nil {
+		return false
+	}
+	key := columnValue(reflect.ValueOf(model), m.meta.primaryKey)
+	found, err := connection.Table(m.meta.table).Where(m.meta.primaryKey.column, "=", key).ExistsContext(m.ctx)
+	return err == nil && found
+}
+
+// pivotRelation returns a belongsToMany relation with its connection and
+// the parent key of the model
+func (m *Model[T]) pivotRelation(model *T, relationName string) (*relation, databaseInterfaces.DatabaseInterface, interface{}, error) {
+	if m.err != nil {
+		return nil, nil, nil, m.err
+	}
+	rel, err := m.meta.relation(relationName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if rel.kind != RelationBelongsToMany {
+		return nil, nil, nil, fmt.Errorf("orm: %s is not a belongsToMany relation", relationName)
+	}
+	parent, ok := m.meta.columns[rel.localKey]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("orm: %s has no %s column", m.meta.table, rel.localKey)
+	}
+	connection, err := m.resolveConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return rel, connection, columnValue(reflect.ValueOf(model), parent), nil
+}
+
+// resolveConnection returns the model connection
+func (m *Model[T]) resolveConnection() (databaseInterfaces.DatabaseInterface, error) {
+	if m.connection != nil {
+		return m.connection, nil
+	}
+	return m.getManager().Connection(m.meta.connection)
+}
+
+// getManager returns the model manager or the default one
+func (m *Model[T]) getManager() *Manager {
+	if m.manager != nil {
+		return m.manager
+	}
+	return Default()
+}
+
+// dispatch sends a model event
+func (m *Model[T]) dispatch(event string, model *T) error {
+	return m.getManager().dispatch(event, m.meta.table, model)
+}
+
+// now returns the current time, honouring carbon.SetTestNow
+func (m *Model[T]) now() time.Time {
+	return carbon.Now().StdTime()
+}
@@ -0,0 +1,328 @@
+package orm
+
+import (
+	"reflect"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// Soft delete scopes of a query
+const (
+	withoutTrashed = iota
+	withTrashed
+	onlyTrashed
+)
+
+// Query builds a query returning models of type T. Conditions apply to
+// the model table; soft deleted models are excluded unless WithTrashed or
+// OnlyTrashed is used.
+type Query[T any] struct {
+	// model is the queried model
+	model *Model[T]
+
+	// connection runs the query
+	connection databaseInterfaces.DatabaseInterface
+
+	// builder holds the conditions
+	builder databaseInterfaces.QueryBuilderInterface
+
+	// err is reported by every terminal method
+	err error
+
+	// relations are eager loaded onto the results
+	relations relationTree
+
+	// trashed is the soft delete scope
+	trashed int
+}
+
+// newQuery starts a query on the model table
+func newQuery[T any](model *Model[T]) *Query[T] {
+	query := &Query[T]{model: model, relations: relationTree{}, err: model.err}
+	if query.err != nil {
+		return query
+	}
+	query.connection, query.err = model.resolveConnection()
+	if query.err == nil {
+		query.builder = query.connection.Table(model.meta.table)
+	}
+	return query
+}
+
+// apply runs fn on the builder unless the query failed
+func (q *Query[T]) apply(fn func(builder databaseInterfaces.QueryBuilderInterface)) *Query[T] {
+	if q.err == nil {
+		fn(q.builder)
+	}
+	return q
+}
+
+// Where adds a WHERE condition
+func (q *Query[T]) Where(column string, operator string, value interface{}) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.Where(column, operator, value) })
+}
+
+// WhereIn adds a WHERE IN condition
+func (q *Query[T]) WhereIn(column string, values []interface{}) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereIn(column, values) })
+}
+
+// WhereNotIn adds a WHERE NOT IN condition
+func (q *Query[T]) WhereNotIn(column string, values []interface{}) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereNotIn(column, values) })
+}
+
+// WhereNull adds a WHERE IS NULL condition
+func (q *Query[T]) WhereNull(column string) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereNull(column) })
+}
+
+// WhereNotNull adds a WHERE IS NOT NULL condition
+func (q *Query[T]) WhereNotNull(column string) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereNotNull(column) })
+}
+
+// WhereBetween adds a WHERE BETWEEN condition
+func (q *Query[T]) WhereBetween(column string, from interface{}, to interface{}) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereBetween(column, from, to) })
+}
+
+// WhereRaw adds a raw WHERE condition using "?" placeholders
+func (q *Query[T]) WhereRaw(sql string, bindings ...interface{}) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.WhereRaw(sql, bindings...) })
+}
+
+// OrderBy adds an ORDER BY clause
+func (q *Query[T]) OrderBy(column string, direction string) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.OrderBy(column, direction) })
+}
+
+// Latest orders by the column, created_at by default, newest first
+func (q *Query[T]) Latest(column ...string) *Query[T] {
+	return q.OrderBy(firstOr(column, CreatedAtColumn), "desc")
+}
+
+// Oldest orders by the column, created_at by default, oldest first
+func (q *Query[T]) Oldest(column ...string) *Query[T] {
+	return q.OrderBy(firstOr(column, CreatedAtColumn), "asc")
+}
+
+// Limit limits the number of models
+func (q *Query[T]) Limit(count int) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.Limit(count) })
+}
+
+// Offset skips models
+func (q *Query[T]) Offset(count int) *Query[T] {
+	return q.apply(func(builder databaseInterfaces.QueryBuilderInterface) { builder.Offset(count) })
+}
+
+// With eager loads relations; nested relations are separated by dots,
+// e.g. "posts.comments"
+func (q *Query[T]) With(relations ...string) *Query[T] {
+	q.relations.merge(parseRelations(relations))
+	return q
+}
+
+// WithTrashed includes soft deleted models
+func (q *Query[T]) WithTrashed() *Query[T] {
+	q.trashed = withTrashed
+	return q
+}
+
+// OnlyTrashed returns only soft deleted models
+func (q *Query[T]) OnlyTrashed() *Query[T] {
+	q.trashed = onlyTrashed
+	return q
+}
+
+// Get returns the matching models with their eager loaded relations
+func (q *Query[T]) Get() ([]*T, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	meta := q.model.meta
+	rows, err := q.scoped().Select(meta.table + ".*").GetContext(q.model.ctx)
+	if err != nil {
+		return nil, err
+	}
+	values, _, err := scanRows(rows, meta)
+	if err != nil {
+		return nil, err
+	}
+	if err := retrieved(q.model.getManager(), meta, values); err != nil {
+		return nil, err
+	}
+	if err := loadRelations(q.model.ctx, q.connection, q.model.getManager(), meta, values, q.relations); err != nil {
+		return nil, err
+	}
+
+	models := make([]*T, len(values))
+	for idx, value := range values {
+		models[idx] = value.Interface().(*T)
+	}
+	return models, nil
+}
+
+// First returns the first matching model or ErrRecordNotFound
+func (q *Query[T]) First() (*T, error) {
+	models, err := q.Limit(1).Get()
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, ErrRecordNotFound
+	}
+	return models[0], nil
+}
+
+// Find returns the model with the primary key or ErrRecordNotFound
+func (q *Query[T]) Find(id interface{}) (*T, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.model.meta.primaryKey == nil {
+		return nil, ErrNoPrimaryKey
+	}
+	return q.Where(q.model.meta.qualify(q.model.meta.primaryKey.column), "=", id).First()
+}
+
+// Count counts the matching models
+func (q *Query[T]) Count() (int64, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+	return q.scoped().CountContext(q.model.ctx)
+}
+
+// Exists reports whether a model matches
+func (q *Query[T]) Exists() (bool, error) {
+	if q.err != nil {
+		return false, q.err
+	}
+	return q.scoped().ExistsContext(q.model.ctx)
+}
+
+// Paginate returns the models of a 1-based page together with the total
+func (q *Query[T]) Paginate(page int, perPage int) (*Paginator[T], error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 15
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	items, err := q.Limit(perPage).Offset((page - 1) * perPage).Get()
+	if err != nil {
+		return nil, err
+	}
+	return newPaginator(items, total, perPage, page), nil
+}
+
+// Update updates the matching rows without loading models or dispatching
+// events, touching updated_at when the model has it
+func (q *Query[T]) Update(values map[string]interface{}) (int64, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+	if q.model.meta.hasColumn(UpdatedAtColumn) {
+		if _, set := values[UpdatedAtColumn]; !set {
+			values[UpdatedAtColumn] = q.model.now()
+		}
+	}
+	result, err := q.scoped().UpdateContext(q.model.ctx, values)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Delete deletes the matching rows, or marks them deleted when the model
+// uses soft deletes, without dispatching events
+func (q *Query[T]) Delete() (int64, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+	if q.model.meta.softDeletes() {
+		return q.Update(map[string]interface{}{DeletedAtColumn: q.model.now()})
+	}
+	result, err := q.scoped().DeleteContext(q.model.ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ToSQL returns the SQL and bindings of the query
+func (q *Query[T]) ToSQL() (string, []interface{}, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	return q.scoped().Select(q.model.meta.table + ".*").ToSQL()
+}
+
+// scoped returns a copy of the builder with the soft delete scope applied
+func (q *Query[T]) scoped() databaseInterfaces.QueryBuilderInterface {
+	builder := q.builder.Clone()
+	if !q.model.meta.softDeletes() {
+		return builder
+	}
+	switch q.trashed {
+	case withoutTrashed:
+		builder.WhereNull(q.model.meta.qualify(DeletedAtColumn))
+	case onlyTrashed:
+		builder.WhereNotNull(q.model.meta.qualify(DeletedAtColumn))
+	}
+	return builder
+}
+
+// retrieved dispatches the retrieved event for every model
+func retrieved(manager *Manager, meta *metadata, models []reflect.Value) error {
+	for _, model := range models {
+		if err := manager.dispatch(EventRetrieved, meta.table, model.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstOr returns the first value or the fallback
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
+
+// Paginator is a page of models
+type Paginator[T any] struct {
+	Items       []*T  `json:"data"`
+	Total       int64 `json:"total"`
+	PerPage     int   `json:"per_page"`
+	CurrentPage int   `json:"current_page"`
+	LastPage    int   `json:"last_page"`
+}
+
+// newPaginator creates a paginator computing the last page
+func newPaginator[T any](items []*T, total int64, perPage int, page int) *Paginator[T] {
+	lastPage := int((total + int64(perPage) - 1) / int64(perPage))
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	return &Paginator[T]{Items: items, Total: total, PerPage: perPage, CurrentPage: page, LastPage: lastPage}
+}
+
+// HasMorePages reports whether pages follow the current one
+func (p *Paginator[T]) HasMorePages() bool {
+	return p.CurrentPage < p.LastPage
+}
+
+// OnFirstPage reports whether the current page is the first
+func (p *Paginator[T]) OnFirstPage() bool {
+	return p.CurrentPage <= 1
+}
@@ -0,0 +1,194 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// pivotKeyAlias is the alias of the pivot parent key selected by
+// belongsToMany eager loads
+const pivotKeyAlias = "orm_pivot_parent_key"
+
+// relationTree maps relation names to their nested relations
+type relationTree map[string]relationTree
+
+// parseRelations parses dotted relation paths such as "posts.comments"
+func parseRelations(paths []string) relationTree {
+	tree := relationTree{}
+	for _, path := range paths {
+		node := tree
+		for _, name := range strings.Split(path, ".") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if node[name] == nil {
+				node[name] = relationTree{}
+			}
+			node = node[name]
+		}
+	}
+	return tree
+}
+
+// merge adds the relations of other to the tree
+func (t relationTree) merge(other relationTree) {
+	for name, children := range other {
+		if t[name] == nil {
+			t[name] = relationTree{}
+		}
+		t[name].merge(children)
+	}
+}
+
+// relation returns a relation by case-insensitive field name
+func (m *metadata) relation(name string) (*relation, error) {
+	rel, ok := m.relations[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("orm: %s has no relation %q", m.structType.Name(), name)
+	}
+	return rel, nil
+}
+
+// loadRelations eager loads the relation tree onto *struct models with
+// one query per relation, avoiding a query per model
+func loadRelations(ctx context.Context, connection databaseInterfaces.DatabaseInterface, manager *Manager, meta *metadata, models []reflect.Value, tree relationTree) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for name, nested := range tree {
+		rel, err := meta.relation(name)
+		if err != nil {
+			return err
+		}
+		relatedMeta, err := metadataOf(rel.related)
+		if err != nil {
+			return err
+		}
+
+		// The key read from the parents and the column matched on the related models
+		parentColumn, relatedColumn := rel.localKey, rel.foreignKey
+		if rel.kind == RelationBelongsTo {
+			parentColumn, relatedColumn = rel.foreignKey, rel.localKey
+		}
+		parentField, ok := meta.columns[parentColumn]
+		if !ok {
+			return fmt.Errorf("orm: %s has no %s column for relation %s", meta.table, parentColumn, rel.name)
+		}
+
+		keys := distinctKeys(models, parentField)
+		var related []reflect.Value
+		var groups map[string][]reflect.Value
+		if len(keys) > 0 {
+			related, groups, err = fetchRelated(ctx, connection, rel, relatedMeta, relatedColumn, keys)
+			if err != nil {
+				return err
+			}
+			if err := retrieved(manager, relatedMeta, related); err != nil {
+				return err
+			}
+			if err := loadRelations(ctx, connection, manager, relatedMeta, related, nested); err != nil {
+				return err
+			}
+		}
+
+		for _, model := range models {
+			matches := groups[keyOf(columnValue(model, parentField))]
+			setRelation(model.Elem().FieldByIndex(rel.index), matches)
+		}
+	}
+	return nil
+}
+
+// fetchRelated queries the related models of the keys, grouped by the
+// key they belong to
+func fetchRelated(ctx context.Context, connection databaseInterfaces.DatabaseInterface, rel *relation, relatedMeta *metadata, relatedColumn string, keys []interface{}) ([]reflect.Value, map[string][]reflect.Value, error) {
+	builder := connection.Table(relatedMeta.table)
+	if rel.kind == RelationBelongsToMany {
+		builder = builder.
+			Select(relatedMeta.table+".*", rel.pivotTable+"."+rel.foreignPivotKey+" as "+pivotKeyAlias).
+			Join(rel.pivotTable, rel.pivotTable+"."+rel.relatedPivotKey, "=", relatedMeta.qualify(relatedColumn)).
+			WhereIn(rel.pivotTable+"."+rel.foreignPivotKey, keys)
+	} else {
+		builder = builder.Select(relatedMeta.table+".*").WhereIn(relatedMeta.qualify(relatedColumn), keys)
+	}
+	if relatedMeta.softDeletes() {
+		builder = builder.WhereNull(relatedMeta.qualify(DeletedAtColumn))
+	}
+	if relatedMeta.primaryKey != nil {
+		builder = builder.OrderBy(relatedMeta.qualify(relatedMeta.primaryKey.column), "asc")
+	}
+
+	rows, err := builder.GetContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	related, extras, err := scanRows(rows, relatedMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := make(map[string][]reflect.Value)
+	for idx, model := range related {
+		var key string
+		if rel.kind == RelationBelongsToMany {
+			key = keyOf(extras[idx][pivotKeyAlias])
+		} else {
+			mapped, ok := relatedMeta.columns[relatedColumn]
+			if !ok {
+				return nil, nil, fmt.Errorf("orm: %s has no %s column for relation %s", relatedMeta.table, relatedColumn, rel.name)
+			}
+			key = keyOf(columnValue(model, mapped))
+		}
+		groups[key] = append(groups[key], model)
+	}
+	return related, groups, nil
+}
+
+// distinctKeys returns the distinct non-nil values of a column
+func distinctKeys(models []reflect.Value, mapped *field) []interface{} {
+	seen := make(map[string]bool)
+	var keys []interface{}
+	for _, model := range models {
+		value := columnValue(model, mapped)
+		key := keyOf(value)
+		if value == nil || key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, value)
+	}
+	return keys
+}
+
+// setRelation stores related *struct models in a relation field of type
+// *R, R, []*R or []R
+func setRelation(target reflect.Value, related []reflect.Value) {
+	switch target.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(target.Type(), 0, len(related))
+		for _, model := range related {
+			if target.Type().Elem().Kind() == reflect.Pointer {
+				slice = reflect.Append(slice, model)
+			} else {
+				slice = reflect.Append(slice, model.Elem())
+			}
+		}
+		target.Set(slice)
+	case reflect.Pointer:
+		if len(related) == 0 {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+		target.Set(related[0])
+	case reflect.Struct:
+		if len(related) > 0 {
+			target.Set(related[0].Elem())
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// timeLayouts are tried when a time column is returned as text
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02",
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// scanRows scans every row into a new *struct of the metadata type.
+// Columns without a mapped field are returned in extras keyed by name.
+func scanRows(rows *sql.Rows, meta *metadata) ([]reflect.Value, []map[string]interface{}, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var models []reflect.Value
+	var extras []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		holders := make([]interface{}, len(columns))
+		for idx := range values {
+			holders[idx] = &values[idx]
+		}
+		if err := rows.Scan(holders...); err != nil {
+			return nil, nil, err
+		}
+
+		model := reflect.New(meta.structType)
+		extra := make(map[string]interface{})
+		for idx, column := range columns {
+			mapped, ok := meta.columns[column]
+			if !ok {
+				extra[column] = values[idx]
+				continue
+			}
+			if err := assign(fieldOf(model, mapped), values[idx]); err != nil {
+				return nil, nil, fmt.Errorf("orm: %s.%s: %w", meta.table, column, err)
+			}
+		}
+		models = append(models, model)
+		extras = append(extras, extra)
+	}
+	return models, extras, rows.Err()
+}
+
+// fieldOf returns the settable field of a *struct, allocating nil
+// embedded struct pointers on the way
+func fieldOf(model reflect.Value, mapped *field) reflect.Value {
+	value := model.Elem()
+	for idx, position := range mapped.index {
+		if idx > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(position)
+	}
+	return value
+}
+
+// assign stores a driver value in a field, converting between the types
+// drivers return and common Go field types
+func assign(target reflect.Value, src interface{}) error {
+	if src == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if reflect.PointerTo(target.Type().Elem()).Implements(scannerType) || target.Type().Elem().Kind() != reflect.Struct || target.Type().Elem() == timeType {
+			value := reflect.New(target.Type().Elem())
+			if err := assign(value.Elem(), src); err != nil {
+				return err
+			}
+			target.Set(value)
+			return nil
+		}
+	}
+
+	if target.CanAddr() && target.Addr().Type().Implements(scannerType) && target.Type() != timeType {
+		return target.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+
+	if bytes, ok := src.([]byte); ok && target.Kind() != reflect.Slice {
+		src = string(bytes)
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(fmt.Sprint(src))
+		return nil
+	case reflect.Bool:
+		switch typed := src.(type) {
+		case bool:
+			target.SetBool(typed)
+		case int64:
+			target.SetBool(typed != 0)
+		case string:
+			parsed, err := strconv.ParseBool(typed)
+			if err != nil {
+				return err
+			}
+			target.SetBool(parsed)
+		default:
+			return fmt.Errorf("cannot assign %T to bool", src)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := toInt64(src)
+		if err != nil {
+			return err
+		}
+		target.SetInt(number)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := toInt64(src)
+		if err != nil {
+			return err
+		}
+		target.SetUint(uint64(number))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch typed := src.(type) {
+		case float64:
+			target.SetFloat(typed)
+		case int64:
+			target.SetFloat(float64(typed))
+		case string:
+			parsed, err := strconv.ParseFloat(typed, 64)
+			if err != nil {
+				return err
+			}
+			target.SetFloat(parsed)
+		default:
+			return fmt.Errorf("cannot assign %T to float", src)
+		}
+		return nil
+	case reflect.Slice:
+		if bytes, ok := src.([]byte); ok && target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes(append([]byte(nil), bytes...))
+			return nil
+		}
+	}
+
+	if target.Type() == timeType {
+		switch typed := src.(type) {
+		case time.Time:
+			target.Set(reflect.ValueOf(typed))
+			return nil
+		case string:
+			for _, layout := range timeLayouts {
+				if parsed, err := time.Parse(layout, typed); err == nil {
+					target.Set(reflect.ValueOf(parsed))
+					return nil
+				}
+			}
+			return fmt.Errorf("cannot parse time %q", typed)
+		}
+	}
+
+	value := reflect.ValueOf(src)
+	if value.Type().ConvertibleTo(target.Type()) {
+		target.Set(value.Convert(target.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", src, target.Type())
+}
+
+// toInt64 converts driver integers, floats and numeric strings
+func toInt64(src interface{}) (int64, error) {
+	switch typed := src.(type) {
+	case int64:
+		return typed, nil
+	case float64:
+		return int64(typed), nil
+	case bool:
+		if typed {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseInt(typed, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot assign %T to integer", src)
+	}
+}
+
+// keyOf normalizes a key value for matching parents and related models,
+// so an int64 foreign key matches an int primary key
+func keyOf(value interface{}) string {
+	switch typed := value.(type) {
+	case []byte:
+		return string(typed)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// columnValue returns the driver value of a mapped field, dereferencing pointers
+func columnValue(model reflect.Value, mapped *field) interface{} {
+	value := fieldOf(model, mapped)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	return value.Interface()
+}
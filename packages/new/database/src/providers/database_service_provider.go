@@ -0,0 +1,179 @@
+// Package providers contains service provider implementations for the database package.
+// Service providers are responsible for registering database services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	database "govel/new/database"
+	"govel/new/database/connections"
+	"govel/new/database/orm"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	databaseInterfaces "govel/types/interfaces/database"
+	ormInterfaces "govel/types/interfaces/orm"
+	schemaInterfaces "govel/types/interfaces/schema"
+	seederInterfaces "govel/types/interfaces/seeder"
+)
+
+// DatabaseServiceProvider implements a Laravel-compatible service provider
+// for the database package.
+//
+// Services registered:
+//   - DATABASE_MANAGER_TOKEN / DATABASE_FACTORY_TOKEN: Singleton DatabaseManager
+//   - DATABASE_TOKEN / DATABASE_INTERFACE_TOKEN: The default connection, resolved lazily
+//   - SCHEMA_TOKEN / SCHEMA_INTERFACE_TOKEN: The schema builder of the default connection
+//   - SCHEMA_MANAGER_TOKEN: The migrator of the default connection
+//   - SEEDER_TOKEN / SEEDER_INTERFACE_TOKEN: The seeder runner of the default connection
+//   - ORM_TOKEN / ORM_INTERFACE_TOKEN / ORM_MANAGER_TOKEN: Singleton ORM manager, also used by models
+type DatabaseServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewDatabaseServiceProvider creates a new DatabaseServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewDatabaseServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register database services:", err)
+//	}
+func NewDatabaseServiceProvider() *DatabaseServiceProvider {
+	return &DatabaseServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all database services in the dependency injection container.
+func (p *DatabaseServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager     *database.DatabaseManager
+		managerOnce sync.Once
+	)
+	managerFactory := func() interface{} {
+		managerOnce.Do(func() {
+			manager = database.NewDatabaseManager(application)
+		})
+		return manager
+	}
+
+	for _, token := range []interface{}{
+		databaseInterfaces.DATABASE_MANAGER_TOKEN,
+		databaseInterfaces.DATABASE_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, managerFactory); err != nil {
+			return fmt.Errorf("failed to bind database manager: %w", err)
+		}
+	}
+
+	connectionFactory := func() (interface{}, error) {
+		connection, err := managerFactory().(*database.DatabaseManager).Connection()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve default database connection: %w", err)
+		}
+		return connection, nil
+	}
+
+	for _, token := range []interface{}{
+		databaseInterfaces.DATABASE_TOKEN,
+		databaseInterfaces.DATABASE_INTERFACE_TOKEN,
+	} {
+		if err := application.Bind(token, connectionFactory); err != nil {
+			return fmt.Errorf("failed to bind database connection: %w", err)
+		}
+	}
+
+	schemaFactory := func() (interface{}, error) {
+		connection, err := connectionFactory()
+		if err != nil {
+			return nil, err
+		}
+		return connection.(*connections.Connection).Schema(), nil
+	}
+
+	for _, token := range []interface{}{
+		schemaInterfaces.SCHEMA_TOKEN,
+		schemaInterfaces.SCHEMA_INTERFACE_TOKEN,
+	} {
+		if err := application.Bind(token, schemaFactory); err != nil {
+			return fmt.Errorf("failed to bind schema builder: %w", err)
+		}
+	}
+
+	migratorFactory := func() (interface{}, error) {
+		migrator, err := managerFactory().(*database.DatabaseManager).Migrator()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve migrator: %w", err)
+		}
+		return migrator, nil
+	}
+
+	if err := application.Bind(schemaInterfaces.SCHEMA_MANAGER_TOKEN, migratorFactory); err != nil {
+		return fmt.Errorf("failed to bind migrator: %w", err)
+	}
+
+	seederFactory := func() (interface{}, error) {
+		runner, err := managerFactory().(*database.DatabaseManager).Seeder()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve seeder runner: %w", err)
+		}
+		return runner, nil
+	}
+
+	for _, token := range []interface{}{
+		seederInterfaces.SEEDER_TOKEN,
+		seederInterfaces.SEEDER_INTERFACE_TOKEN,
+	} {
+		if err := application.Bind(token, seederFactory); err != nil {
+			return fmt.Errorf("failed to bind seeder runner: %w", err)
+		}
+	}
+
+	// The ORM manager becomes the default of models right away and resolves
+	// connections through the database manager on first use
+	ormManager := orm.NewManager(func(name string) (databaseInterfaces.DatabaseInterface, error) {
+		return managerFactory().(*database.DatabaseManager).Database(name)
+	})
+	orm.SetDefault(ormManager)
+	ormFactory := func() interface{} {
+		return ormManager
+	}
+
+	for _, token := range []interface{}{
+		ormInterfaces.ORM_TOKEN,
+		ormInterfaces.ORM_INTERFACE_TOKEN,
+		ormInterfaces.ORM_MANAGER_TOKEN,
+	} {
+		if err := application.Singleton(token, ormFactory); err != nil {
+			return fmt.Errorf("failed to bind orm manager: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *DatabaseServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		databaseInterfaces.DATABASE_TOKEN,
+		databaseInterfaces.DATABASE_INTERFACE_TOKEN,
+		databaseInterfaces.DATABASE_MANAGER_TOKEN,
+		databaseInterfaces.DATABASE_FACTORY_TOKEN,
+		schemaInterfaces.SCHEMA_TOKEN,
+		schemaInterfaces.SCHEMA_INTERFACE_TOKEN,
+		schemaInterfaces.SCHEMA_MANAGER_TOKEN,
+		seederInterfaces.SEEDER_TOKEN,
+		seederInterfaces.SEEDER_INTERFACE_TOKEN,
+		ormInterfaces.ORM_TOKEN,
+		ormInterfaces.ORM_INTERFACE_TOKEN,
+		ormInterfaces.ORM_MANAGER_TOKEN,
+	}
+}
@@ -0,0 +1,556 @@
+// Package query provides the fluent query builder shared by all database
+// connections. SQL generation is delegated to a dialect Grammar.
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// ErrReturningNotSupported is returned by the *Returning methods on
+// dialects without RETURNING clauses, such as MySQL
+var ErrReturningNotSupported = errors.New("database: RETURNING is not supported by this driver")
+
+// Executor runs compiled statements for a builder. Connections route
+// reads to the read pool unless the Write variants are used.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	WriteQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	WriteQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Builder is a fluent query builder.
+//
+// Example:
+//
+//	rows, err := connection.Table("users").
+//		Where("votes", ">", 100).
+//		OrderBy("name", "asc").
+//		Limit(10).
+//		Get()
+type Builder struct {
+	executor   Executor
+	grammar    Grammar
+	components *Components
+	useWrite   bool
+}
+
+// NewBuilder creates a new builder running statements on the executor
+func NewBuilder(executor Executor, grammar Grammar) *Builder {
+	return &Builder{
+		executor:   executor,
+		grammar:    grammar,
+		components: NewComponents(),
+	}
+}
+
+// Components returns the query state compiled by the grammar
+func (b *Builder) Components() *Components {
+	return b.components
+}
+
+// Grammar returns the grammar of the builder
+func (b *Builder) Grammar() Grammar {
+	return b.grammar
+}
+
+// Select sets the columns to select. Every column is quoted as an
+// identifier; use SelectRaw for expressions.
+func (b *Builder) Select(columns ...string) databaseInterfaces.QueryBuilderInterface {
+	b.components.Columns = make([]interface{}, len(columns))
+	for idx, column := range columns {
+		b.components.Columns[idx] = column
+	}
+	return b
+}
+
+// SelectRaw adds a raw expression to the selected columns.
+//
+// Example:
+//
+//	builder.Select("name").SelectRaw("count(*) as total").GroupBy("name")
+func (b *Builder) SelectRaw(expression string) *Builder {
+	b.components.Columns = append(b.components.Columns, Raw(expression))
+	return b
+}
+
+// From sets the table of the query
+func (b *Builder) From(table string) databaseInterfaces.QueryBuilderInterface {
+	b.components.Table = table
+	return b
+}
+
+// Distinct forces the query to return distinct results
+func (b *Builder) Distinct() databaseInterfaces.QueryBuilderInterface {
+	b.components.Distinct = true
+	return b
+}
+
+// Where adds a basic WHERE condition. A nil value with "=" or "!="
+// compiles to IS NULL or IS NOT NULL. As in Laravel, an operator the
+// dialect does not support is compared as a value with "=".
+func (b *Builder) Where(column string, operator string, value interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.addWhere(BooleanAnd, column, operator, value)
+	return b
+}
+
+// OrWhere adds a basic WHERE condition joined with OR
+func (b *Builder) OrWhere(column string, operator string, value interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.addWhere(BooleanOr, column, operator, value)
+	return b
+}
+
+// WhereIn adds a WHERE IN condition
+func (b *Builder) WhereIn(column string, values []interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereIn, Boolean: BooleanAnd, Column: column, Values: values,
+	})
+	return b
+}
+
+// WhereNotIn adds a WHERE NOT IN condition
+func (b *Builder) WhereNotIn(column string, values []interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereNotIn, Boolean: BooleanAnd, Column: column, Values: values,
+	})
+	return b
+}
+
+// WhereNull adds a WHERE IS NULL condition
+func (b *Builder) WhereNull(column string) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereNull, Boolean: BooleanAnd, Column: column,
+	})
+	return b
+}
+
+// WhereNotNull adds a WHERE IS NOT NULL condition
+func (b *Builder) WhereNotNull(column string) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereNotNull, Boolean: BooleanAnd, Column: column,
+	})
+	return b
+}
+
+// WhereBetween adds a WHERE BETWEEN condition
+func (b *Builder) WhereBetween(column string, from interface{}, to interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereBetween, Boolean: BooleanAnd, Column: column, Values: []interface{}{from, to},
+	})
+	return b
+}
+
+// WhereRaw adds a raw WHERE condition using "?" placeholders, which are
+// rewritten for the dialect
+func (b *Builder) WhereRaw(sql string, bindings ...interface{}) databaseInterfaces.QueryBuilderInterface {
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereRawType, Boolean: BooleanAnd, SQL: sql, Values: bindings,
+	})
+	return b
+}
+
+// Join adds an INNER JOIN. An operator the dialect does not support is
+// used as the second column, compared with "=".
+func (b *Builder) Join(table string, first string, operator string, second string) databaseInterfaces.QueryBuilderInterface {
+	return b.addJoin(JoinInner, table, first, operator, second)
+}
+
+// LeftJoin adds a LEFT JOIN
+func (b *Builder) LeftJoin(table string, first string, operator string, second string) databaseInterfaces.QueryBuilderInterface {
+	return b.addJoin(JoinLeft, table, first, operator, second)
+}
+
+// RightJoin adds a RIGHT JOIN
+func (b *Builder) RightJoin(table string, first string, operator string, second string) databaseInterfaces.QueryBuilderInterface {
+	return b.addJoin(JoinRight, table, first, operator, second)
+}
+
+// OrderBy adds an ORDER BY clause, direction is "asc" or "desc"
+func (b *Builder) OrderBy(column string, direction string) databaseInterfaces.QueryBuilderInterface {
+	direction = strings.ToLower(direction)
+	if direction != DirectionDesc {
+		direction = DirectionAsc
+	}
+	b.components.Orders = append(b.components.Orders, Order{Column: column, Direction: direction})
+	return b
+}
+
+// GroupBy adds a GROUP BY clause
+func (b *Builder) GroupBy(columns ...string) databaseInterfaces.QueryBuilderInterface {
+	b.components.Groups = append(b.components.Groups, columns...)
+	return b
+}
+
+// Having adds a HAVING clause, with the operator fallback of Where
+func (b *Builder) Having(column string, operator string, value interface{}) databaseInterfaces.QueryBuilderInterface {
+	operator, value = b.prepareOperator(operator, value)
+	b.components.Havings = append(b.components.Havings, Where{
+		Type: WhereBasic, Boolean: BooleanAnd, Column: column, Operator: operator, Value: value,
+	})
+	return b
+}
+
+// Limit adds a LIMIT clause
+func (b *Builder) Limit(count int) databaseInterfaces.QueryBuilderInterface {
+	if count >= 0 {
+		b.components.Limit = count
+	}
+	return b
+}
+
+// Offset adds an OFFSET clause
+func (b *Builder) Offset(count int) databaseInterfaces.QueryBuilderInterface {
+	if count >= 0 {
+		b.components.Offset = count
+	}
+	return b
+}
+
+// UseWriteConnection runs selects of this builder on the write pool
+func (b *Builder) UseWriteConnection() databaseInterfaces.QueryBuilderInterface {
+	b.useWrite = true
+	return b
+}
+
+// Clone returns an independent copy of the builder
+func (b *Builder) Clone() databaseInterfaces.QueryBuilderInterface {
+	return b.CloneBuilder()
+}
+
+// CloneBuilder returns an independent copy of the builder as *Builder
+func (b *Builder) CloneBuilder() *Builder {
+	return &Builder{
+		executor:   b.executor,
+		grammar:    b.grammar,
+		components: b.components.Clone(),
+		useWrite:   b.useWrite,
+	}
+}
+
+// Get executes the select and returns the rows
+func (b *Builder) Get() (*sql.Rows, error) {
+	return b.GetContext(context.Background())
+}
+
+// GetContext executes the select with context and returns the rows
+func (b *Builder) GetContext(ctx context.Context) (*sql.Rows, error) {
+	query, bindings := b.grammar.CompileSelect(b.components)
+	return b.query(ctx, query, bindings)
+}
+
+// First executes the select limited to one row
+func (b *Builder) First() *sql.Row {
+	return b.FirstContext(context.Background())
+}
+
+// FirstContext executes the select limited to one row with context
+func (b *Builder) FirstContext(ctx context.Context) *sql.Row {
+	components := b.components.Clone()
+	components.Limit = 1
+	query, bindings := b.grammar.CompileSelect(components)
+	return b.queryRow(ctx, query, bindings)
+}
+
+// Count returns the number of matching rows
+func (b *Builder) Count() (int64, error) {
+	return b.CountContext(context.Background())
+}
+
+// CountContext returns the number of matching rows with context
+func (b *Builder) CountContext(ctx context.Context) (int64, error) {
+	query, bindings := b.grammar.CompileCount(b.components)
+
+	var count int64
+	if err := b.queryRow(ctx, query, bindings).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Exists returns true if any row matches
+func (b *Builder) Exists() (bool, error) {
+	return b.ExistsContext(context.Background())
+}
+
+// ExistsContext returns true if any row matches, with context
+func (b *Builder) ExistsContext(ctx context.Context) (bool, error) {
+	query, bindings := b.grammar.CompileExists(b.components)
+
+	var exists bool
+	if err := b.queryRow(ctx, query, bindings).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Insert inserts a row
+func (b *Builder) Insert(data map[string]interface{}) (sql.Result, error) {
+	return b.InsertContext(context.Background(), data)
+}
+
+// InsertContext inserts a row with context
+func (b *Builder) InsertContext(ctx context.Context, data map[string]interface{}) (sql.Result, error) {
+	return b.InsertBatchContext(ctx, []map[string]interface{}{data})
+}
+
+// InsertBatch inserts several rows in one statement. Every row must have
+// the columns of the first row.
+func (b *Builder) InsertBatch(rows []map[string]interface{}) (sql.Result, error) {
+	return b.InsertBatchContext(context.Background(), rows)
+}
+
+// InsertBatchContext inserts several rows in one statement with context
+func (b *Builder) InsertBatchContext(ctx context.Context, rows []map[string]interface{}) (sql.Result, error) {
+	if err := validateRows(rows); err != nil {
+		return nil, err
+	}
+	query, bindings := b.grammar.CompileInsert(b.components, rows)
+	return b.executor.ExecContext(ctx, query, bindings...)
+}
+
+// InsertGetID inserts a row and returns its "id"
+func (b *Builder) InsertGetID(data map[string]interface{}) (int64, error) {
+	return b.InsertGetIDContext(context.Background(), data)
+}
+
+// InsertGetIDContext inserts a row and returns its "id" with context.
+// Dialects with RETURNING read the id back, others use LastInsertId.
+func (b *Builder) InsertGetIDContext(ctx context.Context, data map[string]interface{}) (int64, error) {
+	if !b.grammar.SupportsReturning() {
+		result, err := b.InsertContext(ctx, data)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	if err := validateRows([]map[string]interface{}{data}); err != nil {
+		return 0, err
+	}
+	query, bindings := b.grammar.CompileInsert(b.components, []map[string]interface{}{data})
+	query += " " + b.grammar.CompileReturning([]string{"id"})
+
+	var id int64
+	if err := b.executor.WriteQueryRowContext(ctx, query, bindings...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Upsert inserts rows, updating the given columns of rows conflicting on
+// the uniqueBy columns. A nil update list updates every inserted column.
+func (b *Builder) Upsert(rows []map[string]interface{}, uniqueBy []string, update []string) (sql.Result, error) {
+	return b.UpsertContext(context.Background(), rows, uniqueBy, update)
+}
+
+// UpsertContext performs an upsert with context
+func (b *Builder) UpsertContext(ctx context.Context, rows []map[string]interface{}, uniqueBy []string, update []string) (sql.Result, error) {
+	if err := validateRows(rows); err != nil {
+		return nil, err
+	}
+	if len(uniqueBy) == 0 {
+		return nil, fmt.Errorf("database: upsert requires at least one unique column")
+	}
+	if update == nil {
+		update = sortedKeys(rows[0])
+	}
+	query, bindings := b.grammar.CompileUpsert(b.components, rows, uniqueBy, update)
+	return b.executor.ExecContext(ctx, query, bindings...)
+}
+
+// Update updates the matching rows
+func (b *Builder) Update(data map[string]interface{}) (sql.Result, error) {
+	return b.UpdateContext(context.Background(), data)
+}
+
+// UpdateContext updates the matching rows with context
+func (b *Builder) UpdateContext(ctx context.Context, data map[string]interface{}) (sql.Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("database: update requires at least one column")
+	}
+	query, bindings := b.grammar.CompileUpdate(b.components, data)
+	return b.executor.ExecContext(ctx, query, bindings...)
+}
+
+// Delete deletes the matching rows
+func (b *Builder) Delete() (sql.Result, error) {
+	return b.DeleteContext(context.Background())
+}
+
+// DeleteContext deletes the matching rows with context
+func (b *Builder) DeleteContext(ctx context.Context) (sql.Result, error) {
+	query, bindings := b.grammar.CompileDelete(b.components)
+	return b.executor.ExecContext(ctx, query, bindings...)
+}
+
+// InsertReturning inserts a row and returns the given columns
+func (b *Builder) InsertReturning(data map[string]interface{}, columns ...string) (*sql.Rows, error) {
+	return b.InsertReturningContext(context.Background(), data, columns...)
+}
+
+// InsertReturningContext inserts a row and returns the given columns, with context
+func (b *Builder) InsertReturningContext(ctx context.Context, data map[string]interface{}, columns ...string) (*sql.Rows, error) {
+	if err := validateRows([]map[string]interface{}{data}); err != nil {
+		return nil, err
+	}
+	query, bindings := b.grammar.CompileInsert(b.components, []map[string]interface{}{data})
+	return b.returning(ctx, query, bindings, columns)
+}
+
+// UpdateReturning updates the matching rows and returns the given columns
+func (b *Builder) UpdateReturning(data map[string]interface{}, columns ...string) (*sql.Rows, error) {
+	return b.UpdateReturningContext(context.Background(), data, columns...)
+}
+
+// UpdateReturningContext updates the matching rows and returns the given columns, with context
+func (b *Builder) UpdateReturningContext(ctx context.Context, data map[string]interface{}, columns ...string) (*sql.Rows, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("database: update requires at least one column")
+	}
+	query, bindings := b.grammar.CompileUpdate(b.components, data)
+	return b.returning(ctx, query, bindings, columns)
+}
+
+// DeleteReturning deletes the matching rows and returns the given columns
+func (b *Builder) DeleteReturning(columns ...string) (*sql.Rows, error) {
+	return b.DeleteReturningContext(context.Background(), columns...)
+}
+
+// DeleteReturningContext deletes the matching rows and returns the given columns, with context
+func (b *Builder) DeleteReturningContext(ctx context.Context, columns ...string) (*sql.Rows, error) {
+	query, bindings := b.grammar.CompileDelete(b.components)
+	return b.returning(ctx, query, bindings, columns)
+}
+
+// ToSQL compiles the select statement
+func (b *Builder) ToSQL() (string, []interface{}, error) {
+	if b.components.Table == "" {
+		return "", nil, fmt.Errorf("database: no table selected")
+	}
+	query, bindings := b.grammar.CompileSelect(b.components)
+	return query, bindings, nil
+}
+
+// GetBindings returns the bindings of the select statement
+func (b *Builder) GetBindings() []interface{} {
+	_, bindings := b.grammar.CompileSelect(b.components)
+	return bindings
+}
+
+// addWhere appends a basic condition, turning nil comparisons into null checks
+func (b *Builder) addWhere(boolean, column, operator string, value interface{}) {
+	operator, value = b.prepareOperator(operator, value)
+	if value == nil && (operator == "=" || operator == "!=" || operator == "<>") {
+		whereType := WhereNull
+		if operator != "=" {
+			whereType = WhereNotNull
+		}
+		b.components.Wheres = append(b.components.Wheres, Where{Type: whereType, Boolean: boolean, Column: column})
+		return
+	}
+
+	b.components.Wheres = append(b.components.Wheres, Where{
+		Type: WhereBasic, Boolean: boolean, Column: column, Operator: operator, Value: value,
+	})
+}
+
+func (b *Builder) addJoin(joinType, table, first, operator, second string) *Builder {
+	normalized := normalizeOperator(operator)
+	if !b.grammar.IsOperator(normalized) {
+		normalized, second = defaultOperator, operator
+	}
+	b.components.Joins = append(b.components.Joins, Join{
+		Type: joinType, Table: table, First: first, Operator: normalized, Second: second,
+	})
+	return b
+}
+
+// prepareOperator normalizes the operator of a condition. Operators the
+// grammar does not support never reach the SQL: they become the value,
+// compared with "=".
+func (b *Builder) prepareOperator(operator string, value interface{}) (string, interface{}) {
+	normalized := normalizeOperator(operator)
+	if !b.grammar.IsOperator(normalized) {
+		return defaultOperator, operator
+	}
+	return normalized, value
+}
+
+func (b *Builder) query(ctx context.Context, query string, bindings []interface{}) (*sql.Rows, error) {
+	if b.useWrite {
+		return b.executor.WriteQueryContext(ctx, query, bindings...)
+	}
+	return b.executor.QueryContext(ctx, query, bindings...)
+}
+
+func (b *Builder) queryRow(ctx context.Context, query string, bindings []interface{}) *sql.Row {
+	if b.useWrite {
+		return b.executor.WriteQueryRowContext(ctx, query, bindings...)
+	}
+	return b.executor.QueryRowContext(ctx, query, bindings...)
+}
+
+// returning appends a RETURNING clause and runs the statement on the write pool
+func (b *Builder) returning(ctx context.Context, query string, bindings []interface{}, columns []string) (*sql.Rows, error) {
+	if !b.grammar.SupportsReturning() {
+		return nil, ErrReturningNotSupported
+	}
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+	return b.executor.WriteQueryContext(ctx, query+" "+b.grammar.CompileReturning(columns), bindings...)
+}
+
+// normalizeOperator lowercases operators and defaults to "="
+func normalizeOperator(operator string) string {
+	operator = strings.ToLower(strings.TrimSpace(operator))
+	if operator == "" {
+		return defaultOperator
+	}
+	return operator
+}
+
+// validateRows ensures there is at least one row and that all rows share
+// the columns of the first row
+func validateRows(rows []map[string]interface{}) error {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return fmt.Errorf("database: insert requires at least one column")
+	}
+	for idx, row := range rows[1:] {
+		if len(row) != len(rows[0]) {
+			return fmt.Errorf("database: row %d has different columns than the first row", idx+1)
+		}
+		for column := range rows[0] {
+			if _, ok := row[column]; !ok {
+				return fmt.Errorf("database: row %d is missing column %q", idx+1, column)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the row in a stable order
+func sortedKeys(row map[string]interface{}) []string {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SortedColumns returns the columns of the row in the order used by the grammars
+func SortedColumns(row map[string]interface{}) []string {
+	return sortedKeys(row)
+}
+
+// Compile-time interface compliance check
+var _ databaseInterfaces.QueryBuilderInterface = (*Builder)(nil)
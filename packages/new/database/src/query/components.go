@@ -0,0 +1,90 @@
+package query
+
+// Where types understood by the grammars
+const (
+	WhereBasic      = "basic"
+	WhereIn         = "in"
+	WhereNotIn      = "not_in"
+	WhereNull       = "null"
+	WhereNotNull    = "not_null"
+	WhereBetween    = "between"
+	WhereRawType    = "raw"
+	BooleanAnd      = "and"
+	BooleanOr       = "or"
+	JoinInner       = "inner"
+	JoinLeft        = "left"
+	JoinRight       = "right"
+	DirectionAsc    = "asc"
+	DirectionDesc   = "desc"
+	NoLimit         = -1
+	defaultOperator = "="
+)
+
+// Where is a single WHERE or HAVING condition
+type Where struct {
+	Type     string
+	Boolean  string
+	Column   string
+	Operator string
+	Value    interface{}
+	Values   []interface{}
+	SQL      string
+}
+
+// Join is a JOIN clause
+type Join struct {
+	Type     string
+	Table    string
+	First    string
+	Operator string
+	Second   string
+}
+
+// Order is an ORDER BY column
+type Order struct {
+	Column    string
+	Direction string
+}
+
+// Components holds the state of a query compiled by a Grammar
+type Components struct {
+	Table    string
+	Columns  []interface{}
+	Distinct bool
+	Joins    []Join
+	Wheres   []Where
+	Groups   []string
+	Havings  []Where
+	Orders   []Order
+	Limit    int
+	Offset   int
+}
+
+// NewComponents creates empty query components
+func NewComponents() *Components {
+	return &Components{Limit: NoLimit}
+}
+
+// Clone returns a deep copy of the components
+func (c *Components) Clone() *Components {
+	clone := *c
+	clone.Columns = append([]interface{}(nil), c.Columns...)
+	clone.Joins = append([]Join(nil), c.Joins...)
+	clone.Wheres = cloneWheres(c.Wheres)
+	clone.Groups = append([]string(nil), c.Groups...)
+	clone.Havings = cloneWheres(c.Havings)
+	clone.Orders = append([]Order(nil), c.Orders...)
+	return &clone
+}
+
+func cloneWheres(wheres []Where) []Where {
+	if wheres == nil {
+		return nil
+	}
+	cloned := make([]Where, len(wheres))
+	for idx, where := range wheres {
+		where.Values = append([]interface{}(nil), where.Values...)
+		cloned[idx] = where
+	}
+	return cloned
+}
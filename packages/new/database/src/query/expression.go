@@ -0,0 +1,20 @@
+package query
+
+// Expression is a raw SQL fragment that is never quoted or bound
+type Expression struct {
+	Value string
+}
+
+// Raw creates a new raw expression.
+//
+// Example:
+//
+//	builder.Update(map[string]interface{}{"votes": query.Raw("votes + 1")})
+func Raw(value string) Expression {
+	return Expression{Value: value}
+}
+
+// String returns the raw SQL
+func (e Expression) String() string {
+	return e.Value
+}
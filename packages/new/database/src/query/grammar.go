@@ -0,0 +1,53 @@
+package query
+
+// Grammar compiles query components into dialect specific SQL.
+// Every compile method returns the SQL together with its bindings in
+// placeholder order.
+type Grammar interface {
+	// Driver returns the GoVel driver name of the dialect
+	Driver() string
+
+	// Wrap quotes an identifier such as "users.name" or "name as alias".
+	// Every identifier is quoted; raw SQL goes through Expression.
+	Wrap(value string) string
+
+	// WrapTable quotes a table name and applies the table prefix
+	WrapTable(table string) string
+
+	// Parameter returns the placeholder for the binding at the 1-based index
+	Parameter(index int) string
+
+	// SupportsReturning reports whether RETURNING clauses are available
+	SupportsReturning() bool
+
+	// IsOperator reports whether the lowercase operator is a comparison
+	// operator of the dialect
+	IsOperator(operator string) bool
+
+	CompileSelect(components *Components) (string, []interface{})
+	CompileCount(components *Components) (string, []interface{})
+	CompileExists(components *Components) (string, []interface{})
+	CompileInsert(components *Components, rows []map[string]interface{}) (string, []interface{})
+	CompileUpsert(components *Components, rows []map[string]interface{}, uniqueBy []string, update []string) (string, []interface{})
+	CompileUpdate(components *Components, values map[string]interface{}) (string, []interface{})
+	CompileDelete(components *Components) (string, []interface{})
+	CompileReturning(columns []string) string
+
+	// CompileTableExists returns a query selecting a count of tables named
+	// by the single binding
+	CompileTableExists(table string) (string, []interface{})
+
+	// CompileTables returns a query selecting the table names
+	CompileTables() string
+
+	// CompileColumns returns a query selecting name, type, nullable,
+	// default, max length, precision, scale, primary, unique and
+	// auto increment for every column of the table
+	CompileColumns(table string) (string, []interface{})
+
+	// QuoteValue quotes a value for safe inclusion in SQL
+	QuoteValue(value interface{}) string
+
+	// Escape escapes a string for inclusion in a quoted literal
+	Escape(value string) string
+}
@@ -0,0 +1,367 @@
+package schema
+
+import "strings"
+
+// Blueprint command names
+const (
+	CommandPrimary      = "primary"
+	CommandUnique       = "unique"
+	CommandIndex        = "index"
+	CommandForeign      = "foreign"
+	CommandDropColumn   = "dropColumn"
+	CommandRenameColumn = "renameColumn"
+	CommandDropPrimary  = "dropPrimary"
+	CommandDropIndex    = "dropIndex"
+	CommandDropUnique   = "dropUnique"
+	CommandDropForeign  = "dropForeign"
+)
+
+// Command is a table level operation recorded by a blueprint
+type Command struct {
+	Name    string
+	Columns []string
+	Index   string
+	From    string
+	To      string
+	Foreign *ForeignKeyDefinition
+}
+
+// Blueprint collects the columns and commands of a table definition.
+// It is passed to the callbacks of Builder.Create and Builder.Table and
+// compiled into dialect specific statements once the callback returns.
+type Blueprint struct {
+	// table is the unprefixed table name
+	table string
+
+	// prefix is the connection table prefix used for index names
+	prefix string
+
+	// creating reports whether the blueprint creates the table
+	creating bool
+
+	// columns holds the columns in definition order
+	columns []*ColumnDefinition
+
+	// commands holds the table level commands in definition order
+	commands []*Command
+
+	// Engine, Charset and Collation apply to MySQL tables
+	Engine    string
+	Charset   string
+	Collation string
+}
+
+// NewBlueprint creates a blueprint for the table
+func NewBlueprint(table string, prefix string, creating bool) *Blueprint {
+	return &Blueprint{table: table, prefix: prefix, creating: creating}
+}
+
+// GetTable returns the table name
+func (b *Blueprint) GetTable() string {
+	return b.table
+}
+
+// Creating reports whether the blueprint creates a new table
+func (b *Blueprint) Creating() bool {
+	return b.creating
+}
+
+// Columns returns the added columns
+func (b *Blueprint) Columns() []*ColumnDefinition {
+	return b.columns
+}
+
+// Commands returns the recorded commands
+func (b *Blueprint) Commands() []*Command {
+	return b.commands
+}
+
+// AddColumn adds a column of the given type
+func (b *Blueprint) AddColumn(columnType string, name string) *ColumnDefinition {
+	column := &ColumnDefinition{blueprint: b, Name: name, Type: columnType}
+	b.columns = append(b.columns, column)
+	return column
+}
+
+// ID adds an auto incrementing big integer primary key named "id" or the given name
+func (b *Blueprint) ID(name ...string) *ColumnDefinition {
+	column := "id"
+	if len(name) > 0 && name[0] != "" {
+		column = name[0]
+	}
+	return b.BigIncrements(column)
+}
+
+// BigIncrements adds an auto incrementing big integer primary key
+func (b *Blueprint) BigIncrements(name string) *ColumnDefinition {
+	return b.AddColumn(TypeBigIncrements, name)
+}
+
+// Increments adds an auto incrementing integer primary key
+func (b *Blueprint) Increments(name string) *ColumnDefinition {
+	return b.AddColumn(TypeIncrements, name)
+}
+
+// String adds a VARCHAR column, 255 characters long unless a length is given
+func (b *Blueprint) String(name string, length ...int) *ColumnDefinition {
+	column := b.AddColumn(TypeString, name)
+	column.Length = 255
+	if len(length) > 0 && length[0] > 0 {
+		column.Length = length[0]
+	}
+	return column
+}
+
+// Char adds a fixed length CHAR column
+func (b *Blueprint) Char(name string, length int) *ColumnDefinition {
+	column := b.AddColumn(TypeChar, name)
+	column.Length = length
+	return column
+}
+
+// Text adds a TEXT column
+func (b *Blueprint) Text(name string) *ColumnDefinition {
+	return b.AddColumn(TypeText, name)
+}
+
+// Integer adds an INTEGER column
+func (b *Blueprint) Integer(name string) *ColumnDefinition {
+	return b.AddColumn(TypeInteger, name)
+}
+
+// BigInteger adds a BIGINT column
+func (b *Blueprint) BigInteger(name string) *ColumnDefinition {
+	return b.AddColumn(TypeBigInteger, name)
+}
+
+// SmallInteger adds a SMALLINT column
+func (b *Blueprint) SmallInteger(name string) *ColumnDefinition {
+	return b.AddColumn(TypeSmallInteger, name)
+}
+
+// TinyInteger adds a TINYINT column
+func (b *Blueprint) TinyInteger(name string) *ColumnDefinition {
+	return b.AddColumn(TypeTinyInteger, name)
+}
+
+// UnsignedBigInteger adds an unsigned BIGINT column
+func (b *Blueprint) UnsignedBigInteger(name string) *ColumnDefinition {
+	return b.BigInteger(name).Unsigned()
+}
+
+// ForeignID adds an unsigned BIGINT column meant to reference another
+// table; chain Constrained to add the foreign key
+func (b *Blueprint) ForeignID(name string) *ColumnDefinition {
+	return b.UnsignedBigInteger(name)
+}
+
+// Boolean adds a BOOLEAN column
+func (b *Blueprint) Boolean(name string) *ColumnDefinition {
+	return b.AddColumn(TypeBoolean, name)
+}
+
+// Decimal adds a DECIMAL column with the given precision and scale
+func (b *Blueprint) Decimal(name string, precision int, scale int) *ColumnDefinition {
+	column := b.AddColumn(TypeDecimal, name)
+	column.Precision = precision
+	column.Scale = scale
+	return column
+}
+
+// Float adds a single precision floating point column
+func (b *Blueprint) Float(name string) *ColumnDefinition {
+	return b.AddColumn(TypeFloat, name)
+}
+
+// Double adds a double precision floating point column
+func (b *Blueprint) Double(name string) *ColumnDefinition {
+	return b.AddColumn(TypeDouble, name)
+}
+
+// Date adds a DATE column
+func (b *Blueprint) Date(name string) *ColumnDefinition {
+	return b.AddColumn(TypeDate, name)
+}
+
+// DateTime adds a DATETIME column
+func (b *Blueprint) DateTime(name string) *ColumnDefinition {
+	return b.AddColumn(TypeDateTime, name)
+}
+
+// Timestamp adds a TIMESTAMP column
+func (b *Blueprint) Timestamp(name string) *ColumnDefinition {
+	return b.AddColumn(TypeTimestamp, name)
+}
+
+// Time adds a TIME column
+func (b *Blueprint) Time(name string) *ColumnDefinition {
+	return b.AddColumn(TypeTime, name)
+}
+
+// Timestamps adds nullable created_at and updated_at columns
+func (b *Blueprint) Timestamps() {
+	b.Timestamp("created_at").Nullable()
+	b.Timestamp("updated_at").Nullable()
+}
+
+// SoftDeletes adds a nullable deleted_at column
+func (b *Blueprint) SoftDeletes(name ...string) *ColumnDefinition {
+	column := "deleted_at"
+	if len(name) > 0 && name[0] != "" {
+		column = name[0]
+	}
+	return b.Timestamp(column).Nullable()
+}
+
+// RememberToken adds a nullable remember_token column
+func (b *Blueprint) RememberToken() *ColumnDefinition {
+	return b.String("remember_token", 100).Nullable()
+}
+
+// JSON adds a JSON column
+func (b *Blueprint) JSON(name string) *ColumnDefinition {
+	return b.AddColumn(TypeJSON, name)
+}
+
+// UUID adds a UUID column
+func (b *Blueprint) UUID(name string) *ColumnDefinition {
+	return b.AddColumn(TypeUUID, name)
+}
+
+// Binary adds a binary large object column
+func (b *Blueprint) Binary(name string) *ColumnDefinition {
+	return b.AddColumn(TypeBinary, name)
+}
+
+// Enum adds a column restricted to the allowed values
+func (b *Blueprint) Enum(name string, allowed []string) *ColumnDefinition {
+	column := b.AddColumn(TypeEnum, name)
+	column.Allowed = allowed
+	return column
+}
+
+// Primary adds a (composite) primary key
+func (b *Blueprint) Primary(columns ...string) *Command {
+	return b.indexCommand(CommandPrimary, columns)
+}
+
+// Unique adds a unique index on the columns
+func (b *Blueprint) Unique(columns ...string) *Command {
+	return b.indexCommand(CommandUnique, columns)
+}
+
+// Index adds an index on the columns
+func (b *Blueprint) Index(columns ...string) *Command {
+	return b.indexCommand(CommandIndex, columns)
+}
+
+// Foreign adds a foreign key on the columns
+func (b *Blueprint) Foreign(columns ...string) *ForeignKeyDefinition {
+	foreign := &ForeignKeyDefinition{
+		Columns:   columns,
+		IndexName: b.IndexName(CommandForeign, columns),
+	}
+	b.commands = append(b.commands, &Command{
+		Name:    CommandForeign,
+		Columns: columns,
+		Foreign: foreign,
+	})
+	return foreign
+}
+
+// DropColumn drops the columns
+func (b *Blueprint) DropColumn(columns ...string) {
+	b.commands = append(b.commands, &Command{Name: CommandDropColumn, Columns: columns})
+}
+
+// RenameColumn renames a column
+func (b *Blueprint) RenameColumn(from string, to string) {
+	b.commands = append(b.commands, &Command{Name: CommandRenameColumn, From: from, To: to})
+}
+
+// DropTimestamps drops the created_at and updated_at columns
+func (b *Blueprint) DropTimestamps() {
+	b.DropColumn("created_at", "updated_at")
+}
+
+// DropSoftDeletes drops the deleted_at column
+func (b *Blueprint) DropSoftDeletes() {
+	b.DropColumn("deleted_at")
+}
+
+// DropPrimary drops the primary key
+func (b *Blueprint) DropPrimary(name ...string) {
+	b.dropIndexCommand(CommandDropPrimary, CommandPrimary, name)
+}
+
+// DropIndex drops an index by name or by its columns
+func (b *Blueprint) DropIndex(nameOrColumns ...string) {
+	b.dropIndexCommand(CommandDropIndex, CommandIndex, nameOrColumns)
+}
+
+// DropUnique drops a unique index by name or by its columns
+func (b *Blueprint) DropUnique(nameOrColumns ...string) {
+	b.dropIndexCommand(CommandDropUnique, CommandUnique, nameOrColumns)
+}
+
+// DropForeign drops a foreign key by name or by its columns
+func (b *Blueprint) DropForeign(nameOrColumns ...string) {
+	b.dropIndexCommand(CommandDropForeign, CommandForeign, nameOrColumns)
+}
+
+// IndexName returns the conventional index name for the columns,
+// e.g. "users_email_unique"
+func (b *Blueprint) IndexName(kind string, columns []string) string {
+	name := strings.ToLower(b.prefix + b.table + "_" + strings.Join(columns, "_") + "_" + kind)
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// indexCommand records an index command with its generated name
+func (b *Blueprint) indexCommand(name string, columns []string) *Command {
+	command := &Command{Name: name, Columns: columns, Index: b.IndexName(name, columns)}
+	b.commands = append(b.commands, command)
+	return command
+}
+
+// dropIndexCommand records a drop command; a single argument is an index
+// name, several arguments are the indexed columns
+func (b *Blueprint) dropIndexCommand(name string, kind string, nameOrColumns []string) {
+	var index string
+	switch {
+	case len(nameOrColumns) == 1 && strings.HasSuffix(nameOrColumns[0], "_"+kind):
+		index = nameOrColumns[0]
+	case len(nameOrColumns) > 0:
+		index = b.IndexName(kind, nameOrColumns)
+	default:
+		index = strings.ToLower(b.prefix + b.table + "_" + kind)
+	}
+	b.commands = append(b.commands, &Command{Name: name, Index: index, Columns: nameOrColumns})
+}
+
+// Named overrides the generated index name
+func (c *Command) Named(name string) *Command {
+	c.Index = name
+	return c
+}
+
+// allCommands returns the column level Primary, Unique and Index modifiers
+// as commands followed by the recorded commands
+func (b *Blueprint) allCommands() []*Command {
+	commands := make([]*Command, 0, len(b.commands))
+	for _, column := range b.columns {
+		if column.isIncrementing() {
+			continue
+		}
+		columns := []string{column.Name}
+		if column.IsPrimary {
+			commands = append(commands, &Command{Name: CommandPrimary, Columns: columns, Index: b.IndexName(CommandPrimary, columns)})
+		}
+		if column.IsUnique {
+			commands = append(commands, &Command{Name: CommandUnique, Columns: columns, Index: b.IndexName(CommandUnique, columns)})
+		}
+		if column.IsIndex {
+			commands = append(commands, &Command{Name: CommandIndex, Columns: columns, Index: b.IndexName(CommandIndex, columns)})
+		}
+	}
+	return append(commands, b.commands...)
+}
@@ -0,0 +1,281 @@
+// Package schema builds and runs the DDL statements that create and alter
+// tables.
+//
+// Tables are described with a Blueprint inside Builder.Create or
+// Builder.Table callbacks and compiled by the Grammar of the connection
+// dialect:
+//
+//	schema.Create("users", func(t *schema.Blueprint) {
+//		t.ID()
+//		t.String("email").Unique()
+//		t.Timestamps()
+//	})
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"govel/new/database/query"
+	schemaInterfaces "govel/types/interfaces/schema"
+)
+
+// Executor runs schema statements; connections, *sql.DB and *sql.Tx
+// satisfy it
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Builder runs schema operations against a connection
+type Builder struct {
+	// executor runs the statements
+	executor Executor
+
+	// grammar compiles blueprints for the connection dialect
+	grammar *Grammar
+
+	// ctx is used for every statement
+	ctx context.Context
+
+	// pretend records statements instead of running them
+	pretend bool
+
+	// mu protects statements
+	mu sync.Mutex
+
+	// statements holds the statements recorded while pretending
+	statements []string
+}
+
+// NewBuilder creates a schema builder for the executor and query grammar
+func NewBuilder(executor Executor, queryGrammar query.Grammar) (*Builder, error) {
+	grammar, err := NewGrammar(queryGrammar)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{executor: executor, grammar: grammar, ctx: context.Background()}, nil
+}
+
+// WithContext returns a builder running its statements with the context
+func (b *Builder) WithContext(ctx context.Context) *Builder {
+	return &Builder{executor: b.executor, grammar: b.grammar, ctx: ctx, pretend: b.pretend}
+}
+
+// WithExecutor returns a builder running its statements on the executor,
+// typically a transaction
+func (b *Builder) WithExecutor(executor Executor) *Builder {
+	return &Builder{executor: executor, grammar: b.grammar, ctx: b.ctx, pretend: b.pretend}
+}
+
+// Pretend returns a builder that records its statements instead of running them
+func (b *Builder) Pretend() *Builder {
+	return &Builder{executor: b.executor, grammar: b.grammar, ctx: b.ctx, pretend: true}
+}
+
+// IsPretending reports whether statements are recorded instead of run
+func (b *Builder) IsPretending() bool {
+	return b.pretend
+}
+
+// Statements returns the statements recorded while pretending
+func (b *Builder) Statements() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.statements...)
+}
+
+// Grammar returns the schema grammar
+func (b *Builder) Grammar() *Grammar {
+	return b.grammar
+}
+
+// Create creates a table described by the callback
+func (b *Builder) Create(table string, callback func(table *Blueprint)) error {
+	return b.build(NewBlueprint(table, b.prefix(), true), callback)
+}
+
+// Table alters an existing table as described by the callback
+func (b *Builder) Table(table string, callback func(table *Blueprint)) error {
+	return b.build(NewBlueprint(table, b.prefix(), false), callback)
+}
+
+// ToSQL returns the statements a Create (creating) or Table callback compiles to
+func (b *Builder) ToSQL(table string, creating bool, callback func(table *Blueprint)) ([]string, error) {
+	blueprint := NewBlueprint(table, b.prefix(), creating)
+	callback(blueprint)
+	return b.grammar.Compile(blueprint)
+}
+
+// Drop drops a table
+func (b *Builder) Drop(table string) error {
+	return b.Statement(b.grammar.CompileDrop(table))
+}
+
+// DropIfExists drops a table when it exists
+func (b *Builder) DropIfExists(table string) error {
+	return b.Statement(b.grammar.CompileDropIfExists(table))
+}
+
+// Rename renames a table
+func (b *Builder) Rename(from string, to string) error {
+	return b.Statement(b.grammar.CompileRename(from, to))
+}
+
+// DropAllTables drops every table of the database with foreign key
+// constraints disabled
+func (b *Builder) DropAllTables() error {
+	tables, err := b.GetTables()
+	if err != nil {
+		return err
+	}
+	statements := b.grammar.CompileDropAllTables(tables)
+	if len(statements) == 0 {
+		return nil
+	}
+
+	if err := b.DisableForeignKeyConstraints(); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := b.Statement(statement); err != nil {
+			return err
+		}
+	}
+	return b.EnableForeignKeyConstraints()
+}
+
+// HasTable reports whether the table exists
+func (b *Builder) HasTable(table string) (bool, error) {
+	sql, bindings := b.grammar.query.CompileTableExists(table)
+	var count int
+	if err := b.executor.QueryRowContext(b.ctx, sql, bindings...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// HasColumn reports whether the table has the column
+func (b *Builder) HasColumn(table string, column string) (bool, error) {
+	return b.HasColumns(table, column)
+}
+
+// HasColumns reports whether the table has all of the columns
+func (b *Builder) HasColumns(table string, columns ...string) (bool, error) {
+	listing, err := b.GetColumnListing(table)
+	if err != nil {
+		return false, err
+	}
+	existing := make(map[string]bool, len(listing))
+	for _, name := range listing {
+		existing[name] = true
+	}
+	for _, column := range columns {
+		if !existing[column] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetTables returns the (prefixed) table names of the database
+func (b *Builder) GetTables() ([]string, error) {
+	rows, err := b.executor.QueryContext(b.ctx, b.grammar.query.CompileTables())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// GetColumnListing returns the column names of the table
+func (b *Builder) GetColumnListing(table string) ([]string, error) {
+	sql, bindings := b.grammar.query.CompileColumns(table)
+	rows, err := b.executor.QueryContext(b.ctx, sql, bindings...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		values := make([]interface{}, len(fields))
+		values[0] = &name
+		for idx := 1; idx < len(values); idx++ {
+			values[idx] = new(interface{})
+		}
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
+
+// EnableForeignKeyConstraints turns foreign key enforcement on
+func (b *Builder) EnableForeignKeyConstraints() error {
+	return b.Statement(b.grammar.CompileEnableForeignKeyConstraints())
+}
+
+// DisableForeignKeyConstraints turns foreign key enforcement off
+func (b *Builder) DisableForeignKeyConstraints() error {
+	return b.Statement(b.grammar.CompileDisableForeignKeyConstraints())
+}
+
+// Statement runs a raw schema statement, or records it when pretending
+func (b *Builder) Statement(statement string, args ...interface{}) error {
+	if b.pretend {
+		b.mu.Lock()
+		b.statements = append(b.statements, statement)
+		b.mu.Unlock()
+		return nil
+	}
+	if _, err := b.executor.ExecContext(b.ctx, statement, args...); err != nil {
+		return fmt.Errorf("schema: %w (%s)", err, statement)
+	}
+	return nil
+}
+
+// build runs the callback and the statements its blueprint compiles to
+func (b *Builder) build(blueprint *Blueprint, callback func(table *Blueprint)) error {
+	callback(blueprint)
+	statements, err := b.grammar.Compile(blueprint)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := b.Statement(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefix returns the table prefix of the connection grammar
+func (b *Builder) prefix() string {
+	if prefixed, ok := b.grammar.query.(interface{ Prefix() string }); ok {
+		return prefixed.Prefix()
+	}
+	return ""
+}
+
+// Compile-time interface compliance check
+var _ schemaInterfaces.SchemaInterface = (*Builder)(nil)
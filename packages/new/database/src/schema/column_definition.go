@@ -0,0 +1,192 @@
+package schema
+
+import "strings"
+
+// Column types understood by the schema grammars
+const (
+	TypeBigIncrements = "bigIncrements"
+	TypeIncrements    = "increments"
+	TypeString        = "string"
+	TypeChar          = "char"
+	TypeText          = "text"
+	TypeInteger       = "integer"
+	TypeBigInteger    = "bigInteger"
+	TypeSmallInteger  = "smallInteger"
+	TypeTinyInteger   = "tinyInteger"
+	TypeBoolean       = "boolean"
+	TypeDecimal       = "decimal"
+	TypeFloat         = "float"
+	TypeDouble        = "double"
+	TypeDate          = "date"
+	TypeDateTime      = "dateTime"
+	TypeTimestamp     = "timestamp"
+	TypeTime          = "time"
+	TypeJSON          = "json"
+	TypeUUID          = "uuid"
+	TypeBinary        = "binary"
+	TypeEnum          = "enum"
+)
+
+// ColumnDefinition describes a column added by a blueprint
+type ColumnDefinition struct {
+	blueprint *Blueprint
+
+	Name                string
+	Type                string
+	Length              int
+	Precision           int
+	Scale               int
+	Allowed             []string
+	IsNullable          bool
+	DefaultValue        interface{}
+	HasDefault          bool
+	IsUnsigned          bool
+	IsPrimary           bool
+	IsUnique            bool
+	IsIndex             bool
+	CommentText         string
+	UseCurrentTimestamp bool
+}
+
+// Nullable allows NULL values in the column
+func (c *ColumnDefinition) Nullable(nullable ...bool) *ColumnDefinition {
+	c.IsNullable = len(nullable) == 0 || nullable[0]
+	return c
+}
+
+// Default sets the default value of the column
+func (c *ColumnDefinition) Default(value interface{}) *ColumnDefinition {
+	c.DefaultValue = value
+	c.HasDefault = true
+	return c
+}
+
+// UseCurrent defaults a timestamp column to CURRENT_TIMESTAMP
+func (c *ColumnDefinition) UseCurrent() *ColumnDefinition {
+	c.UseCurrentTimestamp = true
+	return c
+}
+
+// Unsigned marks an integer column unsigned (MySQL only)
+func (c *ColumnDefinition) Unsigned() *ColumnDefinition {
+	c.IsUnsigned = true
+	return c
+}
+
+// Primary makes the column the primary key
+func (c *ColumnDefinition) Primary() *ColumnDefinition {
+	c.IsPrimary = true
+	return c
+}
+
+// Unique adds a unique index on the column
+func (c *ColumnDefinition) Unique() *ColumnDefinition {
+	c.IsUnique = true
+	return c
+}
+
+// Index adds an index on the column
+func (c *ColumnDefinition) Index() *ColumnDefinition {
+	c.IsIndex = true
+	return c
+}
+
+// Comment sets the column comment (MySQL only)
+func (c *ColumnDefinition) Comment(comment string) *ColumnDefinition {
+	c.CommentText = comment
+	return c
+}
+
+// Constrained adds a foreign key referencing the "id" column of the given
+// table, guessed from the column name when omitted ("user_id" references
+// "users").
+func (c *ColumnDefinition) Constrained(table ...string) *ForeignKeyDefinition {
+	referenced := guessTable(c.Name)
+	if len(table) > 0 && table[0] != "" {
+		referenced = table[0]
+	}
+	return c.blueprint.Foreign(c.Name).References("id").On(referenced)
+}
+
+// References adds a foreign key on the column referencing the given column
+func (c *ColumnDefinition) References(column string) *ForeignKeyDefinition {
+	return c.blueprint.Foreign(c.Name).References(column)
+}
+
+// isIncrementing reports whether the column is an auto incrementing key
+func (c *ColumnDefinition) isIncrementing() bool {
+	return c.Type == TypeBigIncrements || c.Type == TypeIncrements
+}
+
+// isInteger reports whether the column stores integers
+func (c *ColumnDefinition) isInteger() bool {
+	switch c.Type {
+	case TypeInteger, TypeBigInteger, TypeSmallInteger, TypeTinyInteger:
+		return true
+	default:
+		return false
+	}
+}
+
+// guessTable derives a plural table name from a foreign key column
+func guessTable(column string) string {
+	name := strings.TrimSuffix(column, "_id")
+	switch {
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ay") && !strings.HasSuffix(name, "ey") && !strings.HasSuffix(name, "oy"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	case strings.HasSuffix(name, "s") || strings.HasSuffix(name, "x") || strings.HasSuffix(name, "ch") || strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// ForeignKeyDefinition describes a foreign key constraint
+type ForeignKeyDefinition struct {
+	Columns        []string
+	Referenced     []string
+	Table          string
+	OnDeleteAction string
+	OnUpdateAction string
+	IndexName      string
+}
+
+// References sets the referenced columns
+func (f *ForeignKeyDefinition) References(columns ...string) *ForeignKeyDefinition {
+	f.Referenced = columns
+	return f
+}
+
+// On sets the referenced table
+func (f *ForeignKeyDefinition) On(table string) *ForeignKeyDefinition {
+	f.Table = table
+	return f
+}
+
+// OnDelete sets the ON DELETE action, such as "cascade" or "set null"
+func (f *ForeignKeyDefinition) OnDelete(action string) *ForeignKeyDefinition {
+	f.OnDeleteAction = action
+	return f
+}
+
+// OnUpdate sets the ON UPDATE action
+func (f *ForeignKeyDefinition) OnUpdate(action string) *ForeignKeyDefinition {
+	f.OnUpdateAction = action
+	return f
+}
+
+// CascadeOnDelete deletes referencing rows with the referenced row
+func (f *ForeignKeyDefinition) CascadeOnDelete() *ForeignKeyDefinition {
+	return f.OnDelete("cascade")
+}
+
+// NullOnDelete sets referencing columns to NULL when the referenced row is deleted
+func (f *ForeignKeyDefinition) NullOnDelete() *ForeignKeyDefinition {
+	return f.OnDelete("set null")
+}
+
+// Name overrides the generated constraint name
+func (f *ForeignKeyDefinition) Name(name string) *ForeignKeyDefinition {
+	f.IndexName = name
+	return f
+}
@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"govel/new/database/query"
+)
+
+// dialect holds the parts of DDL that differ between databases
+type dialect interface {
+	// columnType returns the SQL type of a column
+	columnType(g *Grammar, column *ColumnDefinition) string
+
+	// incrementing returns the modifiers of an auto incrementing key
+	incrementing(column *ColumnDefinition) string
+
+	// columnModifiers returns dialect specific trailing modifiers
+	columnModifiers(g *Grammar, column *ColumnDefinition) string
+
+	// tableOptions returns the options appended to CREATE TABLE
+	tableOptions(g *Grammar, blueprint *Blueprint) string
+
+	// compileCommand compiles commands whose syntax varies; ok is false
+	// when the generic compilation applies
+	compileCommand(g *Grammar, blueprint *Blueprint, command *Command) (sql []string, ok bool, err error)
+
+	// compileRename renames a table
+	compileRename(g *Grammar, from string, to string) string
+
+	// compileDropAllTables drops the given (prefixed) tables
+	compileDropAllTables(g *Grammar, tables []string) []string
+
+	// compileForeignKeyConstraints toggles foreign key enforcement
+	compileForeignKeyConstraints(enabled bool) string
+}
+
+// Grammar compiles blueprints into DDL statements. Identifiers are quoted
+// and prefixed by the query grammar of the connection; the dialect adds
+// type names and the statements that differ between databases.
+type Grammar struct {
+	// query is the query grammar of the connection
+	query query.Grammar
+
+	// dialect holds the database specific compilation
+	dialect dialect
+}
+
+// NewGrammar creates the schema grammar matching a query grammar
+func NewGrammar(queryGrammar query.Grammar) (*Grammar, error) {
+	grammar := &Grammar{query: queryGrammar}
+	switch queryGrammar.Driver() {
+	case "sqlite":
+		grammar.dialect = sqliteDialect{}
+	case "mysql", "mariadb":
+		grammar.dialect = mysqlDialect{}
+	case "pgsql":
+		grammar.dialect = postgresDialect{}
+	default:
+		return nil, fmt.Errorf("schema: unsupported driver %q", queryGrammar.Driver())
+	}
+	return grammar, nil
+}
+
+// QueryGrammar returns the query grammar used for quoting
+func (g *Grammar) QueryGrammar() query.Grammar {
+	return g.query
+}
+
+// Compile compiles a blueprint into the statements creating or altering its table
+func (g *Grammar) Compile(blueprint *Blueprint) ([]string, error) {
+	var statements []string
+	commands := blueprint.allCommands()
+
+	if blueprint.Creating() {
+		statements = append(statements, g.compileCreate(blueprint, commands))
+	} else {
+		for _, column := range blueprint.Columns() {
+			statements = append(statements, "alter table "+g.wrapTable(blueprint)+" add column "+g.compileColumn(column))
+		}
+	}
+
+	for _, command := range commands {
+		if blueprint.Creating() && (command.Name == CommandPrimary || command.Name == CommandForeign) {
+			continue
+		}
+		sql, err := g.compileCommand(blueprint, command)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, sql...)
+	}
+	return statements, nil
+}
+
+// CompileDrop drops a table
+func (g *Grammar) CompileDrop(table string) string {
+	return "drop table " + g.query.WrapTable(table)
+}
+
+// CompileDropIfExists drops a table when it exists
+func (g *Grammar) CompileDropIfExists(table string) string {
+	return "drop table if exists " + g.query.WrapTable(table)
+}
+
+// CompileRename renames a table
+func (g *Grammar) CompileRename(from string, to string) string {
+	return g.dialect.compileRename(g, from, to)
+}
+
+// CompileDropAllTables drops every given table; the names are already prefixed
+func (g *Grammar) CompileDropAllTables(tables []string) []string {
+	if len(tables) == 0 {
+		return nil
+	}
+	return g.dialect.compileDropAllTables(g, tables)
+}
+
+// CompileEnableForeignKeyConstraints turns foreign key enforcement on
+func (g *Grammar) CompileEnableForeignKeyConstraints() string {
+	return g.dialect.compileForeignKeyConstraints(true)
+}
+
+// CompileDisableForeignKeyConstraints turns foreign key enforcement off
+func (g *Grammar) CompileDisableForeignKeyConstraints() string {
+	return g.dialect.compileForeignKeyConstraints(false)
+}
+
+// compileCreate compiles the CREATE TABLE statement with its columns,
+// primary key and foreign keys
+func (g *Grammar) compileCreate(blueprint *Blueprint, commands []*Command) string {
+	definitions := make([]string, 0, len(blueprint.Columns()))
+	for _, column := range blueprint.Columns() {
+		definitions = append(definitions, g.compileColumn(column))
+	}
+	for _, command := range commands {
+		switch command.Name {
+		case CommandPrimary:
+			definitions = append(definitions, "constraint "+g.query.Wrap(command.Index)+" primary key ("+g.columnize(command.Columns)+")")
+		case CommandForeign:
+			definitions = append(definitions, g.compileForeignKey(command.Foreign))
+		}
+	}
+	return "create table " + g.wrapTable(blueprint) + " (" + strings.Join(definitions, ", ") + ")" +
+		g.dialect.tableOptions(g, blueprint)
+}
+
+// compileColumn compiles a column definition
+func (g *Grammar) compileColumn(column *ColumnDefinition) string {
+	sql := g.query.Wrap(column.Name) + " " + g.dialect.columnType(g, column)
+	if column.isIncrementing() {
+		return sql + g.dialect.incrementing(column)
+	}
+	if column.IsNullable {
+		sql += " null"
+	} else {
+		sql += " not null"
+	}
+	if column.UseCurrentTimestamp {
+		sql += " default CURRENT_TIMESTAMP"
+	} else if column.HasDefault {
+		sql += " default " + g.query.QuoteValue(column.DefaultValue)
+	}
+	return sql + g.dialect.columnModifiers(g, column)
+}
+
+// compileForeignKey compiles a foreign key constraint clause
+func (g *Grammar) compileForeignKey(foreign *ForeignKeyDefinition) string {
+	sql := "constraint " + g.query.Wrap(foreign.IndexName) +
+		" foreign key (" + g.columnize(foreign.Columns) + ")" +
+		" references " + g.query.WrapTable(foreign.Table) + " (" + g.columnize(foreign.Referenced) + ")"
+	if foreign.OnDeleteAction != "" {
+		sql += " on delete " + foreign.OnDeleteAction
+	}
+	if foreign.OnUpdateAction != "" {
+		sql += " on update " + foreign.OnUpdateAction
+	}
+	return sql
+}
+
+// compileCommand compiles a table level command
+func (g *Grammar) compileCommand(blueprint *Blueprint, command *Command) ([]string, error) {
+	if sql, ok, err := g.dialect.compileCommand(g, blueprint, command); ok || err != nil {
+		return sql, err
+	}
+
+	table := g.wrapTable(blueprint)
+	switch command.Name {
+	case CommandUnique:
+		return []string{"create unique index " + g.query.Wrap(command.Index) + " on " + table + " (" + g.columnize(command.Columns) + ")"}, nil
+	case CommandIndex:
+		return []string{"create index " + g.query.Wrap(command.Index) + " on " + table + " (" + g.columnize(command.Columns) + ")"}, nil
+	case CommandPrimary:
+		return []string{"alter table " + table + " add constraint " + g.query.Wrap(command.Index) + " primary key (" + g.columnize(command.Columns) + ")"}, nil
+	case CommandForeign:
+		return []string{"alter table " + table + " add " + g.compileForeignKey(command.Foreign)}, nil
+	case CommandDropColumn:
+		statements := make([]string, 0, len(command.Columns))
+		for _, column := range command.Columns {
+			statements = append(statements, "alter table "+table+" drop column "+g.query.Wrap(column))
+		}
+		return statements, nil
+	case CommandRenameColumn:
+		return []string{"alter table " + table + " rename column " + g.query.Wrap(command.From) + " to " + g.query.Wrap(command.To)}, nil
+	case CommandDropIndex, CommandDropUnique:
+		return []string{"drop index " + g.query.Wrap(command.Index)}, nil
+	case CommandDropForeign, CommandDropPrimary:
+		return []string{"alter table " + table + " drop constraint " + g.query.Wrap(command.Index)}, nil
+	default:
+		return nil, fmt.Errorf("schema: unknown command %q", command.Name)
+	}
+}
+
+// wrapTable quotes and prefixes the blueprint table
+func (g *Grammar) wrapTable(blueprint *Blueprint) string {
+	return g.query.WrapTable(blueprint.GetTable())
+}
+
+// columnize quotes and joins column names
+func (g *Grammar) columnize(columns []string) string {
+	wrapped := make([]string, len(columns))
+	for idx, column := range columns {
+		wrapped[idx] = g.query.Wrap(column)
+	}
+	return strings.Join(wrapped, ", ")
+}
+
+// quoteList quotes values as a comma separated list of literals
+func (g *Grammar) quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for idx, value := range values {
+		quoted[idx] = g.query.QuoteValue(value)
+	}
+	return strings.Join(quoted, ", ")
+}
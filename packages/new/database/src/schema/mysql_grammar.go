@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mysqlDialect compiles DDL for MySQL 8 and MariaDB
+type mysqlDialect struct{}
+
+func (mysqlDialect) columnType(g *Grammar, column *ColumnDefinition) string {
+	sql := ""
+	switch column.Type {
+	case TypeBigIncrements, TypeBigInteger:
+		sql = "bigint"
+	case TypeIncrements, TypeInteger:
+		sql = "int"
+	case TypeSmallInteger:
+		sql = "smallint"
+	case TypeTinyInteger:
+		sql = "tinyint"
+	case TypeString:
+		sql = "varchar(" + strconv.Itoa(column.Length) + ")"
+	case TypeChar:
+		sql = "char(" + strconv.Itoa(column.Length) + ")"
+	case TypeUUID:
+		sql = "char(36)"
+	case TypeBoolean:
+		sql = "tinyint(1)"
+	case TypeDecimal:
+		sql = "decimal(" + strconv.Itoa(column.Precision) + ", " + strconv.Itoa(column.Scale) + ")"
+	case TypeFloat:
+		sql = "float"
+	case TypeDouble:
+		sql = "double"
+	case TypeDate:
+		sql = "date"
+	case TypeDateTime:
+		sql = "datetime"
+	case TypeTimestamp:
+		sql = "timestamp"
+	case TypeTime:
+		sql = "time"
+	case TypeJSON:
+		sql = "json"
+	case TypeBinary:
+		sql = "blob"
+	case TypeEnum:
+		sql = "enum(" + g.quoteList(column.Allowed) + ")"
+	default:
+		sql = "text"
+	}
+	if column.isIncrementing() || (column.IsUnsigned && column.isInteger()) {
+		sql += " unsigned"
+	}
+	return sql
+}
+
+func (mysqlDialect) incrementing(column *ColumnDefinition) string {
+	return " not null auto_increment primary key"
+}
+
+func (mysqlDialect) columnModifiers(g *Grammar, column *ColumnDefinition) string {
+	if column.CommentText == "" {
+		return ""
+	}
+	return " comment " + g.query.QuoteValue(column.CommentText)
+}
+
+func (mysqlDialect) tableOptions(g *Grammar, blueprint *Blueprint) string {
+	options := []string{}
+	if blueprint.Charset != "" {
+		options = append(options, "default character set "+blueprint.Charset)
+	}
+	if blueprint.Collation != "" {
+		options = append(options, "collate '"+g.query.Escape(blueprint.Collation)+"'")
+	}
+	if blueprint.Engine != "" {
+		options = append(options, "engine = "+blueprint.Engine)
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return " " + strings.Join(options, " ")
+}
+
+func (mysqlDialect) compileCommand(g *Grammar, blueprint *Blueprint, command *Command) ([]string, bool, error) {
+	table := g.wrapTable(blueprint)
+	switch command.Name {
+	case CommandPrimary:
+		return []string{"alter table " + table + " add primary key (" + g.columnize(command.Columns) + ")"}, true, nil
+	case CommandDropPrimary:
+		return []string{"alter table " + table + " drop primary key"}, true, nil
+	case CommandDropIndex, CommandDropUnique:
+		return []string{"alter table " + table + " drop index " + g.query.Wrap(command.Index)}, true, nil
+	case CommandDropForeign:
+		return []string{"alter table " + table + " drop foreign key " + g.query.Wrap(command.Index)}, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func (mysqlDialect) compileRename(g *Grammar, from string, to string) string {
+	return "rename table " + g.query.WrapTable(from) + " to " + g.query.WrapTable(to)
+}
+
+func (mysqlDialect) compileDropAllTables(g *Grammar, tables []string) []string {
+	wrapped := make([]string, len(tables))
+	for idx, table := range tables {
+		wrapped[idx] = g.query.Wrap(table)
+	}
+	return []string{"drop table " + strings.Join(wrapped, ", ")}
+}
+
+func (mysqlDialect) compileForeignKeyConstraints(enabled bool) string {
+	if enabled {
+		return "SET FOREIGN_KEY_CHECKS=1"
+	}
+	return "SET FOREIGN_KEY_CHECKS=0"
+}
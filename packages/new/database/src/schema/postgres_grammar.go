@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+// postgresDialect compiles DDL for PostgreSQL
+type postgresDialect struct{}
+
+func (postgresDialect) columnType(g *Grammar, column *ColumnDefinition) string {
+	switch column.Type {
+	case TypeBigIncrements:
+		return "bigserial"
+	case TypeIncrements:
+		return "serial"
+	case TypeInteger:
+		return "integer"
+	case TypeBigInteger:
+		return "bigint"
+	case TypeSmallInteger, TypeTinyInteger:
+		return "smallint"
+	case TypeString:
+		return "varchar(" + strconv.Itoa(column.Length) + ")"
+	case TypeChar:
+		return "char(" + strconv.Itoa(column.Length) + ")"
+	case TypeUUID:
+		return "uuid"
+	case TypeBoolean:
+		return "boolean"
+	case TypeDecimal:
+		return "decimal(" + strconv.Itoa(column.Precision) + ", " + strconv.Itoa(column.Scale) + ")"
+	case TypeFloat:
+		return "real"
+	case TypeDouble:
+		return "double precision"
+	case TypeDate:
+		return "date"
+	case TypeDateTime, TypeTimestamp:
+		return "timestamp(0) without time zone"
+	case TypeTime:
+		return "time(0) without time zone"
+	case TypeJSON:
+		return "json"
+	case TypeBinary:
+		return "bytea"
+	case TypeEnum:
+		return "varchar(255) check (" + g.query.Wrap(column.Name) + " in (" + g.quoteList(column.Allowed) + "))"
+	default:
+		return "text"
+	}
+}
+
+func (postgresDialect) incrementing(column *ColumnDefinition) string {
+	return " not null primary key"
+}
+
+func (postgresDialect) columnModifiers(g *Grammar, column *ColumnDefinition) string {
+	return ""
+}
+
+func (postgresDialect) tableOptions(g *Grammar, blueprint *Blueprint) string {
+	return ""
+}
+
+func (postgresDialect) compileCommand(g *Grammar, blueprint *Blueprint, command *Command) ([]string, bool, error) {
+	if command.Name != CommandDropPrimary || len(command.Columns) > 0 {
+		return nil, false, nil
+	}
+	// Inline primary keys get PostgreSQL's default constraint name
+	index := strings.TrimSuffix(command.Index, "_primary") + "_pkey"
+	return []string{"alter table " + g.wrapTable(blueprint) + " drop constraint " + g.query.Wrap(index)}, true, nil
+}
+
+func (postgresDialect) compileRename(g *Grammar, from string, to string) string {
+	return "alter table " + g.query.WrapTable(from) + " rename to " + g.query.WrapTable(to)
+}
+
+func (postgresDialect) compileDropAllTables(g *Grammar, tables []string) []string {
+	wrapped := make([]string, len(tables))
+	for idx, table := range tables {
+		wrapped[idx] = g.query.Wrap(table)
+	}
+	return []string{"drop table " + strings.Join(wrapped, ", ") + " cascade"}
+}
+
+func (postgresDialect) compileForeignKeyConstraints(enabled bool) string {
+	if enabled {
+		return "SET CONSTRAINTS ALL IMMEDIATE"
+	}
+	return "SET CONSTRAINTS ALL DEFERRED"
+}
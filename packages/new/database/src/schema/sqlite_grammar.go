@@ -0,0 +1,76 @@
+package schema
+
+import "fmt"
+
+// sqliteDialect compiles DDL for SQLite 3.35 or later
+type sqliteDialect struct{}
+
+func (sqliteDialect) columnType(g *Grammar, column *ColumnDefinition) string {
+	switch column.Type {
+	case TypeBigIncrements, TypeIncrements, TypeInteger, TypeBigInteger, TypeSmallInteger, TypeTinyInteger:
+		return "integer"
+	case TypeString, TypeChar, TypeUUID:
+		return "varchar"
+	case TypeBoolean:
+		return "tinyint(1)"
+	case TypeDecimal:
+		return "numeric"
+	case TypeFloat:
+		return "float"
+	case TypeDouble:
+		return "double"
+	case TypeDate:
+		return "date"
+	case TypeDateTime, TypeTimestamp:
+		return "datetime"
+	case TypeTime:
+		return "time"
+	case TypeBinary:
+		return "blob"
+	case TypeEnum:
+		return "varchar check (" + g.query.Wrap(column.Name) + " in (" + g.quoteList(column.Allowed) + "))"
+	default:
+		return "text"
+	}
+}
+
+func (sqliteDialect) incrementing(column *ColumnDefinition) string {
+	return " primary key autoincrement not null"
+}
+
+func (sqliteDialect) columnModifiers(g *Grammar, column *ColumnDefinition) string {
+	return ""
+}
+
+func (sqliteDialect) tableOptions(g *Grammar, blueprint *Blueprint) string {
+	return ""
+}
+
+func (sqliteDialect) compileCommand(g *Grammar, blueprint *Blueprint, command *Command) ([]string, bool, error) {
+	switch command.Name {
+	case CommandPrimary, CommandForeign, CommandDropPrimary, CommandDropForeign:
+		// SQLite only declares keys when the table is created
+		return nil, true, fmt.Errorf("schema: sqlite cannot %s on existing table %q", command.Name, blueprint.GetTable())
+	default:
+		return nil, false, nil
+	}
+}
+
+func (sqliteDialect) compileRename(g *Grammar, from string, to string) string {
+	return "alter table " + g.query.WrapTable(from) + " rename to " + g.query.WrapTable(to)
+}
+
+func (sqliteDialect) compileDropAllTables(g *Grammar, tables []string) []string {
+	statements := make([]string, 0, len(tables))
+	for _, table := range tables {
+		statements = append(statements, "drop table if exists "+g.query.Wrap(table))
+	}
+	return statements
+}
+
+func (sqliteDialect) compileForeignKeyConstraints(enabled bool) string {
+	if enabled {
+		return "PRAGMA foreign_keys = ON"
+	}
+	return "PRAGMA foreign_keys = OFF"
+}
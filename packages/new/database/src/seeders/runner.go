@@ -0,0 +1,134 @@
+package seeders
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"govel/new/database/connections"
+	"govel/new/database/migrations"
+	seederInterfaces "govel/types/interfaces/seeder"
+)
+
+// Runner runs seeders against a connection
+type Runner struct {
+	// connection is the seeded connection
+	connection *connections.Connection
+
+	// registry resolves seeders by name
+	registry *Registry
+
+	// output receives progress lines
+	output io.Writer
+
+	// environment is the application environment, db:seed requires
+	// --force in production
+	environment string
+}
+
+// NewRunner creates a runner for the connection, using the default
+// registry when registry is nil
+func NewRunner(connection *connections.Connection, registry *Registry) *Runner {
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	return &Runner{connection: connection, registry: registry, output: io.Discard}
+}
+
+// SetOutput sets the writer receiving progress lines
+func (r *Runner) SetOutput(output io.Writer) *Runner {
+	if output == nil {
+		output = io.Discard
+	}
+	r.output = output
+	return r
+}
+
+// SetEnvironment sets the application environment, such as "production"
+func (r *Runner) SetEnvironment(environment string) *Runner {
+	r.environment = environment
+	return r
+}
+
+// GetEnvironment returns the application environment
+func (r *Runner) GetEnvironment() string {
+	return r.environment
+}
+
+// Connection returns the seeded connection
+func (r *Runner) Connection() *connections.Connection {
+	return r.connection
+}
+
+// Names returns the registered seeder names
+func (r *Runner) Names() []string {
+	return r.registry.Names()
+}
+
+// Call runs seeders in order. Each seeder is a registered name or a
+// Seeder value; the first error stops the run.
+func (r *Runner) Call(ctx context.Context, seeders ...interface{}) error {
+	for _, value := range seeders {
+		seeder, name, err := r.resolve(value)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(r.output, "Seeding: %s\n", name)
+		started := time.Now()
+		if err := seeder.Run(ctx, r); err != nil {
+			return fmt.Errorf("seeders: %s: %w", name, err)
+		}
+		fmt.Fprintf(r.output, "Seeded:  %s (%s)\n", name, time.Since(started).Round(time.Microsecond))
+	}
+	return nil
+}
+
+// resolve returns the seeder and display name of a Call argument
+func (r *Runner) resolve(value interface{}) (Seeder, string, error) {
+	switch typed := value.(type) {
+	case string:
+		seeder, ok := r.registry.Get(typed)
+		if !ok {
+			return nil, "", fmt.Errorf("seeders: seeder %q is not registered", typed)
+		}
+		return seeder, typed, nil
+	case Seeder:
+		return typed, r.registry.nameOf(typed), nil
+	case func(ctx context.Context, runner *Runner) error:
+		return SeederFunc(typed), "closure", nil
+	default:
+		return nil, "", fmt.Errorf("seeders: %T is not a seeder", value)
+	}
+}
+
+// Execute runs the db:seed command: args[0] must be "db:seed", followed
+// by an optional --class naming the seeder, DatabaseSeeder by default.
+// In production the command refuses to run without --force.
+func Execute(ctx context.Context, runner *Runner, args []string, output io.Writer) error {
+	if len(args) == 0 || args[0] != "db:seed" {
+		return fmt.Errorf("seeders: expected the db:seed command")
+	}
+
+	flags := flag.NewFlagSet("db:seed", flag.ContinueOnError)
+	flags.SetOutput(output)
+	class := flags.String("class", DefaultSeeder, "name of the seeder to run")
+	force := flags.Bool("force", false, "run the command in production")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if err := migrations.ConfirmToProceed("db:seed", runner.GetEnvironment(), *force); err != nil {
+		return err
+	}
+
+	if err := runner.SetOutput(output).Call(ctx, *class); err != nil {
+		return err
+	}
+	fmt.Fprintln(output, "Database seeding completed successfully")
+	return nil
+}
+
+// Compile-time interface compliance check
+var _ seederInterfaces.SeederInterface = (*Runner)(nil)
@@ -0,0 +1,125 @@
+// Package seeders fills the database with initial or test data.
+//
+// A seeder is a Go value registered under its name, conventionally the
+// type name, and run by a Runner. Seeders call other seeders through the
+// runner, so a DatabaseSeeder usually composes the application's seeders:
+//
+//	type DatabaseSeeder struct{}
+//
+//	func (DatabaseSeeder) Run(ctx context.Context, runner *seeders.Runner) error {
+//		return runner.Call(ctx, UserSeeder{}, PostSeeder{})
+//	}
+//
+//	func init() {
+//		seeders.Register("DatabaseSeeder", DatabaseSeeder{})
+//	}
+package seeders
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// DefaultSeeder is the seeder run by db:seed without --class
+const DefaultSeeder = "DatabaseSeeder"
+
+// Seeder populates the database
+type Seeder interface {
+	// Run seeds the database; runner gives access to the connection and
+	// to other seeders
+	Run(ctx context.Context, runner *Runner) error
+}
+
+// SeederFunc adapts a function to Seeder
+type SeederFunc func(ctx context.Context, runner *Runner) error
+
+// Run calls the function
+func (f SeederFunc) Run(ctx context.Context, runner *Runner) error {
+	return f(ctx, runner)
+}
+
+// DefaultRegistry holds the seeders added with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds a seeder to the default registry. It panics when the
+// name is registered twice.
+func Register(name string, seeder Seeder) {
+	if err := DefaultRegistry.Add(name, seeder); err != nil {
+		panic(err)
+	}
+}
+
+// Registry holds seeders by name
+type Registry struct {
+	// mu guards seeders
+	mu sync.RWMutex
+
+	// seeders maps names to seeders
+	seeders map[string]Seeder
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{seeders: make(map[string]Seeder)}
+}
+
+// Add registers a seeder under a unique name
+func (r *Registry) Add(name string, seeder Seeder) error {
+	if name == "" || seeder == nil {
+		return fmt.Errorf("seeders: a seeder needs a name and a value")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.seeders[name]; exists {
+		return fmt.Errorf("seeders: seeder %q is already registered", name)
+	}
+	r.seeders[name] = seeder
+	return nil
+}
+
+// Get returns a seeder by name
+func (r *Registry) Get(name string) (Seeder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seeder, ok := r.seeders[name]
+	return seeder, ok
+}
+
+// Names returns the registered names in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sortedNames()
+}
+
+// nameOf returns the registered name of a seeder value's type, or the
+// type name itself
+func (r *Registry) nameOf(seeder Seeder) string {
+	seederType := reflect.TypeOf(seeder)
+	if _, isFunc := seeder.(SeederFunc); isFunc {
+		return "closure"
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, name := range r.sortedNames() {
+		if reflect.TypeOf(r.seeders[name]) == seederType {
+			return name
+		}
+	}
+	return seederType.String()
+}
+
+// sortedNames returns the names in alphabetical order. The caller must hold mu.
+func (r *Registry) sortedNames() []string {
+	names := make([]string, 0, len(r.seeders))
+	for name := range r.seeders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	filesystem "govel/new/filesystem"
+	"govel/new/filesystem/drivers"
+	"govel/new/filesystem/exceptions"
+	"govel/new/filesystem/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// disks returns a fresh disk per driver exercised by the shared contract tests.
+func disks(t *testing.T) map[string]*filesystem.Filesystem {
+	t.Helper()
+
+	local, err := drivers.NewLocalAdapter(map[string]interface{}{"root": t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewLocalAdapter failed: %v", err)
+	}
+
+	return map[string]*filesystem.Filesystem{
+		"local":  filesystem.NewFilesystem(local, nil),
+		"memory": filesystem.NewFilesystem(drivers.NewMemoryAdapter(), nil),
+	}
+}
+
+func TestFilesystem_ReadWriteAndMetadata(t *testing.T) {
+	for name, disk := range disks(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := disk.Put("docs/readme.txt", []byte("hello")); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+
+			contents, err := disk.Get("docs/readme.txt")
+			if err != nil || string(contents) != "hello" {
+				t.Fatalf("Expected hello, got %q (%v)", contents, err)
+			}
+
+			if !disk.Exists("docs/readme.txt") || !disk.Exists("docs") || !disk.Missing("docs/other.txt") {
+				t.Error("Expected file and its directory to exist")
+			}
+
+			if size, _ := disk.Size("docs/readme.txt"); size != 5 {
+				t.Errorf("Expected size 5, got %d", size)
+			}
+
+			if mimeType, _ := disk.MimeType("docs/readme.txt"); !strings.HasPrefix(mimeType, "text/plain") {
+				t.Errorf("Expected text/plain, got %q", mimeType)
+			}
+
+			if modified, err := disk.LastModified("docs/readme.txt"); err != nil || modified.IsZero() {
+				t.Errorf("Expected last modified time, got %v (%v)", modified, err)
+			}
+
+			if _, err := disk.Get("missing.txt"); !exceptions.IsFileNotFound(err) {
+				t.Errorf("Expected FileNotFoundException, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFilesystem_StreamsCopyMoveDelete(t *testing.T) {
+	for name, disk := range disks(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := disk.WriteStream("a.txt", strings.NewReader("streamed")); err != nil {
+				t.Fatalf("WriteStream failed: %v", err)
+			}
+
+			if err := disk.Copy("a.txt", "copies/b.txt"); err != nil {
+				t.Fatalf("Copy failed: %v", err)
+			}
+			if err := disk.Move("a.txt", "moved/c.txt"); err != nil {
+				t.Fatalf("Move failed: %v", err)
+			}
+
+			stream, err := disk.ReadStream("moved/c.txt")
+			if err != nil {
+				t.Fatalf("ReadStream failed: %v", err)
+			}
+			buffer := make([]byte, 8)
+			n, _ := stream.Read(buffer)
+			stream.Close()
+			if string(buffer[:n]) != "streamed" {
+				t.Errorf("Expected streamed, got %q", buffer[:n])
+			}
+
+			if disk.Exists("a.txt") {
+				t.Error("Expected moved source to be gone")
+			}
+
+			if err := disk.Delete("copies/b.txt", "does/not/exist.txt"); err != nil {
+				t.Errorf("Delete failed: %v", err)
+			}
+			if disk.Exists("copies/b.txt") {
+				t.Error("Expected deleted file to be gone")
+			}
+		})
+	}
+}
+
+func TestFilesystem_Listings(t *testing.T) {
+	for name, disk := range disks(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, path := range []string{"root.txt", "a/one.txt", "a/b/two.txt", "c/three.txt"} {
+				if err := disk.Put(path, []byte(path)); err != nil {
+					t.Fatalf("Put %s failed: %v", path, err)
+				}
+			}
+			if err := disk.MakeDirectory("empty/nested"); err != nil {
+				t.Fatalf("MakeDirectory failed: %v", err)
+			}
+
+			assertPaths(t, "Files", disk.Files, "", []string{"root.txt"})
+			assertPaths(t, "Files(a)", disk.Files, "a", []string{"a/one.txt"})
+			assertPaths(t, "AllFiles", disk.AllFiles, "", []string{"a/b/two.txt", "a/one.txt", "c/three.txt", "root.txt"})
+			assertPaths(t, "Directories", disk.Directories, "", []string{"a", "c", "empty"})
+			assertPaths(t, "AllDirectories", disk.AllDirectories, "", []string{"a", "a/b", "c", "empty", "empty/nested"})
+
+			if err := disk.DeleteDirectory("a"); err != nil {
+				t.Fatalf("DeleteDirectory failed: %v", err)
+			}
+			assertPaths(t, "AllFiles after delete", disk.AllFiles, "", []string{"c/three.txt", "root.txt"})
+		})
+	}
+}
+
+func TestFilesystem_Visibility(t *testing.T) {
+	for name, disk := range disks(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := disk.Put("secret.txt", []byte("x"), map[string]interface{}{"visibility": "private"}); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			if visibility, _ := disk.GetVisibility("secret.txt"); visibility != storageInterfaces.VisibilityPrivate {
+				t.Errorf("Expected private, got %q", visibility)
+			}
+
+			if err := disk.SetVisibility("secret.txt", storageInterfaces.VisibilityPublic); err != nil {
+				t.Fatalf("SetVisibility failed: %v", err)
+			}
+			if visibility, _ := disk.GetVisibility("secret.txt"); visibility != storageInterfaces.VisibilityPublic {
+				t.Errorf("Expected public, got %q", visibility)
+			}
+
+			if err := disk.SetVisibility("secret.txt", "world"); err == nil {
+				t.Error("Expected invalid visibility to be rejected")
+			}
+		})
+	}
+}
+
+func TestLocalAdapter_PermissionsTraversalAndURL(t *testing.T) {
+	root := t.TempDir()
+	adapter, err := drivers.NewLocalAdapter(map[string]interface{}{
+		"root":        root,
+		"permissions": map[string]interface{}{"file": map[string]interface{}{"private": "0640"}},
+	})
+	if err != nil {
+		t.Fatalf("NewLocalAdapter failed: %v", err)
+	}
+	disk := filesystem.NewFilesystem(adapter, map[string]interface{}{"url": "https://cdn.test/storage/"})
+
+	if err := disk.Put("p.txt", []byte("x"), map[string]interface{}{"visibility": "private"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(root, "p.txt"))
+	if err != nil || info.Mode().Perm() != 0o640 {
+		t.Errorf("Expected mode 0640, got %v (%v)", info.Mode().Perm(), err)
+	}
+
+	if err := disk.Put("../escape.txt", []byte("x")); err == nil {
+		t.Error("Expected path traversal to be rejected")
+	}
+	if disk.Path("nested/../p.txt") != filepath.Join(root, "p.txt") {
+		t.Errorf("Unexpected path %s", disk.Path("nested/../p.txt"))
+	}
+
+	if url, _ := disk.URL("/avatars/1.png"); url != "https://cdn.test/storage/avatars/1.png" {
+		t.Errorf("Unexpected url %s", url)
+	}
+}
+
+func TestLocalAdapter_LockedWritesDoNotInterleave(t *testing.T) {
+	adapter, err := drivers.NewLocalAdapter(map[string]interface{}{"root": t.TempDir(), "lock": true})
+	if err != nil {
+		t.Fatalf("NewLocalAdapter failed: %v", err)
+	}
+	adapter.SetLockFactory(filesystem.OpenLockableFile)
+	disk := filesystem.NewFilesystem(adapter, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := strings.Repeat(string(rune('a'+i)), 64<<10)
+			if err := disk.Put("shared.txt", []byte(payload)); err != nil {
+				t.Errorf("Put failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	contents, err := disk.Get("shared.txt")
+	if err != nil || len(contents) != 64<<10 || strings.Count(string(contents), string(contents[0])) != len(contents) {
+		t.Errorf("Expected one complete write to win, got %d bytes (%v)", len(contents), err)
+	}
+}
+
+func TestLockableFile_NonBlockingExclusiveLockTimesOut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "file")
+
+	first, err := filesystem.NewLockableFile(path, os.O_RDWR|os.O_CREATE)
+	if err != nil {
+		t.Fatalf("NewLockableFile failed: %v", err)
+	}
+	defer first.Close()
+
+	second, err := filesystem.NewLockableFile(path, os.O_RDWR)
+	if err != nil {
+		t.Fatalf("NewLockableFile failed: %v", err)
+	}
+	defer second.Close()
+
+	if err := first.GetExclusiveLock(true); err != nil {
+		t.Fatalf("GetExclusiveLock failed: %v", err)
+	}
+
+	err = second.GetSharedLock(false)
+	if _, ok := err.(*exceptions.LockTimeoutException); !ok {
+		t.Fatalf("Expected LockTimeoutException, got %v", err)
+	}
+
+	if err := first.ReleaseLock(); err != nil {
+		t.Fatalf("ReleaseLock failed: %v", err)
+	}
+	if err := second.GetSharedLock(false); err != nil {
+		t.Errorf("Expected shared lock after release, got %v", err)
+	}
+}
+
+// assertPaths compares a listing against the expected paths.
+func assertPaths(t *testing.T, label string, list func(string) ([]string, error), directory string, expected []string) {
+	t.Helper()
+
+	paths, err := list(directory)
+	if err != nil {
+		t.Fatalf("%s failed: %v", label, err)
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("%s: expected %v, got %v", label, expected, paths)
+	}
+}
+
+// Compile-time check that disks expose their adapter
+var _ interfaces.FilesystemInterface = (*filesystem.Filesystem)(nil)
@@ -0,0 +1,97 @@
+package drivers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// normalizePath cleans a disk-relative path and rejects paths escaping the
+// disk root. The root itself normalizes to the empty string.
+func normalizePath(location string) (string, error) {
+	location = strings.ReplaceAll(location, "\\", "/")
+
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(location, "/") {
+		switch segment {
+		case "", ".":
+			continue
+		case "..":
+			if len(segments) == 0 {
+				return "", fmt.Errorf("path traversal detected: %s", location)
+			}
+			segments = segments[:len(segments)-1]
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+// detectMimeType guesses a content type from the file extension, falling
+// back to sniffing the first bytes of its contents.
+func detectMimeType(location string, head []byte) string {
+	if mimeType := mime.TypeByExtension(path.Ext(location)); mimeType != "" {
+		return mimeType
+	}
+	if len(head) == 0 {
+		return "application/octet-stream"
+	}
+	return http.DetectContentType(head)
+}
+
+// validateVisibility ensures the visibility is public or private.
+func validateVisibility(visibility string) error {
+	if visibility != storageInterfaces.VisibilityPublic && visibility != storageInterfaces.VisibilityPrivate {
+		return fmt.Errorf("invalid visibility [%s], expected %q or %q", visibility, storageInterfaces.VisibilityPublic, storageInterfaces.VisibilityPrivate)
+	}
+	return nil
+}
+
+// stringOption reads a string option, returning fallback when absent or empty.
+func stringOption(options map[string]interface{}, key, fallback string) string {
+	if value, ok := options[key]; ok && value != nil {
+		if s := fmt.Sprint(value); s != "" {
+			return s
+		}
+	}
+	return fallback
+}
+
+// boolOption reads a boolean option, accepting env-style strings.
+func boolOption(options map[string]interface{}, key string, fallback bool) bool {
+	switch value := options[key].(type) {
+	case bool:
+		return value
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fallback
+		}
+		return parsed
+	default:
+		return fallback
+	}
+}
+
+// mapOption reads a nested options map.
+func mapOption(options map[string]interface{}, key string) map[string]interface{} {
+	switch value := options[key].(type) {
+	case map[string]interface{}:
+		return value
+	case map[string]string:
+		converted := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			converted[k] = v
+		}
+		return converted
+	default:
+		return nil
+	}
+}
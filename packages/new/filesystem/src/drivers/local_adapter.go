@@ -0,0 +1,564 @@
+package drivers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"govel/new/filesystem/exceptions"
+	"govel/new/filesystem/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// LocalAdapter stores files on the local filesystem below a root directory.
+//
+// Visibility is mapped to Unix permissions (see the "permissions" option).
+// Writes go to a temporary file that is renamed into place, so readers never
+// observe a partially written file. With the "lock" option enabled, writes
+// take an exclusive lock and reads a shared lock instead.
+//
+// Supported options:
+//   - root: Root directory (required)
+//   - visibility: Visibility of new files, defaults to public
+//   - directory_visibility: Visibility of new directories, defaults to public
+//   - permissions: {"file": {"public": 0644, "private": 0600}, "dir": {"public": 0755, "private": 0700}}
+//   - lock: Whether to lock files while reading and writing (requires SetLockFactory)
+//   - lock_timeout: Seconds to wait for a lock before failing, 0 waits forever
+type LocalAdapter struct {
+	// root is the absolute root directory
+	root string
+
+	// permissions maps "file"/"dir" and a visibility to a file mode
+	permissions map[string]map[string]os.FileMode
+
+	// visibility is the visibility of new files
+	visibility string
+
+	// directoryVisibility is the visibility of new directories
+	directoryVisibility string
+
+	// lock enables advisory file locking
+	lock bool
+
+	// lockTimeout bounds lock waits
+	lockTimeout time.Duration
+
+	// lockFactory opens lockable files when locking is enabled
+	lockFactory interfaces.LockableFileFactory
+}
+
+// NewLocalAdapter creates a local adapter from a disk configuration and
+// creates the root directory if it does not exist.
+func NewLocalAdapter(config map[string]interface{}) (*LocalAdapter, error) {
+	root := stringOption(config, "root", "")
+	if root == "" {
+		return nil, fmt.Errorf("local disk requires a root directory")
+	}
+
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local disk root %s: %w", root, err)
+	}
+
+	adapter := &LocalAdapter{
+		root: absolute,
+		permissions: map[string]map[string]os.FileMode{
+			"file": {storageInterfaces.VisibilityPublic: 0o644, storageInterfaces.VisibilityPrivate: 0o600},
+			"dir":  {storageInterfaces.VisibilityPublic: 0o755, storageInterfaces.VisibilityPrivate: 0o700},
+		},
+		visibility:          stringOption(config, "visibility", storageInterfaces.VisibilityPublic),
+		directoryVisibility: stringOption(config, "directory_visibility", storageInterfaces.VisibilityPublic),
+		lock:                boolOption(config, "lock", false),
+	}
+
+	if seconds, err := strconv.Atoi(stringOption(config, "lock_timeout", "0")); err == nil {
+		adapter.lockTimeout = time.Duration(seconds) * time.Second
+	}
+
+	for kind, modes := range mapOption(config, "permissions") {
+		for visibility, mode := range toStringMap(modes) {
+			if parsed, ok := parseFileMode(mode); ok && adapter.permissions[kind] != nil {
+				adapter.permissions[kind][visibility] = parsed
+			}
+		}
+	}
+
+	if err := os.MkdirAll(adapter.root, adapter.permissions["dir"][adapter.directoryVisibility]); err != nil {
+		return nil, fmt.Errorf("failed to create local disk root %s: %w", adapter.root, err)
+	}
+
+	return adapter, nil
+}
+
+// SetLockFactory sets the factory opening lockable files for the "lock" option.
+// The filesystem manager wires filesystem.OpenLockableFile here.
+func (a *LocalAdapter) SetLockFactory(factory interfaces.LockableFileFactory) *LocalAdapter {
+	a.lockFactory = factory
+	return a
+}
+
+// Root returns the absolute root directory of the disk.
+func (a *LocalAdapter) Root() string {
+	return a.root
+}
+
+// Path returns the absolute path of a file on the disk.
+func (a *LocalAdapter) Path(location string) string {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return a.root
+	}
+	return full
+}
+
+// FileExists reports whether a regular file exists at the path.
+func (a *LocalAdapter) FileExists(location string) (bool, error) {
+	info, err := a.stat(location)
+	if err != nil {
+		if exceptions.IsFileNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !info.IsDir(), nil
+}
+
+// DirectoryExists reports whether a directory exists at the path.
+func (a *LocalAdapter) DirectoryExists(location string) (bool, error) {
+	info, err := a.stat(location)
+	if err != nil {
+		if exceptions.IsFileNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// Write writes a file from a stream.
+func (a *LocalAdapter) Write(location string, contents io.Reader, options map[string]interface{}) error {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return err
+	}
+
+	visibility := stringOption(options, "visibility", a.visibility)
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	if err := a.ensureDirectory(filepath.Dir(full)); err != nil {
+		return err
+	}
+
+	if a.locking() {
+		err = a.writeLocked(full, contents)
+	} else {
+		err = a.writeAtomic(full, contents)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", location, err)
+	}
+
+	return os.Chmod(full, a.permissions["file"][visibility])
+}
+
+// writeAtomic writes to a temporary file and renames it into place.
+func (a *LocalAdapter) writeAtomic(full string, contents io.Reader) error {
+	temp, err := os.CreateTemp(filepath.Dir(full), "."+filepath.Base(full)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, contents); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), full)
+}
+
+// writeLocked writes in place while holding an exclusive lock.
+func (a *LocalAdapter) writeLocked(full string, contents io.Reader) error {
+	file, err := a.lockFactory(full, os.O_RDWR|os.O_CREATE)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := file.SetTimeout(a.lockTimeout).GetExclusiveLock(true); err != nil {
+		return err
+	}
+	if err := file.Truncate(); err != nil {
+		return err
+	}
+	if _, err := io.Copy(file.File(), contents); err != nil {
+		return err
+	}
+	return file.File().Sync()
+}
+
+// Read opens a file for reading. With locking enabled the returned reader
+// holds a shared lock until it is closed.
+func (a *LocalAdapter) Read(location string) (io.ReadCloser, error) {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Stat(full); err != nil || info.IsDir() {
+		return nil, exceptions.NewFileNotFoundException(location)
+	}
+
+	if !a.locking() {
+		file, err := os.Open(full)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, exceptions.NewFileNotFoundException(location)
+		}
+		return file, err
+	}
+
+	file, err := a.lockFactory(full, os.O_RDONLY)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, exceptions.NewFileNotFoundException(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := file.SetTimeout(a.lockTimeout).GetSharedLock(true); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &lockedReader{file: file}, nil
+}
+
+// Delete removes a file.
+func (a *LocalAdapter) Delete(location string) error {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeleteDirectory removes a directory recursively. Deleting the root only
+// removes its contents.
+func (a *LocalAdapter) DeleteDirectory(location string) error {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return err
+	}
+	if full != a.root {
+		return os.RemoveAll(full)
+	}
+
+	entries, err := os.ReadDir(a.root)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(a.root, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateDirectory creates a directory and its parents.
+func (a *LocalAdapter) CreateDirectory(location string, options map[string]interface{}) error {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return err
+	}
+
+	visibility := stringOption(options, "visibility", a.directoryVisibility)
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	mode := a.permissions["dir"][visibility]
+	if err := os.MkdirAll(full, mode); err != nil {
+		return err
+	}
+	return os.Chmod(full, mode)
+}
+
+// SetVisibility changes the permissions of a file or directory.
+func (a *LocalAdapter) SetVisibility(location, visibility string) error {
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	info, err := a.stat(location)
+	if err != nil {
+		return err
+	}
+
+	kind := "file"
+	if info.IsDir() {
+		kind = "dir"
+	}
+	return os.Chmod(a.Path(location), a.permissions[kind][visibility])
+}
+
+// Visibility maps the permissions of a file back to a visibility.
+func (a *LocalAdapter) Visibility(location string) (string, error) {
+	info, err := a.stat(location)
+	if err != nil {
+		return "", err
+	}
+
+	kind := "file"
+	if info.IsDir() {
+		kind = "dir"
+	}
+	if info.Mode().Perm() == a.permissions[kind][storageInterfaces.VisibilityPrivate] {
+		return storageInterfaces.VisibilityPrivate, nil
+	}
+	return storageInterfaces.VisibilityPublic, nil
+}
+
+// MimeType detects the content type of a file.
+func (a *LocalAdapter) MimeType(location string) (string, error) {
+	reader, err := a.Read(location)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	return detectMimeType(location, head[:n]), nil
+}
+
+// LastModified returns the modification time of a file.
+func (a *LocalAdapter) LastModified(location string) (time.Time, error) {
+	info, err := a.fileInfo(location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// FileSize returns the size of a file.
+func (a *LocalAdapter) FileSize(location string) (int64, error) {
+	info, err := a.fileInfo(location)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// ListContents lists a directory. Missing directories list as empty.
+func (a *LocalAdapter) ListContents(location string, deep bool) ([]interfaces.StorageAttributes, error) {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Stat(full); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	var listing []interfaces.StorageAttributes
+	err = filepath.WalkDir(full, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if current == full {
+			return nil
+		}
+		if strings.HasSuffix(entry.Name(), ".tmp") && strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(a.root, current)
+		if err != nil {
+			return err
+		}
+
+		attributes := interfaces.StorageAttributes{
+			Path:         filepath.ToSlash(relative),
+			IsDir:        entry.IsDir(),
+			LastModified: info.ModTime(),
+		}
+		if !entry.IsDir() {
+			attributes.Size = info.Size()
+		}
+		listing = append(listing, attributes)
+
+		if entry.IsDir() && !deep {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(listing, func(i, j int) bool { return listing[i].Path < listing[j].Path })
+	return listing, nil
+}
+
+// Move renames a file, creating the destination directory as needed.
+func (a *LocalAdapter) Move(from, to string) error {
+	source, err := a.fullPath(from)
+	if err != nil {
+		return err
+	}
+	destination, err := a.fullPath(to)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.fileInfo(from); err != nil {
+		return err
+	}
+	if err := a.ensureDirectory(filepath.Dir(destination)); err != nil {
+		return err
+	}
+	return os.Rename(source, destination)
+}
+
+// Copy copies a file, preserving its visibility.
+func (a *LocalAdapter) Copy(from, to string) error {
+	visibility, err := a.Visibility(from)
+	if err != nil {
+		return err
+	}
+
+	reader, err := a.Read(from)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return a.Write(to, reader, map[string]interface{}{"visibility": visibility})
+}
+
+// locking reports whether reads and writes take file locks.
+func (a *LocalAdapter) locking() bool {
+	return a.lock && a.lockFactory != nil
+}
+
+// fullPath resolves a disk-relative path to an absolute path below the root.
+func (a *LocalAdapter) fullPath(location string) (string, error) {
+	normalized, err := normalizePath(location)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(a.root, filepath.FromSlash(normalized)), nil
+}
+
+// stat returns the file info of a path, or a FileNotFoundException.
+func (a *LocalAdapter) stat(location string) (os.FileInfo, error) {
+	full, err := a.fullPath(location)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, exceptions.NewFileNotFoundException(location)
+	}
+	return info, err
+}
+
+// fileInfo returns the file info of a regular file.
+func (a *LocalAdapter) fileInfo(location string) (os.FileInfo, error) {
+	info, err := a.stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, exceptions.NewFileNotFoundException(location)
+	}
+	return info, nil
+}
+
+// ensureDirectory creates a directory with the default directory visibility.
+func (a *LocalAdapter) ensureDirectory(directory string) error {
+	return os.MkdirAll(directory, a.permissions["dir"][a.directoryVisibility])
+}
+
+// lockedReader releases the shared lock of a LockableFile when closed.
+type lockedReader struct {
+	file interfaces.LockableFileInterface
+}
+
+// Read reads from the locked file.
+func (r *lockedReader) Read(p []byte) (int, error) {
+	return r.file.File().Read(p)
+}
+
+// Seek moves the read position of the locked file.
+func (r *lockedReader) Seek(offset int64, whence int) (int64, error) {
+	return r.file.File().Seek(offset, whence)
+}
+
+// Close releases the lock and closes the file.
+func (r *lockedReader) Close() error {
+	return r.file.Close()
+}
+
+// toStringMap converts a nested permissions map.
+func toStringMap(value interface{}) map[string]interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return typed
+	case map[string]int:
+		converted := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			converted[k] = v
+		}
+		return converted
+	default:
+		return nil
+	}
+}
+
+// parseFileMode parses a permission value given as a number or an octal string.
+func parseFileMode(value interface{}) (os.FileMode, bool) {
+	switch mode := value.(type) {
+	case int:
+		return os.FileMode(mode), true
+	case int64:
+		return os.FileMode(mode), true
+	case float64:
+		return os.FileMode(int(mode)), true
+	case os.FileMode:
+		return mode, true
+	case string:
+		parsed, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil {
+			return 0, false
+		}
+		return os.FileMode(parsed), true
+	default:
+		return 0, false
+	}
+}
+
+// Compile-time interface compliance check
+var _ interfaces.AdapterInterface = (*LocalAdapter)(nil)
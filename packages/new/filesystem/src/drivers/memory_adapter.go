@@ -0,0 +1,429 @@
+package drivers
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"govel/new/filesystem/exceptions"
+	"govel/new/filesystem/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// memoryFile is a file stored by the MemoryAdapter.
+type memoryFile struct {
+	// contents is the file contents
+	contents []byte
+
+	// visibility is the file visibility
+	visibility string
+
+	// mimeType is the content type given on write, if any
+	mimeType string
+
+	// lastModified is the time of the last write
+	lastModified time.Time
+}
+
+// MemoryAdapter keeps files in memory. It is intended for tests and is what
+// FilesystemManager.Fake swaps in for a disk.
+//
+// Directories exist explicitly once created, or implicitly while they
+// contain files.
+type MemoryAdapter struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// files maps normalized paths to files
+	files map[string]*memoryFile
+
+	// directories holds explicitly created directories
+	directories map[string]time.Time
+
+	// visibility is the visibility of new files
+	visibility string
+
+	// clock returns the current time
+	clock func() time.Time
+}
+
+// NewMemoryAdapter creates an empty in-memory adapter.
+//
+// Supported options:
+//   - visibility: Visibility of new files, defaults to public
+func NewMemoryAdapter(config ...map[string]interface{}) *MemoryAdapter {
+	var options map[string]interface{}
+	if len(config) > 0 {
+		options = config[0]
+	}
+
+	return &MemoryAdapter{
+		files:       make(map[string]*memoryFile),
+		directories: make(map[string]time.Time),
+		visibility:  stringOption(options, "visibility", storageInterfaces.VisibilityPublic),
+		clock:       time.Now,
+	}
+}
+
+// SetClock replaces the clock used for modification times.
+func (a *MemoryAdapter) SetClock(clock func() time.Time) *MemoryAdapter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.clock = clock
+	return a
+}
+
+// Flush removes every file and directory.
+func (a *MemoryAdapter) Flush() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.files = make(map[string]*memoryFile)
+	a.directories = make(map[string]time.Time)
+}
+
+// FileExists reports whether a file exists.
+func (a *MemoryAdapter) FileExists(location string) (bool, error) {
+	key, err := normalizePath(location)
+	if err != nil {
+		return false, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	_, exists := a.files[key]
+	return exists, nil
+}
+
+// DirectoryExists reports whether a directory exists explicitly or implicitly.
+func (a *MemoryAdapter) DirectoryExists(location string) (bool, error) {
+	key, err := normalizePath(location)
+	if err != nil {
+		return false, err
+	}
+	if key == "" {
+		return true, nil
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if _, exists := a.directories[key]; exists {
+		return true, nil
+	}
+	for path := range a.files {
+		if strings.HasPrefix(path, key+"/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Write stores a file from a stream.
+func (a *MemoryAdapter) Write(location string, contents io.Reader, options map[string]interface{}) error {
+	key, err := normalizePath(location)
+	if err != nil {
+		return err
+	}
+
+	visibility := stringOption(options, "visibility", a.visibility)
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(contents)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.files[key] = &memoryFile{
+		contents:     data,
+		visibility:   visibility,
+		mimeType:     stringOption(options, "mimetype", ""),
+		lastModified: a.clock(),
+	}
+	return nil
+}
+
+// Read returns a seekable reader over the file contents.
+func (a *MemoryAdapter) Read(location string) (io.ReadCloser, error) {
+	file, err := a.file(location)
+	if err != nil {
+		return nil, err
+	}
+	return memoryReader{bytes.NewReader(file.contents)}, nil
+}
+
+// Delete removes a file.
+func (a *MemoryAdapter) Delete(location string) error {
+	key, err := normalizePath(location)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.files, key)
+	return nil
+}
+
+// DeleteDirectory removes a directory and everything inside it.
+func (a *MemoryAdapter) DeleteDirectory(location string) error {
+	key, err := normalizePath(location)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for path := range a.files {
+		if key == "" || strings.HasPrefix(path, key+"/") {
+			delete(a.files, path)
+		}
+	}
+	for path := range a.directories {
+		if key == "" || path == key || strings.HasPrefix(path, key+"/") {
+			delete(a.directories, path)
+		}
+	}
+	return nil
+}
+
+// CreateDirectory records a directory and its parents.
+func (a *MemoryAdapter) CreateDirectory(location string, options map[string]interface{}) error {
+	key, err := normalizePath(location)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock()
+	for key != "" {
+		a.directories[key] = now
+		key = parentOf(key)
+	}
+	return nil
+}
+
+// SetVisibility changes the visibility of a file.
+func (a *MemoryAdapter) SetVisibility(location, visibility string) error {
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	key, err := normalizePath(location)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, exists := a.files[key]
+	if !exists {
+		return exceptions.NewFileNotFoundException(location)
+	}
+	file.visibility = visibility
+	return nil
+}
+
+// Visibility returns the visibility of a file.
+func (a *MemoryAdapter) Visibility(location string) (string, error) {
+	file, err := a.file(location)
+	if err != nil {
+		return "", err
+	}
+	return file.visibility, nil
+}
+
+// MimeType returns the stored content type or detects it.
+func (a *MemoryAdapter) MimeType(location string) (string, error) {
+	file, err := a.file(location)
+	if err != nil {
+		return "", err
+	}
+	if file.mimeType != "" {
+		return file.mimeType, nil
+	}
+
+	head := file.contents
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return detectMimeType(location, head), nil
+}
+
+// LastModified returns the time of the last write.
+func (a *MemoryAdapter) LastModified(location string) (time.Time, error) {
+	file, err := a.file(location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return file.lastModified, nil
+}
+
+// FileSize returns the size of a file.
+func (a *MemoryAdapter) FileSize(location string) (int64, error) {
+	file, err := a.file(location)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(file.contents)), nil
+}
+
+// ListContents lists the files and directories below a directory.
+func (a *MemoryAdapter) ListContents(location string, deep bool) ([]interfaces.StorageAttributes, error) {
+	key, err := normalizePath(location)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := ""
+	if key != "" {
+		prefix = key + "/"
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	entries := make(map[string]interfaces.StorageAttributes)
+	addDirectories := func(path string) {
+		for parent := parentOf(path); len(parent) > len(key) && strings.HasPrefix(parent, prefix); parent = parentOf(parent) {
+			if deep || !strings.Contains(strings.TrimPrefix(parent, prefix), "/") {
+				if _, exists := entries[parent]; !exists {
+					entries[parent] = interfaces.StorageAttributes{Path: parent, IsDir: true}
+				}
+			}
+		}
+	}
+
+	for path, file := range a.files {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		addDirectories(path)
+		if deep || !strings.Contains(strings.TrimPrefix(path, prefix), "/") {
+			entries[path] = interfaces.StorageAttributes{
+				Path:         path,
+				Size:         int64(len(file.contents)),
+				LastModified: file.lastModified,
+			}
+		}
+	}
+
+	for path, created := range a.directories {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		addDirectories(path)
+		if deep || !strings.Contains(strings.TrimPrefix(path, prefix), "/") {
+			entries[path] = interfaces.StorageAttributes{Path: path, IsDir: true, LastModified: created}
+		}
+	}
+
+	listing := make([]interfaces.StorageAttributes, 0, len(entries))
+	for _, entry := range entries {
+		listing = append(listing, entry)
+	}
+	sort.Slice(listing, func(i, j int) bool { return listing[i].Path < listing[j].Path })
+	return listing, nil
+}
+
+// Move moves a file.
+func (a *MemoryAdapter) Move(from, to string) error {
+	source, err := normalizePath(from)
+	if err != nil {
+		return err
+	}
+	destination, err := normalizePath(to)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, exists := a.files[source]
+	if !exists {
+		return exceptions.NewFileNotFoundException(from)
+	}
+
+	delete(a.files, source)
+	a.files[destination] = file
+	return nil
+}
+
+// Copy copies a file, including its visibility and content type.
+func (a *MemoryAdapter) Copy(from, to string) error {
+	file, err := a.file(from)
+	if err != nil {
+		return err
+	}
+
+	key, err := normalizePath(to)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.files[key] = &memoryFile{
+		contents:     file.contents,
+		visibility:   file.visibility,
+		mimeType:     file.mimeType,
+		lastModified: a.clock(),
+	}
+	return nil
+}
+
+// file returns a snapshot of a stored file.
+func (a *MemoryAdapter) file(location string) (memoryFile, error) {
+	key, err := normalizePath(location)
+	if err != nil {
+		return memoryFile{}, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	file, exists := a.files[key]
+	if !exists {
+		return memoryFile{}, exceptions.NewFileNotFoundException(location)
+	}
+	return *file, nil
+}
+
+// memoryReader is a seekable, no-op closing reader over file contents.
+type memoryReader struct {
+	*bytes.Reader
+}
+
+// Close is a no-op.
+func (memoryReader) Close() error {
+	return nil
+}
+
+// parentOf returns the parent directory of a normalized path.
+func parentOf(path string) string {
+	index := strings.LastIndex(path, "/")
+	if index < 0 {
+		return ""
+	}
+	return path[:index]
+}
+
+// Compile-time interface compliance check
+var _ interfaces.AdapterInterface = (*MemoryAdapter)(nil)
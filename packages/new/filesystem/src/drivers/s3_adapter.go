@@ -0,0 +1,673 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"govel/new/filesystem/exceptions"
+	"govel/new/filesystem/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// allUsersURI identifies the public grantee in S3 access control lists.
+const allUsersURI = "http://acs.amazonaws.com/groups/global/AllUsers"
+
+// S3Adapter stores files in an S3 bucket or an S3-compatible server.
+//
+// Visibility maps to the public-read and private canned ACLs. Directories
+// are key prefixes; MakeDirectory writes an empty "dir/" marker object.
+// Streams of unknown length are spooled to a temporary file so they can be
+// uploaded with a known length and a signed payload hash.
+//
+// Supported options:
+//   - key, secret, token: Credentials
+//   - region: Bucket region, defaults to us-east-1
+//   - bucket: Bucket name (required)
+//   - endpoint: Custom endpoint such as http://localhost:9000
+//   - use_path_style_endpoint: Address the bucket in the path (MinIO)
+//   - root: Key prefix applied to every path
+//   - visibility: Visibility of new files, defaults to private
+type S3Adapter struct {
+	// client performs the signed requests
+	client *S3Client
+
+	// prefix is prepended to every key
+	prefix string
+
+	// visibility is the visibility of new files
+	visibility string
+}
+
+// listBucketResult is the ListObjectsV2 response body.
+type listBucketResult struct {
+	Contents []struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified"`
+		Size         int64  `xml:"Size"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// accessControlPolicy is the GetObjectAcl response body.
+type accessControlPolicy struct {
+	Grants []struct {
+		Grantee struct {
+			URI string `xml:"URI"`
+		} `xml:"Grantee"`
+		Permission string `xml:"Permission"`
+	} `xml:"AccessControlList>Grant"`
+}
+
+// NewS3Adapter creates an S3 adapter from a disk configuration.
+func NewS3Adapter(config map[string]interface{}) (*S3Adapter, error) {
+	bucket := stringOption(config, "bucket", "")
+	if bucket == "" {
+		return nil, fmt.Errorf("s3 disk requires a bucket")
+	}
+
+	prefix, err := normalizePath(stringOption(config, "root", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewS3Client(S3Config{
+		Key:                  stringOption(config, "key", ""),
+		Secret:               stringOption(config, "secret", ""),
+		Token:                stringOption(config, "token", ""),
+		Region:               stringOption(config, "region", ""),
+		Bucket:               bucket,
+		Endpoint:             stringOption(config, "endpoint", ""),
+		UsePathStyleEndpoint: boolOption(config, "use_path_style_endpoint", false),
+	})
+
+	return &S3Adapter{
+		client:     client,
+		prefix:     prefix,
+		visibility: stringOption(config, "visibility", storageInterfaces.VisibilityPrivate),
+	}, nil
+}
+
+// NewS3AdapterWithClient creates an S3 adapter around an existing client.
+func NewS3AdapterWithClient(client *S3Client, prefix string) *S3Adapter {
+	normalized, _ := normalizePath(prefix)
+	return &S3Adapter{
+		client:     client,
+		prefix:     normalized,
+		visibility: storageInterfaces.VisibilityPrivate,
+	}
+}
+
+// Client returns the underlying S3 client.
+func (a *S3Adapter) Client() *S3Client {
+	return a.client
+}
+
+// FileExists reports whether an object exists.
+func (a *S3Adapter) FileExists(location string) (bool, error) {
+	_, err := a.head(location)
+	if exceptions.IsFileNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// DirectoryExists reports whether any object exists below the prefix.
+func (a *S3Adapter) DirectoryExists(location string) (bool, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return false, err
+	}
+
+	query := url.Values{"list-type": {"2"}, "max-keys": {"1"}}
+	if key != "" {
+		query.Set("prefix", key+"/")
+	}
+
+	result, err := a.list(query)
+	if err != nil {
+		return false, err
+	}
+	return len(result.Contents) > 0 || len(result.CommonPrefixes) > 0, nil
+}
+
+// Write uploads an object.
+func (a *S3Adapter) Write(location string, contents io.Reader, options map[string]interface{}) error {
+	key, err := a.key(location)
+	if err != nil {
+		return err
+	}
+
+	visibility := stringOption(options, "visibility", a.visibility)
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	body, size, payloadHash, first, cleanup, err := a.prepareBody(contents)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	header := http.Header{}
+	header.Set("Content-Type", stringOption(options, "mimetype", detectMimeType(location, first)))
+	header.Set("X-Amz-Acl", cannedACL(visibility))
+	if cacheControl := stringOption(options, "cache_control", ""); cacheControl != "" {
+		header.Set("Cache-Control", cacheControl)
+	}
+	for name, value := range mapOption(options, "metadata") {
+		header.Set("X-Amz-Meta-"+name, fmt.Sprint(value))
+	}
+
+	resp, err := a.client.Do(context.Background(), http.MethodPut, key, nil, header, body, size, payloadHash)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", location, err)
+	}
+	return drain(resp)
+}
+
+// prepareBody returns a seekable body with its length, hash and first bytes.
+// In-memory readers are used directly; other streams are spooled to disk.
+func (a *S3Adapter) prepareBody(contents io.Reader) (io.Reader, int64, string, []byte, func(), error) {
+	if reader, ok := contents.(*bytes.Reader); ok {
+		data := make([]byte, reader.Len())
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, 0, "", nil, nil, err
+		}
+		return bytes.NewReader(data), int64(len(data)), hashHex(data), sniffHead(data), func() {}, nil
+	}
+
+	spool, err := os.CreateTemp("", "govel-s3-*")
+	if err != nil {
+		return nil, 0, "", nil, nil, err
+	}
+	cleanup := func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hasher), contents)
+	if err != nil {
+		cleanup()
+		return nil, 0, "", nil, nil, err
+	}
+
+	first := make([]byte, 512)
+	n, _ := spool.ReadAt(first, 0)
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, 0, "", nil, nil, err
+	}
+
+	return spool, size, hex.EncodeToString(hasher.Sum(nil)), first[:n], cleanup, nil
+}
+
+// Read opens an object for streamed reading. The returned reader is also an
+// io.Seeker: seeking re-requests the object from the new offset.
+func (a *S3Adapter) Read(location string) (io.ReadCloser, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(context.Background(), http.MethodGet, key, nil, nil, nil, 0, "")
+	if err != nil {
+		return nil, a.translate(location, err)
+	}
+	return newS3ObjectReader(a.client, key, resp), nil
+}
+
+// Delete removes an object.
+func (a *S3Adapter) Delete(location string) error {
+	key, err := a.key(location)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.client.Do(context.Background(), http.MethodDelete, key, nil, nil, nil, 0, "")
+	if err != nil {
+		if exceptions.IsFileNotFound(a.translate(location, err)) {
+			return nil
+		}
+		return err
+	}
+	return drain(resp)
+}
+
+// DeleteDirectory removes every object below the prefix.
+func (a *S3Adapter) DeleteDirectory(location string) error {
+	key, err := a.key(location)
+	if err != nil {
+		return err
+	}
+
+	prefix := ""
+	if key != "" {
+		prefix = key + "/"
+	}
+
+	keys, err := a.listAll(prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, objectKey := range keys {
+		resp, err := a.client.Do(context.Background(), http.MethodDelete, objectKey, nil, nil, nil, 0, "")
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", objectKey, err)
+		}
+		if err := drain(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateDirectory writes an empty directory marker object.
+func (a *S3Adapter) CreateDirectory(location string, options map[string]interface{}) error {
+	key, err := a.key(location)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return nil
+	}
+
+	header := http.Header{}
+	header.Set("X-Amz-Acl", cannedACL(stringOption(options, "visibility", a.visibility)))
+
+	resp, err := a.client.Do(context.Background(), http.MethodPut, key+"/", nil, header, bytes.NewReader(nil), 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	return drain(resp)
+}
+
+// SetVisibility applies the canned ACL matching the visibility.
+func (a *S3Adapter) SetVisibility(location, visibility string) error {
+	if err := validateVisibility(visibility); err != nil {
+		return err
+	}
+
+	key, err := a.key(location)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("X-Amz-Acl", cannedACL(visibility))
+
+	resp, err := a.client.Do(context.Background(), http.MethodPut, key, url.Values{"acl": {""}}, header, bytes.NewReader(nil), 0, emptyPayloadHash)
+	if err != nil {
+		return a.translate(location, err)
+	}
+	return drain(resp)
+}
+
+// Visibility reads the object ACL; objects readable by AllUsers are public.
+func (a *S3Adapter) Visibility(location string) (string, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := a.client.Do(context.Background(), http.MethodGet, key, url.Values{"acl": {""}}, nil, nil, 0, "")
+	if err != nil {
+		return "", a.translate(location, err)
+	}
+	defer resp.Body.Close()
+
+	var policy accessControlPolicy
+	if err := xml.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return "", fmt.Errorf("failed to decode acl of %s: %w", location, err)
+	}
+
+	for _, grant := range policy.Grants {
+		if grant.Grantee.URI == allUsersURI && (grant.Permission == "READ" || grant.Permission == "FULL_CONTROL") {
+			return storageInterfaces.VisibilityPublic, nil
+		}
+	}
+	return storageInterfaces.VisibilityPrivate, nil
+}
+
+// MimeType returns the stored Content-Type of an object.
+func (a *S3Adapter) MimeType(location string) (string, error) {
+	header, err := a.head(location)
+	if err != nil {
+		return "", err
+	}
+	if mimeType := header.Get("Content-Type"); mimeType != "" {
+		return mimeType, nil
+	}
+	return detectMimeType(location, nil), nil
+}
+
+// LastModified returns the Last-Modified time of an object.
+func (a *S3Adapter) LastModified(location string) (time.Time, error) {
+	header, err := a.head(location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return http.ParseTime(header.Get("Last-Modified"))
+}
+
+// FileSize returns the Content-Length of an object.
+func (a *S3Adapter) FileSize(location string) (int64, error) {
+	header, err := a.head(location)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+}
+
+// ListContents lists the objects and prefixes below a directory.
+func (a *S3Adapter) ListContents(location string, deep bool) ([]interfaces.StorageAttributes, error) {
+	directory, err := normalizePath(location)
+	if err != nil {
+		return nil, err
+	}
+	key, err := a.key(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := ""
+	if key != "" {
+		prefix = key + "/"
+	}
+
+	entries := make(map[string]interfaces.StorageAttributes)
+	query := url.Values{"list-type": {"2"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if !deep {
+		query.Set("delimiter", "/")
+	}
+
+	for {
+		result, err := a.list(query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			if object.Key == prefix {
+				continue
+			}
+
+			relative := a.relative(object.Key)
+			if deep {
+				for parent := parentOf(strings.TrimSuffix(relative, "/")); len(parent) > len(directory); parent = parentOf(parent) {
+					entries[parent] = interfaces.StorageAttributes{Path: parent, IsDir: true}
+				}
+			}
+
+			if strings.HasSuffix(object.Key, "/") {
+				path := strings.TrimSuffix(relative, "/")
+				entries[path] = interfaces.StorageAttributes{Path: path, IsDir: true}
+				continue
+			}
+
+			modified, _ := time.Parse(time.RFC3339, object.LastModified)
+			entries[relative] = interfaces.StorageAttributes{
+				Path:         relative,
+				Size:         object.Size,
+				LastModified: modified,
+			}
+		}
+
+		for _, common := range result.CommonPrefixes {
+			path := strings.TrimSuffix(a.relative(common.Prefix), "/")
+			entries[path] = interfaces.StorageAttributes{Path: path, IsDir: true}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+
+	listing := make([]interfaces.StorageAttributes, 0, len(entries))
+	for _, entry := range entries {
+		listing = append(listing, entry)
+	}
+	sort.Slice(listing, func(i, j int) bool { return listing[i].Path < listing[j].Path })
+	return listing, nil
+}
+
+// Move copies an object and deletes the source.
+func (a *S3Adapter) Move(from, to string) error {
+	if err := a.Copy(from, to); err != nil {
+		return err
+	}
+	return a.Delete(from)
+}
+
+// Copy copies an object server side, preserving its visibility.
+func (a *S3Adapter) Copy(from, to string) error {
+	source, err := a.key(from)
+	if err != nil {
+		return err
+	}
+	destination, err := a.key(to)
+	if err != nil {
+		return err
+	}
+
+	visibility, err := a.Visibility(from)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", "/"+a.client.Config().Bucket+"/"+uriEncode(source, false))
+	header.Set("X-Amz-Acl", cannedACL(visibility))
+
+	resp, err := a.client.Do(context.Background(), http.MethodPut, destination, nil, header, bytes.NewReader(nil), 0, emptyPayloadHash)
+	if err != nil {
+		return a.translate(from, err)
+	}
+	return drain(resp)
+}
+
+// PublicURL returns the unsigned URL of an object.
+func (a *S3Adapter) PublicURL(location string) (string, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return "", err
+	}
+	return a.client.ObjectURL(key, nil).String(), nil
+}
+
+// TemporaryURL returns a presigned GET URL.
+//
+// Supported options map to response header overrides:
+// ResponseContentType, ResponseContentLanguage, ResponseExpires,
+// ResponseCacheControl, ResponseContentDisposition and ResponseContentEncoding.
+func (a *S3Adapter) TemporaryURL(location string, expiration time.Time, options map[string]interface{}) (string, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	for option, parameter := range map[string]string{
+		"ResponseContentType":        "response-content-type",
+		"ResponseContentLanguage":    "response-content-language",
+		"ResponseExpires":            "response-expires",
+		"ResponseCacheControl":       "response-cache-control",
+		"ResponseContentDisposition": "response-content-disposition",
+		"ResponseContentEncoding":    "response-content-encoding",
+	} {
+		if value := stringOption(options, option, ""); value != "" {
+			query.Set(parameter, value)
+		}
+	}
+
+	return a.client.Presign(http.MethodGet, key, query, nil, time.Until(expiration))
+}
+
+// TemporaryUploadURL returns a presigned PUT URL.
+//
+// The "mimetype" and "visibility" options are signed into the URL; the
+// returned headers must be sent with the upload.
+func (a *S3Adapter) TemporaryUploadURL(location string, expiration time.Time, options map[string]interface{}) (string, map[string]string, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return "", nil, err
+	}
+
+	headers := map[string]string{}
+	if mimeType := stringOption(options, "mimetype", ""); mimeType != "" {
+		headers["Content-Type"] = mimeType
+	}
+	if visibility := stringOption(options, "visibility", ""); visibility != "" {
+		if err := validateVisibility(visibility); err != nil {
+			return "", nil, err
+		}
+		headers["x-amz-acl"] = cannedACL(visibility)
+	}
+
+	signed := http.Header{}
+	for name, value := range headers {
+		signed.Set(name, value)
+	}
+
+	presigned, err := a.client.Presign(http.MethodPut, key, nil, signed, time.Until(expiration))
+	if err != nil {
+		return "", nil, err
+	}
+	return presigned, headers, nil
+}
+
+// head returns the response headers of a HEAD request for an object.
+func (a *S3Adapter) head(location string) (http.Header, error) {
+	key, err := a.key(location)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(context.Background(), http.MethodHead, key, nil, nil, nil, 0, "")
+	if err != nil {
+		return nil, a.translate(location, err)
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+// list performs a single ListObjectsV2 request.
+func (a *S3Adapter) list(query url.Values) (*listBucketResult, error) {
+	resp, err := a.client.Do(context.Background(), http.MethodGet, "", query, nil, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode object listing: %w", err)
+	}
+	return &result, nil
+}
+
+// listAll returns every object key below a prefix.
+func (a *S3Adapter) listAll(prefix string) ([]string, error) {
+	query := url.Values{"list-type": {"2"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+
+	var keys []string
+	for {
+		result, err := a.list(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// key resolves a disk-relative path to an object key.
+func (a *S3Adapter) key(location string) (string, error) {
+	normalized, err := normalizePath(location)
+	if err != nil {
+		return "", err
+	}
+	if a.prefix == "" {
+		return normalized, nil
+	}
+	if normalized == "" {
+		return a.prefix, nil
+	}
+	return a.prefix + "/" + normalized, nil
+}
+
+// relative strips the adapter prefix from an object key.
+func (a *S3Adapter) relative(key string) string {
+	if a.prefix == "" {
+		return key
+	}
+	return strings.TrimPrefix(key, a.prefix+"/")
+}
+
+// translate maps 404 responses to FileNotFoundException.
+func (a *S3Adapter) translate(location string, err error) error {
+	var s3Err *S3Error
+	if errors.As(err, &s3Err) && s3Err.StatusCode == http.StatusNotFound {
+		return exceptions.NewFileNotFoundException(location)
+	}
+	return err
+}
+
+// cannedACL returns the canned ACL for a visibility.
+func cannedACL(visibility string) string {
+	if visibility == storageInterfaces.VisibilityPublic {
+		return "public-read"
+	}
+	return "private"
+}
+
+// drain discards and closes a response body so the connection can be reused.
+func drain(resp *http.Response) error {
+	defer resp.Body.Close()
+	_, err := io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// sniffHead returns the first bytes of data used for content sniffing.
+func sniffHead(data []byte) []byte {
+	if len(data) > 512 {
+		return data[:512]
+	}
+	return data
+}
+
+// Compile-time interface compliance checks
+var _ interfaces.AdapterInterface = (*S3Adapter)(nil)
+var _ interfaces.CloudInterface = (*S3Adapter)(nil)
+var _ interfaces.TemporaryURLInterface = (*S3Adapter)(nil)
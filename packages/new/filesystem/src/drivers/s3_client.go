@@ -0,0 +1,362 @@
+package drivers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty request body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config holds the settings of an S3-compatible endpoint.
+type S3Config struct {
+	// Key is the access key ID
+	Key string
+
+	// Secret is the secret access key
+	Secret string
+
+	// Token is the optional session token of temporary credentials
+	Token string
+
+	// Region is the bucket region, defaults to us-east-1
+	Region string
+
+	// Bucket is the bucket name
+	Bucket string
+
+	// Endpoint overrides the AWS endpoint (e.g. http://localhost:9000 for MinIO)
+	Endpoint string
+
+	// UsePathStyleEndpoint addresses the bucket in the path instead of the host
+	UsePathStyleEndpoint bool
+
+	// HTTPClient performs the requests, defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// S3Client is a minimal S3 REST client signing requests with AWS Signature
+// Version 4. It covers the object operations used by the S3 adapter and
+// works against AWS and S3-compatible servers such as MinIO.
+type S3Client struct {
+	// config holds the endpoint settings
+	config S3Config
+
+	// clock returns the current time used for signatures
+	clock func() time.Time
+}
+
+// S3Error is an error response returned by the S3 API.
+type S3Error struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+
+	// Code is the S3 error code (e.g. NoSuchKey)
+	Code string `xml:"Code"`
+
+	// Message is the human readable error message
+	Message string `xml:"Message"`
+}
+
+// Error implements the error interface.
+func (e *S3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3 request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("s3 request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// NewS3Client creates a new S3 client.
+func NewS3Client(config S3Config) *S3Client {
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	return &S3Client{config: config, clock: time.Now}
+}
+
+// SetClock replaces the clock used to date signatures.
+func (c *S3Client) SetClock(clock func() time.Time) *S3Client {
+	c.clock = clock
+	return c
+}
+
+// Config returns the client settings.
+func (c *S3Client) Config() S3Config {
+	return c.config
+}
+
+// ObjectURL returns the unsigned URL of an object key.
+func (c *S3Client) ObjectURL(key string, query url.Values) *url.URL {
+	endpoint := c.config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", c.config.Region)
+	}
+
+	base, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || base.Host == "" {
+		base = &url.URL{Scheme: "https", Host: strings.TrimRight(endpoint, "/")}
+	}
+
+	target := &url.URL{Scheme: base.Scheme, Host: base.Host}
+	objectPath := "/" + key
+	if c.config.UsePathStyleEndpoint {
+		objectPath = "/" + c.config.Bucket + objectPath
+	} else {
+		target.Host = c.config.Bucket + "." + base.Host
+	}
+
+	target.Path = base.Path + objectPath
+	target.RawPath = uriEncode(base.Path, false) + uriEncode(objectPath, false)
+	if query != nil {
+		target.RawQuery = canonicalQuery(query)
+	}
+	return target
+}
+
+// Do signs and sends a request for an object key.
+//
+// Parameters:
+//   - ctx: Request context
+//   - method: HTTP method
+//   - key: Object key, empty for bucket-level requests
+//   - query: Query parameters
+//   - header: Additional headers (Content-Type, x-amz-*)
+//   - body: Request body, nil for none
+//   - size: Body length in bytes
+//   - payloadHash: Hex SHA-256 of the body, or "UNSIGNED-PAYLOAD"
+//
+// Responses with a status of 300 or above are returned as *S3Error.
+func (c *S3Client) Do(ctx context.Context, method, key string, query url.Values, header http.Header, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	if payloadHash == "" {
+		payloadHash = emptyPayloadHash
+	}
+
+	target := c.ObjectURL(key, query)
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	c.sign(req, payloadHash)
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		s3Err := &S3Error{StatusCode: resp.StatusCode}
+		if data, readErr := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); readErr == nil && len(data) > 0 {
+			_ = xml.Unmarshal(data, s3Err)
+		}
+		return nil, s3Err
+	}
+	return resp, nil
+}
+
+// Presign returns a query-string signed URL for an object key, valid for the
+// given duration (at most seven days). Headers passed in are signed and must
+// be sent by the client; the payload is left unsigned.
+//
+// Parameters:
+//   - method: HTTP method the URL is valid for (GET, PUT, ...)
+//   - key: Object key
+//   - query: Additional query parameters (e.g. response-content-disposition)
+//   - header: Headers the client must send, may be nil
+//   - expires: Validity duration
+func (c *S3Client) Presign(method, key string, query url.Values, header http.Header, expires time.Duration) (string, error) {
+	if expires <= 0 || expires > 7*24*time.Hour {
+		return "", fmt.Errorf("presigned url expiration must be between 1 second and 7 days, got %s", expires)
+	}
+
+	now := c.clock().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := c.scope(now)
+
+	target := c.ObjectURL(key, nil)
+
+	headers := map[string]string{"host": target.Host}
+	for name, values := range header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(headers)
+
+	signed := url.Values{}
+	for name, values := range query {
+		signed[name] = append([]string(nil), values...)
+	}
+	signed.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	signed.Set("X-Amz-Credential", c.config.Key+"/"+scope)
+	signed.Set("X-Amz-Date", amzDate)
+	signed.Set("X-Amz-Expires", fmt.Sprint(int64((expires+time.Second-1)/time.Second)))
+	signed.Set("X-Amz-SignedHeaders", signedHeaders)
+	if c.config.Token != "" {
+		signed.Set("X-Amz-Security-Token", c.config.Token)
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		target.EscapedPath(),
+		canonicalQuery(signed),
+		canonicalHeaders,
+		signedHeaders,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signed.Set("X-Amz-Signature", c.signature(now, amzDate, scope, canonicalRequest))
+	target.RawQuery = canonicalQuery(signed)
+	return target.String(), nil
+}
+
+// sign adds the Signature Version 4 Authorization header to a request.
+func (c *S3Client) sign(req *http.Request, payloadHash string) {
+	now := c.clock().UTC()
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.config.Token != "" {
+		req.Header.Set("X-Amz-Security-Token", c.config.Token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "content-md5" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(headers)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := c.scope(now)
+	signature := c.signature(now, amzDate, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.Key, scope, signedHeaders, signature,
+	))
+}
+
+// scope returns the credential scope for a signing time.
+func (c *S3Client) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + c.config.Region + "/s3/aws4_request"
+}
+
+// signature derives the signing key and signs a canonical request.
+func (c *S3Client) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.config.Secret), now.Format("20060102"))
+	key = hmacSHA256(key, c.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalizeHeaders returns the canonical header block and the signed
+// header list for lower-cased header names.
+func canonicalizeHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// canonicalQuery encodes query parameters sorted by key and value.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but RFC 3986 unreserved characters,
+// optionally keeping slashes, as required by Signature Version 4.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			encoded.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			encoded.WriteByte(ch)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", ch)
+		}
+	}
+	return encoded.String()
+}
+
+// hashHex returns the hex SHA-256 of data.
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,98 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// s3ObjectReader streams an object and supports seeking by re-issuing the
+// GET with a Range header, so downloads can serve byte ranges without
+// buffering the object.
+type s3ObjectReader struct {
+	// client performs the ranged requests
+	client *S3Client
+
+	// key is the object key
+	key string
+
+	// size is the object size taken from the first response
+	size int64
+
+	// offset is the current read position
+	offset int64
+
+	// body is the open response body, nil after a seek
+	body io.ReadCloser
+}
+
+// newS3ObjectReader wraps the response of a full GET request.
+func newS3ObjectReader(client *S3Client, key string, resp *http.Response) *s3ObjectReader {
+	size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		size = resp.ContentLength
+	}
+	return &s3ObjectReader{client: client, key: key, size: size, body: resp.Body}
+}
+
+// Read reads from the current position, opening a ranged request if needed.
+func (r *s3ObjectReader) Read(p []byte) (int, error) {
+	if r.body == nil {
+		if r.size >= 0 && r.offset >= r.size {
+			return 0, io.EOF
+		}
+
+		header := http.Header{}
+		header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+		resp, err := r.client.Do(context.Background(), http.MethodGet, r.key, nil, header, nil, 0, "")
+		if err != nil {
+			return 0, err
+		}
+		r.body = resp.Body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+// Seek moves the read position; the next Read fetches from there.
+func (r *s3ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = r.offset + offset
+	case io.SeekEnd:
+		if r.size < 0 {
+			return 0, errors.New("s3 object size is unknown")
+		}
+		target = r.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if target < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if target != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = target
+	return target, nil
+}
+
+// Close closes the open response body.
+func (r *s3ObjectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -0,0 +1,39 @@
+// Package exceptions contains the exceptions raised by the filesystem package.
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+
+	"govel/exceptions/core"
+	"govel/exceptions/interfaces"
+)
+
+// FileNotFoundException is raised when a disk operation targets a file that
+// does not exist. It renders as HTTP 404.
+type FileNotFoundException struct {
+	*core.Exception
+
+	// Path is the missing path, relative to the disk root
+	Path string
+}
+
+// NewFileNotFoundException creates a new file not found exception.
+//
+// Parameters:
+//   - path: The missing path, relative to the disk root
+func NewFileNotFoundException(path string) *FileNotFoundException {
+	return &FileNotFoundException{
+		Exception: core.NewException(fmt.Sprintf("File not found at path: %s", path), 404),
+		Path:      path,
+	}
+}
+
+// IsFileNotFound reports whether err is, or wraps, a FileNotFoundException.
+func IsFileNotFound(err error) bool {
+	var notFound *FileNotFoundException
+	return errors.As(err, &notFound)
+}
+
+// Ensure FileNotFoundException implements the ExceptionInterface
+var _ interfaces.ExceptionInterface = (*FileNotFoundException)(nil)
@@ -0,0 +1,30 @@
+package exceptions
+
+import (
+	"govel/exceptions/core"
+	"govel/exceptions/interfaces"
+)
+
+// LockTimeoutException is raised when a file lock could not be acquired
+// before the configured timeout elapsed. It renders as HTTP 423.
+type LockTimeoutException struct {
+	*core.Exception
+}
+
+// NewLockTimeoutException creates a new lock timeout exception.
+//
+// Parameters:
+//   - message: Optional custom error message
+func NewLockTimeoutException(message ...string) *LockTimeoutException {
+	msg := "Unable to acquire file lock."
+	if len(message) > 0 && message[0] != "" {
+		msg = message[0]
+	}
+
+	return &LockTimeoutException{
+		Exception: core.NewException(msg, 423),
+	}
+}
+
+// Ensure LockTimeoutException implements the ExceptionInterface
+var _ interfaces.ExceptionInterface = (*LockTimeoutException)(nil)
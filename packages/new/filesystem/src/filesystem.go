@@ -0,0 +1,394 @@
+// Package filesystem provides Laravel-style storage disks for GoVel.
+//
+// Disks are configured under filesystems.disks and resolved by name:
+//
+//	disk := manager.Disk("s3")
+//	err := disk.Put("avatars/1.png", contents, map[string]interface{}{"visibility": "public"})
+//	url, err := disk.URL("avatars/1.png")
+//
+// Local, in-memory and S3-compatible drivers ship with the package; custom
+// drivers are registered through FilesystemManager.Extend.
+package filesystem
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"govel/new/filesystem/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// Filesystem is a storage disk: the user-facing API on top of an adapter.
+//
+// Key features:
+//   - Byte and streamed reads and writes
+//   - Public/private visibility, defaulting to the disk's "visibility" option
+//   - Recursive and flat file and directory listings
+//   - Public URLs from the disk's "url" option or the adapter
+//   - Temporary URLs presigned by the adapter, or HMAC-signed for served local disks
+//   - Streamed downloads with Range support
+type Filesystem struct {
+	// adapter is the storage driver
+	adapter interfaces.AdapterInterface
+
+	// config is the disk configuration
+	config map[string]interface{}
+
+	// signer signs temporary URLs for disks served by the application
+	signer *URLSigner
+
+	// serveURL is the base URL of the route serving the disk
+	serveURL string
+}
+
+// NewFilesystem creates a disk around an adapter.
+//
+// Parameters:
+//   - adapter: Storage driver
+//   - config: Disk configuration (url, visibility, ...), may be nil
+func NewFilesystem(adapter interfaces.AdapterInterface, config map[string]interface{}) *Filesystem {
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	return &Filesystem{adapter: adapter, config: config}
+}
+
+// ServeWith enables HMAC-signed temporary URLs pointing at the route
+// serving this disk (see ServeFileHandler).
+//
+// Parameters:
+//   - signer: Signer shared with the serving route
+//   - serveURL: Base URL of the serving route (e.g. https://app.test/storage)
+func (f *Filesystem) ServeWith(signer *URLSigner, serveURL string) *Filesystem {
+	f.signer = signer
+	f.serveURL = strings.TrimRight(serveURL, "/")
+	return f
+}
+
+// GetAdapter returns the storage driver backing the disk.
+func (f *Filesystem) GetAdapter() interfaces.AdapterInterface {
+	return f.adapter
+}
+
+// GetConfig returns the disk configuration.
+func (f *Filesystem) GetConfig() map[string]interface{} {
+	return f.config
+}
+
+// Path returns the full path of a file for adapters with a local root,
+// or the path itself otherwise.
+func (f *Filesystem) Path(path string) string {
+	if local, ok := f.adapter.(interface{ Path(string) string }); ok {
+		return local.Path(path)
+	}
+	return path
+}
+
+// Exists reports whether a file or directory exists at the given path.
+func (f *Filesystem) Exists(path string) bool {
+	if exists, err := f.adapter.FileExists(path); err == nil && exists {
+		return true
+	}
+	exists, err := f.adapter.DirectoryExists(path)
+	return err == nil && exists
+}
+
+// Missing reports whether nothing exists at the given path.
+func (f *Filesystem) Missing(path string) bool {
+	return !f.Exists(path)
+}
+
+// Get returns the contents of a file.
+func (f *Filesystem) Get(path string) ([]byte, error) {
+	reader, err := f.adapter.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// Put writes contents to a file.
+func (f *Filesystem) Put(path string, contents []byte, options ...map[string]interface{}) error {
+	return f.adapter.Write(path, bytes.NewReader(contents), f.writeOptions(options))
+}
+
+// ReadStream opens a file for streamed reading. The caller must close it.
+func (f *Filesystem) ReadStream(path string) (io.ReadCloser, error) {
+	return f.adapter.Read(path)
+}
+
+// WriteStream writes a file from a stream.
+func (f *Filesystem) WriteStream(path string, stream io.Reader, options ...map[string]interface{}) error {
+	return f.adapter.Write(path, stream, f.writeOptions(options))
+}
+
+// Delete removes one or more files, attempting every path before
+// returning the joined errors.
+func (f *Filesystem) Delete(paths ...string) error {
+	var errs []error
+	for _, path := range paths {
+		if err := f.adapter.Delete(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Copy copies a file to a new location.
+func (f *Filesystem) Copy(from, to string) error {
+	return f.adapter.Copy(from, to)
+}
+
+// Move moves a file to a new location.
+func (f *Filesystem) Move(from, to string) error {
+	return f.adapter.Move(from, to)
+}
+
+// Size returns the size of a file in bytes.
+func (f *Filesystem) Size(path string) (int64, error) {
+	return f.adapter.FileSize(path)
+}
+
+// LastModified returns the last modification time of a file.
+func (f *Filesystem) LastModified(path string) (time.Time, error) {
+	return f.adapter.LastModified(path)
+}
+
+// MimeType returns the content type of a file.
+func (f *Filesystem) MimeType(path string) (string, error) {
+	return f.adapter.MimeType(path)
+}
+
+// GetVisibility returns the visibility of a file.
+func (f *Filesystem) GetVisibility(path string) (string, error) {
+	return f.adapter.Visibility(path)
+}
+
+// SetVisibility changes the visibility of a file.
+func (f *Filesystem) SetVisibility(path, visibility string) error {
+	return f.adapter.SetVisibility(path, visibility)
+}
+
+// Files lists the files directly inside a directory.
+func (f *Filesystem) Files(directory string) ([]string, error) {
+	return f.listing(directory, false, false)
+}
+
+// AllFiles lists the files inside a directory, recursively.
+func (f *Filesystem) AllFiles(directory string) ([]string, error) {
+	return f.listing(directory, true, false)
+}
+
+// Directories lists the directories directly inside a directory.
+func (f *Filesystem) Directories(directory string) ([]string, error) {
+	return f.listing(directory, false, true)
+}
+
+// AllDirectories lists the directories inside a directory, recursively.
+func (f *Filesystem) AllDirectories(directory string) ([]string, error) {
+	return f.listing(directory, true, true)
+}
+
+// MakeDirectory creates a directory and its parents.
+func (f *Filesystem) MakeDirectory(path string) error {
+	options := make(map[string]interface{})
+	if visibility, ok := f.config["directory_visibility"]; ok {
+		options["visibility"] = visibility
+	}
+	return f.adapter.CreateDirectory(path, options)
+}
+
+// DeleteDirectory removes a directory and everything inside it.
+func (f *Filesystem) DeleteDirectory(directory string) error {
+	return f.adapter.DeleteDirectory(directory)
+}
+
+// URL returns the public URL of a file.
+//
+// Disks with a "url" option (e.g. the public disk, or a CDN in front of a
+// bucket) use it as the base URL; otherwise the adapter must be able to
+// generate URLs itself.
+func (f *Filesystem) URL(path string) (string, error) {
+	if base, ok := f.config["url"].(string); ok && base != "" {
+		return f.concatPathToURL(base, f.prefixed(path)), nil
+	}
+
+	if cloud, ok := f.adapter.(interfaces.CloudInterface); ok {
+		return cloud.PublicURL(path)
+	}
+
+	return "", fmt.Errorf("this driver does not support retrieving URLs")
+}
+
+// ProvidesTemporaryURLs reports whether the disk can generate temporary URLs.
+func (f *Filesystem) ProvidesTemporaryURLs() bool {
+	if _, ok := f.adapter.(interfaces.TemporaryURLInterface); ok {
+		return true
+	}
+	return f.signer != nil
+}
+
+// TemporaryURL returns a signed URL granting read access to a file until
+// the expiration: presigned by cloud adapters, or HMAC-signed for local
+// disks with the "serve" option.
+func (f *Filesystem) TemporaryURL(path string, expiration time.Time, options ...map[string]interface{}) (string, error) {
+	if cloud, ok := f.adapter.(interfaces.TemporaryURLInterface); ok {
+		return cloud.TemporaryURL(path, expiration, mergeOptions(options))
+	}
+	if f.signer != nil {
+		return f.signer.Sign(http.MethodGet, f.concatPathToURL(f.serveURL, path), expiration)
+	}
+	return "", fmt.Errorf("this driver does not support creating temporary URLs")
+}
+
+// TemporaryUploadURL returns a signed URL accepting a PUT upload of a file
+// until the expiration, and the headers the client must send with it.
+func (f *Filesystem) TemporaryUploadURL(path string, expiration time.Time, options ...map[string]interface{}) (string, map[string]string, error) {
+	if cloud, ok := f.adapter.(interfaces.TemporaryURLInterface); ok {
+		return cloud.TemporaryUploadURL(path, expiration, mergeOptions(options))
+	}
+	if f.signer != nil {
+		signed, err := f.signer.Sign(http.MethodPut, f.concatPathToURL(f.serveURL, path), expiration)
+		return signed, map[string]string{}, err
+	}
+	return "", nil, fmt.Errorf("this driver does not support creating temporary upload URLs")
+}
+
+// Download streams a file as an attachment.
+//
+// The file is never read into memory: the response body is the disk's read
+// stream, which is seekable for every built-in driver so the webserver can
+// answer Range requests with partial content.
+//
+// Parameters:
+//   - resp: Response to populate
+//   - path: File path on the disk
+//   - name: Optional download file name, defaults to the base name of path
+//
+// Example:
+//
+//	func (h *InvoiceHandler) Handle(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+//		resp, err := disk.Download(webserver.NewResponse(), "invoices/42.pdf", "invoice.pdf")
+//		if err != nil {
+//			return webserver.NewResponse().Status(404).Text("Not Found")
+//		}
+//		return resp
+//	}
+func (f *Filesystem) Download(resp webserverInterfaces.ResponseInterface, path string, name ...string) (webserverInterfaces.ResponseInterface, error) {
+	return f.Response(resp, path, firstOr(name, ""), "attachment")
+}
+
+// Response streams a file inline (or with the given disposition).
+//
+// Parameters:
+//   - resp: Response to populate
+//   - path: File path on the disk
+//   - name: File name for the Content-Disposition header, defaults to the base name of path
+//   - disposition: "inline" or "attachment"
+func (f *Filesystem) Response(resp webserverInterfaces.ResponseInterface, path, name, disposition string) (webserverInterfaces.ResponseInterface, error) {
+	size, err := f.adapter.FileSize(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, err := f.adapter.MimeType(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = path[strings.LastIndex(path, "/")+1:]
+	}
+	if disposition == "" {
+		disposition = "inline"
+	}
+
+	stream, err := f.adapter.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Header("Content-Length", strconv.FormatInt(size, 10))
+	resp.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": name}))
+	if modified, err := f.adapter.LastModified(path); err == nil && !modified.IsZero() {
+		resp.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	return resp.Stream(stream, mimeType), nil
+}
+
+// listing lists file or directory paths below a directory.
+func (f *Filesystem) listing(directory string, deep, directories bool) ([]string, error) {
+	entries, err := f.adapter.ListContents(directory, deep)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir == directories {
+			paths = append(paths, entry.Path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// writeOptions merges the caller's write options with the disk defaults.
+func (f *Filesystem) writeOptions(options []map[string]interface{}) map[string]interface{} {
+	merged := mergeOptions(options)
+	if _, ok := merged["visibility"]; !ok {
+		if visibility, ok := f.config["visibility"]; ok {
+			merged["visibility"] = visibility
+		}
+	}
+	return merged
+}
+
+// prefixed applies the disk's "prefix" option to a URL path.
+func (f *Filesystem) prefixed(path string) string {
+	prefix, _ := f.config["prefix"].(string)
+	if prefix == "" {
+		return path
+	}
+	return strings.Trim(prefix, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// concatPathToURL joins a base URL and a path with a single slash.
+func (f *Filesystem) concatPathToURL(base, path string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// mergeOptions merges optional options maps, later maps winning.
+func mergeOptions(options []map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, option := range options {
+		for key, value := range option {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// firstOr returns the first value, or fallback when there is none.
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return fallback
+}
+
+// Compile-time interface compliance checks
+var _ interfaces.FilesystemInterface = (*Filesystem)(nil)
+var _ storageInterfaces.CloudInterface = (*Filesystem)(nil)
@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"io"
+	"time"
+)
+
+// StorageAttributes describes a file or directory returned by an adapter listing.
+type StorageAttributes struct {
+	// Path is the path relative to the disk root, using forward slashes
+	Path string
+
+	// IsDir reports whether the entry is a directory
+	IsDir bool
+
+	// Size is the file size in bytes (zero for directories)
+	Size int64
+
+	// LastModified is the modification time, zero when unknown
+	LastModified time.Time
+}
+
+// AdapterInterface is implemented by storage drivers.
+//
+// Adapters work with paths relative to the disk root and report missing
+// files with exceptions.FileNotFoundException. The Filesystem wrapping an
+// adapter provides the user-facing API on top of these primitives.
+type AdapterInterface interface {
+	// FileExists reports whether a file exists.
+	FileExists(path string) (bool, error)
+
+	// DirectoryExists reports whether a directory exists.
+	DirectoryExists(path string) (bool, error)
+
+	// Write writes a file from a stream. The options map carries the
+	// visibility, mimetype, cache_control and metadata write options.
+	Write(path string, contents io.Reader, options map[string]interface{}) error
+
+	// Read opens a file for reading.
+	Read(path string) (io.ReadCloser, error)
+
+	// Delete removes a file. Deleting a missing file is not an error.
+	Delete(path string) error
+
+	// DeleteDirectory removes a directory and everything inside it.
+	DeleteDirectory(path string) error
+
+	// CreateDirectory creates a directory and its parents.
+	CreateDirectory(path string, options map[string]interface{}) error
+
+	// SetVisibility changes the visibility of a file.
+	SetVisibility(path, visibility string) error
+
+	// Visibility returns the visibility of a file.
+	Visibility(path string) (string, error)
+
+	// MimeType returns the content type of a file.
+	MimeType(path string) (string, error)
+
+	// LastModified returns the modification time of a file.
+	LastModified(path string) (time.Time, error)
+
+	// FileSize returns the size of a file in bytes.
+	FileSize(path string) (int64, error)
+
+	// ListContents lists the entries of a directory, recursively when deep is true.
+	ListContents(path string, deep bool) ([]StorageAttributes, error)
+
+	// Move moves a file.
+	Move(from, to string) error
+
+	// Copy copies a file.
+	Copy(from, to string) error
+}
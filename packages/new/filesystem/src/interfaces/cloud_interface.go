@@ -0,0 +1,22 @@
+package interfaces
+
+import "time"
+
+// CloudInterface is implemented by adapters that can generate public URLs
+// for their files themselves, such as the S3 adapter. Disks configured with
+// an explicit "url" option use that base URL instead.
+type CloudInterface interface {
+	// PublicURL returns the public URL of a file.
+	PublicURL(path string) (string, error)
+}
+
+// TemporaryURLInterface is implemented by adapters that can sign temporary
+// URLs themselves, such as presigned S3 URLs. Local disks get HMAC-signed
+// URLs from the filesystem instead when their "serve" option is enabled.
+type TemporaryURLInterface interface {
+	// TemporaryURL returns a signed URL granting read access until the expiration.
+	TemporaryURL(path string, expiration time.Time, options map[string]interface{}) (string, error)
+
+	// TemporaryUploadURL returns a signed PUT URL and the headers the client must send.
+	TemporaryUploadURL(path string, expiration time.Time, options map[string]interface{}) (string, map[string]string, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// FactoryInterface defines the contract for resolving disks by name.
+type FactoryInterface interface {
+	// Disk returns the named disk, or the default disk when no name is given.
+	Disk(name ...string) storageInterfaces.CloudInterface
+
+	// DiskWithError returns the named disk or the error raised while building it.
+	DiskWithError(name ...string) (storageInterfaces.CloudInterface, error)
+}
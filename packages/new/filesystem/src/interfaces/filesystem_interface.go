@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	webserverInterfaces "govel/new/webserver/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// FilesystemInterface extends the framework-wide disk contract with access to
+// the underlying adapter and disk configuration. It is implemented by
+// filesystem.Filesystem.
+type FilesystemInterface interface {
+	storageInterfaces.CloudInterface
+
+	// GetAdapter returns the storage driver backing the disk.
+	GetAdapter() AdapterInterface
+
+	// GetConfig returns the disk configuration.
+	GetConfig() map[string]interface{}
+
+	// Download streams a file as an attachment through the response.
+	Download(resp webserverInterfaces.ResponseInterface, path string, name ...string) (webserverInterfaces.ResponseInterface, error)
+
+	// Response streams a file through the response with the given disposition.
+	Response(resp webserverInterfaces.ResponseInterface, path, name, disposition string) (webserverInterfaces.ResponseInterface, error)
+
+	// Path returns the full path of a file for adapters with a local root,
+	// or the path itself otherwise.
+	Path(path string) string
+}
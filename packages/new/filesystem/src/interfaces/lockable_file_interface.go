@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"os"
+	"time"
+)
+
+// LockableFileInterface is a file handle guarded by an advisory lock.
+// It is implemented by filesystem.LockableFile.
+type LockableFileInterface interface {
+	// File returns the underlying file handle.
+	File() *os.File
+
+	// Read returns the file contents from the start of the file.
+	Read() ([]byte, error)
+
+	// Truncate empties the file and rewinds the offset to its start.
+	Truncate() error
+
+	// SetTimeout bounds how long blocking lock attempts wait.
+	SetTimeout(timeout time.Duration) LockableFileInterface
+
+	// GetSharedLock acquires a shared (read) lock.
+	GetSharedLock(block bool) error
+
+	// GetExclusiveLock acquires an exclusive (write) lock.
+	GetExclusiveLock(block bool) error
+
+	// ReleaseLock releases the currently held lock.
+	ReleaseLock() error
+
+	// Close releases the lock and closes the file.
+	Close() error
+}
+
+// LockableFileFactory opens a lockable file with os.OpenFile flags.
+type LockableFileFactory func(path string, flag int) (LockableFileInterface, error)
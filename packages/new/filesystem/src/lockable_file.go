@@ -0,0 +1,202 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"govel/new/filesystem/exceptions"
+	"govel/new/filesystem/interfaces"
+)
+
+// errWouldBlock is returned by the platform lock functions when a
+// non-blocking lock attempt fails because another holder owns the lock.
+var errWouldBlock = errors.New("lock is held by another process")
+
+// LockableFile is a file handle guarded by an advisory lock.
+//
+// On Unix systems locks are flock(2) locks and are honoured across processes;
+// elsewhere they only coordinate goroutines of the current process.
+//
+// Example:
+//
+//	file, err := filesystem.NewLockableFile("storage/counter", os.O_RDWR|os.O_CREATE)
+//	if err != nil {
+//		return err
+//	}
+//	defer file.Close()
+//
+//	if err := file.GetExclusiveLock(true); err != nil {
+//		return err
+//	}
+//	contents, _ := file.Read()
+//	_ = file.Truncate()
+//	_ = file.Write(next(contents))
+type LockableFile struct {
+	// file is the underlying file handle
+	file *os.File
+
+	// path is the file path
+	path string
+
+	// locked reports whether a lock is currently held
+	locked bool
+
+	// timeout bounds blocking lock attempts, zero means wait forever
+	timeout time.Duration
+
+	// unlock releases the currently held lock
+	unlock func() error
+}
+
+// NewLockableFile opens the file at path, creating its parent directories
+// when the flags include os.O_CREATE.
+//
+// Parameters:
+//   - path: File path
+//   - flag: os.OpenFile flags (e.g. os.O_RDWR|os.O_CREATE)
+func NewLockableFile(path string, flag int) (*LockableFile, error) {
+	if flag&os.O_CREATE != 0 {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+	}
+
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockableFile{file: file, path: path}, nil
+}
+
+// SetTimeout bounds how long blocking lock attempts wait before failing
+// with a LockTimeoutException. Zero waits forever.
+func (f *LockableFile) SetTimeout(timeout time.Duration) interfaces.LockableFileInterface {
+	f.timeout = timeout
+	return f
+}
+
+// Read returns the file contents from the start of the file.
+func (f *LockableFile) Read() ([]byte, error) {
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f.file)
+}
+
+// Size returns the current file size in bytes.
+func (f *LockableFile) Size() (int64, error) {
+	info, err := f.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Write writes contents at the current offset and flushes them to disk.
+func (f *LockableFile) Write(contents []byte) error {
+	if _, err := f.file.Write(contents); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Truncate empties the file and rewinds the offset to its start.
+func (f *LockableFile) Truncate() error {
+	if err := f.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.file.Seek(0, io.SeekStart)
+	return err
+}
+
+// File returns the underlying file handle.
+func (f *LockableFile) File() *os.File {
+	return f.file
+}
+
+// IsLocked reports whether the handle currently holds a lock.
+func (f *LockableFile) IsLocked() bool {
+	return f.locked
+}
+
+// GetSharedLock acquires a shared (read) lock.
+//
+// When block is false the call fails immediately with a LockTimeoutException
+// if another holder owns an exclusive lock.
+func (f *LockableFile) GetSharedLock(block bool) error {
+	return f.acquire(false, block)
+}
+
+// GetExclusiveLock acquires an exclusive (write) lock.
+//
+// When block is false the call fails immediately with a LockTimeoutException
+// if another holder owns any lock on the file.
+func (f *LockableFile) GetExclusiveLock(block bool) error {
+	return f.acquire(true, block)
+}
+
+// ReleaseLock releases the currently held lock, if any.
+func (f *LockableFile) ReleaseLock() error {
+	if !f.locked {
+		return nil
+	}
+
+	f.locked = false
+	return f.unlock()
+}
+
+// Close releases the lock and closes the file.
+func (f *LockableFile) Close() error {
+	releaseErr := f.ReleaseLock()
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	return releaseErr
+}
+
+// acquire takes a lock, polling non-blocking attempts when a timeout is set.
+func (f *LockableFile) acquire(exclusive, block bool) error {
+	if f.locked {
+		if err := f.ReleaseLock(); err != nil {
+			return err
+		}
+	}
+
+	if block && f.timeout <= 0 {
+		unlock, err := lockFile(f.file, f.path, exclusive, true)
+		if err != nil {
+			return err
+		}
+		f.locked, f.unlock = true, unlock
+		return nil
+	}
+
+	deadline := time.Now().Add(f.timeout)
+	for {
+		unlock, err := lockFile(f.file, f.path, exclusive, false)
+		if err == nil {
+			f.locked, f.unlock = true, unlock
+			return nil
+		}
+		if !errors.Is(err, errWouldBlock) {
+			return err
+		}
+		if !block || time.Now().After(deadline) {
+			return exceptions.NewLockTimeoutException(fmt.Sprintf("Unable to acquire file lock at path [%s].", f.path))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// OpenLockableFile is the interfaces.LockableFileFactory backed by NewLockableFile.
+func OpenLockableFile(path string, flag int) (interfaces.LockableFileInterface, error) {
+	return NewLockableFile(path, flag)
+}
+
+// Compile-time interface compliance check
+var _ interfaces.LockableFileInterface = (*LockableFile)(nil)
@@ -0,0 +1,47 @@
+//go:build !unix
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// processLocks holds one lock per absolute path. Without flock(2) locks only
+// coordinate goroutines of the current process.
+var processLocks sync.Map
+
+// lockFile takes an in-process lock on the path.
+func lockFile(file *os.File, path string, exclusive, block bool) (func() error, error) {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+
+	value, _ := processLocks.LoadOrStore(path, &sync.RWMutex{})
+	mu := value.(*sync.RWMutex)
+
+	switch {
+	case exclusive && block:
+		mu.Lock()
+	case exclusive:
+		if !mu.TryLock() {
+			return nil, errWouldBlock
+		}
+	case block:
+		mu.RLock()
+	default:
+		if !mu.TryRLock() {
+			return nil, errWouldBlock
+		}
+	}
+
+	return func() error {
+		if exclusive {
+			mu.Unlock()
+		} else {
+			mu.RUnlock()
+		}
+		return nil
+	}, nil
+}
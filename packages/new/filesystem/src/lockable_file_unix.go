@@ -0,0 +1,39 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile takes a flock(2) lock on the file.
+func lockFile(file *os.File, path string, exclusive, block bool) (func() error, error) {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	if !block {
+		how |= syscall.LOCK_NB
+	}
+
+	fd := int(file.Fd())
+	for {
+		err := syscall.Flock(fd, how)
+		if err == nil {
+			break
+		}
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errWouldBlock
+		}
+		return nil, err
+	}
+
+	return func() error {
+		return syscall.Flock(fd, syscall.LOCK_UN)
+	}, nil
+}
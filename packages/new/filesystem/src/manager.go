@@ -0,0 +1,508 @@
+package filesystem
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"govel/new/filesystem/drivers"
+	"govel/new/filesystem/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	support "govel/support"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// FilesystemManager resolves and caches named disks.
+// Extends support.MultipleInstanceManager: each entry of filesystems.disks is
+// an instance whose "driver" option selects a Create{Driver}Driver method.
+//
+// Supported drivers: local, memory, s3.
+//
+// The manager also implements StorageInterface by proxying every disk
+// operation to the default disk (filesystems.default).
+//
+// Local disks with the "serve" option get HMAC-signed temporary URLs
+// (keyed with app.key) pointing at routes registered by RegisterServeRoutes.
+type FilesystemManager struct {
+	*support.MultipleInstanceManager
+
+	// mu guards the overrides below
+	mu sync.RWMutex
+
+	// disks holds disks set explicitly through Set or Fake; they take
+	// precedence over configured disks
+	disks map[string]storageInterfaces.CloudInterface
+
+	// signer signs temporary URLs of served local disks, created on first use
+	signer *URLSigner
+}
+
+// NewFilesystemManager creates a new filesystem manager.
+//
+// The application must provide a "config" binding implementing ConfigInterface.
+func NewFilesystemManager(app applicationInterfaces.ApplicationInterface) *FilesystemManager {
+	baseManager := support.NewMultipleInstanceManager(app)
+
+	manager := &FilesystemManager{
+		MultipleInstanceManager: baseManager,
+		disks:                   make(map[string]storageInterfaces.CloudInterface),
+	}
+
+	// Set up proxy self-reference so the base manager finds CreateXXXDriver methods
+	baseManager.SetProxySelf(manager)
+
+	return manager
+}
+
+// Disk returns the named disk, or the default disk when no name is given.
+// It panics if the disk cannot be resolved; use DiskWithError to handle
+// misconfiguration gracefully.
+func (m *FilesystemManager) Disk(name ...string) storageInterfaces.CloudInterface {
+	disk, err := m.DiskWithError(name...)
+	if err != nil {
+		panic(err)
+	}
+	return disk
+}
+
+// DiskWithError returns the named disk or the error raised while building it.
+func (m *FilesystemManager) DiskWithError(name ...string) (storageInterfaces.CloudInterface, error) {
+	diskName := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		diskName = name[0]
+	}
+
+	m.mu.RLock()
+	disk, exists := m.disks[diskName]
+	m.mu.RUnlock()
+	if exists {
+		return disk, nil
+	}
+
+	instance, err := m.Instance(diskName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve disk [%s]: %w", diskName, err)
+	}
+
+	disk, ok := instance.(storageInterfaces.CloudInterface)
+	if !ok {
+		return nil, fmt.Errorf("disk [%s] does not implement CloudInterface, got %T", diskName, instance)
+	}
+	return disk, nil
+}
+
+// Build creates an on-demand disk from the given configuration without
+// caching it. Only the built-in drivers are supported.
+func (m *FilesystemManager) Build(config map[string]interface{}) (storageInterfaces.CloudInterface, error) {
+	var (
+		disk interface{}
+		err  error
+	)
+
+	switch driver, _ := config["driver"].(string); driver {
+	case "local":
+		disk, err = m.CreateLocalDriver(config)
+	case "memory":
+		disk, err = m.CreateMemoryDriver(config)
+	case "s3":
+		disk, err = m.CreateS3Driver(config)
+	default:
+		return nil, fmt.Errorf("disk driver [%s] is not supported", driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return disk.(storageInterfaces.CloudInterface), nil
+}
+
+// Set replaces the named disk with the given one.
+func (m *FilesystemManager) Set(name string, disk storageInterfaces.CloudInterface) *FilesystemManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.disks[name] = disk
+	return m
+}
+
+// Fake replaces the named disk (or the default disk) with an empty
+// in-memory disk and returns it, so tests can assert on stored files.
+func (m *FilesystemManager) Fake(name ...string) *Filesystem {
+	diskName := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		diskName = name[0]
+	}
+
+	config := map[string]interface{}{"driver": "memory"}
+	if url, ok := m.GetInstanceConfig(diskName)["url"]; ok {
+		config["url"] = url
+	}
+
+	disk := NewFilesystem(drivers.NewMemoryAdapter(), config)
+	m.Set(diskName, disk)
+	return disk
+}
+
+// Forget removes the named disks from the cache and the overrides.
+func (m *FilesystemManager) Forget(names ...string) *FilesystemManager {
+	m.mu.Lock()
+	for _, name := range names {
+		delete(m.disks, name)
+	}
+	m.mu.Unlock()
+
+	m.ForgetInstance(names...)
+	return m
+}
+
+// GetDefaultDriver returns the name of the default disk.
+func (m *FilesystemManager) GetDefaultDriver() string {
+	return m.GetConfig().GetString("filesystems.default", "local")
+}
+
+// GetDefaultInstance returns the name of the default disk.
+func (m *FilesystemManager) GetDefaultInstance() string {
+	return m.GetDefaultDriver()
+}
+
+// GetInstanceConfig returns the configuration of the named disk.
+func (m *FilesystemManager) GetInstanceConfig(name string) map[string]interface{} {
+	value, exists := m.GetConfig().Get("filesystems.disks." + name)
+	if !exists {
+		return nil
+	}
+
+	config, _ := value.(map[string]interface{})
+	return config
+}
+
+// CreateLocalDriver creates a disk stored on the local filesystem.
+func (m *FilesystemManager) CreateLocalDriver(config map[string]interface{}) (interface{}, error) {
+	adapter, err := drivers.NewLocalAdapter(config)
+	if err != nil {
+		return nil, err
+	}
+	adapter.SetLockFactory(OpenLockableFile)
+
+	disk := NewFilesystem(adapter, config)
+	if serves(config) {
+		disk.ServeWith(m.URLSigner(), m.serveURL(config))
+	}
+	return disk, nil
+}
+
+// CreateMemoryDriver creates a disk kept in memory.
+func (m *FilesystemManager) CreateMemoryDriver(config map[string]interface{}) (interface{}, error) {
+	return NewFilesystem(drivers.NewMemoryAdapter(config), config), nil
+}
+
+// CreateS3Driver creates a disk stored in an S3-compatible bucket.
+func (m *FilesystemManager) CreateS3Driver(config map[string]interface{}) (interface{}, error) {
+	adapter, err := drivers.NewS3Adapter(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilesystem(adapter, config), nil
+}
+
+// URLSigner returns the signer used for the temporary URLs of served disks.
+func (m *FilesystemManager) URLSigner() *URLSigner {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.signer == nil {
+		m.signer = NewURLSigner(m.GetConfig().GetString("app.key", ""))
+	}
+	return m.signer
+}
+
+// RegisterServeRoutes mounts a ServeFileHandler on the webserver for every
+// local disk with the "serve" option. Each disk is served below the path
+// of its "url" option, or "/storage" when it has none.
+//
+// Parameters:
+//   - server: Webserver to register the routes on
+//   - newResponse: Factory creating empty responses (usually webserver.NewResponse)
+func (m *FilesystemManager) RegisterServeRoutes(server webserverInterfaces.WebserverInterface, newResponse func() webserverInterfaces.ResponseInterface) error {
+	value, _ := m.GetConfig().Get("filesystems.disks")
+	configured, _ := value.(map[string]interface{})
+
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		config := m.GetInstanceConfig(name)
+		if config["driver"] != "local" || !serves(config) {
+			continue
+		}
+
+		disk, err := m.DiskWithError(name)
+		if err != nil {
+			return err
+		}
+		filesystem, ok := disk.(*Filesystem)
+		if !ok {
+			continue
+		}
+
+		handler := NewServeFileHandler(filesystem, m.URLSigner(), serveURI(config), newResponse)
+		route := handler.URI() + "/*"
+		server.Get(route, handler).Head(route, handler).Put(route, handler)
+	}
+	return nil
+}
+
+// serveURL returns the base URL of the route serving a disk.
+func (m *FilesystemManager) serveURL(config map[string]interface{}) string {
+	if base, ok := config["url"].(string); ok && base != "" {
+		return strings.TrimRight(base, "/")
+	}
+	return strings.TrimRight(m.GetConfig().GetString("app.url", ""), "/") + serveURI(config)
+}
+
+// serves reports whether a disk configuration enables the "serve" option.
+func serves(config map[string]interface{}) bool {
+	switch serve := config["serve"].(type) {
+	case bool:
+		return serve
+	case string:
+		enabled, _ := strconv.ParseBool(serve)
+		return enabled
+	default:
+		return false
+	}
+}
+
+// serveURI returns the route prefix serving a disk: the path of its "url"
+// option, or "/storage".
+func serveURI(config map[string]interface{}) string {
+	if base, ok := config["url"].(string); ok && base != "" {
+		if parsed, err := url.Parse(base); err == nil && strings.Trim(parsed.Path, "/") != "" {
+			return "/" + strings.Trim(parsed.Path, "/")
+		}
+	}
+	return "/storage"
+}
+
+// defaultDisk resolves the default disk for the proxied operations.
+func (m *FilesystemManager) defaultDisk() (storageInterfaces.CloudInterface, error) {
+	return m.DiskWithError()
+}
+
+// Exists reports whether a path exists on the default disk.
+func (m *FilesystemManager) Exists(path string) bool {
+	disk, err := m.defaultDisk()
+	return err == nil && disk.Exists(path)
+}
+
+// Missing reports whether a path is missing from the default disk.
+func (m *FilesystemManager) Missing(path string) bool {
+	return !m.Exists(path)
+}
+
+// Get returns the contents of a file on the default disk.
+func (m *FilesystemManager) Get(path string) ([]byte, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.Get(path)
+}
+
+// Put writes a file on the default disk.
+func (m *FilesystemManager) Put(path string, contents []byte, options ...map[string]interface{}) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.Put(path, contents, options...)
+}
+
+// ReadStream opens a file on the default disk for streamed reading.
+func (m *FilesystemManager) ReadStream(path string) (io.ReadCloser, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.ReadStream(path)
+}
+
+// WriteStream writes a file on the default disk from a stream.
+func (m *FilesystemManager) WriteStream(path string, stream io.Reader, options ...map[string]interface{}) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.WriteStream(path, stream, options...)
+}
+
+// Delete removes files from the default disk.
+func (m *FilesystemManager) Delete(paths ...string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.Delete(paths...)
+}
+
+// Copy copies a file on the default disk.
+func (m *FilesystemManager) Copy(from, to string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.Copy(from, to)
+}
+
+// Move moves a file on the default disk.
+func (m *FilesystemManager) Move(from, to string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.Move(from, to)
+}
+
+// Size returns the size of a file on the default disk.
+func (m *FilesystemManager) Size(path string) (int64, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return 0, err
+	}
+	return disk.Size(path)
+}
+
+// LastModified returns the modification time of a file on the default disk.
+func (m *FilesystemManager) LastModified(path string) (time.Time, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return disk.LastModified(path)
+}
+
+// MimeType returns the content type of a file on the default disk.
+func (m *FilesystemManager) MimeType(path string) (string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return "", err
+	}
+	return disk.MimeType(path)
+}
+
+// GetVisibility returns the visibility of a file on the default disk.
+func (m *FilesystemManager) GetVisibility(path string) (string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return "", err
+	}
+	return disk.GetVisibility(path)
+}
+
+// SetVisibility changes the visibility of a file on the default disk.
+func (m *FilesystemManager) SetVisibility(path, visibility string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.SetVisibility(path, visibility)
+}
+
+// Files lists the files directly inside a directory of the default disk.
+func (m *FilesystemManager) Files(directory string) ([]string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.Files(directory)
+}
+
+// AllFiles lists the files inside a directory of the default disk, recursively.
+func (m *FilesystemManager) AllFiles(directory string) ([]string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.AllFiles(directory)
+}
+
+// Directories lists the directories directly inside a directory of the default disk.
+func (m *FilesystemManager) Directories(directory string) ([]string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.Directories(directory)
+}
+
+// AllDirectories lists the directories inside a directory of the default disk, recursively.
+func (m *FilesystemManager) AllDirectories(directory string) ([]string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return nil, err
+	}
+	return disk.AllDirectories(directory)
+}
+
+// MakeDirectory creates a directory on the default disk.
+func (m *FilesystemManager) MakeDirectory(path string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.MakeDirectory(path)
+}
+
+// DeleteDirectory removes a directory from the default disk.
+func (m *FilesystemManager) DeleteDirectory(directory string) error {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return err
+	}
+	return disk.DeleteDirectory(directory)
+}
+
+// URL returns the public URL of a file on the default disk.
+func (m *FilesystemManager) URL(path string) (string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return "", err
+	}
+	return disk.URL(path)
+}
+
+// ProvidesTemporaryURLs reports whether the default disk can generate temporary URLs.
+func (m *FilesystemManager) ProvidesTemporaryURLs() bool {
+	disk, err := m.defaultDisk()
+	return err == nil && disk.ProvidesTemporaryURLs()
+}
+
+// TemporaryURL returns a temporary URL for a file on the default disk.
+func (m *FilesystemManager) TemporaryURL(path string, expiration time.Time, options ...map[string]interface{}) (string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return "", err
+	}
+	return disk.TemporaryURL(path, expiration, options...)
+}
+
+// TemporaryUploadURL returns a temporary upload URL for a file on the default disk.
+func (m *FilesystemManager) TemporaryUploadURL(path string, expiration time.Time, options ...map[string]interface{}) (string, map[string]string, error) {
+	disk, err := m.defaultDisk()
+	if err != nil {
+		return "", nil, err
+	}
+	return disk.TemporaryUploadURL(path, expiration, options...)
+}
+
+// Compile-time interface compliance checks
+var _ storageInterfaces.StorageInterface = (*FilesystemManager)(nil)
+var _ interfaces.FactoryInterface = (*FilesystemManager)(nil)
@@ -0,0 +1,126 @@
+// Package providers contains service provider implementations for the filesystem package.
+// Service providers are responsible for registering filesystem services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	filesystem "govel/new/filesystem"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	storageInterfaces "govel/types/interfaces/storage"
+	webserverTokens "govel/types/interfaces/webserver"
+)
+
+// FilesystemServiceProvider implements a Laravel-compatible service provider
+// for the filesystem package.
+//
+// Services registered:
+//   - STORAGE_TOKEN / STORAGE_MANAGER_TOKEN / STORAGE_FACTORY_TOKEN: Singleton FilesystemManager
+//   - STORAGE_INTERFACE_TOKEN: The default disk, resolved lazily
+//
+// On boot, routes serving local disks with the "serve" option are registered
+// on the webserver when one is bound.
+type FilesystemServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewFilesystemServiceProvider creates a new FilesystemServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewFilesystemServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register filesystem services:", err)
+//	}
+func NewFilesystemServiceProvider() *FilesystemServiceProvider {
+	return &FilesystemServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all filesystem services in the dependency injection container.
+func (p *FilesystemServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager     *filesystem.FilesystemManager
+		managerOnce sync.Once
+	)
+	managerFactory := func() interface{} {
+		managerOnce.Do(func() {
+			manager = filesystem.NewFilesystemManager(application)
+		})
+		return manager
+	}
+
+	for _, token := range []interface{}{
+		storageInterfaces.STORAGE_TOKEN,
+		storageInterfaces.STORAGE_MANAGER_TOKEN,
+		storageInterfaces.STORAGE_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, managerFactory); err != nil {
+			return fmt.Errorf("failed to bind filesystem manager: %w", err)
+		}
+	}
+
+	err := application.Bind(storageInterfaces.STORAGE_INTERFACE_TOKEN, func() (interface{}, error) {
+		disk, err := managerFactory().(*filesystem.FilesystemManager).DiskWithError()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve default disk: %w", err)
+		}
+		return disk, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind default disk: %w", err)
+	}
+
+	return nil
+}
+
+// Boot registers the routes serving local disks when a webserver is bound.
+func (p *FilesystemServiceProvider) Boot(application applicationInterfaces.ApplicationInterface) error {
+	if err := p.ServiceProvider.Boot(application); err != nil {
+		return err
+	}
+
+	if !application.IsBound(webserverTokens.WEBSERVER_TOKEN) {
+		return nil
+	}
+
+	resolved, err := application.Make(webserverTokens.WEBSERVER_TOKEN)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webserver: %w", err)
+	}
+	server, ok := resolved.(webserverInterfaces.WebserverInterface)
+	if !ok {
+		return nil
+	}
+
+	resolved, err = application.Make(storageInterfaces.STORAGE_MANAGER_TOKEN)
+	if err != nil {
+		return fmt.Errorf("failed to resolve filesystem manager: %w", err)
+	}
+
+	return resolved.(*filesystem.FilesystemManager).RegisterServeRoutes(server, func() webserverInterfaces.ResponseInterface {
+		return webserver.NewResponse()
+	})
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *FilesystemServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		storageInterfaces.STORAGE_TOKEN,
+		storageInterfaces.STORAGE_MANAGER_TOKEN,
+		storageInterfaces.STORAGE_FACTORY_TOKEN,
+		storageInterfaces.STORAGE_INTERFACE_TOKEN,
+	}
+}
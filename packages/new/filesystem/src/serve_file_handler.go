@@ -0,0 +1,105 @@
+package filesystem
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"govel/new/filesystem/exceptions"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	storageInterfaces "govel/types/interfaces/storage"
+)
+
+// ServeFileHandler serves the files of a local disk with the "serve" option.
+//
+// It answers:
+//   - GET/HEAD {uri}/{path}: streams the file inline, with Range support
+//   - PUT {uri}/{path}: streams the request body into the file
+//
+// Reads of disks whose visibility is not public, and every upload, require
+// a URL signed by the disk's TemporaryURL or TemporaryUploadURL. Invalid or
+// expired signatures are answered with 403.
+type ServeFileHandler struct {
+	// disk is the served disk
+	disk *Filesystem
+
+	// signer verifies temporary URLs
+	signer *URLSigner
+
+	// uri is the route prefix, e.g. "/storage"
+	uri string
+
+	// newResponse creates empty responses
+	newResponse func() webserverInterfaces.ResponseInterface
+}
+
+// NewServeFileHandler creates a handler serving a disk below a URI prefix.
+//
+// Parameters:
+//   - disk: The served disk
+//   - signer: Signer used by the disk's temporary URLs
+//   - uri: Route prefix the handler is mounted on
+//   - newResponse: Factory creating empty responses (usually webserver.NewResponse)
+func NewServeFileHandler(disk *Filesystem, signer *URLSigner, uri string, newResponse func() webserverInterfaces.ResponseInterface) *ServeFileHandler {
+	return &ServeFileHandler{
+		disk:        disk,
+		signer:      signer,
+		uri:         "/" + strings.Trim(uri, "/"),
+		newResponse: newResponse,
+	}
+}
+
+// URI returns the route prefix the handler is mounted on.
+func (h *ServeFileHandler) URI() string {
+	return h.uri
+}
+
+// Handle serves or stores the requested file.
+func (h *ServeFileHandler) Handle(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+	path, err := url.PathUnescape(strings.TrimPrefix(strings.TrimPrefix(req.Path(), h.uri), "/"))
+	if err != nil || path == "" {
+		return h.abort(http.StatusNotFound, "Not Found")
+	}
+
+	switch req.Method() {
+	case http.MethodPut:
+		if !h.signer.Verify(http.MethodPut, req.URL()) {
+			return h.abort(http.StatusForbidden, "Invalid signature.")
+		}
+		if err := h.disk.WriteStream(path, req.BodyReader()); err != nil {
+			return h.abort(http.StatusInternalServerError, "Unable to store file.")
+		}
+		return h.newResponse().NoContent(http.StatusCreated)
+
+	case http.MethodGet, http.MethodHead:
+		if !h.public() && !h.signer.Verify(http.MethodGet, req.URL()) {
+			return h.abort(http.StatusForbidden, "Invalid signature.")
+		}
+
+		resp, err := h.disk.Response(h.newResponse(), path, "", "inline")
+		if exceptions.IsFileNotFound(err) {
+			return h.abort(http.StatusNotFound, "Not Found")
+		}
+		if err != nil {
+			return h.abort(http.StatusInternalServerError, "Unable to read file.")
+		}
+		return resp
+
+	default:
+		return h.abort(http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// public reports whether the disk serves files without signatures.
+func (h *ServeFileHandler) public() bool {
+	visibility, _ := h.disk.GetConfig()["visibility"].(string)
+	return visibility == storageInterfaces.VisibilityPublic
+}
+
+// abort builds a plain text error response.
+func (h *ServeFileHandler) abort(status int, message string) webserverInterfaces.ResponseInterface {
+	return h.newResponse().Status(status).Text(message)
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.HandlerInterface = (*ServeFileHandler)(nil)
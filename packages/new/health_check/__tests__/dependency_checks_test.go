@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"govel/container"
+	"govel/healthcheck/checks/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/registry"
+	cacheInterfaces "govel/types/interfaces/cache"
+	configInterfaces "govel/types/interfaces/config"
+	queueInterfaces "govel/types/interfaces/queue"
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// memoryCache is a minimal cache store.
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+	err    error
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]interface{})}
+}
+
+func (c *memoryCache) Put(key string, value interface{}, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.values[key] = value
+	return nil
+}
+
+func (c *memoryCache) Get(key string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key], nil
+}
+
+func (c *memoryCache) Forget(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	return nil
+}
+
+// fakeQueue reports fixed queue sizes.
+type fakeQueue map[string]int
+
+func (q fakeQueue) Size(queue string) (int, error) {
+	return q[queue], nil
+}
+
+// fakeRedis answers PING with a fixed response.
+type fakeRedis struct{ response interface{} }
+
+func (r fakeRedis) Command(ctx context.Context, method string, args ...interface{}) (interface{}, error) {
+	return r.response, nil
+}
+
+// fakeConfig is a ConfigInterface serving only app.debug.
+type fakeConfig struct {
+	configInterfaces.ConfigInterface
+	debug bool
+}
+
+func (c fakeConfig) GetBool(key string, defaultValue ...bool) bool {
+	return key == "app.debug" && c.debug
+}
+
+func TestDependencyChecks_ResolveTargetsFromContainer(t *testing.T) {
+	app := container.New()
+	_ = app.Bind(cacheInterfaces.CACHE_TOKEN, newMemoryCache())
+	_ = app.Bind(queueInterfaces.QUEUE_TOKEN, fakeQueue{"default": 3, "emails": 250})
+	_ = app.Bind(redisInterfaces.REDIS_TOKEN, fakeRedis{response: "PONG"})
+	_ = app.Bind(configInterfaces.CONFIG_TOKEN, fakeConfig{debug: true})
+
+	cache := checks.NewCacheCheck()
+	queue := checks.NewQueueSizeCheck().FailWhenQueueSizeIsAbove("emails", 200)
+	redis := checks.NewRedisCheck()
+	debugMode := checks.NewDebugModeCheck()
+
+	reg := registry.NewHealthRegistry()
+	_ = reg.Register("cache", cache)
+	reg.SetContainer(app)
+	_ = reg.Register("queue", queue)
+	_ = reg.Register("redis", redis)
+	_ = reg.Register("debug", debugMode)
+
+	if got := cache.Run().GetStatus(); got != enums.StatusOK {
+		t.Errorf("cache status = %v", got)
+	}
+
+	result := queue.Run()
+	if result.GetStatus() != enums.StatusFailed || result.GetMeta()["emails"] != 250 {
+		t.Errorf("queue result = %v %v", result.GetStatus(), result.GetMeta())
+	}
+
+	if got := redis.Run().GetStatus(); got != enums.StatusOK {
+		t.Errorf("redis status = %v", got)
+	}
+
+	result = debugMode.Run()
+	if result.GetStatus() != enums.StatusFailed {
+		t.Errorf("debug mode status = %v", result.GetStatus())
+	}
+	if got := debugMode.ExpectedToBe(true).Run().GetStatus(); got != enums.StatusOK {
+		t.Errorf("debug mode expected true status = %v", got)
+	}
+}
+
+func TestDependencyChecks_CrashWithoutTarget(t *testing.T) {
+	result := checks.NewRedisCheck().Run()
+	if result.GetStatus() != enums.StatusCrashed {
+		t.Fatalf("status without container = %v", result.GetStatus())
+	}
+
+	check := checks.NewDatabaseCheck()
+	check.SetContainer(container.New())
+	if got := check.Run().GetStatus(); got != enums.StatusCrashed {
+		t.Fatalf("status without binding = %v", got)
+	}
+
+	broken := newMemoryCache()
+	broken.err = errors.New("read-only")
+	app := container.New()
+	_ = app.Bind(cacheInterfaces.CACHE_TOKEN, broken)
+	cache := checks.NewCacheCheck()
+	cache.SetContainer(app)
+	if got := cache.Run().GetStatus(); got != enums.StatusFailed {
+		t.Fatalf("status with failing cache = %v", got)
+	}
+}
+
+func TestScheduleHeartbeatCheck(t *testing.T) {
+	store := newMemoryCache()
+	app := container.New()
+	_ = app.Bind(cacheInterfaces.CACHE_TOKEN, store)
+
+	check := checks.NewScheduleHeartbeatCheck().HeartbeatMaxAgeInMinutes(5)
+	check.SetContainer(app)
+
+	if got := check.Run().GetNotificationMessage(); got != "The schedule did not run yet." {
+		t.Fatalf("message before first heartbeat = %q", got)
+	}
+
+	if err := check.RecordHeartbeat(); err != nil {
+		t.Fatal(err)
+	}
+	if got := check.Run().GetStatus(); got != enums.StatusOK {
+		t.Fatalf("status after heartbeat = %v", got)
+	}
+
+	store.values["health:checks:schedule:latestHeartbeatAt"] = time.Now().Add(-10 * time.Minute).Unix()
+	if got := check.Run().GetStatus(); got != enums.StatusFailed {
+		t.Fatalf("status with stale heartbeat = %v", got)
+	}
+}
+
+func TestOptimizedAppCheck(t *testing.T) {
+	release := &debug.BuildInfo{GoVersion: "go1.23.0", Settings: []debug.BuildSetting{
+		{Key: "-gcflags", Value: "-trimpath"},
+		{Key: "vcs.modified", Value: "false"},
+	}}
+	if got := checks.NewOptimizedAppCheck().BuildInfo(release).Run().GetStatus(); got != enums.StatusOK {
+		t.Fatalf("release build status = %v", got)
+	}
+
+	debugBuild := &debug.BuildInfo{GoVersion: "go1.23.0", Settings: []debug.BuildSetting{
+		{Key: "-gcflags", Value: "all=-N -l"},
+		{Key: "-race", Value: "true"},
+	}}
+	check := checks.NewOptimizedAppCheck().BuildInfo(debugBuild)
+	if got := check.Run().GetStatus(); got != enums.StatusFailed {
+		t.Fatalf("debug build status = %v", got)
+	}
+	if got := check.Checks(checks.OptimizedAppCleanBuild).Run().GetStatus(); got != enums.StatusOK {
+		t.Fatalf("clean build only status = %v", got)
+	}
+}
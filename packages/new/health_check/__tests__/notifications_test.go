@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"govel/healthcheck/enums"
+	"govel/healthcheck/notifications"
+	"govel/healthcheck/registry"
+)
+
+// webhookReceiver records the Slack payloads posted to it.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	payloads []map[string]interface{}
+}
+
+func (w *webhookReceiver) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var payload map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&payload)
+	w.mu.Lock()
+	w.payloads = append(w.payloads, payload)
+	w.mu.Unlock()
+}
+
+func (w *webhookReceiver) texts() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var texts []string
+	for _, payload := range w.payloads {
+		texts = append(texts, payload["text"].(string))
+	}
+	return texts
+}
+
+func TestNotifier_ReportsStateChangesAndThrottlesReminders(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := notifications.NewNotifier().
+		Channel(notifications.NewWebhookChannel(server.URL))
+
+	db := newCountingCheck("db", enums.StatusOK)
+	health := registry.NewHealthRegistry()
+	health.Register("db", db)
+	health.WithNotifier(notifier)
+
+	health.RunChecksWithTimeout(time.Second)
+	db.status = enums.StatusFailed
+	health.RunChecksWithTimeout(time.Second)
+	health.RunChecksWithTimeout(time.Second)
+	db.status = enums.StatusOK
+	health.RunChecksWithTimeout(time.Second)
+
+	texts := receiver.texts()
+	if len(texts) != 2 {
+		t.Fatalf("Expected a failure and a recovery, got %v", texts)
+	}
+	if !strings.Contains(texts[0], "reported failed") || !strings.Contains(texts[1], "recovered") {
+		t.Errorf("Unexpected notifications %v", texts)
+	}
+
+	receiver.mu.Lock()
+	attachment := receiver.payloads[0]["attachments"].([]interface{})[0].(map[string]interface{})
+	receiver.mu.Unlock()
+	if attachment["color"] != "danger" {
+		t.Errorf("Expected a danger attachment, got %v", attachment)
+	}
+}
+
+func TestNotifier_RoutesBySeverityAndCheck(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	var mailed []string
+	mail := notifications.NewMailChannel(func(ctx context.Context, to []string, subject, body string) error {
+		mailed = append(mailed, to[0]+": "+subject)
+		return nil
+	}, "ops@example.com")
+
+	notifier := notifications.NewNotifier().
+		Channel(notifications.NewWebhookChannel(server.URL), mail).
+		ThrottleNotificationsForMinutes(0).
+		Route(enums.StatusWarning, "webhook").
+		RouteCheck("db", enums.StatusFailed, "mail")
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	statuses := map[string]enums.Status{"db": enums.StatusFailed, "api": enums.StatusWarning}
+	notifier.Notify(context.Background(), snapshot(base, statuses))
+
+	if len(mailed) != 1 || mailed[0] != `ops@example.com: Health check "Db" reported failed` {
+		t.Errorf("Expected only the db failure to be mailed, got %v", mailed)
+	}
+	if texts := receiver.texts(); len(texts) != 1 || !strings.Contains(texts[0], `"Api"`) {
+		t.Errorf("Expected only the api warning on the webhook, got %v", texts)
+	}
+
+	// Without throttling, unchanged failures are reminded on every run
+	notifier.Notify(context.Background(), snapshot(base.Add(time.Minute), statuses))
+	if len(mailed) != 2 {
+		t.Errorf("Expected a reminder, got %v", mailed)
+	}
+
+	notifier.OnlyOnStateChange()
+	notifier.Notify(context.Background(), snapshot(base.Add(2*time.Minute), statuses))
+	if len(mailed) != 2 {
+		t.Errorf("Expected no reminder when only notifying on state change, got %v", mailed)
+	}
+}
+
+func TestNotifier_ReportsDeliveryErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var reported []error
+	notifier := notifications.NewNotifier().
+		Channel(notifications.NewWebhookChannel(server.URL)).
+		OnError(func(err error) { reported = append(reported, err) })
+
+	err := notifier.Notify(context.Background(), snapshot(time.Now(), map[string]enums.Status{"db": enums.StatusCrashed}))
+	if err == nil || !strings.Contains(err.Error(), "403") || len(reported) != 1 {
+		t.Errorf("Expected the 403 to be reported, got %v and %v", err, reported)
+	}
+}
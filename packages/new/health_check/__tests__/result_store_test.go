@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/controllers"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	"govel/healthcheck/registry"
+	"govel/healthcheck/stores"
+	"govel/healthcheck/types"
+)
+
+// countingCheck returns a configurable status and counts its executions.
+type countingCheck struct {
+	*checks.BaseCheck
+	status enums.Status
+	runs   int32
+}
+
+func newCountingCheck(name string, status enums.Status) *countingCheck {
+	check := &countingCheck{BaseCheck: checks.NewBaseCheck(), status: status}
+	check.Name(name)
+	return check
+}
+
+func (c *countingCheck) Run() interfaces.ResultInterface {
+	atomic.AddInt32(&c.runs, 1)
+	return checks.NewResult().SetStatus(c.status).SetShortSummary(c.status.String())
+}
+
+// snapshot builds stored results executed at the given time.
+func snapshot(at time.Time, statuses map[string]enums.Status) interfaces.CheckResultsInterface {
+	results := types.NewCheckResults()
+	results.SetExecutedAt(at)
+	for name, status := range statuses {
+		result := checks.NewResult()
+		result.SetStatus(status)
+		result.SetCheck(newCountingCheck(name, status))
+		results.AddResult(result)
+	}
+	return results
+}
+
+func TestInMemoryResultStore_RingBufferAndLookups(t *testing.T) {
+	store := stores.NewInMemoryResultStore(3)
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		store.Store(snapshot(base.Add(time.Duration(i)*time.Minute), map[string]enums.Status{"db": enums.StatusOK}))
+	}
+
+	history, _ := store.GetHistory(base, base.Add(time.Hour))
+	if len(history) != 3 || !history[0].GetExecutedAt().Equal(base.Add(2*time.Minute)) {
+		t.Fatalf("Expected the 3 most recent runs oldest first, got %d", len(history))
+	}
+
+	latest, _ := store.Get()
+	if !latest.GetExecutedAt().Equal(base.Add(4 * time.Minute)) {
+		t.Errorf("Unexpected latest run %v", latest.GetExecutedAt())
+	}
+
+	at, _ := store.GetByTimestamp(base.Add(3*time.Minute + 30*time.Second))
+	if at == nil || !at.GetExecutedAt().Equal(base.Add(3*time.Minute)) {
+		t.Errorf("Expected run current at 12:03:30, got %v", at)
+	}
+	if before, _ := store.GetByTimestamp(base); before != nil {
+		t.Errorf("Expected no run before the evicted ones, got %v", before.GetExecutedAt())
+	}
+}
+
+func TestJSONFileResultStore_PersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health", "results.jsonl")
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writer := stores.NewJSONFileResultStore(path, 2)
+	writer.Store(snapshot(base, map[string]enums.Status{"db": enums.StatusOK}))
+	writer.Store(snapshot(base.Add(time.Minute), map[string]enums.Status{"db": enums.StatusFailed}))
+	writer.Store(snapshot(base.Add(2*time.Minute), map[string]enums.Status{"db": enums.StatusWarning}))
+
+	reader := stores.NewJSONFileResultStore(path, 2)
+	history, err := reader.GetHistory(base, base.Add(time.Hour))
+	if err != nil || len(history) != 2 {
+		t.Fatalf("Expected 2 retained runs, got %d (%v)", len(history), err)
+	}
+
+	result := history[0].GetResultByName("db")
+	if result == nil || result.GetStatus() != enums.StatusFailed || !history[0].ContainsFailingCheck() {
+		t.Errorf("Expected decoded failed db result, got %+v", result)
+	}
+	if info := reader.GetStorageInfo(); info["count"] != 2 {
+		t.Errorf("Unexpected storage info %v", info)
+	}
+
+	reader.Clear()
+	if latest, err := writer.Get(); latest != nil || err != nil {
+		t.Errorf("Expected cleared store, got %v (%v)", latest, err)
+	}
+}
+
+func TestHealthRegistry_PersistsRunsAndServesCachedResults(t *testing.T) {
+	store := stores.NewInMemoryResultStore(10)
+	health := registry.NewHealthRegistry()
+	health.WithResultStore(store)
+
+	cached := newCountingCheck("api", enums.StatusOK)
+	cached.CacheResultsFor(60)
+	uncached := newCountingCheck("db", enums.StatusFailed)
+	health.Register("api", cached)
+	health.Register("db", uncached)
+
+	first := health.RunChecksWithTimeout(time.Second)
+	second := health.RunChecksWithTimeout(time.Second)
+
+	if cached.runs != 1 || uncached.runs != 2 {
+		t.Errorf("Expected cached check to run once and uncached twice, got %d and %d", cached.runs, uncached.runs)
+	}
+	if second.GetResultByName("api").GetMeta()["cached"] != true {
+		t.Errorf("Expected cached result to be flagged, got %v", second.GetResultByName("api").GetMeta())
+	}
+	if first.GetResultByName("api").GetMeta()["cached"] != nil {
+		t.Errorf("Expected fresh result not to be flagged as cached")
+	}
+
+	history, _ := store.GetHistory(time.Now().Add(-time.Minute), time.Now())
+	if len(history) != 2 {
+		t.Fatalf("Expected both runs to be persisted, got %d", len(history))
+	}
+
+	recorder := httptest.NewRecorder()
+	controllers.NewHealthController(health).HandleHistory(recorder, httptest.NewRequest(http.MethodGet, "/health/history?check=db", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected history status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var body struct {
+		Runs   int                             `json:"runs"`
+		Checks map[string][]types.HistoryEntry `json:"checks"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Runs != 2 || len(body.Checks) != 1 || len(body.Checks["db"]) != 2 || body.Checks["db"][1].Status != "failed" {
+		t.Errorf("Unexpected history %+v", body)
+	}
+}
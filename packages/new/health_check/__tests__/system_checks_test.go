@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"govel/healthcheck/checks/checks"
+	"govel/healthcheck/enums"
+)
+
+// writeTree creates files below root.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const meminfo = "MemTotal:       16000000 kB\nMemFree:         1000000 kB\nMemAvailable:    4000000 kB\n"
+
+func TestMemoryUsageCheck_ReadsCgroupsAndMeminfo(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		source   string
+		percent  int
+		status   enums.Status
+		limitKey uint64
+	}{
+		{
+			name: "cgroup v2 with working set",
+			files: map[string]string{
+				"cgroup/cgroup.controllers": "cpu memory",
+				"cgroup/app/memory.max":     "1000000000\n",
+				"cgroup/app/memory.current": "950000000\n",
+				"cgroup/app/memory.stat":    "anon 700000000\ninactive_file 100000000\n",
+				"proc/self/cgroup":          "0::/app\n",
+				"proc/meminfo":              meminfo,
+			},
+			source: "cgroup_v2", percent: 85, status: enums.StatusWarning, limitKey: 1000000000,
+		},
+		{
+			name: "cgroup v1 namespaced",
+			files: map[string]string{
+				"cgroup/memory/memory.limit_in_bytes": "2000000000\n",
+				"cgroup/memory/memory.usage_in_bytes": "1900000000\n",
+				"cgroup/memory/memory.stat":           "total_inactive_file 0\n",
+				"proc/self/cgroup":                    "4:memory:/kubepods/pod1\n0::/\n",
+				"proc/meminfo":                        meminfo,
+			},
+			source: "cgroup_v1", percent: 95, status: enums.StatusFailed, limitKey: 2000000000,
+		},
+		{
+			name: "unlimited cgroup falls back to meminfo",
+			files: map[string]string{
+				"cgroup/cgroup.controllers": "memory",
+				"cgroup/memory.max":         "max\n",
+				"cgroup/memory.current":     "1\n",
+				"proc/meminfo":              meminfo,
+			},
+			source: "meminfo", percent: 75, status: enums.StatusOK, limitKey: 16000000 * 1024,
+		},
+	}
+
+	for _, tc := range cases {
+		root := t.TempDir()
+		writeTree(t, root, tc.files)
+
+		result := checks.NewMemoryUsageCheck().
+			CgroupRoot(filepath.Join(root, "cgroup")).
+			ProcRoot(filepath.Join(root, "proc")).
+			Run()
+
+		meta := result.GetMeta()
+		if result.GetStatus() != tc.status || meta["source"] != tc.source || meta["memory_usage_percentage"] != tc.percent {
+			t.Errorf("%s: got status %v, meta %v", tc.name, result.GetStatus(), meta)
+		}
+		if meta["memory_limit_bytes"] != tc.limitKey {
+			t.Errorf("%s: unexpected limit %v", tc.name, meta["memory_limit_bytes"])
+		}
+	}
+
+	crashed := checks.NewMemoryUsageCheck().CgroupRoot(t.TempDir()).ProcRoot(t.TempDir()).Run()
+	if crashed.GetStatus() != enums.StatusCrashed || crashed.GetNotificationMessage() == "" {
+		t.Errorf("Expected crashed status without memory information, got %v", crashed.GetStatus())
+	}
+}
+
+func TestUsedDiskSpaceCheck_ReportsSpaceAndInodes(t *testing.T) {
+	check := checks.NewUsedDiskSpaceCheck().FilesystemName(t.TempDir())
+	check.WarnWhenUsedSpaceIsAbovePercentage(100).FailWhenUsedSpaceIsAbovePercentage(100)
+	check.WarnWhenUsedInodesIsAbovePercentage(100).FailWhenUsedInodesIsAbovePercentage(100)
+
+	result := check.Run()
+	meta := result.GetMeta()
+	if result.GetStatus() != enums.StatusOK {
+		t.Fatalf("Expected ok status, got %v: %s", result.GetStatus(), result.GetNotificationMessage())
+	}
+	if total, ok := meta["disk_total_bytes"].(uint64); !ok || total == 0 {
+		t.Errorf("Expected raw disk numbers in meta, got %v", meta)
+	}
+	if _, ok := meta["disk_space_used_percentage"].(int); !ok {
+		t.Errorf("Expected used percentage in meta, got %v", meta)
+	}
+
+	full := checks.NewUsedDiskSpaceCheck().FilesystemName(t.TempDir())
+	full.FailWhenUsedSpaceIsAbovePercentage(-1)
+	if got := full.Run().GetStatus(); got != enums.StatusFailed {
+		t.Errorf("Expected failed status past the threshold, got %v", got)
+	}
+
+	missing := checks.NewUsedDiskSpaceCheck().FilesystemName(filepath.Join(t.TempDir(), "missing")).Run()
+	if missing.GetStatus() != enums.StatusCrashed {
+		t.Errorf("Expected crashed status for a missing path, got %v", missing.GetStatus())
+	}
+}
@@ -3,11 +3,14 @@
 package checks
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"govel/healthcheck/enums"
 	"govel/healthcheck/interfaces"
+	containerInterfaces "govel/types/interfaces/container"
 )
 
 // ConditionFunc represents a condition function that returns whether a check should run
@@ -30,6 +33,12 @@ type BaseCheck struct {
 
 	// shouldRun contains conditions that determine if the check should execute
 	shouldRun []interface{} // can be bool or ConditionFunc
+
+	// cacheTTL is how long results are served from cache (0 disables caching)
+	cacheTTL time.Duration
+
+	// container is the service container the check resolves its targets from
+	container containerInterfaces.ContainerInterface
 }
 
 // NewBaseCheck creates a new BaseCheck instance with default values.
@@ -97,6 +106,15 @@ func (bc *BaseCheck) GetLabel() string {
 	return strings.Join(words, " ")
 }
 
+// HasName returns whether a name was set explicitly.
+//
+// Returns:
+//
+//	bool: true if Name was called
+func (bc *BaseCheck) HasName() bool {
+	return bc.name != nil
+}
+
 // GetName returns the name of the health check.
 // If no name is set, it generates one from the struct type name.
 //
@@ -195,6 +213,87 @@ func (bc *BaseCheck) Unless(condition interface{}) *BaseCheck {
 	return bc
 }
 
+// CacheResultsFor serves the results of the check from cache while they
+// are younger than the given number of seconds, instead of running the
+// check on every probe.
+//
+// Parameters:
+//
+//	seconds: How long a result may be reused (0 disables caching)
+//
+// Returns:
+//
+//	*BaseCheck: Self for method chaining
+//
+// Example:
+//
+//	check := checks.NewPingCheck().URL("https://api.example.com")
+//	check.CacheResultsFor(30)
+func (bc *BaseCheck) CacheResultsFor(seconds int) *BaseCheck {
+	bc.cacheTTL = time.Duration(seconds) * time.Second
+	return bc
+}
+
+// GetCacheTTL returns how long results of the check are served from cache.
+//
+// Returns:
+//
+//	time.Duration: Cache lifetime, 0 when caching is disabled
+func (bc *BaseCheck) GetCacheTTL() time.Duration {
+	return bc.cacheTTL
+}
+
+// SetContainer sets the service container the check resolves its targets from.
+// The registry calls this for every registered check that has no container yet.
+//
+// Parameters:
+//
+//	container: The service container
+func (bc *BaseCheck) SetContainer(container containerInterfaces.ContainerInterface) {
+	bc.container = container
+}
+
+// GetContainer returns the service container of the check.
+//
+// Returns:
+//
+//	containerInterfaces.ContainerInterface: The container, nil if none set
+func (bc *BaseCheck) GetContainer() containerInterfaces.ContainerInterface {
+	return bc.container
+}
+
+// HasContainer returns whether a service container is set.
+//
+// Returns:
+//
+//	bool: true if the check has a container
+func (bc *BaseCheck) HasContainer() bool {
+	return bc.container != nil
+}
+
+// Resolve resolves a dependency of the check from its service container.
+//
+// Parameters:
+//
+//	token: The container binding to resolve
+//
+// Returns:
+//
+//	interface{}: The resolved service
+//	error: When no container is set or the binding cannot be resolved
+func (bc *BaseCheck) Resolve(token interface{}) (interface{}, error) {
+	if bc.container == nil {
+		return nil, fmt.Errorf("no container set to resolve %v from", token)
+	}
+
+	service, err := bc.container.Make(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %v: %w", token, err)
+	}
+
+	return service, nil
+}
+
 // MarkAsCrashed creates a result marked as crashed.
 // This mirrors Laravel's markAsCrashed method.
 //
@@ -202,7 +301,7 @@ func (bc *BaseCheck) Unless(condition interface{}) *BaseCheck {
 //
 //	interfaces.ResultInterface: A result with crashed status
 func (bc *BaseCheck) MarkAsCrashed() interfaces.ResultInterface {
-	return types.NewResult().SetStatus(enums.StatusCrashed)
+	return NewResult().SetStatus(enums.StatusCrashed)
 }
 
 // OnTerminate is called when the check terminates (placeholder for Laravel compatibility).
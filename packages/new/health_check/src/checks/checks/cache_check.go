@@ -0,0 +1,134 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	cacheInterfaces "govel/types/interfaces/cache"
+)
+
+// cacheStore is the part of a cache store the cache-backed checks rely on.
+type cacheStore interface {
+	// Put stores a value for the given duration (0 stores it forever).
+	Put(key string, value interface{}, ttl time.Duration) error
+
+	// Get retrieves a value, returning nil when the key is missing.
+	Get(key string) (interface{}, error)
+
+	// Forget removes a value.
+	Forget(key string) error
+}
+
+// CacheCheck verifies that the cache can be written to, read from and
+// cleared by round-tripping a random value through it.
+// It closely mirrors the Laravel health CacheCheck pattern.
+type CacheCheck struct {
+	*checks.BaseCheck
+
+	// token is the container binding of the cache store
+	token interface{}
+
+	// key is the cache key used for the round-trip
+	key string
+}
+
+// NewCacheCheck creates a new cache check resolving the store bound to CACHE_TOKEN.
+//
+// Returns:
+//
+//	*CacheCheck: A new cache check instance
+func NewCacheCheck() *CacheCheck {
+	return &CacheCheck{
+		BaseCheck: checks.NewBaseCheck(),
+		token:     cacheInterfaces.CACHE_TOKEN,
+		key:       "health:checks:cache",
+	}
+}
+
+// Store sets the container binding the cache store is resolved from.
+//
+// Parameters:
+//
+//	token: The container binding of the cache store
+//
+// Returns:
+//
+//	*CacheCheck: Self for method chaining
+func (cc *CacheCheck) Store(token interface{}) *CacheCheck {
+	cc.token = token
+	return cc
+}
+
+// Run performs the cache health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (cc *CacheCheck) Run() interfaces.ResultInterface {
+	store, err := resolveCacheStore(cc.BaseCheck, cc.token)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the cache store: %v", err))
+	}
+
+	if err := cc.roundTrip(store); err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Not working").
+			SetNotificationMessage(fmt.Sprintf("Could not set or retrieve an application cache value: %v", err))
+	}
+
+	return checks.NewResult().
+		SetStatus(enums.StatusOK).
+		SetShortSummary("Working")
+}
+
+// roundTrip writes a random value, reads it back and removes it again.
+func (cc *CacheCheck) roundTrip(store cacheStore) error {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return err
+	}
+	expected := hex.EncodeToString(bytes)
+
+	if err := store.Put(cc.key, expected, 10*time.Second); err != nil {
+		return err
+	}
+
+	actual, err := store.Get(cc.key)
+	if err != nil {
+		return err
+	}
+
+	if fmt.Sprint(actual) != expected {
+		return fmt.Errorf("read back %v, expected %s", actual, expected)
+	}
+
+	return store.Forget(cc.key)
+}
+
+// resolveCacheStore resolves a cache store for a check from its container.
+func resolveCacheStore(check *checks.BaseCheck, token interface{}) (cacheStore, error) {
+	service, err := check.Resolve(token)
+	if err != nil {
+		return nil, err
+	}
+
+	store, ok := service.(cacheStore)
+	if !ok {
+		return nil, fmt.Errorf("%v resolved to %T, which is not a cache store", token, service)
+	}
+
+	return store, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*CacheCheck)(nil)
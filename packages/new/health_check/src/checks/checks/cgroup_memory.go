@@ -0,0 +1,229 @@
+package checks
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupV1Unlimited is the smallest memory.limit_in_bytes value treated as
+// "no limit"; cgroup v1 reports unlimited as a huge page-aligned number.
+const cgroupV1Unlimited = 1 << 62
+
+// memoryStats is a snapshot of the memory available to the process.
+type memoryStats struct {
+	// source is where the numbers come from: "cgroup_v2", "cgroup_v1" or "meminfo"
+	source string
+
+	// usageBytes is the memory charged to the cgroup, or used on the host
+	usageBytes uint64
+
+	// workingSetBytes is usage minus inactive file cache, the value the
+	// kernel OOM killer and Kubernetes evictions act upon
+	workingSetBytes uint64
+
+	// limitBytes is the memory limit, or the host total without a limit
+	limitBytes uint64
+}
+
+// readMemoryStats reads the memory limit and usage of the process' cgroup,
+// falling back to the host memory from /proc/meminfo when the cgroup has
+// no limit.
+//
+// Parameters:
+//
+//	cgroupRoot: Mount point of the cgroup hierarchy, usually /sys/fs/cgroup
+//	procRoot: Mount point of procfs, usually /proc
+func readMemoryStats(cgroupRoot, procRoot string) (memoryStats, error) {
+	stats, limited, err := readCgroupMemory(cgroupRoot, procRoot)
+	if err != nil {
+		return memoryStats{}, err
+	}
+	if limited {
+		return stats, nil
+	}
+
+	return readMeminfo(filepath.Join(procRoot, "meminfo"))
+}
+
+// readCgroupMemory reads cgroup v2, then v1 memory accounting. limited is
+// false when no cgroup hierarchy is found or the cgroup has no limit.
+func readCgroupMemory(cgroupRoot, procRoot string) (stats memoryStats, limited bool, err error) {
+	// cgroup v2: a single unified hierarchy with cgroup.controllers at its root
+	if exists(filepath.Join(cgroupRoot, "cgroup.controllers")) {
+		dir := cgroupDir(cgroupRoot, procRoot, "")
+
+		// The root cgroup of a host has no memory.max
+		limit, err := readCgroupValue(filepath.Join(dir, "memory.max"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return memoryStats{}, false, nil
+		}
+		if err != nil || limit == 0 {
+			return memoryStats{}, false, err
+		}
+		usage, err := readCgroupValue(filepath.Join(dir, "memory.current"))
+		if err != nil {
+			return memoryStats{}, false, err
+		}
+
+		inactive, err := readStatValue(filepath.Join(dir, "memory.stat"), "inactive_file")
+		if err != nil {
+			return memoryStats{}, false, err
+		}
+		return newCgroupStats("cgroup_v2", usage, inactive, limit), true, nil
+	}
+
+	// cgroup v1: one hierarchy per controller
+	memoryRoot := filepath.Join(cgroupRoot, "memory")
+	if exists(filepath.Join(memoryRoot, "memory.limit_in_bytes")) {
+		dir := cgroupDir(memoryRoot, procRoot, "memory")
+
+		limit, err := readCgroupValue(filepath.Join(dir, "memory.limit_in_bytes"))
+		if err != nil || limit == 0 || limit >= cgroupV1Unlimited {
+			return memoryStats{}, false, err
+		}
+		usage, err := readCgroupValue(filepath.Join(dir, "memory.usage_in_bytes"))
+		if err != nil {
+			return memoryStats{}, false, err
+		}
+
+		inactive, err := readStatValue(filepath.Join(dir, "memory.stat"), "total_inactive_file")
+		if err != nil {
+			return memoryStats{}, false, err
+		}
+		return newCgroupStats("cgroup_v1", usage, inactive, limit), true, nil
+	}
+
+	return memoryStats{}, false, nil
+}
+
+// newCgroupStats builds cgroup stats, deriving the working set.
+func newCgroupStats(source string, usage, inactive, limit uint64) memoryStats {
+	workingSet := usage
+	if inactive < usage {
+		workingSet = usage - inactive
+	}
+	return memoryStats{source: source, usageBytes: usage, workingSetBytes: workingSet, limitBytes: limit}
+}
+
+// cgroupDir returns the directory of the process' own cgroup below root,
+// as listed in /proc/self/cgroup, or root when it cannot be resolved (as
+// inside containers with a private cgroup namespace).
+func cgroupDir(root, procRoot, controller string) string {
+	file, err := os.Open(filepath.Join(procRoot, "self", "cgroup"))
+	if err != nil {
+		return root
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		matches := controller == "" && parts[0] == "0" && parts[1] == ""
+		for _, name := range strings.Split(parts[1], ",") {
+			matches = matches || (controller != "" && name == controller)
+		}
+		if !matches {
+			continue
+		}
+
+		dir := filepath.Join(root, parts[2])
+		if exists(dir) {
+			return dir
+		}
+		return root
+	}
+	return root
+}
+
+// readCgroupValue reads a single-number cgroup file; "max" means no limit and yields 0.
+func readCgroupValue(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected value %q in %s", value, path)
+	}
+	return parsed, nil
+}
+
+// readStatValue reads a "key value" line of a memory.stat file, 0 when absent.
+func readStatValue(path, key string) (uint64, error) {
+	values, err := readKeyValues(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	return values[key], err
+}
+
+// readMeminfo reads the host memory from /proc/meminfo.
+func readMeminfo(path string) (memoryStats, error) {
+	values, err := readKeyValues(path)
+	if err != nil {
+		return memoryStats{}, err
+	}
+
+	// /proc/meminfo reports kB
+	total := values["MemTotal:"] * 1024
+	available, ok := values["MemAvailable:"]
+	if !ok {
+		available = values["MemFree:"] + values["Buffers:"] + values["Cached:"]
+	}
+	available *= 1024
+
+	if total == 0 {
+		return memoryStats{}, fmt.Errorf("no MemTotal in %s", path)
+	}
+
+	used := uint64(0)
+	if available < total {
+		used = total - available
+	}
+	return memoryStats{source: "meminfo", usageBytes: used, workingSetBytes: used, limitBytes: total}, nil
+}
+
+// readKeyValues parses lines of the form "key value [unit]".
+func readKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = value
+		}
+	}
+	return values, scanner.Err()
+}
+
+// exists reports whether path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,221 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// databaseConnection is the part of *sql.DB the database check relies on.
+type databaseConnection interface {
+	PingContext(ctx context.Context) error
+	Stats() sql.DBStats
+}
+
+// databaseProvider is implemented by services wrapping a *sql.DB, such as
+// a database manager bound in the container.
+type databaseProvider interface {
+	DB() *sql.DB
+}
+
+// DatabaseCheck verifies that the database can be reached and that the
+// connection pool is not exhausted. The connection is resolved from the
+// container unless one is given with UseDB.
+type DatabaseCheck struct {
+	*checks.BaseCheck
+
+	// token is the container binding of the database connection
+	token interface{}
+
+	// db is an explicitly given connection, preferred over the container
+	db databaseConnection
+
+	// timeout is the maximum time to wait for the ping
+	timeout time.Duration
+
+	// warningThreshold is the open connection count above which the check warns (0 disables)
+	warningThreshold int
+
+	// errorThreshold is the open connection count above which the check fails (0 disables)
+	errorThreshold int
+}
+
+// NewDatabaseCheck creates a new database check resolving the connection
+// bound to DATABASE_TOKEN.
+//
+// Returns:
+//
+//	*DatabaseCheck: A new database check instance
+func NewDatabaseCheck() *DatabaseCheck {
+	return &DatabaseCheck{
+		BaseCheck: checks.NewBaseCheck(),
+		token:     databaseInterfaces.DATABASE_TOKEN,
+		timeout:   5 * time.Second,
+	}
+}
+
+// Connection sets the container binding the connection is resolved from.
+//
+// Parameters:
+//
+//	token: The container binding of the connection
+//
+// Returns:
+//
+//	*DatabaseCheck: Self for method chaining
+func (dc *DatabaseCheck) Connection(token interface{}) *DatabaseCheck {
+	dc.token = token
+	return dc
+}
+
+// UseDB sets the connection to check directly, bypassing the container.
+//
+// Parameters:
+//
+//	db: The database connection
+//
+// Returns:
+//
+//	*DatabaseCheck: Self for method chaining
+func (dc *DatabaseCheck) UseDB(db *sql.DB) *DatabaseCheck {
+	if db == nil {
+		dc.db = nil
+		return dc
+	}
+
+	dc.db = db
+	return dc
+}
+
+// Timeout sets the maximum time to wait for the database to answer.
+//
+// Parameters:
+//
+//	seconds: Timeout in seconds
+//
+// Returns:
+//
+//	*DatabaseCheck: Self for method chaining
+func (dc *DatabaseCheck) Timeout(seconds int) *DatabaseCheck {
+	dc.timeout = time.Duration(seconds) * time.Second
+	return dc
+}
+
+// WarnWhenConnectionCountIsAbove sets the open connection count above which
+// the check reports a warning.
+//
+// Parameters:
+//
+//	connections: Connection count threshold (0 disables the warning)
+//
+// Returns:
+//
+//	*DatabaseCheck: Self for method chaining
+func (dc *DatabaseCheck) WarnWhenConnectionCountIsAbove(connections int) *DatabaseCheck {
+	dc.warningThreshold = connections
+	return dc
+}
+
+// FailWhenConnectionCountIsAbove sets the open connection count above which
+// the check fails.
+//
+// Parameters:
+//
+//	connections: Connection count threshold (0 disables the failure)
+//
+// Returns:
+//
+//	*DatabaseCheck: Self for method chaining
+func (dc *DatabaseCheck) FailWhenConnectionCountIsAbove(connections int) *DatabaseCheck {
+	dc.errorThreshold = connections
+	return dc
+}
+
+// Run performs the database health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (dc *DatabaseCheck) Run() interfaces.ResultInterface {
+	db, err := dc.connection()
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the database connection: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dc.timeout)
+	defer cancel()
+
+	started := time.Now()
+	if err := db.PingContext(ctx); err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Unreachable").
+			SetNotificationMessage(fmt.Sprintf("Could not connect to the database: %v", err))
+	}
+	elapsed := time.Since(started)
+
+	stats := db.Stats()
+	connections := stats.OpenConnections
+
+	result := checks.NewResult().
+		SetMeta(map[string]interface{}{
+			"ping_ms":              elapsed.Milliseconds(),
+			"connection_count":     connections,
+			"in_use":               stats.InUse,
+			"idle":                 stats.Idle,
+			"max_open_connections": stats.MaxOpenConnections,
+			"wait_count":           stats.WaitCount,
+		}).
+		SetShortSummary(fmt.Sprintf("%d connections", connections))
+
+	if dc.errorThreshold > 0 && connections > dc.errorThreshold {
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetNotificationMessage(fmt.Sprintf("There are too many database connections (%d connections)", connections))
+	}
+
+	if dc.warningThreshold > 0 && connections > dc.warningThreshold {
+		return result.
+			SetStatus(enums.StatusWarning).
+			SetNotificationMessage(fmt.Sprintf("The database has %d open connections", connections))
+	}
+
+	return result.SetStatus(enums.StatusOK)
+}
+
+// connection returns the explicitly given connection or resolves it from the container.
+func (dc *DatabaseCheck) connection() (databaseConnection, error) {
+	if dc.db != nil {
+		return dc.db, nil
+	}
+
+	service, err := dc.Resolve(dc.token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch db := service.(type) {
+	case databaseConnection:
+		return db, nil
+	case databaseProvider:
+		if conn := db.DB(); conn != nil {
+			return conn, nil
+		}
+		return nil, fmt.Errorf("%v has no open connection", dc.token)
+	default:
+		return nil, fmt.Errorf("%v resolved to %T, which is not a database connection", dc.token, service)
+	}
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*DatabaseCheck)(nil)
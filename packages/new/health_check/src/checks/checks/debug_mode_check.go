@@ -0,0 +1,90 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"fmt"
+	"strconv"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	configInterfaces "govel/types/interfaces/config"
+)
+
+// DebugModeCheck verifies that the application debug mode (app.debug) has
+// the expected value, which in production should be off.
+// It closely mirrors the Laravel health DebugModeCheck pattern.
+type DebugModeCheck struct {
+	*checks.BaseCheck
+
+	// expected is the expected value of app.debug
+	expected bool
+}
+
+// NewDebugModeCheck creates a new debug mode check expecting debug mode to be off.
+//
+// Returns:
+//
+//	*DebugModeCheck: A new debug mode check instance
+func NewDebugModeCheck() *DebugModeCheck {
+	return &DebugModeCheck{
+		BaseCheck: checks.NewBaseCheck(),
+		expected:  false,
+	}
+}
+
+// ExpectedToBe sets the expected debug mode.
+//
+// Parameters:
+//
+//	debug: The expected value of app.debug
+//
+// Returns:
+//
+//	*DebugModeCheck: Self for method chaining
+func (dc *DebugModeCheck) ExpectedToBe(debug bool) *DebugModeCheck {
+	dc.expected = debug
+	return dc
+}
+
+// Run performs the debug mode health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (dc *DebugModeCheck) Run() interfaces.ResultInterface {
+	service, err := dc.Resolve(configInterfaces.CONFIG_TOKEN)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the configuration: %v", err))
+	}
+
+	config, ok := service.(configInterfaces.ConfigInterface)
+	if !ok {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("The configuration resolved to %T", service))
+	}
+
+	actual := config.GetBool("app.debug", false)
+	result := checks.NewResult().
+		SetMeta(map[string]interface{}{
+			"actual":   actual,
+			"expected": dc.expected,
+		}).
+		SetShortSummary(strconv.FormatBool(actual))
+
+	if actual == dc.expected {
+		return result.SetStatus(enums.StatusOK)
+	}
+
+	return result.
+		SetStatus(enums.StatusFailed).
+		SetNotificationMessage(fmt.Sprintf("The debug mode was expected to be `%t`, but actually was `%t`", dc.expected, actual))
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*DebugModeCheck)(nil)
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package checks
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// readDiskStats is not supported on this platform; the check reports a crash.
+func readDiskStats(path string) (diskStats, error) {
+	return diskStats{}, fmt.Errorf("disk usage is not supported on %s", runtime.GOOS)
+}
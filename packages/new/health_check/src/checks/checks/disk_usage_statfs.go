@@ -0,0 +1,25 @@
+//go:build linux || darwin
+
+package checks
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// readDiskStats reads the usage of the filesystem holding path with statfs(2).
+func readDiskStats(path string) (diskStats, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return diskStats{}, fmt.Errorf("statfs %s: %w", path, err)
+	}
+
+	blockSize := uint64(fs.Bsize)
+	return diskStats{
+		totalBytes:     uint64(fs.Blocks) * blockSize,
+		freeBytes:      uint64(fs.Bfree) * blockSize,
+		availableBytes: uint64(fs.Bavail) * blockSize,
+		totalInodes:    uint64(fs.Files),
+		freeInodes:     uint64(fs.Ffree),
+	}, nil
+}
@@ -10,8 +10,17 @@ import (
 	"govel/healthcheck/interfaces"
 )
 
-// MemoryUsageCheck monitors memory usage and alerts on configurable thresholds.
-// It closely mirrors the Laravel health pattern for resource monitoring.
+// MemoryUsageCheck monitors the memory pressure of the process' container.
+//
+// On Linux it reads the memory limit and usage of the process' cgroup (v2
+// or v1), comparing the working set (usage minus inactive file cache) to
+// the limit, as the OOM killer and Kubernetes evictions do. Without a
+// cgroup limit the host memory from /proc/meminfo is used. When memory
+// cannot be measured the check reports a crashed status.
+//
+// Results carry the raw numbers as meta: memory_usage_bytes,
+// memory_working_set_bytes, memory_limit_bytes, memory_usage_percentage,
+// source and the Go heap statistics heap_alloc_bytes and heap_sys_bytes.
 type MemoryUsageCheck struct {
 	*checks.BaseCheck
 
@@ -20,6 +29,12 @@ type MemoryUsageCheck struct {
 
 	// errorThreshold is the memory usage percentage at which to fail the check (0-100)
 	errorThreshold int
+
+	// cgroupRoot is the mount point of the cgroup hierarchy
+	cgroupRoot string
+
+	// procRoot is the mount point of procfs
+	procRoot string
 }
 
 // NewMemoryUsageCheck creates a new memory usage check with default settings.
@@ -32,6 +47,8 @@ func NewMemoryUsageCheck() *MemoryUsageCheck {
 		BaseCheck:        checks.NewBaseCheck(),
 		warningThreshold: 80, // 80% warning threshold
 		errorThreshold:   90, // 90% error threshold
+		cgroupRoot:       "/sys/fs/cgroup",
+		procRoot:         "/proc",
 	}
 }
 
@@ -63,17 +80,66 @@ func (mc *MemoryUsageCheck) FailWhenMemoryUsageIsAbove(percentage int) *MemoryUs
 	return mc
 }
 
+// CgroupRoot sets the mount point of the cgroup hierarchy.
+//
+// Parameters:
+//
+//	path: Mount point, /sys/fs/cgroup by default
+//
+// Returns:
+//
+//	*MemoryUsageCheck: Self for method chaining
+func (mc *MemoryUsageCheck) CgroupRoot(path string) *MemoryUsageCheck {
+	mc.cgroupRoot = path
+	return mc
+}
+
+// ProcRoot sets the mount point of procfs.
+//
+// Parameters:
+//
+//	path: Mount point, /proc by default
+//
+// Returns:
+//
+//	*MemoryUsageCheck: Self for method chaining
+func (mc *MemoryUsageCheck) ProcRoot(path string) *MemoryUsageCheck {
+	mc.procRoot = path
+	return mc
+}
+
 // Run performs the memory usage health check.
 //
 // Returns:
 //
 //	interfaces.ResultInterface: The health check result
 func (mc *MemoryUsageCheck) Run() interfaces.ResultInterface {
-	memoryUsagePercentage := mc.getMemoryUsagePercentage()
+	var heap runtime.MemStats
+	runtime.ReadMemStats(&heap)
+
+	meta := map[string]interface{}{
+		"heap_alloc_bytes": heap.HeapAlloc,
+		"heap_sys_bytes":   heap.HeapSys,
+	}
+	result := checks.NewResult().SetMeta(meta)
+
+	stats, err := readMemoryStats(mc.cgroupRoot, mc.procRoot)
+	if err != nil {
+		return result.
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not measure memory usage: %v", err))
+	}
+
+	memoryUsagePercentage := percentage(stats.workingSetBytes, stats.limitBytes)
+
+	meta["source"] = stats.source
+	meta["memory_usage_bytes"] = stats.usageBytes
+	meta["memory_working_set_bytes"] = stats.workingSetBytes
+	meta["memory_limit_bytes"] = stats.limitBytes
+	meta["memory_usage_percentage"] = memoryUsagePercentage
 
-	result := checks.NewResult().
-		SetMeta(map[string]interface{}{"memory_usage_percentage": memoryUsagePercentage}).
-		SetShortSummary(fmt.Sprintf("%d%%", memoryUsagePercentage))
+	result.SetShortSummary(fmt.Sprintf("%d%%", memoryUsagePercentage))
 
 	if memoryUsagePercentage > mc.errorThreshold {
 		return result.
@@ -90,30 +156,12 @@ func (mc *MemoryUsageCheck) Run() interfaces.ResultInterface {
 	return result.SetStatus(enums.StatusOK)
 }
 
-// getMemoryUsagePercentage calculates the current memory usage percentage.
-// This uses Go's runtime.MemStats to get heap usage information.
-func (mc *MemoryUsageCheck) getMemoryUsagePercentage() int {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-
-	// Calculate usage percentage based on heap allocation vs system memory
-	// Note: This is a simplified calculation. In production, you might want
-	// to use more sophisticated methods to get system memory limits.
-
-	// For now, we'll calculate based on heap in use vs heap system allocation
-	if m.HeapSys == 0 {
+// percentage returns used as a whole percentage of total, rounded up like df.
+func percentage(used, total uint64) int {
+	if total == 0 {
 		return 0
 	}
-
-	// Calculate percentage: (HeapInuse / HeapSys) * 100
-	usagePercentage := int((float64(m.HeapInuse) / float64(m.HeapSys)) * 100)
-
-	// Ensure we don't exceed 100%
-	if usagePercentage > 100 {
-		usagePercentage = 100
-	}
-
-	return usagePercentage
+	return int((used*100 + total - 1) / total)
 }
 
 // Compile-time interface compliance check
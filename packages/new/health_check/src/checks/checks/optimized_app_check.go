@@ -0,0 +1,164 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+)
+
+// Build properties inspected by OptimizedAppCheck.
+const (
+	// OptimizedAppCompilerOptimizations fails when the binary was built with
+	// optimizations or inlining disabled (-gcflags=-N or -l), as debuggers do.
+	OptimizedAppCompilerOptimizations = "optimizations"
+
+	// OptimizedAppRaceDetector fails when the binary was built with -race.
+	OptimizedAppRaceDetector = "race"
+
+	// OptimizedAppCleanBuild fails when the binary was built from a working
+	// tree with uncommitted changes.
+	OptimizedAppCleanBuild = "clean_build"
+)
+
+// OptimizedAppCheck verifies that the running binary is a release build.
+// Where Laravel checks for cached configuration, routes and events, a Go
+// application is "optimized" when it was compiled with optimizations on,
+// without the race detector and from committed sources. The build settings
+// are read from the build information embedded by the Go toolchain.
+type OptimizedAppCheck struct {
+	*checks.BaseCheck
+
+	// checks are the build properties to verify
+	checks []string
+
+	// buildInfo overrides the build information of the running binary
+	buildInfo *debug.BuildInfo
+}
+
+// NewOptimizedAppCheck creates a new optimized app check verifying all build properties.
+//
+// Returns:
+//
+//	*OptimizedAppCheck: A new optimized app check instance
+func NewOptimizedAppCheck() *OptimizedAppCheck {
+	return &OptimizedAppCheck{
+		BaseCheck: checks.NewBaseCheck(),
+		checks: []string{
+			OptimizedAppCompilerOptimizations,
+			OptimizedAppRaceDetector,
+			OptimizedAppCleanBuild,
+		},
+	}
+}
+
+// Checks limits the check to the given build properties.
+//
+// Parameters:
+//
+//	checks: Build properties such as OptimizedAppRaceDetector
+//
+// Returns:
+//
+//	*OptimizedAppCheck: Self for method chaining
+func (oc *OptimizedAppCheck) Checks(checks ...string) *OptimizedAppCheck {
+	oc.checks = checks
+	return oc
+}
+
+// BuildInfo sets the build information to inspect instead of the one
+// embedded in the running binary.
+//
+// Parameters:
+//
+//	info: The build information
+//
+// Returns:
+//
+//	*OptimizedAppCheck: Self for method chaining
+func (oc *OptimizedAppCheck) BuildInfo(info *debug.BuildInfo) *OptimizedAppCheck {
+	oc.buildInfo = info
+	return oc
+}
+
+// Run performs the optimized app health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (oc *OptimizedAppCheck) Run() interfaces.ResultInterface {
+	info := oc.buildInfo
+	if info == nil {
+		var ok bool
+		if info, ok = debug.ReadBuildInfo(); !ok {
+			return checks.NewResult().
+				SetStatus(enums.StatusCrashed).
+				SetShortSummary("Unavailable").
+				SetNotificationMessage("The binary does not contain build information")
+		}
+	}
+
+	settings := make(map[string]string, len(info.Settings))
+	for _, setting := range info.Settings {
+		settings[setting.Key] = setting.Value
+	}
+
+	var problems []string
+	for _, check := range oc.checks {
+		switch check {
+		case OptimizedAppCompilerOptimizations:
+			if optimizationsDisabled(settings["-gcflags"]) {
+				problems = append(problems, fmt.Sprintf("compiler optimizations are disabled (-gcflags=%s)", settings["-gcflags"]))
+			}
+		case OptimizedAppRaceDetector:
+			if settings["-race"] == "true" {
+				problems = append(problems, "the race detector is enabled")
+			}
+		case OptimizedAppCleanBuild:
+			if settings["vcs.modified"] == "true" {
+				problems = append(problems, "the binary was built from uncommitted changes")
+			}
+		}
+	}
+
+	result := checks.NewResult().
+		SetMeta(map[string]interface{}{
+			"go_version":   info.GoVersion,
+			"gcflags":      settings["-gcflags"],
+			"race":         settings["-race"] == "true",
+			"vcs_revision": settings["vcs.revision"],
+			"vcs_modified": settings["vcs.modified"] == "true",
+		})
+
+	if len(problems) > 0 {
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Not optimized").
+			SetNotificationMessage(fmt.Sprintf("The application is not optimized: %s", strings.Join(problems, ", ")))
+	}
+
+	return result.
+		SetStatus(enums.StatusOK).
+		SetShortSummary("Optimized")
+}
+
+// optimizationsDisabled reports whether gcflags turn off optimizations or inlining.
+func optimizationsDisabled(gcflags string) bool {
+	for _, flag := range strings.Fields(gcflags) {
+		// Flags may be scoped to packages, as in all=-N
+		if i := strings.LastIndex(flag, "="); i >= 0 {
+			flag = flag[i+1:]
+		}
+		if flag == "-N" || flag == "-l" {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile-time interface compliance check
+var _ interfaces.CheckInterface = (*OptimizedAppCheck)(nil)
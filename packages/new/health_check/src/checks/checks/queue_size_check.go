@@ -0,0 +1,204 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	queueInterfaces "govel/types/interfaces/queue"
+)
+
+// queueSizer is the part of a queue connection the queue size check relies on.
+type queueSizer interface {
+	// Size returns the number of pending jobs on a queue.
+	Size(queue string) (int, error)
+}
+
+// QueueSizeCheck fails when more jobs are waiting on a queue than allowed,
+// which usually means the workers are down or cannot keep up.
+type QueueSizeCheck struct {
+	*checks.BaseCheck
+
+	// token is the container binding of the queue connection
+	token interface{}
+
+	// queues are the queues to inspect
+	queues []string
+
+	// maxSize is the default maximum number of pending jobs per queue
+	maxSize int
+
+	// queueMaxSizes overrides maxSize for individual queues
+	queueMaxSizes map[string]int
+}
+
+// NewQueueSizeCheck creates a new queue size check inspecting the "default"
+// queue of the connection bound to QUEUE_TOKEN.
+//
+// Returns:
+//
+//	*QueueSizeCheck: A new queue size check instance
+func NewQueueSizeCheck() *QueueSizeCheck {
+	return &QueueSizeCheck{
+		BaseCheck:     checks.NewBaseCheck(),
+		token:         queueInterfaces.QUEUE_TOKEN,
+		queues:        []string{"default"},
+		maxSize:       100,
+		queueMaxSizes: make(map[string]int),
+	}
+}
+
+// Connection sets the container binding the queue connection is resolved from.
+//
+// Parameters:
+//
+//	token: The container binding of the queue connection
+//
+// Returns:
+//
+//	*QueueSizeCheck: Self for method chaining
+func (qc *QueueSizeCheck) Connection(token interface{}) *QueueSizeCheck {
+	qc.token = token
+	return qc
+}
+
+// OnQueues sets the queues to inspect.
+//
+// Parameters:
+//
+//	queues: Names of the queues
+//
+// Returns:
+//
+//	*QueueSizeCheck: Self for method chaining
+func (qc *QueueSizeCheck) OnQueues(queues ...string) *QueueSizeCheck {
+	qc.queues = queues
+	return qc
+}
+
+// FailWhenSizeIsAbove sets the maximum number of pending jobs on each queue.
+//
+// Parameters:
+//
+//	size: Maximum number of pending jobs
+//
+// Returns:
+//
+//	*QueueSizeCheck: Self for method chaining
+func (qc *QueueSizeCheck) FailWhenSizeIsAbove(size int) *QueueSizeCheck {
+	qc.maxSize = size
+	return qc
+}
+
+// FailWhenQueueSizeIsAbove sets the maximum number of pending jobs for a
+// single queue, overriding FailWhenSizeIsAbove. The queue is inspected even
+// when it was not passed to OnQueues.
+//
+// Parameters:
+//
+//	queue: Name of the queue
+//	size: Maximum number of pending jobs
+//
+// Returns:
+//
+//	*QueueSizeCheck: Self for method chaining
+func (qc *QueueSizeCheck) FailWhenQueueSizeIsAbove(queue string, size int) *QueueSizeCheck {
+	qc.queueMaxSizes[queue] = size
+	return qc
+}
+
+// Run performs the queue size health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (qc *QueueSizeCheck) Run() interfaces.ResultInterface {
+	sizer, err := qc.connection()
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the queue connection: %v", err))
+	}
+
+	sizes := make(map[string]interface{})
+	var overloaded []string
+
+	for _, queue := range qc.queueNames() {
+		size, err := sizer.Size(queue)
+		if err != nil {
+			return checks.NewResult().
+				SetMeta(sizes).
+				SetStatus(enums.StatusCrashed).
+				SetShortSummary("Unavailable").
+				SetNotificationMessage(fmt.Sprintf("Could not determine the size of queue `%s`: %v", queue, err))
+		}
+
+		sizes[queue] = size
+		if size > qc.maxSizeFor(queue) {
+			overloaded = append(overloaded, fmt.Sprintf("%s (%d jobs)", queue, size))
+		}
+	}
+
+	result := checks.NewResult().SetMeta(sizes)
+
+	if len(overloaded) > 0 {
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetShortSummary(fmt.Sprintf("%d queues too large", len(overloaded))).
+			SetNotificationMessage(fmt.Sprintf("Too many pending jobs on: %s", strings.Join(overloaded, ", ")))
+	}
+
+	return result.
+		SetStatus(enums.StatusOK).
+		SetShortSummary("Ok")
+}
+
+// queueNames returns the inspected queues, including those with their own threshold.
+func (qc *QueueSizeCheck) queueNames() []string {
+	names := append([]string(nil), qc.queues...)
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+
+	var extra []string
+	for name := range qc.queueMaxSizes {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+
+	return append(names, extra...)
+}
+
+// maxSizeFor returns the maximum number of pending jobs for a queue.
+func (qc *QueueSizeCheck) maxSizeFor(queue string) int {
+	if size, ok := qc.queueMaxSizes[queue]; ok {
+		return size
+	}
+	return qc.maxSize
+}
+
+// connection resolves the queue connection from the container.
+func (qc *QueueSizeCheck) connection() (queueSizer, error) {
+	service, err := qc.Resolve(qc.token)
+	if err != nil {
+		return nil, err
+	}
+
+	sizer, ok := service.(queueSizer)
+	if !ok {
+		return nil, fmt.Errorf("%v resolved to %T, which cannot report queue sizes", qc.token, service)
+	}
+
+	return sizer, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*QueueSizeCheck)(nil)
@@ -0,0 +1,121 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// RedisCheck verifies that Redis answers a PING.
+// It closely mirrors the Laravel health RedisCheck pattern.
+type RedisCheck struct {
+	*checks.BaseCheck
+
+	// token is the container binding of the Redis connection
+	token interface{}
+
+	// timeout is the maximum time to wait for the PING
+	timeout time.Duration
+}
+
+// NewRedisCheck creates a new Redis check resolving the connection bound to REDIS_TOKEN.
+//
+// Returns:
+//
+//	*RedisCheck: A new Redis check instance
+func NewRedisCheck() *RedisCheck {
+	return &RedisCheck{
+		BaseCheck: checks.NewBaseCheck(),
+		token:     redisInterfaces.REDIS_TOKEN,
+		timeout:   2 * time.Second,
+	}
+}
+
+// Connection sets the container binding the Redis connection is resolved from.
+//
+// Parameters:
+//
+//	token: The container binding of the Redis connection
+//
+// Returns:
+//
+//	*RedisCheck: Self for method chaining
+func (rc *RedisCheck) Connection(token interface{}) *RedisCheck {
+	rc.token = token
+	return rc
+}
+
+// Timeout sets the maximum time to wait for Redis to answer.
+//
+// Parameters:
+//
+//	seconds: Timeout in seconds
+//
+// Returns:
+//
+//	*RedisCheck: Self for method chaining
+func (rc *RedisCheck) Timeout(seconds int) *RedisCheck {
+	rc.timeout = time.Duration(seconds) * time.Second
+	return rc
+}
+
+// Run performs the Redis health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (rc *RedisCheck) Run() interfaces.ResultInterface {
+	service, err := rc.Resolve(rc.token)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the Redis connection: %v", err))
+	}
+
+	redis, ok := service.(redisInterfaces.RedisInterface)
+	if !ok {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("%v resolved to %T, which is not a Redis connection", rc.token, service))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	defer cancel()
+
+	started := time.Now()
+	response, err := redis.Command(ctx, "PING")
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Unreachable").
+			SetNotificationMessage(fmt.Sprintf("An exception occurred when connecting to Redis: %v", err))
+	}
+
+	result := checks.NewResult().
+		SetMeta(map[string]interface{}{
+			"ping_ms": time.Since(started).Milliseconds(),
+		})
+
+	if !strings.EqualFold(fmt.Sprint(response), "PONG") {
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Unexpected response").
+			SetNotificationMessage(fmt.Sprintf("Redis returned an unexpected response to PING: %v", response))
+	}
+
+	return result.
+		SetStatus(enums.StatusOK).
+		SetShortSummary("Connected")
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*RedisCheck)(nil)
@@ -0,0 +1,196 @@
+// Package checks provides built-in health check implementations.
+package checks
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	cacheInterfaces "govel/types/interfaces/cache"
+)
+
+// ScheduleHeartbeatCheck verifies that the scheduler is running. The
+// scheduler records a heartbeat in the cache on every tick by calling
+// RecordHeartbeat, and the check fails when the latest heartbeat is older
+// than the allowed age.
+// It closely mirrors the Laravel health ScheduleCheck pattern.
+type ScheduleHeartbeatCheck struct {
+	*checks.BaseCheck
+
+	// token is the container binding of the cache store holding the heartbeat
+	token interface{}
+
+	// cacheKey is the cache key of the heartbeat
+	cacheKey string
+
+	// heartbeatMaxAge is the maximum allowed age of the latest heartbeat
+	heartbeatMaxAge time.Duration
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewScheduleHeartbeatCheck creates a new schedule heartbeat check storing
+// its heartbeat in the cache bound to CACHE_TOKEN.
+//
+// Returns:
+//
+//	*ScheduleHeartbeatCheck: A new schedule heartbeat check instance
+func NewScheduleHeartbeatCheck() *ScheduleHeartbeatCheck {
+	return &ScheduleHeartbeatCheck{
+		BaseCheck:       checks.NewBaseCheck(),
+		token:           cacheInterfaces.CACHE_TOKEN,
+		cacheKey:        "health:checks:schedule:latestHeartbeatAt",
+		heartbeatMaxAge: time.Minute,
+		now:             time.Now,
+	}
+}
+
+// Store sets the container binding of the cache store holding the heartbeat.
+//
+// Parameters:
+//
+//	token: The container binding of the cache store
+//
+// Returns:
+//
+//	*ScheduleHeartbeatCheck: Self for method chaining
+func (sc *ScheduleHeartbeatCheck) Store(token interface{}) *ScheduleHeartbeatCheck {
+	sc.token = token
+	return sc
+}
+
+// CacheKey sets the cache key of the heartbeat.
+//
+// Parameters:
+//
+//	key: The cache key
+//
+// Returns:
+//
+//	*ScheduleHeartbeatCheck: Self for method chaining
+func (sc *ScheduleHeartbeatCheck) CacheKey(key string) *ScheduleHeartbeatCheck {
+	sc.cacheKey = key
+	return sc
+}
+
+// HeartbeatMaxAgeInMinutes sets how old the latest heartbeat may be before the check fails.
+//
+// Parameters:
+//
+//	minutes: Maximum heartbeat age in minutes
+//
+// Returns:
+//
+//	*ScheduleHeartbeatCheck: Self for method chaining
+func (sc *ScheduleHeartbeatCheck) HeartbeatMaxAgeInMinutes(minutes int) *ScheduleHeartbeatCheck {
+	sc.heartbeatMaxAge = time.Duration(minutes) * time.Minute
+	return sc
+}
+
+// RecordHeartbeat stores the current time as the latest heartbeat. The
+// scheduler should call this on every tick.
+//
+// Returns:
+//
+//	error: Any error resolving the cache store or writing the heartbeat
+//
+// Example:
+//
+//	heartbeat := checks.NewScheduleHeartbeatCheck()
+//	scheduler.EveryMinute(func() { _ = heartbeat.RecordHeartbeat() })
+func (sc *ScheduleHeartbeatCheck) RecordHeartbeat() error {
+	store, err := resolveCacheStore(sc.BaseCheck, sc.token)
+	if err != nil {
+		return err
+	}
+
+	return store.Put(sc.cacheKey, sc.now().Unix(), 0)
+}
+
+// Run performs the schedule heartbeat health check.
+//
+// Returns:
+//
+//	interfaces.ResultInterface: The health check result
+func (sc *ScheduleHeartbeatCheck) Run() interfaces.ResultInterface {
+	store, err := resolveCacheStore(sc.BaseCheck, sc.token)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not resolve the cache store: %v", err))
+	}
+
+	value, err := store.Get(sc.cacheKey)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not read the schedule heartbeat: %v", err))
+	}
+
+	if value == nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Not running").
+			SetNotificationMessage("The schedule did not run yet.")
+	}
+
+	heartbeatAt, err := parseHeartbeat(value)
+	if err != nil {
+		return checks.NewResult().
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not read the schedule heartbeat: %v", err))
+	}
+
+	age := sc.now().Sub(heartbeatAt)
+	result := checks.NewResult().
+		SetMeta(map[string]interface{}{
+			"latest_heartbeat_at":     heartbeatAt.UTC().Format(time.RFC3339),
+			"minutes_since_heartbeat": int(age.Minutes()),
+		})
+
+	if age > sc.heartbeatMaxAge {
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetShortSummary("Not running").
+			SetNotificationMessage(fmt.Sprintf("The last run of the schedule was more than %d minutes ago.", int(sc.heartbeatMaxAge.Minutes())))
+	}
+
+	return result.
+		SetStatus(enums.StatusOK).
+		SetShortSummary("Running")
+}
+
+// parseHeartbeat converts a cached heartbeat back into a time. Stores may
+// hand the unix timestamp back as a number or as a string.
+func parseHeartbeat(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case int64:
+		return time.Unix(v, 0), nil
+	case int:
+		return time.Unix(int64(v), 0), nil
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case string:
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid heartbeat %q", v)
+		}
+		return time.Unix(seconds, 0), nil
+	case []byte:
+		return parseHeartbeat(string(v))
+	default:
+		return time.Time{}, fmt.Errorf("invalid heartbeat of type %T", value)
+	}
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ContainerAwareCheckInterface = (*ScheduleHeartbeatCheck)(nil)
@@ -3,17 +3,22 @@ package checks
 
 import (
 	"fmt"
-	"os/exec"
-	"regexp"
-	"strconv"
 
 	"govel/healthcheck/checks"
 	"govel/healthcheck/enums"
 	"govel/healthcheck/interfaces"
 )
 
-// UsedDiskSpaceCheck monitors disk space usage and alerts on configurable thresholds.
-// It closely mirrors the Laravel health UsedDiskSpaceCheck pattern.
+// UsedDiskSpaceCheck monitors disk space and inode usage and alerts on
+// configurable thresholds. It closely mirrors the Laravel health
+// UsedDiskSpaceCheck pattern, reading usage with statfs(2) instead of
+// shelling out to df. Inode exhaustion is reported like a full disk, since
+// it equally prevents creating files. When usage cannot be measured the
+// check reports a crashed status.
+//
+// Results carry the raw numbers as meta: path, disk_total_bytes,
+// disk_used_bytes, disk_available_bytes, disk_space_used_percentage,
+// inodes_total, inodes_used, inodes_free and inodes_used_percentage.
 type UsedDiskSpaceCheck struct {
 	*checks.BaseCheck
 
@@ -23,10 +28,34 @@ type UsedDiskSpaceCheck struct {
 	// errorThreshold is the percentage at which to fail the check (0-100)
 	errorThreshold int
 
+	// inodeWarningThreshold is the inode usage percentage at which to issue warnings (0-100)
+	inodeWarningThreshold int
+
+	// inodeErrorThreshold is the inode usage percentage at which to fail the check (0-100)
+	inodeErrorThreshold int
+
 	// filesystemName is the filesystem/path to check (nil means current directory)
 	filesystemName *string
 }
 
+// diskStats is a snapshot of a filesystem's capacity.
+type diskStats struct {
+	// totalBytes is the size of the filesystem
+	totalBytes uint64
+
+	// freeBytes is the free space, including space reserved for root
+	freeBytes uint64
+
+	// availableBytes is the free space available to unprivileged users
+	availableBytes uint64
+
+	// totalInodes is the number of inodes, 0 when not reported
+	totalInodes uint64
+
+	// freeInodes is the number of free inodes
+	freeInodes uint64
+}
+
 // NewUsedDiskSpaceCheck creates a new disk space check instance with default settings.
 //
 // Returns:
@@ -34,10 +63,12 @@ type UsedDiskSpaceCheck struct {
 //	*UsedDiskSpaceCheck: A new disk space check instance
 func NewUsedDiskSpaceCheck() *UsedDiskSpaceCheck {
 	return &UsedDiskSpaceCheck{
-		BaseCheck:        checks.NewBaseCheck(),
-		warningThreshold: 70,
-		errorThreshold:   90,
-		filesystemName:   nil,
+		BaseCheck:             checks.NewBaseCheck(),
+		warningThreshold:      70,
+		errorThreshold:        90,
+		inodeWarningThreshold: 80,
+		inodeErrorThreshold:   95,
+		filesystemName:        nil,
 	}
 }
 
@@ -83,67 +114,103 @@ func (dsc *UsedDiskSpaceCheck) FailWhenUsedSpaceIsAbovePercentage(percentage int
 	return dsc
 }
 
+// WarnWhenUsedInodesIsAbovePercentage sets the inode warning threshold percentage.
+//
+// Parameters:
+//
+//	percentage: The percentage (0-100) at which to issue warnings
+//
+// Returns:
+//
+//	*UsedDiskSpaceCheck: Self for method chaining
+func (dsc *UsedDiskSpaceCheck) WarnWhenUsedInodesIsAbovePercentage(percentage int) *UsedDiskSpaceCheck {
+	dsc.inodeWarningThreshold = percentage
+	return dsc
+}
+
+// FailWhenUsedInodesIsAbovePercentage sets the inode failure threshold percentage.
+//
+// Parameters:
+//
+//	percentage: The percentage (0-100) at which to fail the check
+//
+// Returns:
+//
+//	*UsedDiskSpaceCheck: Self for method chaining
+func (dsc *UsedDiskSpaceCheck) FailWhenUsedInodesIsAbovePercentage(percentage int) *UsedDiskSpaceCheck {
+	dsc.inodeErrorThreshold = percentage
+	return dsc
+}
+
 // Run performs the disk space health check.
 //
 // Returns:
 //
 //	interfaces.ResultInterface: The health check result
 func (dsc *UsedDiskSpaceCheck) Run() interfaces.ResultInterface {
-	diskSpaceUsedPercentage := dsc.getDiskUsagePercentage()
+	path := "."
+	if dsc.filesystemName != nil {
+		path = *dsc.filesystemName
+	}
 
-	result := checks.NewResult().
-		SetMeta(map[string]interface{}{"disk_space_used_percentage": diskSpaceUsedPercentage}).
-		SetShortSummary(fmt.Sprintf("%d%%", diskSpaceUsedPercentage))
+	meta := map[string]interface{}{"path": path}
+	result := checks.NewResult().SetMeta(meta)
 
-	if diskSpaceUsedPercentage > dsc.errorThreshold {
+	stats, err := readDiskStats(path)
+	if err != nil {
 		return result.
-			SetStatus(enums.StatusFailed).
-			SetNotificationMessage(fmt.Sprintf("The disk is almost full (%d%% used).", diskSpaceUsedPercentage))
+			SetStatus(enums.StatusCrashed).
+			SetShortSummary("Unavailable").
+			SetNotificationMessage(fmt.Sprintf("Could not measure disk usage: %v", err))
 	}
 
-	if diskSpaceUsedPercentage > dsc.warningThreshold {
-		return result.
-			SetStatus(enums.StatusWarning).
-			SetNotificationMessage(fmt.Sprintf("The disk is almost full (%d%% used).", diskSpaceUsedPercentage))
-	}
+	// Like df, used space excludes the blocks reserved for root from the total
+	used := stats.totalBytes - stats.freeBytes
+	diskSpaceUsedPercentage := percentage(used, used+stats.availableBytes)
 
-	return result.SetStatus(enums.StatusOK)
-}
+	meta["disk_total_bytes"] = stats.totalBytes
+	meta["disk_used_bytes"] = used
+	meta["disk_available_bytes"] = stats.availableBytes
+	meta["disk_space_used_percentage"] = diskSpaceUsedPercentage
 
-// getDiskUsagePercentage gets the disk usage percentage using df command.
-// This mirrors the Laravel implementation exactly.
-func (dsc *UsedDiskSpaceCheck) getDiskUsagePercentage() int {
-	// Build the df command - use current directory if no filesystem specified
-	path := "."
-	if dsc.filesystemName != nil {
-		path = *dsc.filesystemName
-	}
+	summary := fmt.Sprintf("%d%%", diskSpaceUsedPercentage)
 
-	// Execute df -P command (POSIX format for consistent parsing)
-	cmd := exec.Command("df", "-P", path)
-	output, err := cmd.Output()
-	if err != nil {
-		// If df command fails, return 0 to avoid false alarms
-		return 0
-	}
+	// Some filesystems (e.g. btrfs) allocate inodes dynamically and report none
+	inodesUsedPercentage := 0
+	if stats.totalInodes > 0 {
+		inodesUsed := stats.totalInodes - stats.freeInodes
+		inodesUsedPercentage = percentage(inodesUsed, stats.totalInodes)
 
-	// Parse the percentage from df output using regex
-	// df output format: "Filesystem 1024-blocks Used Available Capacity Mounted"
-	// We need to extract the percentage (e.g., "85%")
-	re := regexp.MustCompile(`(\d+)%`)
-	matches := re.FindStringSubmatch(string(output))
-	if len(matches) < 2 {
-		// If we can't parse the percentage, return 0
-		return 0
+		meta["inodes_total"] = stats.totalInodes
+		meta["inodes_used"] = inodesUsed
+		meta["inodes_free"] = stats.freeInodes
+		meta["inodes_used_percentage"] = inodesUsedPercentage
+
+		summary += fmt.Sprintf(", %d%% inodes", inodesUsedPercentage)
 	}
 
-	// Convert percentage string to int
-	percentage, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return 0
+	result.SetShortSummary(summary)
+
+	switch {
+	case diskSpaceUsedPercentage > dsc.errorThreshold:
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetNotificationMessage(fmt.Sprintf("The disk is almost full (%d%% used).", diskSpaceUsedPercentage))
+	case inodesUsedPercentage > dsc.inodeErrorThreshold:
+		return result.
+			SetStatus(enums.StatusFailed).
+			SetNotificationMessage(fmt.Sprintf("The disk is running out of inodes (%d%% used).", inodesUsedPercentage))
+	case diskSpaceUsedPercentage > dsc.warningThreshold:
+		return result.
+			SetStatus(enums.StatusWarning).
+			SetNotificationMessage(fmt.Sprintf("The disk is almost full (%d%% used).", diskSpaceUsedPercentage))
+	case inodesUsedPercentage > dsc.inodeWarningThreshold:
+		return result.
+			SetStatus(enums.StatusWarning).
+			SetNotificationMessage(fmt.Sprintf("The disk is running out of inodes (%d%% used).", inodesUsedPercentage))
 	}
 
-	return percentage
+	return result.SetStatus(enums.StatusOK)
 }
 
 // Compile-time interface compliance check
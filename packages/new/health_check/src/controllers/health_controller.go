@@ -4,12 +4,15 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"govel/healthcheck/interfaces"
+	"govel/healthcheck/types"
 )
 
 // HealthController handles HTTP requests for health check endpoints.
@@ -83,8 +86,8 @@ func (hc *HealthController) HandleHealthCheckJSON(w http.ResponseWriter, r *http
 	}
 
 	// Try to get cached results if result store is available
-	if hc.resultStore != nil {
-		if storedResults, err := hc.resultStore.Get(); err == nil && storedResults != nil {
+	if store := hc.store(); store != nil {
+		if storedResults, err := store.Get(); err == nil && storedResults != nil {
 			hc.handleJSONResponse(w, r, storedResults)
 			return
 		}
@@ -181,6 +184,65 @@ func (hc *HealthController) HandleLivenessCheck(w http.ResponseWriter, r *http.R
 	w.Write([]byte(response))
 }
 
+// HandleHistory handles the health history endpoint.
+// Responds with the status of each check over time, read from the result store.
+//
+// Query parameters:
+//
+//	check: Check names to include, repeatable or comma-separated (default: all)
+//	from: Start of the range, RFC 3339 or unix seconds (default: 24 hours before to)
+//	to: End of the range, RFC 3339 or unix seconds (default: now)
+//
+// Endpoints:
+//
+//	GET /health/history - Per-check status history
+func (hc *HealthController) HandleHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+
+	store := hc.store()
+	if store == nil {
+		hc.writeJSONError(w, http.StatusNotImplemented, "No health check result store is configured")
+		return
+	}
+
+	query := r.URL.Query()
+
+	to, err := parseHistoryTime(query.Get("to"), time.Now())
+	if err != nil {
+		hc.writeJSONError(w, http.StatusBadRequest, "Invalid 'to' parameter: "+err.Error())
+		return
+	}
+	from, err := parseHistoryTime(query.Get("from"), to.Add(-24*time.Hour))
+	if err != nil {
+		hc.writeJSONError(w, http.StatusBadRequest, "Invalid 'from' parameter: "+err.Error())
+		return
+	}
+
+	var names []string
+	for _, value := range query["check"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	snapshots, err := store.GetHistory(from, to)
+	if err != nil {
+		hc.writeJSONError(w, http.StatusInternalServerError, "Failed to read health check history: "+err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":   from.Format(time.RFC3339),
+		"to":     to.Format(time.RFC3339),
+		"runs":   len(snapshots),
+		"checks": types.BuildHistory(snapshots, names...),
+	})
+}
+
 // SetRegistry sets the health registry for the controller.
 //
 // Parameters:
@@ -227,6 +289,38 @@ func (hc *HealthController) GetResultStore() interfaces.ResultStoreInterface {
 	return hc.resultStore
 }
 
+// store returns the controller's result store, falling back to the one of the registry.
+func (hc *HealthController) store() interfaces.ResultStoreInterface {
+	if hc.resultStore != nil {
+		return hc.resultStore
+	}
+	if hc.registry != nil {
+		return hc.registry.GetResultStore()
+	}
+	return nil
+}
+
+// writeJSONError writes a JSON error response with the given status code.
+func (hc *HealthController) writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":    "error",
+		"message":   message,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// parseHistoryTime parses an RFC 3339 or unix seconds time, fallback when empty.
+func parseHistoryTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 // handleJSONResponse handles JSON response formatting.
 func (hc *HealthController) handleJSONResponse(w http.ResponseWriter, r *http.Request, results interfaces.CheckResultsInterface) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -493,6 +587,7 @@ func (hc *HealthController) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/health/simple", hc.HandleSimpleHealthCheck)
 	mux.HandleFunc("/health/ready", hc.HandleReadinessCheck)
 	mux.HandleFunc("/health/live", hc.HandleLivenessCheck)
+	mux.HandleFunc("/health/history", hc.HandleHistory)
 }
 
 // WithTimeout returns an HTTP handler that wraps the health check with a timeout.
@@ -0,0 +1,77 @@
+// Package enums provides the enumerations of the GoVel health check system.
+package enums
+
+import (
+	"govel/healthcheck/interfaces"
+)
+
+// Status is the outcome of a health check, mirroring Laravel Health's Status enum.
+type Status string
+
+const (
+	// StatusOK means the check passed
+	StatusOK Status = "ok"
+
+	// StatusWarning means the check passed but needs attention
+	StatusWarning Status = "warning"
+
+	// StatusFailed means the check did not pass
+	StatusFailed Status = "failed"
+
+	// StatusCrashed means the check could not be executed
+	StatusCrashed Status = "crashed"
+
+	// StatusSkipped means the check did not run
+	StatusSkipped Status = "skipped"
+)
+
+// StatusFromString returns the status named s.
+//
+// Returns:
+//
+//	Status: The matching status
+//	bool: false if s names no status
+func StatusFromString(s string) (Status, bool) {
+	switch status := Status(s); status {
+	case StatusOK, StatusWarning, StatusFailed, StatusCrashed, StatusSkipped:
+		return status, true
+	}
+	return "", false
+}
+
+// String returns the string representation of the status.
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsHealthy returns true for StatusOK.
+func (s Status) IsHealthy() bool {
+	return s == StatusOK
+}
+
+// IsWarning returns true for StatusWarning.
+func (s Status) IsWarning() bool {
+	return s == StatusWarning
+}
+
+// IsFailed returns true for StatusFailed and StatusCrashed.
+func (s Status) IsFailed() bool {
+	return s == StatusFailed || s == StatusCrashed
+}
+
+// GetSeverityLevel returns 0 for ok and skipped, 1 for warning, 2 for
+// failed and 3 for crashed.
+func (s Status) GetSeverityLevel() int {
+	switch s {
+	case StatusWarning:
+		return 1
+	case StatusFailed:
+		return 2
+	case StatusCrashed:
+		return 3
+	}
+	return 0
+}
+
+// Compile-time interface compliance check
+var _ interfaces.StatusInterface = StatusOK
@@ -29,10 +29,11 @@ import (
 	"context"
 	"time"
 
-	"govel/healthcheck/http/controllers"
+	"govel/healthcheck/controllers"
 	"govel/healthcheck/interfaces"
 	"govel/healthcheck/registry"
 	"govel/healthcheck/types"
+	containerInterfaces "govel/types/interfaces/container"
 )
 
 // Health is the main facade for the health check system.
@@ -230,6 +231,35 @@ func (h *Health) WithResultStore(store interfaces.ResultStoreInterface) *Health
 	return h
 }
 
+// WithNotifier sets the notifier alerting about failing checks.
+//
+// Parameters:
+//
+//	notifier: Notifier implementation, such as notifications.NewNotifier()
+//
+// Returns:
+//
+//	*Health: Self for method chaining
+func (h *Health) WithNotifier(notifier interfaces.NotifierInterface) *Health {
+	h.registry.WithNotifier(notifier)
+	return h
+}
+
+// SetContainer sets the service container that dependency checks such as
+// DatabaseCheck or CacheCheck resolve their targets from.
+//
+// Parameters:
+//
+//	container: The service container
+//
+// Returns:
+//
+//	*Health: Self for method chaining
+func (h *Health) SetContainer(container containerInterfaces.ContainerInterface) *Health {
+	h.registry.SetContainer(container)
+	return h
+}
+
 // NewController creates a new HTTP controller for this health instance.
 // The controller provides HTTP endpoints for health monitoring.
 //
@@ -4,6 +4,8 @@ package interfaces
 
 import (
 	"time"
+
+	containerInterfaces "govel/types/interfaces/container"
 )
 
 // CheckInterface defines the contract that all health checks must implement.
@@ -43,6 +45,40 @@ type CheckInterface interface {
 	GetName() string
 }
 
+// CacheableCheckInterface is implemented by checks whose results may be
+// served from cache. The registry reuses the last result of such a check
+// while it is younger than the cache TTL, so frequent probes do not hammer
+// the checked dependency.
+type CacheableCheckInterface interface {
+	CheckInterface
+
+	// GetCacheTTL returns how long a result may be served from cache.
+	//
+	// Returns:
+	//   time.Duration: Cache lifetime, 0 to always run the check
+	GetCacheTTL() time.Duration
+}
+
+// ContainerAwareCheckInterface is implemented by checks that resolve the
+// dependency they probe (database, cache, queue, ...) from the service
+// container. The registry hands its container to such checks when they are
+// registered, unless the check already has one.
+type ContainerAwareCheckInterface interface {
+	CheckInterface
+
+	// SetContainer sets the container the check resolves its targets from.
+	//
+	// Parameters:
+	//   container: The service container
+	SetContainer(container containerInterfaces.ContainerInterface)
+
+	// HasContainer returns whether a container is set.
+	//
+	// Returns:
+	//   bool: true if the check has a container
+	HasContainer() bool
+}
+
 // ResultInterface defines the contract for health check results.
 // Results contain the status, messages, metadata, and timing information
 // from health check executions.
@@ -249,6 +285,13 @@ type CheckResultsInterface interface {
 	//   []ResultInterface: Results matching the status
 	FilterByStatus(status StatusInterface) []ResultInterface
 
+	// GetHealthSummary returns the number of checks per outcome.
+	//
+	// Returns:
+	//   map[string]interface{}: "total", "healthy", "warnings", "failed" (int)
+	//   and "total_duration_ms" (int64)
+	GetHealthSummary() map[string]interface{}
+
 	// ToJSON converts the results to JSON format.
 	//
 	// Returns:
@@ -4,6 +4,8 @@ package interfaces
 import (
 	"context"
 	"time"
+
+	containerInterfaces "govel/types/interfaces/container"
 )
 
 // HealthRegistryInterface defines the contract for health check registry implementations.
@@ -196,6 +198,39 @@ type HealthRegistryInterface interface {
 	// Returns:
 	//   ResultStoreInterface: The configured result store, nil if none set
 	GetResultStore() ResultStoreInterface
+
+	// SetContainer sets the service container handed to container-aware
+	// checks, both those already registered and those registered later.
+	//
+	// Parameters:
+	//   container: The service container
+	//
+	// Returns:
+	//   HealthRegistryInterface: Self for method chaining
+	SetContainer(container containerInterfaces.ContainerInterface) HealthRegistryInterface
+
+	// WithNotifier sets the notifier told about the results of every run.
+	//
+	// Parameters:
+	//   notifier: Notifier implementation
+	//
+	// Returns:
+	//   HealthRegistryInterface: Self for method chaining
+	WithNotifier(notifier NotifierInterface) HealthRegistryInterface
+}
+
+// NotifierInterface defines the contract for alerting on check results.
+// Notifiers decide which results deserve a notification and deliver them.
+type NotifierInterface interface {
+	// Notify inspects the results of a run and sends the due notifications.
+	//
+	// Parameters:
+	//   ctx: Context for timeout and cancellation control
+	//   results: The results of the run
+	//
+	// Returns:
+	//   error: Any error delivering notifications
+	Notify(ctx context.Context, results CheckResultsInterface) error
 }
 
 // ResultStoreInterface defines the contract for result storage backends.
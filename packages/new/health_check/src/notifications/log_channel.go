@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"context"
+
+	loggerInterfaces "govel/types/interfaces/logger"
+)
+
+// LogChannel writes notifications to the application log. Failures are
+// logged as errors, warnings as warnings and recoveries as info.
+type LogChannel struct {
+	// logger receives the notifications
+	logger loggerInterfaces.LoggerInterface
+}
+
+// NewLogChannel creates a log channel named "log".
+//
+// Parameters:
+//
+//	logger: The logger to write to
+//
+// Returns:
+//
+//	*LogChannel: A new log channel
+func NewLogChannel(logger loggerInterfaces.LoggerInterface) *LogChannel {
+	return &LogChannel{logger: logger}
+}
+
+// Name returns the channel name used in routes.
+//
+// Returns:
+//
+//	string: The channel name
+func (lc *LogChannel) Name() string {
+	return "log"
+}
+
+// Send logs the notification.
+//
+// Parameters:
+//
+//	ctx: Context for timeout and cancellation control
+//	notification: The notification to log
+//
+// Returns:
+//
+//	error: Always nil
+func (lc *LogChannel) Send(ctx context.Context, notification Notification) error {
+	logger := lc.logger.WithFields(map[string]interface{}{
+		"check":           notification.CheckName,
+		"status":          notification.Status.String(),
+		"previous_status": notification.PreviousStatus.String(),
+		"summary":         notification.ShortSummary,
+	})
+
+	switch {
+	case notification.Status.IsHealthy():
+		logger.Info("%s: %s", notification.Title(), notification.Text())
+	case notification.Status.IsWarning():
+		logger.Warn("%s: %s", notification.Title(), notification.Text())
+	default:
+		logger.Error("%s: %s", notification.Title(), notification.Text())
+	}
+
+	return nil
+}
+
+// Compile-time interface compliance check
+var _ Channel = (*LogChannel)(nil)
@@ -0,0 +1,84 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MailSendFunc sends a plain text mail. It decouples the mail channel from
+// a specific mailer; wrap the application mailer in one.
+type MailSendFunc func(ctx context.Context, to []string, subject, body string) error
+
+// MailChannel mails notifications to a fixed list of recipients.
+type MailChannel struct {
+	// send delivers the mail
+	send MailSendFunc
+
+	// to are the recipients
+	to []string
+}
+
+// NewMailChannel creates a mail channel named "mail".
+//
+// Parameters:
+//
+//	send: Function delivering the mail
+//	to: Recipient addresses
+//
+// Returns:
+//
+//	*MailChannel: A new mail channel
+func NewMailChannel(send MailSendFunc, to ...string) *MailChannel {
+	return &MailChannel{send: send, to: to}
+}
+
+// Name returns the channel name used in routes.
+//
+// Returns:
+//
+//	string: The channel name
+func (mc *MailChannel) Name() string {
+	return "mail"
+}
+
+// Send mails the notification.
+//
+// Parameters:
+//
+//	ctx: Context for timeout and cancellation control
+//	notification: The notification to mail
+//
+// Returns:
+//
+//	error: Any error delivering the mail
+func (mc *MailChannel) Send(ctx context.Context, notification Notification) error {
+	if len(mc.to) == 0 {
+		return fmt.Errorf("mail channel has no recipients")
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", notification.Text())
+	fmt.Fprintf(&body, "Check:  %s (%s)\n", notification.CheckLabel, notification.CheckName)
+	fmt.Fprintf(&body, "Status: %s\n", notification.Status)
+	fmt.Fprintf(&body, "Time:   %s\n", notification.OccurredAt.Format("2006-01-02 15:04:05 MST"))
+
+	if len(notification.Meta) > 0 {
+		keys := make([]string, 0, len(notification.Meta))
+		for key := range notification.Meta {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		body.WriteString("\n")
+		for _, key := range keys {
+			fmt.Fprintf(&body, "%s: %v\n", key, notification.Meta[key])
+		}
+	}
+
+	return mc.send(ctx, mc.to, notification.Title(), body.String())
+}
+
+// Compile-time interface compliance check
+var _ Channel = (*MailChannel)(nil)
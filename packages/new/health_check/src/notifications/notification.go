@@ -0,0 +1,103 @@
+// Package notifications alerts about failing health checks. A Notifier
+// inspects the results of every run, throttles repeated alerts, and routes
+// each notification to channels such as a Slack-compatible webhook, mail or
+// the application log depending on the check and its severity.
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+)
+
+// Notification describes a check whose status deserves attention.
+type Notification struct {
+	// CheckName is the unique name of the check
+	CheckName string
+
+	// CheckLabel is the display label of the check
+	CheckLabel string
+
+	// Status is the status reported by the latest run
+	Status enums.Status
+
+	// PreviousStatus is the status of the run before, empty when unknown
+	PreviousStatus enums.Status
+
+	// Message is the notification message of the result
+	Message string
+
+	// ShortSummary is the short summary of the result
+	ShortSummary string
+
+	// Meta is the metadata of the result
+	Meta map[string]interface{}
+
+	// OccurredAt is when the check finished
+	OccurredAt time.Time
+}
+
+// newNotification creates a notification from a check result.
+func newNotification(name string, result interfaces.ResultInterface, status, previous enums.Status) Notification {
+	label := name
+	if labeled, ok := result.GetCheck().(interface{ GetLabel() string }); ok {
+		label = labeled.GetLabel()
+	}
+
+	occurredAt := time.Now()
+	if endedAt := result.GetEndedAt(); endedAt != nil {
+		occurredAt = *endedAt
+	}
+
+	return Notification{
+		CheckName:      name,
+		CheckLabel:     label,
+		Status:         status,
+		PreviousStatus: previous,
+		Message:        result.GetNotificationMessage(),
+		ShortSummary:   result.GetShortSummary(),
+		Meta:           result.GetMeta(),
+		OccurredAt:     occurredAt,
+	}
+}
+
+// IsRecovery returns whether the check became healthy again.
+//
+// Returns:
+//
+//	bool: true if the check is ok after not being ok
+func (n Notification) IsRecovery() bool {
+	return n.Status.IsHealthy() && n.PreviousStatus != "" && !n.PreviousStatus.IsHealthy()
+}
+
+// Title returns a one-line headline for the notification.
+//
+// Returns:
+//
+//	string: The headline
+func (n Notification) Title() string {
+	if n.IsRecovery() {
+		return fmt.Sprintf("Health check %q recovered", n.CheckLabel)
+	}
+	return fmt.Sprintf("Health check %q reported %s", n.CheckLabel, n.Status)
+}
+
+// Text returns the body of the notification.
+//
+// Returns:
+//
+//	string: The result message, falling back to the short summary
+func (n Notification) Text() string {
+	text := n.Message
+	if text == "" {
+		text = n.ShortSummary
+	}
+
+	if n.PreviousStatus != "" && n.PreviousStatus != n.Status {
+		text = fmt.Sprintf("%s (previously %s)", text, n.PreviousStatus)
+	}
+
+	return text
+}
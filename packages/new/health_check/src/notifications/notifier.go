@@ -0,0 +1,327 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+)
+
+// Channel delivers notifications to one destination.
+type Channel interface {
+	// Name returns the name routes refer to the channel by.
+	Name() string
+
+	// Send delivers a notification.
+	Send(ctx context.Context, notification Notification) error
+}
+
+// checkState is what the notifier remembers about a check between runs.
+type checkState struct {
+	// status is the status reported by the latest run
+	status enums.Status
+
+	// notifiedAt is when a notification was last sent for the check
+	notifiedAt time.Time
+}
+
+// delivery is a notification together with the channels it goes to.
+type delivery struct {
+	notification Notification
+	channels     []string
+}
+
+// Notifier turns health check results into notifications. A check is
+// reported when its status becomes warning, failed or crashed, and again
+// once it recovers. While a check keeps failing, reminders are sent at most
+// once per throttle window, or never with OnlyOnStateChange.
+//
+// Example:
+//
+//	notifier := notifications.NewNotifier().
+//		Channel(notifications.NewWebhookChannel(slackURL), notifications.NewLogChannel(logger)).
+//		Route(enums.StatusWarning, "log").
+//		RouteCheck("database", enums.StatusFailed, "webhook", "log")
+//	health.WithNotifier(notifier)
+type Notifier struct {
+	// channels holds the registered channels by name
+	channels map[string]Channel
+
+	// channelNames keeps the registration order of channels
+	channelNames []string
+
+	// routes maps statuses to channel names for all checks
+	routes map[enums.Status][]string
+
+	// checkRoutes maps check names and statuses to channel names
+	checkRoutes map[string]map[enums.Status][]string
+
+	// throttle is the minimum time between reminders for a failing check
+	throttle time.Duration
+
+	// onlyOnStateChange disables reminders for checks that keep failing
+	onlyOnStateChange bool
+
+	// states holds the last known state of every check by name
+	states map[string]checkState
+
+	// errorHandler is called when a channel fails to deliver
+	errorHandler func(err error)
+
+	// now returns the current time
+	now func() time.Time
+
+	// mutex provides thread-safe access to the notifier
+	mutex sync.Mutex
+}
+
+// NewNotifier creates a notifier without channels that sends reminders for
+// failing checks at most once an hour.
+//
+// Returns:
+//
+//	*Notifier: A new notifier instance
+func NewNotifier() *Notifier {
+	return &Notifier{
+		channels:    make(map[string]Channel),
+		routes:      make(map[enums.Status][]string),
+		checkRoutes: make(map[string]map[enums.Status][]string),
+		throttle:    time.Hour,
+		states:      make(map[string]checkState),
+		now:         time.Now,
+	}
+}
+
+// Channel registers channels. Notifications without a route go to every
+// registered channel.
+//
+// Parameters:
+//
+//	channels: The channels to register
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) Channel(channels ...Channel) *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, channel := range channels {
+		if _, exists := n.channels[channel.Name()]; !exists {
+			n.channelNames = append(n.channelNames, channel.Name())
+		}
+		n.channels[channel.Name()] = channel
+	}
+	return n
+}
+
+// Route sends notifications with the given status to the given channels.
+// Recoveries are routed with enums.StatusOK. Passing no channels mutes
+// the status.
+//
+// Parameters:
+//
+//	status: The status to route
+//	channels: Names of the channels
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) Route(status enums.Status, channels ...string) *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.routes[status] = channels
+	return n
+}
+
+// RouteCheck sends notifications of one check with the given status to the
+// given channels, overriding Route.
+//
+// Parameters:
+//
+//	check: Name of the check
+//	status: The status to route
+//	channels: Names of the channels
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) RouteCheck(check string, status enums.Status, channels ...string) *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.checkRoutes[check] == nil {
+		n.checkRoutes[check] = make(map[enums.Status][]string)
+	}
+	n.checkRoutes[check][status] = channels
+	return n
+}
+
+// ThrottleNotificationsForMinutes sets the minimum time between reminders
+// for a check that keeps failing. Status changes are always reported.
+//
+// Parameters:
+//
+//	minutes: Minutes between reminders (0 reminds on every run)
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) ThrottleNotificationsForMinutes(minutes int) *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.throttle = time.Duration(minutes) * time.Minute
+	return n
+}
+
+// OnlyOnStateChange only reports checks whose status changed since the
+// previous run, never sending reminders.
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) OnlyOnStateChange() *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.onlyOnStateChange = true
+	return n
+}
+
+// OnError sets the handler called when a channel fails to deliver.
+//
+// Parameters:
+//
+//	handler: Function receiving the delivery error
+//
+// Returns:
+//
+//	*Notifier: Self for method chaining
+func (n *Notifier) OnError(handler func(err error)) *Notifier {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.errorHandler = handler
+	return n
+}
+
+// Notify inspects the results of a run and sends the due notifications.
+//
+// Parameters:
+//
+//	ctx: Context for timeout and cancellation control
+//	results: The results of the run
+//
+// Returns:
+//
+//	error: The joined delivery errors, nil if all notifications were sent
+func (n *Notifier) Notify(ctx context.Context, results interfaces.CheckResultsInterface) error {
+	n.mutex.Lock()
+	deliveries := n.collect(results)
+	channels := make(map[string]Channel, len(n.channels))
+	for name, channel := range n.channels {
+		channels[name] = channel
+	}
+	onError := n.errorHandler
+	n.mutex.Unlock()
+
+	var errs []error
+	for _, delivery := range deliveries {
+		for _, name := range delivery.channels {
+			channel, ok := channels[name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("notification channel %q is not registered", name))
+				continue
+			}
+
+			if err := channel.Send(ctx, delivery.notification); err != nil {
+				errs = append(errs, fmt.Errorf("channel %q failed to notify about %s: %w", name, delivery.notification.CheckName, err))
+			}
+		}
+	}
+
+	if onError != nil {
+		for _, err := range errs {
+			onError(err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collect updates the check states and returns the notifications due for
+// the run. The caller must hold the mutex.
+func (n *Notifier) collect(results interfaces.CheckResultsInterface) []delivery {
+	now := n.now()
+	var deliveries []delivery
+
+	for _, result := range results.GetResults() {
+		if result.GetCheck() == nil || result.GetStatus() == nil {
+			continue
+		}
+
+		status, ok := enums.StatusFromString(result.GetStatus().String())
+		if !ok || status == enums.StatusSkipped {
+			continue
+		}
+
+		name := result.GetCheck().GetName()
+		previous, known := n.states[name]
+		state := checkState{status: status, notifiedAt: previous.notifiedAt}
+
+		if n.shouldNotify(status, previous, known, now) {
+			notification := newNotification(name, result, status, previous.status)
+			deliveries = append(deliveries, delivery{
+				notification: notification,
+				channels:     n.channelsFor(name, status),
+			})
+			state.notifiedAt = now
+		}
+
+		n.states[name] = state
+	}
+
+	return deliveries
+}
+
+// shouldNotify decides whether a status reported for a check deserves a notification.
+func (n *Notifier) shouldNotify(status enums.Status, previous checkState, known bool, now time.Time) bool {
+	if status.IsHealthy() {
+		// Only recoveries of checks that were reported as unhealthy
+		return known && !previous.status.IsHealthy()
+	}
+
+	if !known || previous.status != status {
+		return true
+	}
+
+	if n.onlyOnStateChange {
+		return false
+	}
+
+	return now.Sub(previous.notifiedAt) >= n.throttle
+}
+
+// channelsFor returns the channels a notification of a check is routed to.
+func (n *Notifier) channelsFor(check string, status enums.Status) []string {
+	if routes, ok := n.checkRoutes[check]; ok {
+		if channels, ok := routes[status]; ok {
+			return channels
+		}
+	}
+
+	if channels, ok := n.routes[status]; ok {
+		return channels
+	}
+
+	return append([]string(nil), n.channelNames...)
+}
+
+// Compile-time interface compliance check
+var _ interfaces.NotifierInterface = (*Notifier)(nil)
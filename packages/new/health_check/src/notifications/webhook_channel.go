@@ -0,0 +1,220 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"govel/healthcheck/enums"
+)
+
+// WebhookChannel posts notifications as JSON to a URL. The payload follows
+// the Slack incoming webhook format, which Mattermost, Rocket.Chat and
+// Discord's /slack endpoint accept as well.
+type WebhookChannel struct {
+	// name is the channel name used in routes
+	name string
+
+	// url is the webhook URL
+	url string
+
+	// username overrides the name the message is posted as
+	username string
+
+	// headers are additional HTTP headers sent with the request
+	headers map[string]string
+
+	// client performs the requests
+	client *http.Client
+}
+
+// slackPayload is the body of a Slack incoming webhook request.
+type slackPayload struct {
+	Username    string            `json:"username,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+// slackAttachment is a colored block of a Slack message.
+type slackAttachment struct {
+	Color    string       `json:"color"`
+	Title    string       `json:"title"`
+	Text     string       `json:"text"`
+	Fallback string       `json:"fallback"`
+	Fields   []slackField `json:"fields"`
+	Ts       int64        `json:"ts"`
+}
+
+// slackField is a labelled value of a Slack attachment.
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// NewWebhookChannel creates a webhook channel named "webhook".
+//
+// Parameters:
+//
+//	url: The webhook URL
+//
+// Returns:
+//
+//	*WebhookChannel: A new webhook channel
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{
+		name:    "webhook",
+		url:     url,
+		headers: make(map[string]string),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Named sets the channel name used in routes, so several webhooks can be registered.
+//
+// Parameters:
+//
+//	name: The channel name
+//
+// Returns:
+//
+//	*WebhookChannel: Self for method chaining
+func (wc *WebhookChannel) Named(name string) *WebhookChannel {
+	wc.name = name
+	return wc
+}
+
+// Username sets the name the message is posted as.
+//
+// Parameters:
+//
+//	username: The display name
+//
+// Returns:
+//
+//	*WebhookChannel: Self for method chaining
+func (wc *WebhookChannel) Username(username string) *WebhookChannel {
+	wc.username = username
+	return wc
+}
+
+// Header adds an HTTP header to every request.
+//
+// Parameters:
+//
+//	key: Header name
+//	value: Header value
+//
+// Returns:
+//
+//	*WebhookChannel: Self for method chaining
+func (wc *WebhookChannel) Header(key, value string) *WebhookChannel {
+	wc.headers[key] = value
+	return wc
+}
+
+// Timeout sets the maximum time to wait for the webhook to answer.
+//
+// Parameters:
+//
+//	seconds: Timeout in seconds
+//
+// Returns:
+//
+//	*WebhookChannel: Self for method chaining
+func (wc *WebhookChannel) Timeout(seconds int) *WebhookChannel {
+	wc.client.Timeout = time.Duration(seconds) * time.Second
+	return wc
+}
+
+// Name returns the channel name used in routes.
+//
+// Returns:
+//
+//	string: The channel name
+func (wc *WebhookChannel) Name() string {
+	return wc.name
+}
+
+// Send posts the notification to the webhook.
+//
+// Parameters:
+//
+//	ctx: Context for timeout and cancellation control
+//	notification: The notification to send
+//
+// Returns:
+//
+//	error: Any error sending the request or a non-2xx response
+func (wc *WebhookChannel) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(wc.payload(notification))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wc.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range wc.headers {
+		req.Header.Set(key, value)
+	}
+
+	response, err := wc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("webhook responded with status %d: %s", response.StatusCode, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
+
+// payload builds the Slack-compatible body for a notification.
+func (wc *WebhookChannel) payload(notification Notification) slackPayload {
+	fields := []slackField{
+		{Title: "Check", Value: notification.CheckName, Short: true},
+		{Title: "Status", Value: notification.Status.String(), Short: true},
+	}
+	if notification.ShortSummary != "" {
+		fields = append(fields, slackField{Title: "Summary", Value: notification.ShortSummary, Short: true})
+	}
+
+	return slackPayload{
+		Username: wc.username,
+		Text:     notification.Title(),
+		Attachments: []slackAttachment{{
+			Color:    statusColor(notification.Status),
+			Title:    notification.CheckLabel,
+			Text:     notification.Text(),
+			Fallback: notification.Title() + ": " + notification.Text(),
+			Fields:   fields,
+			Ts:       notification.OccurredAt.Unix(),
+		}},
+	}
+}
+
+// statusColor returns the Slack attachment color of a status.
+func statusColor(status enums.Status) string {
+	switch {
+	case status.IsHealthy():
+		return "good"
+	case status.IsWarning():
+		return "warning"
+	default:
+		return "danger"
+	}
+}
+
+// Compile-time interface compliance check
+var _ Channel = (*WebhookChannel)(nil)
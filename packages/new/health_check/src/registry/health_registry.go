@@ -12,6 +12,7 @@ import (
 	"govel/healthcheck/enums"
 	"govel/healthcheck/interfaces"
 	"govel/healthcheck/types"
+	containerInterfaces "govel/types/interfaces/container"
 )
 
 // HealthRegistry is the central registry for managing health checks.
@@ -30,10 +31,40 @@ type HealthRegistry struct {
 	// maxConcurrency limits the number of concurrent check executions
 	maxConcurrency int
 
+	// storeErrorHandler is called when persisting results fails
+	storeErrorHandler func(err error)
+
+	// notifier is told about the results of every run
+	notifier interfaces.NotifierInterface
+
+	// container is handed to container-aware checks on registration
+	container containerInterfaces.ContainerInterface
+
+	// cache holds the last result of cacheable checks by name
+	cache map[string]cachedResult
+
+	// cacheMutex provides thread-safe access to the cache
+	cacheMutex sync.Mutex
+
 	// mutex provides thread-safe access to the registry
 	mutex sync.RWMutex
 }
 
+// namedCheck is implemented by checks embedding checks.BaseCheck.
+type namedCheck interface {
+	HasName() bool
+	Name(name string) *checks.BaseCheck
+}
+
+// cachedResult is a result kept for a cacheable check.
+type cachedResult struct {
+	// result is the result of the last execution
+	result *checks.Result
+
+	// storedAt is when the result was produced
+	storedAt time.Time
+}
+
 // NewHealthRegistry creates a new health check registry with default settings.
 //
 // Returns:
@@ -44,6 +75,7 @@ func NewHealthRegistry() *HealthRegistry {
 		checks:         make(map[string]interfaces.CheckInterface),
 		defaultTimeout: 30 * time.Second,
 		maxConcurrency: 0, // 0 means unlimited
+		cache:          make(map[string]cachedResult),
 	}
 }
 
@@ -62,6 +94,7 @@ func NewHealthRegistryWithDefaults(timeout time.Duration, maxConcurrency int) *H
 		checks:         make(map[string]interfaces.CheckInterface),
 		defaultTimeout: timeout,
 		maxConcurrency: maxConcurrency,
+		cache:          make(map[string]cachedResult),
 	}
 }
 
@@ -91,6 +124,12 @@ func (hr *HealthRegistry) Register(name string, check interfaces.CheckInterface)
 		return fmt.Errorf("health check with name '%s' is already registered", name)
 	}
 
+	if named, ok := check.(namedCheck); ok && !named.HasName() {
+		// Unnamed checks take their registration name, so results stay distinguishable
+		named.Name(name)
+	}
+
+	hr.injectContainer(check)
 	hr.checks[name] = check
 	return nil
 }
@@ -236,7 +275,8 @@ func (hr *HealthRegistry) Count() int {
 	return len(hr.checks)
 }
 
-// RunChecks executes all registered health checks.
+// RunChecks executes all registered health checks and persists the results
+// to the configured result store.
 //
 // Parameters:
 //
@@ -256,13 +296,27 @@ func (hr *HealthRegistry) RunChecks(ctx context.Context) interfaces.CheckResults
 	results := types.NewCheckResultsWithCapacity(len(checks))
 	results.SetExecutedAt(time.Now())
 
-	// If no checks registered, return empty results
-	if len(checks) == 0 {
-		return results
+	// Execute checks with concurrency control
+	hr.executeChecksWithConcurrency(ctx, checks, results)
+
+	// Store results if result store is configured
+	hr.mutex.RLock()
+	store, onError, notifier := hr.resultStore, hr.storeErrorHandler, hr.notifier
+	hr.mutex.RUnlock()
+
+	if store != nil {
+		if err := store.Store(results); err != nil && onError != nil {
+			// Persistence failures never fail the health check run itself
+			onError(err)
+		}
 	}
 
-	// Execute checks with concurrency control
-	return hr.executeChecksWithConcurrency(ctx, checks, results)
+	if notifier != nil {
+		// Delivery failures are reported by the notifier itself
+		_ = notifier.Notify(ctx, results)
+	}
+
+	return results
 }
 
 // RunCheck executes a specific health check by name.
@@ -429,10 +483,14 @@ func (hr *HealthRegistry) Clone() interfaces.HealthRegistryInterface {
 	defer hr.mutex.RUnlock()
 
 	clone := &HealthRegistry{
-		checks:         make(map[string]interfaces.CheckInterface),
-		resultStore:    hr.resultStore,
-		defaultTimeout: hr.defaultTimeout,
-		maxConcurrency: hr.maxConcurrency,
+		checks:            make(map[string]interfaces.CheckInterface),
+		resultStore:       hr.resultStore,
+		defaultTimeout:    hr.defaultTimeout,
+		maxConcurrency:    hr.maxConcurrency,
+		storeErrorHandler: hr.storeErrorHandler,
+		notifier:          hr.notifier,
+		container:         hr.container,
+		cache:             make(map[string]cachedResult),
 	}
 
 	for name, check := range hr.checks {
@@ -471,6 +529,90 @@ func (hr *HealthRegistry) GetResultStore() interfaces.ResultStoreInterface {
 	return hr.resultStore
 }
 
+// WithNotifier sets the notifier told about the results of every RunChecks call.
+//
+// Parameters:
+//
+//	notifier: Notifier implementation
+//
+// Returns:
+//
+//	interfaces.HealthRegistryInterface: Self for method chaining
+func (hr *HealthRegistry) WithNotifier(notifier interfaces.NotifierInterface) interfaces.HealthRegistryInterface {
+	hr.mutex.Lock()
+	defer hr.mutex.Unlock()
+
+	hr.notifier = notifier
+	return hr
+}
+
+// SetContainer sets the service container handed to container-aware checks.
+// Checks that are already registered receive it immediately, checks
+// registered later receive it on registration. Checks that were given
+// their own container keep it.
+//
+// Parameters:
+//
+//	container: The service container
+//
+// Returns:
+//
+//	interfaces.HealthRegistryInterface: Self for method chaining
+func (hr *HealthRegistry) SetContainer(container containerInterfaces.ContainerInterface) interfaces.HealthRegistryInterface {
+	hr.mutex.Lock()
+	defer hr.mutex.Unlock()
+
+	hr.container = container
+	for _, check := range hr.checks {
+		hr.injectContainer(check)
+	}
+
+	return hr
+}
+
+// OnStoreError sets the handler called when persisting results to the
+// result store fails. Failures never fail the health check run itself.
+//
+// Parameters:
+//
+//	handler: Function receiving the storage error
+//
+// Returns:
+//
+//	*HealthRegistry: Self for method chaining
+func (hr *HealthRegistry) OnStoreError(handler func(err error)) *HealthRegistry {
+	hr.mutex.Lock()
+	defer hr.mutex.Unlock()
+
+	hr.storeErrorHandler = handler
+	return hr
+}
+
+// ClearCache forgets the cached results of cacheable checks.
+//
+// Returns:
+//
+//	*HealthRegistry: Self for method chaining
+func (hr *HealthRegistry) ClearCache() *HealthRegistry {
+	hr.cacheMutex.Lock()
+	defer hr.cacheMutex.Unlock()
+
+	hr.cache = make(map[string]cachedResult)
+	return hr
+}
+
+// injectContainer hands the registry container to a container-aware check
+// that has none yet. The caller must hold the registry mutex.
+func (hr *HealthRegistry) injectContainer(check interfaces.CheckInterface) {
+	if hr.container == nil {
+		return
+	}
+
+	if aware, ok := check.(interfaces.ContainerAwareCheckInterface); ok && !aware.HasContainer() {
+		aware.SetContainer(hr.container)
+	}
+}
+
 // executeChecksWithConcurrency executes checks with concurrency control.
 func (hr *HealthRegistry) executeChecksWithConcurrency(
 	ctx context.Context,
@@ -486,8 +628,8 @@ func (hr *HealthRegistry) executeChecksWithConcurrency(
 
 	// Semaphore for concurrency control
 	var semaphore chan struct{}
-	if hr.maxConcurrency > 0 {
-		semaphore = make(chan struct{}, hr.maxConcurrency)
+	if maxConcurrency := hr.GetMaxConcurrency(); maxConcurrency > 0 {
+		semaphore = make(chan struct{}, maxConcurrency)
 	}
 
 	var wg sync.WaitGroup
@@ -520,26 +662,46 @@ func (hr *HealthRegistry) executeChecksWithConcurrency(
 		results.AddResult(result)
 	}
 
-	// Store results if result store is configured
-	if hr.resultStore != nil {
-		if err := hr.resultStore.Store(results); err != nil {
-			// Log error but don't fail the entire operation
-			// In a real implementation, you'd use the GoVel logger here
-		}
-	}
-
 	return results
 }
 
-// executeCheck executes a single health check with proper error handling.
+// executeCheck executes a single health check, serving the result from
+// cache when the check is cacheable and its last result is still fresh.
 func (hr *HealthRegistry) executeCheck(ctx context.Context, name string, check interfaces.CheckInterface) interfaces.ResultInterface {
+	cacheable, ok := check.(interfaces.CacheableCheckInterface)
+	if !ok || cacheable.GetCacheTTL() <= 0 {
+		return hr.runCheck(ctx, check)
+	}
+
+	hr.cacheMutex.Lock()
+	cached, found := hr.cache[name]
+	hr.cacheMutex.Unlock()
+
+	if found && time.Since(cached.storedAt) < cacheable.GetCacheTTL() {
+		result := cached.result.Clone()
+		result.GetMeta()["cached"] = true
+		result.GetMeta()["cached_at"] = cached.storedAt.Format(time.RFC3339)
+		return result
+	}
+
+	result := hr.runCheck(ctx, check)
+
+	hr.cacheMutex.Lock()
+	hr.cache[name] = cachedResult{result: result, storedAt: time.Now()}
+	hr.cacheMutex.Unlock()
+
+	return result
+}
+
+// runCheck runs a single health check with proper error handling.
+func (hr *HealthRegistry) runCheck(ctx context.Context, check interfaces.CheckInterface) *checks.Result {
 	// Create a result with timing information
 	result := checks.NewResult()
 	result.SetCheck(check)
 	result.SetStartedAt(time.Now())
 
 	// Create timeout context
-	checkCtx, cancel := context.WithTimeout(ctx, hr.defaultTimeout)
+	checkCtx, cancel := context.WithTimeout(ctx, hr.GetDefaultTimeout())
 	defer cancel()
 
 	// Execute the check with panic recovery
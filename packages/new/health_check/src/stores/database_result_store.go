@@ -0,0 +1,343 @@
+package stores
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	"govel/healthcheck/types"
+)
+
+// DatabaseResultStore persists results to a database table with one row
+// per check and run, mirroring Laravel Health's EloquentHealthResultStore.
+// Rows of one run share a batch identifier.
+//
+// Expected schema (see TableSQL):
+//
+//	id                   BIGINT PRIMARY KEY (auto increment)
+//	batch                VARCHAR(36) NOT NULL (indexed)
+//	executed_at          BIGINT NOT NULL, unix milliseconds (indexed)
+//	check_name           VARCHAR(255) NOT NULL
+//	check_label          VARCHAR(255) NOT NULL
+//	status               VARCHAR(16) NOT NULL
+//	notification_message TEXT NULL
+//	short_summary        VARCHAR(255) NULL
+//	meta                 TEXT NULL, JSON encoded
+//	started_at           BIGINT NULL, unix milliseconds
+//	ended_at             BIGINT NULL, unix milliseconds
+type DatabaseResultStore struct {
+	// db is the database connection pool
+	db *sql.DB
+
+	// table is the history table name
+	table string
+
+	// dialect selects the placeholder style ("postgres"/"pgsql" use $N)
+	dialect string
+}
+
+// NewDatabaseResultStore creates a new database result store.
+//
+// Parameters:
+//
+//	db: Database connection pool
+//	table: History table name, "health_check_result_history_items" when empty
+//	dialect: SQL dialect used for placeholders ("mysql", "sqlite", "postgres")
+//
+// Returns:
+//
+//	*DatabaseResultStore: A new database store
+func NewDatabaseResultStore(db *sql.DB, table, dialect string) *DatabaseResultStore {
+	if table == "" {
+		table = "health_check_result_history_items"
+	}
+	return &DatabaseResultStore{
+		db:      db,
+		table:   table,
+		dialect: strings.ToLower(dialect),
+	}
+}
+
+// TableSQL returns the CREATE TABLE statements of the history table for the
+// store's dialect.
+func (s *DatabaseResultStore) TableSQL() string {
+	id := "BIGINT AUTO_INCREMENT PRIMARY KEY"
+	switch s.dialect {
+	case "postgres", "pgsql":
+		id = "BIGSERIAL PRIMARY KEY"
+	case "sqlite", "sqlite3":
+		id = "INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %[1]s (
+    id %[2]s,
+    batch VARCHAR(36) NOT NULL,
+    executed_at BIGINT NOT NULL,
+    check_name VARCHAR(255) NOT NULL,
+    check_label VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    notification_message TEXT NULL,
+    short_summary VARCHAR(255) NULL,
+    meta TEXT NULL,
+    started_at BIGINT NULL,
+    ended_at BIGINT NULL
+);
+CREATE INDEX %[1]s_batch_index ON %[1]s (batch);
+CREATE INDEX %[1]s_executed_at_index ON %[1]s (executed_at);
+`, s.table, id)
+}
+
+// Store inserts one row per result within a transaction.
+func (s *DatabaseResultStore) Store(results interfaces.CheckResultsInterface) error {
+	batch, err := newBatchID()
+	if err != nil {
+		return err
+	}
+
+	at := executedAt(results)
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to store health check results: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (batch, executed_at, check_name, check_label, status, notification_message, short_summary, meta, started_at, ended_at) VALUES (%s)",
+		s.table, s.placeholders(10),
+	)
+
+	for _, result := range results.GetResults() {
+		meta, err := json.Marshal(result.GetMeta())
+		if err != nil {
+			return fmt.Errorf("failed to encode health check metadata: %w", err)
+		}
+
+		name, label := checkNames(result.GetCheck())
+		status := ""
+		if result.GetStatus() != nil {
+			status = result.GetStatus().String()
+		}
+
+		if _, err := tx.Exec(query,
+			batch, at.UnixMilli(), name, label, status,
+			result.GetNotificationMessage(), result.GetShortSummary(), string(meta),
+			millis(result.GetStartedAt()), millis(result.GetEndedAt()),
+		); err != nil {
+			return fmt.Errorf("failed to store health check results: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to store health check results: %w", err)
+	}
+	return nil
+}
+
+// Get returns the results of the latest run, nil if none.
+func (s *DatabaseResultStore) Get() (interfaces.CheckResultsInterface, error) {
+	return s.batch(fmt.Sprintf("SELECT batch FROM %s ORDER BY executed_at DESC, id DESC LIMIT 1", s.table))
+}
+
+// GetByTimestamp returns the results that were current at timestamp: the
+// latest run executed at or before it, nil if none.
+func (s *DatabaseResultStore) GetByTimestamp(timestamp time.Time) (interfaces.CheckResultsInterface, error) {
+	return s.batch(fmt.Sprintf(
+		"SELECT batch FROM %s WHERE executed_at <= %s ORDER BY executed_at DESC, id DESC LIMIT 1",
+		s.table, s.placeholder(1),
+	), timestamp.UnixMilli())
+}
+
+// GetHistory returns the runs executed within from..to, oldest first.
+func (s *DatabaseResultStore) GetHistory(from, to time.Time) ([]interfaces.CheckResultsInterface, error) {
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE executed_at >= %s AND executed_at <= %s ORDER BY executed_at, id",
+		columns, s.table, s.placeholder(1), s.placeholder(2),
+	)
+	return s.query(query, from.UnixMilli(), to.UnixMilli())
+}
+
+// Clear removes all stored results.
+func (s *DatabaseResultStore) Clear() error {
+	if _, err := s.db.Exec("DELETE FROM " + s.table); err != nil {
+		return fmt.Errorf("failed to clear health check results: %w", err)
+	}
+	return nil
+}
+
+// Prune removes the runs executed before the given time.
+//
+// Parameters:
+//
+//	before: Runs executed before this time are removed
+//
+// Returns:
+//
+//	int64: Number of removed rows
+//	error: Any error during removal
+func (s *DatabaseResultStore) Prune(before time.Time) (int64, error) {
+	result, err := s.db.Exec(
+		fmt.Sprintf("DELETE FROM %s WHERE executed_at < %s", s.table, s.placeholder(1)),
+		before.UnixMilli(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune health check results: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetStorageInfo returns the driver name, table and number of stored rows.
+func (s *DatabaseResultStore) GetStorageInfo() map[string]interface{} {
+	info := map[string]interface{}{
+		"driver":  "database",
+		"table":   s.table,
+		"dialect": s.dialect,
+	}
+
+	var count int64
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM " + s.table).Scan(&count); err != nil {
+		info["error"] = err.Error()
+	} else {
+		info["count"] = count
+	}
+	return info
+}
+
+// columns are the selected columns, in scan order.
+const columns = "batch, executed_at, check_name, check_label, status, notification_message, short_summary, meta, started_at, ended_at"
+
+// batch returns the run whose batch is selected by batchQuery, nil if none.
+func (s *DatabaseResultStore) batch(batchQuery string, args ...interface{}) (interfaces.CheckResultsInterface, error) {
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE batch = (%s) ORDER BY id",
+		columns, s.table, batchQuery,
+	)
+
+	snapshots, err := s.query(query, args...)
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[0], nil
+}
+
+// query groups the selected rows into runs by batch, keeping row order.
+func (s *DatabaseResultStore) query(query string, args ...interface{}) ([]interfaces.CheckResultsInterface, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read health check results: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []interfaces.CheckResultsInterface
+	var current *types.CheckResults
+	var currentBatch string
+
+	for rows.Next() {
+		var (
+			batch, name, label, status string
+			executed                   int64
+			message, summary, meta     sql.NullString
+			startedAt, endedAt         sql.NullInt64
+		)
+		if err := rows.Scan(&batch, &executed, &name, &label, &status, &message, &summary, &meta, &startedAt, &endedAt); err != nil {
+			return nil, fmt.Errorf("failed to read health check results: %w", err)
+		}
+
+		if current == nil || batch != currentBatch {
+			current = types.NewCheckResults()
+			current.SetExecutedAt(time.UnixMilli(executed))
+			currentBatch = batch
+			snapshots = append(snapshots, current)
+		}
+
+		result := checks.NewResult()
+		if value, ok := enums.StatusFromString(status); ok {
+			result.SetStatus(value)
+		}
+		result.SetNotificationMessage(message.String)
+		result.SetShortSummary(summary.String)
+		if meta.Valid && meta.String != "" {
+			decoded := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(meta.String), &decoded); err == nil {
+				result.SetMeta(decoded)
+			}
+		}
+		if startedAt.Valid {
+			result.SetStartedAt(time.UnixMilli(startedAt.Int64))
+		}
+		if endedAt.Valid {
+			result.SetEndedAt(time.UnixMilli(endedAt.Int64))
+		}
+		result.SetCheck(types.NewStoredCheck(name, label, result))
+		current.AddResult(result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read health check results: %w", err)
+	}
+	return snapshots, nil
+}
+
+// placeholder returns the nth bind placeholder for the dialect.
+func (s *DatabaseResultStore) placeholder(n int) string {
+	if s.dialect == "postgres" || s.dialect == "pgsql" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// placeholders returns n comma-separated bind placeholders.
+func (s *DatabaseResultStore) placeholders(n int) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = s.placeholder(i + 1)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// checkNames returns the name and display label of a check.
+func checkNames(check interfaces.CheckInterface) (name, label string) {
+	if check == nil {
+		return "", ""
+	}
+
+	name, label = check.GetName(), check.GetName()
+	if labeled, ok := check.(interface{ GetLabel() string }); ok {
+		label = labeled.GetLabel()
+	}
+	return name, label
+}
+
+// millis converts an optional time to unix milliseconds.
+func millis(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UnixMilli()
+}
+
+// newBatchID returns a random UUID-formatted batch identifier.
+func newBatchID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate health check batch id: %w", err)
+	}
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	encoded := hex.EncodeToString(id[:])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:], nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ResultStoreInterface = (*DatabaseResultStore)(nil)
@@ -0,0 +1,203 @@
+package stores
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"govel/healthcheck/interfaces"
+	"govel/healthcheck/types"
+)
+
+// JSONFileResultStore persists results to a file holding one JSON document
+// per run (JSON lines), in the Laravel Health JSON format. The file is
+// rewritten atomically on every run and keeps the last maxEntries runs, so
+// results survive restarts and can be shared by processes on one host.
+type JSONFileResultStore struct {
+	// path is the file results are written to
+	path string
+
+	// maxEntries is the number of runs kept in the file
+	maxEntries int
+
+	// mutex serializes access to the file within the process
+	mutex sync.Mutex
+}
+
+// NewJSONFileResultStore creates a store writing to path.
+//
+// Parameters:
+//
+//	path: File results are written to, e.g. storage/app/health.jsonl
+//	maxEntries: Number of runs to keep, 100 when not positive
+//
+// Returns:
+//
+//	*JSONFileResultStore: A new JSON file store
+func NewJSONFileResultStore(path string, maxEntries int) *JSONFileResultStore {
+	if maxEntries <= 0 {
+		maxEntries = 100
+	}
+	return &JSONFileResultStore{path: path, maxEntries: maxEntries}
+}
+
+// Store appends results to the file, dropping the oldest runs beyond maxEntries.
+func (s *JSONFileResultStore) Store(results interfaces.CheckResultsInterface) error {
+	line, err := types.MarshalCheckResults(results)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return err
+	}
+
+	lines = append(lines, line)
+	if len(lines) > s.maxEntries {
+		lines = lines[len(lines)-s.maxEntries:]
+	}
+
+	return s.writeLines(lines)
+}
+
+// Get returns the latest stored results, nil if none.
+func (s *JSONFileResultStore) Get() (interfaces.CheckResultsInterface, error) {
+	snapshots, err := s.all()
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+// GetByTimestamp returns the results that were current at timestamp: the
+// latest run executed at or before it, nil if none.
+func (s *JSONFileResultStore) GetByTimestamp(timestamp time.Time) (interfaces.CheckResultsInterface, error) {
+	snapshots, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	return latestAt(snapshots, timestamp), nil
+}
+
+// GetHistory returns the runs executed within from..to, oldest first.
+func (s *JSONFileResultStore) GetHistory(from, to time.Time) ([]interfaces.CheckResultsInterface, error) {
+	snapshots, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	return between(snapshots, from, to), nil
+}
+
+// Clear removes the results file.
+func (s *JSONFileResultStore) Clear() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to clear health check results: %w", err)
+	}
+	return nil
+}
+
+// GetStorageInfo returns the driver name, file path, retention and number of stored runs.
+func (s *JSONFileResultStore) GetStorageInfo() map[string]interface{} {
+	s.mutex.Lock()
+	lines, err := s.readLines()
+	s.mutex.Unlock()
+
+	info := map[string]interface{}{
+		"driver":      "json",
+		"path":        s.path,
+		"max_entries": s.maxEntries,
+		"count":       len(lines),
+	}
+	if err != nil {
+		info["error"] = err.Error()
+	}
+	return info
+}
+
+// all decodes every stored run, oldest first.
+func (s *JSONFileResultStore) all() ([]interfaces.CheckResultsInterface, error) {
+	s.mutex.Lock()
+	lines, err := s.readLines()
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]interfaces.CheckResultsInterface, 0, len(lines))
+	for _, line := range lines {
+		results, err := types.UnmarshalCheckResults(line)
+		if err != nil {
+			return nil, fmt.Errorf("health check results file [%s] is corrupted: %w", s.path, err)
+		}
+		snapshots = append(snapshots, results)
+	}
+	return snapshots, nil
+}
+
+// readLines returns the non-empty lines of the file; a missing file has none.
+func (s *JSONFileResultStore) readLines() ([][]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read health check results: %w", err)
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// writeLines replaces the file with lines through a temporary file.
+func (s *JSONFileResultStore) writeLines(lines [][]byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create health check results directory: %w", err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write health check results: %w", err)
+	}
+	defer os.Remove(temp.Name())
+
+	writer := bufio.NewWriter(temp)
+	for _, line := range lines {
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write health check results: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write health check results: %w", err)
+	}
+
+	if err := os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write health check results: %w", err)
+	}
+	return nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ResultStoreInterface = (*JSONFileResultStore)(nil)
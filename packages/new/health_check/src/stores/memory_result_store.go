@@ -0,0 +1,114 @@
+package stores
+
+import (
+	"sync"
+	"time"
+
+	"govel/healthcheck/interfaces"
+)
+
+// InMemoryResultStore keeps the most recent results in a fixed-size ring
+// buffer. History is lost when the process exits; use it for single
+// instances and tests.
+type InMemoryResultStore struct {
+	// snapshots is the ring buffer of stored results
+	snapshots []interfaces.CheckResultsInterface
+
+	// next is the index the next snapshot is written to
+	next int
+
+	// count is the number of stored snapshots
+	count int
+
+	// mutex provides thread-safe access to the buffer
+	mutex sync.RWMutex
+}
+
+// NewInMemoryResultStore creates a store keeping the last capacity runs.
+//
+// Parameters:
+//
+//	capacity: Number of runs to keep, 100 when not positive
+//
+// Returns:
+//
+//	*InMemoryResultStore: A new in-memory store
+func NewInMemoryResultStore(capacity int) *InMemoryResultStore {
+	if capacity <= 0 {
+		capacity = 100
+	}
+	return &InMemoryResultStore{
+		snapshots: make([]interfaces.CheckResultsInterface, capacity),
+	}
+}
+
+// Store adds results to the buffer, evicting the oldest run when full.
+func (s *InMemoryResultStore) Store(results interfaces.CheckResultsInterface) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.snapshots[s.next] = results
+	s.next = (s.next + 1) % len(s.snapshots)
+	if s.count < len(s.snapshots) {
+		s.count++
+	}
+	return nil
+}
+
+// Get returns the latest stored results, nil if none.
+func (s *InMemoryResultStore) Get() (interfaces.CheckResultsInterface, error) {
+	snapshots := s.ordered()
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+// GetByTimestamp returns the results that were current at timestamp: the
+// latest run executed at or before it, nil if none.
+func (s *InMemoryResultStore) GetByTimestamp(timestamp time.Time) (interfaces.CheckResultsInterface, error) {
+	return latestAt(s.ordered(), timestamp), nil
+}
+
+// GetHistory returns the runs executed within from..to, oldest first.
+func (s *InMemoryResultStore) GetHistory(from, to time.Time) ([]interfaces.CheckResultsInterface, error) {
+	return between(s.ordered(), from, to), nil
+}
+
+// Clear removes all stored results.
+func (s *InMemoryResultStore) Clear() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.snapshots = make([]interfaces.CheckResultsInterface, len(s.snapshots))
+	s.next, s.count = 0, 0
+	return nil
+}
+
+// GetStorageInfo returns the driver name, capacity and number of stored runs.
+func (s *InMemoryResultStore) GetStorageInfo() map[string]interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return map[string]interface{}{
+		"driver":   "memory",
+		"capacity": len(s.snapshots),
+		"count":    s.count,
+	}
+}
+
+// ordered returns the stored runs, oldest first.
+func (s *InMemoryResultStore) ordered() []interfaces.CheckResultsInterface {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	snapshots := make([]interfaces.CheckResultsInterface, 0, s.count)
+	start := (s.next - s.count + len(s.snapshots)) % len(s.snapshots)
+	for i := 0; i < s.count; i++ {
+		snapshots = append(snapshots, s.snapshots[(start+i)%len(s.snapshots)])
+	}
+	return snapshots
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ResultStoreInterface = (*InMemoryResultStore)(nil)
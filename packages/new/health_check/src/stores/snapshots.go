@@ -0,0 +1,41 @@
+// Package stores provides result store implementations that persist health
+// check results and keep their history.
+package stores
+
+import (
+	"time"
+
+	"govel/healthcheck/interfaces"
+)
+
+// executedAt returns when results were executed, the zero time if unknown.
+func executedAt(results interfaces.CheckResultsInterface) time.Time {
+	if results == nil || results.GetExecutedAt() == nil {
+		return time.Time{}
+	}
+	return *results.GetExecutedAt()
+}
+
+// latestAt returns the last of the chronologically ordered snapshots that
+// was executed at or before timestamp, nil if none.
+func latestAt(snapshots []interfaces.CheckResultsInterface, timestamp time.Time) interfaces.CheckResultsInterface {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !executedAt(snapshots[i]).After(timestamp) {
+			return snapshots[i]
+		}
+	}
+	return nil
+}
+
+// between returns the chronologically ordered snapshots executed within
+// the inclusive range from..to.
+func between(snapshots []interfaces.CheckResultsInterface, from, to time.Time) []interfaces.CheckResultsInterface {
+	var history []interfaces.CheckResultsInterface
+	for _, snapshot := range snapshots {
+		at := executedAt(snapshot)
+		if !at.Before(from) && !at.After(to) {
+			history = append(history, snapshot)
+		}
+	}
+	return history
+}
@@ -0,0 +1,310 @@
+// Package types provides the result collections of the GoVel health check system.
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+)
+
+// CheckResults is a collection of health check results from one run.
+type CheckResults struct {
+	// results holds the individual check results
+	results []interfaces.ResultInterface
+
+	// executedAt is when the checks were executed
+	executedAt *time.Time
+
+	// mutex provides thread-safe access to the collection
+	mutex sync.RWMutex
+}
+
+// NewCheckResults creates an empty result collection.
+//
+// Returns:
+//
+//	*CheckResults: A new result collection
+func NewCheckResults() *CheckResults {
+	return &CheckResults{}
+}
+
+// NewCheckResultsWithCapacity creates an empty result collection sized for capacity results.
+//
+// Parameters:
+//
+//	capacity: Expected number of results
+//
+// Returns:
+//
+//	*CheckResults: A new result collection
+func NewCheckResultsWithCapacity(capacity int) *CheckResults {
+	return &CheckResults{results: make([]interfaces.ResultInterface, 0, capacity)}
+}
+
+// NewResult creates a new result instance.
+//
+// Returns:
+//
+//	*checks.Result: A new result instance
+func NewResult() *checks.Result {
+	return checks.NewResult()
+}
+
+// GetResults returns all individual health check results.
+func (cr *CheckResults) GetResults() []interfaces.ResultInterface {
+	cr.mutex.RLock()
+	defer cr.mutex.RUnlock()
+
+	return append([]interfaces.ResultInterface(nil), cr.results...)
+}
+
+// AddResult adds a result to the collection.
+func (cr *CheckResults) AddResult(result interfaces.ResultInterface) interfaces.CheckResultsInterface {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	cr.results = append(cr.results, result)
+	return cr
+}
+
+// GetResultByName returns the result of the named check, nil if not found.
+func (cr *CheckResults) GetResultByName(name string) interfaces.ResultInterface {
+	for _, result := range cr.GetResults() {
+		if result.GetCheck() != nil && result.GetCheck().GetName() == name {
+			return result
+		}
+	}
+	return nil
+}
+
+// ContainsFailingCheck returns true if any result failed or crashed.
+func (cr *CheckResults) ContainsFailingCheck() bool {
+	for _, result := range cr.GetResults() {
+		if result.GetStatus() != nil && result.GetStatus().IsFailed() {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsWarningCheck returns true if any result has a warning status.
+func (cr *CheckResults) ContainsWarningCheck() bool {
+	for _, result := range cr.GetResults() {
+		if result.GetStatus() != nil && result.GetStatus().IsWarning() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetOverallStatus returns the worst status among all results, StatusOK when empty.
+func (cr *CheckResults) GetOverallStatus() interfaces.StatusInterface {
+	var worst interfaces.StatusInterface = enums.StatusOK
+	for _, result := range cr.GetResults() {
+		if status := result.GetStatus(); status != nil && status.GetSeverityLevel() > worst.GetSeverityLevel() {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// FilterByStatus returns the results having the given status.
+func (cr *CheckResults) FilterByStatus(status interfaces.StatusInterface) []interfaces.ResultInterface {
+	var filtered []interfaces.ResultInterface
+	for _, result := range cr.GetResults() {
+		if result.GetStatus() != nil && result.GetStatus().String() == status.String() {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// GetTotalDuration returns the sum of all check durations.
+func (cr *CheckResults) GetTotalDuration() time.Duration {
+	var total time.Duration
+	for _, result := range cr.GetResults() {
+		total += result.GetDuration()
+	}
+	return total
+}
+
+// GetExecutedAt returns when the checks were executed.
+func (cr *CheckResults) GetExecutedAt() *time.Time {
+	cr.mutex.RLock()
+	defer cr.mutex.RUnlock()
+
+	return cr.executedAt
+}
+
+// SetExecutedAt sets when the checks were executed.
+func (cr *CheckResults) SetExecutedAt(executedAt time.Time) interfaces.CheckResultsInterface {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	cr.executedAt = &executedAt
+	return cr
+}
+
+// GetHealthSummary returns the number of checks per outcome.
+//
+// Returns:
+//
+//	map[string]interface{}: "total", "healthy", "warnings", "failed" (int)
+//	and "total_duration_ms" (int64)
+func (cr *CheckResults) GetHealthSummary() map[string]interface{} {
+	summary := map[string]interface{}{
+		"total":             0,
+		"healthy":           0,
+		"warnings":          0,
+		"failed":            0,
+		"total_duration_ms": cr.GetTotalDuration().Milliseconds(),
+	}
+
+	for _, result := range cr.GetResults() {
+		summary["total"] = summary["total"].(int) + 1
+
+		status := result.GetStatus()
+		switch {
+		case status == nil:
+		case status.IsHealthy():
+			summary["healthy"] = summary["healthy"].(int) + 1
+		case status.IsWarning():
+			summary["warnings"] = summary["warnings"].(int) + 1
+		case status.IsFailed():
+			summary["failed"] = summary["failed"].(int) + 1
+		}
+	}
+	return summary
+}
+
+// jsonResults is the JSON document of a result collection, following the
+// Laravel Health format with additional timing fields.
+type jsonResults struct {
+	FinishedAt   int64        `json:"finishedAt"`
+	ExecutedAt   *time.Time   `json:"executedAt,omitempty"`
+	CheckResults []jsonResult `json:"checkResults"`
+}
+
+// jsonResult is the JSON document of a single result.
+type jsonResult struct {
+	Name                string                 `json:"name"`
+	Label               string                 `json:"label"`
+	NotificationMessage string                 `json:"notificationMessage"`
+	ShortSummary        string                 `json:"shortSummary"`
+	Status              string                 `json:"status"`
+	Meta                map[string]interface{} `json:"meta"`
+	StartedAt           *time.Time             `json:"startedAt,omitempty"`
+	EndedAt             *time.Time             `json:"endedAt,omitempty"`
+}
+
+// ToJSON converts the results to JSON.
+//
+// Returns:
+//
+//	string: JSON representation of results
+//	error: Any error during JSON marshaling
+func (cr *CheckResults) ToJSON() (string, error) {
+	data, err := MarshalCheckResults(cr)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// MarshalCheckResults encodes any result collection to JSON.
+//
+// Parameters:
+//
+//	results: The results to encode
+//
+// Returns:
+//
+//	[]byte: JSON representation of results
+//	error: Any error during JSON marshaling
+func MarshalCheckResults(results interfaces.CheckResultsInterface) ([]byte, error) {
+	document := jsonResults{
+		ExecutedAt:   results.GetExecutedAt(),
+		CheckResults: make([]jsonResult, 0, len(results.GetResults())),
+	}
+	if document.ExecutedAt != nil {
+		document.FinishedAt = document.ExecutedAt.Add(results.GetTotalDuration()).Unix()
+	}
+
+	for _, result := range results.GetResults() {
+		entry := jsonResult{
+			NotificationMessage: result.GetNotificationMessage(),
+			ShortSummary:        result.GetShortSummary(),
+			Meta:                result.GetMeta(),
+			StartedAt:           result.GetStartedAt(),
+			EndedAt:             result.GetEndedAt(),
+		}
+		if result.GetStatus() != nil {
+			entry.Status = result.GetStatus().String()
+		}
+		if check := result.GetCheck(); check != nil {
+			entry.Name = check.GetName()
+			entry.Label = entry.Name
+			if labeled, ok := check.(interface{ GetLabel() string }); ok {
+				entry.Label = labeled.GetLabel()
+			}
+		}
+		document.CheckResults = append(document.CheckResults, entry)
+	}
+
+	return json.Marshal(document)
+}
+
+// UnmarshalCheckResults decodes results encoded with MarshalCheckResults.
+// The checks of the decoded results are StoredCheck instances.
+//
+// Parameters:
+//
+//	data: JSON representation of results
+//
+// Returns:
+//
+//	*CheckResults: The decoded results
+//	error: Any error during JSON unmarshaling
+func UnmarshalCheckResults(data []byte) (*CheckResults, error) {
+	var document jsonResults
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode health check results: %w", err)
+	}
+
+	results := NewCheckResultsWithCapacity(len(document.CheckResults))
+	switch {
+	case document.ExecutedAt != nil:
+		results.SetExecutedAt(*document.ExecutedAt)
+	case document.FinishedAt > 0:
+		results.SetExecutedAt(time.Unix(document.FinishedAt, 0))
+	}
+
+	for _, entry := range document.CheckResults {
+		result := checks.NewResult()
+		if status, ok := enums.StatusFromString(entry.Status); ok {
+			result.SetStatus(status)
+		}
+		result.SetNotificationMessage(entry.NotificationMessage)
+		result.SetShortSummary(entry.ShortSummary)
+		if entry.Meta != nil {
+			result.SetMeta(entry.Meta)
+		}
+		if entry.StartedAt != nil {
+			result.SetStartedAt(*entry.StartedAt)
+		}
+		if entry.EndedAt != nil {
+			result.SetEndedAt(*entry.EndedAt)
+		}
+		result.SetCheck(NewStoredCheck(entry.Name, entry.Label, result))
+		results.AddResult(result)
+	}
+	return results, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.CheckResultsInterface = (*CheckResults)(nil)
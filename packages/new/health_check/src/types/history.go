@@ -0,0 +1,72 @@
+package types
+
+import (
+	"time"
+
+	"govel/healthcheck/interfaces"
+)
+
+// HistoryEntry is the outcome of one check in one stored run.
+type HistoryEntry struct {
+	// Status is the status of the check
+	Status string `json:"status"`
+
+	// ShortSummary is the brief description of the result
+	ShortSummary string `json:"shortSummary"`
+
+	// NotificationMessage is the notification message of the result
+	NotificationMessage string `json:"notificationMessage,omitempty"`
+
+	// ExecutedAt is when the run was executed
+	ExecutedAt time.Time `json:"executedAt"`
+
+	// DurationMs is the execution time of the check in milliseconds
+	DurationMs int64 `json:"durationMs"`
+}
+
+// BuildHistory arranges stored runs per check, in the order of the runs.
+//
+// Parameters:
+//
+//	snapshots: Stored runs, oldest first
+//	names: Checks to include, all checks when empty
+//
+// Returns:
+//
+//	map[string][]HistoryEntry: Entries keyed by check name
+func BuildHistory(snapshots []interfaces.CheckResultsInterface, names ...string) map[string][]HistoryEntry {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	history := make(map[string][]HistoryEntry)
+	for _, snapshot := range snapshots {
+		var at time.Time
+		if snapshot.GetExecutedAt() != nil {
+			at = *snapshot.GetExecutedAt()
+		}
+
+		for _, result := range snapshot.GetResults() {
+			if result.GetCheck() == nil {
+				continue
+			}
+			name := result.GetCheck().GetName()
+			if len(wanted) > 0 && !wanted[name] {
+				continue
+			}
+
+			entry := HistoryEntry{
+				ShortSummary:        result.GetShortSummary(),
+				NotificationMessage: result.GetNotificationMessage(),
+				ExecutedAt:          at,
+				DurationMs:          result.GetDuration().Milliseconds(),
+			}
+			if result.GetStatus() != nil {
+				entry.Status = result.GetStatus().String()
+			}
+			history[name] = append(history[name], entry)
+		}
+	}
+	return history
+}
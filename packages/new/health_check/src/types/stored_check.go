@@ -0,0 +1,54 @@
+package types
+
+import (
+	"govel/healthcheck/interfaces"
+)
+
+// StoredCheck stands in for the check of a result read back from a result
+// store, where the original check instance is no longer available.
+type StoredCheck struct {
+	// name is the name of the original check
+	name string
+
+	// label is the display label of the original check
+	label string
+
+	// result is the stored result
+	result interfaces.ResultInterface
+}
+
+// NewStoredCheck creates a stand-in for a stored check.
+//
+// Parameters:
+//
+//	name: Name of the original check
+//	label: Display label of the original check, the name when empty
+//	result: The stored result, returned by Run
+//
+// Returns:
+//
+//	*StoredCheck: A new stored check
+func NewStoredCheck(name, label string, result interfaces.ResultInterface) *StoredCheck {
+	if label == "" {
+		label = name
+	}
+	return &StoredCheck{name: name, label: label, result: result}
+}
+
+// Run returns the stored result; stored checks are never executed.
+func (sc *StoredCheck) Run() interfaces.ResultInterface {
+	return sc.result
+}
+
+// GetName returns the name of the original check.
+func (sc *StoredCheck) GetName() string {
+	return sc.name
+}
+
+// GetLabel returns the display label of the original check.
+func (sc *StoredCheck) GetLabel() string {
+	return sc.label
+}
+
+// Compile-time interface compliance check
+var _ interfaces.CheckInterface = (*StoredCheck)(nil)
@@ -0,0 +1,397 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"govel/ignition/models"
+	"govel/ignition/reporters"
+)
+
+type memoryBackend struct {
+	mu     sync.Mutex
+	events []*reporters.Event
+}
+
+func (b *memoryBackend) Name() string { return "memory" }
+
+func (b *memoryBackend) Send(ctx context.Context, event *reporters.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *memoryBackend) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.events)
+}
+
+func newReportable(t *testing.T, line int) *models.ReportableErrorReport {
+	t.Helper()
+
+	frame := models.NewStackFrame()
+	frame.SetFunction("app/users.Create")
+	frame.SetFile("/app/users/service.go")
+	frame.SetLine(line)
+	frame.SetApplicationFrame(true)
+
+	report := models.NewErrorReport()
+	report.SetMessage("user not found")
+	report.SetType("users/service.go")
+	report.SetStack([]models.StackFrame{*frame})
+
+	request := httptest.NewRequest(http.MethodPost, "https://example.com/users?token=abc&page=2", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set("Accept", "application/json")
+	request.PostForm = map[string][]string{"email": {"a@b.c"}, "password": {"hunter2"}}
+
+	return models.NewReportableErrorReport(report, request)
+}
+
+func TestReporterRedactsRequest(t *testing.T) {
+	backend := &memoryBackend{}
+	reporter := reporters.NewReporter(backend)
+
+	if err := reporter.Report(newReportable(t, 10)); err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+
+	event := backend.events[0]
+	if event.Request.Headers["Authorization"] != reporters.RedactedValue {
+		t.Fatalf("authorization header not redacted: %q", event.Request.Headers["Authorization"])
+	}
+	if event.Request.Headers["Accept"] != "application/json" {
+		t.Fatal("expected accept header to be kept")
+	}
+	if strings.Contains(event.Request.URL, "abc") || !strings.Contains(event.Request.URL, "page=2") {
+		t.Fatalf("unexpected url %q", event.Request.URL)
+	}
+	if strings.Contains(event.Request.Body, "hunter2") || !strings.Contains(event.Request.Body, "email=") {
+		t.Fatalf("unexpected body %q", event.Request.Body)
+	}
+}
+
+func TestRedactorRedactsJSONBody(t *testing.T) {
+	body := reporters.NewRedactor().Inputs("pin").RedactBody(`{"user":{"password":"x","pin":"1234","name":"ann"}}`)
+	if strings.Contains(body, `"x"`) || strings.Contains(body, "1234") || !strings.Contains(body, "ann") {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
+
+func TestReporterDeduplicatesAndSamples(t *testing.T) {
+	backend := &memoryBackend{}
+	reporter := reporters.NewReporter(backend).DeduplicateFor(time.Minute)
+
+	_ = reporter.Report(newReportable(t, 10))
+	_ = reporter.Report(newReportable(t, 10))
+	_ = reporter.Report(newReportable(t, 11))
+	if backend.count() != 2 {
+		t.Fatalf("expected 2 deduplicated events, got %d", backend.count())
+	}
+
+	sampled := &memoryBackend{}
+	_ = reporters.NewReporter(sampled).SampleRate(0).Report(newReportable(t, 10))
+	if sampled.count() != 0 {
+		t.Fatal("expected report to be sampled out")
+	}
+
+	disabled := reporters.NewReporter(sampled)
+	disabled.SetEnabled(false)
+	_ = disabled.Report(newReportable(t, 10))
+	if sampled.count() != 0 {
+		t.Fatal("expected disabled reporter to skip")
+	}
+}
+
+// flakyBackend fails the first deliveries
+type flakyBackend struct {
+	memoryBackend
+	failures int
+}
+
+func (b *flakyBackend) Send(ctx context.Context, event *reporters.Event) error {
+	b.mu.Lock()
+	if b.failures > 0 {
+		b.failures--
+		b.mu.Unlock()
+		return errors.New("backend unavailable")
+	}
+	b.mu.Unlock()
+	return b.memoryBackend.Send(ctx, event)
+}
+
+func TestReporterDoesNotDeduplicateFailedReports(t *testing.T) {
+	backend := &flakyBackend{failures: 1}
+	reporter := reporters.NewReporter(backend).DeduplicateFor(time.Minute)
+
+	if err := reporter.Report(newReportable(t, 10)); err == nil {
+		t.Fatal("expected the failed delivery to be returned")
+	}
+	if err := reporter.Report(newReportable(t, 10)); err != nil {
+		t.Fatalf("expected the retry to be delivered, got %v", err)
+	}
+	if backend.count() != 1 {
+		t.Fatalf("expected the retry to reach the backend, got %d events", backend.count())
+	}
+	_ = reporter.Report(newReportable(t, 10))
+	if backend.count() != 1 {
+		t.Fatal("expected delivered reports to be deduplicated")
+	}
+}
+
+// blockingBackend holds deliveries until released
+type blockingBackend struct {
+	memoryBackend
+	release chan struct{}
+}
+
+func (b *blockingBackend) Send(ctx context.Context, event *reporters.Event) error {
+	<-b.release
+	return b.memoryBackend.Send(ctx, event)
+}
+
+func TestAsyncReporterDoesNotDeduplicateDroppedReports(t *testing.T) {
+	backend := &blockingBackend{release: make(chan struct{})}
+	reporter := reporters.NewReporter(backend).Async(1).DeduplicateFor(time.Minute)
+
+	// The worker takes the first report and blocks, the second fills the queue
+	_ = reporter.Report(newReportable(t, 1))
+	deadline := time.Now().Add(time.Second)
+	for reporter.Report(newReportable(t, 2)) == reporters.ErrQueueFull && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := reporter.Report(newReportable(t, 3)); !errors.Is(err, reporters.ErrQueueFull) {
+		t.Fatalf("expected a full queue, got %v", err)
+	}
+
+	close(backend.release)
+	deadline = time.Now().Add(time.Second)
+	for backend.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := reporter.Report(newReportable(t, 3)); err != nil {
+		t.Fatalf("expected the dropped report to be accepted later, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := reporter.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if backend.count() != 3 {
+		t.Fatalf("expected 3 events, got %d", backend.count())
+	}
+}
+
+func TestAsyncReporterDoesNotDeduplicateFailedDeliveries(t *testing.T) {
+	backend := &flakyBackend{failures: 1}
+	failed := make(chan error, 1)
+	reporter := reporters.NewReporter(backend).Async(10).DeduplicateFor(time.Minute).
+		OnError(func(err error) { failed <- err })
+
+	if err := reporter.Report(newReportable(t, 10)); err != nil {
+		t.Fatalf("expected the report to be queued, got %v", err)
+	}
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the delivery to fail")
+	}
+
+	if err := reporter.Report(newReportable(t, 10)); err != nil {
+		t.Fatalf("expected the retry to be queued, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := reporter.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if backend.count() != 1 {
+		t.Fatalf("expected the retry to reach the backend, got %d events", backend.count())
+	}
+}
+
+func TestReporterDeduplicatesConcurrentReports(t *testing.T) {
+	backend := &blockingBackend{release: make(chan struct{})}
+	reporter := reporters.NewReporter(backend).DeduplicateFor(time.Minute)
+
+	const reports = 10
+	var wg sync.WaitGroup
+	returned := make(chan struct{}, reports)
+	for i := 0; i < reports; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = reporter.Report(newReportable(t, 10))
+			returned <- struct{}{}
+		}()
+	}
+
+	// Duplicates return while the first report is being delivered
+	timeout := time.After(time.Second)
+	for i := 0; i < reports-1; i++ {
+		select {
+		case <-returned:
+		case <-timeout:
+			i = reports
+		}
+	}
+	close(backend.release)
+	wg.Wait()
+
+	if backend.count() != 1 {
+		t.Fatalf("expected 1 delivered event, got %d", backend.count())
+	}
+}
+
+func TestReporterToggleIsSafeDuringReports(t *testing.T) {
+	reporter := reporters.NewReporter(&memoryBackend{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(line int) {
+			defer wg.Done()
+			_ = reporter.Report(newReportable(t, line))
+		}(i)
+		go func(enabled bool) {
+			defer wg.Done()
+			reporter.SetEnabled(enabled)
+			_ = reporter.IsEnabled()
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
+
+func TestFingerprintUsesTypeAndTopApplicationFrame(t *testing.T) {
+	first := reporters.Fingerprint(newReportable(t, 10))
+	if first != reporters.Fingerprint(newReportable(t, 10)) {
+		t.Fatal("expected stable fingerprint")
+	}
+	if first == reporters.Fingerprint(newReportable(t, 11)) {
+		t.Fatal("expected different fingerprint for another frame")
+	}
+}
+
+func TestAsyncReporterFlushesOnShutdown(t *testing.T) {
+	backend := &memoryBackend{}
+	reporter := reporters.NewReporter(backend).Async(10)
+
+	for line := 0; line < 5; line++ {
+		if err := reporter.Report(newReportable(t, line)); err != nil {
+			t.Fatalf("report failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := reporter.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if backend.count() != 5 {
+		t.Fatalf("expected 5 flushed events, got %d", backend.count())
+	}
+	if err := reporter.Report(newReportable(t, 1)); !errors.Is(err, reporters.ErrReporterClosed) {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+}
+
+func TestFileReporterWritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "errors.jsonl")
+	reporter := reporters.NewReporter(reporters.NewFileBackend(path))
+
+	_ = reporter.Report(newReportable(t, 10))
+	_ = reporter.Report(newReportable(t, 11))
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event reporters.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid line: %v", err)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Fatalf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestSentryReporterSendsEnvelope(t *testing.T) {
+	var (
+		path, auth string
+		body       []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("X-Sentry-Auth")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://public@", 1) + "/42"
+	backend, err := reporters.NewSentryBackend(dsn)
+	if err != nil {
+		t.Fatalf("invalid dsn: %v", err)
+	}
+
+	if err := reporters.NewReporter(backend.Environment("testing")).Report(newReportable(t, 10)); err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+
+	if path != "/api/42/envelope/" {
+		t.Fatalf("unexpected path %q", path)
+	}
+	if !strings.Contains(auth, "sentry_key=public") {
+		t.Fatalf("unexpected auth header %q", auth)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 envelope lines, got %d", len(lines))
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(lines[2], &event); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if event["environment"] != "testing" || event["platform"] != "go" {
+		t.Fatalf("unexpected event %v", event)
+	}
+	if strings.Contains(string(lines[2]), "hunter2") || strings.Contains(string(lines[2]), "Bearer secret") {
+		t.Fatal("expected sensitive data to be redacted")
+	}
+}
+
+func TestWebhookReporterReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := reporters.NewReporter(reporters.NewWebhookBackend(server.URL)).Report(newReportable(t, 10))
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"govel/ignition/handlers"
+)
+
+type tracedError struct {
+	trace []string
+}
+
+func (e *tracedError) Error() string { return "traced" }
+
+func (e *tracedError) GetStackTrace() []string { return e.trace }
+
+type callersError struct {
+	pcs []uintptr
+}
+
+func (e *callersError) Error() string { return "callers" }
+
+func (e *callersError) Callers() []uintptr { return e.pcs }
+
+func newCallersError() error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs)
+	return &callersError{pcs: pcs[:n]}
+}
+
+func TestStackTraceFromExceptionTrace(t *testing.T) {
+	err := &tracedError{trace: []string{
+		"/app/users/service.go:42 app/users.(*Service).Create",
+		"/root/go/pkg/mod/github.com/lib/pq@v1.10.0/conn.go:10 github.com/lib/pq.(*conn).Exec",
+	}}
+
+	frames := handlers.NewStackTraceBuilder("/app").Build(err)
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+	if frames[0].GetFile() != "/app/users/service.go" || frames[0].GetLine() != 42 {
+		t.Fatalf("unexpected first frame %s:%d", frames[0].GetFile(), frames[0].GetLine())
+	}
+	if frames[0].GetFunction() != "app/users.(*Service).Create" {
+		t.Fatalf("unexpected function %q", frames[0].GetFunction())
+	}
+	if !frames[0].IsApplicationFrame() {
+		t.Fatal("expected application frame")
+	}
+	if frames[1].IsApplicationFrame() {
+		t.Fatal("expected vendor frame")
+	}
+}
+
+func TestStackTraceUsesDeepestWrappedError(t *testing.T) {
+	inner := &tracedError{trace: []string{"/app/inner.go:7 app.inner"}}
+	outer := &tracedError{trace: []string{"/app/outer.go:3 app.outer"}}
+	err := fmt.Errorf("outer: %w", errors.Join(outer, fmt.Errorf("inner: %w", inner)))
+
+	frames := handlers.NewStackTraceBuilder("/app").Build(err)
+	if len(frames) != 1 || frames[0].GetFile() != "/app/inner.go" {
+		t.Fatalf("expected inner frame, got %+v", frames)
+	}
+}
+
+func TestStackTraceFromCallers(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", newCallersError())
+
+	frames := handlers.NewStackTraceBuilder("").Build(err)
+	if len(frames) == 0 {
+		t.Fatal("expected frames")
+	}
+	if !strings.HasSuffix(frames[0].GetFunction(), "newCallersError") {
+		t.Fatalf("expected origin frame first, got %q", frames[0].GetFunction())
+	}
+}
+
+func TestStackTraceFromPanic(t *testing.T) {
+	var err error
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = handlers.NewPanicError(recovered)
+			}
+		}()
+		panicking()
+	}()
+
+	if err.Error() != "boom" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+
+	frames := handlers.NewStackTraceBuilder("").Build(err)
+	if len(frames) == 0 {
+		t.Fatal("expected frames")
+	}
+	if !strings.HasSuffix(frames[0].GetFunction(), "panicking") {
+		t.Fatalf("expected panicking frame first, got %q", frames[0].GetFunction())
+	}
+}
+
+func panicking() {
+	panic("boom")
+}
+
+func TestStackTraceFallsBackToCaller(t *testing.T) {
+	frames := handlers.NewStackTraceBuilder("").Build(errors.New("plain"))
+	if len(frames) == 0 {
+		t.Fatal("expected frames")
+	}
+	if !strings.Contains(frames[0].GetFunction(), "TestStackTraceFallsBackToCaller") {
+		t.Fatalf("expected test frame first, got %q", frames[0].GetFunction())
+	}
+}
@@ -0,0 +1,55 @@
+package constants
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// UnixPathSeparator is the path separator used in stack frame paths
+const UnixPathSeparator = "/"
+
+// goRootSource is the standard library source directory of the toolchain
+var goRootSource = filepath.ToSlash(filepath.Join(runtime.GOROOT(), "src")) + UnixPathSeparator
+
+// IsGoStdLibPath returns true if the file belongs to the Go standard library
+func IsGoStdLibPath(file string) bool {
+	file = filepath.ToSlash(file)
+	if runtime.GOROOT() != "" && strings.HasPrefix(file, goRootSource) {
+		return true
+	}
+	return strings.Contains(file, "/go/src/runtime/") || strings.HasPrefix(file, "runtime/")
+}
+
+// IsThirdPartyPath returns true if the file comes from a dependency, either
+// the module cache or a vendor directory
+func IsThirdPartyPath(file string) bool {
+	file = filepath.ToSlash(file)
+	return strings.Contains(file, "/pkg/mod/") ||
+		strings.Contains(file, "/vendor/") ||
+		strings.HasPrefix(file, "vendor/")
+}
+
+// IsApplicationPath returns true if the file is neither standard library
+// nor a dependency
+func IsApplicationPath(file string) bool {
+	return file != "" && !IsGoStdLibPath(file) && !IsThirdPartyPath(file)
+}
+
+// IsApplicationFrame classifies a frame file as application or vendor code.
+// With an application path, only files below it that are not vendored
+// count as application frames; without one IsApplicationPath decides.
+func IsApplicationFrame(file, applicationPath string) bool {
+	if applicationPath == "" {
+		return IsApplicationPath(file)
+	}
+
+	root := strings.TrimSuffix(filepath.ToSlash(filepath.Clean(applicationPath)), UnixPathSeparator) + UnixPathSeparator
+	file = filepath.ToSlash(file)
+	if !strings.HasPrefix(file, root) {
+		return false
+	}
+
+	relative := strings.TrimPrefix(file, root)
+	return !strings.HasPrefix(relative, "vendor/") && !IsThirdPartyPath(relative)
+}
@@ -0,0 +1,41 @@
+package enums
+
+// ArchType is the processor architecture the application runs on.
+type ArchType string
+
+const (
+	ArchAMD64   ArchType = "amd64"
+	ArchARM64   ArchType = "arm64"
+	Arch386     ArchType = "386"
+	ArchARM     ArchType = "arm"
+	ArchUnknown ArchType = "unknown"
+)
+
+// ParseArchType converts a GOARCH value to an ArchType
+func ParseArchType(goarch string) ArchType {
+	switch arch := ArchType(goarch); arch {
+	case ArchAMD64, ArchARM64, Arch386, ArchARM:
+		return arch
+	}
+	return ArchUnknown
+}
+
+// String returns the architecture name
+func (a ArchType) String() string {
+	return string(a)
+}
+
+// DisplayName returns a human readable architecture name
+func (a ArchType) DisplayName() string {
+	switch a {
+	case ArchAMD64:
+		return "x86-64"
+	case ArchARM64:
+		return "ARM64"
+	case Arch386:
+		return "x86"
+	case ArchARM:
+		return "ARM"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,42 @@
+package enums
+
+// Editor is the editor the error page links files to.
+// The values match the editor options of the Ignition frontend.
+type Editor string
+
+const (
+	EditorClipboard            Editor = "clipboard"
+	EditorSublime              Editor = "sublime"
+	EditorTextMate             Editor = "textmate"
+	EditorEmacs                Editor = "emacs"
+	EditorMacVim               Editor = "macvim"
+	EditorPhpStorm             Editor = "phpstorm"
+	EditorPhpStormRemote       Editor = "phpstorm-remote"
+	EditorIdea                 Editor = "idea"
+	EditorVSCode               Editor = "vscode"
+	EditorVSCodeInsiders       Editor = "vscode-insiders"
+	EditorVSCodeRemote         Editor = "vscode-remote"
+	EditorVSCodeInsidersRemote Editor = "vscode-insiders-remote"
+	EditorVSCodium             Editor = "vscodium"
+	EditorCursor               Editor = "cursor"
+	EditorAtom                 Editor = "atom"
+	EditorNova                 Editor = "nova"
+	EditorNetBeans             Editor = "netbeans"
+)
+
+// String returns the editor name
+func (e Editor) String() string {
+	return string(e)
+}
+
+// IsValid returns true if the editor is supported
+func (e Editor) IsValid() bool {
+	switch e {
+	case EditorClipboard, EditorSublime, EditorTextMate, EditorEmacs, EditorMacVim,
+		EditorPhpStorm, EditorPhpStormRemote, EditorIdea, EditorVSCode, EditorVSCodeInsiders,
+		EditorVSCodeRemote, EditorVSCodeInsidersRemote, EditorVSCodium, EditorCursor,
+		EditorAtom, EditorNova, EditorNetBeans:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package enums
+
+// OSType is the operating system the application runs on.
+type OSType string
+
+const (
+	OSLinux   OSType = "linux"
+	OSDarwin  OSType = "darwin"
+	OSWindows OSType = "windows"
+	OSFreeBSD OSType = "freebsd"
+	OSUnknown OSType = "unknown"
+)
+
+// ParseOSType converts a GOOS value to an OSType
+func ParseOSType(goos string) OSType {
+	switch os := OSType(goos); os {
+	case OSLinux, OSDarwin, OSWindows, OSFreeBSD:
+		return os
+	}
+	return OSUnknown
+}
+
+// String returns the operating system name
+func (o OSType) String() string {
+	return string(o)
+}
+
+// DisplayName returns a human readable operating system name
+func (o OSType) DisplayName() string {
+	switch o {
+	case OSLinux:
+		return "Linux"
+	case OSDarwin:
+		return "macOS"
+	case OSWindows:
+		return "Windows"
+	case OSFreeBSD:
+		return "FreeBSD"
+	}
+	return "Unknown"
+}
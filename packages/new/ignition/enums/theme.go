@@ -0,0 +1,29 @@
+package enums
+
+// Theme is the color scheme of the error page.
+type Theme string
+
+const (
+	// ThemeAuto follows the operating system preference
+	ThemeAuto Theme = "auto"
+
+	// ThemeLight is the light color scheme
+	ThemeLight Theme = "light"
+
+	// ThemeDark is the dark color scheme
+	ThemeDark Theme = "dark"
+)
+
+// String returns the theme name
+func (t Theme) String() string {
+	return string(t)
+}
+
+// IsValid returns true if the theme is supported
+func (t Theme) IsValid() bool {
+	switch t {
+	case ThemeAuto, ThemeLight, ThemeDark:
+		return true
+	}
+	return false
+}
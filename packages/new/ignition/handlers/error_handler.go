@@ -1,9 +1,9 @@
 package handlers
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
-	"runtime"
 	"strings"
 	"time"
 
@@ -23,6 +23,8 @@ type ErrorHandler struct {
 	renderer          *renderer.HTMLRenderer
 	customHTMLHead    string
 	customHTMLBody    string
+	reporters         []interfaces.ReporterInterface
+	onReportError     func(reporter string, err error)
 }
 
 // NewErrorHandler creates a new error handler
@@ -32,6 +34,7 @@ func NewErrorHandler(cfg *config.Config) *ErrorHandler {
 		shouldDisplay:     true,
 		middleware:        []interfaces.MiddlewareInterface{},
 		solutionProviders: []interfaces.SolutionProviderInterface{},
+		reporters:         []interfaces.ReporterInterface{},
 		renderer:          renderer.NewHTMLRenderer(),
 	}
 }
@@ -72,17 +75,75 @@ func (h *ErrorHandler) RegisterMiddleware(middleware []interfaces.MiddlewareInte
 	return h
 }
 
-// HandleError handles an error and renders the error page
+// AddReporters adds reporters receiving every handled error
+func (h *ErrorHandler) AddReporters(reporters []interfaces.ReporterInterface) *ErrorHandler {
+	h.reporters = append(h.reporters, reporters...)
+	return h
+}
+
+// OnReportError sets the callback receiving reporter failures
+func (h *ErrorHandler) OnReportError(fn func(reporter string, err error)) *ErrorHandler {
+	h.onReportError = fn
+	return h
+}
+
+// HandleError handles an error, reports it and renders the error page
 func (h *ErrorHandler) HandleError(err error, w http.ResponseWriter, r *http.Request) {
-	if !h.shouldDisplay {
+	if !h.shouldDisplay && len(h.reporters) == 0 {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
 	report := h.CreateReport(err, r)
+	h.sendReport(report, r)
+
+	if !h.shouldDisplay {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
 	h.renderer.RenderErrorPage(report, w, r, h.config, h.applicationPath, h.customHTMLHead, h.customHTMLBody)
 }
 
+// ReportError creates a report for the error and sends it to the reporters
+// without rendering anything
+func (h *ErrorHandler) ReportError(err error, r *http.Request) {
+	if len(h.reporters) == 0 {
+		return
+	}
+	h.sendReport(h.CreateReport(err, r), r)
+}
+
+// Shutdown flushes reporters that deliver asynchronously
+func (h *ErrorHandler) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, reporter := range h.reporters {
+		if closer, ok := reporter.(interface{ Shutdown(context.Context) error }); ok {
+			if err := closer.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sendReport sends the report to every enabled reporter
+func (h *ErrorHandler) sendReport(report *models.ErrorReport, r *http.Request) {
+	if len(h.reporters) == 0 {
+		return
+	}
+
+	reportable := models.NewReportableErrorReport(report, r)
+	for _, reporter := range h.reporters {
+		if !reporter.IsEnabled() {
+			continue
+		}
+		if err := reporter.Report(reportable); err != nil && h.onReportError != nil {
+			h.onReportError(reporter.GetName(), err)
+		}
+	}
+}
+
 // CreateReport creates a structured error report
 func (h *ErrorHandler) CreateReport(err error, r *http.Request) *models.ErrorReport {
 	stack := h.buildStackTrace(err)
@@ -110,39 +171,10 @@ func (h *ErrorHandler) CreateReport(err error, r *http.Request) *models.ErrorRep
 	return report
 }
 
-// buildStackTrace builds a detailed stack trace
+// buildStackTrace builds a detailed stack trace from the error itself,
+// falling back to the current stack when the error carries none
 func (h *ErrorHandler) buildStackTrace(err error) []models.StackFrame {
-	var frames []models.StackFrame
-
-	// Get the current stack
-	pcs := make([]uintptr, 50)
-	n := runtime.Callers(0, pcs)
-
-	for idx := 0; idx < n; idx++ {
-		pc := pcs[idx]
-		fn := runtime.FuncForPC(pc)
-		if fn == nil {
-			continue
-		}
-
-		file, line := fn.FileLine(pc)
-
-		// Skip internal Go files and our own stack frames
-		if strings.Contains(file, "runtime/") ||
-			strings.Contains(file, "error_handler.go") {
-			continue
-		}
-
-		frame := models.NewStackFrame()
-		frame.SetFunction(fn.Name())
-		frame.SetFile(file)
-		frame.SetLine(line)
-		frame.SetCode(h.getSourceCode(file, line))
-
-		frames = append(frames, *frame)
-	}
-
-	return frames
+	return NewStackTraceBuilder(h.applicationPath).Build(err)
 }
 
 // getSourceCode retrieves source code around the error line
@@ -211,13 +243,7 @@ func (h *ErrorHandler) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if recovered := recover(); recovered != nil {
-				var err error
-				if e, ok := recovered.(error); ok {
-					err = e
-				} else {
-					err = fmt.Errorf("%v", recovered)
-				}
-				h.HandleError(err, w, r)
+				h.HandleError(NewPanicError(recovered), w, r)
 			}
 		}()
 
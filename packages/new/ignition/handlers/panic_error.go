@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError wraps a recovered panic value together with the stack of the
+// goroutine that panicked
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// NewPanicError creates a new panic error, capturing the current goroutine
+// stack. It must be called from the deferred function that recovered.
+func NewPanicError(recovered interface{}) *PanicError {
+	return &PanicError{
+		Value: recovered,
+		Stack: debug.Stack(),
+	}
+}
+
+// Error returns the panic message
+func (p *PanicError) Error() string {
+	if err, ok := p.Value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprintf("%v", p.Value)
+}
+
+// Unwrap returns the recovered value when it is an error
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
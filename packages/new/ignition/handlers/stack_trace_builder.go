@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"govel/ignition/constants"
+	"govel/ignition/interfaces"
+	"govel/ignition/models"
+)
+
+// maxStackDepth limits the number of frames captured from the runtime
+const maxStackDepth = 64
+
+// exceptionFramePattern matches "file:line function" lines produced by
+// exceptions.Exception.GetStackTrace
+var exceptionFramePattern = regexp.MustCompile(`^(.+):(\d+)\s+(.+)$`)
+
+// goroutineFilePattern matches the file line of a goroutine stack dump
+var goroutineFilePattern = regexp.MustCompile(`^\s+(.+):(\d+)(?:\s+\+0x[0-9a-f]+)?$`)
+
+// stackTracer is implemented by errors exposing their stack as strings,
+// such as exceptions.Exception
+type stackTracer interface {
+	GetStackTrace() []string
+}
+
+// callersProvider is implemented by errors exposing raw program counters
+type callersProvider interface {
+	Callers() []uintptr
+}
+
+// rawFrame is a frame before source code and classification are attached
+type rawFrame struct {
+	function string
+	file     string
+	line     int
+}
+
+// StackTraceBuilder extracts stack frames from errors
+type StackTraceBuilder struct {
+	applicationPath string
+	contextLines    int
+}
+
+// NewStackTraceBuilder creates a new stack trace builder
+func NewStackTraceBuilder(applicationPath string) *StackTraceBuilder {
+	return &StackTraceBuilder{
+		applicationPath: applicationPath,
+		contextLines:    5,
+	}
+}
+
+// SetApplicationPath sets the root used to classify application frames
+func (b *StackTraceBuilder) SetApplicationPath(path string) *StackTraceBuilder {
+	b.applicationPath = path
+	return b
+}
+
+// SetContextLines sets the number of context lines to include around each frame
+func (b *StackTraceBuilder) SetContextLines(lines int) {
+	b.contextLines = lines
+}
+
+// GetContextLines returns the number of context lines
+func (b *StackTraceBuilder) GetContextLines() int {
+	return b.contextLines
+}
+
+// ShouldSkipFrame returns true for runtime internals that never help
+// locating an error
+func (b *StackTraceBuilder) ShouldSkipFrame(function string) bool {
+	return function == "" ||
+		strings.HasPrefix(function, "runtime.") ||
+		strings.HasPrefix(function, "runtime/debug.")
+}
+
+// BuildStackTrace builds a stack trace from the given error
+func (b *StackTraceBuilder) BuildStackTrace(err error) []interfaces.StackFrameInterface {
+	frames := b.Build(err)
+	result := make([]interfaces.StackFrameInterface, 0, len(frames))
+	for idx := range frames {
+		result = append(result, &frames[idx])
+	}
+	return result
+}
+
+// Build extracts the frames of the error, preferring the deepest error in
+// the wrap chain that carries its own stack. Errors without any stack fall
+// back to the stack of the caller.
+func (b *StackTraceBuilder) Build(err error) []models.StackFrame {
+	raw := b.extract(err)
+	if len(raw) == 0 {
+		raw = b.callerFrames()
+	}
+
+	extractor := NewSourceCodeExtractor(b.contextLines)
+	frames := make([]models.StackFrame, 0, len(raw))
+	for _, r := range raw {
+		if b.ShouldSkipFrame(r.function) {
+			continue
+		}
+
+		frame := models.NewStackFrame()
+		frame.SetFunction(r.function)
+		frame.SetFile(r.file)
+		frame.SetLine(r.line)
+		frame.SetCode(extractor.ExtractSourceCode(r.file, r.line))
+		frame.SetApplicationFrame(constants.IsApplicationFrame(r.file, b.applicationPath))
+
+		frames = append(frames, *frame)
+	}
+
+	return frames
+}
+
+// extract walks the wrap chain and returns the frames of the deepest error
+// carrying a stack
+func (b *StackTraceBuilder) extract(err error) []rawFrame {
+	var (
+		best      []rawFrame
+		bestDepth = -1
+	)
+
+	var walk func(e error, depth int)
+	walk = func(e error, depth int) {
+		if e == nil {
+			return
+		}
+		if frames := framesOf(e); len(frames) > 0 && depth > bestDepth {
+			best, bestDepth = frames, depth
+		}
+
+		switch wrapped := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapped.Unwrap() {
+				walk(inner, depth+1)
+			}
+		case interface{ Unwrap() error }:
+			walk(wrapped.Unwrap(), depth+1)
+		}
+	}
+	walk(err, 0)
+
+	return best
+}
+
+// callerFrames captures the current stack, skipping the error handler itself
+func (b *StackTraceBuilder) callerFrames() []rawFrame {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(1, pcs)
+
+	var frames []rawFrame
+	for _, frame := range framesFromPCs(pcs[:n]) {
+		if strings.HasPrefix(frame.function, "govel/ignition/handlers.") {
+			continue
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// framesOf returns the frames carried by a single error, without unwrapping
+func framesOf(err error) []rawFrame {
+	switch e := err.(type) {
+	case *PanicError:
+		return parseGoroutineStack(e.Stack)
+	case stackTracer:
+		return parseExceptionTrace(e.GetStackTrace())
+	case callersProvider:
+		return framesFromPCs(e.Callers())
+	}
+
+	return framesFromStackTraceMethod(err)
+}
+
+// framesFromStackTraceMethod supports errors created by github.com/pkg/errors
+// and compatible packages, whose StackTrace method returns a slice of
+// uintptr-based frames
+func framesFromStackTraceMethod(err error) []rawFrame {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	pcs := make([]uintptr, trace.Len())
+	for idx := range pcs {
+		// pkg/errors stores pc+1 in each frame
+		pcs[idx] = uintptr(trace.Index(idx).Uint()) - 1
+	}
+
+	return framesFromPCs(pcs)
+}
+
+// framesFromPCs resolves program counters into frames
+func framesFromPCs(pcs []uintptr) []rawFrame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var frames []rawFrame
+	iterator := runtime.CallersFrames(pcs)
+	for {
+		frame, more := iterator.Next()
+		if frame.Function != "" || frame.File != "" {
+			frames = append(frames, rawFrame{
+				function: frame.Function,
+				file:     frame.File,
+				line:     frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// parseExceptionTrace parses "file:line function" lines
+func parseExceptionTrace(lines []string) []rawFrame {
+	var frames []rawFrame
+	for _, line := range lines {
+		matches := exceptionFramePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		lineNumber, _ := strconv.Atoi(matches[2])
+		frames = append(frames, rawFrame{
+			function: matches[3],
+			file:     matches[1],
+			line:     lineNumber,
+		})
+	}
+	return frames
+}
+
+// parseGoroutineStack parses a runtime/debug.Stack dump and returns the
+// frames below the panic call, i.e. where the panic happened
+func parseGoroutineStack(stack []byte) []rawFrame {
+	var (
+		frames   []rawFrame
+		function string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(stack))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "\t") {
+			function = parseGoroutineFunction(line)
+			continue
+		}
+
+		matches := goroutineFilePattern.FindStringSubmatch(line)
+		if matches == nil || function == "" {
+			continue
+		}
+
+		lineNumber, _ := strconv.Atoi(matches[2])
+		frames = append(frames, rawFrame{
+			function: function,
+			file:     matches[1],
+			line:     lineNumber,
+		})
+		function = ""
+	}
+
+	for idx := len(frames) - 1; idx >= 0; idx-- {
+		if frames[idx].function == "panic" {
+			return frames[idx+1:]
+		}
+	}
+	return frames
+}
+
+// parseGoroutineFunction strips call arguments and "created by" prefixes
+// from a function line of a goroutine dump
+func parseGoroutineFunction(line string) string {
+	if strings.HasPrefix(line, "created by ") {
+		line = strings.TrimPrefix(line, "created by ")
+		if idx := strings.Index(line, " in goroutine "); idx >= 0 {
+			line = line[:idx]
+		}
+		return line
+	}
+
+	if strings.HasSuffix(line, ")") {
+		if idx := strings.LastIndex(line, "("); idx > 0 {
+			line = line[:idx]
+		}
+	}
+	return line
+}
+
+var _ interfaces.StackTraceBuilderInterface = (*StackTraceBuilder)(nil)
@@ -1,6 +1,7 @@
 package ignition
 
 import (
+	"context"
 	"net/http"
 
 	"govel/ignition/config"
@@ -80,6 +81,28 @@ func (i *Ignition) RegisterMiddleware(middleware []interfaces.MiddlewareInterfac
 	return i
 }
 
+// AddReporters adds reporters receiving every handled error
+func (i *Ignition) AddReporters(reporters []interfaces.ReporterInterface) *Ignition {
+	i.handler.AddReporters(reporters)
+	return i
+}
+
+// OnReportError sets the callback receiving reporter failures
+func (i *Ignition) OnReportError(fn func(reporter string, err error)) *Ignition {
+	i.handler.OnReportError(fn)
+	return i
+}
+
+// ReportError sends an error to the reporters without rendering anything
+func (i *Ignition) ReportError(err error, r *http.Request) {
+	i.handler.ReportError(err, r)
+}
+
+// Shutdown flushes reporters that deliver asynchronously
+func (i *Ignition) Shutdown(ctx context.Context) error {
+	return i.handler.Shutdown(ctx)
+}
+
 // HandleError handles an error and renders the error page
 func (i *Ignition) HandleError(err error, w http.ResponseWriter, r *http.Request) {
 	i.handler.HandleError(err, w, r)
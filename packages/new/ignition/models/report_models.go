@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
-	"strings"
 	"time"
+
+	"govel/ignition/constants"
 )
 
 // CompleteStackFrame represents a complete stack frame with all required fields
@@ -111,7 +112,7 @@ func CreateCompleteReport(errorReport *ErrorReport, req *http.Request, applicati
 			Class:            nil,
 			CodeSnippet:      frame.GetCode(),
 			Arguments:        []interface{}{},
-			ApplicationFrame: isApplicationFrame(frame.GetFile()),
+			ApplicationFrame: constants.IsApplicationFrame(frame.GetFile(), applicationPath),
 		})
 	}
 
@@ -215,13 +216,6 @@ func ensureSolutionsArray(solutions []Solution) []Solution {
 	return solutions
 }
 
-func isApplicationFrame(file string) bool {
-	// Consider a frame as application frame if it's not in Go runtime or standard library
-	return !strings.Contains(file, "runtime/") &&
-		!strings.Contains(file, "/go") &&
-		!strings.Contains(file, "vendor/")
-}
-
 func generateUUID() string {
 	// Simple UUID generation - in production you'd use a proper UUID library
 	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), time.Now().Unix())
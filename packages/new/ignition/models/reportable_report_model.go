@@ -0,0 +1,223 @@
+package models
+
+import (
+	"net/http"
+	"time"
+
+	"govel/ignition/interfaces"
+)
+
+// ReportContext is the error context handed to reporters
+type ReportContext struct {
+	Request     interfaces.RequestContextInterface `json:"request,omitempty"`
+	Environment interfaces.EnvContextInterface     `json:"environment,omitempty"`
+	User        interface{}                        `json:"user,omitempty"`
+}
+
+// GetRequest returns the request context
+func (c *ReportContext) GetRequest() interfaces.RequestContextInterface {
+	return c.Request
+}
+
+// SetRequest sets the request context
+func (c *ReportContext) SetRequest(request interfaces.RequestContextInterface) {
+	c.Request = request
+}
+
+// GetEnvironment returns the environment context
+func (c *ReportContext) GetEnvironment() interfaces.EnvContextInterface {
+	return c.Environment
+}
+
+// SetEnvironment sets the environment context
+func (c *ReportContext) SetEnvironment(environment interfaces.EnvContextInterface) {
+	c.Environment = environment
+}
+
+// GetUser returns the user information
+func (c *ReportContext) GetUser() interface{} {
+	return c.User
+}
+
+// SetUser sets the user information
+func (c *ReportContext) SetUser(user interface{}) {
+	c.User = user
+}
+
+// HasRequest returns true if request context is available
+func (c *ReportContext) HasRequest() bool {
+	return c.Request != nil
+}
+
+// HasEnvironment returns true if environment context is available
+func (c *ReportContext) HasEnvironment() bool {
+	return c.Environment != nil
+}
+
+// HasUser returns true if user information is available
+func (c *ReportContext) HasUser() bool {
+	return c.User != nil
+}
+
+// IsEmpty returns true if the context is empty
+func (c *ReportContext) IsEmpty() bool {
+	return c.Request == nil && c.Environment == nil && c.User == nil
+}
+
+// ReportableErrorReport is the interface based view of an ErrorReport
+// consumed by reporters
+type ReportableErrorReport struct {
+	message   string
+	errorType string
+	file      string
+	line      int
+	stack     []interfaces.StackFrameInterface
+	context   interfaces.ErrorContextInterface
+	solutions []interfaces.SolutionInterface
+	timestamp time.Time
+}
+
+// NewReportableErrorReport creates a reportable copy of the report,
+// attaching a snapshot of the request
+func NewReportableErrorReport(report *ErrorReport, r *http.Request) *ReportableErrorReport {
+	reportable := &ReportableErrorReport{
+		message:   report.GetMessage(),
+		errorType: report.GetType(),
+		file:      report.GetFile(),
+		line:      report.GetLine(),
+		timestamp: report.GetTimestamp(),
+	}
+
+	for idx := range report.Stack {
+		frame := report.Stack[idx]
+		reportable.stack = append(reportable.stack, &frame)
+	}
+	for idx := range report.Solutions {
+		solution := report.Solutions[idx]
+		reportable.solutions = append(reportable.solutions, &solution)
+	}
+
+	context := &ReportContext{User: report.Context.GetUser()}
+	if r != nil {
+		context.SetRequest(NewRequestSnapshot(r))
+	}
+	if report.Context.Environment != nil {
+		context.SetEnvironment(report.Context.Environment)
+	} else {
+		context.SetEnvironment(NewEnvContext())
+	}
+	reportable.context = context
+
+	return reportable
+}
+
+// GetMessage returns the error message
+func (e *ReportableErrorReport) GetMessage() string {
+	return e.message
+}
+
+// SetMessage sets the error message
+func (e *ReportableErrorReport) SetMessage(message string) {
+	e.message = message
+}
+
+// GetType returns the error type
+func (e *ReportableErrorReport) GetType() string {
+	return e.errorType
+}
+
+// SetType sets the error type
+func (e *ReportableErrorReport) SetType(errorType string) {
+	e.errorType = errorType
+}
+
+// GetFile returns the file where the error occurred
+func (e *ReportableErrorReport) GetFile() string {
+	return e.file
+}
+
+// SetFile sets the file where the error occurred
+func (e *ReportableErrorReport) SetFile(file string) {
+	e.file = file
+}
+
+// GetLine returns the line number where the error occurred
+func (e *ReportableErrorReport) GetLine() int {
+	return e.line
+}
+
+// SetLine sets the line number where the error occurred
+func (e *ReportableErrorReport) SetLine(line int) {
+	e.line = line
+}
+
+// GetStack returns the stack trace
+func (e *ReportableErrorReport) GetStack() []interfaces.StackFrameInterface {
+	return e.stack
+}
+
+// SetStack sets the stack trace
+func (e *ReportableErrorReport) SetStack(stack []interfaces.StackFrameInterface) {
+	e.stack = stack
+}
+
+// AddStackFrame adds a frame to the stack trace
+func (e *ReportableErrorReport) AddStackFrame(frame interfaces.StackFrameInterface) {
+	e.stack = append(e.stack, frame)
+}
+
+// GetContext returns the error context
+func (e *ReportableErrorReport) GetContext() interfaces.ErrorContextInterface {
+	return e.context
+}
+
+// SetContext sets the error context
+func (e *ReportableErrorReport) SetContext(context interfaces.ErrorContextInterface) {
+	e.context = context
+}
+
+// GetSolutions returns the list of solutions
+func (e *ReportableErrorReport) GetSolutions() []interfaces.SolutionInterface {
+	return e.solutions
+}
+
+// SetSolutions sets the list of solutions
+func (e *ReportableErrorReport) SetSolutions(solutions []interfaces.SolutionInterface) {
+	e.solutions = solutions
+}
+
+// AddSolution adds a solution to the report
+func (e *ReportableErrorReport) AddSolution(solution interfaces.SolutionInterface) {
+	e.solutions = append(e.solutions, solution)
+}
+
+// GetTimestamp returns the timestamp when the error occurred
+func (e *ReportableErrorReport) GetTimestamp() time.Time {
+	return e.timestamp
+}
+
+// SetTimestamp sets the timestamp when the error occurred
+func (e *ReportableErrorReport) SetTimestamp(timestamp time.Time) {
+	e.timestamp = timestamp
+}
+
+// IsEmpty returns true if the error report is empty
+func (e *ReportableErrorReport) IsEmpty() bool {
+	return e.message == "" && e.errorType == ""
+}
+
+// HasSolutions returns true if the report has solutions
+func (e *ReportableErrorReport) HasSolutions() bool {
+	return len(e.solutions) > 0
+}
+
+// GetStackFrameCount returns the number of stack frames
+func (e *ReportableErrorReport) GetStackFrameCount() int {
+	return len(e.stack)
+}
+
+// Compile-time interface compliance checks
+var (
+	_ interfaces.ErrorContextInterface = (*ReportContext)(nil)
+	_ interfaces.ErrorReportInterface  = (*ReportableErrorReport)(nil)
+)
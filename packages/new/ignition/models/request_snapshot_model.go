@@ -0,0 +1,142 @@
+package models
+
+import (
+	"net/http"
+	"strings"
+
+	"govel/ignition/interfaces"
+)
+
+// RequestSnapshot holds the request information sent to reporters.
+// Headers are kept verbatim, reporters are responsible for redaction.
+type RequestSnapshot struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// NewRequestSnapshot creates a new request snapshot from an HTTP request.
+// The body is taken from the parsed form only, as the request body has
+// usually been consumed by the time an error is handled.
+func NewRequestSnapshot(r *http.Request) *RequestSnapshot {
+	snapshot := &RequestSnapshot{Headers: map[string]string{}}
+	if r == nil {
+		return snapshot
+	}
+
+	snapshot.Method = r.Method
+	snapshot.URL = getRequestURL(r)
+	for name, values := range r.Header {
+		if len(values) > 0 {
+			snapshot.Headers[name] = values[0]
+		}
+	}
+	if r.PostForm != nil {
+		snapshot.Body = r.PostForm.Encode()
+	}
+
+	return snapshot
+}
+
+// GetMethod returns the request method
+func (r *RequestSnapshot) GetMethod() string {
+	return r.Method
+}
+
+// SetMethod sets the request method
+func (r *RequestSnapshot) SetMethod(method string) {
+	r.Method = method
+}
+
+// GetURL returns the request URL
+func (r *RequestSnapshot) GetURL() string {
+	return r.URL
+}
+
+// SetURL sets the request URL
+func (r *RequestSnapshot) SetURL(url string) {
+	r.URL = url
+}
+
+// GetHeaders returns the request headers
+func (r *RequestSnapshot) GetHeaders() map[string]string {
+	return r.Headers
+}
+
+// SetHeaders sets the request headers
+func (r *RequestSnapshot) SetHeaders(headers map[string]string) {
+	r.Headers = headers
+}
+
+// GetHeader returns a request header by name
+func (r *RequestSnapshot) GetHeader(name string) string {
+	return r.Headers[http.CanonicalHeaderKey(name)]
+}
+
+// SetHeader sets a request header
+func (r *RequestSnapshot) SetHeader(name string, value string) {
+	if r.Headers == nil {
+		r.Headers = map[string]string{}
+	}
+	r.Headers[http.CanonicalHeaderKey(name)] = value
+}
+
+// GetBody returns the request body
+func (r *RequestSnapshot) GetBody() string {
+	return r.Body
+}
+
+// SetBody sets the request body
+func (r *RequestSnapshot) SetBody(body string) {
+	r.Body = body
+}
+
+// HasHeader returns true if the header is present
+func (r *RequestSnapshot) HasHeader(name string) bool {
+	_, ok := r.Headers[http.CanonicalHeaderKey(name)]
+	return ok
+}
+
+// GetHeaderCount returns the number of headers
+func (r *RequestSnapshot) GetHeaderCount() int {
+	return len(r.Headers)
+}
+
+// HasBody returns true if the request has a body
+func (r *RequestSnapshot) HasBody() bool {
+	return r.Body != ""
+}
+
+// IsGET returns true for GET requests
+func (r *RequestSnapshot) IsGET() bool {
+	return r.Method == http.MethodGet
+}
+
+// IsPOST returns true for POST requests
+func (r *RequestSnapshot) IsPOST() bool {
+	return r.Method == http.MethodPost
+}
+
+// IsPUT returns true for PUT requests
+func (r *RequestSnapshot) IsPUT() bool {
+	return r.Method == http.MethodPut
+}
+
+// IsDELETE returns true for DELETE requests
+func (r *RequestSnapshot) IsDELETE() bool {
+	return r.Method == http.MethodDelete
+}
+
+// IsAjax returns true for XMLHttpRequest requests
+func (r *RequestSnapshot) IsAjax() bool {
+	return r.GetHeader("X-Requested-With") == "XMLHttpRequest"
+}
+
+// IsSecure returns true for HTTPS requests
+func (r *RequestSnapshot) IsSecure() bool {
+	return strings.HasPrefix(r.URL, "https://")
+}
+
+// Compile-time interface compliance check
+var _ interfaces.RequestContextInterface = (*RequestSnapshot)(nil)
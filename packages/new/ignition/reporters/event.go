@@ -0,0 +1,133 @@
+package reporters
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"govel/ignition/interfaces"
+)
+
+// Event is the serializable form of an error report sent to backends
+type Event struct {
+	ID          string            `json:"id"`
+	Fingerprint string            `json:"fingerprint"`
+	Message     string            `json:"message"`
+	Type        string            `json:"type"`
+	File        string            `json:"file"`
+	Line        int               `json:"line"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Frames      []EventFrame      `json:"frames"`
+	Request     *EventRequest     `json:"request,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	User        interface{}       `json:"user,omitempty"`
+}
+
+// EventFrame is a single stack frame of an event, innermost first
+type EventFrame struct {
+	Function    string `json:"function"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Application bool   `json:"application"`
+}
+
+// EventRequest is the redacted request of an event
+type EventRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// NewEvent creates a new event from the report, redacting the request
+// with the given redactor
+func NewEvent(report interfaces.ErrorReportInterface, redactor *Redactor) *Event {
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+
+	event := &Event{
+		ID:          newEventID(),
+		Fingerprint: Fingerprint(report),
+		Message:     report.GetMessage(),
+		Type:        report.GetType(),
+		File:        report.GetFile(),
+		Line:        report.GetLine(),
+		Timestamp:   report.GetTimestamp(),
+		Frames:      make([]EventFrame, 0, report.GetStackFrameCount()),
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	for _, frame := range report.GetStack() {
+		event.Frames = append(event.Frames, EventFrame{
+			Function:    frame.GetFunction(),
+			File:        frame.GetFile(),
+			Line:        frame.GetLine(),
+			Application: frame.IsApplicationFrame(),
+		})
+	}
+
+	context := report.GetContext()
+	if context == nil {
+		return event
+	}
+
+	if context.HasRequest() {
+		request := context.GetRequest()
+		event.Request = &EventRequest{
+			Method:  request.GetMethod(),
+			URL:     redactor.RedactURL(request.GetURL()),
+			Headers: redactor.RedactHeaders(request.GetHeaders()),
+			Body:    redactor.RedactBody(request.GetBody()),
+		}
+	}
+	if context.HasEnvironment() {
+		env := context.GetEnvironment()
+		event.Environment = map[string]string{
+			"go_version": env.GetGoVersion(),
+			"os":         env.GetOS(),
+			"arch":       env.GetArch(),
+		}
+	}
+	event.User = context.GetUser()
+
+	return event
+}
+
+// Fingerprint identifies the report by its error type and its top
+// application frame, falling back to the top frame
+func Fingerprint(report interfaces.ErrorReportInterface) string {
+	var top interfaces.StackFrameInterface
+	for _, frame := range report.GetStack() {
+		if frame.IsApplicationFrame() {
+			top = frame
+			break
+		}
+	}
+	if top == nil && report.GetStackFrameCount() > 0 {
+		top = report.GetStack()[0]
+	}
+
+	source := report.GetType()
+	if top != nil {
+		source += fmt.Sprintf("|%s|%s:%d", top.GetFunction(), top.GetFile(), top.GetLine())
+	} else {
+		source += "|" + report.GetMessage()
+	}
+
+	sum := sha1.Sum([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+// newEventID returns a random 32 character hexadecimal identifier
+func newEventID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%032x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
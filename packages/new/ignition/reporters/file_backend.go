@@ -0,0 +1,54 @@
+package reporters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileBackend appends events as JSON lines to a file
+type FileBackend struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileBackend creates a new JSON lines file backend
+func NewFileBackend(path string) *FileBackend {
+	return &FileBackend{path: path}
+}
+
+// Name returns the name of the backend
+func (b *FileBackend) Name() string {
+	return "file"
+}
+
+// Send appends the event to the file
+func (b *FileBackend) Send(ctx context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("reporters: failed to encode event: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+		return fmt.Errorf("reporters: failed to create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(b.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("reporters: failed to open %s: %w", b.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("reporters: failed to write %s: %w", b.path, err)
+	}
+	return nil
+}
+
+var _ Backend = (*FileBackend)(nil)
@@ -0,0 +1,50 @@
+package reporters
+
+import (
+	"context"
+	"log"
+	"strconv"
+)
+
+// Logger is the logging interface used by LogBackend, satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// LogBackend writes events to a logger
+type LogBackend struct {
+	logger Logger
+}
+
+// NewLogBackend creates a new log backend, using the standard logger when nil
+func NewLogBackend(logger Logger) *LogBackend {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogBackend{logger: logger}
+}
+
+// Name returns the name of the backend
+func (b *LogBackend) Name() string {
+	return "log"
+}
+
+// Send logs the event
+func (b *LogBackend) Send(ctx context.Context, event *Event) error {
+	location := event.File
+	if event.Line > 0 {
+		location = location + ":" + strconv.Itoa(event.Line)
+	}
+
+	if event.Request != nil {
+		b.logger.Printf("[ignition] %s: %s at %s (%s %s) fingerprint=%s",
+			event.Type, event.Message, location, event.Request.Method, event.Request.URL, event.Fingerprint)
+		return nil
+	}
+
+	b.logger.Printf("[ignition] %s: %s at %s fingerprint=%s",
+		event.Type, event.Message, location, event.Fingerprint)
+	return nil
+}
+
+var _ Backend = (*LogBackend)(nil)
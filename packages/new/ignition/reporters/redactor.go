@@ -0,0 +1,171 @@
+package reporters
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values in reported data
+const RedactedValue = "[REDACTED]"
+
+// DefaultSensitiveHeaders are the headers redacted by NewRedactor
+var DefaultSensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Proxy-Authorization",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"X-Csrf-Token",
+	"X-Xsrf-Token",
+}
+
+// DefaultSensitiveInputs are the input keys redacted by NewRedactor
+var DefaultSensitiveInputs = []string{
+	"password",
+	"password_confirmation",
+	"current_password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"secret",
+	"api_key",
+	"credit_card",
+	"card_number",
+	"cvv",
+}
+
+// Redactor removes sensitive headers and inputs from reported requests
+type Redactor struct {
+	headers map[string]bool
+	inputs  map[string]bool
+}
+
+// NewRedactor creates a new redactor using the default sensitive names
+func NewRedactor() *Redactor {
+	return NewEmptyRedactor().
+		Headers(DefaultSensitiveHeaders...).
+		Inputs(DefaultSensitiveInputs...)
+}
+
+// NewEmptyRedactor creates a new redactor without any sensitive names
+func NewEmptyRedactor() *Redactor {
+	return &Redactor{
+		headers: map[string]bool{},
+		inputs:  map[string]bool{},
+	}
+}
+
+// Headers adds header names to redact, matched case-insensitively
+func (r *Redactor) Headers(names ...string) *Redactor {
+	for _, name := range names {
+		r.headers[strings.ToLower(name)] = true
+	}
+	return r
+}
+
+// Inputs adds query, form and JSON keys to redact, matched case-insensitively
+func (r *Redactor) Inputs(names ...string) *Redactor {
+	for _, name := range names {
+		r.inputs[strings.ToLower(name)] = true
+	}
+	return r
+}
+
+// RedactHeaders returns a copy of the headers with sensitive values replaced
+func (r *Redactor) RedactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if r.headers[strings.ToLower(name)] {
+			value = RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// RedactURL replaces sensitive query parameters of the URL
+func (r *Redactor) RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+
+	query, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return rawURL
+	}
+
+	if r.redactValues(query) {
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
+
+// RedactBody replaces sensitive inputs of a JSON or form encoded body.
+// Bodies in any other format are returned unchanged.
+func (r *Redactor) RedactBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return body
+	}
+
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+			return body
+		}
+		encoded, err := json.Marshal(r.redactJSON(decoded))
+		if err != nil {
+			return body
+		}
+		return string(encoded)
+	}
+
+	if !strings.Contains(trimmed, "=") {
+		return body
+	}
+	values, err := url.ParseQuery(trimmed)
+	if err != nil {
+		return body
+	}
+	if r.redactValues(values) {
+		return values.Encode()
+	}
+	return body
+}
+
+// redactValues redacts sensitive keys in place and reports whether any matched
+func (r *Redactor) redactValues(values url.Values) bool {
+	changed := false
+	for key, entries := range values {
+		if !r.inputs[strings.ToLower(key)] {
+			continue
+		}
+		for idx := range entries {
+			entries[idx] = RedactedValue
+		}
+		changed = true
+	}
+	return changed
+}
+
+// redactJSON walks decoded JSON and redacts sensitive object keys
+func (r *Redactor) redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range typed {
+			if r.inputs[strings.ToLower(key)] {
+				typed[key] = RedactedValue
+				continue
+			}
+			typed[key] = r.redactJSON(inner)
+		}
+	case []interface{}:
+		for idx, inner := range typed {
+			typed[idx] = r.redactJSON(inner)
+		}
+	}
+	return value
+}
@@ -0,0 +1,280 @@
+package reporters
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"govel/ignition/interfaces"
+)
+
+var (
+	// ErrQueueFull is returned when an asynchronous reporter drops a report
+	ErrQueueFull = errors.New("reporters: queue is full")
+
+	// ErrReporterClosed is returned when reporting after Shutdown
+	ErrReporterClosed = errors.New("reporters: reporter is shut down")
+)
+
+// Backend delivers events to a reporting destination
+type Backend interface {
+	// Name returns the name of the backend
+	Name() string
+
+	// Send delivers the event
+	Send(ctx context.Context, event *Event) error
+}
+
+// Reporter implements ReporterInterface on top of a backend, adding
+// sampling, deduplication, redaction and optional asynchronous delivery
+type Reporter struct {
+	backend     Backend
+	enabled     atomic.Bool
+	sampleRate  float64
+	dedupWindow time.Duration
+	redactor    *Redactor
+	timeout     time.Duration
+	queueSize   int
+	onError     func(error)
+	random      func() float64
+	now         func() time.Time
+
+	mu       sync.Mutex
+	seen     map[string]time.Time
+	inflight map[string]bool
+	queue    chan *Event
+	done     chan struct{}
+	started  bool
+	closed   bool
+}
+
+// NewReporter creates a new reporter delivering to the backend
+func NewReporter(backend Backend) *Reporter {
+	reporter := &Reporter{
+		backend:    backend,
+		sampleRate: 1,
+		redactor:   NewRedactor(),
+		timeout:    5 * time.Second,
+		random:     rand.Float64,
+		now:        time.Now,
+		seen:       map[string]time.Time{},
+		inflight:   map[string]bool{},
+	}
+	reporter.enabled.Store(true)
+	return reporter
+}
+
+// SampleRate sets the fraction of reports delivered, between 0 and 1
+func (r *Reporter) SampleRate(rate float64) *Reporter {
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	r.sampleRate = rate
+	return r
+}
+
+// DeduplicateFor drops reports sharing a fingerprint with a report
+// delivered within the window. Zero disables deduplication.
+func (r *Reporter) DeduplicateFor(window time.Duration) *Reporter {
+	r.dedupWindow = window
+	return r
+}
+
+// Redact sets the redactor applied to reported requests
+func (r *Reporter) Redact(redactor *Redactor) *Reporter {
+	r.redactor = redactor
+	return r
+}
+
+// Timeout sets the delivery timeout of a single report
+func (r *Reporter) Timeout(timeout time.Duration) *Reporter {
+	r.timeout = timeout
+	return r
+}
+
+// Async delivers reports from a background worker through a queue of
+// the given size. Reports are dropped with ErrQueueFull when the queue
+// is full, and the queue is flushed by Shutdown.
+func (r *Reporter) Async(queueSize int) *Reporter {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	r.queueSize = queueSize
+	return r
+}
+
+// OnError sets the callback receiving asynchronous delivery errors
+func (r *Reporter) OnError(fn func(error)) *Reporter {
+	r.onError = fn
+	return r
+}
+
+// Report sends the error report to the backend
+func (r *Reporter) Report(report interfaces.ErrorReportInterface) error {
+	if !r.enabled.Load() || report == nil {
+		return nil
+	}
+	if r.sampleRate < 1 && r.random() >= r.sampleRate {
+		return nil
+	}
+
+	event := NewEvent(report, r.redactor)
+
+	if r.queueSize == 0 {
+		if !r.claim(event.Fingerprint) {
+			return nil
+		}
+		err := r.deliver(event)
+		r.settle(event.Fingerprint, err == nil)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return ErrReporterClosed
+	}
+	if !r.claimLocked(event.Fingerprint) {
+		return nil
+	}
+	if !r.started {
+		r.start()
+	}
+
+	select {
+	case r.queue <- event:
+		return nil
+	default:
+		r.settleLocked(event.Fingerprint, false)
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting reports and waits until queued reports are
+// delivered or the context is done
+func (r *Reporter) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	started := r.started
+	if started {
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsEnabled returns true if the reporter is enabled
+func (r *Reporter) IsEnabled() bool {
+	return r.enabled.Load()
+}
+
+// SetEnabled enables or disables the reporter
+func (r *Reporter) SetEnabled(enabled bool) {
+	r.enabled.Store(enabled)
+}
+
+// GetName returns the name of the reporter
+func (r *Reporter) GetName() string {
+	return r.backend.Name()
+}
+
+// claim reserves the fingerprint for delivery, returning false when it
+// was delivered within the deduplication window or is being delivered
+func (r *Reporter) claim(fingerprint string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.claimLocked(fingerprint)
+}
+
+// claimLocked is claim for callers holding the lock
+func (r *Reporter) claimLocked(fingerprint string) bool {
+	if r.dedupWindow <= 0 {
+		return true
+	}
+
+	now := r.now()
+	for key, seenAt := range r.seen {
+		if now.Sub(seenAt) >= r.dedupWindow {
+			delete(r.seen, key)
+		}
+	}
+
+	if _, ok := r.seen[fingerprint]; ok || r.inflight[fingerprint] {
+		return false
+	}
+	r.inflight[fingerprint] = true
+	return true
+}
+
+// settle releases a claimed fingerprint, recording it for deduplication
+// when the report was delivered. Failed reports are not recorded so that
+// a retry of the same error is not dropped.
+func (r *Reporter) settle(fingerprint string, delivered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settleLocked(fingerprint, delivered)
+}
+
+// settleLocked is settle for callers holding the lock
+func (r *Reporter) settleLocked(fingerprint string, delivered bool) {
+	if r.dedupWindow <= 0 {
+		return
+	}
+	delete(r.inflight, fingerprint)
+	if delivered {
+		r.seen[fingerprint] = r.now()
+	}
+}
+
+// start launches the background worker, the caller must hold the lock
+func (r *Reporter) start() {
+	r.queue = make(chan *Event, r.queueSize)
+	r.done = make(chan struct{})
+	r.started = true
+
+	go func() {
+		defer close(r.done)
+		for event := range r.queue {
+			err := r.deliver(event)
+			r.settle(event.Fingerprint, err == nil)
+			if err != nil && r.onError != nil {
+				r.onError(err)
+			}
+		}
+	}()
+}
+
+// deliver sends a single event within the configured timeout
+func (r *Reporter) deliver(event *Event) error {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.backend.Send(ctx, event)
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ReporterInterface = (*Reporter)(nil)
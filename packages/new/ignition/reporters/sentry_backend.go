@@ -0,0 +1,201 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// sentryClient identifies this client to Sentry compatible servers
+const sentryClient = "govel-ignition/1.0"
+
+// SentryBackend sends events in the Sentry envelope format, which is
+// accepted by Sentry and compatible servers such as GlitchTip
+type SentryBackend struct {
+	dsn         string
+	endpoint    string
+	publicKey   string
+	environment string
+	release     string
+	client      *http.Client
+}
+
+// NewSentryBackend creates a new Sentry backend from a DSN of the form
+// scheme://public_key@host[/path]/project_id
+func NewSentryBackend(dsn string) (*SentryBackend, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("reporters: invalid sentry DSN: %w", err)
+	}
+	if parsed.User == nil || parsed.User.Username() == "" {
+		return nil, fmt.Errorf("reporters: sentry DSN is missing the public key")
+	}
+
+	path := strings.Trim(parsed.Path, "/")
+	index := strings.LastIndex(path, "/")
+	projectID, prefix := path, ""
+	if index >= 0 {
+		projectID, prefix = path[index+1:], "/"+path[:index]
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("reporters: sentry DSN is missing the project id")
+	}
+
+	return &SentryBackend{
+		dsn:       dsn,
+		endpoint:  fmt.Sprintf("%s://%s%s/api/%s/envelope/", parsed.Scheme, parsed.Host, prefix, projectID),
+		publicKey: parsed.User.Username(),
+		client:    http.DefaultClient,
+	}, nil
+}
+
+// Environment sets the environment attached to events
+func (b *SentryBackend) Environment(environment string) *SentryBackend {
+	b.environment = environment
+	return b
+}
+
+// Release sets the release attached to events
+func (b *SentryBackend) Release(release string) *SentryBackend {
+	b.release = release
+	return b
+}
+
+// Client sets the HTTP client used to deliver events
+func (b *SentryBackend) Client(client *http.Client) *SentryBackend {
+	b.client = client
+	return b
+}
+
+// Endpoint returns the envelope endpoint derived from the DSN
+func (b *SentryBackend) Endpoint() string {
+	return b.endpoint
+}
+
+// Name returns the name of the backend
+func (b *SentryBackend) Name() string {
+	return "sentry"
+}
+
+// Send posts the event as an envelope
+func (b *SentryBackend) Send(ctx context.Context, event *Event) error {
+	envelope, err := b.envelope(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		return fmt.Errorf("reporters: failed to create sentry request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-sentry-envelope")
+	request.Header.Set("X-Sentry-Auth", fmt.Sprintf(
+		"Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, b.publicKey))
+
+	return doRequest(b.client, request, "sentry")
+}
+
+// envelope encodes the envelope header, item header and event payload
+func (b *SentryBackend) envelope(event *Event) ([]byte, error) {
+	payload, err := json.Marshal(b.payload(event))
+	if err != nil {
+		return nil, fmt.Errorf("reporters: failed to encode sentry event: %w", err)
+	}
+
+	header, _ := json.Marshal(map[string]interface{}{
+		"event_id": event.ID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+		"dsn":      b.dsn,
+	})
+	item, _ := json.Marshal(map[string]interface{}{
+		"type":   "event",
+		"length": len(payload),
+	})
+
+	var buffer bytes.Buffer
+	buffer.Write(header)
+	buffer.WriteByte('\n')
+	buffer.Write(item)
+	buffer.WriteByte('\n')
+	buffer.Write(payload)
+	buffer.WriteByte('\n')
+	return buffer.Bytes(), nil
+}
+
+// payload maps the event to the Sentry event schema
+func (b *SentryBackend) payload(event *Event) map[string]interface{} {
+	// Sentry expects frames ordered from the outermost call to the innermost
+	frames := make([]map[string]interface{}, 0, len(event.Frames))
+	for idx := len(event.Frames) - 1; idx >= 0; idx-- {
+		frame := event.Frames[idx]
+		frames = append(frames, map[string]interface{}{
+			"function": frame.Function,
+			"filename": frame.File,
+			"abs_path": frame.File,
+			"lineno":   frame.Line,
+			"in_app":   frame.Application,
+		})
+	}
+
+	exceptionType := event.Type
+	if exceptionType == "" {
+		exceptionType = "error"
+	}
+
+	payload := map[string]interface{}{
+		"event_id":    event.ID,
+		"timestamp":   event.Timestamp.UTC().Format(time.RFC3339Nano),
+		"platform":    "go",
+		"level":       "error",
+		"logger":      "ignition",
+		"fingerprint": []string{event.Fingerprint},
+		"exception": map[string]interface{}{
+			"values": []map[string]interface{}{{
+				"type":       exceptionType,
+				"value":      event.Message,
+				"stacktrace": map[string]interface{}{"frames": frames},
+			}},
+		},
+		"contexts": map[string]interface{}{
+			"runtime": map[string]interface{}{"name": "go", "version": runtime.Version()},
+			"os":      map[string]interface{}{"name": runtime.GOOS},
+		},
+	}
+	if b.environment != "" {
+		payload["environment"] = b.environment
+	}
+	if b.release != "" {
+		payload["release"] = b.release
+	}
+	if event.User != nil {
+		payload["user"] = event.User
+	}
+
+	if event.Request != nil {
+		request := map[string]interface{}{
+			"method":  event.Request.Method,
+			"headers": event.Request.Headers,
+		}
+		if parsed, err := url.Parse(event.Request.URL); err == nil {
+			request["query_string"] = parsed.RawQuery
+			parsed.RawQuery = ""
+			request["url"] = parsed.String()
+		} else {
+			request["url"] = event.Request.URL
+		}
+		if event.Request.Body != "" {
+			request["data"] = event.Request.Body
+		}
+		payload["request"] = request
+	}
+
+	return payload
+}
+
+var _ Backend = (*SentryBackend)(nil)
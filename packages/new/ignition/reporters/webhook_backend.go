@@ -0,0 +1,79 @@
+package reporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WebhookBackend posts events as JSON to an HTTP endpoint
+type WebhookBackend struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookBackend creates a new webhook backend
+func NewWebhookBackend(url string) *WebhookBackend {
+	return &WebhookBackend{
+		url:     url,
+		headers: map[string]string{},
+		client:  http.DefaultClient,
+	}
+}
+
+// Header sets a header sent with every request
+func (b *WebhookBackend) Header(name, value string) *WebhookBackend {
+	b.headers[name] = value
+	return b
+}
+
+// Client sets the HTTP client used to deliver events
+func (b *WebhookBackend) Client(client *http.Client) *WebhookBackend {
+	b.client = client
+	return b
+}
+
+// Name returns the name of the backend
+func (b *WebhookBackend) Name() string {
+	return "webhook"
+}
+
+// Send posts the event to the webhook
+func (b *WebhookBackend) Send(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("reporters: failed to encode event: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("reporters: failed to create webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range b.headers {
+		request.Header.Set(name, value)
+	}
+
+	return doRequest(b.client, request, "webhook")
+}
+
+// doRequest executes the request and turns non 2xx responses into errors
+func doRequest(client *http.Client, request *http.Request, name string) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("reporters: %s request failed: %w", name, err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("reporters: %s responded with status %d", name, response.StatusCode)
+	}
+	return nil
+}
+
+var _ Backend = (*WebhookBackend)(nil)
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// ArrayTransport keeps sent messages in memory instead of delivering them.
+// It backs the "array" transport used in tests.
+type ArrayTransport struct {
+	// mu guards messages
+	mu sync.Mutex
+
+	// messages holds the sent messages in order
+	messages []*Message
+}
+
+// NewArrayTransport creates an empty array transport
+func NewArrayTransport() *ArrayTransport {
+	return &ArrayTransport{}
+}
+
+// Send records a copy of the message
+func (t *ArrayTransport) Send(ctx context.Context, message *Message) error {
+	if _, err := message.Bytes(); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, message.Clone())
+	return nil
+}
+
+// Messages returns the sent messages
+func (t *ArrayTransport) Messages() []*Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Message(nil), t.messages...)
+}
+
+// Flush forgets the sent messages
+func (t *ArrayTransport) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = nil
+}
+
+// String returns the transport name
+func (t *ArrayTransport) String() string {
+	return "array"
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoSender is returned for messages without a From address and no
+// mail.from default
+var ErrNoSender = errors.New("mail: message has no sender")
+
+// ErrNoRecipients is returned for messages without To, Cc or Bcc
+// recipients
+var ErrNoRecipients = errors.New("mail: message has no recipients")
+
+// ErrInvalidHeader is returned for custom headers whose name is not an
+// RFC 5322 field name
+var ErrInvalidHeader = errors.New("mail: invalid header name")
+
+// ErrNoRenderer is returned when sending a templated message from a
+// mailer without a template renderer
+var ErrNoRenderer = errors.New("mail: no template renderer set")
+
+// ErrNoDispatcher is returned when queueing mail from a mailer without a
+// command bus dispatcher
+var ErrNoDispatcher = errors.New("mail: no bus dispatcher set for queued mail")
+
+// ErrNoMailManager is returned when queueing mail from a mailer that was
+// not created by a MailManager, or when handling a queued message that has
+// no manager to resolve its mailer
+var ErrNoMailManager = errors.New("mail: no mail manager set for queued mail")
+
+// MailerNotDefinedError is returned for mailers missing from mail.mailers
+// or configured with an unsupported transport
+type MailerNotDefinedError struct {
+	// Name is the mailer name
+	Name string
+
+	// Transport is the configured transport, empty when the mailer is
+	// missing
+	Transport string
+}
+
+// Error implements error
+func (e *MailerNotDefinedError) Error() string {
+	if e.Transport == "" {
+		return fmt.Sprintf("mailer [%s] is not defined", e.Name)
+	}
+	return fmt.Sprintf("mail transport [%s] for mailer [%s] is not supported", e.Transport, e.Name)
+}
@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FailoverTransport sends through the first transport that succeeds. A
+// transport that fails is tried last for retryAfter, so healthy transports
+// are used first while it recovers.
+type FailoverTransport struct {
+	// transports are tried in order
+	transports []Transport
+
+	// retryAfter is how long a failed transport is tried last
+	retryAfter time.Duration
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+
+	// mu guards failedAt
+	mu sync.Mutex
+
+	// failedAt holds when each transport last failed
+	failedAt map[int]time.Time
+}
+
+// NewFailoverTransport creates a failover transport over transports
+func NewFailoverTransport(retryAfter time.Duration, transports ...Transport) *FailoverTransport {
+	return &FailoverTransport{
+		transports: transports,
+		retryAfter: retryAfter,
+		now:        time.Now,
+		failedAt:   make(map[int]time.Time),
+	}
+}
+
+// SetClock replaces the clock used to expire failures
+func (t *FailoverTransport) SetClock(now func() time.Time) *FailoverTransport {
+	t.now = now
+	return t
+}
+
+// Send sends through the first available transport, falling through to
+// the next one on error
+func (t *FailoverTransport) Send(ctx context.Context, message *Message) error {
+	if len(t.transports) == 0 {
+		return errors.New("mail: failover transport has no transports")
+	}
+
+	var errs []error
+	for _, index := range t.order() {
+		transport := t.transports[index]
+		err := transport.Send(ctx, message)
+		if err == nil {
+			t.mu.Lock()
+			delete(t.failedAt, index)
+			t.mu.Unlock()
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", transport, err))
+		t.mu.Lock()
+		t.failedAt[index] = t.now()
+		t.mu.Unlock()
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return fmt.Errorf("mail: all transports failed: %w", errors.Join(errs...))
+}
+
+// order returns the transports to try: the available ones first, then
+// the ones still within their retry delay
+func (t *FailoverTransport) order() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	available := make([]int, 0, len(t.transports))
+	var waiting []int
+	for index := range t.transports {
+		if failedAt, failed := t.failedAt[index]; failed && now.Sub(failedAt) < t.retryAfter {
+			waiting = append(waiting, index)
+			continue
+		}
+		available = append(available, index)
+	}
+	return append(available, waiting...)
+}
+
+// String returns the transport name with its transports
+func (t *FailoverTransport) String() string {
+	names := make([]string, len(t.transports))
+	for i, transport := range t.transports {
+		names[i] = transport.String()
+	}
+	return "failover(" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	loggerInterfaces "govel/types/interfaces/logger"
+)
+
+// LogTransport writes the encoded message to a logger instead of
+// delivering it. It backs the "log" transport used during development.
+type LogTransport struct {
+	// logger receives the messages, the standard logger when nil
+	logger loggerInterfaces.LoggerInterface
+
+	// channel is recorded with each message, empty for none
+	channel string
+}
+
+// NewLogTransport creates a log transport writing to logger, or to the
+// standard library logger when nil
+func NewLogTransport(logger loggerInterfaces.LoggerInterface) *LogTransport {
+	return &LogTransport{logger: logger}
+}
+
+// SetChannel sets the log channel recorded with each message
+func (t *LogTransport) SetChannel(channel string) *LogTransport {
+	t.channel = channel
+	return t
+}
+
+// Send logs the message at debug level
+func (t *LogTransport) Send(ctx context.Context, message *Message) error {
+	encoded, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+	content := strings.ReplaceAll(string(encoded), "\r\n", "\n")
+	if t.logger == nil {
+		log.Printf("mail: %s", content)
+		return nil
+	}
+	fields := map[string]interface{}{
+		"recipients": message.Recipients(),
+		"subject":    message.GetSubject(),
+	}
+	if t.channel != "" {
+		fields["channel"] = t.channel
+	}
+	t.logger.WithFields(fields).Debug("%s", content)
+	return nil
+}
+
+// String returns the transport name
+func (t *LogTransport) String() string {
+	return "log"
+}
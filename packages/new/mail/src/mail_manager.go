@@ -0,0 +1,414 @@
+// Package mail sends email for GoVel.
+//
+// Mailers are configured under mail.mailers and resolved by name:
+//
+//	mailer, err := manager.Mailer("smtp")
+//	err = mailer.SendMessage(mailer.NewMessage().
+//		To("alice@example.com").
+//		Subject("Invoice #12345").
+//		Text("Please find your invoice attached.").
+//		Attach("/path/to/invoice.pdf"))
+//
+// Messages are encoded as MIME with an HTML body and its plain text
+// alternative, inline images and attachments. The "smtp", "log", "array"
+// and "failover" transports are built in; others are added with Extend.
+// Mailables render messages from templates and can be queued through the
+// command bus.
+package mail
+
+import (
+	"fmt"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	busInterfaces "govel/new/bus/interfaces"
+	configInterfaces "govel/types/interfaces/config"
+	loggerInterfaces "govel/types/interfaces/logger"
+	mailInterfaces "govel/types/interfaces/mail"
+)
+
+// TransportCreator creates a transport of a custom driver
+type TransportCreator func(manager *MailManager, name string, config map[string]interface{}) (Transport, error)
+
+// MailManager resolves and caches named mailers.
+//
+// Key features:
+//   - "smtp", "log", "array" and "failover" transports, plus custom ones
+//     through Extend
+//   - Default sender from mail.from
+//   - Shared template renderer and bus dispatcher for every mailer
+//   - MailInterface methods sending through the default mailer
+type MailManager struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// config provides mail.* settings, nil for standalone managers
+	config configInterfaces.ConfigInterface
+
+	// logger receives messages of "log" transports
+	logger loggerInterfaces.LoggerInterface
+
+	// renderer renders template views
+	renderer Renderer
+
+	// dispatcher queues mail
+	dispatcher busInterfaces.Dispatcher
+
+	// defaultMailer overrides mail.default when set
+	defaultMailer string
+
+	// from overrides mail.from when set
+	from string
+
+	// mailerConfigs holds mailers added through AddMailer
+	mailerConfigs map[string]map[string]interface{}
+
+	// mailers caches resolved mailers by name
+	mailers map[string]*Mailer
+
+	// creators are the custom transport drivers
+	creators map[string]TransportCreator
+}
+
+// New creates a standalone mail manager configured through AddMailer.
+//
+// Example:
+//
+//	manager := mail.New(logger).
+//		SetFrom("Example <hello@example.com>").
+//		AddMailer("log", map[string]interface{}{"transport": "log"})
+func New(logger loggerInterfaces.LoggerInterface) *MailManager {
+	return &MailManager{
+		logger:        logger,
+		mailerConfigs: make(map[string]map[string]interface{}),
+		mailers:       make(map[string]*Mailer),
+		creators:      make(map[string]TransportCreator),
+	}
+}
+
+// NewMailManager creates a mail manager reading the mail.* configuration
+func NewMailManager(config configInterfaces.ConfigInterface, logger loggerInterfaces.LoggerInterface) *MailManager {
+	manager := New(logger)
+	manager.config = config
+	return manager
+}
+
+// SetRenderer sets the template renderer of every mailer
+func (m *MailManager) SetRenderer(renderer Renderer) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.renderer = renderer
+	for _, mailer := range m.mailers {
+		mailer.SetRenderer(renderer)
+	}
+	return m
+}
+
+// SetDispatcher sets the command bus of every mailer
+func (m *MailManager) SetDispatcher(dispatcher busInterfaces.Dispatcher) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dispatcher = dispatcher
+	for _, mailer := range m.mailers {
+		mailer.SetDispatcher(dispatcher)
+	}
+	return m
+}
+
+// SetFrom sets the default sender, taking precedence over mail.from
+func (m *MailManager) SetFrom(address string) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.from = address
+	return m
+}
+
+// AddMailer registers a mailer configuration, taking precedence over
+// mail.mailers. The first added mailer becomes the default of standalone
+// managers.
+func (m *MailManager) AddMailer(name string, config map[string]interface{}) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mailerConfigs[name] = config
+	delete(m.mailers, name)
+	if m.defaultMailer == "" && m.config == nil {
+		m.defaultMailer = name
+	}
+	return m
+}
+
+// Extend registers a custom transport driver
+func (m *MailManager) Extend(transport string, creator TransportCreator) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.creators[transport] = creator
+	return m
+}
+
+// GetDefaultDriver returns the default mailer name
+func (m *MailManager) GetDefaultDriver() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.defaultMailer != "" {
+		return m.defaultMailer
+	}
+	if m.config != nil {
+		return m.config.GetString("mail.default", "log")
+	}
+	return "log"
+}
+
+// SetDefaultDriver sets the default mailer
+func (m *MailManager) SetDefaultDriver(name string) *MailManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultMailer = name
+	return m
+}
+
+// Mailer returns the named mailer, the default one when no name is given
+func (m *MailManager) Mailer(name ...string) (*Mailer, error) {
+	mailerName := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		mailerName = name[0]
+	}
+
+	m.mu.RLock()
+	mailer, exists := m.mailers[mailerName]
+	m.mu.RUnlock()
+	if exists {
+		return mailer, nil
+	}
+
+	transport, err := m.CreateTransport(mailerName)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, exists := m.mailers[mailerName]; exists {
+		return existing, nil
+	}
+	mailer = NewMailer(mailerName, transport).
+		SetFrom(m.fromAddress()).
+		SetRenderer(m.renderer).
+		SetDispatcher(m.dispatcher)
+	mailer.manager = m
+	m.mailers[mailerName] = mailer
+	return mailer, nil
+}
+
+// CreateTransport creates the transport of the named mailer
+func (m *MailManager) CreateTransport(name string) (Transport, error) {
+	return m.createTransport(name, map[string]bool{})
+}
+
+// Send sends a plain text message through the default mailer
+func (m *MailManager) Send(to, subject, body string) error {
+	mailer, err := m.Mailer()
+	if err != nil {
+		return err
+	}
+	return mailer.Send(to, subject, body)
+}
+
+// SendToMany sends a plain text message to every recipient through the
+// default mailer
+func (m *MailManager) SendToMany(to []string, subject, body string) error {
+	mailer, err := m.Mailer()
+	if err != nil {
+		return err
+	}
+	return mailer.SendToMany(to, subject, body)
+}
+
+// SendTemplate sends a template message through the default mailer
+func (m *MailManager) SendTemplate(to, subject, template string, data map[string]interface{}) error {
+	mailer, err := m.Mailer()
+	if err != nil {
+		return err
+	}
+	return mailer.SendTemplate(to, subject, template, data)
+}
+
+// Queue queues a template message on the default mailer
+func (m *MailManager) Queue(to, subject, template string, data map[string]interface{}) error {
+	mailer, err := m.Mailer()
+	if err != nil {
+		return err
+	}
+	return mailer.Queue(to, subject, template, data)
+}
+
+// Later queues a plain text message on the default mailer
+func (m *MailManager) Later(at time.Time, to, subject, body string) error {
+	mailer, err := m.Mailer()
+	if err != nil {
+		return err
+	}
+	return mailer.Later(at, to, subject, body)
+}
+
+// createTransport creates a transport, tracking the mailers being created
+// to reject failover loops
+func (m *MailManager) createTransport(name string, creating map[string]bool) (Transport, error) {
+	if creating[name] {
+		return nil, fmt.Errorf("mail: failover mailer [%s] includes itself", name)
+	}
+	creating[name] = true
+	defer delete(creating, name)
+
+	config, err := m.mailerConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	driver := stringOption(config, "transport", "")
+
+	m.mu.RLock()
+	creator, custom := m.creators[driver]
+	m.mu.RUnlock()
+	if custom {
+		return creator(m, name, config)
+	}
+
+	switch driver {
+	case "smtp":
+		return smtpTransport(config)
+	case "log":
+		return NewLogTransport(m.logger).SetChannel(stringOption(config, "channel", "")), nil
+	case "array":
+		return NewArrayTransport(), nil
+	case "failover":
+		mailers := stringSliceOption(config, "mailers")
+		if len(mailers) == 0 {
+			return nil, fmt.Errorf("mail: failover mailer [%s] has no mailers", name)
+		}
+		transports := make([]Transport, 0, len(mailers))
+		for _, mailer := range mailers {
+			transport, err := m.createTransport(mailer, creating)
+			if err != nil {
+				return nil, err
+			}
+			transports = append(transports, transport)
+		}
+		retryAfter := time.Duration(intOption(config, "retry_after", 60)) * time.Second
+		return NewFailoverTransport(retryAfter, transports...), nil
+	default:
+		return nil, &MailerNotDefinedError{Name: name, Transport: driver}
+	}
+}
+
+// mailerConfig returns the configuration of a mailer
+func (m *MailManager) mailerConfig(name string) (map[string]interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if config, exists := m.mailerConfigs[name]; exists {
+		return config, nil
+	}
+	if m.config != nil {
+		if value, exists := m.config.Get("mail.mailers." + name); exists {
+			if config, ok := value.(map[string]interface{}); ok {
+				return config, nil
+			}
+		}
+	}
+	return nil, &MailerNotDefinedError{Name: name}
+}
+
+// fromAddress returns the default sender. The caller must hold mu.
+func (m *MailManager) fromAddress() string {
+	if m.from != "" || m.config == nil {
+		return m.from
+	}
+	address := m.config.GetString("mail.from.address", "")
+	if address == "" {
+		return ""
+	}
+	from := netmail.Address{Name: m.config.GetString("mail.from.name", ""), Address: address}
+	return from.String()
+}
+
+// smtpTransport creates an SMTP transport from a mailer configuration
+func smtpTransport(config map[string]interface{}) (*SMTPTransport, error) {
+	var transport *SMTPTransport
+	if rawURL := stringOption(config, "url", ""); rawURL != "" {
+		parsed, err := NewSMTPTransportFromURL(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		transport = parsed
+	} else {
+		transport = NewSMTPTransport(stringOption(config, "host", "127.0.0.1"), intOption(config, "port", 587)).
+			SetScheme(stringOption(config, "scheme", "")).
+			SetAuth(stringOption(config, "username", ""), stringOption(config, "password", ""))
+	}
+
+	if seconds := intOption(config, "timeout", 0); seconds > 0 {
+		transport.SetTimeout(time.Duration(seconds) * time.Second)
+	}
+	transport.SetLocalDomain(localDomain(stringOption(config, "local_domain", "")))
+	return transport, nil
+}
+
+// localDomain returns the EHLO domain of a configured value, which may be
+// the application URL
+func localDomain(value string) string {
+	if strings.Contains(value, "://") {
+		if parsed, err := url.Parse(value); err == nil {
+			return parsed.Hostname()
+		}
+	}
+	return value
+}
+
+// stringOption returns a string option or the fallback
+func stringOption(config map[string]interface{}, key string, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// intOption returns an integer option or the fallback
+func intOption(config map[string]interface{}, key string, fallback int) int {
+	switch value := config[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case string:
+		var parsed int
+		if _, err := fmt.Sscan(value, &parsed); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// stringSliceOption returns a list of strings option
+func stringSliceOption(config map[string]interface{}, key string) []string {
+	switch value := config[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Compile-time interface compliance check
+var _ mailInterfaces.MailInterface = (*MailManager)(nil)
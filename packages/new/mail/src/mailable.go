@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Mailable is a reusable message defined by the application. Build fills
+// in the message, usually rendering it from templates with View and
+// TextView:
+//
+//	type WelcomeMail struct {
+//		User *User
+//	}
+//
+//	func (w *WelcomeMail) Build(message *mail.Message) error {
+//		message.To(w.User.Email).
+//			Subject("Welcome!").
+//			View("emails/welcome.html", w).
+//			TextView("emails/welcome.txt", w)
+//		return nil
+//	}
+//
+// Mailables that also implement ShouldQueue() bool returning true are
+// queued by Mailer.SendMailable instead of being sent immediately.
+type Mailable interface {
+	// Build fills in the message
+	Build(message *Message) error
+}
+
+// shouldQueue is implemented by mailables and commands sent in the
+// background, matching the bus Queueable trait
+type shouldQueue interface {
+	ShouldQueue() bool
+}
+
+// SendQueuedMessage is the bus command dispatched for queued mail. Its
+// message is fully rendered, so handling it only delivers it.
+//
+// The command serializes to JSON. Handling resolves the mailer by name
+// from the MailManager whose mailer queued it; queue workers handling a
+// deserialized command attach their manager with SetManager first.
+type SendQueuedMessage struct {
+	// Mailer is the name of the mailer that queued the message
+	Mailer string `json:"mailer"`
+
+	// Message is the rendered message
+	Message *Message `json:"message"`
+
+	// Delay is how long the queue waits before sending
+	Delay time.Duration `json:"delay"`
+
+	// manager resolves the mailer, not serialized
+	manager *MailManager
+}
+
+// SetManager sets the mail manager resolving the mailer of the command
+func (c *SendQueuedMessage) SetManager(manager *MailManager) *SendQueuedMessage {
+	c.manager = manager
+	return c
+}
+
+// Handle delivers the message through the mailer it was queued from
+func (c *SendQueuedMessage) Handle(ctx context.Context) (interface{}, error) {
+	if c.manager == nil {
+		return nil, ErrNoMailManager
+	}
+	if c.Message == nil {
+		return nil, fmt.Errorf("mail: queued mail for mailer [%s] has no message", c.Mailer)
+	}
+
+	mailer, err := c.manager.Mailer(c.Mailer)
+	if err != nil {
+		return nil, err
+	}
+	return nil, mailer.Deliver(ctx, c.Message)
+}
+
+// ShouldQueue marks the command for the queue
+func (c *SendQueuedMessage) ShouldQueue() bool {
+	return true
+}
+
+// GetDelay returns how long the queue waits before sending
+func (c *SendQueuedMessage) GetDelay() time.Duration {
+	return c.Delay
+}
+
+// DisplayName returns a human-readable name for the job
+func (c *SendQueuedMessage) DisplayName() string {
+	return "Send Queued Mail"
+}
@@ -0,0 +1,247 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	busInterfaces "govel/new/bus/interfaces"
+	mailInterfaces "govel/types/interfaces/mail"
+)
+
+// Mailer sends messages through a transport, filling in the default
+// sender and rendering template views.
+//
+// Example:
+//
+//	mailer := mail.NewMailer("smtp", mail.NewSMTPTransport("smtp.example.com", 587)).
+//		SetFrom("Example <hello@example.com>")
+//
+//	err := mailer.SendMessage(mailer.NewMessage().
+//		To("alice@example.com").
+//		Subject("Monthly Newsletter").
+//		Html(`<img src="cid:logo"><p>Welcome!</p>`).
+//		Embed("/path/to/logo.png", "logo"))
+type Mailer struct {
+	// name is the mailer name from mail.mailers
+	name string
+
+	// transport delivers messages
+	transport Transport
+
+	// from is the default sender, empty for none
+	from string
+
+	// renderer renders template views
+	renderer Renderer
+
+	// dispatcher queues mail through the command bus
+	dispatcher busInterfaces.Dispatcher
+
+	// manager created the mailer and resolves it when queued mail is
+	// handled, nil for standalone mailers
+	manager *MailManager
+}
+
+// NewMailer creates a mailer sending through transport
+func NewMailer(name string, transport Transport) *Mailer {
+	return &Mailer{name: name, transport: transport}
+}
+
+// Name returns the mailer name
+func (m *Mailer) Name() string {
+	return m.name
+}
+
+// Transport returns the transport
+func (m *Mailer) Transport() Transport {
+	return m.transport
+}
+
+// SetFrom sets the default sender of messages without a From address
+func (m *Mailer) SetFrom(address string) *Mailer {
+	m.from = address
+	return m
+}
+
+// SetRenderer sets the renderer of template views
+func (m *Mailer) SetRenderer(renderer Renderer) *Mailer {
+	m.renderer = renderer
+	return m
+}
+
+// SetDispatcher sets the command bus used to queue mail
+func (m *Mailer) SetDispatcher(dispatcher busInterfaces.Dispatcher) *Mailer {
+	m.dispatcher = dispatcher
+	return m
+}
+
+// NewMessage creates a message from the default sender
+func (m *Mailer) NewMessage() *Message {
+	message := NewMessage()
+	if m.from != "" {
+		message.From(m.from)
+	}
+	return message
+}
+
+// Send sends a plain text message to a single recipient
+func (m *Mailer) Send(to, subject, body string) error {
+	return m.SendMessage(m.NewMessage().To(to).Subject(subject).Text(body))
+}
+
+// SendToMany sends a plain text message to every recipient
+func (m *Mailer) SendToMany(to []string, subject, body string) error {
+	return m.SendMessage(m.NewMessage().To(to...).Subject(subject).Text(body))
+}
+
+// SendPlain sends a plain text message with a context; its signature
+// matches the health check MailSendFunc
+func (m *Mailer) SendPlain(ctx context.Context, to []string, subject, body string) error {
+	return m.Deliver(ctx, m.NewMessage().To(to...).Subject(subject).Text(body))
+}
+
+// SendTemplate sends a message rendered from "<template>.html", with
+// "<template>.txt" as the text alternative when it exists
+func (m *Mailer) SendTemplate(to, subject, template string, data map[string]interface{}) error {
+	message, err := m.templateMessage(to, subject, template, data)
+	if err != nil {
+		return err
+	}
+	return m.SendMessage(message)
+}
+
+// SendMessage sends the message
+func (m *Mailer) SendMessage(message *Message) error {
+	return m.Deliver(context.Background(), message)
+}
+
+// Deliver renders and sends the message, stopping when ctx is done
+func (m *Mailer) Deliver(ctx context.Context, message *Message) error {
+	if err := m.prepare(message); err != nil {
+		return err
+	}
+	return m.transport.Send(ctx, message)
+}
+
+// SendMailable builds and sends the mailable, queueing it when it
+// implements ShouldQueue() returning true
+func (m *Mailer) SendMailable(ctx context.Context, mailable Mailable) error {
+	if queueable, ok := mailable.(shouldQueue); ok && queueable.ShouldQueue() {
+		return m.QueueMailable(ctx, mailable)
+	}
+	message, err := m.build(mailable)
+	if err != nil {
+		return err
+	}
+	return m.Deliver(ctx, message)
+}
+
+// QueueMailable builds the mailable and queues it
+func (m *Mailer) QueueMailable(ctx context.Context, mailable Mailable) error {
+	message, err := m.build(mailable)
+	if err != nil {
+		return err
+	}
+	var delay time.Duration
+	if delayed, ok := mailable.(interface{ GetDelay() time.Duration }); ok {
+		delay = delayed.GetDelay()
+	}
+	return m.QueueMessage(ctx, message, delay)
+}
+
+// Queue renders a template message and queues it
+func (m *Mailer) Queue(to, subject, template string, data map[string]interface{}) error {
+	message, err := m.templateMessage(to, subject, template, data)
+	if err != nil {
+		return err
+	}
+	return m.QueueMessage(context.Background(), message, 0)
+}
+
+// Later queues a plain text message to be sent at the given time
+func (m *Mailer) Later(at time.Time, to, subject, body string) error {
+	message := m.NewMessage().To(to).Subject(subject).Text(body)
+	return m.QueueMessage(context.Background(), message, time.Until(at))
+}
+
+// QueueMessage renders the message and dispatches a SendQueuedMessage
+// command, sent after delay. Only mailers created by a MailManager can
+// queue, since the manager resolves the mailer by name when the command is
+// handled.
+func (m *Mailer) QueueMessage(ctx context.Context, message *Message, delay time.Duration) error {
+	if m.dispatcher == nil {
+		return ErrNoDispatcher
+	}
+	if m.manager == nil {
+		return ErrNoMailManager
+	}
+	if err := m.prepare(message); err != nil {
+		return err
+	}
+	if err := message.Err(); err != nil {
+		return err
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	_, err := m.dispatcher.Dispatch(ctx, &SendQueuedMessage{
+		Mailer:  m.name,
+		Message: message,
+		Delay:   delay,
+		manager: m.manager,
+	})
+	return err
+}
+
+// build creates a message from a mailable
+func (m *Mailer) build(mailable Mailable) (*Message, error) {
+	message := m.NewMessage()
+	if err := mailable.Build(message); err != nil {
+		return nil, fmt.Errorf("mail: build %T: %w", mailable, err)
+	}
+	return message, nil
+}
+
+// templateMessage creates a message rendered from template views
+func (m *Mailer) templateMessage(to, subject, template string, data map[string]interface{}) (*Message, error) {
+	if m.renderer == nil {
+		return nil, ErrNoRenderer
+	}
+	message := m.NewMessage().To(to).Subject(subject).View(template+".html", data)
+	if m.renderer.Exists(template + ".txt") {
+		message.TextView(template+".txt", data)
+	}
+	return message, nil
+}
+
+// prepare fills in the default sender and renders pending views
+func (m *Mailer) prepare(message *Message) error {
+	if message.from == nil && m.from != "" {
+		message.From(m.from)
+	}
+	if message.htmlView == nil && message.textView == nil {
+		return nil
+	}
+	if m.renderer == nil {
+		return ErrNoRenderer
+	}
+	if message.htmlView != nil {
+		html, err := m.renderer.Render(message.htmlView.Name, message.htmlView.Data)
+		if err != nil {
+			return fmt.Errorf("mail: render %s: %w", message.htmlView.Name, err)
+		}
+		message.html, message.htmlView = html, nil
+	}
+	if message.textView != nil {
+		text, err := m.renderer.Render(message.textView.Name, message.textView.Data)
+		if err != nil {
+			return fmt.Errorf("mail: render %s: %w", message.textView.Name, err)
+		}
+		message.text, message.textView = text, nil
+	}
+	return nil
+}
+
+// Compile-time interface compliance check
+var _ mailInterfaces.MailInterface = (*Mailer)(nil)
@@ -0,0 +1,354 @@
+package mail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Attachment is a file attached to a message. Inline attachments are
+// referenced from the HTML body as "cid:<ContentID>".
+type Attachment struct {
+	// Filename is the name shown to the recipient
+	Filename string `json:"filename"`
+
+	// ContentType is the MIME type, detected from Filename when empty
+	ContentType string `json:"content_type,omitempty"`
+
+	// Data is the file content
+	Data []byte `json:"data"`
+
+	// ContentID identifies inline attachments, empty for regular ones
+	ContentID string `json:"content_id,omitempty"`
+}
+
+// Inline reports whether the attachment is embedded in the HTML body
+func (a Attachment) Inline() bool {
+	return a.ContentID != ""
+}
+
+// view is a template rendered into a message body when it is sent
+type view struct {
+	// Name is the template name
+	Name string
+
+	// Data is passed to the template
+	Data interface{}
+}
+
+// Message is an email message built with chained setters:
+//
+//	message := mail.NewMessage().
+//		From("Example <hello@example.com>").
+//		To("alice@example.com").
+//		Subject("Invoice #12345").
+//		Text("Please find your invoice attached.").
+//		Attach("/path/to/invoice.pdf")
+//
+// Setters never fail; invalid addresses and unreadable files are reported
+// by Err and when the message is sent.
+type Message struct {
+	from     *netmail.Address
+	replyTo  []*netmail.Address
+	to       []*netmail.Address
+	cc       []*netmail.Address
+	bcc      []*netmail.Address
+	subject  string
+	text     string
+	html     string
+	textView *view
+	htmlView *view
+	headers  map[string]string
+	files    []Attachment
+	date     time.Time
+	id       string
+	errs     []error
+}
+
+// NewMessage creates an empty message
+func NewMessage() *Message {
+	return &Message{headers: make(map[string]string)}
+}
+
+// From sets the sender
+func (m *Message) From(address string) *Message {
+	if parsed := m.parse(address); parsed != nil {
+		m.from = parsed
+	}
+	return m
+}
+
+// ReplyTo adds reply-to addresses
+func (m *Message) ReplyTo(addresses ...string) *Message {
+	m.replyTo = m.appendAddresses(m.replyTo, addresses)
+	return m
+}
+
+// To adds recipients
+func (m *Message) To(addresses ...string) *Message {
+	m.to = m.appendAddresses(m.to, addresses)
+	return m
+}
+
+// Cc adds carbon copy recipients
+func (m *Message) Cc(addresses ...string) *Message {
+	m.cc = m.appendAddresses(m.cc, addresses)
+	return m
+}
+
+// Bcc adds blind carbon copy recipients; they receive the message but are
+// left out of its headers
+func (m *Message) Bcc(addresses ...string) *Message {
+	m.bcc = m.appendAddresses(m.bcc, addresses)
+	return m
+}
+
+// Subject sets the subject
+func (m *Message) Subject(subject string) *Message {
+	m.subject = subject
+	return m
+}
+
+// Text sets the plain text body
+func (m *Message) Text(body string) *Message {
+	m.text = body
+	return m
+}
+
+// Body is an alias of Text
+func (m *Message) Body(body string) *Message {
+	return m.Text(body)
+}
+
+// Html sets the HTML body; a plain text body set alongside it is sent as
+// the alternative for clients that do not display HTML
+func (m *Message) Html(body string) *Message {
+	m.html = body
+	return m
+}
+
+// View renders the named template into the HTML body when the message is
+// sent
+func (m *Message) View(name string, data interface{}) *Message {
+	m.htmlView = &view{Name: name, Data: data}
+	return m
+}
+
+// TextView renders the named template into the plain text body when the
+// message is sent
+func (m *Message) TextView(name string, data interface{}) *Message {
+	m.textView = &view{Name: name, Data: data}
+	return m
+}
+
+// Header sets a custom header such as "X-Priority"; names that are not
+// RFC 5322 field names are recorded as errors
+func (m *Message) Header(name, value string) *Message {
+	if !validHeaderName(name) {
+		m.errs = append(m.errs, fmt.Errorf("%w %q", ErrInvalidHeader, name))
+		return m
+	}
+	m.headers[textprotoKey(name)] = value
+	return m
+}
+
+// Attach attaches a file from disk
+func (m *Message) Attach(path string) *Message {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.errs = append(m.errs, fmt.Errorf("mail: attach %s: %w", path, err))
+		return m
+	}
+	return m.AttachData(filepath.Base(path), data, "")
+}
+
+// AttachData attaches in-memory content; the content type is detected
+// from the file name when empty
+func (m *Message) AttachData(filename string, data []byte, contentType string) *Message {
+	m.files = append(m.files, Attachment{Filename: filename, ContentType: contentType, Data: data})
+	return m
+}
+
+// Embed embeds an image from disk, referenced in the HTML body as
+// "cid:<contentID>"
+func (m *Message) Embed(path, contentID string) *Message {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.errs = append(m.errs, fmt.Errorf("mail: embed %s: %w", path, err))
+		return m
+	}
+	return m.EmbedData(contentID, filepath.Base(path), data, "")
+}
+
+// EmbedData embeds in-memory content referenced as "cid:<contentID>"
+func (m *Message) EmbedData(contentID, filename string, data []byte, contentType string) *Message {
+	m.files = append(m.files, Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		Data:        data,
+		ContentID:   contentID,
+	})
+	return m
+}
+
+// Date sets the Date header, the send time by default
+func (m *Message) Date(date time.Time) *Message {
+	m.date = date
+	return m
+}
+
+// MessageID sets the Message-ID header, generated by default
+func (m *Message) MessageID(id string) *Message {
+	m.id = strings.Trim(id, "<>")
+	return m
+}
+
+// GetFrom returns the sender, nil when unset
+func (m *Message) GetFrom() *netmail.Address {
+	return m.from
+}
+
+// GetTo returns the recipients
+func (m *Message) GetTo() []*netmail.Address {
+	return m.to
+}
+
+// GetCc returns the carbon copy recipients
+func (m *Message) GetCc() []*netmail.Address {
+	return m.cc
+}
+
+// GetBcc returns the blind carbon copy recipients
+func (m *Message) GetBcc() []*netmail.Address {
+	return m.bcc
+}
+
+// GetSubject returns the subject
+func (m *Message) GetSubject() string {
+	return m.subject
+}
+
+// GetText returns the plain text body
+func (m *Message) GetText() string {
+	return m.text
+}
+
+// GetHtml returns the HTML body
+func (m *Message) GetHtml() string {
+	return m.html
+}
+
+// GetHeader returns a custom header
+func (m *Message) GetHeader(name string) string {
+	return m.headers[textprotoKey(name)]
+}
+
+// Attachments returns the attachments, inline ones included
+func (m *Message) Attachments() []Attachment {
+	return m.files
+}
+
+// Recipients returns the envelope recipients: To, Cc and Bcc
+func (m *Message) Recipients() []string {
+	recipients := make([]string, 0, len(m.to)+len(m.cc)+len(m.bcc))
+	for _, list := range [][]*netmail.Address{m.to, m.cc, m.bcc} {
+		for _, address := range list {
+			recipients = append(recipients, address.Address)
+		}
+	}
+	return recipients
+}
+
+// Err returns the errors collected while building the message, and an
+// error when it has no sender or recipients
+func (m *Message) Err() error {
+	errs := append([]error(nil), m.errs...)
+	if m.from == nil {
+		errs = append(errs, ErrNoSender)
+	}
+	if len(m.to)+len(m.cc)+len(m.bcc) == 0 {
+		errs = append(errs, ErrNoRecipients)
+	}
+	return errors.Join(errs...)
+}
+
+// Bytes encodes the message as MIME, generating the Date and Message-ID
+// headers when unset
+func (m *Message) Bytes() ([]byte, error) {
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	if m.date.IsZero() {
+		m.date = time.Now()
+	}
+	if m.id == "" {
+		m.id = generateMessageID(m.from.Address)
+	}
+	return encode(m), nil
+}
+
+// Clone returns a copy of the message that can be changed independently
+func (m *Message) Clone() *Message {
+	clone := *m
+	clone.replyTo = append([]*netmail.Address(nil), m.replyTo...)
+	clone.to = append([]*netmail.Address(nil), m.to...)
+	clone.cc = append([]*netmail.Address(nil), m.cc...)
+	clone.bcc = append([]*netmail.Address(nil), m.bcc...)
+	clone.files = append([]Attachment(nil), m.files...)
+	clone.errs = append([]error(nil), m.errs...)
+	clone.headers = make(map[string]string, len(m.headers))
+	for name, value := range m.headers {
+		clone.headers[name] = value
+	}
+	return &clone
+}
+
+// parse parses an address, recording the error when it is invalid
+func (m *Message) parse(address string) *netmail.Address {
+	parsed, err := netmail.ParseAddress(address)
+	if err != nil {
+		m.errs = append(m.errs, fmt.Errorf("mail: invalid address %q: %w", address, err))
+		return nil
+	}
+	return parsed
+}
+
+// appendAddresses parses and appends addresses to a list
+func (m *Message) appendAddresses(list []*netmail.Address, addresses []string) []*netmail.Address {
+	for _, address := range addresses {
+		if parsed := m.parse(address); parsed != nil {
+			list = append(list, parsed)
+		}
+	}
+	return list
+}
+
+// generateMessageID returns a random Message-ID in the sender's domain
+func generateMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf) + "@" + domain
+}
+
+// contentType returns the attachment content type, detected from the file
+// name when unset
+func contentType(attachment Attachment) string {
+	if attachment.ContentType != "" {
+		return attachment.ContentType
+	}
+	if detected := mime.TypeByExtension(filepath.Ext(attachment.Filename)); detected != "" {
+		return detected
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"time"
+)
+
+// messageJSON is the serialized form of a rendered message, used when
+// queued mail crosses a process boundary
+type messageJSON struct {
+	From        string            `json:"from"`
+	ReplyTo     []string          `json:"reply_to,omitempty"`
+	To          []string          `json:"to,omitempty"`
+	Cc          []string          `json:"cc,omitempty"`
+	Bcc         []string          `json:"bcc,omitempty"`
+	Subject     string            `json:"subject"`
+	Text        string            `json:"text,omitempty"`
+	Html        string            `json:"html,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	Date        time.Time         `json:"date"`
+	MessageID   string            `json:"message_id,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Only rendered, valid messages are
+// serialized: template views must have been rendered (as QueueMessage
+// does) and the message must have no errors.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	if m.htmlView != nil || m.textView != nil {
+		return nil, errors.New("mail: cannot serialize a message with unrendered views")
+	}
+	if err := m.Err(); err != nil {
+		return nil, fmt.Errorf("mail: cannot serialize an invalid message: %w", err)
+	}
+
+	return json.Marshal(messageJSON{
+		From:        m.from.String(),
+		ReplyTo:     formatAddresses(m.replyTo),
+		To:          formatAddresses(m.to),
+		Cc:          formatAddresses(m.cc),
+		Bcc:         formatAddresses(m.bcc),
+		Subject:     m.subject,
+		Text:        m.text,
+		Html:        m.html,
+		Headers:     m.headers,
+		Attachments: m.files,
+		Date:        m.date,
+		MessageID:   m.id,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Addresses and header names
+// are validated again, so a tampered payload cannot inject headers.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var decoded messageJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	message := NewMessage().
+		From(decoded.From).
+		ReplyTo(decoded.ReplyTo...).
+		To(decoded.To...).
+		Cc(decoded.Cc...).
+		Bcc(decoded.Bcc...).
+		Subject(decoded.Subject).
+		Text(decoded.Text).
+		Html(decoded.Html).
+		Date(decoded.Date).
+		MessageID(decoded.MessageID)
+	for name, value := range decoded.Headers {
+		message.Header(name, value)
+	}
+	message.files = decoded.Attachments
+
+	if err := message.Err(); err != nil {
+		return fmt.Errorf("mail: invalid serialized message: %w", err)
+	}
+	*m = *message
+	return nil
+}
+
+// formatAddresses formats addresses for serialization
+func formatAddresses(addresses []*netmail.Address) []string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	return formatted
+}
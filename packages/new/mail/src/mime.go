@@ -0,0 +1,200 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// maxLineLength is the length base64 content is wrapped at (RFC 2045)
+const maxLineLength = 76
+
+// entity is a MIME part: its headers and encoded body
+type entity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// textprotoKey canonicalizes a header name
+func textprotoKey(name string) string {
+	return textproto.CanonicalMIMEHeaderKey(name)
+}
+
+// validHeaderName reports whether name is an RFC 5322 field name: one or
+// more printable US-ASCII characters other than the colon
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] < 33 || name[i] > 126 || name[i] == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// stripLineBreaks removes CR and LF so a value cannot start a new header
+func stripLineBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// encode writes the message headers followed by its MIME body. The body
+// nests multipart/alternative (text and HTML) in multipart/related
+// (inline images) in multipart/mixed (attachments), leaving out the
+// levels the message does not need.
+func encode(m *Message) []byte {
+	var buf bytes.Buffer
+
+	writeHeader(&buf, "From", m.from.String())
+	if len(m.replyTo) > 0 {
+		writeHeader(&buf, "Reply-To", formatAddressList(m.replyTo))
+	}
+	if len(m.to) > 0 {
+		writeHeader(&buf, "To", formatAddressList(m.to))
+	}
+	if len(m.cc) > 0 {
+		writeHeader(&buf, "Cc", formatAddressList(m.cc))
+	}
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", m.subject))
+	writeHeader(&buf, "Date", m.date.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	writeHeader(&buf, "Message-ID", "<"+m.id+">")
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	names := make([]string, 0, len(m.headers))
+	for name := range m.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(&buf, name, mime.QEncoding.Encode("utf-8", m.headers[name]))
+	}
+
+	body := bodyEntity(m)
+	writeEntityHeader(&buf, body.header)
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+	return buf.Bytes()
+}
+
+// bodyEntity builds the MIME structure of the message body
+func bodyEntity(m *Message) entity {
+	var alternatives []entity
+	if m.text != "" || m.html == "" {
+		alternatives = append(alternatives, textEntity("text/plain", m.text))
+	}
+	if m.html != "" {
+		alternatives = append(alternatives, textEntity("text/html", m.html))
+	}
+
+	content := alternatives[0]
+	if len(alternatives) > 1 {
+		content = multipartEntity("alternative", alternatives)
+	}
+
+	var inline, attached []entity
+	for _, attachment := range m.files {
+		if attachment.Inline() {
+			inline = append(inline, attachmentEntity(attachment))
+		} else {
+			attached = append(attached, attachmentEntity(attachment))
+		}
+	}
+	if len(inline) > 0 {
+		content = multipartEntity("related", append([]entity{content}, inline...))
+	}
+	if len(attached) > 0 {
+		content = multipartEntity("mixed", append([]entity{content}, attached...))
+	}
+	return content
+}
+
+// textEntity encodes a UTF-8 text part as quoted-printable
+func textEntity(mediaType, content string) entity {
+	var body bytes.Buffer
+	writer := quotedprintable.NewWriter(&body)
+	_, _ = writer.Write([]byte(content))
+	_ = writer.Close()
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", mediaType+"; charset=utf-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return entity{header: header, body: body.Bytes()}
+}
+
+// attachmentEntity encodes an attachment as base64
+func attachmentEntity(attachment Attachment) entity {
+	disposition := "attachment"
+	filename := stripLineBreaks(attachment.Filename)
+	header := make(textproto.MIMEHeader)
+	if attachment.Inline() {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+stripLineBreaks(attachment.ContentID)+">")
+	}
+	header.Set("Content-Type", mime.FormatMediaType(contentType(attachment), map[string]string{"name": filename}))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": filename}))
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	var body bytes.Buffer
+	for len(encoded) > maxLineLength {
+		body.WriteString(encoded[:maxLineLength])
+		body.WriteString("\r\n")
+		encoded = encoded[maxLineLength:]
+	}
+	body.WriteString(encoded)
+	return entity{header: header, body: body.Bytes()}
+}
+
+// multipartEntity wraps parts in a multipart entity of the given subtype
+func multipartEntity(subtype string, parts []entity) entity {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		partWriter, _ := writer.CreatePart(part.header)
+		_, _ = partWriter.Write(part.body)
+	}
+	_ = writer.Close()
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", "multipart/"+subtype+"; boundary="+writer.Boundary())
+	return entity{header: header, body: body.Bytes()}
+}
+
+// writeHeader writes a single header line
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+// writeEntityHeader writes entity headers in a stable order
+func writeEntityHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			writeHeader(buf, name, value)
+		}
+	}
+}
+
+// formatAddressList formats addresses for a header, folding the line
+// between addresses
+func formatAddressList(addresses []*netmail.Address) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	return strings.Join(formatted, ",\r\n ")
+}
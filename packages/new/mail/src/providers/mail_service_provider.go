@@ -0,0 +1,114 @@
+// Package providers contains service provider implementations for the mail package.
+// Service providers are responsible for registering mail services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	busInterfaces "govel/new/bus/interfaces"
+	mail "govel/new/mail"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	configInterfaces "govel/types/interfaces/config"
+	loggerInterfaces "govel/types/interfaces/logger"
+	mailInterfaces "govel/types/interfaces/mail"
+)
+
+// MailServiceProvider implements a Laravel-compatible service provider
+// for the mail package.
+//
+// Services registered:
+//   - MAIL_TOKEN / MAIL_INTERFACE_TOKEN / MAIL_MANAGER_TOKEN / MAIL_FACTORY_TOKEN:
+//     Singleton MailManager reading mail.* from "config". "log" transports
+//     write to LOGGER_TOKEN when bound, and mail is queued through the
+//     "bus" dispatcher when bound
+type MailServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewMailServiceProvider creates a new MailServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewMailServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register mail services:", err)
+//	}
+func NewMailServiceProvider() *MailServiceProvider {
+	return &MailServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all mail services in the dependency injection container.
+func (p *MailServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager      *mail.MailManager
+		managerMutex sync.Mutex
+	)
+	managerFactory := func() (interface{}, error) {
+		managerMutex.Lock()
+		defer managerMutex.Unlock()
+
+		if manager != nil {
+			return manager, nil
+		}
+
+		config, err := application.Make("config")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config from container: %w", err)
+		}
+		configInterface, ok := config.(configInterfaces.ConfigInterface)
+		if !ok {
+			return nil, fmt.Errorf("config service does not implement ConfigInterface, got %T", config)
+		}
+
+		var logger loggerInterfaces.LoggerInterface
+		if application.IsBound(loggerInterfaces.LOGGER_TOKEN) {
+			if resolved, err := application.Make(loggerInterfaces.LOGGER_TOKEN); err == nil {
+				logger, _ = resolved.(loggerInterfaces.LoggerInterface)
+			}
+		}
+		manager = mail.NewMailManager(configInterface, logger)
+
+		if application.IsBound("bus") {
+			if resolved, err := application.Make("bus"); err == nil {
+				if dispatcher, ok := resolved.(busInterfaces.Dispatcher); ok {
+					manager.SetDispatcher(dispatcher)
+				}
+			}
+		}
+		return manager, nil
+	}
+
+	for _, token := range []interface{}{
+		mailInterfaces.MAIL_TOKEN,
+		mailInterfaces.MAIL_INTERFACE_TOKEN,
+		mailInterfaces.MAIL_MANAGER_TOKEN,
+		mailInterfaces.MAIL_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, managerFactory); err != nil {
+			return fmt.Errorf("failed to bind mail manager: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *MailServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		mailInterfaces.MAIL_TOKEN,
+		mailInterfaces.MAIL_INTERFACE_TOKEN,
+		mailInterfaces.MAIL_MANAGER_TOKEN,
+		mailInterfaces.MAIL_FACTORY_TOKEN,
+	}
+}
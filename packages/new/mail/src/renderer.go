@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	textTemplate "text/template"
+)
+
+// Renderer renders the templates of mail views
+type Renderer interface {
+	// Render renders the named template with data
+	Render(name string, data interface{}) (string, error)
+
+	// Exists reports whether the named template exists
+	Exists(name string) bool
+}
+
+// TemplateRenderer renders mail views from a file system. Templates ending
+// in ".txt" are parsed with text/template, all others with html/template
+// so data is escaped in HTML bodies. Parsed templates are cached.
+//
+// Example:
+//
+//	//go:embed emails
+//	var emails embed.FS
+//
+//	renderer := mail.NewTemplateRenderer(emails)
+//	html, err := renderer.Render("emails/welcome.html", data)
+type TemplateRenderer struct {
+	// fsys holds the template files
+	fsys fs.FS
+
+	// funcs are available to every template
+	funcs map[string]interface{}
+
+	// mu guards cache
+	mu sync.Mutex
+
+	// cache holds parsed templates by name
+	cache map[string]func(*bytes.Buffer, interface{}) error
+}
+
+// NewTemplateRenderer creates a renderer reading templates from fsys
+func NewTemplateRenderer(fsys fs.FS) *TemplateRenderer {
+	return &TemplateRenderer{
+		fsys:  fsys,
+		funcs: make(map[string]interface{}),
+		cache: make(map[string]func(*bytes.Buffer, interface{}) error),
+	}
+}
+
+// Funcs adds functions available to templates parsed afterwards
+func (r *TemplateRenderer) Funcs(funcs map[string]interface{}) *TemplateRenderer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, fn := range funcs {
+		r.funcs[name] = fn
+	}
+	return r
+}
+
+// Render renders the named template with data
+func (r *TemplateRenderer) Render(name string, data interface{}) (string, error) {
+	execute, err := r.template(name)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Exists reports whether the named template exists
+func (r *TemplateRenderer) Exists(name string) bool {
+	_, err := fs.Stat(r.fsys, name)
+	return err == nil
+}
+
+// template returns the parsed template, parsing it on first use
+func (r *TemplateRenderer) template(name string) (func(*bytes.Buffer, interface{}) error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if execute, exists := r.cache[name]; exists {
+		return execute, nil
+	}
+
+	source, err := fs.ReadFile(r.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var execute func(*bytes.Buffer, interface{}) error
+	if strings.HasSuffix(name, ".txt") {
+		parsed, err := textTemplate.New(path.Base(name)).Funcs(r.funcs).Parse(string(source))
+		if err != nil {
+			return nil, err
+		}
+		execute = func(buf *bytes.Buffer, data interface{}) error { return parsed.Execute(buf, data) }
+	} else {
+		parsed, err := htmlTemplate.New(path.Base(name)).Funcs(r.funcs).Parse(string(source))
+		if err != nil {
+			return nil, err
+		}
+		execute = func(buf *bytes.Buffer, data interface{}) error { return parsed.Execute(buf, data) }
+	}
+	r.cache[name] = execute
+	return execute, nil
+}
+
+// Compile-time interface compliance check
+var _ Renderer = (*TemplateRenderer)(nil)
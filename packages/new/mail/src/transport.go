@@ -0,0 +1,12 @@
+package mail
+
+import "context"
+
+// Transport delivers encoded messages
+type Transport interface {
+	// Send delivers the message to its recipients
+	Send(ctx context.Context, message *Message) error
+
+	// String returns the transport name for logs and errors
+	String() string
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"govel/container"
+	"govel/healthcheck/checks"
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	"govel/healthcheck/registry"
+	"govel/healthcheck/stores"
+	"govel/new/metrics"
+	"govel/new/metrics/collectors"
+	compilerTypes "govel/support/compiler/types"
+)
+
+func TestRegistry_WritesOpenMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.Counter("http_requests_total", "Handled requests.", "method").Add(3, "GET")
+	registry.Gauge("queue_size", "Pending \"jobs\".").Set(1.5)
+	latency := registry.Histogram("latency_seconds", "Latency.", []float64{0.5, 0.1})
+	latency.Observe(0.05)
+	latency.Observe(0.3)
+	latency.Observe(2)
+
+	recorder := httptest.NewRecorder()
+	metrics.NewHandler(registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	expected := `# TYPE http_requests counter
+# HELP http_requests Handled requests.
+http_requests_total{method="GET"} 3
+# TYPE latency_seconds histogram
+# HELP latency_seconds Latency.
+latency_seconds_bucket{le="0.1"} 1
+latency_seconds_bucket{le="0.5"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_count 3
+latency_seconds_sum 2.35
+# TYPE queue_size gauge
+# HELP queue_size Pending \"jobs\".
+queue_size 1.5
+# EOF
+`
+	if got := recorder.Body.String(); got != expected {
+		t.Errorf("Unexpected exposition:\n%s", got)
+	}
+	if got := recorder.Header().Get("Content-Type"); got != metrics.OpenMetricsContentType {
+		t.Errorf("Unexpected content type %q", got)
+	}
+}
+
+func TestRegistry_RejectsConflictingMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	if registry.Counter("jobs", "Jobs.", "queue") != registry.Counter("jobs_total", "Jobs.", "queue") {
+		t.Error("Expected the existing counter to be returned")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a gauge reusing a counter name")
+		}
+	}()
+	registry.Gauge("jobs", "Jobs.")
+}
+
+// handlerSnapshot mirrors the middleware HandlerMetricsSnapshot.
+type handlerSnapshot struct {
+	HandlerName         string        `json:"handler_name"`
+	TotalRequests       int64         `json:"total_requests"`
+	TotalProcessingTime time.Duration `json:"total_processing_time"`
+}
+
+// statusCheck returns a fixed status.
+type statusCheck struct {
+	*checks.BaseCheck
+	status enums.Status
+}
+
+func (c *statusCheck) Run() interfaces.ResultInterface {
+	return checks.NewResult().SetStatus(c.status)
+}
+
+func TestCollectors_PublishFrameworkStatistics(t *testing.T) {
+	app := container.New()
+	app.Bind("config", "value")
+	app.Make("config")
+
+	compilerMetrics := compilerTypes.NewMetrics()
+	compilerMetrics.RecordCompilation(&compilerTypes.Result{Success: false, CompileTime: 1500 * time.Millisecond})
+
+	health := registry.NewHealthRegistry()
+	health.WithResultStore(stores.NewInMemoryResultStore(5))
+	health.Register("db", &statusCheck{BaseCheck: checks.NewBaseCheck(), status: enums.StatusFailed})
+	health.RunChecksWithTimeout(time.Second)
+
+	registry := metrics.NewRegistry().Register(
+		collectors.NewContainerCollector(app),
+		collectors.NewCompilerCollector(compilerMetrics),
+		collectors.NewMiddlewareCollector("web", func() interface{} {
+			return handlerSnapshot{HandlerName: "web", TotalRequests: 7, TotalProcessingTime: 2 * time.Second}
+		}),
+		collectors.NewHealthCollector(health),
+	)
+
+	var out strings.Builder
+	if err := metrics.WriteOpenMetrics(&out, registry.Gather()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"govel_container_bindings 1",
+		"govel_container_resolutions_total 1",
+		"govel_compiler_compilations_failed_total 1",
+		"govel_compiler_compile_seconds_total 1.5",
+		`govel_middleware_requests_total{handler="web"} 7`,
+		`govel_middleware_processing_seconds_total{handler="web"} 2`,
+		`govel_health_check_status{check="db",status="failed"} 1`,
+		`govel_health_check_status{check="db",status="ok"} 0`,
+		`govel_health_check_duration_seconds{check="db"}`,
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected %q in exposition:\n%s", line, out.String())
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package collectors
+
+import "govel/new/metrics"
+
+// NewCompilerCollector publishes the metrics of the support compiler.
+//
+// Parameters:
+//
+//	compilerMetrics: The compiler metrics, as returned by GetMetrics
+//
+// Returns:
+//
+//	metrics.Collector: The compiler collector
+//
+// Example:
+//
+//	registry.Register(collectors.NewCompilerCollector(compiler.GetMetrics()))
+func NewCompilerCollector(compilerMetrics StatisticsProvider) metrics.Collector {
+	return &statisticsCollector{
+		snapshot: func() interface{} { return compilerMetrics.GetStatistics() },
+		specs: []metricSpec{
+			{key: "total_compilations", name: "govel_compiler_compilations", help: "Compilation attempts.", metricType: metrics.CounterType},
+			{key: "failed_compilations", name: "govel_compiler_compilations_failed", help: "Failed compilations.", metricType: metrics.CounterType},
+			{key: "cache_hits", name: "govel_compiler_cache_hits", help: "Compilations served from the cache.", metricType: metrics.CounterType},
+			{key: "cache_misses", name: "govel_compiler_cache_misses", help: "Compilations missing the cache.", metricType: metrics.CounterType},
+			{key: "total_compile_time", name: "govel_compiler_compile_seconds", help: "Time spent compiling.", metricType: metrics.CounterType},
+			{key: "total_execution_time", name: "govel_compiler_execution_seconds", help: "Time spent executing compiled code.", metricType: metrics.CounterType},
+			{key: "peak_memory_usage", name: "govel_compiler_peak_memory_bytes", help: "Highest memory usage of a compilation.", metricType: metrics.GaugeType},
+			{key: "last_compilation_time", name: "govel_compiler_last_compilation_timestamp_seconds", help: "When the last compilation ran.", metricType: metrics.GaugeType},
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package collectors
+
+import "govel/new/metrics"
+
+// NewContainerCollector publishes the binding and resolution statistics of
+// the service container.
+//
+// Parameters:
+//
+//	container: The container, or anything reporting the same statistics
+//
+// Returns:
+//
+//	metrics.Collector: The container collector
+//
+// Example:
+//
+//	registry.Register(collectors.NewContainerCollector(app.GetContainer()))
+func NewContainerCollector(container StatisticsProvider) metrics.Collector {
+	return &statisticsCollector{
+		snapshot: func() interface{} { return container.GetStatistics() },
+		specs: []metricSpec{
+			{key: "total_bindings", name: "govel_container_bindings", help: "Services bound in the container.", metricType: metrics.GaugeType},
+			{key: "singleton_bindings", name: "govel_container_singleton_bindings", help: "Services bound as singletons.", metricType: metrics.GaugeType},
+			{key: "cached_singletons", name: "govel_container_cached_singletons", help: "Singletons that have been instantiated.", metricType: metrics.GaugeType},
+			{key: "total_resolutions", name: "govel_container_resolutions", help: "Services resolved from the container.", metricType: metrics.CounterType},
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package collectors
+
+import (
+	"govel/new/metrics"
+	"govel/support"
+)
+
+// NewFacadeCollector publishes the statistics of the facade resolution cache.
+//
+// Returns:
+//
+//	metrics.Collector: The facade collector
+func NewFacadeCollector() metrics.Collector {
+	return &statisticsCollector{
+		snapshot: func() interface{} { return support.GetStats() },
+		specs: []metricSpec{
+			{key: "cache_hits", name: "govel_facade_cache_hits", help: "Facade lookups served from the cache.", metricType: metrics.CounterType},
+			{key: "cache_misses", name: "govel_facade_cache_misses", help: "Facade lookups resolved from the container.", metricType: metrics.CounterType},
+			{key: "resolutions", name: "govel_facade_resolutions", help: "Successful facade resolutions.", metricType: metrics.CounterType},
+			{key: "errors", name: "govel_facade_errors", help: "Errors resolving facades.", metricType: metrics.CounterType},
+			{key: "type_assertion_failures", name: "govel_facade_type_assertion_failures", help: "Facades resolving to an unexpected type.", metricType: metrics.CounterType},
+			{key: "cache_evictions", name: "govel_facade_cache_evictions", help: "Services evicted from the facade cache.", metricType: metrics.CounterType},
+			{key: "cache_size", name: "govel_facade_cache_size", help: "Services currently held in the facade cache.", metricType: metrics.GaugeType},
+		},
+	}
+}
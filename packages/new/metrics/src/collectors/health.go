@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"govel/healthcheck/enums"
+	"govel/healthcheck/interfaces"
+	"govel/new/metrics"
+)
+
+// healthStatuses are the statuses published for every check.
+var healthStatuses = []enums.Status{
+	enums.StatusOK,
+	enums.StatusWarning,
+	enums.StatusFailed,
+	enums.StatusCrashed,
+	enums.StatusSkipped,
+}
+
+// HealthCollector publishes the status and latency of every health check
+// from the latest run kept in the registry's result store. Scrapes do not
+// run the checks; without a result store nothing is published.
+type HealthCollector struct {
+	// registry provides the result store
+	registry interfaces.HealthRegistryInterface
+}
+
+// NewHealthCollector creates a collector for the checks of a health registry.
+//
+// Parameters:
+//
+//	registry: The health registry, configured with a result store
+//
+// Returns:
+//
+//	*HealthCollector: The health collector
+func NewHealthCollector(registry interfaces.HealthRegistryInterface) *HealthCollector {
+	return &HealthCollector{registry: registry}
+}
+
+// Collect converts the latest stored run into metric families.
+//
+// Returns:
+//
+//	[]metrics.MetricFamily: Status, duration and run timestamp families
+func (hc *HealthCollector) Collect() []metrics.MetricFamily {
+	store := hc.registry.GetResultStore()
+	if store == nil {
+		return nil
+	}
+
+	results, err := store.Get()
+	if err != nil || results == nil {
+		return nil
+	}
+
+	status := metrics.MetricFamily{
+		Name: "govel_health_check_status",
+		Help: "Status of the health check in the latest run, 1 for the current status.",
+		Type: metrics.GaugeType,
+	}
+	duration := metrics.MetricFamily{
+		Name: "govel_health_check_duration_seconds",
+		Help: "Time the health check took in the latest run.",
+		Type: metrics.GaugeType,
+	}
+
+	for _, result := range results.GetResults() {
+		if result.GetCheck() == nil || result.GetStatus() == nil {
+			continue
+		}
+
+		check := result.GetCheck().GetName()
+		current := result.GetStatus().String()
+
+		for _, candidate := range healthStatuses {
+			value := 0.0
+			if candidate.String() == current {
+				value = 1
+			}
+			status.Samples = append(status.Samples, metrics.Sample{
+				Labels: []metrics.Label{{Name: "check", Value: check}, {Name: "status", Value: candidate.String()}},
+				Value:  value,
+			})
+		}
+
+		duration.Samples = append(duration.Samples, metrics.Sample{
+			Labels: []metrics.Label{{Name: "check", Value: check}},
+			Value:  result.GetDuration().Seconds(),
+		})
+	}
+
+	families := []metrics.MetricFamily{status, duration}
+	if executedAt := results.GetExecutedAt(); !executedAt.IsZero() {
+		families = append(families, metrics.MetricFamily{
+			Name:    "govel_health_last_run_timestamp_seconds",
+			Help:    "When the health checks last ran.",
+			Type:    metrics.GaugeType,
+			Samples: []metrics.Sample{{Value: float64(executedAt.UnixNano()) / 1e9}},
+		})
+	}
+
+	return families
+}
+
+// Compile-time interface compliance check
+var _ metrics.Collector = (*HealthCollector)(nil)
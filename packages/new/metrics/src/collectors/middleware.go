@@ -0,0 +1,39 @@
+package collectors
+
+import "govel/new/metrics"
+
+// NewMiddlewareCollector publishes the metrics of a middleware handler,
+// labelled with the handler name.
+//
+// Parameters:
+//
+//	handler: Name of the handler, used as the "handler" label
+//	snapshot: Function returning the handler's HandlerMetricsSnapshot
+//
+// Returns:
+//
+//	metrics.Collector: The middleware collector
+//
+// Example:
+//
+//	registry.Register(collectors.NewMiddlewareCollector("web", func() interface{} {
+//		return handler.GetMetrics()
+//	}))
+func NewMiddlewareCollector(handler string, snapshot func() interface{}) metrics.Collector {
+	return &statisticsCollector{
+		snapshot: snapshot,
+		labels:   []metrics.Label{{Name: "handler", Value: handler}},
+		specs: []metricSpec{
+			{key: "total_requests", name: "govel_middleware_requests", help: "Requests processed by the middleware handler.", metricType: metrics.CounterType},
+			{key: "successful_requests", name: "govel_middleware_requests_successful", help: "Requests that completed successfully.", metricType: metrics.CounterType},
+			{key: "failed_requests", name: "govel_middleware_requests_failed", help: "Requests that failed.", metricType: metrics.CounterType},
+			{key: "total_processing_time", name: "govel_middleware_processing_seconds", help: "Time spent processing requests.", metricType: metrics.CounterType},
+			{key: "average_processing_time", name: "govel_middleware_processing_average_seconds", help: "Average time to process a request.", metricType: metrics.GaugeType},
+			{key: "min_processing_time", name: "govel_middleware_processing_min_seconds", help: "Shortest time to process a request.", metricType: metrics.GaugeType},
+			{key: "max_processing_time", name: "govel_middleware_processing_max_seconds", help: "Longest time to process a request.", metricType: metrics.GaugeType},
+			{key: "current_concurrency", name: "govel_middleware_concurrency", help: "Requests currently being processed.", metricType: metrics.GaugeType},
+			{key: "max_concurrency", name: "govel_middleware_concurrency_max", help: "Peak number of concurrent requests.", metricType: metrics.GaugeType},
+			{key: "last_request_time", name: "govel_middleware_last_request_timestamp_seconds", help: "When the last request was processed.", metricType: metrics.GaugeType},
+		},
+	}
+}
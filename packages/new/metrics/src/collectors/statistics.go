@@ -0,0 +1,150 @@
+// Package collectors publishes statistics that GoVel components keep on
+// their own - the container, the facade cache, middleware handlers, the
+// support compiler and health checks - as metrics. Collectors read the
+// statistics when the registry is gathered, so nothing has to be pushed.
+package collectors
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"govel/new/metrics"
+)
+
+// StatisticsProvider is implemented by components reporting their
+// statistics as a map, such as the service container and compiler metrics.
+type StatisticsProvider interface {
+	// GetStatistics returns statistic values by name.
+	GetStatistics() map[string]interface{}
+}
+
+// metricSpec maps a statistic to the metric publishing it.
+type metricSpec struct {
+	// key is the name of the statistic
+	key string
+
+	// name is the metric name
+	name string
+
+	// help describes the metric
+	help string
+
+	// metricType is the type of the metric
+	metricType metrics.MetricType
+}
+
+// statisticsCollector publishes a snapshot of statistics kept elsewhere.
+type statisticsCollector struct {
+	// snapshot returns the statistics as a map or struct
+	snapshot func() interface{}
+
+	// specs map statistics to metrics
+	specs []metricSpec
+
+	// labels are added to every sample
+	labels []metrics.Label
+}
+
+// Collect reads the statistics and converts them to metric families.
+// Statistics that are missing or not numeric are skipped.
+func (c *statisticsCollector) Collect() []metrics.MetricFamily {
+	values := statisticValues(c.snapshot())
+
+	families := make([]metrics.MetricFamily, 0, len(c.specs))
+	for _, spec := range c.specs {
+		value, ok := toFloat(values[spec.key])
+		if !ok {
+			continue
+		}
+
+		families = append(families, metrics.MetricFamily{
+			Name:    spec.name,
+			Help:    spec.help,
+			Type:    spec.metricType,
+			Samples: []metrics.Sample{{Labels: c.labels, Value: value}},
+		})
+	}
+	return families
+}
+
+// statisticValues turns a map or struct into values by name. Struct fields
+// are named after their json tag, or their snake_cased field name.
+func statisticValues(snapshot interface{}) map[string]interface{} {
+	if values, ok := snapshot.(map[string]interface{}); ok {
+		return values
+	}
+
+	v := reflect.ValueOf(snapshot)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	values := make(map[string]interface{})
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := snakeCase(field.Name)
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		values[name] = v.Field(i).Interface()
+	}
+	return values
+}
+
+// toFloat converts a statistic to a sample value. Durations become seconds
+// and times become unix timestamps.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.Seconds(), true
+	case time.Time:
+		if v.IsZero() {
+			return 0, false
+		}
+		return float64(v.UnixNano()) / 1e9, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// snakeCase converts a Go field name such as CacheHits to cache_hits.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
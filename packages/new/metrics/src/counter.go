@@ -0,0 +1,72 @@
+package metrics
+
+import "fmt"
+
+// Counter is a monotonically increasing metric, such as the number of
+// handled requests. Label values are passed positionally in the order of
+// the label names the counter was created with.
+type Counter struct {
+	*metric
+}
+
+// Inc increments the counter by one.
+//
+// Parameters:
+//
+//	labelValues: Values of the counter's labels
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter by the given value.
+//
+// Parameters:
+//
+//	value: Non-negative amount to add
+//	labelValues: Values of the counter's labels
+//
+// Panics:
+//
+//	When value is negative or the number of label values is wrong
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.name))
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.with(labelValues, func() *series { return &series{} }).value += value
+}
+
+// Value returns the current value of a series.
+//
+// Parameters:
+//
+//	labelValues: Values of the counter's labels
+//
+// Returns:
+//
+//	float64: The counter value, 0 for unknown series
+func (c *Counter) Value(labelValues ...string) float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.value(labelValues)
+}
+
+// Collect returns the counter as a metric family.
+//
+// Returns:
+//
+//	[]MetricFamily: The counter family
+func (c *Counter) Collect() []MetricFamily {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	family := MetricFamily{Name: c.name, Help: c.help, Type: CounterType}
+	for _, s := range c.sortedSeries() {
+		family.Samples = append(family.Samples, Sample{Suffix: "_total", Labels: c.labels(s), Value: s.value})
+	}
+	return []MetricFamily{family}
+}
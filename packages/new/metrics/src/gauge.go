@@ -0,0 +1,84 @@
+package metrics
+
+// Gauge is a metric that can go up and down, such as the number of open
+// connections. Label values are passed positionally in the order of the
+// label names the gauge was created with.
+type Gauge struct {
+	*metric
+}
+
+// Set sets the gauge to the given value.
+//
+// Parameters:
+//
+//	value: The new value
+//	labelValues: Values of the gauge's labels
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.with(labelValues, func() *series { return &series{} }).value = value
+}
+
+// Add adds the given value, which may be negative, to the gauge.
+//
+// Parameters:
+//
+//	value: The amount to add
+//	labelValues: Values of the gauge's labels
+func (g *Gauge) Add(value float64, labelValues ...string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.with(labelValues, func() *series { return &series{} }).value += value
+}
+
+// Inc increments the gauge by one.
+//
+// Parameters:
+//
+//	labelValues: Values of the gauge's labels
+func (g *Gauge) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements the gauge by one.
+//
+// Parameters:
+//
+//	labelValues: Values of the gauge's labels
+func (g *Gauge) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Value returns the current value of a series.
+//
+// Parameters:
+//
+//	labelValues: Values of the gauge's labels
+//
+// Returns:
+//
+//	float64: The gauge value, 0 for unknown series
+func (g *Gauge) Value(labelValues ...string) float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.value(labelValues)
+}
+
+// Collect returns the gauge as a metric family.
+//
+// Returns:
+//
+//	[]MetricFamily: The gauge family
+func (g *Gauge) Collect() []MetricFamily {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	family := MetricFamily{Name: g.name, Help: g.help, Type: GaugeType}
+	for _, s := range g.sortedSeries() {
+		family.Samples = append(family.Samples, Sample{Labels: g.labels(s), Value: s.value})
+	}
+	return []MetricFamily{family}
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Handler serves the metrics of a registry in the OpenMetrics text format.
+type Handler struct {
+	// registry is the registry to expose
+	registry *Registry
+}
+
+// NewHandler creates a handler exposing the given registry.
+//
+// Parameters:
+//
+//	registry: The registry to expose
+//
+// Returns:
+//
+//	*Handler: A new handler
+func NewHandler(registry *Registry) *Handler {
+	return &Handler{registry: registry}
+}
+
+// ServeHTTP gathers the registry and writes the exposition.
+//
+// Parameters:
+//
+//	w: HTTP response writer
+//	r: HTTP request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := WriteOpenMetrics(&body, h.registry.Gather()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", OpenMetricsContentType)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(body.Bytes())
+	}
+}
+
+// RegisterRoutes registers the /metrics endpoint.
+//
+// Parameters:
+//
+//	mux: HTTP serve mux to register routes with
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	metrics.NewHandler(registry).RegisterRoutes(mux)
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("/metrics", h)
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultBuckets are histogram buckets suited to request latencies in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram counts observations, such as request durations, in buckets.
+// Label values are passed positionally in the order of the label names the
+// histogram was created with.
+type Histogram struct {
+	*metric
+
+	// buckets are the sorted upper bounds of the buckets, without +Inf
+	buckets []float64
+}
+
+// Observe records an observation.
+//
+// Parameters:
+//
+//	value: The observed value
+//	labelValues: Values of the histogram's labels
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	s := h.with(labelValues, func() *series {
+		return &series{bucketCounts: make([]uint64, len(h.buckets)+1)}
+	})
+
+	// The last count is the +Inf bucket
+	s.bucketCounts[sort.SearchFloat64s(h.buckets, value)]++
+	s.sum += value
+	s.count++
+}
+
+// ObserveSince records the seconds elapsed since start.
+//
+// Parameters:
+//
+//	start: When the observed operation started
+//	labelValues: Values of the histogram's labels
+//
+// Example:
+//
+//	start := time.Now()
+//	handle(request)
+//	latency.ObserveSince(start, request.Method)
+func (h *Histogram) ObserveSince(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+// Collect returns the histogram as a metric family with cumulative buckets.
+//
+// Returns:
+//
+//	[]MetricFamily: The histogram family
+func (h *Histogram) Collect() []MetricFamily {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	family := MetricFamily{Name: h.name, Help: h.help, Type: HistogramType}
+	for _, s := range h.sortedSeries() {
+		var cumulative uint64
+		for i, count := range s.bucketCounts {
+			cumulative += count
+
+			bound := math.Inf(1)
+			if i < len(h.buckets) {
+				bound = h.buckets[i]
+			}
+
+			family.Samples = append(family.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: h.labels(s, Label{Name: "le", Value: formatValue(bound)}),
+				Value:  float64(cumulative),
+			})
+		}
+
+		family.Samples = append(family.Samples,
+			Sample{Suffix: "_count", Labels: h.labels(s), Value: float64(s.count)},
+			Sample{Suffix: "_sum", Labels: h.labels(s), Value: s.sum},
+		)
+	}
+	return []MetricFamily{family}
+}
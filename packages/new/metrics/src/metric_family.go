@@ -0,0 +1,101 @@
+// Package metrics provides a metrics registry with counters, gauges and
+// histograms and renders it in the OpenMetrics text format, which
+// Prometheus and compatible scrapers understand.
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MetricType is the type of a metric family.
+type MetricType string
+
+const (
+	// CounterType is a monotonically increasing value
+	CounterType MetricType = "counter"
+
+	// GaugeType is a value that can go up and down
+	GaugeType MetricType = "gauge"
+
+	// HistogramType is a distribution of observations in buckets
+	HistogramType MetricType = "histogram"
+)
+
+var (
+	// metricNamePattern matches valid metric names
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+	// labelNamePattern matches valid label names
+	labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Label is a name/value pair identifying a series.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single value of a metric family.
+type Sample struct {
+	// Suffix is appended to the family name, e.g. "_bucket" or "_sum".
+	// Counter samples without a suffix are written with "_total".
+	Suffix string
+
+	// Labels identify the series
+	Labels []Label
+
+	// Value is the sample value
+	Value float64
+}
+
+// MetricFamily is a named metric with all its samples.
+type MetricFamily struct {
+	// Name is the metric name without type suffixes
+	Name string
+
+	// Help describes the metric
+	Help string
+
+	// Type is the metric type
+	Type MetricType
+
+	// Samples are the values of the metric
+	Samples []Sample
+}
+
+// Collector produces metric families when the registry is gathered.
+// Counters, gauges and histograms are collectors, and adapters publishing
+// statistics kept elsewhere implement it to be read at scrape time.
+type Collector interface {
+	// Collect returns the current metric families.
+	Collect() []MetricFamily
+}
+
+// CollectorFunc adapts a function to the Collector interface.
+type CollectorFunc func() []MetricFamily
+
+// Collect calls the function.
+func (f CollectorFunc) Collect() []MetricFamily {
+	return f()
+}
+
+// validateNames checks a metric name and its label names.
+func validateNames(name string, labelNames []string) error {
+	if !metricNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid metric name %q", name)
+	}
+
+	seen := make(map[string]bool, len(labelNames))
+	for _, label := range labelNames {
+		if !labelNamePattern.MatchString(label) || label == "le" {
+			return fmt.Errorf("invalid label name %q for metric %q", label, name)
+		}
+		if seen[label] {
+			return fmt.Errorf("duplicate label name %q for metric %q", label, name)
+		}
+		seen[label] = true
+	}
+
+	return nil
+}
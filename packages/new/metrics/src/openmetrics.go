@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// OpenMetricsContentType is the content type of the OpenMetrics text format.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// WriteOpenMetrics writes metric families in the OpenMetrics text format,
+// terminated by the mandatory "# EOF" line.
+//
+// Parameters:
+//
+//	w: Destination of the exposition
+//	families: The metric families to write
+//
+// Returns:
+//
+//	error: Any error writing to w
+func WriteOpenMetrics(w io.Writer, families []MetricFamily) error {
+	out := bufio.NewWriter(w)
+
+	for _, family := range families {
+		out.WriteString("# TYPE " + family.Name + " " + string(family.Type) + "\n")
+		if family.Help != "" {
+			out.WriteString("# HELP " + family.Name + " " + escapeHelp(family.Help) + "\n")
+		}
+
+		for _, sample := range family.Samples {
+			suffix := sample.Suffix
+			if suffix == "" && family.Type == CounterType {
+				suffix = "_total"
+			}
+
+			out.WriteString(family.Name + suffix)
+			writeLabels(out, sample.Labels)
+			out.WriteString(" " + formatValue(sample.Value) + "\n")
+		}
+	}
+
+	out.WriteString("# EOF\n")
+	return out.Flush()
+}
+
+// writeLabels writes a label set in braces, nothing for an empty set.
+func writeLabels(out *bufio.Writer, labels []Label) {
+	if len(labels) == 0 {
+		return
+	}
+
+	out.WriteByte('{')
+	for i, label := range labels {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		out.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+	}
+	out.WriteByte('}')
+}
+
+// escapeHelp escapes backslashes, quotes and newlines in help texts.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(help)
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in label values.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+// formatValue formats a sample value, writing integral values without exponent.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	case value == math.Trunc(value) && math.Abs(value) < 1e15:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
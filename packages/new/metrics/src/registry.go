@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry holds the metrics and collectors of an application and gathers
+// them for exposition.
+//
+// Example:
+//
+//	registry := metrics.NewRegistry()
+//	requests := registry.Counter("http_requests", "Handled HTTP requests.", "method", "status")
+//	requests.Inc("GET", "200")
+//	http.Handle("/metrics", metrics.NewHandler(registry))
+type Registry struct {
+	// metrics holds the counters, gauges and histograms by name
+	metrics map[string]Collector
+
+	// collectors are additional collectors read at gather time
+	collectors []Collector
+
+	// mutex provides thread-safe access to the registry
+	mutex sync.RWMutex
+}
+
+// NewRegistry creates an empty registry.
+//
+// Returns:
+//
+//	*Registry: A new registry instance
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]Collector),
+	}
+}
+
+// Counter returns the counter with the given name, creating it on first use.
+// A trailing "_total" is dropped from the name, since it is added on exposition.
+//
+// Parameters:
+//
+//	name: Metric name
+//	help: Description of the metric
+//	labelNames: Names of the counter's labels
+//
+// Returns:
+//
+//	*Counter: The counter
+//
+// Panics:
+//
+//	When the name is invalid or already used by a different metric
+func (r *Registry) Counter(name, help string, labelNames ...string) *Counter {
+	name = strings.TrimSuffix(name, "_total")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, ok := r.metrics[name]; ok {
+		if counter, ok := existing.(*Counter); ok && counter.sameShape(labelNames) {
+			return counter
+		}
+		panic(fmt.Sprintf("metrics: %s is already registered with a different type or labels", name))
+	}
+
+	m, err := newMetric(name, help, labelNames)
+	if err != nil {
+		panic("metrics: " + err.Error())
+	}
+
+	counter := &Counter{metric: m}
+	r.metrics[name] = counter
+	return counter
+}
+
+// Gauge returns the gauge with the given name, creating it on first use.
+//
+// Parameters:
+//
+//	name: Metric name
+//	help: Description of the metric
+//	labelNames: Names of the gauge's labels
+//
+// Returns:
+//
+//	*Gauge: The gauge
+//
+// Panics:
+//
+//	When the name is invalid or already used by a different metric
+func (r *Registry) Gauge(name, help string, labelNames ...string) *Gauge {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, ok := r.metrics[name]; ok {
+		if gauge, ok := existing.(*Gauge); ok && gauge.sameShape(labelNames) {
+			return gauge
+		}
+		panic(fmt.Sprintf("metrics: %s is already registered with a different type or labels", name))
+	}
+
+	m, err := newMetric(name, help, labelNames)
+	if err != nil {
+		panic("metrics: " + err.Error())
+	}
+
+	gauge := &Gauge{metric: m}
+	r.metrics[name] = gauge
+	return gauge
+}
+
+// Histogram returns the histogram with the given name, creating it on first use.
+//
+// Parameters:
+//
+//	name: Metric name
+//	help: Description of the metric
+//	buckets: Upper bounds of the buckets, nil for DefaultBuckets
+//	labelNames: Names of the histogram's labels
+//
+// Returns:
+//
+//	*Histogram: The histogram
+//
+// Panics:
+//
+//	When the name is invalid or already used by a different metric
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, ok := r.metrics[name]; ok {
+		if histogram, ok := existing.(*Histogram); ok && histogram.sameShape(labelNames) {
+			return histogram
+		}
+		panic(fmt.Sprintf("metrics: %s is already registered with a different type or labels", name))
+	}
+
+	m, err := newMetric(name, help, labelNames)
+	if err != nil {
+		panic("metrics: " + err.Error())
+	}
+
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	histogram := &Histogram{metric: m, buckets: sorted}
+	r.metrics[name] = histogram
+	return histogram
+}
+
+// Register adds collectors that are read every time the registry is gathered.
+//
+// Parameters:
+//
+//	collectors: The collectors to add
+//
+// Returns:
+//
+//	*Registry: Self for method chaining
+func (r *Registry) Register(collectors ...Collector) *Registry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.collectors = append(r.collectors, collectors...)
+	return r
+}
+
+// Gather collects all metric families sorted by name. Families with the
+// same name from different collectors are merged.
+//
+// Returns:
+//
+//	[]MetricFamily: The metric families
+func (r *Registry) Gather() []MetricFamily {
+	r.mutex.RLock()
+	sources := make([]Collector, 0, len(r.metrics)+len(r.collectors))
+	for _, m := range r.metrics {
+		sources = append(sources, m)
+	}
+	sources = append(sources, r.collectors...)
+	r.mutex.RUnlock()
+
+	byName := make(map[string]*MetricFamily)
+	var names []string
+
+	for _, source := range sources {
+		for _, family := range source.Collect() {
+			if existing, ok := byName[family.Name]; ok {
+				existing.Samples = append(existing.Samples, family.Samples...)
+				continue
+			}
+
+			family := family
+			byName[family.Name] = &family
+			names = append(names, family.Name)
+		}
+	}
+
+	sort.Strings(names)
+	families := make([]MetricFamily, 0, len(names))
+	for _, name := range names {
+		families = append(families, *byName[name])
+	}
+	return families
+}
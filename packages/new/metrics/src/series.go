@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// series is one labelled time series of a metric.
+type series struct {
+	// labelValues are the values of the metric's labels
+	labelValues []string
+
+	// value is the counter or gauge value
+	value float64
+
+	// bucketCounts are the non-cumulative histogram bucket counts
+	bucketCounts []uint64
+
+	// sum is the sum of histogram observations
+	sum float64
+
+	// count is the number of histogram observations
+	count uint64
+}
+
+// metric holds what counters, gauges and histograms have in common.
+type metric struct {
+	// name is the metric name
+	name string
+
+	// help describes the metric
+	help string
+
+	// labelNames are the names of the metric's labels
+	labelNames []string
+
+	// series holds the series by joined label values
+	series map[string]*series
+
+	// mutex provides thread-safe access to the series
+	mutex sync.Mutex
+}
+
+// newMetric creates a metric after validating its names.
+func newMetric(name, help string, labelNames []string) (*metric, error) {
+	if err := validateNames(name, labelNames); err != nil {
+		return nil, err
+	}
+
+	return &metric{
+		name:       name,
+		help:       help,
+		labelNames: append([]string(nil), labelNames...),
+		series:     make(map[string]*series),
+	}, nil
+}
+
+// with returns the series for the label values, creating it when needed.
+// The caller must hold the mutex.
+func (m *metric) with(labelValues []string, create func() *series) *series {
+	if len(labelValues) != len(m.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", m.name, len(m.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := m.series[key]
+	if !ok {
+		s = create()
+		s.labelValues = append([]string(nil), labelValues...)
+		m.series[key] = s
+	}
+	return s
+}
+
+// value returns the value of an existing series, 0 when it does not exist.
+// The caller must hold the mutex.
+func (m *metric) value(labelValues []string) float64 {
+	if s, ok := m.series[strings.Join(labelValues, "\xff")]; ok {
+		return s.value
+	}
+	return 0
+}
+
+// sortedSeries returns the series ordered by label values. The caller must hold the mutex.
+func (m *metric) sortedSeries() []*series {
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]*series, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, m.series[key])
+	}
+	return sorted
+}
+
+// labels pairs the metric's label names with the values of a series.
+func (m *metric) labels(s *series, extra ...Label) []Label {
+	labels := make([]Label, 0, len(m.labelNames)+len(extra))
+	for i, name := range m.labelNames {
+		labels = append(labels, Label{Name: name, Value: s.labelValues[i]})
+	}
+	return append(labels, extra...)
+}
+
+// sameShape reports whether an existing metric matches a requested definition.
+func (m *metric) sameShape(labelNames []string) bool {
+	if len(m.labelNames) != len(labelNames) {
+		return false
+	}
+	for i := range labelNames {
+		if m.labelNames[i] != labelNames[i] {
+			return false
+		}
+	}
+	return true
+}
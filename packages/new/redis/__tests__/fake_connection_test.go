@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"govel/new/redis/connections"
+	"govel/new/redis/events"
+	"govel/new/redis/interfaces"
+	"govel/new/redis/limiters"
+	"govel/new/redis/protocol"
+)
+
+func TestFakeConnection_StringsAndExpiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	conn := connections.NewFakeConnection("default", "app:")
+	conn.SetClock(func() time.Time { return now })
+
+	if err := conn.Set(ctx, "name", "govel", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := conn.Get(ctx, "name")
+	if err != nil || value != "govel" {
+		t.Fatalf("Expected govel, got %q (%v)", value, err)
+	}
+
+	if keys := conn.Fake().Keys(); len(keys) != 1 || keys[0] != "app:name" {
+		t.Errorf("Expected prefixed key, got %v", keys)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := conn.Get(ctx, "name"); !errors.Is(err, protocol.ErrNil) {
+		t.Errorf("Expected expired key to return ErrNil, got %v", err)
+	}
+}
+
+func TestFakeConnection_SetNXAndIncr(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+
+	acquired, err := conn.SetNX(ctx, "lock", "owner-1", time.Second)
+	if err != nil || !acquired {
+		t.Fatalf("Expected first SetNX to succeed, got %v (%v)", acquired, err)
+	}
+
+	acquired, _ = conn.SetNX(ctx, "lock", "owner-2", time.Second)
+	if acquired {
+		t.Error("Expected second SetNX to fail")
+	}
+
+	for i := 1; i <= 3; i++ {
+		value, err := conn.IncrBy(ctx, "hits", 1)
+		if err != nil || value != int64(i) {
+			t.Fatalf("Expected %d, got %d (%v)", i, value, err)
+		}
+	}
+
+	if _, err := conn.Command(ctx, "LPUSH", "lock", "x"); err == nil {
+		t.Error("Expected WRONGTYPE error")
+	}
+}
+
+func TestFakeConnection_PipelineAndTransaction(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+
+	replies, err := conn.Pipeline(ctx, func(pipe interfaces.PipelineInterface) {
+		pipe.Command("RPUSH", "jobs", "a", "b")
+		pipe.Command("HSET", "job:a", "attempts", 1)
+		pipe.Command("LLEN", "jobs")
+	})
+	if err != nil {
+		t.Fatalf("Pipeline failed: %v", err)
+	}
+	if len(replies) != 3 || replies[2] != int64(2) {
+		t.Errorf("Unexpected pipeline replies: %v", replies)
+	}
+
+	replies, err = conn.Transaction(ctx, func(pipe interfaces.PipelineInterface) {
+		pipe.Command("LPOP", "jobs")
+		pipe.Command("ZADD", "reserved", 100, "a")
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+	if replies[0] != "a" || replies[1] != int64(1) {
+		t.Errorf("Unexpected transaction replies: %v", replies)
+	}
+
+	members, err := protocol.Strings(conn.Command(ctx, "ZRANGEBYSCORE", "reserved", "-inf", "+inf"))
+	if err != nil || len(members) != 1 || members[0] != "a" {
+		t.Errorf("Expected reserved job, got %v (%v)", members, err)
+	}
+}
+
+func TestFakeConnection_EvalUsesDefinedScript(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+
+	script := `return redis.call('incrby', KEYS[1], ARGV[1])`
+	conn.DefineScript(script, func(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error) {
+		amount, _ := strconv.Atoi(args[0])
+		return call("INCRBY", keys[0], amount)
+	})
+
+	var executed []string
+	conn.Listen(func(event *events.CommandExecutedEvent) {
+		executed = append(executed, event.Command)
+	})
+
+	result, err := conn.Eval(ctx, script, []string{"counter"}, 5)
+	if err != nil || result != int64(5) {
+		t.Fatalf("Expected 5, got %v (%v)", result, err)
+	}
+
+	if len(executed) != 1 || executed[0] != "EVALSHA" {
+		t.Errorf("Expected a single EVALSHA event, got %v", executed)
+	}
+
+	if _, err := conn.Eval(ctx, "return 1", nil); err == nil {
+		t.Error("Expected undefined script to fail")
+	}
+}
+
+func TestFakeConnection_RunsFrameworkScripts(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+
+	// Duration limiter: two slots per 1000ms window starting at 5000
+	take := func(now int64) []interface{} {
+		t.Helper()
+		reply, err := conn.Eval(ctx, limiters.DurationLimiterScript, []string{"api"}, now, 1000, 2)
+		if err != nil {
+			t.Fatalf("DurationLimiterScript failed: %v", err)
+		}
+		return reply.([]interface{})
+	}
+	for i, expected := range [][]interface{}{
+		{int64(1), int64(6000), int64(1)},
+		{int64(1), int64(6000), int64(0)},
+		{nil, int64(6000), int64(-1)},
+	} {
+		if got := take(5000 + int64(i)); !equalReplies(got, expected) {
+			t.Errorf("Attempt %d: expected %v, got %v", i+1, expected, got)
+		}
+	}
+	if got := take(6001); !equalReplies(got, []interface{}{int64(1), int64(7001), int64(1)}) {
+		t.Errorf("Expected a new window, got %v", got)
+	}
+
+	reply, err := conn.Eval(ctx, limiters.DurationLimiterTooManyAttemptsScript, []string{"api"}, 6002, 1000, 2)
+	if err != nil || !equalReplies(reply.([]interface{}), []interface{}{int64(1), int64(7001)}) {
+		t.Errorf("Expected one remaining slot, got %v (%v)", reply, err)
+	}
+	reply, err = conn.Eval(ctx, limiters.DurationLimiterTooManyAttemptsScript, []string{"unused"}, 6002, 1000, 2)
+	if err != nil || !equalReplies(reply.([]interface{}), []interface{}{int64(2), int64(7002)}) {
+		t.Errorf("Expected an empty window, got %v (%v)", reply, err)
+	}
+
+	// Concurrency limiter: the first free slot is taken and only its owner
+	// releases it
+	slots := []string{"funnel1", "funnel2"}
+	for _, expected := range []int64{1, 2, 0} {
+		index, err := protocol.Int64(conn.Eval(ctx, limiters.ConcurrencyLimiterLockScript, slots, "owner", 60000))
+		if err != nil || index != expected {
+			t.Fatalf("Expected slot %d, got %d (%v)", expected, index, err)
+		}
+	}
+	if ttl, _ := conn.TTL(ctx, "funnel1"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("Expected the slot to expire within a minute, got %v", ttl)
+	}
+	if released, _ := protocol.Int64(conn.Eval(ctx, limiters.ConcurrencyLimiterReleaseScript, []string{"funnel1"}, "intruder")); released != 0 {
+		t.Error("Expected other owners not to release the slot")
+	}
+	if released, _ := protocol.Int64(conn.Eval(ctx, limiters.ConcurrencyLimiterReleaseScript, []string{"funnel1"}, "owner")); released != 1 {
+		t.Error("Expected the owner to release the slot")
+	}
+	if index, _ := protocol.Int64(conn.Eval(ctx, limiters.ConcurrencyLimiterLockScript, slots, "next", 60000)); index != 1 {
+		t.Errorf("Expected the released slot to be taken again, got %d", index)
+	}
+}
+
+// equalReplies compares flat array replies.
+func equalReplies(got, expected []interface{}) bool {
+	if len(got) != len(expected) {
+		return false
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFakeConnection_PubSub(t *testing.T) {
+	conn := connections.NewFakeConnection("default")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- conn.PSubscribe(ctx, []string{"orders.*"}, func(message, channel string) {
+			received <- channel + ":" + message
+		})
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for conn.Fake().Subscribers() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	count, err := conn.Publish(context.Background(), "orders.created", "42")
+	if err != nil || count != 1 {
+		t.Fatalf("Expected 1 receiver, got %d (%v)", count, err)
+	}
+
+	select {
+	case message := <-received:
+		if message != "orders.created:42" {
+			t.Errorf("Unexpected message %q", message)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Message not delivered")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected clean unsubscribe, got %v", err)
+	}
+}
+
+func TestSlotHasher_MatchesRedisCluster(t *testing.T) {
+	hasher := connections.NewSlotHasher()
+
+	if slot := hasher.Slot("123456789"); slot != 12739 {
+		t.Errorf("Expected slot 12739, got %d", slot)
+	}
+	if hasher.Slot("{user1000}.following") != hasher.Slot("{user1000}.followers") {
+		t.Error("Expected keys with the same hash tag to share a slot")
+	}
+	if hasher.Slot("foo{}{bar}") != hasher.Slot("foo{}{bar}") || hasher.Slot("foo{}{bar}") == hasher.Slot("bar") {
+		t.Error("Expected empty hash tags to hash the whole key")
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"govel/new/redis/connections"
+	"govel/new/redis/exceptions"
+	"govel/new/redis/limiters"
+)
+
+func TestDurationLimiter_AllowsMaxLocksPerWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	conn := connections.NewFakeConnection("default")
+	conn.SetClock(clock)
+
+	limiter := limiters.NewDurationLimiter(conn, "api", 2, time.Minute).SetClock(clock)
+
+	for i := 0; i < 2; i++ {
+		acquired, err := limiter.Acquire(ctx)
+		if err != nil || !acquired {
+			t.Fatalf("Expected attempt %d to be allowed (%v)", i+1, err)
+		}
+	}
+
+	acquired, err := limiter.Acquire(ctx)
+	if err != nil || acquired {
+		t.Fatalf("Expected third attempt to be throttled (%v)", err)
+	}
+	if limiter.Remaining() != 0 {
+		t.Errorf("Expected no remaining slots, got %d", limiter.Remaining())
+	}
+
+	now = now.Add(61 * time.Second)
+	if acquired, _ := limiter.Acquire(ctx); !acquired {
+		t.Error("Expected a new window to allow the attempt")
+	}
+}
+
+func TestDurationLimiterBuilder_CallsFailureOnTimeout(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+
+	builder := func() *limiters.DurationLimiterBuilder {
+		return limiters.NewDurationLimiterBuilder(conn, "reports").Allow(1).Every(time.Minute).Block(0)
+	}
+
+	ran := 0
+	if err := builder().Then(ctx, func() error { ran++; return nil }); err != nil {
+		t.Fatalf("Expected first run to succeed, got %v", err)
+	}
+
+	failed := false
+	err := builder().Then(ctx, func() error { ran++; return nil }, func(err error) error {
+		failed = true
+		return nil
+	})
+	if err != nil || !failed || ran != 1 {
+		t.Errorf("Expected failure callback, got err=%v failed=%v ran=%d", err, failed, ran)
+	}
+
+	err = builder().Then(ctx, func() error { return nil })
+	var timeout *exceptions.LimiterTimeoutException
+	if !errors.As(err, &timeout) {
+		t.Errorf("Expected LimiterTimeoutException, got %v", err)
+	}
+}
+
+func TestConcurrencyLimiter_LimitsConcurrentHolders(t *testing.T) {
+	ctx := context.Background()
+	conn := connections.NewFakeConnection("default")
+	limiter := limiters.NewConcurrencyLimiter(conn, "exports", 1, time.Minute)
+
+	slot, err := limiter.Acquire(ctx, "worker-1")
+	if err != nil || slot != "exports1" {
+		t.Fatalf("Expected slot exports1, got %q (%v)", slot, err)
+	}
+
+	err = limiter.Block(ctx, 20*time.Millisecond, func() error { return nil }, 5*time.Millisecond)
+	var timeout *exceptions.LimiterTimeoutException
+	if !errors.As(err, &timeout) {
+		t.Fatalf("Expected LimiterTimeoutException while slot is held, got %v", err)
+	}
+
+	// Only the owner can release the slot
+	limiter.Release(ctx, slot, "worker-2")
+	if slot, _ := limiter.Acquire(ctx, "worker-3"); slot != "" {
+		t.Fatal("Expected slot to still be held")
+	}
+
+	limiter.Release(ctx, slot, "worker-1")
+
+	ran := false
+	if err := limiter.Block(ctx, 0, func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("Expected callback to run after release, got %v", err)
+	}
+
+	if slot, _ := limiter.Acquire(ctx, "worker-4"); slot == "" {
+		t.Error("Expected Block to release its slot")
+	}
+}
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"govel/new/redis/connections"
+	"govel/new/redis/protocol"
+)
+
+// startRespServer starts a minimal RESP server supporting GET, SET, EVAL
+// and EVALSHA (which always replies NOSCRIPT).
+func startRespServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	data := map[string]string{}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader, writer := bufio.NewReader(conn), bufio.NewWriter(conn)
+
+				for {
+					request, err := protocol.ReadReply(reader)
+					if err != nil {
+						return
+					}
+
+					args, _ := protocol.Strings(request, nil)
+					mu.Lock()
+					switch strings.ToUpper(args[0]) {
+					case "SET":
+						data[args[1]] = args[2]
+						writer.WriteString("+OK\r\n")
+					case "GET":
+						if value, ok := data[args[1]]; ok {
+							writer.WriteString("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n")
+						} else {
+							writer.WriteString("$-1\r\n")
+						}
+					case "EVALSHA":
+						writer.WriteString("-NOSCRIPT No matching script. Please use EVAL.\r\n")
+					case "EVAL":
+						writer.WriteString("*2\r\n:1\r\n$" + strconv.Itoa(len(args[3])) + "\r\n" + args[3] + "\r\n")
+					default:
+						writer.WriteString("-ERR unknown command\r\n")
+					}
+					mu.Unlock()
+					writer.Flush()
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestNodeClient_RoundTripsOverRESP(t *testing.T) {
+	ctx := context.Background()
+	client := connections.NewNodeClient(connections.NodeOptions{Address: startRespServer(t)})
+	defer client.Close()
+
+	conn := connections.NewConnection("default", client, "")
+
+	if err := conn.Set(ctx, "greeting", "hello world", 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := conn.Get(ctx, "greeting")
+	if err != nil || value != "hello world" {
+		t.Fatalf("Expected hello world, got %q (%v)", value, err)
+	}
+
+	if _, err := conn.Get(ctx, "missing"); !errors.Is(err, protocol.ErrNil) {
+		t.Errorf("Expected ErrNil, got %v", err)
+	}
+
+	replies, err := client.DoMulti(ctx, [][]interface{}{{"GET", "greeting"}, {"BOGUS"}})
+	if err != nil {
+		t.Fatalf("DoMulti failed: %v", err)
+	}
+	if replies[0] != "hello world" {
+		t.Errorf("Unexpected first reply %v", replies[0])
+	}
+	if _, ok := replies[1].(protocol.RedisError); !ok {
+		t.Errorf("Expected error reply in pipeline, got %T", replies[1])
+	}
+}
+
+func TestConnection_EvalFallsBackToEval(t *testing.T) {
+	ctx := context.Background()
+	client := connections.NewNodeClient(connections.NodeOptions{Address: startRespServer(t)})
+	defer client.Close()
+
+	conn := connections.NewConnection("default", client, "app:")
+
+	result, err := conn.Eval(ctx, "return {1, KEYS[1]}", []string{"key"})
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	items, ok := result.([]interface{})
+	if !ok || len(items) != 2 || items[0] != int64(1) || items[1] != "app:key" {
+		t.Errorf("Unexpected script result %v", result)
+	}
+}
@@ -0,0 +1,475 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// keylessCommands are routed to any node instead of by key slot.
+var keylessCommands = map[string]bool{
+	"PING": true, "ECHO": true, "INFO": true, "TIME": true, "PUBLISH": true,
+	"SCRIPT": true, "CLUSTER": true, "DBSIZE": true, "FLUSHDB": true,
+	"FLUSHALL": true, "KEYS": true, "SCAN": true, "RANDOMKEY": true,
+	"MULTI": true, "EXEC": true, "DISCARD": true, "ASKING": true,
+}
+
+// ClusterClient routes commands to the nodes of a Redis Cluster.
+//
+// The slot map is loaded with CLUSTER SLOTS on first use. MOVED replies
+// update the map and retry on the new owner; ASK replies retry once on the
+// importing node after ASKING.
+type ClusterClient struct {
+	// mu guards nodes, slots and loaded
+	mu sync.RWMutex
+
+	// seeds are the configured node addresses used to discover the cluster
+	seeds []string
+
+	// template holds the settings shared by all node clients
+	template NodeOptions
+
+	// nodes are the node clients keyed by address
+	nodes map[string]*NodeClient
+
+	// slots maps each hash slot to its master's address
+	slots [ClusterSlots]string
+
+	// loaded is set once the slot map has been fetched
+	loaded bool
+
+	// hasher maps keys to slots
+	hasher interfaces.HasherInterface
+
+	// maxRedirects bounds the MOVED/ASK redirects followed per command
+	maxRedirects int
+}
+
+// NewClusterClient creates a new cluster client.
+//
+// Parameters:
+//   - seeds: Addresses of one or more cluster nodes
+//   - template: Settings applied to every node connection (Address is ignored)
+//   - hasher: Key slot hasher; nil uses SlotHasher
+func NewClusterClient(seeds []string, template NodeOptions, hasher interfaces.HasherInterface) *ClusterClient {
+	if hasher == nil {
+		hasher = NewSlotHasher()
+	}
+
+	return &ClusterClient{
+		seeds:        seeds,
+		template:     template,
+		nodes:        make(map[string]*NodeClient),
+		hasher:       hasher,
+		maxRedirects: 5,
+	}
+}
+
+// Do routes a single command by its key slot and follows redirects.
+func (c *ClusterClient) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	node, err := c.nodeForCommand(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	asking := false
+	for redirects := 0; ; redirects++ {
+		var reply interface{}
+		if asking {
+			replies, doErr := node.DoMulti(ctx, [][]interface{}{{"ASKING"}, args})
+			if doErr != nil {
+				return nil, doErr
+			}
+			reply, err = replies[1], nil
+			if redisErr, ok := reply.(protocol.RedisError); ok {
+				reply, err = nil, redisErr
+			}
+		} else {
+			reply, err = node.Do(ctx, args...)
+		}
+
+		var redisErr protocol.RedisError
+		if !errors.As(err, &redisErr) || redirects >= c.maxRedirects {
+			return reply, err
+		}
+
+		switch redisErr.Prefix() {
+		case "MOVED":
+			slot, address, parseErr := parseRedirect(redisErr)
+			if parseErr != nil {
+				return nil, err
+			}
+			c.setSlotOwner(slot, address)
+			node, asking = c.node(address), false
+		case "ASK":
+			_, address, parseErr := parseRedirect(redisErr)
+			if parseErr != nil {
+				return nil, err
+			}
+			node, asking = c.node(address), true
+		case "CLUSTERDOWN", "TRYAGAIN":
+			if refreshErr := c.Refresh(ctx); refreshErr != nil {
+				return nil, err
+			}
+			if node, err = c.nodeForCommand(ctx, args); err != nil {
+				return nil, err
+			}
+			asking = false
+		default:
+			return reply, err
+		}
+	}
+}
+
+// DoMulti groups the commands by node, pipelines each group and retries
+// commands that were redirected.
+func (c *ClusterClient) DoMulti(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	groups := make(map[*NodeClient][]int)
+	for i, command := range commands {
+		node, err := c.nodeForCommand(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+		groups[node] = append(groups[node], i)
+	}
+
+	replies := make([]interface{}, len(commands))
+	for node, indexes := range groups {
+		batch := make([][]interface{}, len(indexes))
+		for i, index := range indexes {
+			batch[i] = commands[index]
+		}
+
+		results, err := node.DoMulti(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for i, index := range indexes {
+			replies[index] = results[i]
+		}
+	}
+
+	for i, reply := range replies {
+		redisErr, ok := reply.(protocol.RedisError)
+		if !ok || (redisErr.Prefix() != "MOVED" && redisErr.Prefix() != "ASK") {
+			continue
+		}
+
+		result, err := c.Do(ctx, commands[i]...)
+		if redisErr, ok := err.(protocol.RedisError); ok {
+			result, err = redisErr, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = result
+	}
+	return replies, nil
+}
+
+// DoTransaction runs the commands in MULTI/EXEC on the node owning their
+// slot. All keys must hash to the same slot (use {hash tags}).
+func (c *ClusterClient) DoTransaction(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	slot := -1
+	for _, command := range commands {
+		key, ok := commandKey(command)
+		if !ok {
+			continue
+		}
+
+		keySlot := c.hasher.Slot(key)
+		if slot >= 0 && keySlot != slot {
+			return nil, errors.New("redis: CROSSSLOT keys in a cluster transaction must hash to the same slot")
+		}
+		slot = keySlot
+	}
+
+	for attempt := 0; ; attempt++ {
+		node, err := c.nodeForSlot(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+
+		replies, err := node.DoTransaction(ctx, commands)
+
+		// The transaction was not executed if it was queued on the wrong node
+		var redisErr protocol.RedisError
+		if attempt == 0 && errors.As(err, &redisErr) && redisErr.Prefix() == "EXECABORT" && strings.Contains(err.Error(), "MOVED") {
+			if refreshErr := c.Refresh(ctx); refreshErr != nil {
+				return nil, err
+			}
+			continue
+		}
+		return replies, err
+	}
+}
+
+// Subscribe subscribes on any node; cluster pub/sub messages are broadcast
+// to every node.
+func (c *ClusterClient) Subscribe(ctx context.Context, pattern bool, channels []string, callback func(message, channel string)) error {
+	node, err := c.nodeForSlot(ctx, -1)
+	if err != nil {
+		return err
+	}
+	return node.Subscribe(ctx, pattern, channels, callback)
+}
+
+// Close closes every node client.
+func (c *ClusterClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for _, node := range c.nodes {
+		if err := node.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Masters returns the addresses of the nodes owning at least one slot.
+func (c *ClusterClient) Masters(ctx context.Context) ([]string, error) {
+	if err := c.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var masters []string
+	for _, address := range c.slots {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			masters = append(masters, address)
+		}
+	}
+	return masters, nil
+}
+
+// ForEachMaster runs the callback against every master node.
+func (c *ClusterClient) ForEachMaster(ctx context.Context, callback func(node *NodeClient) error) error {
+	masters, err := c.Masters(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range masters {
+		if err := callback(c.node(address)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refresh reloads the slot map with CLUSTER SLOTS from the first reachable node.
+func (c *ClusterClient) Refresh(ctx context.Context) error {
+	c.mu.RLock()
+	candidates := append([]string{}, c.seeds...)
+	for address := range c.nodes {
+		candidates = append(candidates, address)
+	}
+	c.mu.RUnlock()
+
+	var lastErr error
+	for _, address := range candidates {
+		reply, err := c.node(address).Do(ctx, "CLUSTER", "SLOTS")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		slots, err := parseClusterSlots(reply, address)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		c.mu.Lock()
+		c.slots = slots
+		c.loaded = true
+		c.mu.Unlock()
+		return nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no seed nodes configured")
+	}
+	return fmt.Errorf("redis: failed to load cluster slots: %w", lastErr)
+}
+
+// ensureLoaded loads the slot map if it has not been loaded yet.
+func (c *ClusterClient) ensureLoaded(ctx context.Context) error {
+	c.mu.RLock()
+	loaded := c.loaded
+	c.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+	return c.Refresh(ctx)
+}
+
+// nodeForCommand returns the node owning the command's key.
+func (c *ClusterClient) nodeForCommand(ctx context.Context, command []interface{}) (*NodeClient, error) {
+	key, ok := commandKey(command)
+	if !ok {
+		return c.nodeForSlot(ctx, -1)
+	}
+	return c.nodeForSlot(ctx, c.hasher.Slot(key))
+}
+
+// nodeForSlot returns the node owning the slot; -1 returns any node.
+func (c *ClusterClient) nodeForSlot(ctx context.Context, slot int) (*NodeClient, error) {
+	if err := c.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	var address string
+	if slot >= 0 {
+		address = c.slots[slot]
+	} else {
+		for _, owner := range c.slots {
+			if owner != "" {
+				address = owner
+				break
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	if address == "" {
+		return nil, fmt.Errorf("redis: no node serves slot %d", slot)
+	}
+	return c.node(address), nil
+}
+
+// node returns the client for an address, creating it on first use.
+func (c *ClusterClient) node(address string) *NodeClient {
+	c.mu.RLock()
+	node, exists := c.nodes[address]
+	c.mu.RUnlock()
+	if exists {
+		return node
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if node, exists := c.nodes[address]; exists {
+		return node
+	}
+
+	options := c.template
+	options.Address = address
+	// Cluster nodes only support database 0
+	options.Database = 0
+
+	node = NewNodeClient(options)
+	c.nodes[address] = node
+	return node
+}
+
+// setSlotOwner records a MOVED redirect.
+func (c *ClusterClient) setSlotOwner(slot int, address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot >= 0 && slot < ClusterSlots {
+		c.slots[slot] = address
+	}
+}
+
+// commandKey returns the key a command is routed by.
+func commandKey(command []interface{}) (string, bool) {
+	if len(command) < 2 {
+		return "", false
+	}
+
+	name, _ := protocol.ArgToString(command[0])
+	name = strings.ToUpper(name)
+	if keylessCommands[name] {
+		return "", false
+	}
+
+	if name == "EVAL" || name == "EVALSHA" {
+		if len(command) < 4 {
+			return "", false
+		}
+		numKeys, err := protocol.ArgToString(command[2])
+		if err != nil || numKeys == "0" {
+			return "", false
+		}
+		key, err := protocol.ArgToString(command[3])
+		return key, err == nil
+	}
+
+	key, err := protocol.ArgToString(command[1])
+	return key, err == nil
+}
+
+// parseRedirect parses "MOVED 3999 127.0.0.1:6381" and "ASK 3999 127.0.0.1:6381".
+func parseRedirect(redisErr protocol.RedisError) (int, string, error) {
+	parts := strings.Fields(string(redisErr))
+	if len(parts) != 3 {
+		return 0, "", fmt.Errorf("redis: malformed redirect %q", string(redisErr))
+	}
+
+	slot, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, "", err
+	}
+	return slot, parts[2], nil
+}
+
+// parseClusterSlots converts a CLUSTER SLOTS reply to a slot map.
+func parseClusterSlots(reply interface{}, queried string) ([ClusterSlots]string, error) {
+	var slots [ClusterSlots]string
+
+	ranges, ok := reply.([]interface{})
+	if !ok {
+		return slots, fmt.Errorf("unexpected CLUSTER SLOTS reply %T", reply)
+	}
+
+	queriedHost, _, _ := net.SplitHostPort(queried)
+	for _, item := range ranges {
+		entry, ok := item.([]interface{})
+		if !ok || len(entry) < 3 {
+			continue
+		}
+
+		start, startErr := protocol.Int64(entry[0], nil)
+		end, endErr := protocol.Int64(entry[1], nil)
+		master, masterOK := entry[2].([]interface{})
+		if startErr != nil || endErr != nil || !masterOK || len(master) < 2 {
+			continue
+		}
+
+		host, _ := master[0].(string)
+		port, err := protocol.Int64(master[1], nil)
+		if err != nil {
+			continue
+		}
+		if host == "" {
+			host = queriedHost
+		}
+
+		address := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+		for slot := start; slot <= end && slot < ClusterSlots; slot++ {
+			slots[slot] = address
+		}
+	}
+	return slots, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ClientInterface = (*ClusterClient)(nil)
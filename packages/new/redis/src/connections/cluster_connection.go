@@ -0,0 +1,38 @@
+package connections
+
+import (
+	"context"
+)
+
+// ClusterConnection is a connection to a Redis Cluster.
+//
+// Commands are routed by key slot; multi-key commands, transactions and Lua
+// scripts must keep their keys in one slot using {hash tags}.
+type ClusterConnection struct {
+	*Connection
+
+	// cluster is the routing client
+	cluster *ClusterClient
+}
+
+// NewClusterConnection creates a new cluster connection.
+func NewClusterConnection(name string, cluster *ClusterClient, prefix string) *ClusterConnection {
+	return &ClusterConnection{
+		Connection: NewConnection(name, cluster, prefix),
+		cluster:    cluster,
+	}
+}
+
+// Masters returns the addresses of the cluster's master nodes.
+func (c *ClusterConnection) Masters(ctx context.Context) ([]string, error) {
+	return c.cluster.Masters(ctx)
+}
+
+// FlushDB flushes every master node; FLUSHDB on a single node would only
+// clear its own slots.
+func (c *ClusterConnection) FlushDB(ctx context.Context) error {
+	return c.cluster.ForEachMaster(ctx, func(node *NodeClient) error {
+		_, err := node.Do(ctx, "FLUSHDB")
+		return err
+	})
+}
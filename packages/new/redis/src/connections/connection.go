@@ -0,0 +1,295 @@
+// Package connections contains the Redis connection implementations:
+// a connection to a single server, a cluster connection and an in-memory
+// fake for tests.
+package connections
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"govel/new/redis/events"
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// Connection is a named Redis connection running commands on a client.
+//
+// Key features:
+//   - Typed helpers for the most common commands, applying the key prefix
+//   - Pipelines and MULTI/EXEC transactions
+//   - Pub/sub with channel and pattern subscriptions
+//   - Lua scripts through EVALSHA with transparent EVAL fallback
+//   - CommandExecuted events with timing for every command
+type Connection struct {
+	// mu guards name, listeners and eventsEnabled
+	mu sync.RWMutex
+
+	// name is the connection name from config
+	name string
+
+	// client is the transport commands are sent on
+	client interfaces.ClientInterface
+
+	// prefix is prepended to keys by the typed helpers
+	prefix string
+
+	// listeners receive a CommandExecuted event after every command
+	listeners []func(event *events.CommandExecutedEvent)
+
+	// eventsEnabled toggles event dispatching
+	eventsEnabled bool
+}
+
+// NewConnection creates a new connection on the given client.
+//
+// Parameters:
+//   - name: Connection name
+//   - client: Transport used to run commands
+//   - prefix: Prefix prepended to keys by the typed helpers
+func NewConnection(name string, client interfaces.ClientInterface, prefix string) *Connection {
+	return &Connection{
+		name:          name,
+		client:        client,
+		prefix:        prefix,
+		eventsEnabled: true,
+	}
+}
+
+// GetName returns the connection name.
+func (c *Connection) GetName() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.name
+}
+
+// SetName sets the connection name.
+func (c *Connection) SetName(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.name = name
+}
+
+// GetPrefix returns the prefix applied to keys by the typed helpers.
+func (c *Connection) GetPrefix() string {
+	return c.prefix
+}
+
+// Client returns the underlying transport.
+func (c *Connection) Client() interfaces.ClientInterface {
+	return c.client
+}
+
+// Command runs a raw command.
+func (c *Connection) Command(ctx context.Context, method string, args ...interface{}) (interface{}, error) {
+	command := make([]interface{}, 0, len(args)+1)
+	command = append(command, method)
+	command = append(command, args...)
+
+	start := time.Now()
+	reply, err := c.client.Do(ctx, command...)
+	c.dispatch(strings.ToUpper(method), args, time.Since(start), err)
+
+	return reply, err
+}
+
+// Get returns the value of a key, or protocol.ErrNil if it does not exist.
+func (c *Connection) Get(ctx context.Context, key string) (string, error) {
+	return protocol.String(c.Command(ctx, "GET", c.prefixed(key)))
+}
+
+// Set stores a value; a zero ttl stores it without expiration.
+func (c *Connection) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	args := []interface{}{c.prefixed(key), value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+
+	_, err := c.Command(ctx, "SET", args...)
+	return err
+}
+
+// SetNX stores a value only if the key does not exist.
+func (c *Connection) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	args := []interface{}{c.prefixed(key), value, "NX"}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+
+	reply, err := c.Command(ctx, "SET", args...)
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// Del removes keys and returns how many existed.
+func (c *Connection) Del(ctx context.Context, keys ...string) (int64, error) {
+	return protocol.Int64(c.Command(ctx, "DEL", c.prefixedKeys(keys)...))
+}
+
+// Exists returns how many of the given keys exist.
+func (c *Connection) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return protocol.Int64(c.Command(ctx, "EXISTS", c.prefixedKeys(keys)...))
+}
+
+// IncrBy increments a key and returns the new value.
+func (c *Connection) IncrBy(ctx context.Context, key string, amount int64) (int64, error) {
+	return protocol.Int64(c.Command(ctx, "INCRBY", c.prefixed(key), amount))
+}
+
+// Expire sets a key's time to live.
+func (c *Connection) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return protocol.Bool(c.Command(ctx, "PEXPIRE", c.prefixed(key), ttl.Milliseconds()))
+}
+
+// TTL returns a key's remaining time to live.
+// Returns -1ms for keys without expiration and -2ms for missing keys, like PTTL.
+func (c *Connection) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ms, err := protocol.Int64(c.Command(ctx, "PTTL", c.prefixed(key)))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// Eval runs a Lua script.
+//
+// The script is first sent as EVALSHA; when the server does not know it
+// (NOSCRIPT) it is sent once with EVAL, which also loads it into the
+// server's script cache for subsequent calls.
+func (c *Connection) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	sum := sha1.Sum([]byte(script))
+	sha := hex.EncodeToString(sum[:])
+
+	params := make([]interface{}, 0, len(keys)+len(args)+2)
+	params = append(params, sha, len(keys))
+	params = append(params, c.prefixedKeys(keys)...)
+	params = append(params, args...)
+
+	reply, err := c.Command(ctx, "EVALSHA", params...)
+
+	var redisErr protocol.RedisError
+	if errors.As(err, &redisErr) && redisErr.Prefix() == "NOSCRIPT" {
+		params[0] = script
+		return c.Command(ctx, "EVAL", params...)
+	}
+	return reply, err
+}
+
+// Pipeline sends every command queued by the callback in one round trip.
+// The result holds one reply per command; failed commands are represented
+// by protocol.RedisError values.
+func (c *Connection) Pipeline(ctx context.Context, callback func(pipe interfaces.PipelineInterface)) ([]interface{}, error) {
+	pipe := &Pipeline{}
+	callback(pipe)
+	if pipe.Len() == 0 {
+		return []interface{}{}, nil
+	}
+
+	start := time.Now()
+	replies, err := c.client.DoMulti(ctx, pipe.Commands())
+	c.dispatch("PIPELINE", commandsAsParameters(pipe.Commands()), time.Since(start), err)
+
+	return replies, err
+}
+
+// Transaction runs every command queued by the callback inside MULTI/EXEC.
+func (c *Connection) Transaction(ctx context.Context, callback func(pipe interfaces.PipelineInterface)) ([]interface{}, error) {
+	pipe := &Pipeline{}
+	callback(pipe)
+	if pipe.Len() == 0 {
+		return []interface{}{}, nil
+	}
+
+	start := time.Now()
+	replies, err := c.client.DoTransaction(ctx, pipe.Commands())
+	c.dispatch("MULTI", commandsAsParameters(pipe.Commands()), time.Since(start), err)
+
+	return replies, err
+}
+
+// Publish posts a message to a channel and returns the receiver count.
+func (c *Connection) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	return protocol.Int64(c.Command(ctx, "PUBLISH", channel, message))
+}
+
+// Subscribe blocks and delivers channel messages until ctx is cancelled.
+func (c *Connection) Subscribe(ctx context.Context, channels []string, callback func(message, channel string)) error {
+	return c.client.Subscribe(ctx, false, channels, callback)
+}
+
+// PSubscribe blocks and delivers pattern messages until ctx is cancelled.
+func (c *Connection) PSubscribe(ctx context.Context, patterns []string, callback func(message, channel string)) error {
+	return c.client.Subscribe(ctx, true, patterns, callback)
+}
+
+// Listen registers a callback fired after every executed command.
+func (c *Connection) Listen(callback func(event *events.CommandExecutedEvent)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listeners = append(c.listeners, callback)
+}
+
+// SetEventsEnabled toggles CommandExecuted events.
+func (c *Connection) SetEventsEnabled(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.eventsEnabled = enabled
+}
+
+// Close closes the underlying client.
+func (c *Connection) Close() error {
+	return c.client.Close()
+}
+
+// dispatch fires a CommandExecuted event to all listeners.
+func (c *Connection) dispatch(command string, parameters []interface{}, duration time.Duration, err error) {
+	c.mu.RLock()
+	if !c.eventsEnabled || len(c.listeners) == 0 {
+		c.mu.RUnlock()
+		return
+	}
+	listeners := append([]func(event *events.CommandExecutedEvent){}, c.listeners...)
+	name := c.name
+	c.mu.RUnlock()
+
+	event := events.NewCommandExecutedEvent(command, parameters, duration, name).WithError(err)
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// prefixed returns the key with the connection prefix.
+func (c *Connection) prefixed(key string) string {
+	return c.prefix + key
+}
+
+// prefixedKeys returns the keys with the connection prefix as arguments.
+func (c *Connection) prefixedKeys(keys []string) []interface{} {
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = c.prefixed(key)
+	}
+	return args
+}
+
+// commandsAsParameters converts queued commands to event parameters.
+func commandsAsParameters(commands [][]interface{}) []interface{} {
+	parameters := make([]interface{}, len(commands))
+	for i, command := range commands {
+		parameters[i] = command
+	}
+	return parameters
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ConnectionInterface = (*Connection)(nil)
@@ -0,0 +1,392 @@
+package connections
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// ScriptFunc emulates a Lua script for the fake client.
+//
+// The call function behaves like redis.call inside Lua: it runs a command
+// against the fake data set and returns its reply. Scripts run atomically,
+// like on a real server.
+type ScriptFunc func(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error)
+
+// Errors returned by the fake, matching the real server's messages.
+const (
+	errWrongType = protocol.RedisError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	errNotInt    = protocol.RedisError("ERR value is not an integer or out of range")
+	errNotFloat  = protocol.RedisError("ERR value is not a valid float")
+	errSyntax    = protocol.RedisError("ERR syntax error")
+)
+
+// fakeEntry is a stored value with its expiration.
+type fakeEntry struct {
+	// value is a string, fakeHash, fakeList, fakeSet or fakeZSet
+	value interface{}
+
+	// expiresAt is the expiration time; zero means no expiration
+	expiresAt time.Time
+}
+
+type (
+	fakeHash map[string]string
+	fakeList []string
+	fakeSet  map[string]struct{}
+	fakeZSet map[string]float64
+)
+
+// fakeSubscriber is an active Subscribe call.
+type fakeSubscriber struct {
+	pattern  bool
+	channels []string
+	messages chan [2]string
+}
+
+// FakeClient is an in-memory implementation of ClientInterface for tests.
+//
+// It supports the string, key, hash, list, set and sorted set commands used
+// by caches, queues and locks, plus pipelines, transactions and pub/sub.
+// Lua cannot be executed: the limiter scripts shipped by the framework are
+// emulated out of the box, other scripts need a Go equivalent registered
+// with DefineScript.
+type FakeClient struct {
+	// mu serializes all commands, making scripts and transactions atomic
+	mu sync.Mutex
+
+	// data holds the stored entries
+	data map[string]*fakeEntry
+
+	// scripts holds script emulations keyed by SHA1
+	scripts map[string]ScriptFunc
+
+	// subscribers are the active subscriptions
+	subscribers map[*fakeSubscriber]struct{}
+
+	// now returns the current time; replaceable for expiration tests
+	now func() time.Time
+}
+
+// NewFakeClient creates an empty fake client.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		data:        make(map[string]*fakeEntry),
+		scripts:     frameworkScripts(),
+		subscribers: make(map[*fakeSubscriber]struct{}),
+		now:         time.Now,
+	}
+}
+
+// SetClock replaces the clock used for expirations.
+func (c *FakeClient) SetClock(now func() time.Time) *FakeClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+	return c
+}
+
+// DefineScript registers a Go emulation of a Lua script.
+// EVAL and EVALSHA of the script then run the emulation.
+func (c *FakeClient) DefineScript(script string, fn ScriptFunc) *FakeClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scripts[scriptSHA(script)] = fn
+	return c
+}
+
+// Flush removes all keys.
+func (c *FakeClient) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data = make(map[string]*fakeEntry)
+}
+
+// Keys returns all live keys, sorted.
+func (c *FakeClient) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.data))
+	for key := range c.data {
+		if c.lookup(key) != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Do runs a single command.
+func (c *FakeClient) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	reply := c.exec(args)
+	c.mu.Unlock()
+
+	if redisErr, ok := reply.(protocol.RedisError); ok {
+		return nil, redisErr
+	}
+	return reply, nil
+}
+
+// DoMulti runs the commands in order; failures are returned as RedisError values.
+func (c *FakeClient) DoMulti(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]interface{}, len(commands))
+	for i, command := range commands {
+		c.mu.Lock()
+		replies[i] = c.exec(command)
+		c.mu.Unlock()
+	}
+	return replies, nil
+}
+
+// DoTransaction runs the commands atomically.
+func (c *FakeClient) DoTransaction(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	replies := make([]interface{}, len(commands))
+	for i, command := range commands {
+		replies[i] = c.exec(command)
+	}
+	return replies, nil
+}
+
+// Subscribe delivers published messages until ctx is cancelled.
+func (c *FakeClient) Subscribe(ctx context.Context, pattern bool, channels []string, callback func(message, channel string)) error {
+	if len(channels) == 0 {
+		return errors.New("redis: subscribe requires at least one channel")
+	}
+
+	subscriber := &fakeSubscriber{
+		pattern:  pattern,
+		channels: channels,
+		messages: make(chan [2]string, 128),
+	}
+
+	c.mu.Lock()
+	c.subscribers[subscriber] = struct{}{}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.subscribers, subscriber)
+		c.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message := <-subscriber.messages:
+			callback(message[1], message[0])
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions.
+func (c *FakeClient) Subscribers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.subscribers)
+}
+
+// Close is a no-op.
+func (c *FakeClient) Close() error {
+	return nil
+}
+
+// exec runs one command; the caller must hold mu.
+func (c *FakeClient) exec(command []interface{}) interface{} {
+	if len(command) == 0 {
+		return protocol.RedisError("ERR empty command")
+	}
+
+	args := make([]string, len(command))
+	for i, arg := range command {
+		value, err := protocol.ArgToString(arg)
+		if err != nil {
+			return protocol.RedisError("ERR " + err.Error())
+		}
+		args[i] = value
+	}
+
+	name := strings.ToUpper(args[0])
+	handler, exists := fakeCommands[name]
+	if !exists {
+		return protocol.RedisError("ERR unknown command '" + args[0] + "'")
+	}
+
+	arity := fakeArity[name]
+	if len(args)-1 < arity {
+		return protocol.RedisError("ERR wrong number of arguments for '" + strings.ToLower(name) + "' command")
+	}
+	return handler(c, name, args[1:])
+}
+
+// lookup returns the live entry for a key, deleting it if it has expired.
+func (c *FakeClient) lookup(key string) *fakeEntry {
+	entry, exists := c.data[key]
+	if !exists {
+		return nil
+	}
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		delete(c.data, key)
+		return nil
+	}
+	return entry
+}
+
+// stringValue returns a string key's value.
+func (c *FakeClient) stringValue(key string) (string, bool, interface{}) {
+	entry := c.lookup(key)
+	if entry == nil {
+		return "", false, nil
+	}
+	value, ok := entry.value.(string)
+	if !ok {
+		return "", false, errWrongType
+	}
+	return value, true, nil
+}
+
+// entryOf returns the entry at key if it holds the given kind. Missing keys
+// are created with create() when create is not nil, and nil otherwise.
+// The second result is an error reply for keys of another kind.
+func (c *FakeClient) entryOf(key, kind string, create func() interface{}) (*fakeEntry, interface{}) {
+	entry := c.lookup(key)
+	if entry == nil {
+		if create == nil {
+			return nil, nil
+		}
+		entry = &fakeEntry{value: create()}
+		c.data[key] = entry
+	}
+
+	if fakeKind(entry.value) != kind {
+		return nil, errWrongType
+	}
+	return entry, nil
+}
+
+// dropIfEmpty removes a key whose collection has become empty.
+func (c *FakeClient) dropIfEmpty(key string) {
+	entry, exists := c.data[key]
+	if !exists {
+		return
+	}
+
+	empty := false
+	switch v := entry.value.(type) {
+	case fakeHash:
+		empty = len(v) == 0
+	case fakeList:
+		empty = len(v) == 0
+	case fakeSet:
+		empty = len(v) == 0
+	case fakeZSet:
+		empty = len(v) == 0
+	}
+	if empty {
+		delete(c.data, key)
+	}
+}
+
+// publish delivers a message to matching subscribers; the caller must hold mu.
+func (c *FakeClient) publish(channel, message string) int64 {
+	var receivers int64
+	for subscriber := range c.subscribers {
+		for _, target := range subscriber.channels {
+			matched := target == channel
+			if subscriber.pattern {
+				matched, _ = path.Match(target, channel)
+			}
+			if !matched {
+				continue
+			}
+
+			select {
+			case subscriber.messages <- [2]string{channel, message}:
+				receivers++
+			default:
+			}
+			break
+		}
+	}
+	return receivers
+}
+
+// fakeKind returns the Redis type name of a stored value.
+func fakeKind(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case fakeHash:
+		return "hash"
+	case fakeList:
+		return "list"
+	case fakeSet:
+		return "set"
+	case fakeZSet:
+		return "zset"
+	default:
+		return "none"
+	}
+}
+
+// scriptSHA returns the SHA1 digest Redis uses to identify a script.
+func scriptSHA(script string) string {
+	sum := sha1.Sum([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseScore parses a sorted set score bound such as "1.5", "(3", "-inf".
+func parseScore(value string) (float64, bool, error) {
+	exclusive := strings.HasPrefix(value, "(")
+	value = strings.TrimPrefix(value, "(")
+
+	switch strings.ToLower(value) {
+	case "-inf":
+		return math.Inf(-1), exclusive, nil
+	case "+inf", "inf":
+		return math.Inf(1), exclusive, nil
+	}
+
+	score, err := strconv.ParseFloat(value, 64)
+	return score, exclusive, err
+}
+
+// formatFloat formats a float like Redis does in replies.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ClientInterface = (*FakeClient)(nil)
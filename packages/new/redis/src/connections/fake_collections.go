@@ -0,0 +1,668 @@
+package connections
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"govel/new/redis/protocol"
+)
+
+// Hash commands
+
+func fakeHSet(c *FakeClient, name string, args []string) interface{} {
+	if len(args)%2 != 1 {
+		return protocol.RedisError("ERR wrong number of arguments for 'hset' command")
+	}
+
+	entry, errReply := c.entryOf(args[0], "hash", func() interface{} { return fakeHash{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	hash := entry.value.(fakeHash)
+	var added int64
+	for i := 1; i < len(args); i += 2 {
+		if _, exists := hash[args[i]]; !exists {
+			added++
+		}
+		hash[args[i]] = args[i+1]
+	}
+	return added
+}
+
+func fakeHGet(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil || entry == nil {
+		return errReply
+	}
+
+	if value, exists := entry.value.(fakeHash)[args[1]]; exists {
+		return value
+	}
+	return nil
+}
+
+func fakeHMGet(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+
+	values := make([]interface{}, len(args)-1)
+	if entry == nil {
+		return values
+	}
+
+	hash := entry.value.(fakeHash)
+	for i, field := range args[1:] {
+		if value, exists := hash[field]; exists {
+			values[i] = value
+		}
+	}
+	return values
+}
+
+func fakeHDel(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	hash := entry.value.(fakeHash)
+	var removed int64
+	for _, field := range args[1:] {
+		if _, exists := hash[field]; exists {
+			delete(hash, field)
+			removed++
+		}
+	}
+	c.dropIfEmpty(args[0])
+	return removed
+}
+
+func fakeHGetAll(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return []interface{}{}
+	}
+
+	hash := entry.value.(fakeHash)
+	fields := make([]string, 0, len(hash))
+	for field := range hash {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	reply := make([]interface{}, 0, len(hash)*2)
+	for _, field := range fields {
+		reply = append(reply, field, hash[field])
+	}
+	return reply
+}
+
+func fakeHExists(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	if _, exists := entry.value.(fakeHash)[args[1]]; exists {
+		return int64(1)
+	}
+	return int64(0)
+}
+
+func fakeHIncrBy(c *FakeClient, name string, args []string) interface{} {
+	amount, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errNotInt
+	}
+
+	entry, errReply := c.entryOf(args[0], "hash", func() interface{} { return fakeHash{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	hash := entry.value.(fakeHash)
+	var current int64
+	if value, exists := hash[args[1]]; exists {
+		if current, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return protocol.RedisError("ERR hash value is not an integer")
+		}
+	}
+
+	current += amount
+	hash[args[1]] = strconv.FormatInt(current, 10)
+	return current
+}
+
+func fakeHLen(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+	return int64(len(entry.value.(fakeHash)))
+}
+
+func fakeHKeys(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "hash", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return []interface{}{}
+	}
+
+	fields := make([]string, 0)
+	for field := range entry.value.(fakeHash) {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return stringsReply(fields)
+}
+
+// List commands
+
+func fakePush(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "list", func() interface{} { return fakeList{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	list := entry.value.(fakeList)
+	for _, value := range args[1:] {
+		if name == "LPUSH" {
+			list = append(fakeList{value}, list...)
+		} else {
+			list = append(list, value)
+		}
+	}
+	entry.value = list
+	return int64(len(list))
+}
+
+func fakePop(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "list", nil)
+	if errReply != nil || entry == nil {
+		return errReply
+	}
+
+	count, withCount := 1, len(args) > 1
+	if withCount {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 0 {
+			return protocol.RedisError("ERR value is out of range, must be positive")
+		}
+		count = parsed
+	}
+
+	list := entry.value.(fakeList)
+	if count > len(list) {
+		count = len(list)
+	}
+
+	var popped []string
+	if name == "LPOP" {
+		popped, list = append([]string{}, list[:count]...), list[count:]
+	} else {
+		popped = make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			popped = append(popped, list[len(list)-1-i])
+		}
+		list = list[:len(list)-count]
+	}
+
+	entry.value = list
+	c.dropIfEmpty(args[0])
+
+	if withCount {
+		return stringsReply(popped)
+	}
+	if len(popped) == 0 {
+		return nil
+	}
+	return popped[0]
+}
+
+func fakeLLen(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "list", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+	return int64(len(entry.value.(fakeList)))
+}
+
+func fakeLRange(c *FakeClient, name string, args []string) interface{} {
+	start, startErr := strconv.Atoi(args[1])
+	stop, stopErr := strconv.Atoi(args[2])
+	if startErr != nil || stopErr != nil {
+		return errNotInt
+	}
+
+	entry, errReply := c.entryOf(args[0], "list", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return []interface{}{}
+	}
+
+	list := entry.value.(fakeList)
+	from, to, ok := rangeBounds(start, stop, len(list))
+	if !ok {
+		return []interface{}{}
+	}
+	return stringsReply(list[from : to+1])
+}
+
+func fakeLRem(c *FakeClient, name string, args []string) interface{} {
+	count, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errNotInt
+	}
+
+	entry, errReply := c.entryOf(args[0], "list", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	list := entry.value.(fakeList)
+	limit := count
+	if limit < 0 {
+		limit = -limit
+	}
+
+	// Negative counts remove from the tail, so walk the list backwards
+	indexes := make([]int, len(list))
+	for i := range indexes {
+		indexes[i] = i
+		if count < 0 {
+			indexes[i] = len(list) - 1 - i
+		}
+	}
+
+	remove := make(map[int]bool)
+	for _, i := range indexes {
+		if list[i] == args[2] && (limit == 0 || len(remove) < limit) {
+			remove[i] = true
+		}
+	}
+
+	kept := make(fakeList, 0, len(list)-len(remove))
+	for i, value := range list {
+		if !remove[i] {
+			kept = append(kept, value)
+		}
+	}
+
+	entry.value = kept
+	c.dropIfEmpty(args[0])
+	return int64(len(remove))
+}
+
+// Set commands
+
+func fakeSAdd(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "set", func() interface{} { return fakeSet{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	set := entry.value.(fakeSet)
+	var added int64
+	for _, member := range args[1:] {
+		if _, exists := set[member]; !exists {
+			set[member] = struct{}{}
+			added++
+		}
+	}
+	return added
+}
+
+func fakeSRem(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "set", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	set := entry.value.(fakeSet)
+	var removed int64
+	for _, member := range args[1:] {
+		if _, exists := set[member]; exists {
+			delete(set, member)
+			removed++
+		}
+	}
+	c.dropIfEmpty(args[0])
+	return removed
+}
+
+func fakeSMembers(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "set", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return []interface{}{}
+	}
+
+	members := make([]string, 0)
+	for member := range entry.value.(fakeSet) {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return stringsReply(members)
+}
+
+func fakeSIsMember(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "set", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	if _, exists := entry.value.(fakeSet)[args[1]]; exists {
+		return int64(1)
+	}
+	return int64(0)
+}
+
+func fakeSCard(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "set", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+	return int64(len(entry.value.(fakeSet)))
+}
+
+// Sorted set commands
+
+func fakeZAdd(c *FakeClient, name string, args []string) interface{} {
+	key, rest := args[0], args[1:]
+
+	var nx, xx bool
+	for len(rest) > 0 {
+		option := strings.ToUpper(rest[0])
+		if option == "NX" {
+			nx = true
+		} else if option == "XX" {
+			xx = true
+		} else {
+			break
+		}
+		rest = rest[1:]
+	}
+	if len(rest) == 0 || len(rest)%2 != 0 || (nx && xx) {
+		return errSyntax
+	}
+
+	scores := make([]float64, 0, len(rest)/2)
+	for i := 0; i < len(rest); i += 2 {
+		score, err := strconv.ParseFloat(rest[i], 64)
+		if err != nil {
+			return errNotFloat
+		}
+		scores = append(scores, score)
+	}
+
+	entry, errReply := c.entryOf(key, "zset", func() interface{} { return fakeZSet{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	zset := entry.value.(fakeZSet)
+	var added int64
+	for i := 0; i < len(rest); i += 2 {
+		member := rest[i+1]
+		_, exists := zset[member]
+		if (nx && exists) || (xx && !exists) {
+			continue
+		}
+		if !exists {
+			added++
+		}
+		zset[member] = scores[i/2]
+	}
+	c.dropIfEmpty(key)
+	return added
+}
+
+func fakeZRem(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "zset", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+
+	zset := entry.value.(fakeZSet)
+	var removed int64
+	for _, member := range args[1:] {
+		if _, exists := zset[member]; exists {
+			delete(zset, member)
+			removed++
+		}
+	}
+	c.dropIfEmpty(args[0])
+	return removed
+}
+
+func fakeZCard(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "zset", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return int64(0)
+	}
+	return int64(len(entry.value.(fakeZSet)))
+}
+
+func fakeZScore(c *FakeClient, name string, args []string) interface{} {
+	entry, errReply := c.entryOf(args[0], "zset", nil)
+	if errReply != nil || entry == nil {
+		return errReply
+	}
+
+	if score, exists := entry.value.(fakeZSet)[args[1]]; exists {
+		return formatFloat(score)
+	}
+	return nil
+}
+
+func fakeZIncrBy(c *FakeClient, name string, args []string) interface{} {
+	amount, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return errNotFloat
+	}
+
+	entry, errReply := c.entryOf(args[0], "zset", func() interface{} { return fakeZSet{} })
+	if errReply != nil {
+		return errReply
+	}
+
+	zset := entry.value.(fakeZSet)
+	zset[args[2]] += amount
+	return formatFloat(zset[args[2]])
+}
+
+func fakeZRange(c *FakeClient, name string, args []string) interface{} {
+	start, startErr := strconv.Atoi(args[1])
+	stop, stopErr := strconv.Atoi(args[2])
+	if startErr != nil || stopErr != nil {
+		return errNotInt
+	}
+
+	withScores := len(args) > 3 && strings.ToUpper(args[3]) == "WITHSCORES"
+
+	entry, errReply := c.entryOf(args[0], "zset", nil)
+	if errReply != nil {
+		return errReply
+	}
+	if entry == nil {
+		return []interface{}{}
+	}
+
+	members := sortedMembers(entry.value.(fakeZSet))
+	from, to, ok := rangeBounds(start, stop, len(members))
+	if !ok {
+		return []interface{}{}
+	}
+	return zsetReply(entry.value.(fakeZSet), members[from:to+1], withScores)
+}
+
+func fakeZRangeByScore(c *FakeClient, name string, args []string) interface{} {
+	matched, zset, errReply := c.zsetByScore(args)
+	if errReply != nil {
+		return errReply
+	}
+
+	withScores := false
+	offset, count := 0, -1
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			if i+2 >= len(args) {
+				return errSyntax
+			}
+			var err1, err2 error
+			offset, err1 = strconv.Atoi(args[i+1])
+			count, err2 = strconv.Atoi(args[i+2])
+			if err1 != nil || err2 != nil {
+				return errNotInt
+			}
+			i += 2
+		default:
+			return errSyntax
+		}
+	}
+
+	if offset < 0 || offset >= len(matched) {
+		return []interface{}{}
+	}
+	matched = matched[offset:]
+	if count >= 0 && count < len(matched) {
+		matched = matched[:count]
+	}
+	return zsetReply(zset, matched, withScores)
+}
+
+func fakeZRemRangeByScore(c *FakeClient, name string, args []string) interface{} {
+	matched, zset, errReply := c.zsetByScore(args)
+	if errReply != nil {
+		return errReply
+	}
+
+	for _, member := range matched {
+		delete(zset, member)
+	}
+	c.dropIfEmpty(args[0])
+	return int64(len(matched))
+}
+
+// zsetByScore returns the members of a sorted set within [min, max] (args[1:3]).
+func (c *FakeClient) zsetByScore(args []string) ([]string, fakeZSet, interface{}) {
+	minScore, minExclusive, minErr := parseScore(args[1])
+	maxScore, maxExclusive, maxErr := parseScore(args[2])
+	if minErr != nil || maxErr != nil {
+		return nil, nil, protocol.RedisError("ERR min or max is not a float")
+	}
+
+	entry, errReply := c.entryOf(args[0], "zset", nil)
+	if errReply != nil {
+		return nil, nil, errReply
+	}
+	if entry == nil {
+		return []string{}, fakeZSet{}, nil
+	}
+
+	zset := entry.value.(fakeZSet)
+	matched := make([]string, 0)
+	for _, member := range sortedMembers(zset) {
+		score := zset[member]
+		if score < minScore || (minExclusive && score == minScore) {
+			continue
+		}
+		if score > maxScore || (maxExclusive && score == maxScore) {
+			continue
+		}
+		matched = append(matched, member)
+	}
+	return matched, zset, nil
+}
+
+// sortedMembers returns the members ordered by score, then lexicographically.
+func sortedMembers(zset fakeZSet) []string {
+	members := make([]string, 0, len(zset))
+	for member := range zset {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if zset[members[i]] != zset[members[j]] {
+			return zset[members[i]] < zset[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	return members
+}
+
+// zsetReply builds a member list reply, optionally interleaved with scores.
+func zsetReply(zset fakeZSet, members []string, withScores bool) []interface{} {
+	reply := make([]interface{}, 0, len(members)*2)
+	for _, member := range members {
+		reply = append(reply, member)
+		if withScores {
+			reply = append(reply, formatFloat(zset[member]))
+		}
+	}
+	return reply
+}
+
+// rangeBounds resolves LRANGE/ZRANGE style indexes (negative from the end).
+func rangeBounds(start, stop, length int) (int, int, bool) {
+	if start < 0 {
+		start = int(math.Max(float64(length+start), 0))
+	}
+	if stop < 0 {
+		stop = length + stop
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if start > stop || start >= length {
+		return 0, 0, false
+	}
+	return start, stop, true
+}
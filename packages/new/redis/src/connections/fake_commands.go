@@ -0,0 +1,503 @@
+package connections
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"govel/new/redis/protocol"
+)
+
+// fakeCommand implements a command on the fake; it returns a reply or a
+// protocol.RedisError value.
+type fakeCommand func(c *FakeClient, name string, args []string) interface{}
+
+// fakeCommands maps command names to their implementation. It is filled in
+// init because EVAL re-enters exec.
+var fakeCommands map[string]fakeCommand
+
+// fakeArity holds the minimum number of arguments per command.
+var fakeArity = map[string]int{
+	"ECHO": 1, "GET": 1, "SET": 2, "SETNX": 2, "SETEX": 3, "PSETEX": 3,
+	"GETSET": 2, "MGET": 1, "MSET": 2, "DEL": 1, "UNLINK": 1, "EXISTS": 1,
+	"EXPIRE": 2, "PEXPIRE": 2, "PERSIST": 1, "TTL": 1, "PTTL": 1, "TYPE": 1,
+	"INCR": 1, "INCRBY": 2, "DECR": 1, "DECRBY": 2, "INCRBYFLOAT": 2, "KEYS": 1,
+	"HSET": 3, "HGET": 2, "HMGET": 2, "HDEL": 2, "HGETALL": 1, "HEXISTS": 2,
+	"HINCRBY": 3, "HLEN": 1, "HKEYS": 1,
+	"LPUSH": 2, "RPUSH": 2, "LPOP": 1, "RPOP": 1, "LLEN": 1, "LRANGE": 3, "LREM": 3,
+	"SADD": 2, "SREM": 2, "SMEMBERS": 1, "SISMEMBER": 2, "SCARD": 1,
+	"ZADD": 3, "ZREM": 2, "ZCARD": 1, "ZSCORE": 2, "ZINCRBY": 3, "ZRANGE": 3,
+	"ZRANGEBYSCORE": 3, "ZREMRANGEBYSCORE": 3,
+	"PUBLISH": 2, "EVAL": 2, "EVALSHA": 2, "SCRIPT": 1,
+}
+
+func init() {
+	fakeCommands = map[string]fakeCommand{
+		"PING":             fakePing,
+		"ECHO":             fakeEcho,
+		"GET":              fakeGet,
+		"SET":              fakeSetString,
+		"SETNX":            fakeSetNX,
+		"SETEX":            fakeSetEX,
+		"PSETEX":           fakeSetEX,
+		"GETSET":           fakeGetSet,
+		"MGET":             fakeMGet,
+		"MSET":             fakeMSet,
+		"INCR":             fakeIncr,
+		"INCRBY":           fakeIncr,
+		"DECR":             fakeIncr,
+		"DECRBY":           fakeIncr,
+		"INCRBYFLOAT":      fakeIncrByFloat,
+		"DEL":              fakeDel,
+		"UNLINK":           fakeDel,
+		"EXISTS":           fakeExists,
+		"EXPIRE":           fakeExpire,
+		"PEXPIRE":          fakeExpire,
+		"PERSIST":          fakePersist,
+		"TTL":              fakeTTL,
+		"PTTL":             fakeTTL,
+		"TYPE":             fakeType,
+		"KEYS":             fakeKeys,
+		"DBSIZE":           fakeDBSize,
+		"FLUSHDB":          fakeFlush,
+		"FLUSHALL":         fakeFlush,
+		"HSET":             fakeHSet,
+		"HGET":             fakeHGet,
+		"HMGET":            fakeHMGet,
+		"HDEL":             fakeHDel,
+		"HGETALL":          fakeHGetAll,
+		"HEXISTS":          fakeHExists,
+		"HINCRBY":          fakeHIncrBy,
+		"HLEN":             fakeHLen,
+		"HKEYS":            fakeHKeys,
+		"LPUSH":            fakePush,
+		"RPUSH":            fakePush,
+		"LPOP":             fakePop,
+		"RPOP":             fakePop,
+		"LLEN":             fakeLLen,
+		"LRANGE":           fakeLRange,
+		"LREM":             fakeLRem,
+		"SADD":             fakeSAdd,
+		"SREM":             fakeSRem,
+		"SMEMBERS":         fakeSMembers,
+		"SISMEMBER":        fakeSIsMember,
+		"SCARD":            fakeSCard,
+		"ZADD":             fakeZAdd,
+		"ZREM":             fakeZRem,
+		"ZCARD":            fakeZCard,
+		"ZSCORE":           fakeZScore,
+		"ZINCRBY":          fakeZIncrBy,
+		"ZRANGE":           fakeZRange,
+		"ZRANGEBYSCORE":    fakeZRangeByScore,
+		"ZREMRANGEBYSCORE": fakeZRemRangeByScore,
+		"PUBLISH":          fakePublish,
+		"EVAL":             fakeEval,
+		"EVALSHA":          fakeEval,
+		"SCRIPT":           fakeScript,
+	}
+}
+
+// Connection and keyspace commands
+
+func fakePing(c *FakeClient, name string, args []string) interface{} {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return "PONG"
+}
+
+func fakeEcho(c *FakeClient, name string, args []string) interface{} {
+	return args[0]
+}
+
+func fakeDel(c *FakeClient, name string, args []string) interface{} {
+	var removed int64
+	for _, key := range args {
+		if c.lookup(key) != nil {
+			delete(c.data, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+func fakeExists(c *FakeClient, name string, args []string) interface{} {
+	var count int64
+	for _, key := range args {
+		if c.lookup(key) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func fakeExpire(c *FakeClient, name string, args []string) interface{} {
+	amount, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return errNotInt
+	}
+
+	entry := c.lookup(args[0])
+	if entry == nil {
+		return int64(0)
+	}
+
+	if amount <= 0 {
+		delete(c.data, args[0])
+		return int64(1)
+	}
+
+	entry.expiresAt = c.now().Add(time.Duration(amount) * fakeUnit(name))
+	return int64(1)
+}
+
+func fakePersist(c *FakeClient, name string, args []string) interface{} {
+	entry := c.lookup(args[0])
+	if entry == nil || entry.expiresAt.IsZero() {
+		return int64(0)
+	}
+
+	entry.expiresAt = time.Time{}
+	return int64(1)
+}
+
+func fakeTTL(c *FakeClient, name string, args []string) interface{} {
+	entry := c.lookup(args[0])
+	if entry == nil {
+		return int64(-2)
+	}
+	if entry.expiresAt.IsZero() {
+		return int64(-1)
+	}
+
+	remaining := entry.expiresAt.Sub(c.now())
+	unit := fakeUnit(name)
+	// Round up like Redis, so a live key never reports 0 seconds
+	return int64((remaining + unit - 1) / unit)
+}
+
+func fakeType(c *FakeClient, name string, args []string) interface{} {
+	entry := c.lookup(args[0])
+	if entry == nil {
+		return "none"
+	}
+	return fakeKind(entry.value)
+}
+
+func fakeKeys(c *FakeClient, name string, args []string) interface{} {
+	keys := make([]string, 0)
+	for key := range c.data {
+		if c.lookup(key) == nil {
+			continue
+		}
+		if matched, _ := path.Match(args[0], key); matched {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return stringsReply(keys)
+}
+
+func fakeDBSize(c *FakeClient, name string, args []string) interface{} {
+	var count int64
+	for key := range c.data {
+		if c.lookup(key) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func fakeFlush(c *FakeClient, name string, args []string) interface{} {
+	c.data = make(map[string]*fakeEntry)
+	return "OK"
+}
+
+// String commands
+
+func fakeGet(c *FakeClient, name string, args []string) interface{} {
+	value, exists, errReply := c.stringValue(args[0])
+	if errReply != nil {
+		return errReply
+	}
+	if !exists {
+		return nil
+	}
+	return value
+}
+
+func fakeSetString(c *FakeClient, name string, args []string) interface{} {
+	key, value := args[0], args[1]
+
+	var ttl time.Duration
+	var nx, xx, keepTTL, get bool
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "KEEPTTL":
+			keepTTL = true
+		case "GET":
+			get = true
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			amount, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || amount <= 0 {
+				return protocol.RedisError("ERR invalid expire time in 'set' command")
+			}
+			ttl = time.Duration(amount) * time.Second
+			if strings.ToUpper(args[i]) == "PX" {
+				ttl = time.Duration(amount) * time.Millisecond
+			}
+			i++
+		default:
+			return errSyntax
+		}
+	}
+	if nx && xx {
+		return errSyntax
+	}
+
+	existing := c.lookup(key)
+	var previous interface{}
+	if get && existing != nil {
+		current, ok := existing.value.(string)
+		if !ok {
+			return errWrongType
+		}
+		previous = current
+	}
+
+	if (nx && existing != nil) || (xx && existing == nil) {
+		if get {
+			return previous
+		}
+		return nil
+	}
+
+	entry := &fakeEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	} else if keepTTL && existing != nil {
+		entry.expiresAt = existing.expiresAt
+	}
+	c.data[key] = entry
+
+	if get {
+		return previous
+	}
+	return "OK"
+}
+
+func fakeSetNX(c *FakeClient, name string, args []string) interface{} {
+	if c.lookup(args[0]) != nil {
+		return int64(0)
+	}
+
+	c.data[args[0]] = &fakeEntry{value: args[1]}
+	return int64(1)
+}
+
+func fakeSetEX(c *FakeClient, name string, args []string) interface{} {
+	amount, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || amount <= 0 {
+		return protocol.RedisError("ERR invalid expire time in '" + strings.ToLower(name) + "' command")
+	}
+
+	unit := time.Second
+	if name == "PSETEX" {
+		unit = time.Millisecond
+	}
+
+	c.data[args[0]] = &fakeEntry{
+		value:     args[2],
+		expiresAt: c.now().Add(time.Duration(amount) * unit),
+	}
+	return "OK"
+}
+
+func fakeGetSet(c *FakeClient, name string, args []string) interface{} {
+	previous := fakeGet(c, "GET", args[:1])
+	if _, isErr := previous.(protocol.RedisError); isErr {
+		return previous
+	}
+
+	c.data[args[0]] = &fakeEntry{value: args[1]}
+	return previous
+}
+
+func fakeMGet(c *FakeClient, name string, args []string) interface{} {
+	values := make([]interface{}, len(args))
+	for i, key := range args {
+		if value, exists, errReply := c.stringValue(key); exists && errReply == nil {
+			values[i] = value
+		}
+	}
+	return values
+}
+
+func fakeMSet(c *FakeClient, name string, args []string) interface{} {
+	if len(args)%2 != 0 {
+		return protocol.RedisError("ERR wrong number of arguments for 'mset' command")
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		c.data[args[i]] = &fakeEntry{value: args[i+1]}
+	}
+	return "OK"
+}
+
+func fakeIncr(c *FakeClient, name string, args []string) interface{} {
+	amount := int64(1)
+	if name == "INCRBY" || name == "DECRBY" {
+		parsed, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		amount = parsed
+	}
+	if name == "DECR" || name == "DECRBY" {
+		amount = -amount
+	}
+
+	value, exists, errReply := c.stringValue(args[0])
+	if errReply != nil {
+		return errReply
+	}
+
+	var current int64
+	if exists {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		current = parsed
+	}
+
+	current += amount
+	c.setStringKeepTTL(args[0], strconv.FormatInt(current, 10))
+	return current
+}
+
+func fakeIncrByFloat(c *FakeClient, name string, args []string) interface{} {
+	amount, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return errNotFloat
+	}
+
+	value, exists, errReply := c.stringValue(args[0])
+	if errReply != nil {
+		return errReply
+	}
+
+	var current float64
+	if exists {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errNotFloat
+		}
+		current = parsed
+	}
+
+	result := formatFloat(current + amount)
+	c.setStringKeepTTL(args[0], result)
+	return result
+}
+
+// Pub/sub and scripting
+
+func fakePublish(c *FakeClient, name string, args []string) interface{} {
+	return c.publish(args[0], args[1])
+}
+
+func fakeEval(c *FakeClient, name string, args []string) interface{} {
+	sha := args[0]
+	if name == "EVAL" {
+		sha = scriptSHA(args[0])
+	}
+
+	script, exists := c.scripts[strings.ToLower(sha)]
+	if !exists {
+		if name == "EVALSHA" {
+			return protocol.RedisError("NOSCRIPT No matching script. Please use EVAL.")
+		}
+		return protocol.RedisError("ERR fake redis cannot run Lua; register the script with DefineScript")
+	}
+
+	numKeys, err := strconv.Atoi(args[1])
+	if err != nil || numKeys < 0 || numKeys > len(args)-2 {
+		return protocol.RedisError("ERR Number of keys can't be greater than number of args")
+	}
+	keys := args[2 : 2+numKeys]
+	argv := args[2+numKeys:]
+
+	call := func(command ...interface{}) (interface{}, error) {
+		reply := c.exec(command)
+		if redisErr, ok := reply.(protocol.RedisError); ok {
+			return nil, redisErr
+		}
+		return reply, nil
+	}
+
+	reply, scriptErr := script(call, keys, argv)
+	if scriptErr != nil {
+		if redisErr, ok := scriptErr.(protocol.RedisError); ok {
+			return redisErr
+		}
+		return protocol.RedisError("ERR " + scriptErr.Error())
+	}
+	return reply
+}
+
+func fakeScript(c *FakeClient, name string, args []string) interface{} {
+	switch strings.ToUpper(args[0]) {
+	case "LOAD":
+		if len(args) < 2 {
+			return errSyntax
+		}
+		return scriptSHA(args[1])
+	case "EXISTS":
+		result := make([]interface{}, len(args)-1)
+		for i, sha := range args[1:] {
+			_, exists := c.scripts[strings.ToLower(sha)]
+			result[i] = map[bool]int64{true: 1, false: 0}[exists]
+		}
+		return result
+	case "FLUSH":
+		return "OK"
+	default:
+		return errSyntax
+	}
+}
+
+// setStringKeepTTL stores a string value, keeping the key's expiration.
+func (c *FakeClient) setStringKeepTTL(key, value string) {
+	if entry := c.lookup(key); entry != nil {
+		entry.value = value
+		return
+	}
+	c.data[key] = &fakeEntry{value: value}
+}
+
+// fakeUnit returns the time unit of an expiration command.
+func fakeUnit(name string) time.Duration {
+	if strings.HasPrefix(name, "P") {
+		return time.Millisecond
+	}
+	return time.Second
+}
+
+// stringsReply converts strings to an array reply.
+func stringsReply(values []string) []interface{} {
+	reply := make([]interface{}, len(values))
+	for i, value := range values {
+		reply[i] = value
+	}
+	return reply
+}
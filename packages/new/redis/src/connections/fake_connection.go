@@ -0,0 +1,64 @@
+package connections
+
+import (
+	"time"
+)
+
+// FakeConnection is an in-memory connection for tests.
+//
+// It is a regular Connection running on a FakeClient, so it implements
+// ConnectionInterface exactly like a real connection and fires the same
+// CommandExecuted events.
+//
+// Example:
+//
+//	conn := connections.NewFakeConnection("default")
+//	conn.Set(ctx, "key", "value", time.Minute)
+//	value, _ := conn.Get(ctx, "key")
+type FakeConnection struct {
+	*Connection
+
+	// fake is the in-memory client
+	fake *FakeClient
+}
+
+// NewFakeConnection creates a new fake connection with an empty data set.
+//
+// Parameters:
+//   - name: Connection name
+//   - prefix: Optional key prefix
+func NewFakeConnection(name string, prefix ...string) *FakeConnection {
+	fake := NewFakeClient()
+
+	keyPrefix := ""
+	if len(prefix) > 0 {
+		keyPrefix = prefix[0]
+	}
+
+	return &FakeConnection{
+		Connection: NewConnection(name, fake, keyPrefix),
+		fake:       fake,
+	}
+}
+
+// Fake returns the in-memory client.
+func (c *FakeConnection) Fake() *FakeClient {
+	return c.fake
+}
+
+// DefineScript registers a Go emulation of a Lua script used with Eval.
+func (c *FakeConnection) DefineScript(script string, fn ScriptFunc) *FakeConnection {
+	c.fake.DefineScript(script, fn)
+	return c
+}
+
+// SetClock replaces the clock used for key expiration.
+func (c *FakeConnection) SetClock(now func() time.Time) *FakeConnection {
+	c.fake.SetClock(now)
+	return c
+}
+
+// Flush removes all keys.
+func (c *FakeConnection) Flush() {
+	c.fake.Flush()
+}
@@ -0,0 +1,154 @@
+package connections
+
+import (
+	"strconv"
+
+	"govel/new/redis/limiters"
+	"govel/new/redis/protocol"
+)
+
+// frameworkScripts returns Go emulations of the Lua scripts shipped by the
+// framework, keyed by SHA1, so the fake runs them without DefineScript.
+func frameworkScripts() map[string]ScriptFunc {
+	return map[string]ScriptFunc{
+		scriptSHA(limiters.DurationLimiterScript):                fakeDurationLimiterScript,
+		scriptSHA(limiters.DurationLimiterTooManyAttemptsScript): fakeDurationLimiterTooManyAttemptsScript,
+		scriptSHA(limiters.ConcurrencyLimiterLockScript):         fakeConcurrencyLimiterLockScript,
+		scriptSHA(limiters.ConcurrencyLimiterReleaseScript):      fakeConcurrencyLimiterReleaseScript,
+	}
+}
+
+// fakeDurationLimiterScript emulates limiters.DurationLimiterScript.
+func fakeDurationLimiterScript(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error) {
+	now, decay, maxLocks, err := durationLimiterArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	key := keys[0]
+
+	reset := func() (interface{}, error) {
+		if _, err := call("HSET", key, "start", now, "end", now+decay, "count", 1); err != nil {
+			return nil, err
+		}
+		acquired, err := call("PEXPIRE", key, decay*2)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{acquired, now + decay, maxLocks - 1}, nil
+	}
+
+	start, end, exists, err := durationLimiterWindow(call, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || now < start || now > end {
+		return reset()
+	}
+
+	count, err := protocol.Int64(call("HINCRBY", key, "count", 1))
+	if err != nil {
+		return nil, err
+	}
+
+	// Lua true is returned as 1, false as nil
+	var acquired interface{}
+	if count <= maxLocks {
+		acquired = int64(1)
+	}
+	return []interface{}{acquired, end, maxLocks - count}, nil
+}
+
+// fakeDurationLimiterTooManyAttemptsScript emulates
+// limiters.DurationLimiterTooManyAttemptsScript.
+func fakeDurationLimiterTooManyAttemptsScript(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error) {
+	now, decay, maxLocks, err := durationLimiterArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, exists, err := durationLimiterWindow(call, keys[0])
+	if err != nil {
+		return nil, err
+	}
+	if !exists || now < start || now > end {
+		return []interface{}{maxLocks, now + decay}, nil
+	}
+
+	count, err := protocol.Int64(call("HGET", keys[0], "count"))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{maxLocks - count, end}, nil
+}
+
+// fakeConcurrencyLimiterLockScript emulates
+// limiters.ConcurrencyLimiterLockScript.
+func fakeConcurrencyLimiterLockScript(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error) {
+	if len(keys) == 0 {
+		return int64(0), nil
+	}
+
+	command := make([]interface{}, 0, len(keys)+1)
+	command = append(command, "MGET")
+	for _, key := range keys {
+		command = append(command, key)
+	}
+	reply, err := call(command...)
+	if err != nil {
+		return nil, err
+	}
+	values, _ := reply.([]interface{})
+
+	for index, value := range values {
+		if value == nil {
+			if _, err := call("SET", keys[index], args[0], "PX", args[1]); err != nil {
+				return nil, err
+			}
+			return int64(index + 1), nil
+		}
+	}
+	return int64(0), nil
+}
+
+// fakeConcurrencyLimiterReleaseScript emulates
+// limiters.ConcurrencyLimiterReleaseScript.
+func fakeConcurrencyLimiterReleaseScript(call func(args ...interface{}) (interface{}, error), keys []string, args []string) (interface{}, error) {
+	owner, err := call("GET", keys[0])
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := owner.(string); ok && value == args[0] {
+		return call("DEL", keys[0])
+	}
+	return int64(0), nil
+}
+
+// durationLimiterArgs parses the current time, window length and maximum
+// slots passed to the duration limiter scripts.
+func durationLimiterArgs(args []string) (now, decay, maxLocks int64, err error) {
+	values := make([]int64, 3)
+	for i := range values {
+		if i >= len(args) {
+			return 0, 0, 0, protocol.RedisError("ERR wrong number of arguments for the duration limiter script")
+		}
+		if values[i], err = strconv.ParseInt(args[i], 10, 64); err != nil {
+			return 0, 0, 0, protocol.RedisError("ERR duration limiter arguments must be integers")
+		}
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// durationLimiterWindow returns the current window of a duration limiter.
+func durationLimiterWindow(call func(args ...interface{}) (interface{}, error), key string) (start, end int64, exists bool, err error) {
+	found, err := protocol.Int64(call("EXISTS", key))
+	if err != nil || found == 0 {
+		return 0, 0, false, err
+	}
+	if start, err = protocol.Int64(call("HGET", key, "start")); err != nil {
+		return 0, 0, false, err
+	}
+	if end, err = protocol.Int64(call("HGET", key, "end")); err != nil {
+		return 0, 0, false, err
+	}
+	return start, end, true, nil
+}
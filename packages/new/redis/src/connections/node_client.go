@@ -0,0 +1,464 @@
+package connections
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// NodeOptions configures a connection to a single Redis server.
+type NodeOptions struct {
+	// Network is "tcp" (default) or "unix"
+	Network string
+
+	// Address is "host:port" or a unix socket path
+	Address string
+
+	// Username is the ACL user name (Redis 6+); empty uses the default user
+	Username string
+
+	// Password is the AUTH password; empty disables authentication
+	Password string
+
+	// Database is the logical database selected after connecting
+	Database int
+
+	// TLS enables TLS when not nil
+	TLS *tls.Config
+
+	// DialTimeout bounds the time to establish a connection
+	DialTimeout time.Duration
+
+	// ReadTimeout bounds each round trip when the context has no deadline
+	ReadTimeout time.Duration
+
+	// PoolSize is the maximum number of idle connections kept open
+	PoolSize int
+
+	// MaxRetries is the number of additional dial attempts on failure
+	MaxRetries int
+
+	// BackoffAlgorithm is "fixed", "linear", "exponential" or "decorrelated_jitter"
+	BackoffAlgorithm string
+
+	// BackoffBase is the base delay between dial attempts
+	BackoffBase time.Duration
+
+	// BackoffCap is the maximum delay between dial attempts
+	BackoffCap time.Duration
+}
+
+// NodeClient is a pooled RESP client for a single Redis server.
+//
+// Commands are never retried once written, as they may not be idempotent;
+// only failed dial attempts are retried using the configured backoff.
+type NodeClient struct {
+	// options are the connection settings
+	options NodeOptions
+
+	// idle holds pooled connections ready for reuse
+	idle chan *nodeConn
+
+	// mu guards closed
+	mu sync.RWMutex
+
+	// closed is set once Close has been called
+	closed bool
+}
+
+// nodeConn is a single network connection with buffered I/O.
+type nodeConn struct {
+	net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// NewNodeClient creates a new client. Connections are opened lazily.
+func NewNodeClient(options NodeOptions) *NodeClient {
+	if options.Network == "" {
+		options.Network = "tcp"
+	}
+	if options.PoolSize <= 0 {
+		options.PoolSize = 10
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = 5 * time.Second
+	}
+	if options.BackoffBase <= 0 {
+		options.BackoffBase = 100 * time.Millisecond
+	}
+	if options.BackoffCap <= 0 {
+		options.BackoffCap = time.Second
+	}
+
+	return &NodeClient{
+		options: options,
+		idle:    make(chan *nodeConn, options.PoolSize),
+	}
+}
+
+// Address returns the server address.
+func (c *NodeClient) Address() string {
+	return c.options.Address
+}
+
+// Options returns the connection settings.
+func (c *NodeClient) Options() NodeOptions {
+	return c.options
+}
+
+// Do sends a single command and returns its reply.
+func (c *NodeClient) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	replies, err := c.DoMulti(ctx, [][]interface{}{args})
+	if err != nil {
+		return nil, err
+	}
+
+	if redisErr, ok := replies[0].(protocol.RedisError); ok {
+		return nil, redisErr
+	}
+	return replies[0], nil
+}
+
+// DoMulti writes all commands, flushes once and reads one reply per command.
+func (c *NodeClient) DoMulti(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := c.roundTrip(ctx, conn, commands)
+	if err != nil {
+		// The stream state is unknown after a transport error
+		conn.Close()
+		return nil, err
+	}
+
+	c.put(conn)
+	return replies, nil
+}
+
+// DoTransaction wraps the commands in MULTI/EXEC.
+// Returns protocol.ErrNil when the transaction was aborted by WATCH.
+func (c *NodeClient) DoTransaction(ctx context.Context, commands [][]interface{}) ([]interface{}, error) {
+	wrapped := make([][]interface{}, 0, len(commands)+2)
+	wrapped = append(wrapped, []interface{}{"MULTI"})
+	wrapped = append(wrapped, commands...)
+	wrapped = append(wrapped, []interface{}{"EXEC"})
+
+	replies, err := c.DoMulti(ctx, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return execResult(replies)
+}
+
+// Subscribe opens a dedicated connection and delivers messages until ctx is
+// cancelled. Returns nil when stopped by the context.
+func (c *NodeClient) Subscribe(ctx context.Context, pattern bool, channels []string, callback func(message, channel string)) error {
+	if len(channels) == 0 {
+		return errors.New("redis: subscribe requires at least one channel")
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	command := "SUBSCRIBE"
+	if pattern {
+		command = "PSUBSCRIBE"
+	}
+
+	args := []interface{}{command}
+	for _, channel := range channels {
+		args = append(args, channel)
+	}
+	if err := protocol.WriteCommand(conn.writer, args); err != nil {
+		return err
+	}
+	if err := conn.writer.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		reply, err := protocol.ReadValue(conn.reader)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if redisErr, ok := reply.(protocol.RedisError); ok {
+			return redisErr
+		}
+
+		items, ok := reply.([]interface{})
+		if !ok || len(items) < 3 {
+			continue
+		}
+
+		kind, _ := items[0].(string)
+		switch {
+		case kind == "message" && len(items) == 3:
+			channel, _ := items[1].(string)
+			message, _ := items[2].(string)
+			callback(message, channel)
+		case kind == "pmessage" && len(items) == 4:
+			channel, _ := items[2].(string)
+			message, _ := items[3].(string)
+			callback(message, channel)
+		}
+	}
+}
+
+// Close closes all pooled connections. In-flight connections are closed
+// when they are returned.
+func (c *NodeClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	for {
+		select {
+		case conn := <-c.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// roundTrip sends the commands on conn and reads their replies.
+func (c *NodeClient) roundTrip(ctx context.Context, conn *nodeConn, commands [][]interface{}) ([]interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok && c.options.ReadTimeout > 0 {
+		deadline = time.Now().Add(c.options.ReadTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	for _, command := range commands {
+		if err := protocol.WriteCommand(conn.writer, command); err != nil {
+			return nil, err
+		}
+	}
+	if err := conn.writer.Flush(); err != nil {
+		return nil, c.contextError(ctx, err)
+	}
+
+	replies := make([]interface{}, len(commands))
+	for i := range commands {
+		reply, err := protocol.ReadValue(conn.reader)
+		if err != nil {
+			return nil, c.contextError(ctx, err)
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// get returns a pooled connection or dials a new one.
+func (c *NodeClient) get(ctx context.Context) (*nodeConn, error) {
+	c.mu.RLock()
+	closed := c.closed
+	c.mu.RUnlock()
+	if closed {
+		return nil, errors.New("redis: client is closed")
+	}
+
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+		return c.dial(ctx)
+	}
+}
+
+// put returns a connection to the pool, closing it if the pool is full.
+func (c *NodeClient) put(conn *nodeConn) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		conn.Close()
+		return
+	}
+
+	select {
+	case c.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// dial opens and initializes a connection, retrying with backoff.
+func (c *NodeClient) dial(ctx context.Context) (*nodeConn, error) {
+	var lastErr error
+	delay := c.options.BackoffBase
+
+	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay = c.backoff(attempt, delay)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		conn, err := c.dialOnce(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+
+		// Authentication and selection errors will not fix themselves
+		var redisErr protocol.RedisError
+		if errors.As(err, &redisErr) {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("redis: failed to connect to %s: %w", c.options.Address, lastErr)
+}
+
+// dialOnce opens a connection and runs AUTH and SELECT.
+func (c *NodeClient) dialOnce(ctx context.Context) (*nodeConn, error) {
+	dialer := &net.Dialer{Timeout: c.options.DialTimeout}
+
+	var raw net.Conn
+	var err error
+	if c.options.TLS != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: c.options.TLS}
+		raw, err = tlsDialer.DialContext(ctx, c.options.Network, c.options.Address)
+	} else {
+		raw, err = dialer.DialContext(ctx, c.options.Network, c.options.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &nodeConn{
+		Conn:   raw,
+		reader: bufio.NewReader(raw),
+		writer: bufio.NewWriter(raw),
+	}
+
+	var setup [][]interface{}
+	if c.options.Password != "" {
+		if c.options.Username != "" {
+			setup = append(setup, []interface{}{"AUTH", c.options.Username, c.options.Password})
+		} else {
+			setup = append(setup, []interface{}{"AUTH", c.options.Password})
+		}
+	}
+	if c.options.Database != 0 {
+		setup = append(setup, []interface{}{"SELECT", c.options.Database})
+	}
+	if len(setup) == 0 {
+		return conn, nil
+	}
+
+	replies, err := c.roundTrip(ctx, conn, setup)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, reply := range replies {
+		if redisErr, ok := reply.(protocol.RedisError); ok {
+			conn.Close()
+			return nil, redisErr
+		}
+	}
+	return conn, nil
+}
+
+// backoff returns the delay before the given dial attempt.
+func (c *NodeClient) backoff(attempt int, previous time.Duration) time.Duration {
+	base, limit := c.options.BackoffBase, c.options.BackoffCap
+
+	var delay time.Duration
+	switch c.options.BackoffAlgorithm {
+	case "fixed":
+		delay = base
+	case "linear":
+		delay = base * time.Duration(attempt)
+	case "exponential":
+		delay = base << (attempt - 1)
+	default:
+		// Decorrelated jitter: random between base and three times the previous delay
+		upper := previous * 3
+		if upper <= base {
+			upper = base + 1
+		}
+		delay = base + time.Duration(rand.Int63n(int64(upper-base)))
+	}
+
+	if delay > limit || delay <= 0 {
+		delay = limit
+	}
+	return delay
+}
+
+// contextError prefers the context error when the context ended the I/O.
+func (c *NodeClient) contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// execResult extracts the EXEC reply from a MULTI ... EXEC round trip.
+func execResult(replies []interface{}) ([]interface{}, error) {
+	if redisErr, ok := replies[0].(protocol.RedisError); ok {
+		return nil, redisErr
+	}
+
+	switch exec := replies[len(replies)-1].(type) {
+	case protocol.RedisError:
+		// EXECABORT: report the first queuing error, which explains the abort
+		for _, reply := range replies[1 : len(replies)-1] {
+			if redisErr, ok := reply.(protocol.RedisError); ok {
+				return nil, fmt.Errorf("%w: %v", exec, redisErr)
+			}
+		}
+		return nil, exec
+	case nil:
+		return nil, protocol.ErrNil
+	case []interface{}:
+		return exec, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected EXEC reply %T", exec)
+	}
+}
+
+// Compile-time interface compliance check
+var _ interfaces.ClientInterface = (*NodeClient)(nil)
@@ -0,0 +1,31 @@
+package connections
+
+import "govel/new/redis/interfaces"
+
+// Pipeline collects commands for Connection.Pipeline and Connection.Transaction.
+type Pipeline struct {
+	commands [][]interface{}
+}
+
+// Command queues a raw command.
+func (p *Pipeline) Command(method string, args ...interface{}) interfaces.PipelineInterface {
+	command := make([]interface{}, 0, len(args)+1)
+	command = append(command, method)
+	command = append(command, args...)
+
+	p.commands = append(p.commands, command)
+	return p
+}
+
+// Len returns the number of queued commands.
+func (p *Pipeline) Len() int {
+	return len(p.commands)
+}
+
+// Commands returns the queued commands.
+func (p *Pipeline) Commands() [][]interface{} {
+	return p.commands
+}
+
+// Compile-time interface compliance check
+var _ interfaces.PipelineInterface = (*Pipeline)(nil)
@@ -0,0 +1,55 @@
+package connections
+
+import (
+	"strings"
+
+	"govel/new/redis/interfaces"
+)
+
+// ClusterSlots is the number of hash slots in a Redis Cluster.
+const ClusterSlots = 16384
+
+// SlotHasher computes Redis Cluster hash slots: CRC16 (XMODEM) of the key
+// modulo 16384. If the key contains a non-empty {hash tag}, only the tag is
+// hashed, so related keys can be kept on the same node.
+type SlotHasher struct{}
+
+// crc16Table is the lookup table for the CRC16-CCITT (XMODEM) polynomial 0x1021.
+var crc16Table = func() [256]uint16 {
+	var table [256]uint16
+	for i := range table {
+		crc := uint16(i) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// NewSlotHasher creates a new slot hasher.
+func NewSlotHasher() *SlotHasher {
+	return &SlotHasher{}
+}
+
+// Slot returns the hash slot owning the key.
+func (h *SlotHasher) Slot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc = crc<<8 ^ crc16Table[byte(crc>>8)^key[i]]
+	}
+	return int(crc) % ClusterSlots
+}
+
+// Compile-time interface compliance check
+var _ interfaces.HasherInterface = (*SlotHasher)(nil)
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommandExecutedEvent is fired after a Redis command has been executed
+type CommandExecutedEvent struct {
+	// Command is the command name (e.g. "GET", "EVALSHA", "PIPELINE")
+	Command string
+
+	// Parameters are the command arguments; for pipelines and transactions
+	// each parameter is one queued command
+	Parameters []interface{}
+
+	// Time is how long the command took, including the network round trip
+	Time time.Duration
+
+	// ConnectionName is the name of the connection the command ran on
+	ConnectionName string
+
+	// Error is the error returned by the command, if any
+	Error error
+
+	// ExecutedAt is when the command finished
+	ExecutedAt time.Time
+}
+
+// NewCommandExecutedEvent creates a new CommandExecutedEvent
+func NewCommandExecutedEvent(command string, parameters []interface{}, duration time.Duration, connectionName string) *CommandExecutedEvent {
+	return &CommandExecutedEvent{
+		Command:        command,
+		Parameters:     parameters,
+		Time:           duration,
+		ConnectionName: connectionName,
+		ExecutedAt:     time.Now(),
+	}
+}
+
+// GetEventName returns the event name
+func (e *CommandExecutedEvent) GetEventName() string {
+	return "redis.command.executed"
+}
+
+// WithError sets the error returned by the command
+func (e *CommandExecutedEvent) WithError(err error) *CommandExecutedEvent {
+	e.Error = err
+	return e
+}
+
+// HasError returns true if the command failed
+func (e *CommandExecutedEvent) HasError() bool {
+	return e.Error != nil
+}
+
+// ToMap converts the event to a map for serialization
+func (e *CommandExecutedEvent) ToMap() map[string]interface{} {
+	data := map[string]interface{}{
+		"event":       e.GetEventName(),
+		"command":     e.Command,
+		"parameters":  e.Parameters,
+		"time_ms":     float64(e.Time.Microseconds()) / 1000,
+		"connection":  e.ConnectionName,
+		"executed_at": e.ExecutedAt,
+	}
+
+	if e.HasError() {
+		data["error"] = e.Error.Error()
+	}
+
+	return data
+}
+
+// String returns a string representation of the event
+func (e *CommandExecutedEvent) String() string {
+	baseMsg := fmt.Sprintf("Redis command %s executed on '%s' in %v", e.Command, e.ConnectionName, e.Time)
+
+	if e.HasError() {
+		baseMsg += fmt.Sprintf(" (error: %v)", e.Error)
+	}
+
+	return baseMsg
+}
@@ -0,0 +1,31 @@
+// Package exceptions contains the exceptions raised by the redis package.
+package exceptions
+
+import (
+	"govel/exceptions/core"
+	"govel/exceptions/interfaces"
+)
+
+// LimiterTimeoutException is raised when a Redis limiter could not acquire a
+// slot before its block timeout elapsed. It renders as HTTP 429.
+type LimiterTimeoutException struct {
+	*core.Exception
+}
+
+// NewLimiterTimeoutException creates a new limiter timeout exception.
+//
+// Parameters:
+//   - message: Optional custom error message
+func NewLimiterTimeoutException(message ...string) *LimiterTimeoutException {
+	msg := "Timed out waiting for a Redis limiter slot."
+	if len(message) > 0 && message[0] != "" {
+		msg = message[0]
+	}
+
+	return &LimiterTimeoutException{
+		Exception: core.NewException(msg, 429),
+	}
+}
+
+// Ensure LimiterTimeoutException implements the ExceptionInterface
+var _ interfaces.ExceptionInterface = (*LimiterTimeoutException)(nil)
@@ -0,0 +1,116 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"govel/new/redis/events"
+)
+
+// ClientInterface is the low-level transport a connection runs commands on.
+// Implementations exist for a single server, a Redis Cluster and an
+// in-memory fake.
+type ClientInterface interface {
+	// Do sends a single command and returns its decoded reply.
+	// Server error replies are returned as protocol.RedisError errors.
+	Do(ctx context.Context, args ...interface{}) (interface{}, error)
+
+	// DoMulti sends several commands in one round trip (pipelining).
+	// The returned slice holds one reply per command; failed commands are
+	// represented by protocol.RedisError values. The error is reserved for
+	// transport failures.
+	DoMulti(ctx context.Context, commands [][]interface{}) ([]interface{}, error)
+
+	// DoTransaction runs the commands atomically inside MULTI/EXEC and
+	// returns one reply per command.
+	DoTransaction(ctx context.Context, commands [][]interface{}) ([]interface{}, error)
+
+	// Subscribe listens on the given channels (or patterns) and invokes the
+	// callback for every message until the context is cancelled.
+	Subscribe(ctx context.Context, pattern bool, channels []string, callback func(message, channel string)) error
+
+	// Close releases all resources held by the client.
+	Close() error
+}
+
+// PipelineInterface collects commands to be sent in a single round trip.
+type PipelineInterface interface {
+	// Command queues a raw command. Keys are not prefixed.
+	Command(method string, args ...interface{}) PipelineInterface
+
+	// Len returns the number of queued commands.
+	Len() int
+}
+
+// ConnectionInterface defines a named Redis connection.
+//
+// Typed helpers apply the connection's key prefix; Command and pipelined
+// commands send their arguments as given.
+type ConnectionInterface interface {
+	// GetName returns the connection name.
+	GetName() string
+
+	// SetName sets the connection name.
+	SetName(name string)
+
+	// GetPrefix returns the prefix applied to keys by the typed helpers.
+	GetPrefix() string
+
+	// Client returns the underlying transport.
+	Client() ClientInterface
+
+	// Command runs a raw command such as ("HSET", "key", "field", "value").
+	Command(ctx context.Context, method string, args ...interface{}) (interface{}, error)
+
+	// Get returns the value of a key, or protocol.ErrNil if it does not exist.
+	Get(ctx context.Context, key string) (string, error)
+
+	// Set stores a value; a zero ttl stores it without expiration.
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// SetNX stores a value only if the key does not exist.
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// Del removes keys and returns how many existed.
+	Del(ctx context.Context, keys ...string) (int64, error)
+
+	// Exists returns how many of the given keys exist.
+	Exists(ctx context.Context, keys ...string) (int64, error)
+
+	// IncrBy increments a key and returns the new value.
+	IncrBy(ctx context.Context, key string, amount int64) (int64, error)
+
+	// Expire sets a key's time to live.
+	Expire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// TTL returns a key's remaining time to live (negative if none/missing).
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// Eval runs a Lua script, using EVALSHA and the server's script cache
+	// when possible.
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+
+	// Pipeline sends every command queued by the callback in one round trip.
+	Pipeline(ctx context.Context, callback func(pipe PipelineInterface)) ([]interface{}, error)
+
+	// Transaction runs every command queued by the callback inside MULTI/EXEC.
+	Transaction(ctx context.Context, callback func(pipe PipelineInterface)) ([]interface{}, error)
+
+	// Publish posts a message to a channel and returns the receiver count.
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+
+	// Subscribe blocks and delivers channel messages until ctx is cancelled.
+	Subscribe(ctx context.Context, channels []string, callback func(message, channel string)) error
+
+	// PSubscribe blocks and delivers pattern messages until ctx is cancelled.
+	PSubscribe(ctx context.Context, patterns []string, callback func(message, channel string)) error
+
+	// Listen registers a callback fired after every executed command.
+	Listen(callback func(event *events.CommandExecutedEvent))
+
+	// SetEventsEnabled toggles CommandExecuted events.
+	SetEventsEnabled(enabled bool)
+
+	// Close closes the connection.
+	Close() error
+}
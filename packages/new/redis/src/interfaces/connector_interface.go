@@ -0,0 +1,20 @@
+package interfaces
+
+// ConnectorInterface creates connections for a Redis client library.
+// Connectors are selected with the database.redis.client setting.
+type ConnectorInterface interface {
+	// Connect creates a connection to a single server.
+	//
+	// Parameters:
+	//   - config: Connection settings (url, host, port, username, password, database, ...)
+	//   - options: Global and connection options (prefix, ...)
+	Connect(config map[string]interface{}, options map[string]interface{}) (ConnectionInterface, error)
+
+	// ConnectToCluster creates a connection to a Redis Cluster.
+	//
+	// Parameters:
+	//   - nodes: Seed node settings
+	//   - clusterOptions: Options of the cluster definition
+	//   - options: Global options (prefix, ...)
+	ConnectToCluster(nodes []map[string]interface{}, clusterOptions map[string]interface{}, options map[string]interface{}) (ConnectionInterface, error)
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+// FactoryInterface resolves named Redis connections.
+type FactoryInterface interface {
+	// Connection returns the named connection, or the default one.
+	Connection(name ...string) (ConnectionInterface, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+// HasherInterface maps keys to Redis Cluster hash slots.
+type HasherInterface interface {
+	// Slot returns the hash slot (0-16383) owning the key, honouring
+	// {hash tags}.
+	Slot(key string) int
+}
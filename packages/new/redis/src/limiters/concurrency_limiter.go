@@ -0,0 +1,155 @@
+package limiters
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"govel/new/redis/exceptions"
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// ConcurrencyLimiterLockScript atomically takes the first free slot.
+//
+// KEYS    - The slot keys (name1 ... nameN)
+// ARGV[1] - Owner ID written to the slot
+// ARGV[2] - Time in milliseconds after which the slot is released automatically
+//
+// Returns the 1-based index of the acquired slot, or 0 if all are taken.
+const ConcurrencyLimiterLockScript = `
+for index, value in pairs(redis.call('MGET', unpack(KEYS))) do
+    if not value then
+        redis.call('SET', KEYS[index], ARGV[1], 'PX', ARGV[2])
+        return index
+    end
+end
+return 0
+`
+
+// ConcurrencyLimiterReleaseScript releases a slot if it is still owned by
+// the caller.
+//
+// KEYS[1] - The slot key
+// ARGV[1] - Owner ID
+const ConcurrencyLimiterReleaseScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+    return redis.call('DEL', KEYS[1])
+else
+    return 0
+end
+`
+
+// ConcurrencyLimiter limits how many executions may run at the same time
+// (Laravel's Redis::funnel).
+//
+// Each slot is a separate key, name1 ... nameN. On Redis Cluster the name
+// must contain a {hash tag} so that all slots live on the same node.
+type ConcurrencyLimiter struct {
+	// connection is the Redis connection holding the slots
+	connection interfaces.ConnectionInterface
+
+	// name is the slot key prefix
+	name string
+
+	// maxLocks is the number of concurrent executions allowed
+	maxLocks int
+
+	// releaseAfter releases slots of crashed holders automatically
+	releaseAfter time.Duration
+}
+
+// NewConcurrencyLimiter creates a new concurrency limiter.
+//
+// Parameters:
+//   - connection: Redis connection
+//   - name: Limiter key
+//   - maxLocks: Concurrent executions allowed
+//   - releaseAfter: Time after which a slot is released even if its holder crashed
+func NewConcurrencyLimiter(connection interfaces.ConnectionInterface, name string, maxLocks int, releaseAfter time.Duration) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		connection:   connection,
+		name:         name,
+		maxLocks:     maxLocks,
+		releaseAfter: releaseAfter,
+	}
+}
+
+// Block attempts to acquire a slot until the timeout elapses, runs the
+// callback and releases the slot. A zero timeout makes a single attempt.
+//
+// Returns a LimiterTimeoutException if no slot could be acquired in time,
+// otherwise the callback's error.
+func (l *ConcurrencyLimiter) Block(ctx context.Context, timeout time.Duration, callback func() error, sleep ...time.Duration) error {
+	wait := 250 * time.Millisecond
+	if len(sleep) > 0 && sleep[0] > 0 {
+		wait = sleep[0]
+	}
+
+	id := ownerID()
+	started := time.Now()
+
+	var slot string
+	for {
+		var err error
+		if slot, err = l.Acquire(ctx, id); err != nil {
+			return err
+		}
+		if slot != "" {
+			break
+		}
+
+		if time.Since(started) >= timeout {
+			return exceptions.NewLimiterTimeoutException()
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	if callback == nil {
+		return nil
+	}
+
+	defer func() {
+		// Use a fresh context so a cancelled request still frees its slot
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		l.Release(releaseCtx, slot, id)
+	}()
+
+	return callback()
+}
+
+// Acquire attempts to take a free slot for the owner ID.
+// Returns the slot key, or "" if every slot is taken.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, id string) (string, error) {
+	slots := make([]string, l.maxLocks)
+	for i := range slots {
+		slots[i] = fmt.Sprintf("%s%d", l.name, i+1)
+	}
+
+	index, err := protocol.Int64(l.connection.Eval(ctx, ConcurrencyLimiterLockScript, slots, id, l.releaseAfter.Milliseconds()))
+	if err != nil {
+		return "", err
+	}
+	if index < 1 || int(index) > len(slots) {
+		return "", nil
+	}
+	return slots[index-1], nil
+}
+
+// Release frees a slot if it is still owned by the given ID.
+func (l *ConcurrencyLimiter) Release(ctx context.Context, slot, id string) error {
+	_, err := l.connection.Eval(ctx, ConcurrencyLimiterReleaseScript, []string{slot}, id)
+	return err
+}
+
+// ownerID returns a random slot owner ID.
+func ownerID() string {
+	buf := make([]byte, 10)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,100 @@
+package limiters
+
+import (
+	"context"
+	"time"
+
+	"govel/new/redis/interfaces"
+)
+
+// ConcurrencyLimiterBuilder builds and runs a ConcurrencyLimiter fluently.
+//
+// Example:
+//
+//	err := manager.Funnel("reports").Limit(3).Block(5*time.Second).Then(ctx,
+//		func() error { return buildReport() },
+//		func(err error) error { return retryLater() },
+//	)
+type ConcurrencyLimiterBuilder struct {
+	// connection is the Redis connection holding the slots
+	connection interfaces.ConnectionInterface
+
+	// name is the limiter key
+	name string
+
+	// maxLocks is the number of concurrent executions allowed
+	maxLocks int
+
+	// releaseAfter releases slots of crashed holders automatically
+	releaseAfter time.Duration
+
+	// timeout is how long Then waits for a slot
+	timeout time.Duration
+
+	// sleep is the delay between attempts
+	sleep time.Duration
+
+	// err is a deferred error (e.g. connection resolution) returned by Then
+	err error
+}
+
+// NewConcurrencyLimiterBuilder creates a new builder with Laravel's defaults:
+// slots released after 60 seconds, a 3 second block timeout and 250ms
+// between attempts.
+func NewConcurrencyLimiterBuilder(connection interfaces.ConnectionInterface, name string) *ConcurrencyLimiterBuilder {
+	return &ConcurrencyLimiterBuilder{
+		connection:   connection,
+		name:         name,
+		releaseAfter: time.Minute,
+		timeout:      3 * time.Second,
+		sleep:        250 * time.Millisecond,
+	}
+}
+
+// WithError makes Then return the given error without running anything.
+func (b *ConcurrencyLimiterBuilder) WithError(err error) *ConcurrencyLimiterBuilder {
+	b.err = err
+	return b
+}
+
+// Limit sets the number of concurrent executions allowed.
+func (b *ConcurrencyLimiterBuilder) Limit(maxLocks int) *ConcurrencyLimiterBuilder {
+	b.maxLocks = maxLocks
+	return b
+}
+
+// ReleaseAfter sets when slots of crashed holders are released.
+func (b *ConcurrencyLimiterBuilder) ReleaseAfter(releaseAfter time.Duration) *ConcurrencyLimiterBuilder {
+	b.releaseAfter = releaseAfter
+	return b
+}
+
+// Block sets how long to wait for a slot.
+func (b *ConcurrencyLimiterBuilder) Block(timeout time.Duration) *ConcurrencyLimiterBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// Sleep sets the delay between attempts.
+func (b *ConcurrencyLimiterBuilder) Sleep(sleep time.Duration) *ConcurrencyLimiterBuilder {
+	b.sleep = sleep
+	return b
+}
+
+// Limiter returns the configured limiter.
+func (b *ConcurrencyLimiterBuilder) Limiter() *ConcurrencyLimiter {
+	return NewConcurrencyLimiter(b.connection, b.name, b.maxLocks, b.releaseAfter)
+}
+
+// Then runs the callback once a slot is acquired and releases it afterwards.
+//
+// If the block timeout elapses and a failure callback is given, its result
+// is returned; otherwise the LimiterTimeoutException is returned.
+func (b *ConcurrencyLimiterBuilder) Then(ctx context.Context, callback func() error, failure ...func(err error) error) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	err := b.Limiter().Block(ctx, b.timeout, callback, b.sleep)
+	return handleFailure(err, failure)
+}
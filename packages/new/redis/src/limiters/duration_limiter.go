@@ -0,0 +1,250 @@
+// Package limiters implements Laravel's Redis throttle and funnel limiters
+// using atomic Lua scripts, so limits hold across processes and servers.
+package limiters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"govel/new/redis/exceptions"
+	"govel/new/redis/interfaces"
+	"govel/new/redis/protocol"
+)
+
+// DurationLimiterScript atomically takes a slot in the current window.
+//
+// KEYS[1] - The limiter name
+// ARGV[1] - Current time in milliseconds
+// ARGV[2] - Window length in milliseconds
+// ARGV[3] - Maximum slots per window
+//
+// Returns {acquired, window end in milliseconds, remaining slots}.
+const DurationLimiterScript = `
+local function reset()
+    redis.call('HSET', KEYS[1], 'start', ARGV[1], 'end', ARGV[1] + ARGV[2], 'count', 1)
+    return redis.call('PEXPIRE', KEYS[1], ARGV[2] * 2)
+end
+
+if redis.call('EXISTS', KEYS[1]) == 0 then
+    return {reset(), ARGV[1] + ARGV[2], ARGV[3] - 1}
+end
+
+if tonumber(ARGV[1]) >= tonumber(redis.call('HGET', KEYS[1], 'start')) and tonumber(ARGV[1]) <= tonumber(redis.call('HGET', KEYS[1], 'end')) then
+    return {
+        tonumber(redis.call('HINCRBY', KEYS[1], 'count', 1)) <= tonumber(ARGV[3]),
+        tonumber(redis.call('HGET', KEYS[1], 'end')),
+        ARGV[3] - redis.call('HGET', KEYS[1], 'count')
+    }
+end
+
+return {reset(), ARGV[1] + ARGV[2], ARGV[3] - 1}
+`
+
+// DurationLimiterTooManyAttemptsScript reports the state of the current
+// window without taking a slot.
+//
+// KEYS[1] - The limiter name
+// ARGV[1] - Current time in milliseconds
+// ARGV[2] - Window length in milliseconds
+// ARGV[3] - Maximum slots per window
+//
+// Returns {remaining slots, window end in milliseconds}.
+const DurationLimiterTooManyAttemptsScript = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+    return {tonumber(ARGV[3]), ARGV[1] + ARGV[2]}
+end
+
+if tonumber(ARGV[1]) >= tonumber(redis.call('HGET', KEYS[1], 'start')) and tonumber(ARGV[1]) <= tonumber(redis.call('HGET', KEYS[1], 'end')) then
+    return {
+        ARGV[3] - redis.call('HGET', KEYS[1], 'count'),
+        tonumber(redis.call('HGET', KEYS[1], 'end'))
+    }
+end
+
+return {tonumber(ARGV[3]), ARGV[1] + ARGV[2]}
+`
+
+// DurationLimiter allows a fixed number of executions per time window
+// (Laravel's Redis::throttle).
+type DurationLimiter struct {
+	// connection is the Redis connection holding the limiter state
+	connection interfaces.ConnectionInterface
+
+	// name is the limiter key
+	name string
+
+	// maxLocks is the number of executions allowed per window
+	maxLocks int
+
+	// decay is the window length
+	decay time.Duration
+
+	// decaysAt is when the current window ends, updated by each attempt
+	decaysAt time.Time
+
+	// remaining is the number of slots left in the window, updated by each attempt
+	remaining int
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewDurationLimiter creates a new duration limiter.
+//
+// Parameters:
+//   - connection: Redis connection
+//   - name: Limiter key
+//   - maxLocks: Executions allowed per window
+//   - decay: Window length
+func NewDurationLimiter(connection interfaces.ConnectionInterface, name string, maxLocks int, decay time.Duration) *DurationLimiter {
+	return &DurationLimiter{
+		connection: connection,
+		name:       name,
+		maxLocks:   maxLocks,
+		decay:      decay,
+		now:        time.Now,
+	}
+}
+
+// SetClock replaces the clock used to compute windows.
+func (l *DurationLimiter) SetClock(now func() time.Time) *DurationLimiter {
+	l.now = now
+	return l
+}
+
+// Block attempts to acquire a slot until the timeout elapses, then runs the
+// callback. A zero timeout makes a single attempt.
+//
+// Returns a LimiterTimeoutException if no slot could be acquired in time,
+// otherwise the callback's error.
+func (l *DurationLimiter) Block(ctx context.Context, timeout time.Duration, callback func() error, sleep ...time.Duration) error {
+	wait := 750 * time.Millisecond
+	if len(sleep) > 0 && sleep[0] > 0 {
+		wait = sleep[0]
+	}
+
+	started := l.now()
+	for {
+		acquired, err := l.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			break
+		}
+
+		if l.now().Sub(started) >= timeout {
+			return exceptions.NewLimiterTimeoutException()
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	if callback == nil {
+		return nil
+	}
+	return callback()
+}
+
+// Acquire attempts to take a slot in the current window.
+func (l *DurationLimiter) Acquire(ctx context.Context) (bool, error) {
+	reply, err := l.connection.Eval(ctx, DurationLimiterScript, []string{l.name},
+		l.now().UnixMilli(), l.decay.Milliseconds(), l.maxLocks)
+	if err != nil {
+		return false, err
+	}
+
+	values, err := replyValues(reply, 3)
+	if err != nil {
+		return false, err
+	}
+
+	// Lua true is returned as 1, false as nil
+	acquired := values[0] != nil
+	if n, ok := values[0].(int64); ok {
+		acquired = n == 1
+	}
+
+	if err := l.update(values[1], values[2]); err != nil {
+		return false, err
+	}
+	return acquired, nil
+}
+
+// TooManyAttempts reports whether the current window has no slots left.
+func (l *DurationLimiter) TooManyAttempts(ctx context.Context) (bool, error) {
+	reply, err := l.connection.Eval(ctx, DurationLimiterTooManyAttemptsScript, []string{l.name},
+		l.now().UnixMilli(), l.decay.Milliseconds(), l.maxLocks)
+	if err != nil {
+		return false, err
+	}
+
+	values, err := replyValues(reply, 2)
+	if err != nil {
+		return false, err
+	}
+
+	if err := l.update(values[1], values[0]); err != nil {
+		return false, err
+	}
+	return l.remaining <= 0, nil
+}
+
+// Clear resets the limiter.
+func (l *DurationLimiter) Clear(ctx context.Context) error {
+	_, err := l.connection.Del(ctx, l.name)
+	return err
+}
+
+// DecaysAt returns when the current window ends, as of the last attempt.
+func (l *DurationLimiter) DecaysAt() time.Time {
+	return l.decaysAt
+}
+
+// Remaining returns the slots left in the window, as of the last attempt.
+func (l *DurationLimiter) Remaining() int {
+	return l.remaining
+}
+
+// update records the window end and remaining slots from a script reply.
+func (l *DurationLimiter) update(decaysAt, remaining interface{}) error {
+	endMillis, err := protocol.Int64(decaysAt, nil)
+	if err != nil {
+		return err
+	}
+	left, err := protocol.Int64(remaining, nil)
+	if err != nil {
+		return err
+	}
+
+	l.decaysAt = time.UnixMilli(endMillis)
+	l.remaining = int(left)
+	if l.remaining < 0 {
+		l.remaining = 0
+	}
+	return nil
+}
+
+// replyValues checks that a script returned an array of the given size.
+func replyValues(reply interface{}, size int) ([]interface{}, error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != size {
+		return nil, fmt.Errorf("redis: unexpected limiter script reply %v", reply)
+	}
+	return values, nil
+}
+
+// sleepContext waits for the duration or until the context is done.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package limiters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"govel/new/redis/exceptions"
+	"govel/new/redis/interfaces"
+)
+
+// DurationLimiterBuilder builds and runs a DurationLimiter fluently.
+//
+// Example:
+//
+//	err := manager.Throttle("api:github").Allow(10).Every(time.Minute).Then(ctx,
+//		func() error { return callGitHub() },
+//		func(err error) error { return retryLater() },
+//	)
+type DurationLimiterBuilder struct {
+	// connection is the Redis connection holding the limiter state
+	connection interfaces.ConnectionInterface
+
+	// name is the limiter key
+	name string
+
+	// maxLocks is the number of executions allowed per window
+	maxLocks int
+
+	// decay is the window length
+	decay time.Duration
+
+	// timeout is how long Then waits for a slot
+	timeout time.Duration
+
+	// sleep is the delay between attempts
+	sleep time.Duration
+
+	// err is a deferred error (e.g. connection resolution) returned by Then
+	err error
+}
+
+// NewDurationLimiterBuilder creates a new builder with Laravel's defaults:
+// no executions allowed until Allow is called, a 60 second window, a 3 second
+// block timeout and 750ms between attempts.
+func NewDurationLimiterBuilder(connection interfaces.ConnectionInterface, name string) *DurationLimiterBuilder {
+	return &DurationLimiterBuilder{
+		connection: connection,
+		name:       name,
+		decay:      time.Minute,
+		timeout:    3 * time.Second,
+		sleep:      750 * time.Millisecond,
+	}
+}
+
+// WithError makes Then return the given error without running anything.
+func (b *DurationLimiterBuilder) WithError(err error) *DurationLimiterBuilder {
+	b.err = err
+	return b
+}
+
+// Allow sets the number of executions allowed per window.
+func (b *DurationLimiterBuilder) Allow(maxLocks int) *DurationLimiterBuilder {
+	b.maxLocks = maxLocks
+	return b
+}
+
+// Every sets the window length.
+func (b *DurationLimiterBuilder) Every(decay time.Duration) *DurationLimiterBuilder {
+	b.decay = decay
+	return b
+}
+
+// Block sets how long to wait for a slot.
+func (b *DurationLimiterBuilder) Block(timeout time.Duration) *DurationLimiterBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// Sleep sets the delay between attempts.
+func (b *DurationLimiterBuilder) Sleep(sleep time.Duration) *DurationLimiterBuilder {
+	b.sleep = sleep
+	return b
+}
+
+// Limiter returns the configured limiter.
+func (b *DurationLimiterBuilder) Limiter() *DurationLimiter {
+	return NewDurationLimiter(b.connection, b.name, b.maxLocks, b.decay)
+}
+
+// Then runs the callback once a slot is acquired.
+//
+// If the block timeout elapses and a failure callback is given, its result
+// is returned; otherwise the LimiterTimeoutException is returned.
+func (b *DurationLimiterBuilder) Then(ctx context.Context, callback func() error, failure ...func(err error) error) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	err := b.Limiter().Block(ctx, b.timeout, callback, b.sleep)
+	return handleFailure(err, failure)
+}
+
+// handleFailure routes limiter timeouts to the failure callback.
+func handleFailure(err error, failure []func(err error) error) error {
+	var timeout *exceptions.LimiterTimeoutException
+	if errors.As(err, &timeout) && len(failure) > 0 && failure[0] != nil {
+		return failure[0](err)
+	}
+	return err
+}
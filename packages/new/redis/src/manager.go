@@ -0,0 +1,382 @@
+// Package redis provides Laravel-style Redis connection management for GoVel.
+//
+// Connections are configured under database.redis and resolved by name:
+//
+//	conn, err := manager.Connection("cache")
+//	err = conn.Set(ctx, "key", "value", time.Minute)
+//
+// Single servers, Redis Cluster (database.redis.clusters) and an in-memory
+// fake for tests all implement interfaces.ConnectionInterface.
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"govel/new/redis/connections"
+	"govel/new/redis/connectors"
+	"govel/new/redis/events"
+	"govel/new/redis/interfaces"
+	"govel/new/redis/limiters"
+	configInterfaces "govel/types/interfaces/config"
+	containerInterfaces "govel/types/interfaces/container"
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// RedisManager resolves and caches named Redis connections.
+//
+// Key features:
+//   - Named connections and clusters from database.redis
+//   - Pluggable client connectors selected by database.redis.client
+//   - CommandExecuted events with timing, forwarded to registered listeners
+//   - Throttle and Funnel limiters backed by atomic Lua scripts
+//   - In-memory fakes swapped in per connection for tests
+type RedisManager struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// container is the application container
+	container containerInterfaces.ContainerInterface
+
+	// config provides the database.redis settings
+	config configInterfaces.ConfigInterface
+
+	// driver is the client connector name
+	driver string
+
+	// connections caches resolved connections by name
+	connections map[string]interfaces.ConnectionInterface
+
+	// customCreators holds connectors registered through Extend
+	customCreators map[string]func() interfaces.ConnectorInterface
+
+	// listeners receive CommandExecuted events from every connection
+	listeners []func(event *events.CommandExecutedEvent)
+
+	// eventsEnabled toggles CommandExecuted events on all connections
+	eventsEnabled bool
+}
+
+// NewRedisManager creates a new Redis manager.
+//
+// The container must provide a "config" binding implementing ConfigInterface.
+func NewRedisManager(container containerInterfaces.ContainerInterface) *RedisManager {
+	config, err := container.Make("config")
+	if err != nil {
+		panic(fmt.Sprintf("Failed to resolve config from container: %v", err))
+	}
+
+	configInterface, ok := config.(configInterfaces.ConfigInterface)
+	if !ok {
+		panic(fmt.Sprintf("Config service does not implement ConfigInterface, got %T", config))
+	}
+
+	return &RedisManager{
+		container:      container,
+		config:         configInterface,
+		driver:         configInterface.GetString("database.redis.client", "native"),
+		connections:    make(map[string]interfaces.ConnectionInterface),
+		customCreators: make(map[string]func() interfaces.ConnectorInterface),
+	}
+}
+
+// Connection returns the named connection, resolving it on first use.
+// If no name is provided, the "default" connection is used.
+func (m *RedisManager) Connection(name ...string) (interfaces.ConnectionInterface, error) {
+	connectionName := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connectionName = name[0]
+	}
+
+	m.mu.RLock()
+	connection, exists := m.connections[connectionName]
+	m.mu.RUnlock()
+	if exists {
+		return connection, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if connection, exists := m.connections[connectionName]; exists {
+		return connection, nil
+	}
+
+	connection, err := m.resolve(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	m.configure(connection, connectionName)
+	m.connections[connectionName] = connection
+	return connection, nil
+}
+
+// Resolve creates a new, uncached connection by name.
+func (m *RedisManager) Resolve(name string) (interfaces.ConnectionInterface, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	connection, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.configure(connection, name)
+	return connection, nil
+}
+
+// Command runs a raw command on the default connection.
+func (m *RedisManager) Command(ctx context.Context, method string, args ...interface{}) (interface{}, error) {
+	connection, err := m.Connection()
+	if err != nil {
+		return nil, err
+	}
+	return connection.Command(ctx, method, args...)
+}
+
+// Throttle returns a duration limiter builder on the default connection
+// (Laravel's Redis::throttle). Connection errors are returned by Then.
+//
+// Example:
+//
+//	err := manager.Throttle("key").Allow(10).Every(time.Minute).Then(ctx, callback, failure)
+func (m *RedisManager) Throttle(name string) *limiters.DurationLimiterBuilder {
+	connection, err := m.Connection()
+	return limiters.NewDurationLimiterBuilder(connection, name).WithError(err)
+}
+
+// Funnel returns a concurrency limiter builder on the default connection
+// (Laravel's Redis::funnel). Connection errors are returned by Then.
+//
+// Example:
+//
+//	err := manager.Funnel("key").Limit(3).Block(5*time.Second).Then(ctx, callback, failure)
+func (m *RedisManager) Funnel(name string) *limiters.ConcurrencyLimiterBuilder {
+	connection, err := m.Connection()
+	return limiters.NewConcurrencyLimiterBuilder(connection, name).WithError(err)
+}
+
+// Connections returns the resolved connections.
+func (m *RedisManager) Connections() map[string]interfaces.ConnectionInterface {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string]interfaces.ConnectionInterface, len(m.connections))
+	for name, connection := range m.connections {
+		result[name] = connection
+	}
+	return result
+}
+
+// Extend registers a connector for a client name.
+//
+// Example:
+//
+//	manager.Extend("goredis", func() interfaces.ConnectorInterface {
+//		return NewGoRedisConnector()
+//	})
+func (m *RedisManager) Extend(driver string, callback func() interfaces.ConnectorInterface) *RedisManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.customCreators[driver] = callback
+	return m
+}
+
+// SetDriver changes the client connector used for new connections.
+func (m *RedisManager) SetDriver(driver string) *RedisManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.driver = driver
+	return m
+}
+
+// Purge closes and forgets the named connection (default if omitted).
+func (m *RedisManager) Purge(name ...string) error {
+	connectionName := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connectionName = name[0]
+	}
+
+	m.mu.Lock()
+	connection, exists := m.connections[connectionName]
+	delete(m.connections, connectionName)
+	m.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+	return connection.Close()
+}
+
+// Fake replaces the named connection (default if omitted) with an in-memory
+// fake and returns it. Later Connection calls return the fake.
+func (m *RedisManager) Fake(name ...string) *connections.FakeConnection {
+	connectionName := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connectionName = name[0]
+	}
+
+	fake := connections.NewFakeConnection(connectionName)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if previous, exists := m.connections[connectionName]; exists {
+		previous.Close()
+	}
+
+	m.configure(fake, connectionName)
+	m.connections[connectionName] = fake
+	return fake
+}
+
+// EnableEvents enables CommandExecuted events on all connections.
+func (m *RedisManager) EnableEvents() {
+	m.setEventsEnabled(true)
+}
+
+// DisableEvents disables CommandExecuted events on all connections.
+func (m *RedisManager) DisableEvents() {
+	m.setEventsEnabled(false)
+}
+
+// Listen registers a CommandExecuted listener on all current and future
+// connections and enables events.
+func (m *RedisManager) Listen(callback func(event *events.CommandExecutedEvent)) {
+	m.mu.Lock()
+	m.listeners = append(m.listeners, callback)
+	resolved := make([]interfaces.ConnectionInterface, 0, len(m.connections))
+	for _, connection := range m.connections {
+		resolved = append(resolved, connection)
+	}
+	m.mu.Unlock()
+
+	for _, connection := range resolved {
+		connection.Listen(callback)
+	}
+	m.EnableEvents()
+}
+
+// GetDefaultConnection returns the name of the default connection.
+func (m *RedisManager) GetDefaultConnection() string {
+	return "default"
+}
+
+// setEventsEnabled toggles events on the manager and all connections.
+func (m *RedisManager) setEventsEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.eventsEnabled = enabled
+	for _, connection := range m.connections {
+		connection.SetEventsEnabled(enabled)
+	}
+}
+
+// configure names a connection and attaches the manager's listeners.
+// The caller must hold mu.
+func (m *RedisManager) configure(connection interfaces.ConnectionInterface, name string) {
+	connection.SetName(name)
+	for _, listener := range m.listeners {
+		connection.Listen(listener)
+	}
+	connection.SetEventsEnabled(m.eventsEnabled)
+}
+
+// resolve creates a connection or cluster from config. The caller must hold mu.
+func (m *RedisManager) resolve(name string) (interfaces.ConnectionInterface, error) {
+	options := m.mapValue("database.redis.options")
+
+	if config := m.mapValue("database.redis." + name); config != nil && name != "clusters" && name != "options" {
+		connector, err := m.connector()
+		if err != nil {
+			return nil, err
+		}
+		return connector.Connect(config, options)
+	}
+
+	if nodes, clusterOptions, ok := m.clusterConfig(name); ok {
+		connector, err := m.connector()
+		if err != nil {
+			return nil, err
+		}
+		return connector.ConnectToCluster(nodes, clusterOptions, options)
+	}
+
+	return nil, fmt.Errorf("redis connection [%s] not configured", name)
+}
+
+// connector returns the connector for the configured client.
+// "phpredis" and "predis" are accepted as aliases of the native client.
+func (m *RedisManager) connector() (interfaces.ConnectorInterface, error) {
+	if creator, exists := m.customCreators[m.driver]; exists {
+		return creator(), nil
+	}
+
+	switch strings.ToLower(m.driver) {
+	case "", "native", "phpredis", "predis":
+		return connectors.NewNativeConnector(), nil
+	default:
+		return nil, fmt.Errorf("redis client [%s] is not supported", m.driver)
+	}
+}
+
+// clusterConfig returns the nodes and options of a cluster definition.
+//
+// A cluster is either a list of nodes or a map with "nodes" and "options".
+func (m *RedisManager) clusterConfig(name string) ([]map[string]interface{}, map[string]interface{}, bool) {
+	value, exists := m.config.Get("database.redis.clusters." + name)
+	if !exists {
+		return nil, nil, false
+	}
+
+	var clusterOptions map[string]interface{}
+	if definition, ok := value.(map[string]interface{}); ok {
+		clusterOptions, _ = definition["options"].(map[string]interface{})
+		value = definition["nodes"]
+	}
+
+	nodes := toNodeList(value)
+	if len(nodes) == 0 {
+		return nil, nil, false
+	}
+	return nodes, clusterOptions, true
+}
+
+// mapValue returns a config value as a map, or nil.
+func (m *RedisManager) mapValue(key string) map[string]interface{} {
+	value, exists := m.config.Get(key)
+	if !exists {
+		return nil
+	}
+
+	config, _ := value.(map[string]interface{})
+	return config
+}
+
+// toNodeList converts a list of node settings to maps.
+func toNodeList(value interface{}) []map[string]interface{} {
+	switch list := value.(type) {
+	case []map[string]interface{}:
+		return list
+	case []interface{}:
+		nodes := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if node, ok := item.(map[string]interface{}); ok {
+				nodes = append(nodes, node)
+			}
+		}
+		return nodes
+	default:
+		return nil
+	}
+}
+
+// Compile-time interface compliance checks
+var _ interfaces.FactoryInterface = (*RedisManager)(nil)
+var _ redisInterfaces.RedisInterface = (*RedisManager)(nil)
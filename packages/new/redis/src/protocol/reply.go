@@ -0,0 +1,132 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// String converts a reply to a string. Nil replies return ErrNil.
+func String(reply interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return "", ErrNil
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case RedisError:
+		return "", v
+	default:
+		return "", fmt.Errorf("redis: unexpected reply type %T for string", reply)
+	}
+}
+
+// Int64 converts a reply to an int64. Nil replies return ErrNil.
+func Int64(reply interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return 0, ErrNil
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case RedisError:
+		return 0, v
+	default:
+		return 0, fmt.Errorf("redis: unexpected reply type %T for integer", reply)
+	}
+}
+
+// Float64 converts a reply to a float64. Nil replies return ErrNil.
+func Float64(reply interface{}, err error) (float64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return 0, ErrNil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case RedisError:
+		return 0, v
+	default:
+		return 0, fmt.Errorf("redis: unexpected reply type %T for float", reply)
+	}
+}
+
+// Bool converts an integer or "OK" reply to a bool. Nil replies are false.
+func Bool(reply interface{}, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return false, nil
+	case int64:
+		return v != 0, nil
+	case string:
+		return v == "OK" || v == "1", nil
+	case RedisError:
+		return false, v
+	default:
+		return false, fmt.Errorf("redis: unexpected reply type %T for bool", reply)
+	}
+}
+
+// Strings converts an array reply to a string slice. Nil elements become "".
+func Strings(reply interface{}, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return nil, ErrNil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, item := range v {
+			if item == nil {
+				continue
+			}
+			s, err := String(item, nil)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = s
+		}
+		return result, nil
+	case RedisError:
+		return nil, v
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %T for array", reply)
+	}
+}
+
+// StringMap converts a flat key/value array reply (e.g. HGETALL) to a map.
+func StringMap(reply interface{}, err error) (map[string]string, error) {
+	values, err := Strings(reply, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, fmt.Errorf("redis: expected an even number of values, got %d", len(values))
+	}
+
+	result := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		result[values[i]] = values[i+1]
+	}
+	return result, nil
+}
@@ -0,0 +1,173 @@
+// Package protocol implements the subset of the Redis serialization protocol
+// (RESP2) needed by the govel Redis client: encoding commands as arrays of
+// bulk strings and decoding every reply type.
+package protocol
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNil is returned by typed helpers when Redis replies with a nil bulk
+// string or nil array (e.g. GET on a missing key).
+var ErrNil = errors.New("redis: nil")
+
+// RedisError is an error reply sent by the server (e.g. "WRONGTYPE ...").
+//
+// Error replies are regular replies: inside pipelines and transactions they
+// are returned in place of the failed command's result.
+type RedisError string
+
+// Error returns the error message as sent by the server.
+func (e RedisError) Error() string {
+	return string(e)
+}
+
+// Prefix returns the error code, i.e. the first word of the message
+// ("MOVED", "ASK", "NOSCRIPT", "WRONGTYPE", ...).
+func (e RedisError) Prefix() string {
+	message := string(e)
+	if index := strings.IndexByte(message, ' '); index >= 0 {
+		return message[:index]
+	}
+	return message
+}
+
+// WriteCommand encodes a command as a RESP array of bulk strings.
+// The writer is not flushed so that pipelines can batch several commands.
+//
+// Supported argument types are strings, byte slices, integers, floats,
+// booleans (1/0), time.Duration (milliseconds), nil (empty string) and
+// fmt.Stringer values.
+func WriteCommand(w *bufio.Writer, args []interface{}) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		value, err := ArgToString(arg)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArgToString converts a command argument to its wire representation.
+func ArgToString(arg interface{}) (string, error) {
+	switch v := arg.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Duration:
+		return strconv.FormatInt(v.Milliseconds(), 10), nil
+	case nil:
+		return "", nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("redis: unsupported argument type %T", arg)
+	}
+}
+
+// ReadReply reads a single reply from the connection.
+//
+// Replies are decoded as:
+//   - simple and bulk strings: string
+//   - integers: int64
+//   - arrays: []interface{} (elements may be RedisError values)
+//   - nil bulk strings and nil arrays: nil
+//
+// A top-level error reply is returned as a RedisError error.
+func ReadReply(r *bufio.Reader) (interface{}, error) {
+	reply, err := ReadValue(r)
+	if err != nil {
+		return nil, err
+	}
+	if redisErr, ok := reply.(RedisError); ok {
+		return nil, redisErr
+	}
+	return reply, nil
+}
+
+// ReadValue reads one RESP value, returning error replies as RedisError values.
+// Used for pipelines, where a failed command must not hide the other replies.
+func ReadValue(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply line")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return RedisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = ReadValue(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+	}
+}
+
+// readLine reads a CRLF terminated line without the terminator.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
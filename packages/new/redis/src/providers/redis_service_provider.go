@@ -0,0 +1,91 @@
+// Package providers contains service provider implementations for the redis package.
+// Service providers are responsible for registering Redis services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	redis "govel/new/redis"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	redisInterfaces "govel/types/interfaces/redis"
+)
+
+// RedisServiceProvider implements a Laravel-compatible service provider
+// for the redis package.
+//
+// Services registered:
+//   - REDIS_TOKEN / REDIS_MANAGER_TOKEN / REDIS_FACTORY_TOKEN: Singleton RedisManager
+//   - REDIS_CONNECTION_TOKEN: The default connection, resolved lazily
+type RedisServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewRedisServiceProvider creates a new RedisServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewRedisServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register redis services:", err)
+//	}
+func NewRedisServiceProvider() *RedisServiceProvider {
+	return &RedisServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all Redis services in the dependency injection container.
+func (p *RedisServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager     *redis.RedisManager
+		managerOnce sync.Once
+	)
+	managerFactory := func() interface{} {
+		managerOnce.Do(func() {
+			manager = redis.NewRedisManager(application)
+		})
+		return manager
+	}
+
+	for _, token := range []interface{}{
+		redisInterfaces.REDIS_TOKEN,
+		redisInterfaces.REDIS_MANAGER_TOKEN,
+		redisInterfaces.REDIS_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, managerFactory); err != nil {
+			return fmt.Errorf("failed to bind redis manager: %w", err)
+		}
+	}
+
+	err := application.Bind(redisInterfaces.REDIS_CONNECTION_TOKEN, func() (interface{}, error) {
+		connection, err := managerFactory().(*redis.RedisManager).Connection()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve default redis connection: %w", err)
+		}
+		return connection, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind redis connection: %w", err)
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *RedisServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		redisInterfaces.REDIS_TOKEN,
+		redisInterfaces.REDIS_MANAGER_TOKEN,
+		redisInterfaces.REDIS_FACTORY_TOKEN,
+		redisInterfaces.REDIS_CONNECTION_TOKEN,
+	}
+}
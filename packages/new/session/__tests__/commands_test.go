@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	configMocks "govel/config/mocks"
+	containerMocks "govel/container/mocks"
+	session "govel/new/session"
+	"govel/new/session/console/commands"
+)
+
+// fileManager creates a session manager using the file driver in dir
+func fileManager(t *testing.T, dir string) *session.SessionManager {
+	t.Helper()
+	config := configMocks.NewMockConfigWithData(map[string]interface{}{
+		"session.driver":   "file",
+		"session.files":    dir,
+		"session.lifetime": 60,
+	})
+	container := containerMocks.NewMockContainer()
+	if err := container.Singleton("config", func() interface{} { return config }); err != nil {
+		t.Fatalf("Failed to bind config: %v", err)
+	}
+	return session.NewSessionManager(container)
+}
+
+func TestSessionTableCommand_WritesMigrations(t *testing.T) {
+	dir := t.TempDir()
+	var output bytes.Buffer
+
+	command := commands.NewSessionTableCommand("", dir).SetOutput(&output)
+	if err := command.Execute(context.Background(), []string{"--table=web_sessions", "--dialect=sqlite"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	ups, _ := filepath.Glob(filepath.Join(dir, "*_create_web_sessions_table.up.sql"))
+	downs, _ := filepath.Glob(filepath.Join(dir, "*_create_web_sessions_table.down.sql"))
+	if len(ups) != 1 || len(downs) != 1 {
+		t.Fatalf("Expected one up and one down migration, got %v and %v", ups, downs)
+	}
+
+	up, _ := os.ReadFile(ups[0])
+	if !strings.Contains(string(up), "CREATE TABLE web_sessions") || !strings.Contains(string(up), "last_activity INTEGER NOT NULL") {
+		t.Errorf("Unexpected up migration:\n%s", up)
+	}
+	down, _ := os.ReadFile(downs[0])
+	if string(down) != "DROP TABLE IF EXISTS web_sessions;\n" {
+		t.Errorf("Unexpected down migration: %q", down)
+	}
+	if output.String() != "Created migration: "+ups[0]+"\n" {
+		t.Errorf("Unexpected output: %q", output.String())
+	}
+
+	if err := command.Execute(context.Background(), []string{"--dialect=oracle"}); err == nil {
+		t.Error("Expected unsupported dialects to fail")
+	}
+}
+
+func TestSessionGCCommand_RemovesExpiredSessions(t *testing.T) {
+	dir := t.TempDir()
+	manager := fileManager(t, dir)
+
+	handler, err := manager.Handler()
+	if err != nil {
+		t.Fatalf("Handler failed: %v", err)
+	}
+	for _, id := range []string{"fresh", "stale"} {
+		if err := handler.Write(id, "{}"); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "stale"), past, past); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+
+	var output bytes.Buffer
+	command := commands.NewSessionGCCommand(manager).SetOutput(&output)
+	if err := command.Execute(context.Background(), []string{"--driver=file"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if output.String() != "Removed 1 expired session(s)\n" {
+		t.Errorf("Unexpected output: %q", output.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stale")); !os.IsNotExist(err) {
+		t.Error("Expected the stale session file to be removed")
+	}
+
+	if err := command.Execute(context.Background(), []string{"--driver=unknown"}); err == nil {
+		t.Error("Expected unknown drivers to fail")
+	}
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"govel/new/session/console/commands"
+	"govel/new/session/handlers"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// sessionsDB opens a SQLite database with the session:table schema
+func sessionsDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "sessions.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	schema, err := commands.SessionTableSQL("sessions", "sqlite")
+	if err != nil {
+		t.Fatalf("SessionTableSQL failed: %v", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Creating the sessions table failed: %v", err)
+	}
+	return db
+}
+
+// setLastActivity moves the last activity of a session row
+func setLastActivity(t *testing.T, db *sql.DB, id string, at time.Time) {
+	t.Helper()
+	if _, err := db.Exec("UPDATE sessions SET last_activity = ? WHERE id = ?", at.Unix(), id); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+}
+
+func TestDatabaseHandler_ReadWriteAndDestroy(t *testing.T) {
+	db := sessionsDB(t)
+	handler := handlers.NewDatabaseHandler(db, "sessions", time.Hour, "sqlite")
+
+	if data, err := handler.Read("missing"); err != nil || data != "" {
+		t.Fatalf("Expected an empty payload for unknown sessions, got %q (%v)", data, err)
+	}
+
+	if err := handler.Write("abc", `{"cart":1}`); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := handler.Write("abc", `{"cart":2}`); err != nil {
+		t.Fatalf("Second write failed: %v", err)
+	}
+
+	var rows int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&rows); err != nil || rows != 1 {
+		t.Fatalf("Expected the second write to update the row, got %d rows (%v)", rows, err)
+	}
+	if data, _ := handler.Read("abc"); data != `{"cart":2}` {
+		t.Errorf("Expected the latest payload, got %q", data)
+	}
+
+	setLastActivity(t, db, "abc", time.Now().Add(-2*time.Hour))
+	if data, _ := handler.Read("abc"); data != "" {
+		t.Errorf("Expected expired sessions to read empty, got %q", data)
+	}
+
+	if err := handler.Destroy("abc"); err != nil {
+		t.Fatalf("Destroy failed: %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&rows); err != nil || rows != 0 {
+		t.Errorf("Expected the row to be deleted, got %d rows (%v)", rows, err)
+	}
+}
+
+func TestDatabaseHandler_RecordsRequestMetadata(t *testing.T) {
+	db := sessionsDB(t)
+	handler := handlers.NewDatabaseHandler(db, "sessions", time.Hour, "sqlite").
+		SetUserResolver(func(req webserverInterfaces.RequestInterface) interface{} {
+			return 42
+		})
+
+	if err := handler.ForRequest(newTestRequest(nil)).Write("abc", "{}"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var userID int
+	var ip, userAgent string
+	err := db.QueryRow("SELECT user_id, ip_address, user_agent FROM sessions WHERE id = ?", "abc").Scan(&userID, &ip, &userAgent)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if userID != 42 || ip != "127.0.0.1" || userAgent != "mock" {
+		t.Errorf("Unexpected metadata: user %d, ip %q, user agent %q", userID, ip, userAgent)
+	}
+}
+
+func TestDatabaseHandler_GCRemovesExpiredSessions(t *testing.T) {
+	db := sessionsDB(t)
+	handler := handlers.NewDatabaseHandler(db, "sessions", time.Hour, "sqlite")
+
+	for _, id := range []string{"fresh", "stale", "older"} {
+		if err := handler.Write(id, "{}"); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	setLastActivity(t, db, "stale", time.Now().Add(-2*time.Hour))
+	setLastActivity(t, db, "older", time.Now().Add(-48*time.Hour))
+
+	removed, err := handler.GC(time.Hour)
+	if err != nil {
+		t.Fatalf("GC failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 removed sessions, got %d", removed)
+	}
+	if data, _ := handler.Read("fresh"); data != "{}" {
+		t.Errorf("Expected the fresh session to be kept, got %q", data)
+	}
+}
@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	session "govel/new/session"
+	"govel/new/session/handlers"
+	"govel/new/session/interfaces"
+	"govel/new/session/middlewares"
+	"govel/new/session/stores"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	"govel/new/webserver/mocks"
+)
+
+// testRequest is a request carrying a context and content negotiation
+// flags; it shadows the mock methods whose signatures drifted
+type testRequest struct {
+	mocks.RequestMock
+	values    map[string]interface{}
+	wantsJSON bool
+}
+
+func newTestRequest(store interfaces.SessionInterface) *testRequest {
+	req := &testRequest{values: make(map[string]interface{})}
+	if store != nil {
+		req.values[session.RequestContextKey] = store
+	}
+	return req
+}
+
+func (r *testRequest) GetContext(key string) interface{}        { return r.values[key] }
+func (r *testRequest) SetContext(key string, value interface{}) { r.values[key] = value }
+func (r *testRequest) WantsJson() bool                          { return r.wantsJSON }
+func (r *testRequest) BodyReader() io.Reader                    { return nil }
+func (r *testRequest) File(key string) (*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *testRequest) Files(key string) ([]*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *testRequest) AllFiles() (map[string][]*multipart.FileHeader, error) {
+	return nil, nil
+}
+func (r *testRequest) IfModifiedSince() time.Time { return time.Time{} }
+
+// handlerFunc adapts a function to a webserver handler
+type handlerFunc func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface
+
+func (f handlerFunc) Handle(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+	return f(req)
+}
+
+// fakeUser is an authenticated user with a password hash
+type fakeUser struct {
+	id       int
+	password string
+}
+
+func (u *fakeUser) GetAuthIdentifier() interface{} { return u.id }
+func (u *fakeUser) GetAuthPassword() string        { return u.password }
+
+// fakeGuard authenticates every request as its user until logged out
+type fakeGuard struct {
+	user      *fakeUser
+	loggedOut bool
+}
+
+func (g *fakeGuard) GetName() string { return "web" }
+
+func (g *fakeGuard) User(req webserverInterfaces.RequestInterface) interfaces.AuthenticatableInterface {
+	if g.user == nil || g.loggedOut {
+		return nil
+	}
+	return g.user
+}
+
+func (g *fakeGuard) LogoutCurrentDevice(req webserverInterfaces.RequestInterface) error {
+	g.loggedOut = true
+	return nil
+}
+
+// response returns the concrete response produced by a middleware
+func response(t *testing.T, resp webserverInterfaces.ResponseInterface) *webserver.Response {
+	t.Helper()
+	concrete, ok := resp.(*webserver.Response)
+	if !ok {
+		t.Fatalf("Expected a *webserver.Response, got %T", resp)
+	}
+	return concrete
+}
+
+// startedStore returns a started store backed by an array handler
+func startedStore(t *testing.T) *stores.Store {
+	t.Helper()
+	store := stores.NewStore("govel-session", handlers.NewArrayHandler(time.Hour))
+	if err := store.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return store
+}
+
+// okHandler counts its calls and returns 200
+func okHandler(calls *int) webserverInterfaces.HandlerInterface {
+	return handlerFunc(func(req webserverInterfaces.RequestInterface) webserverInterfaces.ResponseInterface {
+		*calls++
+		return webserver.NewResponse().Text("ok")
+	})
+}
+
+func TestAuthenticateSession_StoresPasswordDigest(t *testing.T) {
+	guard := &fakeGuard{user: &fakeUser{id: 1, password: "$2y$10$first"}}
+	middleware := middlewares.NewAuthenticateSessionMiddleware(guard, []byte("app-key"), "")
+	store := startedStore(t)
+
+	calls := 0
+	resp := response(t, middleware.Handle(newTestRequest(store), okHandler(&calls)))
+
+	if calls != 1 || resp.StatusCode() != http.StatusOK {
+		t.Fatalf("Expected the request to pass, got %d calls and status %d", calls, resp.StatusCode())
+	}
+	digest := store.GetString("password_hash_web")
+	if digest == "" || strings.Contains(digest, "first") {
+		t.Errorf("Expected a keyed digest of the password hash, got %q", digest)
+	}
+
+	// The same password keeps the session authenticated
+	middleware.Handle(newTestRequest(store), okHandler(&calls))
+	if calls != 2 || guard.loggedOut {
+		t.Error("Expected an unchanged password to keep the session")
+	}
+}
+
+func TestAuthenticateSession_LogsOutWhenPasswordChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantsJSON bool
+		status    int
+	}{
+		{name: "browser", status: http.StatusFound},
+		{name: "json", wantsJSON: true, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &fakeGuard{user: &fakeUser{id: 1, password: "$2y$10$first"}}
+			middleware := middlewares.NewAuthenticateSessionMiddleware(guard, []byte("app-key"), "/signin")
+			store := startedStore(t)
+
+			calls := 0
+			middleware.Handle(newTestRequest(store), okHandler(&calls))
+			store.Put("cart", 3)
+			oldID := store.GetID()
+
+			// The password is reset on another device
+			guard.user.password = "$2y$10$second"
+			req := newTestRequest(store)
+			req.wantsJSON = tt.wantsJSON
+			resp := response(t, middleware.Handle(req, okHandler(&calls)))
+
+			if calls != 1 {
+				t.Error("Expected the request to be stopped")
+			}
+			if resp.StatusCode() != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, resp.StatusCode())
+			}
+			if !tt.wantsJSON && resp.HeadersMap()["Location"] != "/signin" {
+				t.Errorf("Expected a redirect to /signin, got %v", resp.HeadersMap())
+			}
+			if !guard.loggedOut {
+				t.Error("Expected the guard to log the user out")
+			}
+			if store.Has("cart") || store.GetID() == oldID {
+				t.Error("Expected the session to be invalidated")
+			}
+		})
+	}
+}
+
+func TestAuthenticateSession_PassesGuestsAndRequestsWithoutSession(t *testing.T) {
+	calls := 0
+
+	guest := middlewares.NewAuthenticateSessionMiddleware(&fakeGuard{}, []byte("app-key"), "")
+	store := startedStore(t)
+	guest.Handle(newTestRequest(store), okHandler(&calls))
+	if store.Has("password_hash_web") {
+		t.Error("Expected nothing stored for guests")
+	}
+
+	authenticated := middlewares.NewAuthenticateSessionMiddleware(&fakeGuard{user: &fakeUser{id: 1}}, []byte("app-key"), "")
+	authenticated.Handle(newTestRequest(nil), okHandler(&calls))
+
+	if calls != 2 {
+		t.Errorf("Expected both requests to pass, got %d", calls)
+	}
+}
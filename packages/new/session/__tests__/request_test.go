@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	session "govel/new/session"
+	"govel/new/session/interfaces"
+	"govel/new/webserver/mocks"
+)
+
+// testRequest is a request carrying a context and content negotiation
+// flags; it shadows the mock methods whose signatures drifted
+type testRequest struct {
+	mocks.RequestMock
+	values    map[string]interface{}
+	wantsJSON bool
+}
+
+func newTestRequest(store interfaces.SessionInterface) *testRequest {
+	req := &testRequest{values: make(map[string]interface{})}
+	if store != nil {
+		req.values[session.RequestContextKey] = store
+	}
+	return req
+}
+
+func (r *testRequest) GetContext(key string) interface{}        { return r.values[key] }
+func (r *testRequest) SetContext(key string, value interface{}) { r.values[key] = value }
+func (r *testRequest) WantsJson() bool                          { return r.wantsJSON }
+func (r *testRequest) BodyReader() io.Reader                    { return nil }
+func (r *testRequest) File(key string) (*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *testRequest) Files(key string) ([]*multipart.FileHeader, error) {
+	return nil, http.ErrMissingFile
+}
+func (r *testRequest) AllFiles() (map[string][]*multipart.FileHeader, error) {
+	return nil, nil
+}
+func (r *testRequest) IfModifiedSince() time.Time { return time.Time{} }
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"govel/new/session/handlers"
+	"govel/new/session/stores"
+)
+
+func TestStore_ParallelWritesAreMerged(t *testing.T) {
+	handler := handlers.NewArrayHandler(time.Hour)
+
+	seed := stores.NewStore("govel-session", handler)
+	if err := seed.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	seed.Put("cart", 1)
+	if err := seed.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	id := seed.GetID()
+
+	// Two requests load the same session before either saves
+	first := stores.NewStore("govel-session", handler, id)
+	second := stores.NewStore("govel-session", handler, id)
+	if err := first.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := second.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	first.Flash("status", "saved")
+	second.Put("theme", "dark")
+
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := second.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded := stores.NewStore("govel-session", handler, id)
+	if err := reloaded.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if got := reloaded.GetString("status"); got != "saved" {
+		t.Errorf("Expected flash data from the first request to survive, got %q", got)
+	}
+	if got := reloaded.GetString("theme"); got != "dark" {
+		t.Errorf("Expected attribute from the second request, got %q", got)
+	}
+	if got := reloaded.GetInt("cart"); got != 1 {
+		t.Errorf("Expected untouched attribute to be kept, got %d", got)
+	}
+}
+
+func TestStore_RegenerateRotatesIDAndToken(t *testing.T) {
+	handler := handlers.NewArrayHandler(time.Hour)
+
+	store := stores.NewStore("govel-session", handler)
+	if err := store.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	store.Put("user_id", 42)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	oldID, oldToken := store.GetID(), store.Token()
+
+	if err := store.Regenerate(true); err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+
+	if store.GetID() == oldID {
+		t.Error("Expected a new session ID")
+	}
+	if store.Token() == oldToken {
+		t.Error("Expected a new CSRF token")
+	}
+	if store.GetInt("user_id") != 42 {
+		t.Error("Expected attributes to survive regeneration")
+	}
+	if data, _ := handler.Read(oldID); data != "" {
+		t.Error("Expected the old session to be destroyed")
+	}
+}
+
+func TestStore_SetIDRejectsInvalidIDs(t *testing.T) {
+	store := stores.NewStore("govel-session", handlers.NewNullHandler())
+	generated := store.GetID()
+
+	store.SetID("../../etc/passwd")
+
+	if store.GetID() == "../../etc/passwd" || !store.IsValidID(store.GetID()) {
+		t.Errorf("Expected invalid ID to be replaced, got %q", store.GetID())
+	}
+	if store.GetID() == "" || generated == "" {
+		t.Error("Expected a generated ID")
+	}
+}
+
+func TestFileHandler_GCRemovesExpiredSessions(t *testing.T) {
+	dir := t.TempDir()
+	handler := handlers.NewFileHandler(dir, time.Hour)
+
+	if err := handler.Write("fresh", "{}"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := handler.Write("stale", "{}"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "stale"), past, past); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+
+	removed, err := handler.GC(time.Hour)
+	if err != nil {
+		t.Fatalf("GC failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 removed session, got %d", removed)
+	}
+	if data, _ := handler.Read("fresh"); data != "{}" {
+		t.Errorf("Expected fresh session to be kept, got %q", data)
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	session "govel/new/session"
+)
+
+// SessionGCCommand removes expired sessions from a driver's storage
+// (session:gc). Useful as a scheduled task when the request lottery is
+// disabled with session.lottery = [0, 100].
+//
+// Usage:
+//
+//	session:gc [--driver=file]
+type SessionGCCommand struct {
+	// manager resolves the handlers to collect
+	manager *session.SessionManager
+
+	// output receives the command's report, os.Stdout by default
+	output io.Writer
+}
+
+// NewSessionGCCommand creates a new session:gc command.
+func NewSessionGCCommand(manager *session.SessionManager) *SessionGCCommand {
+	return &SessionGCCommand{manager: manager, output: os.Stdout}
+}
+
+// SetOutput sets the writer receiving the command's report.
+func (cmd *SessionGCCommand) SetOutput(output io.Writer) *SessionGCCommand {
+	if output == nil {
+		output = io.Discard
+	}
+	cmd.output = output
+	return cmd
+}
+
+// Execute runs garbage collection and reports the number of removed sessions.
+func (cmd *SessionGCCommand) Execute(ctx context.Context, args []string) error {
+	var drivers []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--driver=") {
+			drivers = append(drivers, strings.TrimPrefix(arg, "--driver="))
+		}
+	}
+
+	removed, err := cmd.manager.CollectGarbage(drivers...)
+	if err != nil {
+		return fmt.Errorf("failed to collect expired sessions: %w", err)
+	}
+
+	fmt.Fprintf(cmd.output, "Removed %d expired session(s)\n", removed)
+	return nil
+}
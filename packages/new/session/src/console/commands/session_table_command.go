@@ -0,0 +1,130 @@
+// Package commands contains the console commands shipped with the session package.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SessionTableCommand creates a migration for the session database table
+// (session:table).
+//
+// Usage:
+//
+//	session:table [--table=sessions] [--dialect=mysql|postgres|sqlite] [--path=database/migrations]
+//
+// The generated SQL matches the schema expected by handlers.DatabaseHandler.
+type SessionTableCommand struct {
+	// table is the default table name, usually session.table
+	table string
+
+	// path is the default directory migrations are written to
+	path string
+
+	// output receives the command's report, os.Stdout by default
+	output io.Writer
+}
+
+// NewSessionTableCommand creates a new session:table command.
+//
+// Parameters:
+//   - table: Default sessions table name (e.g. the session.table config value)
+//   - path: Default migrations directory
+func NewSessionTableCommand(table, path string) *SessionTableCommand {
+	if table == "" {
+		table = "sessions"
+	}
+	if path == "" {
+		path = "database/migrations"
+	}
+	return &SessionTableCommand{table: table, path: path, output: os.Stdout}
+}
+
+// SetOutput sets the writer receiving the command's report.
+func (cmd *SessionTableCommand) SetOutput(output io.Writer) *SessionTableCommand {
+	if output == nil {
+		output = io.Discard
+	}
+	cmd.output = output
+	return cmd
+}
+
+// Execute writes the up and down migration files for the sessions table.
+func (cmd *SessionTableCommand) Execute(ctx context.Context, args []string) error {
+	table := cmd.table
+	path := cmd.path
+	dialect := "mysql"
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--table="):
+			table = strings.TrimPrefix(arg, "--table=")
+		case strings.HasPrefix(arg, "--path="):
+			path = strings.TrimPrefix(arg, "--path=")
+		case strings.HasPrefix(arg, "--dialect="):
+			dialect = strings.ToLower(strings.TrimPrefix(arg, "--dialect="))
+		}
+	}
+
+	up, err := SessionTableSQL(table, dialect)
+	if err != nil {
+		return err
+	}
+	down := fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", table)
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s_create_%s_table", time.Now().Format("20060102150405"), table)
+	upFile := filepath.Join(path, name+".up.sql")
+	downFile := filepath.Join(path, name+".down.sql")
+
+	if err := os.WriteFile(upFile, []byte(up), 0644); err != nil {
+		return fmt.Errorf("failed to write migration: %w", err)
+	}
+	if err := os.WriteFile(downFile, []byte(down), 0644); err != nil {
+		return fmt.Errorf("failed to write migration: %w", err)
+	}
+
+	fmt.Fprintf(cmd.output, "Created migration: %s\n", upFile)
+	return nil
+}
+
+// SessionTableSQL returns the CREATE TABLE statement for the sessions table.
+//
+// Parameters:
+//   - table: Table name
+//   - dialect: "mysql", "postgres"/"pgsql" or "sqlite"
+func SessionTableSQL(table, dialect string) (string, error) {
+	var userID, lastActivity string
+	switch dialect {
+	case "mysql", "mariadb":
+		userID, lastActivity = "BIGINT UNSIGNED NULL", "INT NOT NULL"
+	case "postgres", "pgsql":
+		userID, lastActivity = "BIGINT NULL", "INTEGER NOT NULL"
+	case "sqlite":
+		userID, lastActivity = "INTEGER NULL", "INTEGER NOT NULL"
+	default:
+		return "", fmt.Errorf("unsupported dialect [%s]", dialect)
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "CREATE TABLE %s (\n", table)
+	sql.WriteString("    id VARCHAR(255) NOT NULL PRIMARY KEY,\n")
+	fmt.Fprintf(&sql, "    user_id %s,\n", userID)
+	sql.WriteString("    ip_address VARCHAR(45) NULL,\n")
+	sql.WriteString("    user_agent TEXT NULL,\n")
+	sql.WriteString("    payload TEXT NOT NULL,\n")
+	fmt.Fprintf(&sql, "    last_activity %s\n", lastActivity)
+	sql.WriteString(");\n")
+	fmt.Fprintf(&sql, "CREATE INDEX %s_user_id_index ON %s (user_id);\n", table, table)
+	fmt.Fprintf(&sql, "CREATE INDEX %s_last_activity_index ON %s (last_activity);\n", table, table)
+
+	return sql.String(), nil
+}
@@ -0,0 +1,31 @@
+package exceptions
+
+import (
+	"govel/exceptions/core"
+	"govel/exceptions/interfaces"
+)
+
+// TokenMismatchException is raised when the CSRF token submitted with a request
+// does not match the token stored in the session. It renders as HTTP 419
+// ("Page Expired"), matching Laravel.
+type TokenMismatchException struct {
+	*core.Exception
+}
+
+// NewTokenMismatchException creates a new CSRF token mismatch exception.
+//
+// Parameters:
+//   - message: Optional custom error message
+func NewTokenMismatchException(message ...string) *TokenMismatchException {
+	msg := "CSRF token mismatch."
+	if len(message) > 0 && message[0] != "" {
+		msg = message[0]
+	}
+
+	return &TokenMismatchException{
+		Exception: core.NewException(msg, 419),
+	}
+}
+
+// Ensure TokenMismatchException implements the ExceptionInterface
+var _ interfaces.ExceptionInterface = (*TokenMismatchException)(nil)
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"govel/new/session/interfaces"
+)
+
+// arraySession is a single session held by the ArrayHandler.
+type arraySession struct {
+	data string
+	time time.Time
+}
+
+// ArrayHandler keeps sessions in process memory.
+// Sessions are lost when the process exits; intended for tests and
+// single-instance development servers.
+type ArrayHandler struct {
+	// mu guards storage
+	mu sync.RWMutex
+
+	// storage holds the sessions keyed by session ID
+	storage map[string]arraySession
+
+	// lifetime is the idle time after which a session expires
+	lifetime time.Duration
+
+	// now returns the current time; replaceable for tests
+	now func() time.Time
+}
+
+// NewArrayHandler creates a new in-memory session handler.
+//
+// Parameters:
+//   - lifetime: Idle time after which a session expires
+func NewArrayHandler(lifetime time.Duration) *ArrayHandler {
+	return &ArrayHandler{
+		storage:  make(map[string]arraySession),
+		lifetime: lifetime,
+		now:      time.Now,
+	}
+}
+
+// SetClock replaces the clock used for expiry calculations.
+func (h *ArrayHandler) SetClock(now func() time.Time) *ArrayHandler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.now = now
+	return h
+}
+
+// Open is a no-op.
+func (h *ArrayHandler) Open(savePath, sessionName string) error {
+	return nil
+}
+
+// Close is a no-op.
+func (h *ArrayHandler) Close() error {
+	return nil
+}
+
+// Read returns the payload if the session exists and has not expired.
+func (h *ArrayHandler) Read(sessionID string) (string, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	session, exists := h.storage[sessionID]
+	if !exists || !h.validExpiration(session.time) {
+		return "", nil
+	}
+	return session.data, nil
+}
+
+// Write stores the payload and refreshes the session's activity time.
+func (h *ArrayHandler) Write(sessionID, data string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.storage[sessionID] = arraySession{data: data, time: h.now()}
+	return nil
+}
+
+// Destroy removes the session.
+func (h *ArrayHandler) Destroy(sessionID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.storage, sessionID)
+	return nil
+}
+
+// GC removes sessions idle for longer than maxLifetime.
+func (h *ArrayHandler) GC(maxLifetime time.Duration) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := h.now().Add(-maxLifetime)
+	deleted := 0
+	for id, session := range h.storage {
+		if session.time.Before(cutoff) {
+			delete(h.storage, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Count returns the number of stored sessions, expired or not.
+func (h *ArrayHandler) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.storage)
+}
+
+// validExpiration reports whether a session last active at t is still valid.
+func (h *ArrayHandler) validExpiration(t time.Time) bool {
+	return !t.Before(h.now().Add(-h.lifetime))
+}
+
+// Compile-time interface compliance check
+var _ interfaces.HandlerInterface = (*ArrayHandler)(nil)
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"time"
+
+	"govel/new/session/interfaces"
+)
+
+// CacheBasedHandler stores sessions in a cache store (redis, memcached...).
+// Expiry is delegated to the cache TTL, so GC has nothing to do.
+type CacheBasedHandler struct {
+	// cache is the backing cache store
+	cache interfaces.CacheStoreInterface
+
+	// lifetime is used as the TTL of every write
+	lifetime time.Duration
+
+	// prefix is prepended to session IDs to form cache keys
+	prefix string
+}
+
+// NewCacheBasedHandler creates a new cache-based session handler.
+//
+// Parameters:
+//   - cache: Cache store holding the sessions
+//   - lifetime: Session lifetime, used as the cache TTL
+//   - prefix: Prefix prepended to session IDs to form cache keys
+func NewCacheBasedHandler(cache interfaces.CacheStoreInterface, lifetime time.Duration, prefix string) *CacheBasedHandler {
+	return &CacheBasedHandler{
+		cache:    cache,
+		lifetime: lifetime,
+		prefix:   prefix,
+	}
+}
+
+// GetCache returns the underlying cache store.
+func (h *CacheBasedHandler) GetCache() interfaces.CacheStoreInterface {
+	return h.cache
+}
+
+// Open is a no-op.
+func (h *CacheBasedHandler) Open(savePath, sessionName string) error {
+	return nil
+}
+
+// Close is a no-op.
+func (h *CacheBasedHandler) Close() error {
+	return nil
+}
+
+// Read returns the cached payload.
+func (h *CacheBasedHandler) Read(sessionID string) (string, error) {
+	value, found := h.cache.Get(h.prefix + sessionID)
+	if !found {
+		return "", nil
+	}
+
+	switch data := value.(type) {
+	case string:
+		return data, nil
+	case []byte:
+		return string(data), nil
+	}
+	return "", nil
+}
+
+// Write caches the payload for the session lifetime.
+func (h *CacheBasedHandler) Write(sessionID, data string) error {
+	return h.cache.Put(h.prefix+sessionID, data, h.lifetime)
+}
+
+// Destroy removes the cached payload.
+func (h *CacheBasedHandler) Destroy(sessionID string) error {
+	return h.cache.Forget(h.prefix + sessionID)
+}
+
+// GC is a no-op; the cache expires sessions itself.
+func (h *CacheBasedHandler) GC(maxLifetime time.Duration) (int, error) {
+	return 0, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.HandlerInterface = (*CacheBasedHandler)(nil)
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"govel/new/session/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// maxCookieSize is the largest cookie value browsers reliably accept.
+const maxCookieSize = 4093
+
+// cookiePayload is the envelope stored in the session cookie.
+type cookiePayload struct {
+	Data    string `json:"data"`
+	Expires int64  `json:"expires"`
+}
+
+// CookieHandler stores the whole session payload in a cookie named after the
+// session ID. Combine it with an encrypted store so clients cannot read or
+// tamper with their session.
+//
+// The shared handler cannot read anything by itself; the StartSession
+// middleware binds a copy to each request through ForRequest and adds the
+// cookies it queued to the response.
+type CookieHandler struct {
+	// mu guards queued
+	mu sync.Mutex
+
+	// lifetime is the cookie lifetime
+	lifetime time.Duration
+
+	// options are applied to every queued cookie (path, domain, secure...)
+	options func(cookie *http.Cookie)
+
+	// request is the request this copy is bound to, nil for the shared handler
+	request webserverInterfaces.RequestInterface
+
+	// queued holds the cookies to add to the response
+	queued []*http.Cookie
+}
+
+// NewCookieHandler creates a new cookie session handler.
+//
+// Parameters:
+//   - lifetime: Cookie and session lifetime
+//   - options: Optional function applied to every cookie (path, domain, flags)
+func NewCookieHandler(lifetime time.Duration, options func(cookie *http.Cookie)) *CookieHandler {
+	return &CookieHandler{
+		lifetime: lifetime,
+		options:  options,
+	}
+}
+
+// ForRequest returns a copy of the handler bound to the given request.
+func (h *CookieHandler) ForRequest(req webserverInterfaces.RequestInterface) interfaces.HandlerInterface {
+	return &CookieHandler{
+		lifetime: h.lifetime,
+		options:  h.options,
+		request:  req,
+	}
+}
+
+// QueuedCookies returns the cookies queued by Write and Destroy.
+func (h *CookieHandler) QueuedCookies() []*http.Cookie {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]*http.Cookie(nil), h.queued...)
+}
+
+// Open is a no-op.
+func (h *CookieHandler) Open(savePath, sessionName string) error {
+	return nil
+}
+
+// Close is a no-op.
+func (h *CookieHandler) Close() error {
+	return nil
+}
+
+// Read returns the payload stored in the request cookie if it has not expired.
+func (h *CookieHandler) Read(sessionID string) (string, error) {
+	if h.request == nil {
+		return "", nil
+	}
+
+	value := h.request.Cookie(sessionID)
+	if value == "" {
+		return "", nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", nil
+	}
+
+	var payload cookiePayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return "", nil
+	}
+	if time.Now().Unix() > payload.Expires {
+		return "", nil
+	}
+	return payload.Data, nil
+}
+
+// Write queues a cookie holding the payload.
+func (h *CookieHandler) Write(sessionID, data string) error {
+	expires := time.Now().Add(h.lifetime)
+	raw, err := json.Marshal(cookiePayload{Data: data, Expires: expires.Unix()})
+	if err != nil {
+		return err
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(raw)
+	if len(value) > maxCookieSize {
+		return fmt.Errorf("session payload of %d bytes exceeds the cookie size limit", len(value))
+	}
+
+	h.queue(&http.Cookie{
+		Name:     sessionID,
+		Value:    value,
+		Expires:  expires,
+		MaxAge:   int(h.lifetime.Seconds()),
+		Path:     "/",
+		HttpOnly: true,
+	})
+	return nil
+}
+
+// Destroy queues an expired cookie to remove the session from the client.
+func (h *CookieHandler) Destroy(sessionID string) error {
+	h.queue(&http.Cookie{
+		Name:    sessionID,
+		Value:   "",
+		Expires: time.Unix(0, 0),
+		MaxAge:  -1,
+		Path:    "/",
+	})
+	return nil
+}
+
+// GC is a no-op; browsers expire cookies on their own.
+func (h *CookieHandler) GC(maxLifetime time.Duration) (int, error) {
+	return 0, nil
+}
+
+// queue applies the configured options and queues the cookie.
+func (h *CookieHandler) queue(cookie *http.Cookie) {
+	if h.options != nil {
+		h.options(cookie)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.queued = append(h.queued, cookie)
+}
+
+// Compile-time interface compliance checks
+var _ interfaces.HandlerInterface = (*CookieHandler)(nil)
+var _ interfaces.RequestAwareHandlerInterface = (*CookieHandler)(nil)
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"govel/new/session/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// DatabaseHandler stores sessions in a database table.
+//
+// Expected schema (see the session:table command):
+//
+//	id            VARCHAR(255) PRIMARY KEY
+//	user_id       BIGINT NULL
+//	ip_address    VARCHAR(45) NULL
+//	user_agent    TEXT NULL
+//	payload       TEXT NOT NULL
+//	last_activity INTEGER NOT NULL (indexed)
+//
+// The handler is shared across requests. Request metadata (IP, user agent,
+// user ID) is captured on a request-bound copy obtained through ForRequest.
+// Writes try an UPDATE first and fall back to an INSERT, retrying the UPDATE
+// if a parallel request inserted the same session ID in the meantime.
+type DatabaseHandler struct {
+	// db is the database connection pool
+	db *sql.DB
+
+	// table is the sessions table name
+	table string
+
+	// lifetime is the idle time after which a session expires
+	lifetime time.Duration
+
+	// dialect selects the placeholder style ("postgres"/"pgsql" use $N)
+	dialect string
+
+	// userResolver extracts the authenticated user ID from a request
+	userResolver func(req webserverInterfaces.RequestInterface) interface{}
+
+	// request is the request this copy is bound to, nil for the shared handler
+	request webserverInterfaces.RequestInterface
+}
+
+// NewDatabaseHandler creates a new database session handler.
+//
+// Parameters:
+//   - db: Database connection pool
+//   - table: Sessions table name
+//   - lifetime: Idle time after which a session expires
+//   - dialect: SQL dialect used for placeholders ("mysql", "sqlite", "postgres")
+func NewDatabaseHandler(db *sql.DB, table string, lifetime time.Duration, dialect string) *DatabaseHandler {
+	return &DatabaseHandler{
+		db:       db,
+		table:    table,
+		lifetime: lifetime,
+		dialect:  strings.ToLower(dialect),
+	}
+}
+
+// SetUserResolver sets the function used to record the user owning a session.
+func (h *DatabaseHandler) SetUserResolver(resolver func(req webserverInterfaces.RequestInterface) interface{}) *DatabaseHandler {
+	h.userResolver = resolver
+	return h
+}
+
+// ForRequest returns a copy of the handler bound to the given request.
+func (h *DatabaseHandler) ForRequest(req webserverInterfaces.RequestInterface) interfaces.HandlerInterface {
+	bound := *h
+	bound.request = req
+	return &bound
+}
+
+// QueuedCookies returns nil; the database handler never sets cookies.
+func (h *DatabaseHandler) QueuedCookies() []*http.Cookie {
+	return nil
+}
+
+// Open is a no-op.
+func (h *DatabaseHandler) Open(savePath, sessionName string) error {
+	return nil
+}
+
+// Close is a no-op; the connection pool is owned by the caller.
+func (h *DatabaseHandler) Close() error {
+	return nil
+}
+
+// Read returns the payload if the session exists and has not expired.
+func (h *DatabaseHandler) Read(sessionID string) (string, error) {
+	query := fmt.Sprintf("SELECT payload, last_activity FROM %s WHERE id = %s", h.table, h.placeholder(1))
+
+	var payload string
+	var lastActivity int64
+	err := h.db.QueryRow(query, sessionID).Scan(&payload, &lastActivity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if h.expired(lastActivity) {
+		return "", nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil
+	}
+	return string(decoded), nil
+}
+
+// Write stores the payload, updating the existing row or inserting a new one.
+func (h *DatabaseHandler) Write(sessionID, data string) error {
+	payload := base64.StdEncoding.EncodeToString([]byte(data))
+	now := time.Now().Unix()
+	userID, ip, userAgent := h.requestMetadata()
+
+	updated, err := h.update(sessionID, payload, now, userID, ip, userAgent)
+	if err != nil {
+		return err
+	}
+	if updated {
+		return nil
+	}
+
+	insert := fmt.Sprintf(
+		"INSERT INTO %s (id, user_id, ip_address, user_agent, payload, last_activity) VALUES (%s, %s, %s, %s, %s, %s)",
+		h.table, h.placeholder(1), h.placeholder(2), h.placeholder(3), h.placeholder(4), h.placeholder(5), h.placeholder(6),
+	)
+	if _, err := h.db.Exec(insert, sessionID, userID, ip, userAgent, payload, now); err != nil {
+		// A parallel request may have inserted the row first
+		if updated, updateErr := h.update(sessionID, payload, now, userID, ip, userAgent); updateErr == nil && updated {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// update updates an existing session row and reports whether one was found.
+func (h *DatabaseHandler) update(sessionID, payload string, now int64, userID interface{}, ip, userAgent interface{}) (bool, error) {
+	query := fmt.Sprintf(
+		"UPDATE %s SET user_id = %s, ip_address = %s, user_agent = %s, payload = %s, last_activity = %s WHERE id = %s",
+		h.table, h.placeholder(1), h.placeholder(2), h.placeholder(3), h.placeholder(4), h.placeholder(5), h.placeholder(6),
+	)
+	result, err := h.db.Exec(query, userID, ip, userAgent, payload, now, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Destroy removes the session row.
+func (h *DatabaseHandler) Destroy(sessionID string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = %s", h.table, h.placeholder(1))
+	_, err := h.db.Exec(query, sessionID)
+	return err
+}
+
+// GC removes sessions idle for longer than maxLifetime.
+func (h *DatabaseHandler) GC(maxLifetime time.Duration) (int, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE last_activity <= %s", h.table, h.placeholder(1))
+	result, err := h.db.Exec(query, time.Now().Add(-maxLifetime).Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// requestMetadata returns the user ID, IP address and user agent of the bound request.
+func (h *DatabaseHandler) requestMetadata() (interface{}, interface{}, interface{}) {
+	if h.request == nil {
+		return nil, nil, nil
+	}
+
+	var userID interface{}
+	if h.userResolver != nil {
+		userID = h.userResolver(h.request)
+	}
+
+	userAgent := h.request.UserAgent()
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+	return userID, h.request.IP(), userAgent
+}
+
+// expired reports whether a session last active at the given Unix time has expired.
+func (h *DatabaseHandler) expired(lastActivity int64) bool {
+	return lastActivity < time.Now().Add(-h.lifetime).Unix()
+}
+
+// placeholder returns the bind placeholder for the n-th parameter.
+func (h *DatabaseHandler) placeholder(n int) string {
+	if h.dialect == "postgres" || h.dialect == "pgsql" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Compile-time interface compliance checks
+var _ interfaces.HandlerInterface = (*DatabaseHandler)(nil)
+var _ interfaces.RequestAwareHandlerInterface = (*DatabaseHandler)(nil)
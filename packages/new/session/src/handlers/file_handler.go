@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"govel/new/session/interfaces"
+)
+
+// FileHandler stores each session in its own file.
+//
+// Writes go to a temporary file in the same directory which is then renamed
+// over the session file. Renames are atomic, so a concurrent Read always sees
+// either the previous or the new payload, never a partially written one.
+// Expired files are removed by GC, which the StartSession middleware runs
+// according to the session.lottery configuration.
+type FileHandler struct {
+	// path is the directory holding the session files
+	path string
+
+	// lifetime is the idle time after which a session expires
+	lifetime time.Duration
+
+	// fileMode is the permission used for session files
+	fileMode os.FileMode
+}
+
+// NewFileHandler creates a new file session handler.
+// The directory is created when it does not exist.
+//
+// Parameters:
+//   - path: Directory holding the session files
+//   - lifetime: Idle time after which a session expires
+func NewFileHandler(path string, lifetime time.Duration) *FileHandler {
+	return &FileHandler{
+		path:     path,
+		lifetime: lifetime,
+		fileMode: 0600,
+	}
+}
+
+// GetPath returns the directory holding the session files.
+func (h *FileHandler) GetPath() string {
+	return h.path
+}
+
+// Open ensures the session directory exists.
+func (h *FileHandler) Open(savePath, sessionName string) error {
+	if err := os.MkdirAll(h.path, 0700); err != nil {
+		return fmt.Errorf("failed to create session directory %s: %w", h.path, err)
+	}
+	return nil
+}
+
+// Close is a no-op.
+func (h *FileHandler) Close() error {
+	return nil
+}
+
+// Read returns the payload if the session file exists and has not expired.
+func (h *FileHandler) Read(sessionID string) (string, error) {
+	file, err := h.sessionPath(sessionID)
+	if err != nil {
+		return "", nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if info.ModTime().Before(time.Now().Add(-h.lifetime)) {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Write atomically replaces the session file with the payload.
+func (h *FileHandler) Write(sessionID, data string) error {
+	file, err := h.sessionPath(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Open(h.path, ""); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(h.path, ".tmp-"+sessionID+"-*")
+	if err != nil {
+		return err
+	}
+	tempName := temp.Name()
+
+	if _, err := temp.WriteString(data); err != nil {
+		temp.Close()
+		os.Remove(tempName)
+		return err
+	}
+	if err := temp.Chmod(h.fileMode); err != nil {
+		temp.Close()
+		os.Remove(tempName)
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(tempName)
+		return err
+	}
+
+	if err := os.Rename(tempName, file); err != nil {
+		os.Remove(tempName)
+		return err
+	}
+	return nil
+}
+
+// Destroy removes the session file.
+func (h *FileHandler) Destroy(sessionID string) error {
+	file, err := h.sessionPath(sessionID)
+	if err != nil {
+		return nil
+	}
+
+	if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// GC removes session files not modified within maxLifetime.
+// Leftover temporary files from interrupted writes are removed as well.
+func (h *FileHandler) GC(maxLifetime time.Duration) (int, error) {
+	entries, err := os.ReadDir(h.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-maxLifetime)
+	deleted := 0
+
+	for _, entry := range entries {
+		temporary := strings.HasPrefix(entry.Name(), ".tmp-")
+		if entry.IsDir() || (strings.HasPrefix(entry.Name(), ".") && !temporary) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(h.path, entry.Name())); err == nil {
+			if !temporary {
+				deleted++
+			}
+		}
+	}
+
+	return deleted, nil
+}
+
+// sessionPath returns the file path for a session ID.
+// IDs containing path separators are rejected to prevent path traversal.
+func (h *FileHandler) sessionPath(sessionID string) (string, error) {
+	if sessionID == "" || strings.ContainsAny(sessionID, `/\.`) {
+		return "", fmt.Errorf("invalid session id %q", sessionID)
+	}
+	return filepath.Join(h.path, sessionID), nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.HandlerInterface = (*FileHandler)(nil)
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"time"
+
+	"govel/new/session/interfaces"
+)
+
+// NullHandler is a session handler that stores nothing.
+// Every session starts empty on each request; useful for stateless APIs
+// and tests that do not care about persistence.
+type NullHandler struct{}
+
+// NewNullHandler creates a new null session handler.
+func NewNullHandler() *NullHandler {
+	return &NullHandler{}
+}
+
+// Open is a no-op.
+func (h *NullHandler) Open(savePath, sessionName string) error {
+	return nil
+}
+
+// Close is a no-op.
+func (h *NullHandler) Close() error {
+	return nil
+}
+
+// Read always returns an empty payload.
+func (h *NullHandler) Read(sessionID string) (string, error) {
+	return "", nil
+}
+
+// Write discards the payload.
+func (h *NullHandler) Write(sessionID, data string) error {
+	return nil
+}
+
+// Destroy is a no-op.
+func (h *NullHandler) Destroy(sessionID string) error {
+	return nil
+}
+
+// GC is a no-op.
+func (h *NullHandler) GC(maxLifetime time.Duration) (int, error) {
+	return 0, nil
+}
+
+// Compile-time interface compliance check
+var _ interfaces.HandlerInterface = (*NullHandler)(nil)
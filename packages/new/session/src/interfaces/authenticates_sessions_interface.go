@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// AuthenticatableInterface is the minimal view of an authenticated user
+// required to bind a session to the user's current credentials.
+type AuthenticatableInterface interface {
+	// GetAuthIdentifier returns the unique identifier of the user.
+	GetAuthIdentifier() interface{}
+
+	// GetAuthPassword returns the user's current password hash.
+	GetAuthPassword() string
+}
+
+// AuthenticatesSessionsInterface is implemented by authentication guards that
+// can be protected by the AuthenticateSession middleware. When the password
+// hash of the user changes (e.g. a password reset on another device), every
+// other session of that user is logged out on its next request.
+type AuthenticatesSessionsInterface interface {
+	// GetName returns the guard name. It namespaces the hash stored in the session.
+	GetName() string
+
+	// User returns the user authenticated for the request, or nil.
+	User(req webserverInterfaces.RequestInterface) AuthenticatableInterface
+
+	// LogoutCurrentDevice logs the user out of the current session only.
+	//
+	// Returns:
+	//   - error: Any error raised while logging out
+	LogoutCurrentDevice(req webserverInterfaces.RequestInterface) error
+}
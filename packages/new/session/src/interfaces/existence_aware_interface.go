@@ -0,0 +1,15 @@
+package interfaces
+
+// ExistenceAwareInterface is implemented by handlers that need to know whether
+// the current session already exists in storage. The database handler uses it
+// to choose between an UPDATE and an INSERT without an extra round-trip.
+type ExistenceAwareInterface interface {
+	// SetExists records whether the session exists in storage.
+	//
+	// Parameters:
+	//   - exists: true when the session payload was found in storage
+	//
+	// Returns:
+	//   - HandlerInterface: The handler for method chaining
+	SetExists(exists bool) HandlerInterface
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+// FactoryInterface defines the contract for building session stores.
+// Handlers are shared and cached per driver while every call to Store
+// produces a fresh, request-scoped session.
+type FactoryInterface interface {
+	// Handler returns the storage handler for the given driver.
+	// If no name is provided, the configured default driver is used.
+	//
+	// Returns:
+	//   - HandlerInterface: The cached handler for the driver
+	//   - error: Any error raised while creating the handler
+	Handler(name ...string) (HandlerInterface, error)
+
+	// Store builds a new session backed by the given driver's handler.
+	// If no name is provided, the configured default driver is used.
+	//
+	// Returns:
+	//   - SessionInterface: A new, unstarted session
+	//   - error: Any error raised while creating the handler
+	Store(name ...string) (SessionInterface, error)
+}
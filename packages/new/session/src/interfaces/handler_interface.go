@@ -0,0 +1,95 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// HandlerInterface defines the contract for session storage handlers.
+// It mirrors PHP's SessionHandlerInterface as used by Laravel: a handler only
+// moves opaque, already-serialized payloads in and out of a storage backend.
+//
+// Implementations must be safe for concurrent use because a single handler
+// instance is shared by every request served by the application.
+type HandlerInterface interface {
+	// Open prepares the handler for use.
+	//
+	// Parameters:
+	//   - savePath: Storage location hint (directory, table, prefix)
+	//   - sessionName: Name of the session being opened
+	//
+	// Returns:
+	//   - error: Any error raised while preparing the backend
+	Open(savePath, sessionName string) error
+
+	// Close releases resources acquired by Open.
+	Close() error
+
+	// Read returns the serialized payload stored for the session ID.
+	// An unknown or expired session yields an empty string and no error.
+	//
+	// Parameters:
+	//   - sessionID: The session identifier
+	//
+	// Returns:
+	//   - string: The stored payload, empty when none exists
+	//   - error: Any error raised by the backend
+	Read(sessionID string) (string, error)
+
+	// Write stores the serialized payload for the session ID.
+	//
+	// Parameters:
+	//   - sessionID: The session identifier
+	//   - data: The serialized session payload
+	//
+	// Returns:
+	//   - error: Any error raised by the backend
+	Write(sessionID, data string) error
+
+	// Destroy removes the payload stored for the session ID.
+	//
+	// Parameters:
+	//   - sessionID: The session identifier
+	//
+	// Returns:
+	//   - error: Any error raised by the backend
+	Destroy(sessionID string) error
+
+	// GC removes sessions that have been idle for longer than maxLifetime.
+	//
+	// Parameters:
+	//   - maxLifetime: Maximum idle time before a session is considered expired
+	//
+	// Returns:
+	//   - int: Number of sessions removed
+	//   - error: Any error raised by the backend
+	GC(maxLifetime time.Duration) (int, error)
+}
+
+// RequestAwareHandlerInterface is implemented by handlers whose storage lives
+// in the HTTP exchange itself (e.g. the cookie handler). Because handlers are
+// shared between requests, such handlers hand out a request-bound copy.
+type RequestAwareHandlerInterface interface {
+	HandlerInterface
+
+	// ForRequest returns a copy of the handler bound to the given request.
+	ForRequest(req webserverInterfaces.RequestInterface) HandlerInterface
+
+	// QueuedCookies returns the cookies the handler needs added to the response.
+	QueuedCookies() []*http.Cookie
+}
+
+// CacheStoreInterface is the minimal cache contract required by the
+// cache-based session handler. Any cache repository can be adapted to it.
+type CacheStoreInterface interface {
+	// Get returns the cached value and whether it was found.
+	Get(key string) (interface{}, bool)
+
+	// Put stores a value for the given duration.
+	Put(key string, value interface{}, ttl time.Duration) error
+
+	// Forget removes a value from the cache.
+	Forget(key string) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	sessionInterfaces "govel/types/interfaces/session"
+)
+
+// SessionInterface extends the framework-wide session contract with access to
+// the storage handler. It is implemented by stores.Store and stores.EncryptedStore.
+type SessionInterface interface {
+	sessionInterfaces.SessionInterface
+
+	// GetHandler returns the storage handler backing the session.
+	GetHandler() HandlerInterface
+
+	// SetHandler replaces the storage handler backing the session.
+	SetHandler(handler HandlerInterface)
+
+	// SetExists records whether the session payload exists in storage.
+	SetExists(exists bool)
+}
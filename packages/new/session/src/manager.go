@@ -0,0 +1,319 @@
+package session
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"govel/new/session/handlers"
+	"govel/new/session/interfaces"
+	"govel/new/session/stores"
+	support "govel/support"
+	containerInterfaces "govel/types/interfaces/container"
+	encryptionInterfaces "govel/types/interfaces/encryption"
+)
+
+// SessionManager provides centralized session handler management.
+// Extends support.Manager implementing Laravel-style driver pattern for session storage.
+//
+// Unlike Laravel, drivers resolve to storage handlers rather than stores:
+// handlers are shared and cached by the base manager, while a fresh
+// request-scoped store is built on every Store call. Caching stores would
+// share one session between all concurrent requests.
+//
+// Supported drivers: file, array, database, cookie, redis, memcached, dynamodb, null.
+type SessionManager struct {
+	*support.Manager
+
+	// mu guards the resolvers below
+	mu sync.RWMutex
+
+	// connectionResolver resolves database connections for the database driver
+	connectionResolver func(name string) (*sql.DB, string, error)
+
+	// cacheResolver resolves cache stores for the cache-based drivers
+	cacheResolver func(store string) (interfaces.CacheStoreInterface, error)
+
+	// lottery decides whether garbage collection runs on a request
+	lottery func(chances, outOf int) bool
+}
+
+// NewSessionManager creates a new session manager.
+//
+// Returns configured SessionManager ready to build sessions.
+func NewSessionManager(container containerInterfaces.ContainerInterface) *SessionManager {
+	baseManager := support.NewManager(container)
+
+	sessionManager := &SessionManager{
+		Manager: baseManager,
+		lottery: func(chances, outOf int) bool {
+			return rand.Intn(outOf) < chances
+		},
+	}
+
+	// Set up proxy self-reference so the base manager finds CreateXXXDriver methods
+	baseManager.SetProxySelf(sessionManager)
+
+	return sessionManager
+}
+
+// SetConnectionResolver sets the function resolving database connections for
+// the database driver. The resolver returns the pool and its SQL dialect.
+func (m *SessionManager) SetConnectionResolver(resolver func(name string) (*sql.DB, string, error)) *SessionManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.connectionResolver = resolver
+	return m
+}
+
+// SetCacheResolver sets the function resolving cache stores for the
+// redis, memcached and dynamodb drivers.
+func (m *SessionManager) SetCacheResolver(resolver func(store string) (interfaces.CacheStoreInterface, error)) *SessionManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cacheResolver = resolver
+	return m
+}
+
+// SetLottery replaces the function deciding whether garbage collection runs.
+// Mostly useful in tests to make the lottery deterministic.
+func (m *SessionManager) SetLottery(lottery func(chances, outOf int) bool) *SessionManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lottery = lottery
+	return m
+}
+
+// Handler returns the cached storage handler for the given driver.
+// If no name is provided, the configured default driver is used.
+func (m *SessionManager) Handler(name ...string) (interfaces.HandlerInterface, error) {
+	driver, err := m.Driver(name...)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, ok := driver.(interfaces.HandlerInterface)
+	if !ok {
+		return nil, fmt.Errorf("session driver does not implement HandlerInterface, got %T", driver)
+	}
+	return handler, nil
+}
+
+// Store builds a new, unstarted session backed by the given driver's handler.
+// The store is encrypted when session.encrypt is enabled.
+func (m *SessionManager) Store(name ...string) (interfaces.SessionInterface, error) {
+	handler, err := m.Handler(name...)
+	if err != nil {
+		return nil, err
+	}
+	return m.Build(handler)
+}
+
+// Build builds a new session around the given handler using the configured
+// cookie name and encryption setting.
+func (m *SessionManager) Build(handler interfaces.HandlerInterface) (interfaces.SessionInterface, error) {
+	if !m.GetConfig().GetBool("session.encrypt", false) {
+		return stores.NewStore(m.CookieName(), handler), nil
+	}
+
+	encrypter, err := m.GetContainer().Make(encryptionInterfaces.ENCRYPTION_DRIVER_TOKEN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve encrypter for session: %w", err)
+	}
+
+	typed, ok := encrypter.(encryptionInterfaces.EncrypterInterface)
+	if !ok {
+		return nil, fmt.Errorf("encrypter does not implement EncrypterInterface, got %T", encrypter)
+	}
+	return stores.NewEncryptedStore(m.CookieName(), handler, typed), nil
+}
+
+// CookieName returns the configured session cookie name.
+func (m *SessionManager) CookieName() string {
+	return m.GetConfig().GetString("session.cookie", "govel-session")
+}
+
+// Lifetime returns the configured session lifetime.
+func (m *SessionManager) Lifetime() time.Duration {
+	return time.Duration(m.GetConfig().GetInt("session.lifetime", 120)) * time.Minute
+}
+
+// CookieOptions applies the configured cookie attributes to a cookie.
+//
+// session.partitioned is not applied: http.Cookie only carries the
+// Partitioned attribute from Go 1.23, and the modules target Go 1.21.
+func (m *SessionManager) CookieOptions(cookie *http.Cookie) {
+	config := m.GetConfig()
+
+	cookie.Path = config.GetString("session.path", "/")
+	cookie.Domain = config.GetString("session.domain", "")
+	cookie.Secure = config.GetBool("session.secure", false)
+	cookie.HttpOnly = config.GetBool("session.http_only", true)
+
+	switch strings.ToLower(config.GetString("session.same_site", "lax")) {
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "none":
+		cookie.SameSite = http.SameSiteNoneMode
+	case "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	default:
+		cookie.SameSite = http.SameSiteDefaultMode
+	}
+}
+
+// Lottery returns the configured garbage collection odds (e.g. 2 out of 100).
+func (m *SessionManager) Lottery() (int, int) {
+	chances, outOf := 2, 100
+
+	value, exists := m.GetConfig().Get("session.lottery")
+	if !exists {
+		return chances, outOf
+	}
+
+	var odds []int
+	switch lottery := value.(type) {
+	case []int:
+		odds = lottery
+	case []interface{}:
+		for _, item := range lottery {
+			switch n := item.(type) {
+			case int:
+				odds = append(odds, n)
+			case int64:
+				odds = append(odds, int(n))
+			case float64:
+				odds = append(odds, int(n))
+			}
+		}
+	}
+
+	if len(odds) == 2 && odds[1] > 0 {
+		chances, outOf = odds[0], odds[1]
+	}
+	return chances, outOf
+}
+
+// ShouldCollectGarbage draws the configured lottery.
+func (m *SessionManager) ShouldCollectGarbage() bool {
+	chances, outOf := m.Lottery()
+
+	m.mu.RLock()
+	lottery := m.lottery
+	m.mu.RUnlock()
+
+	return lottery(chances, outOf)
+}
+
+// CollectGarbage removes expired sessions from the given driver's storage.
+//
+// Returns:
+//   - int: Number of sessions removed
+//   - error: Any error raised by the handler
+func (m *SessionManager) CollectGarbage(name ...string) (int, error) {
+	handler, err := m.Handler(name...)
+	if err != nil {
+		return 0, err
+	}
+	return handler.GC(m.Lifetime())
+}
+
+// CreateArrayDriver creates the in-memory session handler.
+func (m *SessionManager) CreateArrayDriver() (interface{}, error) {
+	return handlers.NewArrayHandler(m.Lifetime()), nil
+}
+
+// CreateNullDriver creates a session handler that stores nothing.
+func (m *SessionManager) CreateNullDriver() (interface{}, error) {
+	return handlers.NewNullHandler(), nil
+}
+
+// CreateFileDriver creates the file session handler using session.files.
+func (m *SessionManager) CreateFileDriver() (interface{}, error) {
+	path := m.GetConfig().GetString("session.files", "storage/framework/sessions")
+
+	handler := handlers.NewFileHandler(path, m.Lifetime())
+	if err := handler.Open(path, m.CookieName()); err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// CreateCookieDriver creates the cookie session handler.
+func (m *SessionManager) CreateCookieDriver() (interface{}, error) {
+	lifetime := m.Lifetime()
+	return handlers.NewCookieHandler(lifetime, m.CookieOptions), nil
+}
+
+// CreateDatabaseDriver creates the database session handler using
+// session.connection and session.table.
+func (m *SessionManager) CreateDatabaseDriver() (interface{}, error) {
+	m.mu.RLock()
+	resolver := m.connectionResolver
+	m.mu.RUnlock()
+
+	if resolver == nil {
+		return nil, fmt.Errorf("session driver [database] requires a connection resolver")
+	}
+
+	db, dialect, err := resolver(m.GetConfig().GetString("session.connection", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve session database connection: %w", err)
+	}
+
+	table := m.GetConfig().GetString("session.table", "sessions")
+	return handlers.NewDatabaseHandler(db, table, m.Lifetime(), dialect), nil
+}
+
+// CreateRedisDriver creates a cache-based session handler backed by redis.
+func (m *SessionManager) CreateRedisDriver() (interface{}, error) {
+	return m.createCacheBased("redis")
+}
+
+// CreateMemcachedDriver creates a cache-based session handler backed by memcached.
+func (m *SessionManager) CreateMemcachedDriver() (interface{}, error) {
+	return m.createCacheBased("memcached")
+}
+
+// CreateDynamodbDriver creates a cache-based session handler backed by DynamoDB.
+func (m *SessionManager) CreateDynamodbDriver() (interface{}, error) {
+	return m.createCacheBased("dynamodb")
+}
+
+// createCacheBased creates a cache-based handler for the given cache driver.
+// session.store selects the cache store and defaults to the driver name.
+func (m *SessionManager) createCacheBased(driver string) (interface{}, error) {
+	m.mu.RLock()
+	resolver := m.cacheResolver
+	m.mu.RUnlock()
+
+	if resolver == nil {
+		return nil, fmt.Errorf("session driver [%s] requires a cache resolver", driver)
+	}
+
+	store := m.GetConfig().GetString("session.store", "")
+	if store == "" {
+		store = driver
+	}
+
+	cache, err := resolver(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve session cache store [%s]: %w", store, err)
+	}
+	return handlers.NewCacheBasedHandler(cache, m.Lifetime(), ""), nil
+}
+
+// GetDefaultDriver returns the configured default session driver.
+func (m *SessionManager) GetDefaultDriver() string {
+	return m.GetConfig().GetString("session.driver", "file")
+}
+
+// Compile-time interface compliance check
+var _ interfaces.FactoryInterface = (*SessionManager)(nil)
@@ -0,0 +1,121 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	session "govel/new/session"
+	"govel/new/session/interfaces"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// AuthenticateSessionMiddleware binds a session to the password of the user
+// it was authenticated with. When the user's password hash changes (password
+// reset, "log out other devices"), every other session of that user is logged
+// out on its next request.
+//
+// A keyed digest of the password hash is stored in the session under
+// "password_hash_{guard}"; the hash itself never leaves the user provider.
+// Must run after StartSessionMiddleware.
+type AuthenticateSessionMiddleware struct {
+	webserver.BaseMiddleware
+
+	// guard resolves and logs out the authenticated user
+	guard interfaces.AuthenticatesSessionsInterface
+
+	// key is the HMAC key used to digest password hashes (usually APP_KEY)
+	key []byte
+
+	// redirectTo is where browser requests are sent after being logged out
+	redirectTo string
+}
+
+// NewAuthenticateSessionMiddleware creates a new session authentication middleware.
+//
+// Parameters:
+//   - guard: Guard resolving the authenticated user
+//   - key: Secret used to digest password hashes (usually the application key)
+//   - redirectTo: Location browser requests are redirected to after logout
+func NewAuthenticateSessionMiddleware(guard interfaces.AuthenticatesSessionsInterface, key []byte, redirectTo string) *AuthenticateSessionMiddleware {
+	if redirectTo == "" {
+		redirectTo = "/login"
+	}
+
+	return &AuthenticateSessionMiddleware{
+		guard:      guard,
+		key:        key,
+		redirectTo: redirectTo,
+	}
+}
+
+// Handle verifies the stored password digest and logs the user out on mismatch.
+func (m *AuthenticateSessionMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	store := session.FromRequest(req)
+	if store == nil {
+		return next.Handle(req)
+	}
+
+	user := m.guard.User(req)
+	if user == nil {
+		return next.Handle(req)
+	}
+
+	key := m.sessionKey()
+	if !store.Has(key) {
+		store.Put(key, m.digest(user.GetAuthPassword()))
+	}
+
+	stored := store.GetString(key)
+	if !hmac.Equal([]byte(stored), []byte(m.digest(user.GetAuthPassword()))) {
+		return m.logout(req, store)
+	}
+
+	resp := next.Handle(req)
+
+	// The user may have changed their own password during this request
+	if current := m.guard.User(req); current != nil {
+		store.Put(key, m.digest(current.GetAuthPassword()))
+	}
+
+	return resp
+}
+
+// logout logs the user out of the current device and invalidates the session.
+func (m *AuthenticateSessionMiddleware) logout(req webserverInterfaces.RequestInterface, store interfaces.SessionInterface) webserverInterfaces.ResponseInterface {
+	_ = m.guard.LogoutCurrentDevice(req)
+
+	store.Flush()
+	if err := store.Invalidate(); err != nil {
+		return sessionErrorResponse("Unable to invalidate session")
+	}
+
+	if req.WantsJson() || req.IsAjax() {
+		return webserver.NewResponse().Status(http.StatusUnauthorized).Json(map[string]interface{}{
+			"message": "Unauthenticated.",
+		})
+	}
+	return webserver.NewResponse().Redirect(m.redirectTo, http.StatusFound)
+}
+
+// sessionKey returns the session key holding the password digest.
+func (m *AuthenticateSessionMiddleware) sessionKey() string {
+	return "password_hash_" + m.guard.GetName()
+}
+
+// digest returns a keyed digest of the password hash.
+func (m *AuthenticateSessionMiddleware) digest(passwordHash string) string {
+	mac := hmac.New(sha256.New, m.key)
+	mac.Write([]byte(passwordHash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Priority returns the middleware priority; it must run after StartSession.
+func (m *AuthenticateSessionMiddleware) Priority() int {
+	return 20
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*AuthenticateSessionMiddleware)(nil)
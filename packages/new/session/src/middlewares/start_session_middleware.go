@@ -0,0 +1,138 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	session "govel/new/session"
+	"govel/new/session/interfaces"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// StartSessionMiddleware starts a session for every request and persists it
+// once the response has been produced.
+//
+// Per request it:
+//   - Builds a fresh store from the manager and loads it using the session cookie
+//   - Exposes the store on the request (see session.FromRequest)
+//   - Draws the session.lottery and, on a win, garbage collects expired sessions
+//   - Remembers the previous URL for GET requests
+//   - Saves the store and attaches the session cookie to the response
+//
+// Saving goes through the store's per-ID lock and last-write-merge, so parallel
+// requests for the same session (e.g. concurrent XHRs) do not clobber each
+// other's writes or flash data.
+type StartSessionMiddleware struct {
+	webserver.BaseMiddleware
+
+	// manager builds the request-scoped stores
+	manager *session.SessionManager
+
+	// driver is the session driver to use; empty means the default driver
+	driver string
+}
+
+// NewStartSessionMiddleware creates a new session starting middleware.
+//
+// Parameters:
+//   - manager: Session manager building the stores
+//   - driver: Optional driver name; the configured default is used when omitted
+func NewStartSessionMiddleware(manager *session.SessionManager, driver ...string) *StartSessionMiddleware {
+	m := &StartSessionMiddleware{manager: manager}
+	if len(driver) > 0 {
+		m.driver = driver[0]
+	}
+	return m
+}
+
+// Handle starts the session, runs the rest of the chain and saves the session.
+func (m *StartSessionMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	handler, err := m.manager.Handler(m.driver)
+	if err != nil {
+		return sessionErrorResponse("Session store unavailable")
+	}
+
+	if aware, ok := handler.(interfaces.RequestAwareHandlerInterface); ok {
+		handler = aware.ForRequest(req)
+	}
+
+	store, err := m.manager.Build(handler)
+	if err != nil {
+		return sessionErrorResponse("Session store unavailable")
+	}
+
+	store.SetID(req.Cookie(store.GetName()))
+	if err := store.Start(); err != nil {
+		return sessionErrorResponse("Unable to start session")
+	}
+
+	req.SetContext(session.RequestContextKey, store)
+
+	m.collectGarbage(handler)
+
+	resp := next.Handle(req)
+
+	m.storeCurrentURL(req, store)
+
+	if err := store.Save(); err != nil {
+		return sessionErrorResponse("Unable to persist session")
+	}
+
+	m.addCookieToResponse(resp, store)
+
+	if aware, ok := store.GetHandler().(interfaces.RequestAwareHandlerInterface); ok {
+		for _, cookie := range aware.QueuedCookies() {
+			resp.Cookie(cookie)
+		}
+	}
+
+	return resp
+}
+
+// collectGarbage runs the handler's GC if the configured lottery is won.
+// Errors are ignored: a failed sweep must never fail the request.
+func (m *StartSessionMiddleware) collectGarbage(handler interfaces.HandlerInterface) {
+	if m.manager.ShouldCollectGarbage() {
+		_, _ = handler.GC(m.manager.Lifetime())
+	}
+}
+
+// storeCurrentURL remembers the URL of full-page GET requests.
+func (m *StartSessionMiddleware) storeCurrentURL(req webserverInterfaces.RequestInterface, store interfaces.SessionInterface) {
+	if req.Method() == http.MethodGet && !req.IsAjax() && !req.WantsJson() {
+		store.SetPreviousURL(req.FullURL())
+	}
+}
+
+// addCookieToResponse attaches the session cookie to the response.
+func (m *StartSessionMiddleware) addCookieToResponse(resp webserverInterfaces.ResponseInterface, store interfaces.SessionInterface) {
+	cookie := &http.Cookie{
+		Name:  store.GetName(),
+		Value: store.GetID(),
+	}
+	m.manager.CookieOptions(cookie)
+
+	if !m.manager.GetConfig().GetBool("session.expire_on_close", false) {
+		lifetime := m.manager.Lifetime()
+		cookie.Expires = time.Now().Add(lifetime)
+		cookie.MaxAge = int(lifetime.Seconds())
+	}
+
+	resp.Cookie(cookie)
+}
+
+// Priority returns the middleware priority; sessions must start early.
+func (m *StartSessionMiddleware) Priority() int {
+	return 10
+}
+
+// sessionErrorResponse builds the response returned when the session fails.
+func sessionErrorResponse(message string) webserverInterfaces.ResponseInterface {
+	return webserver.NewResponse().Status(http.StatusInternalServerError).Json(map[string]interface{}{
+		"message": message,
+	})
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*StartSessionMiddleware)(nil)
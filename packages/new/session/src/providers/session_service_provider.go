@@ -0,0 +1,93 @@
+// Package providers contains service provider implementations for the session package.
+// Service providers are responsible for registering session services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	session "govel/new/session"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	sessionInterfaces "govel/types/interfaces/session"
+)
+
+// SessionServiceProvider implements a Laravel-compatible service provider
+// for the session package.
+//
+// Services registered:
+//   - SESSION_MANAGER_TOKEN: Singleton SessionManager resolving handlers and building stores
+//   - SESSION_FACTORY_TOKEN: Alias of the manager as session factory
+//   - SESSION_TOKEN: Factory producing a new store from the default driver
+//
+// Request code should prefer session.FromRequest(req), which returns the store
+// started for the current request by the StartSession middleware.
+type SessionServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewSessionServiceProvider creates a new SessionServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewSessionServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register session services:", err)
+//	}
+func NewSessionServiceProvider() *SessionServiceProvider {
+	return &SessionServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all session services in the dependency injection container.
+func (h *SessionServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := h.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		manager     *session.SessionManager
+		managerOnce sync.Once
+	)
+	managerFactory := func() interface{} {
+		managerOnce.Do(func() {
+			manager = session.NewSessionManager(application)
+		})
+		return manager
+	}
+
+	if err := application.Singleton(sessionInterfaces.SESSION_MANAGER_TOKEN, managerFactory); err != nil {
+		return fmt.Errorf("failed to bind session manager: %w", err)
+	}
+
+	if err := application.Singleton(sessionInterfaces.SESSION_FACTORY_TOKEN, managerFactory); err != nil {
+		return fmt.Errorf("failed to bind session factory: %w", err)
+	}
+
+	// Sessions are request scoped, so every resolution builds a new store
+	err := application.Bind(sessionInterfaces.SESSION_TOKEN, func() (interface{}, error) {
+		store, err := managerFactory().(*session.SessionManager).Store()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build session store: %w", err)
+		}
+		return store, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind session store: %w", err)
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (h *SessionServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		sessionInterfaces.SESSION_MANAGER_TOKEN,
+		sessionInterfaces.SESSION_FACTORY_TOKEN,
+		sessionInterfaces.SESSION_TOKEN,
+	}
+}
@@ -0,0 +1,32 @@
+// Package session provides Laravel-style HTTP sessions for GoVel applications.
+//
+// A SessionManager resolves shared storage handlers (file, database, cookie,
+// cache, array) and builds a fresh, request-scoped store for every request.
+// The StartSession middleware starts the store, exposes it on the request and
+// persists it once the response has been produced.
+package session
+
+import (
+	"govel/new/session/interfaces"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// RequestContextKey is the request context key holding the current session.
+const RequestContextKey = "__session"
+
+// FromRequest returns the session started for the request, or nil when the
+// StartSession middleware did not run.
+//
+// Example:
+//
+//	if store := session.FromRequest(req); store != nil {
+//	    store.Flash("status", "Profile updated!")
+//	}
+func FromRequest(req webserverInterfaces.RequestInterface) interfaces.SessionInterface {
+	if value := req.GetContext(RequestContextKey); value != nil {
+		if store, ok := value.(interfaces.SessionInterface); ok {
+			return store
+		}
+	}
+	return nil
+}
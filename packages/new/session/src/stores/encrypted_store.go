@@ -0,0 +1,47 @@
+package stores
+
+import (
+	"govel/new/session/interfaces"
+	encryptionInterfaces "govel/types/interfaces/encryption"
+)
+
+// EncryptedStore is a session store that encrypts its payload at rest.
+// It behaves exactly like Store; only the serialized payload handed to the
+// storage handler is encrypted, so every handler benefits transparently.
+type EncryptedStore struct {
+	*Store
+
+	// encrypter encrypts and decrypts the serialized payload
+	encrypter encryptionInterfaces.EncrypterInterface
+}
+
+// NewEncryptedStore creates a new encrypted session store.
+//
+// Parameters:
+//   - name: Session name (cookie name)
+//   - handler: Storage handler used to read and write the payload
+//   - encrypter: Encrypter used to protect the payload
+//   - id: Optional session ID; a fresh ID is generated when omitted or invalid
+//
+// Returns:
+//   - *EncryptedStore: A new, unstarted encrypted session store
+func NewEncryptedStore(name string, handler interfaces.HandlerInterface, encrypter encryptionInterfaces.EncrypterInterface, id ...string) *EncryptedStore {
+	store := &EncryptedStore{
+		Store:     NewStore(name, handler, id...),
+		encrypter: encrypter,
+	}
+
+	// Payloads that fail to decrypt are treated as empty sessions by the store
+	store.Store.encode = encrypter.EncryptString
+	store.Store.decode = encrypter.DecryptString
+
+	return store
+}
+
+// GetEncrypter returns the encrypter used by the store.
+func (s *EncryptedStore) GetEncrypter() encryptionInterfaces.EncrypterInterface {
+	return s.encrypter
+}
+
+// Compile-time interface compliance check
+var _ interfaces.SessionInterface = (*EncryptedStore)(nil)
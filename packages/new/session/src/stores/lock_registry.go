@@ -0,0 +1,67 @@
+package stores
+
+import "sync"
+
+// DefaultLockRegistry is the process-wide registry shared by all stores.
+// Using a single registry guarantees that every store writing a given session
+// ID within this process is serialized, whichever manager created it.
+var DefaultLockRegistry = NewLockRegistry()
+
+// LockRegistry hands out one mutex per session ID.
+// Entries are reference counted and removed once no goroutine holds or waits
+// for them, so the registry does not grow with the number of sessions seen.
+type LockRegistry struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+// sessionLock is a reference-counted mutex for a single session ID.
+type sessionLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewLockRegistry creates an empty lock registry.
+func NewLockRegistry() *LockRegistry {
+	return &LockRegistry{
+		locks: make(map[string]*sessionLock),
+	}
+}
+
+// Lock acquires the lock for the given session ID and returns its release function.
+//
+// Example:
+//
+//	unlock := registry.Lock(sessionID)
+//	defer unlock()
+func (r *LockRegistry) Lock(id string) func() {
+	r.mu.Lock()
+	lock, exists := r.locks[id]
+	if !exists {
+		lock = &sessionLock{}
+		r.locks[id] = lock
+	}
+	lock.refs++
+	r.mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		r.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(r.locks, id)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Size returns the number of session IDs currently locked or waited on.
+func (r *LockRegistry) Size() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.locks)
+}
@@ -0,0 +1,937 @@
+package stores
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"govel/new/session/interfaces"
+)
+
+const (
+	// idLength is the length of generated session IDs (matches Laravel).
+	idLength = 40
+
+	// idAlphabet is the alphabet session IDs and CSRF tokens are drawn from.
+	idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	// flashNewKey holds the keys flashed during the current request.
+	flashNewKey = "_flash.new"
+
+	// flashOldKey holds the keys flashed during the previous request.
+	flashOldKey = "_flash.old"
+
+	// tokenKey holds the CSRF token.
+	tokenKey = "_token"
+
+	// previousURLKey holds the URL of the previous GET request.
+	previousURLKey = "_previous.url"
+
+	// oldInputKey holds input flashed by the previous request.
+	oldInputKey = "_old_input"
+
+	// passwordConfirmedKey holds the last password confirmation timestamp.
+	passwordConfirmedKey = "auth.password_confirmed_at"
+)
+
+// Store implements a Laravel-style, request-scoped session.
+// A Store is created per request, loaded from its handler by Start and written
+// back by Save. It is not meant to be shared between goroutines serving
+// different requests; the internal mutex only protects against concurrent use
+// within a single request (e.g. handler goroutines fanning out).
+//
+// Concurrency between requests that share a session ID is handled on Save:
+//   - Writes for the same session ID are serialized through a process-wide lock
+//   - The payload is merged with the latest stored payload (last-write-merge), so
+//     only keys changed by this request are written; keys written in the meantime
+//     by a parallel request (e.g. flash data from another XHR) are preserved
+type Store struct {
+	// mu guards every field below
+	mu sync.RWMutex
+
+	// id is the current session identifier
+	id string
+
+	// name is the session name, used as the cookie name
+	name string
+
+	// attributes holds the live session data
+	attributes map[string]interface{}
+
+	// original holds the payload as it was loaded by Start, keyed by attribute.
+	// Values are kept in their serialized form for cheap change detection.
+	original map[string]json.RawMessage
+
+	// loadedID is the session ID the original payload was read from
+	loadedID string
+
+	// handler persists the serialized payload
+	handler interfaces.HandlerInterface
+
+	// started reports whether Start has been called
+	started bool
+
+	// locks serializes Save calls for the same session ID
+	locks *LockRegistry
+
+	// encode transforms the serialized payload before it is written
+	encode func(payload string) (string, error)
+
+	// decode reverses encode after the payload is read
+	decode func(payload string) (string, error)
+}
+
+// NewStore creates a new session store.
+//
+// Parameters:
+//   - name: Session name (cookie name)
+//   - handler: Storage handler used to read and write the payload
+//   - id: Optional session ID; a fresh ID is generated when omitted or invalid
+//
+// Returns:
+//   - *Store: A new, unstarted session store
+//
+// Example:
+//
+//	store := stores.NewStore("govel-session", handlers.NewArrayHandler(120*time.Minute))
+//	if err := store.Start(); err != nil {
+//	    return err
+//	}
+//	store.Put("user_id", 42)
+//	err := store.Save()
+func NewStore(name string, handler interfaces.HandlerInterface, id ...string) *Store {
+	store := &Store{
+		name:       name,
+		handler:    handler,
+		attributes: make(map[string]interface{}),
+		original:   make(map[string]json.RawMessage),
+		locks:      DefaultLockRegistry,
+		encode:     func(payload string) (string, error) { return payload, nil },
+		decode:     func(payload string) (string, error) { return payload, nil },
+	}
+
+	sessionID := ""
+	if len(id) > 0 {
+		sessionID = id[0]
+	}
+	store.SetID(sessionID)
+
+	return store
+}
+
+// SetLockRegistry replaces the lock registry used to serialize writes.
+// Stores share DefaultLockRegistry unless told otherwise.
+func (s *Store) SetLockRegistry(locks *LockRegistry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks = locks
+}
+
+// Start loads the session payload from the handler.
+// A CSRF token is generated if the session does not have one yet.
+func (s *Store) Start() error {
+	if err := s.loadSession(); err != nil {
+		return err
+	}
+
+	if !s.Has(tokenKey) {
+		s.RegenerateToken()
+	}
+
+	s.mu.Lock()
+	s.started = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// loadSession merges the stored payload into the current attributes and keeps
+// a serialized snapshot of it for change detection on Save.
+func (s *Store) loadSession() error {
+	s.mu.RLock()
+	id := s.id
+	s.mu.RUnlock()
+
+	raw, err := s.readFromHandler(id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, value := range raw {
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return fmt.Errorf("session: failed to decode attribute %q: %w", key, err)
+		}
+		s.attributes[key] = decoded
+	}
+	s.original = raw
+	s.loadedID = id
+
+	return nil
+}
+
+// readFromHandler reads and decodes the stored payload for the given ID.
+// Values are returned in their raw JSON form.
+func (s *Store) readFromHandler(id string) (map[string]json.RawMessage, error) {
+	data, err := s.handler.Read(id)
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to read session: %w", err)
+	}
+
+	raw := make(map[string]json.RawMessage)
+	if data == "" {
+		s.setHandlerExists(false)
+		return raw, nil
+	}
+
+	payload, err := s.decode(data)
+	if err != nil {
+		// An undecodable payload (e.g. after an APP_KEY rotation) is treated
+		// as an empty session rather than a hard failure, like Laravel does.
+		s.setHandlerExists(false)
+		return raw, nil
+	}
+
+	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
+		s.setHandlerExists(false)
+		return make(map[string]json.RawMessage), nil
+	}
+
+	s.setHandlerExists(true)
+	return raw, nil
+}
+
+// Save ages the flash data and persists the session payload.
+//
+// Writes are serialized per session ID and merged with the latest stored
+// payload, so two parallel requests only overwrite the keys they changed.
+func (s *Store) Save() error {
+	s.AgeFlashData()
+
+	s.mu.RLock()
+	id := s.id
+	locks := s.locks
+	s.mu.RUnlock()
+
+	unlock := locks.Lock(id)
+	defer unlock()
+
+	payload, err := s.payloadForSave(id)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("session: failed to serialize session: %w", err)
+	}
+
+	encoded, err := s.encode(string(serialized))
+	if err != nil {
+		return fmt.Errorf("session: failed to encode session: %w", err)
+	}
+
+	if err := s.handler.Write(id, encoded); err != nil {
+		return fmt.Errorf("session: failed to write session: %w", err)
+	}
+
+	s.mu.Lock()
+	s.started = false
+	s.original = payload
+	s.loadedID = id
+	s.mu.Unlock()
+
+	return nil
+}
+
+// payloadForSave builds the payload to write for the given ID.
+// When the ID is the one the session was loaded from, the changes made by this
+// request are replayed on top of the latest stored payload.
+func (s *Store) payloadForSave(id string) (map[string]json.RawMessage, error) {
+	current, err := s.serializedAttributes()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	merge := s.loadedID != "" && s.loadedID == id
+	original := s.original
+	s.mu.RUnlock()
+
+	if !merge {
+		return current, nil
+	}
+
+	latest, err := s.readFromHandler(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergePayloads(latest, original, current), nil
+}
+
+// serializedAttributes serializes every attribute to its JSON form.
+func (s *Store) serializedAttributes() (map[string]json.RawMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	serialized := make(map[string]json.RawMessage, len(s.attributes))
+	for key, value := range s.attributes {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("session: failed to serialize attribute %q: %w", key, err)
+		}
+		serialized[key] = encoded
+	}
+
+	return serialized, nil
+}
+
+// mergePayloads applies the difference between original and current to latest.
+//
+// Regular keys use last-write-wins per key: a key is only written if this
+// request changed it, and only deleted if this request removed it. The flash
+// bookkeeping lists are merged as sets so that keys flashed by a parallel
+// request are never dropped by a request that merely aged its own view.
+func mergePayloads(latest, original, current map[string]json.RawMessage) map[string]json.RawMessage {
+	merged := make(map[string]json.RawMessage, len(latest)+len(current))
+	for key, value := range latest {
+		merged[key] = value
+	}
+
+	for key, value := range current {
+		if key == flashNewKey || key == flashOldKey {
+			continue
+		}
+		if before, existed := original[key]; !existed || !bytes.Equal(before, value) {
+			merged[key] = value
+		}
+	}
+
+	for key := range original {
+		if key == flashNewKey || key == flashOldKey {
+			continue
+		}
+		if _, stillSet := current[key]; !stillSet {
+			delete(merged, key)
+		}
+	}
+
+	for _, key := range []string{flashNewKey, flashOldKey} {
+		if list, ok := mergeKeyLists(latest[key], original[key], current[key]); ok {
+			merged[key] = list
+		}
+	}
+
+	return merged
+}
+
+// mergeKeyLists merges a list of flash keys as a set:
+// result = latest + (current - original) - (original - current).
+func mergeKeyLists(latest, original, current json.RawMessage) (json.RawMessage, bool) {
+	if latest == nil && original == nil && current == nil {
+		return nil, false
+	}
+
+	latestKeys := decodeKeyList(latest)
+	originalKeys := toSet(decodeKeyList(original))
+	currentKeys := decodeKeyList(current)
+	currentSet := toSet(currentKeys)
+
+	result := make([]string, 0, len(latestKeys)+len(currentKeys))
+	seen := make(map[string]bool)
+
+	for _, key := range latestKeys {
+		if originalKeys[key] && !currentSet[key] {
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	for _, key := range currentKeys {
+		if !originalKeys[key] && !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
+
+// decodeKeyList decodes a JSON array of strings, ignoring malformed input.
+func decodeKeyList(raw json.RawMessage) []string {
+	if raw == nil {
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil
+	}
+	return keys
+}
+
+// toSet converts a slice of keys to a lookup set.
+func toSet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// AgeFlashData removes the previous request's flash data and marks the
+// current request's flash data as old.
+func (s *Store) AgeFlashData() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range s.keyList(flashOldKey) {
+		delete(s.attributes, key)
+	}
+
+	s.attributes[flashOldKey] = s.keyList(flashNewKey)
+	s.attributes[flashNewKey] = []string{}
+}
+
+// keyList returns the string list stored under key. Callers must hold s.mu.
+func (s *Store) keyList(key string) []string {
+	switch list := s.attributes[key].(type) {
+	case []string:
+		return append([]string(nil), list...)
+	case []interface{}:
+		keys := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				keys = append(keys, str)
+			}
+		}
+		return keys
+	}
+	return []string{}
+}
+
+// All returns a copy of all session attributes.
+func (s *Store) All() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make(map[string]interface{}, len(s.attributes))
+	for key, value := range s.attributes {
+		all[key] = value
+	}
+	return all
+}
+
+// Only returns the attributes matching the given keys.
+func (s *Store) Only(keys ...string) map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	only := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if value, exists := s.attributes[key]; exists {
+			only[key] = value
+		}
+	}
+	return only
+}
+
+// Except returns all attributes except the given keys.
+func (s *Store) Except(keys ...string) map[string]interface{} {
+	excluded := toSet(keys)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	except := make(map[string]interface{}, len(s.attributes))
+	for key, value := range s.attributes {
+		if !excluded[key] {
+			except[key] = value
+		}
+	}
+	return except
+}
+
+// Exists reports whether the key is present, even when its value is nil.
+func (s *Store) Exists(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.attributes[key]
+	return exists
+}
+
+// Missing reports whether the key is absent.
+func (s *Store) Missing(key string) bool {
+	return !s.Exists(key)
+}
+
+// Has reports whether the key is present and not nil.
+func (s *Store) Has(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, exists := s.attributes[key]
+	return exists && value != nil
+}
+
+// Get returns the value of a key or the optional default.
+func (s *Store) Get(key string, defaultValue ...interface{}) interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if value, exists := s.attributes[key]; exists {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+// GetString returns the value of a key as a string.
+func (s *Store) GetString(key string, defaultValue ...string) string {
+	if value, ok := s.Get(key).(string); ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+// GetInt returns the value of a key as an int.
+// Numbers decoded from storage (float64) and numeric strings are converted.
+func (s *Store) GetInt(key string, defaultValue ...int) int {
+	if value, ok := toInt(s.Get(key)); ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return 0
+}
+
+// GetBool returns the value of a key as a bool.
+func (s *Store) GetBool(key string, defaultValue ...bool) bool {
+	if value, ok := s.Get(key).(bool); ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return false
+}
+
+// Pull returns the value of a key and removes it from the session.
+func (s *Store) Pull(key string, defaultValue ...interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value, exists := s.attributes[key]; exists {
+		delete(s.attributes, key)
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+// HasOldInput reports whether the session holds old input.
+// Without a key it reports whether any old input exists at all.
+func (s *Store) HasOldInput(key ...string) bool {
+	if len(key) == 0 {
+		input, _ := s.Get(oldInputKey).(map[string]interface{})
+		return len(input) > 0
+	}
+	return s.GetOldInput(key[0]) != nil
+}
+
+// GetOldInput returns input flashed by the previous request.
+func (s *Store) GetOldInput(key string, defaultValue ...interface{}) interface{} {
+	if input, ok := s.Get(oldInputKey).(map[string]interface{}); ok {
+		if value, exists := input[key]; exists {
+			return value
+		}
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+// Put stores a value in the session.
+func (s *Store) Put(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes[key] = value
+}
+
+// PutMany stores multiple values in the session.
+func (s *Store) PutMany(values map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, value := range values {
+		s.attributes[key] = value
+	}
+}
+
+// Remember returns the value of a key, storing the callback result when absent.
+func (s *Store) Remember(key string, callback func() interface{}) interface{} {
+	if value := s.Get(key); value != nil {
+		return value
+	}
+
+	value := callback()
+	s.Put(key, value)
+	return value
+}
+
+// Push appends a value to an array attribute.
+func (s *Store) Push(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list []interface{}
+	switch existing := s.attributes[key].(type) {
+	case []interface{}:
+		list = append(list, existing...)
+	case []string:
+		for _, item := range existing {
+			list = append(list, item)
+		}
+	}
+	s.attributes[key] = append(list, value)
+}
+
+// Increment increments an integer attribute and returns the new value.
+func (s *Store) Increment(key string, amount ...int) int {
+	step := 1
+	if len(amount) > 0 {
+		step = amount[0]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, _ := toInt(s.attributes[key])
+	current += step
+	s.attributes[key] = current
+	return current
+}
+
+// Decrement decrements an integer attribute and returns the new value.
+func (s *Store) Decrement(key string, amount ...int) int {
+	step := 1
+	if len(amount) > 0 {
+		step = amount[0]
+	}
+	return s.Increment(key, -step)
+}
+
+// Flash stores a value for the next request only.
+func (s *Store) Flash(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes[key] = value
+	s.attributes[flashNewKey] = appendUnique(s.keyList(flashNewKey), key)
+	s.attributes[flashOldKey] = removeKeys(s.keyList(flashOldKey), key)
+}
+
+// Now stores a value for the current request only.
+func (s *Store) Now(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes[key] = value
+	s.attributes[flashOldKey] = appendUnique(s.keyList(flashOldKey), key)
+}
+
+// Reflash keeps all flash data for an additional request.
+func (s *Store) Reflash() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes[flashNewKey] = appendUnique(s.keyList(flashNewKey), s.keyList(flashOldKey)...)
+	s.attributes[flashOldKey] = []string{}
+}
+
+// Keep keeps the given flash keys for an additional request.
+func (s *Store) Keep(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes[flashNewKey] = appendUnique(s.keyList(flashNewKey), keys...)
+	s.attributes[flashOldKey] = removeKeys(s.keyList(flashOldKey), keys...)
+}
+
+// FlashInput flashes the given input for the next request.
+func (s *Store) FlashInput(input map[string]interface{}) {
+	s.Flash(oldInputKey, input)
+}
+
+// Remove removes a key and returns its previous value.
+func (s *Store) Remove(key string) interface{} {
+	return s.Pull(key)
+}
+
+// Forget removes one or more keys.
+func (s *Store) Forget(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.attributes, key)
+	}
+}
+
+// Flush removes all attributes.
+func (s *Store) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes = make(map[string]interface{})
+}
+
+// Invalidate flushes the session data and regenerates the ID,
+// destroying the data stored under the old ID.
+func (s *Store) Invalidate() error {
+	s.Flush()
+	return s.Migrate(true)
+}
+
+// Regenerate generates a new session ID and a new CSRF token.
+// Call it after a successful login to prevent session fixation.
+//
+// Parameters:
+//   - destroy: Remove the data stored under the old ID
+func (s *Store) Regenerate(destroy bool) error {
+	if err := s.Migrate(destroy); err != nil {
+		return err
+	}
+
+	s.RegenerateToken()
+	return nil
+}
+
+// Migrate generates a new session ID while keeping all attributes.
+//
+// Parameters:
+//   - destroy: Remove the data stored under the old ID
+func (s *Store) Migrate(destroy bool) error {
+	s.mu.RLock()
+	oldID := s.id
+	locks := s.locks
+	s.mu.RUnlock()
+
+	if destroy {
+		unlock := locks.Lock(oldID)
+		err := s.handler.Destroy(oldID)
+		unlock()
+
+		if err != nil {
+			return fmt.Errorf("session: failed to destroy session: %w", err)
+		}
+	}
+
+	s.setHandlerExists(false)
+
+	s.mu.Lock()
+	s.id = generateRandomString(idLength)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// IsStarted reports whether the session has been started.
+func (s *Store) IsStarted() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.started
+}
+
+// GetName returns the session name.
+func (s *Store) GetName() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.name
+}
+
+// SetName sets the session name.
+func (s *Store) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = name
+}
+
+// GetID returns the current session ID.
+func (s *Store) GetID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.id
+}
+
+// SetID sets the session ID. Invalid IDs are replaced with a fresh one,
+// so a client can never choose its own session ID.
+func (s *Store) SetID(id string) {
+	if !s.IsValidID(id) {
+		id = generateRandomString(idLength)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.id = id
+}
+
+// IsValidID reports whether the given string is a well-formed session ID.
+func (s *Store) IsValidID(id string) bool {
+	if len(id) != idLength {
+		return false
+	}
+	for _, char := range id {
+		if !((char >= '0' && char <= '9') || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
+
+// Token returns the CSRF token value.
+func (s *Store) Token() string {
+	return s.GetString(tokenKey)
+}
+
+// RegenerateToken generates a new CSRF token.
+func (s *Store) RegenerateToken() {
+	s.Put(tokenKey, generateRandomString(idLength))
+}
+
+// PreviousURL returns the previous URL stored in the session.
+func (s *Store) PreviousURL() string {
+	return s.GetString(previousURLKey)
+}
+
+// SetPreviousURL stores the previous URL in the session.
+func (s *Store) SetPreviousURL(url string) {
+	s.Put(previousURLKey, url)
+}
+
+// PasswordConfirmed records the time the user last confirmed their password.
+func (s *Store) PasswordConfirmed() {
+	s.Put(passwordConfirmedKey, time.Now().Unix())
+}
+
+// GetHandler returns the storage handler backing the session.
+func (s *Store) GetHandler() interfaces.HandlerInterface {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.handler
+}
+
+// SetHandler replaces the storage handler backing the session.
+func (s *Store) SetHandler(handler interfaces.HandlerInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handler = handler
+}
+
+// SetExists records whether the session payload exists in storage.
+func (s *Store) SetExists(exists bool) {
+	s.setHandlerExists(exists)
+}
+
+// setHandlerExists forwards existence information to aware handlers.
+func (s *Store) setHandlerExists(exists bool) {
+	if aware, ok := s.handler.(interfaces.ExistenceAwareInterface); ok {
+		aware.SetExists(exists)
+	}
+}
+
+// generateRandomString returns a cryptographically random alphanumeric string.
+func generateRandomString(length int) string {
+	max := big.NewInt(int64(len(idAlphabet)))
+	result := make([]byte, length)
+
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(fmt.Sprintf("session: unable to generate random string: %v", err))
+		}
+		result[i] = idAlphabet[n.Int64()]
+	}
+
+	return string(result)
+}
+
+// toInt converts the numeric representations found in sessions to int.
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// appendUnique appends keys that are not already present.
+func appendUnique(list []string, keys ...string) []string {
+	seen := toSet(list)
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// removeKeys returns the list without the given keys.
+func removeKeys(list []string, keys ...string) []string {
+	removed := toSet(keys)
+	result := make([]string, 0, len(list))
+	for _, key := range list {
+		if !removed[key] {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// Compile-time interface compliance check
+var _ interfaces.SessionInterface = (*Store)(nil)
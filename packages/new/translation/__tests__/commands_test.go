@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	translation "govel/new/translation"
+	"govel/new/translation/console/commands"
+	"govel/new/translation/loaders"
+)
+
+func TestLangMissingCommand_ReportsAndExports(t *testing.T) {
+	source := t.TempDir()
+	writeFiles(t, source, map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\ttranslator.Trans(\"messages.welcome\", nil)\n\t__(\"Log out\")\n}\n",
+	})
+
+	lang := t.TempDir()
+	writeFiles(t, lang, map[string]string{
+		"en/messages.json": `{"welcome": "Welcome"}`,
+		"en.json":          `{"Log out": "Log out"}`,
+		"fr/messages.json": `{"welcome": "Bienvenue", "banner": "Bannière"}`,
+	})
+
+	loader := loaders.NewFileLoader(lang)
+	translator := translation.NewTranslator(loader, "en")
+	translator.SetFallback("en")
+
+	exports := filepath.Join(t.TempDir(), "exports")
+	var output bytes.Buffer
+	command := commands.NewLangMissingCommand(translator, loader, "en").SetOutput(&output)
+	err := command.Execute(context.Background(), []string{"--path=" + source, "--export=json", "--output=" + exports})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	expected := "Found 2 translation call(s) in " + source + "\n" +
+		"[en] All keys are translated\n" +
+		"[fr] 1 missing, 1 unused\n" +
+		"  - missing: Log out\n" +
+		"  - unused:  messages.banner\n" +
+		"Exported 1 key(s) for [fr] to " + filepath.Join(exports, "fr.json") + "\n"
+	if output.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", output.String(), expected)
+	}
+
+	if err := command.Execute(context.Background(), []string{"--export=csv"}); err == nil {
+		t.Error("Expected unsupported export formats to fail")
+	}
+}
+
+func TestLangImportCommand_StoresTranslations(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"fr.json": `{"Log out": "Se déconnecter", "messages.title": "Accueil"}`,
+	})
+	lang := filepath.Join(dir, "lang")
+
+	var output bytes.Buffer
+	command := commands.NewLangImportCommand(lang).SetOutput(&output)
+	if err := command.Execute(context.Background(), []string{"--file=" + filepath.Join(dir, "fr.json"), "--locale=fr"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if expected := "Imported 2 translation(s) for [fr] into " + lang + "\n"; output.String() != expected {
+		t.Errorf("Unexpected output %q", output.String())
+	}
+	translator := translation.NewTranslator(loaders.NewFileLoader(lang), "fr")
+	if got := translator.Trans("messages.title", nil); got != "Accueil" {
+		t.Errorf("Expected the imported translation, got %q", got)
+	}
+
+	if err := command.Execute(context.Background(), nil); err == nil {
+		t.Error("Expected a missing --file to fail")
+	}
+}
+
+func TestLangImportCommand_RejectsTraversalLocales(t *testing.T) {
+	dir := t.TempDir()
+	export := &translation.TranslationExport{
+		SourceLocale: "en",
+		TargetLocale: "../../config/x",
+		Entries:      []translation.TranslationEntry{{Key: "messages.title", Source: "Home", Target: "Accueil"}},
+	}
+	var document bytes.Buffer
+	if err := export.Write(&document, translation.ExportFormatXLIFF); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	writeFiles(t, dir, map[string]string{"fr.xlf": document.String()})
+	lang := filepath.Join(dir, "app", "lang")
+
+	command := commands.NewLangImportCommand(lang).SetOutput(nil)
+	for _, args := range [][]string{
+		{"--file=" + filepath.Join(dir, "fr.xlf")},
+		{"--file=" + filepath.Join(dir, "fr.xlf"), "--locale=../fr"},
+		{"--file=" + filepath.Join(dir, "fr.xlf"), "--locale=fr/../../x"},
+	} {
+		if err := command.Execute(context.Background(), args); err == nil {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "config")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written outside the lang directory, got %v", err)
+	}
+	if _, err := os.Stat(lang); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written, got %v", err)
+	}
+}
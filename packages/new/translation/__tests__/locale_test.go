@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	session "govel/new/session"
+	translation "govel/new/translation"
+	"govel/new/translation/loaders"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	"govel/support/carbon"
+	"govel/support/money"
+	sessionInterfaces "govel/types/interfaces/session"
+)
+
+// fakeRequest implements the parts of RequestInterface read by LocaleDetector.
+type fakeRequest struct {
+	webserverInterfaces.RequestInterface
+	path    string
+	params  map[string]string
+	query   map[string]string
+	cookies map[string]string
+	headers map[string]string
+	context map[string]interface{}
+}
+
+func (r *fakeRequest) Path() string                         { return r.path }
+func (r *fakeRequest) Param(key string) string              { return r.params[key] }
+func (r *fakeRequest) Query(key string, _ ...string) string { return r.query[key] }
+func (r *fakeRequest) Cookie(name string, _ ...string) string {
+	return r.cookies[name]
+}
+func (r *fakeRequest) Header(key string, _ ...string) string { return r.headers[key] }
+func (r *fakeRequest) GetContext(key string) interface{}     { return r.context[key] }
+func (r *fakeRequest) SetContext(key string, value interface{}) {
+	if r.context == nil {
+		r.context = make(map[string]interface{})
+	}
+	r.context[key] = value
+}
+
+// fakeSession implements the session Get read by the "session" source.
+type fakeSession struct {
+	sessionInterfaces.SessionInterface
+	attributes map[string]interface{}
+}
+
+func (s *fakeSession) Get(key string, _ ...interface{}) interface{} { return s.attributes[key] }
+
+func TestParseAcceptLanguage_OrdersByQuality(t *testing.T) {
+	tags := translation.ParseAcceptLanguage("en;q=0.8, fr-CH, de;q=0, fr;q=0.9, *;q=0.5, es;q=0.8")
+	if expected := []string{"fr-CH", "fr", "en", "es"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+func TestLocaleDetector_SourcesInConfiguredOrder(t *testing.T) {
+	detector := translation.NewLocaleDetector("en", "fr", "pt_BR")
+
+	req := &fakeRequest{
+		path:    "/fr/products",
+		query:   map[string]string{"lang": "en"},
+		cookies: map[string]string{"locale": "pt-br"},
+		headers: map[string]string{"Accept-Language": "de-DE, pt;q=0.9"},
+		context: map[string]interface{}{
+			session.RequestContextKey: &fakeSession{attributes: map[string]interface{}{"locale": "en"}},
+		},
+	}
+
+	cases := []struct {
+		order  []string
+		locale string
+		source string
+	}{
+		{[]string{"route", "query"}, "fr", "route"},
+		{[]string{"query", "route"}, "en", "query"},
+		{[]string{"cookie"}, "pt_BR", "cookie"},
+		{[]string{"session", "header"}, "en", "session"},
+		{[]string{"header"}, "pt_BR", "header"},
+	}
+	for _, tc := range cases {
+		locale, source := detector.SetOrder(tc.order...).Detect(req)
+		if locale != tc.locale || source != tc.source {
+			t.Errorf("Order %v: expected %s from %s, got %s from %s", tc.order, tc.locale, tc.source, locale, source)
+		}
+	}
+
+	if locale, _ := detector.SetOrder("route", "query").Detect(&fakeRequest{path: "/products"}); locale != "" {
+		t.Errorf("Expected no locale, got %q", locale)
+	}
+	if locale, ok := detector.Match("fr_CA"); !ok || locale != "fr" {
+		t.Errorf("Expected fr_CA to match fr, got %q", locale)
+	}
+}
+
+func TestTranslator_ForLocaleKeepsFallbackPerView(t *testing.T) {
+	loader := loaders.NewArrayLoader()
+	loader.AddMessages("en", "messages", map[string]interface{}{"bye": "Goodbye", "apples": "{1} :count apple|[2,*] :count apples"})
+	loader.AddMessages("fr", "messages", map[string]interface{}{"bye": "Au revoir", "apples": "{1} :count pomme|[2,*] :count pommes"})
+
+	translator := translation.NewTranslator(loader, "en")
+	translator.SetFallback("en")
+
+	german := translator.ForLocale("de")
+	german.SetFallback("fr")
+	other := translator.ForLocale("de")
+
+	if translator.GetFallback() != "en" || other.GetFallback() != "en" || german.GetFallback() != "fr" {
+		t.Fatalf("Expected the fallback to stay per view, got %q, %q and %q", translator.GetFallback(), other.GetFallback(), german.GetFallback())
+	}
+	if got := german.Trans("messages.bye", nil); got != "Au revoir" {
+		t.Errorf("Expected the view fallback translation, got %q", got)
+	}
+	if got := german.Choice("messages.apples", 2, nil); got != "2 pommes" {
+		t.Errorf("Expected the view fallback plural, got %q", got)
+	}
+	if !german.Has("messages.bye") {
+		t.Error("Expected Has to use the view fallback")
+	}
+	if got := other.Trans("messages.bye", nil); got != "Goodbye" {
+		t.Errorf("Expected other views to keep the translator fallback, got %q", got)
+	}
+	if got := translator.Trans("messages.bye", nil, "de"); got != "Goodbye" {
+		t.Errorf("Expected the translator fallback to be untouched, got %q", got)
+	}
+}
+
+func TestTranslator_ForRequestLeavesApplicationLocaleUntouched(t *testing.T) {
+	loader := loaders.NewArrayLoader()
+	loader.AddMessages("en", "messages", map[string]interface{}{"hello": "Hello"})
+	loader.AddMessages("fr", "messages", map[string]interface{}{"hello": "Bonjour"})
+
+	translator := translation.NewTranslator(loader, "en")
+	translator.SetFallback("en")
+
+	req := &fakeRequest{}
+	translation.SetRequestLocale(req, "fr")
+
+	lang := translator.ForRequest(req)
+	if got := lang.Trans("messages.hello", nil); got != "Bonjour" {
+		t.Errorf("Expected request locale translation, got %q", got)
+	}
+	if got := translator.Trans("messages.hello", nil); got != "Hello" || translator.GetLocale() != "en" {
+		t.Errorf("Expected translator locale to be untouched, got %q (%s)", got, translator.GetLocale())
+	}
+	if got := translator.ForRequest(&fakeRequest{}).GetLocale(); got != "en" {
+		t.Errorf("Expected default locale without detection, got %q", got)
+	}
+
+	date := carbon.CreateFromDate(2024, 2, 5, "UTC")
+	if got := lang.Dates().FormatLocalized(date, "l j F Y"); got != "Lundi 5 Février 2024" {
+		t.Errorf("Unexpected localized date %q", got)
+	}
+	if got := lang.Date(carbon.Now().SubDays(3)).DiffForHumans(); !strings.HasPrefix(got, "il y a 3 jours") {
+		t.Errorf("Unexpected localized difference %q", got)
+	}
+	if got := date.DiffForHumans(carbon.CreateFromDate(2024, 2, 8, "UTC")); !strings.Contains(got, "before") {
+		t.Errorf("Expected the original date to keep its locale, got %q", got)
+	}
+
+	price := money.NewMoneyFromInt(123456, money.EUR)
+	if got := lang.Money(price).Format(true, ","); got != "1\u202f234,56\u00a0€" {
+		t.Errorf("Unexpected localized price %q", got)
+	}
+	if got := price.WithLocale("en").Format(true, ","); got != "€1,234.56" {
+		t.Errorf("Unexpected price %q", got)
+	}
+	if got := money.NewMoneyFromInt(-500, money.USD).WithLocale("de").Format(false, ""); got != "-5,00 USD" {
+		t.Errorf("Unexpected negative price %q", got)
+	}
+}
@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	translation "govel/new/translation"
+	"govel/new/translation/loaders"
+	loggerInterfaces "govel/types/interfaces/logger"
+)
+
+// writeFiles creates files below root.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestKeyScannerAndAuditor_ReportMissingAndUnusedKeys(t *testing.T) {
+	source := t.TempDir()
+	writeFiles(t, source, map[string]string{
+		"main.go": `package main
+
+func main() {
+	translator.Trans("messages.welcome", nil)
+	lang.TransChoice("messages.apples", 3)
+	__("Log out")
+	translator.Trans(dynamicKey, nil)
+}
+`,
+		"views/home.go": "package views\n\nvar title = t.Trans(`messages.title`, nil)\n",
+		"main_test.go":  "package main\n\nvar _ = translator.Trans(\"test.only\", nil)\n",
+		"vendor/x/x.go": "package x\n\nvar _ = translator.Trans(\"vendor.key\", nil)\n",
+	})
+
+	usages, err := translation.NewKeyScanner().ScanDir(source)
+	if err != nil {
+		t.Fatalf("ScanDir failed: %v", err)
+	}
+	expected := []string{"Log out", "messages.apples", "messages.title", "messages.welcome"}
+	if got := translation.UsedKeys(usages); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("UsedKeys = %v, expected %v", got, expected)
+	}
+	if usages[0].Line != 6 || filepath.Base(usages[0].File) != "main.go" {
+		t.Errorf("Unexpected usage position %+v", usages[0])
+	}
+
+	lang := t.TempDir()
+	writeFiles(t, lang, map[string]string{
+		"en/messages.json": `{"welcome": "Welcome", "title": "Home", "apples": "apple|apples", "old": {"banner": "Old"}}`,
+		"fr/messages.json": `{"welcome": "Bienvenue", "old": {"banner": "Ancien"}}`,
+		"fr.json":          `{"Sign in": "Se connecter"}`,
+	})
+
+	loader := loaders.NewFileLoader(lang)
+	translator := translation.NewTranslator(loader, "en")
+	translator.SetFallback("en")
+
+	audits, err := translation.NewTranslationAuditor(translator, loader, "en").
+		Audit(translation.UsedKeys(usages))
+	if err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if len(audits) != 2 || audits[0].Locale != "en" || audits[1].Locale != "fr" {
+		t.Fatalf("Expected audits for en and fr, got %+v", audits)
+	}
+
+	if len(audits[0].Missing) != 0 || !reflect.DeepEqual(audits[0].Unused, []string{"messages.old.banner"}) {
+		t.Errorf("Unexpected en audit %+v", audits[0])
+	}
+	if !reflect.DeepEqual(audits[1].Missing, []string{"Log out", "messages.apples", "messages.title"}) {
+		t.Errorf("Unexpected fr missing keys %v", audits[1].Missing)
+	}
+	if !reflect.DeepEqual(audits[1].Unused, []string{"Sign in", "messages.old.banner"}) {
+		t.Errorf("Unexpected fr unused keys %v", audits[1].Unused)
+	}
+
+	ignored, err := translation.NewTranslationAuditor(translator, loader, "en").
+		Ignore("messages.old").
+		Audit(translation.UsedKeys(usages), "en")
+	if err != nil || len(ignored[0].Unused) != 0 {
+		t.Errorf("Expected ignored prefix to hide unused keys, got %+v (%v)", ignored, err)
+	}
+
+	entries := translation.NewTranslationAuditor(translator, loader, "en").Entries(audits[1])
+	if entries[0].Source != "Log out" || entries[2].Source != "Home" {
+		t.Errorf("Unexpected export entries %+v", entries)
+	}
+}
+
+func TestTranslationExport_RoundTripAndWriteTranslations(t *testing.T) {
+	export := &translation.TranslationExport{
+		SourceLocale: "en",
+		TargetLocale: "fr",
+		Entries: []translation.TranslationEntry{
+			{Key: "Log out", Source: "Log out", Target: "Se déconnecter"},
+			{Key: "messages.nested.title", Source: "<b>Home</b>", Target: "<b>Accueil</b>"},
+			{Key: "courier::mail.sent", Source: "Sent", Target: "Envoyé"},
+			{Key: "messages.pending", Source: "Pending"},
+		},
+	}
+
+	for _, format := range []string{translation.ExportFormatJSON, translation.ExportFormatXLIFF} {
+		var buffer bytes.Buffer
+		if err := export.Write(&buffer, format); err != nil {
+			t.Fatalf("Write(%s) failed: %v", format, err)
+		}
+		if format == translation.ExportFormatXLIFF && !strings.Contains(buffer.String(), `<trans-unit id="courier::mail.sent">`) {
+			t.Errorf("Unexpected XLIFF document:\n%s", buffer.String())
+		}
+
+		imported, err := translation.ReadTranslationExport(&buffer, format)
+		if err != nil {
+			t.Fatalf("ReadTranslationExport(%s) failed: %v", format, err)
+		}
+		if imported.SourceLocale != "en" || imported.TargetLocale != "fr" || len(imported.Entries) != 4 {
+			t.Fatalf("Unexpected %s import %+v", format, imported)
+		}
+		if imported.Entries[2] != export.Entries[1] {
+			t.Errorf("Expected %s round trip of %+v, got %+v", format, export.Entries[1], imported.Entries[2])
+		}
+	}
+
+	flat, err := translation.ReadTranslationExport(strings.NewReader(`{"messages.title": "Accueil"}`), translation.FormatFromPath("fr.json"))
+	if err != nil || len(flat.Entries) != 1 || flat.Entries[0].Target != "Accueil" {
+		t.Errorf("Expected flat JSON import, got %+v (%v)", flat, err)
+	}
+
+	lang := t.TempDir()
+	writeFiles(t, lang, map[string]string{"fr/messages.json": `{"welcome": "Bienvenue"}`})
+
+	written, err := translation.WriteTranslations(lang, "fr", export.Entries)
+	if err != nil || written != 3 {
+		t.Fatalf("WriteTranslations = %d, %v", written, err)
+	}
+
+	loader := loaders.NewFileLoader(lang)
+	translator := translation.NewTranslator(loader, "fr")
+	translator.AddNamespace("courier", t.TempDir())
+	for key, expected := range map[string]string{
+		"Log out":               "Se déconnecter",
+		"messages.welcome":      "Bienvenue",
+		"messages.nested.title": "<b>Accueil</b>",
+		"courier::mail.sent":    "Envoyé",
+	} {
+		if got := translator.Trans(key, nil); got != expected {
+			t.Errorf("Trans(%q) = %q, expected %q", key, got, expected)
+		}
+	}
+}
+
+// recordingLogger records warnings and their fields.
+type recordingLogger struct {
+	loggerInterfaces.LoggerInterface
+	fields   map[string]interface{}
+	warnings *[]string
+}
+
+func (l *recordingLogger) WithFields(fields map[string]interface{}) loggerInterfaces.LoggerInterface {
+	return &recordingLogger{fields: fields, warnings: l.warnings}
+}
+
+func (l *recordingLogger) Warn(format string, args ...interface{}) {
+	*l.warnings = append(*l.warnings, l.fields["locale"].(string)+" "+l.fields["key"].(string))
+}
+
+func TestMissingKeyLogger_RecordsRuntimeMissesOnce(t *testing.T) {
+	var warnings []string
+	misses := translation.NewMissingKeyLogger(&recordingLogger{warnings: &warnings})
+
+	translator := newTranslator()
+	translator.HandleMissingKeysUsing(misses.Handle)
+
+	translator.Trans("messages.absent", nil)
+	translator.Trans("messages.absent", nil)
+	translator.Trans("messages.absent", nil, "pt")
+	translator.Trans("messages.welcome", nil)
+	translator.Has("messages.unknown")
+	translator.Choice("messages.apples", 2, nil)
+
+	if !reflect.DeepEqual(warnings, []string{"en messages.absent", "pt messages.absent"}) {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+
+	expected := []translation.MissingKey{
+		{Key: "messages.absent", Locale: "en", Count: 2},
+		{Key: "messages.absent", Locale: "pt", Count: 1},
+	}
+	if got := misses.Misses(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Misses = %+v, expected %+v", got, expected)
+	}
+}
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	translation "govel/new/translation"
+	"govel/new/translation/lang/en"
+	"govel/new/translation/loaders"
+)
+
+func TestMessageSelector_ConditionsAndPluralRules(t *testing.T) {
+	selector := translation.NewMessageSelector()
+
+	cases := []struct {
+		line     string
+		number   int
+		locale   string
+		expected string
+	}{
+		{"{0} No apples|[1,19] Some apples|[20,*] Many apples", 0, "en", "No apples"},
+		{"{0} No apples|[1,19] Some apples|[20,*] Many apples", 7, "en", "Some apples"},
+		{"{0} No apples|[1,19] Some apples|[20,*] Many apples", 20, "en", "Many apples"},
+		{"[*,0] Nothing|[1,*] Something", -3, "en", "Nothing"},
+		{"apple|apples", 1, "en", "apple"},
+		{"apple|apples", 0, "en", "apples"},
+		{"pomme|pommes", 0, "fr", "pomme"},
+		{"{1} :count item|[2,*] :count items", 5, "en", ":count items"},
+		{"maçã|maçãs", 0, "pt_BR", "maçã"},
+		{"maçã|maçãs", 0, "pt", "maçãs"},
+		{"яблоко|яблока|яблок", 22, "ru", "яблока"},
+		{"яблоко|яблока|яблок", 11, "ru_RU", "яблок"},
+		{"single", 42, "en", "single"},
+	}
+
+	for _, tc := range cases {
+		if got := selector.Choose(tc.line, tc.number, tc.locale); got != tc.expected {
+			t.Errorf("Choose(%q, %d, %s) = %q, expected %q", tc.line, tc.number, tc.locale, got, tc.expected)
+		}
+	}
+}
+
+func newTranslator() *translation.Translator {
+	loader := loaders.NewArrayLoader()
+	loader.AddMessages("en", "messages", map[string]interface{}{
+		"welcome": "Welcome, :name!",
+		"shout":   ":NAME and :Name",
+		"apples":  "{0} There are none|{1} There is one|[2,*] There are :count",
+		"nested":  map[string]interface{}{"deep": "Deep :name"},
+		"only_en": "English only",
+	})
+	loader.AddMessages("pt", "messages", map[string]interface{}{
+		"welcome": "Bem-vindo, :name!",
+		"apples":  "maçã|maçãs",
+	})
+	loader.AddMessages("pt_BR", "messages", map[string]interface{}{
+		"welcome": "Seja bem-vindo, :name!",
+	})
+	loader.AddMessages("pt_BR", "*", map[string]interface{}{
+		"Log out": "Sair",
+	})
+	loader.AddMessages("en", "mail", map[string]interface{}{
+		"sent": "Sent by :app",
+	}, "courier")
+
+	translator := translation.NewTranslator(loader, "en")
+	translator.SetFallback("en")
+	return translator
+}
+
+func TestTranslator_TransWithReplacementsAndFallbackChain(t *testing.T) {
+	translator := newTranslator()
+	name := map[string]interface{}{"name": "taylor"}
+
+	if got := translator.Trans("messages.welcome", name); got != "Welcome, taylor!" {
+		t.Errorf("Unexpected translation %q", got)
+	}
+	if got := translator.Trans("messages.shout", name); got != "TAYLOR and Taylor" {
+		t.Errorf("Expected case variants, got %q", got)
+	}
+	if got := translator.Trans("messages.nested.deep", name); got != "Deep taylor" {
+		t.Errorf("Expected nested lookup, got %q", got)
+	}
+	if got := translator.Trans("courier::mail.sent", map[string]interface{}{"app": "GoVel"}); got != "Sent by GoVel" {
+		t.Errorf("Expected namespaced translation, got %q", got)
+	}
+	if got := translator.Trans("messages.missing", nil); got != "messages.missing" {
+		t.Errorf("Expected missing key to be returned, got %q", got)
+	}
+
+	if chain := translator.LocaleChain("pt_BR"); !reflect.DeepEqual(chain, []string{"pt_BR", "pt", "en"}) {
+		t.Errorf("Unexpected locale chain %v", chain)
+	}
+	if got := translator.Trans("messages.welcome", name, "pt_BR"); got != "Seja bem-vindo, taylor!" {
+		t.Errorf("Expected regional translation, got %q", got)
+	}
+	if got := translator.Trans("messages.apples", nil, "pt_BR"); got != "maçã|maçãs" {
+		t.Errorf("Expected parent locale translation, got %q", got)
+	}
+	if got := translator.Trans("messages.only_en", nil, "pt_BR"); got != "English only" {
+		t.Errorf("Expected fallback translation, got %q", got)
+	}
+	if got := translator.Trans("Log out", nil, "pt_BR"); got != "Sair" {
+		t.Errorf("Expected JSON translation, got %q", got)
+	}
+
+	if !translator.Has("messages.only_en", "pt") || translator.HasForLocale("messages.only_en", "pt") {
+		t.Error("Expected Has to fall back and HasForLocale not to")
+	}
+	if lines, ok := translator.Get("messages.nested", name, "", true).(map[string]interface{}); !ok || lines["deep"] != "Deep taylor" {
+		t.Errorf("Expected group lines, got %v", lines)
+	}
+
+	translator.AddLines(map[string]string{"messages.added": "Added at runtime"}, "en")
+	if got := translator.Trans("messages.added", nil); got != "Added at runtime" {
+		t.Errorf("Expected runtime line, got %q", got)
+	}
+	if got := translator.Trans("messages.welcome", name); got != "Welcome, taylor!" {
+		t.Errorf("Expected loaded lines to be kept, got %q", got)
+	}
+}
+
+func TestTranslator_ChoiceUsesRulesOfSelectedLocale(t *testing.T) {
+	translator := newTranslator()
+
+	if got := translator.TransChoice("messages.apples", 0); got != "There are none" {
+		t.Errorf("Unexpected choice %q", got)
+	}
+	if got := translator.TransChoice("messages.apples", 12); got != "There are 12" {
+		t.Errorf("Unexpected choice %q", got)
+	}
+	// pt rules: 0 is plural, unlike pt_BR whose line is inherited from pt
+	if got := translator.Choice("messages.apples", 0, nil, "pt_BR"); got != "maçãs" {
+		t.Errorf("Expected rules of the pt line, got %q", got)
+	}
+	if got := translator.Choice("messages.apples", 1, nil, "pt"); got != "maçã" {
+		t.Errorf("Unexpected choice %q", got)
+	}
+
+	message := translation.NewPotentiallyTranslatedString("messages.welcome", translator)
+	if got := message.Translate(map[string]interface{}{"name": "Abigail"}).String(); got != "Welcome, Abigail!" {
+		t.Errorf("Unexpected potentially translated string %q", got)
+	}
+}
+
+func TestTranslator_ReplacementsWrapTagsAndPreferLongestPlaceholder(t *testing.T) {
+	translator := newTranslator()
+
+	got := translator.MakeReplacements("Hi :name_full (:name), <b>read</b> the terms", map[string]interface{}{
+		"name":      "Tay",
+		"name_full": "Taylor Otwell",
+		"b":         func(text string) string { return "**" + strings.ToUpper(text) + "**" },
+	})
+	if got != "Hi Taylor Otwell (Tay), **READ** the terms" {
+		t.Errorf("Unexpected replacements %q", got)
+	}
+}
+
+func TestFileLoader_JSONGroupsNamespacesAndDefaults(t *testing.T) {
+	root := t.TempDir()
+	packageDir := t.TempDir()
+
+	files := map[string]string{
+		filepath.Join(root, "fr.json"):                              `{"Log out": "Se déconnecter"}`,
+		filepath.Join(root, "en", "validation.json"):                `{"required": "Please fill in :attribute."}`,
+		filepath.Join(root, "fr", "validation.json"):                `{"required": "Le champ :attribute est obligatoire.", "min": {"string": "Au moins :min caractères."}}`,
+		filepath.Join(packageDir, "en", "mail.json"):                `{"sent": "Sent", "queued": "Queued"}`,
+		filepath.Join(root, "vendor", "courier", "en", "mail.json"): `{"sent": "Delivered"}`,
+		filepath.Join(root, "de", "broken.json"):                    `{"oops": `,
+	}
+	for path, contents := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader := loaders.NewFileLoader(root).AddDefaults("en", en.Groups())
+	translator := translation.NewTranslator(loader, "fr")
+	translator.SetFallback("en")
+	translator.AddNamespace("courier", packageDir)
+
+	attribute := map[string]interface{}{"attribute": "email", "min": 8}
+	if got := translator.Trans("validation.required", attribute); got != "Le champ email est obligatoire." {
+		t.Errorf("Unexpected file translation %q", got)
+	}
+	if got := translator.Trans("validation.min.string", attribute); got != "Au moins 8 caractères." {
+		t.Errorf("Unexpected nested file translation %q", got)
+	}
+	if got := translator.Trans("validation.required", attribute, "en"); got != "Please fill in email." {
+		t.Errorf("Expected file to override defaults, got %q", got)
+	}
+	if got := translator.Trans("validation.email", attribute); got != "The email field must be a valid email address." {
+		t.Errorf("Expected compiled default through fallback, got %q", got)
+	}
+	if got := translator.Trans("Log out", nil); got != "Se déconnecter" {
+		t.Errorf("Unexpected JSON translation %q", got)
+	}
+	if got := translator.Trans("courier::mail.sent", nil); got != "Delivered" {
+		t.Errorf("Expected vendor override, got %q", got)
+	}
+	if got := translator.Trans("courier::mail.queued", nil); got != "Queued" {
+		t.Errorf("Expected package translation, got %q", got)
+	}
+
+	if err := translator.Load("*", "broken", "de"); err == nil || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("Expected invalid JSON error, got %v", err)
+	}
+}
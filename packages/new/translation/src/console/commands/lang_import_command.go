@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	translation "govel/new/translation"
+)
+
+// LangImportCommand stores translations returned by translators into the
+// language files (lang:import). The format follows the file extension
+// (.xlf/.xliff or .json) and the locale is read from the file unless given.
+//
+// Usage:
+//
+//	lang:import --file=lang/exports/fr.xlf [--locale=fr] [--path=lang]
+type LangImportCommand struct {
+	// path is the default language directory
+	path string
+
+	// output receives the command's report, os.Stdout by default
+	output io.Writer
+}
+
+// NewLangImportCommand creates a new lang:import command writing to the
+// language directory path.
+func NewLangImportCommand(path string) *LangImportCommand {
+	if path == "" {
+		path = "lang"
+	}
+	return &LangImportCommand{path: path, output: os.Stdout}
+}
+
+// SetOutput sets the writer receiving the command's report.
+func (cmd *LangImportCommand) SetOutput(output io.Writer) *LangImportCommand {
+	if output == nil {
+		output = io.Discard
+	}
+	cmd.output = output
+	return cmd
+}
+
+// Execute imports the file and reports the number of stored translations.
+func (cmd *LangImportCommand) Execute(ctx context.Context, args []string) error {
+	path := cmd.path
+	var file, locale string
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--file="):
+			file = strings.TrimPrefix(arg, "--file=")
+		case strings.HasPrefix(arg, "--locale="):
+			locale = strings.TrimPrefix(arg, "--locale=")
+		case strings.HasPrefix(arg, "--path="):
+			path = strings.TrimPrefix(arg, "--path=")
+		}
+	}
+
+	if file == "" {
+		return fmt.Errorf("the --file option is required")
+	}
+
+	reader, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer reader.Close()
+
+	export, err := translation.ReadTranslationExport(reader, translation.FormatFromPath(file))
+	if err != nil {
+		return err
+	}
+
+	if locale == "" {
+		locale = export.TargetLocale
+	}
+	if locale == "" {
+		return fmt.Errorf("the import file has no target locale, pass --locale")
+	}
+
+	written, err := translation.WriteTranslations(path, locale, export.Entries)
+	if err != nil {
+		return fmt.Errorf("failed to store translations: %w", err)
+	}
+
+	fmt.Fprintf(cmd.output, "Imported %d translation(s) for [%s] into %s\n", written, locale, path)
+	return nil
+}
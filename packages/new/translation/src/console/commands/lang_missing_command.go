@@ -0,0 +1,152 @@
+// Package commands contains the console commands shipped with the translation package.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	translation "govel/new/translation"
+	"govel/new/translation/loaders"
+)
+
+// LangMissingCommand reports translation keys used in Go source but missing
+// from a locale's files, and keys defined in the files but never used
+// (lang:missing). With --export, the missing keys of every audited locale
+// are written for translators, one file per locale.
+//
+// Usage:
+//
+//	lang:missing [--path=.] [--locale=fr]... [--ignore=validation,passwords]
+//	             [--export=json|xliff] [--output=lang/exports]
+type LangMissingCommand struct {
+	translator *translation.Translator
+	loader     *loaders.FileLoader
+
+	// source is the locale source texts are written in
+	source string
+
+	// output receives the command's report, os.Stdout by default
+	output io.Writer
+}
+
+// NewLangMissingCommand creates a new lang:missing command.
+//
+// Parameters:
+//   - translator: Translator resolving keys
+//   - loader: Loader of the application's language files
+//   - sourceLocale: Locale source texts are written in, usually app.fallback_locale
+func NewLangMissingCommand(translator *translation.Translator, loader *loaders.FileLoader, sourceLocale string) *LangMissingCommand {
+	return &LangMissingCommand{translator: translator, loader: loader, source: sourceLocale, output: os.Stdout}
+}
+
+// SetOutput sets the writer receiving the command's report.
+func (cmd *LangMissingCommand) SetOutput(output io.Writer) *LangMissingCommand {
+	if output == nil {
+		output = io.Discard
+	}
+	cmd.output = output
+	return cmd
+}
+
+// Execute scans the source tree, prints the audit and exports missing keys.
+func (cmd *LangMissingCommand) Execute(ctx context.Context, args []string) error {
+	root := "."
+	exportDir := filepath.Join("lang", "exports")
+	var locales, ignore []string
+	var format string
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--path="):
+			root = strings.TrimPrefix(arg, "--path=")
+		case strings.HasPrefix(arg, "--locale="):
+			locales = append(locales, strings.TrimPrefix(arg, "--locale="))
+		case strings.HasPrefix(arg, "--ignore="):
+			ignore = append(ignore, strings.Split(strings.TrimPrefix(arg, "--ignore="), ",")...)
+		case strings.HasPrefix(arg, "--export="):
+			format = strings.TrimPrefix(arg, "--export=")
+		case strings.HasPrefix(arg, "--output="):
+			exportDir = strings.TrimPrefix(arg, "--output=")
+		}
+	}
+
+	if format != "" && format != translation.ExportFormatJSON && format != translation.ExportFormatXLIFF {
+		return fmt.Errorf("unsupported export format [%s], expected json or xliff", format)
+	}
+
+	usages, err := translation.NewKeyScanner().ScanDir(root)
+	if err != nil {
+		return fmt.Errorf("failed to scan source: %w", err)
+	}
+
+	auditor := translation.NewTranslationAuditor(cmd.translator, cmd.loader, cmd.source).Ignore(ignore...)
+	audits, err := auditor.Audit(translation.UsedKeys(usages), locales...)
+	if err != nil {
+		return fmt.Errorf("failed to audit translations: %w", err)
+	}
+
+	fmt.Fprintf(cmd.output, "Found %d translation call(s) in %s\n", len(usages), root)
+	for _, audit := range audits {
+		if len(audit.Missing) == 0 && len(audit.Unused) == 0 {
+			fmt.Fprintf(cmd.output, "[%s] All keys are translated\n", audit.Locale)
+			continue
+		}
+
+		fmt.Fprintf(cmd.output, "[%s] %d missing, %d unused\n", audit.Locale, len(audit.Missing), len(audit.Unused))
+		for _, key := range audit.Missing {
+			fmt.Fprintf(cmd.output, "  - missing: %s\n", key)
+		}
+		for _, key := range audit.Unused {
+			fmt.Fprintf(cmd.output, "  - unused:  %s\n", key)
+		}
+	}
+
+	if format == "" {
+		return nil
+	}
+
+	extension := ".json"
+	if format == translation.ExportFormatXLIFF {
+		extension = ".xlf"
+	}
+	if err := os.MkdirAll(exportDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	for _, audit := range audits {
+		if len(audit.Missing) == 0 {
+			continue
+		}
+
+		export := &translation.TranslationExport{
+			SourceLocale: cmd.source,
+			TargetLocale: audit.Locale,
+			Entries:      auditor.Entries(audit),
+		}
+
+		path := filepath.Join(exportDir, audit.Locale+extension)
+		if err := writeExport(path, export, format); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.output, "Exported %d key(s) for [%s] to %s\n", len(export.Entries), audit.Locale, path)
+	}
+	return nil
+}
+
+// writeExport writes export to path in format.
+func writeExport(path string, export *translation.TranslationExport, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	if err := export.Write(file, format); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package translation
+
+import (
+	langInterfaces "govel/types/interfaces/lang"
+)
+
+// CreatesPotentiallyTranslatedStrings is embedded by types, such as
+// validation rules, that report messages which may be translation keys.
+//
+// Example:
+//
+//	type Uppercase struct {
+//		translation.CreatesPotentiallyTranslatedStrings
+//	}
+//
+//	func (r *Uppercase) Message() string {
+//		return r.PotentiallyTranslated("validation.uppercase").
+//			Translate(map[string]interface{}{"attribute": "name"}).String()
+//	}
+type CreatesPotentiallyTranslatedStrings struct {
+	// Translator resolves messages as keys; messages stay literal when nil
+	Translator langInterfaces.LanguageInterface
+}
+
+// SetTranslator sets the translator used for messages.
+func (c *CreatesPotentiallyTranslatedStrings) SetTranslator(translator langInterfaces.LanguageInterface) {
+	c.Translator = translator
+}
+
+// PotentiallyTranslated wraps a message that may be a translation key.
+func (c *CreatesPotentiallyTranslatedStrings) PotentiallyTranslated(message string) *PotentiallyTranslatedString {
+	return NewPotentiallyTranslatedString(message, c.Translator)
+}
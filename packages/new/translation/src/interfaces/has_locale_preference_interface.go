@@ -0,0 +1,9 @@
+package interfaces
+
+// HasLocalePreferenceInterface is implemented by entities, usually users or
+// notifiables, that prefer a specific locale for messages sent to them.
+// It mirrors Laravel's Illuminate\Contracts\Translation\HasLocalePreference.
+type HasLocalePreferenceInterface interface {
+	// PreferredLocale returns the preferred locale, or "" for no preference.
+	PreferredLocale() string
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+// LoaderInterface loads translation lines for a locale, group and namespace.
+// It mirrors Laravel's Illuminate\Contracts\Translation\Loader contract.
+//
+// The group "*" with namespace "*" denotes JSON string translations, whose
+// keys are the untranslated strings themselves.
+type LoaderInterface interface {
+	// Load returns the messages of a group in the given locale.
+	//
+	// Parameters:
+	//   - locale: Locale to load, e.g. "en" or "pt_BR"
+	//   - group: Group name, e.g. "validation", or "*" for JSON strings
+	//   - namespace: Package namespace, or "*" for application translations
+	//
+	// Returns:
+	//   - map[string]interface{}: Nested messages; values are strings or maps
+	//   - error: Any error raised while reading or decoding translation files
+	Load(locale, group, namespace string) (map[string]interface{}, error)
+
+	// AddNamespace registers a directory holding a package's translations.
+	AddNamespace(namespace, hint string)
+
+	// AddJSONPath registers an additional directory holding JSON translations.
+	AddJSONPath(path string)
+
+	// Namespaces returns the registered namespaces and their directories.
+	Namespaces() map[string]string
+}
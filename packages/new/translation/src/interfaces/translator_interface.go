@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	langInterfaces "govel/types/interfaces/lang"
+)
+
+// TranslatorInterface extends the framework-wide translator contract with
+// loader management. It is implemented by translation.Translator.
+type TranslatorInterface interface {
+	langInterfaces.LanguageInterface
+
+	// Load loads a group of translations into memory ahead of lookups.
+	//
+	// Returns:
+	//   - error: Any error raised by the loader
+	Load(namespace, group, locale string) error
+
+	// AddLines adds translation lines at runtime. Keys are "group.item"
+	// for group translations.
+	AddLines(lines map[string]string, locale string, namespace ...string)
+
+	// AddNamespace registers a directory holding a package's translations.
+	AddNamespace(namespace, hint string)
+
+	// AddJSONPath registers an additional directory holding JSON translations.
+	AddJSONPath(path string)
+
+	// ParseKey splits a key into its namespace, group and item.
+	ParseKey(key string) (namespace, group, item string)
+
+	// GetLoader returns the loader used by the translator.
+	GetLoader() LoaderInterface
+}
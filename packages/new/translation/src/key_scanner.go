@@ -0,0 +1,160 @@
+package translation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTranslationFunctions are the function and method names whose first
+// argument KeyScanner reads as a translation key.
+var DefaultTranslationFunctions = []string{"Trans", "TransChoice", "Choice", "__"}
+
+// KeyUsage is a translation key found in Go source.
+type KeyUsage struct {
+	// Key is the translation key
+	Key string
+
+	// File is the path of the source file
+	File string
+
+	// Line is the line of the call
+	Line int
+}
+
+// KeyScanner finds the translation keys used in Go source by looking for
+// calls to translation functions with a string literal as first argument:
+//
+//	translator.Trans("messages.welcome", nil)
+//	lang.TransChoice("cart.items", count)
+//	__("Log out")
+//
+// Keys built at runtime ("validation." + rule) cannot be detected.
+type KeyScanner struct {
+	// functions are the function and method names to look for
+	functions map[string]bool
+}
+
+// NewKeyScanner creates a scanner for the given function names,
+// DefaultTranslationFunctions when none are given.
+func NewKeyScanner(functions ...string) *KeyScanner {
+	if len(functions) == 0 {
+		functions = DefaultTranslationFunctions
+	}
+
+	scanner := &KeyScanner{functions: make(map[string]bool, len(functions))}
+	for _, name := range functions {
+		scanner.functions[name] = true
+	}
+	return scanner
+}
+
+// ScanDir scans the Go files below root, skipping test files, vendor,
+// testdata and hidden directories. Usages are sorted by key, file and line.
+func (s *KeyScanner) ScanDir(root string) ([]KeyUsage, error) {
+	var usages []KeyUsage
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		found, err := s.ScanFile(path)
+		if err != nil {
+			return err
+		}
+		usages = append(usages, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].Key != usages[j].Key {
+			return usages[i].Key < usages[j].Key
+		}
+		if usages[i].File != usages[j].File {
+			return usages[i].File < usages[j].File
+		}
+		return usages[i].Line < usages[j].Line
+	})
+	return usages, nil
+}
+
+// ScanFile scans a single Go file.
+func (s *KeyScanner) ScanFile(path string) ([]KeyUsage, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse [%s]: %w", path, err)
+	}
+
+	var usages []KeyUsage
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || !s.functions[callName(call.Fun)] {
+			return true
+		}
+
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+
+		key, err := strconv.Unquote(literal.Value)
+		if err != nil || key == "" {
+			return true
+		}
+
+		usages = append(usages, KeyUsage{
+			Key:  key,
+			File: path,
+			Line: fset.Position(call.Pos()).Line,
+		})
+		return true
+	})
+	return usages, nil
+}
+
+// callName returns the name of the called function or method.
+func callName(fun ast.Expr) string {
+	switch fn := fun.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+// UsedKeys returns the distinct keys of usages, sorted.
+func UsedKeys(usages []KeyUsage) []string {
+	seen := make(map[string]bool, len(usages))
+	keys := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		if !seen[usage.Key] {
+			seen[usage.Key] = true
+			keys = append(keys, usage.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,8 @@
+package en
+
+// Auth holds the messages used during authentication.
+var Auth = map[string]interface{}{
+	"failed":   "These credentials do not match our records.",
+	"password": "The provided password is incorrect.",
+	"throttle": "Too many login attempts. Please try again in :seconds seconds.",
+}
@@ -0,0 +1,14 @@
+// Package en holds the framework's default English translations. They are
+// registered with the file loader and can be overridden by lang/en/*.json
+// files of the application.
+package en
+
+// Groups returns every default English group keyed by group name.
+func Groups() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"auth":       Auth,
+		"pagination": Pagination,
+		"passwords":  Passwords,
+		"validation": Validation,
+	}
+}
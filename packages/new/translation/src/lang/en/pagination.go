@@ -0,0 +1,7 @@
+package en
+
+// Pagination holds the labels of the paginator links.
+var Pagination = map[string]interface{}{
+	"previous": "&laquo; Previous",
+	"next":     "Next &raquo;",
+}
@@ -0,0 +1,10 @@
+package en
+
+// Passwords holds the messages returned by the password broker.
+var Passwords = map[string]interface{}{
+	"reset":     "Your password has been reset.",
+	"sent":      "We have emailed your password reset link.",
+	"throttled": "Please wait before retrying.",
+	"token":     "This password reset token is invalid.",
+	"user":      "We can't find a user with that email address.",
+}
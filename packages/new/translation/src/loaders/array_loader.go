@@ -0,0 +1,118 @@
+// Package loaders provides translation loaders: an in-memory ArrayLoader and
+// a FileLoader reading JSON files and Go-defined default translations.
+package loaders
+
+import (
+	"sync"
+
+	"govel/new/translation/interfaces"
+)
+
+// ArrayLoader keeps translations in memory. It is useful for tests and for
+// packages registering translations programmatically.
+type ArrayLoader struct {
+	mu sync.RWMutex
+
+	// messages is keyed by namespace, group and locale
+	messages map[string]map[string]map[string]map[string]interface{}
+
+	// hints holds the registered namespaces
+	hints map[string]string
+}
+
+// NewArrayLoader creates an empty in-memory loader.
+func NewArrayLoader() *ArrayLoader {
+	return &ArrayLoader{
+		messages: make(map[string]map[string]map[string]map[string]interface{}),
+		hints:    make(map[string]string),
+	}
+}
+
+// AddMessages adds messages to the loader, merging them over existing ones.
+//
+// Parameters:
+//   - locale: Locale of the messages
+//   - group: Group name, or "*" for JSON string translations
+//   - messages: Nested messages; values are strings or maps
+//   - namespace: Optional package namespace, "*" by default
+//
+// Example:
+//
+//	loader.AddMessages("en", "messages", map[string]interface{}{
+//		"welcome": "Welcome, :name!",
+//	})
+func (l *ArrayLoader) AddMessages(locale, group string, messages map[string]interface{}, namespace ...string) *ArrayLoader {
+	ns := "*"
+	if len(namespace) > 0 && namespace[0] != "" {
+		ns = namespace[0]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.messages[ns] == nil {
+		l.messages[ns] = make(map[string]map[string]map[string]interface{})
+	}
+	if l.messages[ns][group] == nil {
+		l.messages[ns][group] = make(map[string]map[string]interface{})
+	}
+	l.messages[ns][group][locale] = Merge(l.messages[ns][group][locale], messages)
+	return l
+}
+
+// Load returns the messages of a group in the given locale.
+func (l *ArrayLoader) Load(locale, group, namespace string) (map[string]interface{}, error) {
+	if namespace == "" {
+		namespace = "*"
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return Merge(nil, l.messages[namespace][group][locale]), nil
+}
+
+// AddNamespace registers a namespace. The hint is only recorded.
+func (l *ArrayLoader) AddNamespace(namespace, hint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hints[namespace] = hint
+}
+
+// AddJSONPath is a no-op for in-memory translations.
+func (l *ArrayLoader) AddJSONPath(path string) {}
+
+// Namespaces returns the registered namespaces.
+func (l *ArrayLoader) Namespaces() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	namespaces := make(map[string]string, len(l.hints))
+	for namespace, hint := range l.hints {
+		namespaces[namespace] = hint
+	}
+	return namespaces
+}
+
+// Merge deep-merges src over dst and returns the result. Nested maps are
+// merged recursively, other values in src replace those in dst. Neither
+// argument is modified.
+func Merge(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for key, value := range dst {
+		merged[key] = value
+	}
+
+	for key, value := range src {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			merged[key] = value
+			continue
+		}
+		existing, _ := merged[key].(map[string]interface{})
+		merged[key] = Merge(existing, nested)
+	}
+	return merged
+}
+
+// Ensure ArrayLoader implements the LoaderInterface
+var _ interfaces.LoaderInterface = (*ArrayLoader)(nil)
@@ -0,0 +1,284 @@
+package loaders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"govel/new/translation/interfaces"
+)
+
+// FileLoader loads translations from language directories.
+//
+// Each directory follows Laravel's lang layout with JSON files:
+//
+//	lang/
+//	  en.json                      JSON string translations ("*" group)
+//	  en/validation.json           Group translations, nested objects allowed
+//	  vendor/courier/en/mail.json  Overrides of the "courier" namespace
+//
+// Later directories override earlier ones. Translations compiled into the
+// binary (see the lang/en package) are registered with AddDefaults and are
+// overridden by files.
+type FileLoader struct {
+	mu sync.RWMutex
+
+	// paths are the language directories, in increasing priority
+	paths []string
+
+	// jsonPaths are additional directories holding JSON string translations
+	jsonPaths []string
+
+	// hints maps namespaces to their translation directories
+	hints map[string]string
+
+	// defaults holds Go-defined translations keyed by namespace, locale and group
+	defaults map[string]map[string]map[string]map[string]interface{}
+}
+
+// NewFileLoader creates a loader reading the given language directories.
+//
+// Example:
+//
+//	loader := loaders.NewFileLoader(filepath.Join(app.BasePath(), "lang"))
+//	loader.AddDefaults("en", en.Groups())
+func NewFileLoader(paths ...string) *FileLoader {
+	return &FileLoader{
+		paths:    paths,
+		hints:    make(map[string]string),
+		defaults: make(map[string]map[string]map[string]map[string]interface{}),
+	}
+}
+
+// AddDefaults registers translations compiled into the binary. Files with
+// the same locale and group are merged over them.
+//
+// Parameters:
+//   - locale: Locale of the translations
+//   - groups: Messages keyed by group name
+//   - namespace: Optional package namespace, "*" by default
+func (l *FileLoader) AddDefaults(locale string, groups map[string]map[string]interface{}, namespace ...string) *FileLoader {
+	ns := "*"
+	if len(namespace) > 0 && namespace[0] != "" {
+		ns = namespace[0]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.defaults[ns] == nil {
+		l.defaults[ns] = make(map[string]map[string]map[string]interface{})
+	}
+	if l.defaults[ns][locale] == nil {
+		l.defaults[ns][locale] = make(map[string]map[string]interface{})
+	}
+	for group, messages := range groups {
+		l.defaults[ns][locale][group] = Merge(l.defaults[ns][locale][group], messages)
+	}
+	return l
+}
+
+// Load returns the messages of a group in the given locale.
+func (l *FileLoader) Load(locale, group, namespace string) (map[string]interface{}, error) {
+	if namespace == "" {
+		namespace = "*"
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if group == "*" && namespace == "*" {
+		return l.loadJSONPaths(locale)
+	}
+
+	messages := Merge(nil, l.defaults[namespace][locale][group])
+
+	if namespace == "*" {
+		return l.mergeFiles(messages, l.paths, locale, group)
+	}
+
+	// Package translations, then application overrides in lang/vendor/{namespace}
+	if hint, ok := l.hints[namespace]; ok {
+		var err error
+		if messages, err = l.mergeFiles(messages, []string{hint}, locale, group); err != nil {
+			return nil, err
+		}
+	}
+
+	overrides := make([]string, len(l.paths))
+	for i, path := range l.paths {
+		overrides[i] = filepath.Join(path, "vendor", namespace)
+	}
+	return l.mergeFiles(messages, overrides, locale, group)
+}
+
+// mergeFiles merges {path}/{locale}/{group}.json of every path over messages.
+func (l *FileLoader) mergeFiles(messages map[string]interface{}, paths []string, locale, group string) (map[string]interface{}, error) {
+	for _, path := range paths {
+		lines, err := readJSON(filepath.Join(path, locale, group+".json"))
+		if err != nil {
+			return nil, err
+		}
+		messages = Merge(messages, lines)
+	}
+	return messages, nil
+}
+
+// loadJSONPaths merges {path}/{locale}.json of every path.
+func (l *FileLoader) loadJSONPaths(locale string) (map[string]interface{}, error) {
+	messages := Merge(nil, l.defaults["*"][locale]["*"])
+
+	for _, path := range append(append([]string(nil), l.paths...), l.jsonPaths...) {
+		lines, err := readJSON(filepath.Join(path, locale+".json"))
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range lines {
+			messages[key] = value
+		}
+	}
+	return messages, nil
+}
+
+// AddNamespace registers a directory holding a package's translations.
+func (l *FileLoader) AddNamespace(namespace, hint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hints[namespace] = hint
+}
+
+// AddJSONPath registers an additional directory holding JSON translations.
+func (l *FileLoader) AddJSONPath(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.jsonPaths = append(l.jsonPaths, path)
+}
+
+// Namespaces returns the registered namespaces and their directories.
+func (l *FileLoader) Namespaces() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	namespaces := make(map[string]string, len(l.hints))
+	for namespace, hint := range l.hints {
+		namespaces[namespace] = hint
+	}
+	return namespaces
+}
+
+// Paths returns the language directories, in increasing priority.
+func (l *FileLoader) Paths() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]string(nil), l.paths...)
+}
+
+// Locales returns the locales that have translation files in the language
+// directories, sorted. The vendor directory is skipped.
+func (l *FileLoader) Locales() ([]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, path := range append(append([]string(nil), l.paths...), l.jsonPaths...) {
+		entries, err := os.ReadDir(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read language directory [%s]: %w", path, err)
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			switch {
+			case entry.IsDir() && name != "vendor":
+				seen[name] = true
+			case !entry.IsDir() && filepath.Ext(name) == ".json":
+				seen[strings.TrimSuffix(name, ".json")] = true
+			}
+		}
+	}
+
+	locales := make([]string, 0, len(seen))
+	for locale := range seen {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales, nil
+}
+
+// Groups returns the groups that have a file for locale in the language
+// directories, sorted. Go-defined defaults are not included.
+func (l *FileLoader) Groups(locale string) ([]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, path := range l.paths {
+		files, err := filepath.Glob(filepath.Join(path, locale, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list translation files of [%s]: %w", locale, err)
+		}
+		for _, file := range files {
+			seen[strings.TrimSuffix(filepath.Base(file), ".json")] = true
+		}
+	}
+
+	groups := make([]string, 0, len(seen))
+	for group := range seen {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+// LoadFiles returns the messages of a group as defined by the application's
+// files only, without Go-defined defaults or package namespaces. The "*"
+// group reads the JSON string translations.
+func (l *FileLoader) LoadFiles(locale, group string) (map[string]interface{}, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if group == "*" {
+		messages := make(map[string]interface{})
+		for _, path := range append(append([]string(nil), l.paths...), l.jsonPaths...) {
+			lines, err := readJSON(filepath.Join(path, locale+".json"))
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range lines {
+				messages[key] = value
+			}
+		}
+		return messages, nil
+	}
+
+	return l.mergeFiles(nil, l.paths, locale, group)
+}
+
+// readJSON decodes a translation file; missing files yield no messages.
+func readJSON(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation file [%s]: %w", path, err)
+	}
+
+	var messages map[string]interface{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("translation file [%s] contains an invalid JSON structure: %w", path, err)
+	}
+	return messages, nil
+}
+
+// Ensure FileLoader implements the LoaderInterface
+var _ interfaces.LoaderInterface = (*FileLoader)(nil)
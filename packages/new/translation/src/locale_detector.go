@@ -0,0 +1,239 @@
+package translation
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	session "govel/new/session"
+	webserverInterfaces "govel/new/webserver/interfaces"
+	configInterfaces "govel/types/interfaces/config"
+	sessionInterfaces "govel/types/interfaces/session"
+)
+
+// Locale sources understood by LocaleDetector.
+const (
+	// LocaleSourceRoute reads the {locale} route parameter or the first path segment ("/fr/products")
+	LocaleSourceRoute = "route"
+
+	// LocaleSourceQuery reads a query parameter ("?lang=fr")
+	LocaleSourceQuery = "query"
+
+	// LocaleSourceCookie reads a cookie
+	LocaleSourceCookie = "cookie"
+
+	// LocaleSourceSession reads a session attribute
+	LocaleSourceSession = "session"
+
+	// LocaleSourceHeader negotiates the Accept-Language header
+	LocaleSourceHeader = "header"
+)
+
+// LocaleDetector picks the locale of a request among the available locales.
+//
+// Sources are tried in order; the first one yielding an available locale
+// wins. Candidates match available locales exactly ("pt_BR" == "pt-br"),
+// by language ("fr_CA" -> "fr") or by region ("pt" -> "pt_BR").
+type LocaleDetector struct {
+	// supported are the available locales, in order of preference
+	supported []string
+
+	// order lists the sources to try
+	order []string
+
+	// queryKey is the query parameter holding the locale
+	queryKey string
+
+	// cookieName is the cookie holding the locale
+	cookieName string
+
+	// sessionKey is the session attribute holding the locale
+	sessionKey string
+
+	// routeParameter is the route parameter holding the locale
+	routeParameter string
+}
+
+// NewLocaleDetector creates a detector for the given available locales,
+// trying the route, "lang" query parameter, "locale" cookie, "locale"
+// session attribute and Accept-Language header in that order.
+func NewLocaleDetector(supported ...string) *LocaleDetector {
+	return &LocaleDetector{
+		supported: supported,
+		order: []string{
+			LocaleSourceRoute,
+			LocaleSourceQuery,
+			LocaleSourceCookie,
+			LocaleSourceSession,
+			LocaleSourceHeader,
+		},
+		queryKey:       "lang",
+		cookieName:     "locale",
+		sessionKey:     "locale",
+		routeParameter: "locale",
+	}
+}
+
+// NewLocaleDetectorFromConfig creates a detector from the app.available_locales
+// and app.locale_detection configuration.
+func NewLocaleDetectorFromConfig(config configInterfaces.ConfigInterface) *LocaleDetector {
+	supported := config.GetStringSlice("app.available_locales", []string{config.GetString("app.locale", "en")})
+
+	detector := NewLocaleDetector(supported...)
+	if order := config.GetStringSlice("app.locale_detection.order"); len(order) > 0 {
+		detector.SetOrder(order...)
+	}
+	detector.queryKey = config.GetString("app.locale_detection.query", detector.queryKey)
+	detector.cookieName = config.GetString("app.locale_detection.cookie", detector.cookieName)
+	detector.sessionKey = config.GetString("app.locale_detection.session", detector.sessionKey)
+	detector.routeParameter = config.GetString("app.locale_detection.route_parameter", detector.routeParameter)
+	return detector
+}
+
+// SetOrder sets the sources to try, in order.
+func (d *LocaleDetector) SetOrder(sources ...string) *LocaleDetector {
+	d.order = sources
+	return d
+}
+
+// SetQueryKey sets the query parameter holding the locale.
+func (d *LocaleDetector) SetQueryKey(key string) *LocaleDetector {
+	d.queryKey = key
+	return d
+}
+
+// SetCookieName sets the cookie holding the locale.
+func (d *LocaleDetector) SetCookieName(name string) *LocaleDetector {
+	d.cookieName = name
+	return d
+}
+
+// SetSessionKey sets the session attribute holding the locale.
+func (d *LocaleDetector) SetSessionKey(key string) *LocaleDetector {
+	d.sessionKey = key
+	return d
+}
+
+// SetRouteParameter sets the route parameter holding the locale.
+func (d *LocaleDetector) SetRouteParameter(name string) *LocaleDetector {
+	d.routeParameter = name
+	return d
+}
+
+// Supported returns the available locales.
+func (d *LocaleDetector) Supported() []string {
+	return append([]string(nil), d.supported...)
+}
+
+// Detect returns the locale of the request and the source it came from, or
+// empty strings when no source yields an available locale.
+func (d *LocaleDetector) Detect(req webserverInterfaces.RequestInterface) (locale, source string) {
+	for _, source := range d.order {
+		for _, candidate := range d.candidates(req, source) {
+			if locale, ok := d.Match(candidate); ok {
+				return locale, source
+			}
+		}
+	}
+	return "", ""
+}
+
+// candidates returns the locales proposed by a source.
+func (d *LocaleDetector) candidates(req webserverInterfaces.RequestInterface, source string) []string {
+	switch source {
+	case LocaleSourceRoute:
+		segment, _, _ := strings.Cut(strings.TrimPrefix(req.Path(), "/"), "/")
+		return []string{req.Param(d.routeParameter), segment}
+	case LocaleSourceQuery:
+		return []string{req.Query(d.queryKey)}
+	case LocaleSourceCookie:
+		return []string{req.Cookie(d.cookieName)}
+	case LocaleSourceSession:
+		if store, ok := req.GetContext(session.RequestContextKey).(sessionInterfaces.SessionInterface); ok {
+			locale, _ := store.Get(d.sessionKey).(string)
+			return []string{locale}
+		}
+	case LocaleSourceHeader:
+		return ParseAcceptLanguage(req.Header("Accept-Language"))
+	}
+	return nil
+}
+
+// Match returns the available locale matching candidate: an exact match,
+// then the candidate's language, then the first locale of that language.
+func (d *LocaleDetector) Match(candidate string) (string, bool) {
+	wanted := normalizeLocale(candidate)
+	if wanted == "" {
+		return "", false
+	}
+	language, _, _ := strings.Cut(wanted, "_")
+
+	for _, supported := range d.supported {
+		if normalizeLocale(supported) == wanted {
+			return supported, true
+		}
+	}
+	for _, supported := range d.supported {
+		if normalizeLocale(supported) == language {
+			return supported, true
+		}
+	}
+	for _, supported := range d.supported {
+		if base, _, _ := strings.Cut(normalizeLocale(supported), "_"); base == language {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// normalizeLocale lower-cases a locale and uses "_" as separator.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"))
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header
+// ordered by decreasing quality. Tags with q=0 and the "*" wildcard are
+// dropped; tags of equal quality keep their order.
+//
+// Example:
+//
+//	ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5") // [fr-CH fr en]
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	ordered := make([]string, len(tags))
+	for i, tag := range tags {
+		ordered[i] = tag.tag
+	}
+	return ordered
+}
@@ -0,0 +1,125 @@
+package translation
+
+import (
+	"govel/support/carbon"
+	"govel/support/money"
+	langInterfaces "govel/types/interfaces/lang"
+)
+
+// LocalizedTranslator is a view of a Translator bound to one locale, usually
+// the locale of the current request. Lookups without an explicit locale use
+// the bound locale; the translator's own locale and fallback are never
+// changed, so views are safe to use concurrently across requests.
+//
+// The bound locale also drives dates and prices through Date and Money, so
+// everything rendered for a request is in the same language.
+type LocalizedTranslator struct {
+	// translator performs the lookups
+	translator *Translator
+
+	// locale is the bound locale
+	locale string
+
+	// fallback overrides the translator's fallback locale when set
+	fallback string
+}
+
+// ForLocale returns a view of the translator bound to locale. An empty
+// locale binds the translator's current locale.
+func (t *Translator) ForLocale(locale string) *LocalizedTranslator {
+	if locale == "" {
+		locale = t.GetLocale()
+	}
+	return &LocalizedTranslator{translator: t, locale: locale}
+}
+
+// Get returns the translation for the given key in the bound locale unless
+// a locale is given.
+func (l *LocalizedTranslator) Get(key string, replace map[string]interface{}, locale string, fallback bool) interface{} {
+	return l.translator.get(key, replace, l.localeOr(locale), fallback, l.GetFallback())
+}
+
+// Trans translates a key to a string in the bound locale unless a locale is given.
+func (l *LocalizedTranslator) Trans(key string, replace map[string]interface{}, locale ...string) string {
+	if line, ok := l.Get(key, replace, firstLocale(locale), true).(string); ok {
+		return line
+	}
+	return key
+}
+
+// Choice selects the plural form of a key in the bound locale unless a locale is given.
+func (l *LocalizedTranslator) Choice(key string, number int, replace map[string]interface{}, locale ...string) string {
+	return l.translator.choice(key, number, replace, l.localeOr(firstLocale(locale)), l.GetFallback())
+}
+
+// TransChoice selects the plural form of a key in the bound locale.
+func (l *LocalizedTranslator) TransChoice(key string, number int, replace ...map[string]interface{}) string {
+	var replacements map[string]interface{}
+	if len(replace) > 0 {
+		replacements = replace[0]
+	}
+	return l.translator.choice(key, number, replacements, l.locale, l.GetFallback())
+}
+
+// Has reports whether a translation exists in the bound locale chain.
+func (l *LocalizedTranslator) Has(key string, locale ...string) bool {
+	return l.translator.exists(key, l.localeOr(firstLocale(locale)), true, l.GetFallback())
+}
+
+// HasForLocale reports whether a translation exists in the given locale.
+func (l *LocalizedTranslator) HasForLocale(key, locale string) bool {
+	return l.translator.HasForLocale(key, locale)
+}
+
+// GetLocale returns the bound locale.
+func (l *LocalizedTranslator) GetLocale() string {
+	return l.locale
+}
+
+// SetLocale rebinds the view; the translator's locale is not changed.
+func (l *LocalizedTranslator) SetLocale(locale string) {
+	l.locale = locale
+}
+
+// GetFallback returns the fallback locale of the view, the translator's
+// unless SetFallback was called.
+func (l *LocalizedTranslator) GetFallback() string {
+	if l.fallback != "" {
+		return l.fallback
+	}
+	return l.translator.GetFallback()
+}
+
+// SetFallback sets the fallback locale of the view; the translator's
+// fallback is not changed.
+func (l *LocalizedTranslator) SetFallback(fallback string) {
+	l.fallback = fallback
+}
+
+// Date returns a copy of date whose DiffForHumans and month and weekday
+// names are in the bound locale.
+func (l *LocalizedTranslator) Date(date *carbon.Carbon) *carbon.Carbon {
+	return carbon.Localize(date, l.locale)
+}
+
+// Dates returns a carbon helper whose DiffForHumans and FormatLocalized use
+// the bound locale.
+func (l *LocalizedTranslator) Dates() *carbon.CarbonHelper {
+	return carbon.NewHelper().WithLocale(l.locale)
+}
+
+// Money returns a view of amount formatted with the conventions of the bound locale.
+func (l *LocalizedTranslator) Money(amount *money.Money) *money.LocalizedMoney {
+	return amount.WithLocale(l.locale)
+}
+
+// localeOr returns locale, or the bound locale when empty.
+func (l *LocalizedTranslator) localeOr(locale string) string {
+	if locale == "" {
+		return l.locale
+	}
+	return locale
+}
+
+// Ensure LocalizedTranslator implements the LanguageInterface
+var _ langInterfaces.LanguageInterface = (*LocalizedTranslator)(nil)
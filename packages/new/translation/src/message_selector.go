@@ -0,0 +1,258 @@
+package translation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// conditionPattern matches a leading "{n}" or "[from,to]" plural condition.
+var conditionPattern = regexp.MustCompile(`^[\{\[]([^\[\]\{\}]*)[\}\]]`)
+
+// MessageSelector selects the plural form of a translation line.
+//
+// Lines hold "|" separated segments. Segments may carry an explicit
+// condition, an exact value "{0}" or an inclusive interval "[1,19]" where
+// "*" is unbounded; otherwise the segment is chosen by the plural rules of
+// the locale:
+//
+//	"{0} No apples|[1,19] Some apples|[20,*] Many apples"
+//	"apple|apples"
+type MessageSelector struct{}
+
+// NewMessageSelector creates a new message selector.
+func NewMessageSelector() *MessageSelector {
+	return &MessageSelector{}
+}
+
+// Choose selects the proper translation segment for the given number.
+//
+// Parameters:
+//   - line: Translation line with "|" separated segments
+//   - number: Number deciding the plural form
+//   - locale: Locale whose plural rules apply to unconditioned segments
+func (s *MessageSelector) Choose(line string, number int, locale string) string {
+	segments := strings.Split(line, "|")
+
+	if value, ok := s.extract(segments, number); ok {
+		return strings.TrimSpace(value)
+	}
+
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(conditionPattern.ReplaceAllString(segment, ""))
+	}
+
+	index := s.GetPluralIndex(locale, number)
+	if len(segments) == 1 || index >= len(segments) {
+		return segments[0]
+	}
+	return segments[index]
+}
+
+// extract returns the first segment whose explicit condition matches number.
+func (s *MessageSelector) extract(segments []string, number int) (string, bool) {
+	for _, segment := range segments {
+		segment = strings.TrimSpace(segment)
+
+		match := conditionPattern.FindStringSubmatchIndex(segment)
+		if match == nil {
+			continue
+		}
+		condition, value := segment[match[2]:match[3]], segment[match[1]:]
+
+		if from, to, ok := strings.Cut(condition, ","); ok {
+			if matchesInterval(strings.TrimSpace(from), strings.TrimSpace(to), float64(number)) {
+				return value, true
+			}
+			continue
+		}
+
+		if exact, err := strconv.ParseFloat(strings.TrimSpace(condition), 64); err == nil && exact == float64(number) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// matchesInterval reports whether number lies in the inclusive interval
+// [from, to], where "*" leaves a bound open.
+func matchesInterval(from, to string, number float64) bool {
+	lower, lowerErr := strconv.ParseFloat(from, 64)
+	upper, upperErr := strconv.ParseFloat(to, 64)
+
+	switch {
+	case to == "*":
+		return from == "*" || (lowerErr == nil && number >= lower)
+	case from == "*":
+		return upperErr == nil && number <= upper
+	default:
+		return lowerErr == nil && upperErr == nil && number >= lower && number <= upper
+	}
+}
+
+// GetPluralIndex returns the index of the plural form to use for a number
+// in the given locale. The rules are those of Laravel's MessageSelector,
+// themselves taken from Zend Framework's plural rules.
+func (s *MessageSelector) GetPluralIndex(locale string, number int) int {
+	locale = strings.ReplaceAll(locale, "-", "_")
+	if locale == "pt_BR" {
+		// Brazilian Portuguese is singular for 0, unlike Portuguese
+		locale = "xbr"
+	}
+	if len(locale) > 3 {
+		if i := strings.LastIndex(locale, "_"); i > 0 {
+			locale = locale[:i]
+		}
+	}
+
+	switch locale {
+	case "az", "bo", "dz", "id", "ja", "jv", "ka", "km", "kn", "ko", "ms", "th", "tr", "vi", "zh":
+		return 0
+
+	case "af", "bn", "bg", "ca", "da", "de", "el", "en", "eo", "es", "et", "eu", "fa", "fi", "fo",
+		"fur", "fy", "gl", "gu", "ha", "he", "hu", "is", "it", "ku", "lb", "ml", "mn", "mr", "nah",
+		"nb", "ne", "nl", "nn", "no", "oc", "om", "or", "pa", "pap", "ps", "pt", "so", "sq", "sv",
+		"sw", "ta", "te", "tk", "ur", "zu":
+		if number == 1 {
+			return 0
+		}
+		return 1
+
+	case "am", "bh", "fil", "fr", "gun", "hi", "hy", "ln", "mg", "nso", "xbr", "ti", "wa":
+		if number == 0 || number == 1 {
+			return 0
+		}
+		return 1
+
+	case "be", "bs", "hr", "ru", "sh", "sr", "uk":
+		switch {
+		case number%10 == 1 && number%100 != 11:
+			return 0
+		case number%10 >= 2 && number%10 <= 4 && (number%100 < 10 || number%100 >= 20):
+			return 1
+		default:
+			return 2
+		}
+
+	case "cs", "sk":
+		switch {
+		case number == 1:
+			return 0
+		case number >= 2 && number <= 4:
+			return 1
+		default:
+			return 2
+		}
+
+	case "ga":
+		switch number {
+		case 1:
+			return 0
+		case 2:
+			return 1
+		default:
+			return 2
+		}
+
+	case "lt":
+		switch {
+		case number%10 == 1 && number%100 != 11:
+			return 0
+		case number%10 >= 2 && (number%100 < 10 || number%100 >= 20):
+			return 1
+		default:
+			return 2
+		}
+
+	case "sl":
+		switch number % 100 {
+		case 1:
+			return 0
+		case 2:
+			return 1
+		case 3, 4:
+			return 2
+		default:
+			return 3
+		}
+
+	case "mk":
+		if number%10 == 1 {
+			return 0
+		}
+		return 1
+
+	case "mt":
+		switch {
+		case number == 1:
+			return 0
+		case number == 0 || (number%100 > 1 && number%100 < 11):
+			return 1
+		case number%100 > 10 && number%100 < 20:
+			return 2
+		default:
+			return 3
+		}
+
+	case "lv":
+		switch {
+		case number == 0:
+			return 0
+		case number%10 == 1 && number%100 != 11:
+			return 1
+		default:
+			return 2
+		}
+
+	case "pl":
+		switch {
+		case number == 1:
+			return 0
+		case number%10 >= 2 && number%10 <= 4 && (number%100 < 12 || number%100 > 14):
+			return 1
+		default:
+			return 2
+		}
+
+	case "cy":
+		switch number {
+		case 1:
+			return 0
+		case 2:
+			return 1
+		case 8, 11:
+			return 2
+		default:
+			return 3
+		}
+
+	case "ro":
+		switch {
+		case number == 1:
+			return 0
+		case number == 0 || (number%100 > 0 && number%100 < 20):
+			return 1
+		default:
+			return 2
+		}
+
+	case "ar":
+		switch {
+		case number == 0:
+			return 0
+		case number == 1:
+			return 1
+		case number == 2:
+			return 2
+		case number%100 >= 3 && number%100 <= 10:
+			return 3
+		case number%100 >= 11 && number%100 <= 99:
+			return 4
+		default:
+			return 5
+		}
+
+	default:
+		return 0
+	}
+}
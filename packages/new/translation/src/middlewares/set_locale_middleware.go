@@ -0,0 +1,60 @@
+// Package middlewares provides webserver middlewares for the translation package.
+package middlewares
+
+import (
+	translation "govel/new/translation"
+	webserver "govel/new/webserver"
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// SetLocaleMiddleware detects the locale of every request and stores it on
+// the request (see translation.LocaleFromRequest and Translator.ForRequest).
+//
+// The application locale is never changed: concurrent requests in different
+// languages each keep their own locale. Requests with no detectable locale
+// get the translator's locale. The response carries a Content-Language header.
+//
+// Register it after StartSession when the "session" source is used.
+type SetLocaleMiddleware struct {
+	webserver.BaseMiddleware
+
+	// translator provides the default locale
+	translator *translation.Translator
+
+	// detector picks the request locale
+	detector *translation.LocaleDetector
+}
+
+// NewSetLocaleMiddleware creates a new locale detection middleware.
+//
+// Parameters:
+//   - translator: Translator providing the default locale
+//   - detector: Detector picking the request locale, usually built with
+//     translation.NewLocaleDetectorFromConfig
+func NewSetLocaleMiddleware(translator *translation.Translator, detector *translation.LocaleDetector) *SetLocaleMiddleware {
+	return &SetLocaleMiddleware{translator: translator, detector: detector}
+}
+
+// Handle stores the request locale and runs the rest of the chain.
+func (m *SetLocaleMiddleware) Handle(req webserverInterfaces.RequestInterface, next webserverInterfaces.HandlerInterface) webserverInterfaces.ResponseInterface {
+	locale, _ := m.detector.Detect(req)
+	if locale == "" {
+		locale = m.translator.GetLocale()
+	}
+
+	translation.SetRequestLocale(req, locale)
+
+	resp := next.Handle(req)
+	if resp != nil {
+		resp.Header("Content-Language", locale)
+	}
+	return resp
+}
+
+// Priority returns the middleware priority; it runs right after sessions start.
+func (m *SetLocaleMiddleware) Priority() int {
+	return 11
+}
+
+// Compile-time interface compliance check
+var _ webserverInterfaces.MiddlewareInterface = (*SetLocaleMiddleware)(nil)
@@ -0,0 +1,93 @@
+package translation
+
+import (
+	"sort"
+	"sync"
+
+	loggerInterfaces "govel/types/interfaces/logger"
+)
+
+// MissingKey describes a translation key that could not be resolved at runtime.
+type MissingKey struct {
+	// Key is the requested translation key
+	Key string
+
+	// Locale is the locale the key was requested in
+	Locale string
+
+	// Count is the number of failed lookups
+	Count int
+}
+
+// MissingKeyLogger records translation misses reported by the translator and
+// writes each key/locale pair to the logger once, so hot paths do not flood
+// the log.
+//
+// Example:
+//
+//	misses := translation.NewMissingKeyLogger(app.GetLogger())
+//	translator.HandleMissingKeysUsing(misses.Handle)
+type MissingKeyLogger struct {
+	mu sync.Mutex
+
+	// logger receives a warning for every new miss; nil only records
+	logger loggerInterfaces.LoggerInterface
+
+	// misses counts failed lookups keyed by locale and key
+	misses map[string]map[string]int
+}
+
+// NewMissingKeyLogger creates a recorder writing misses to logger.
+func NewMissingKeyLogger(logger loggerInterfaces.LoggerInterface) *MissingKeyLogger {
+	return &MissingKeyLogger{
+		logger: logger,
+		misses: make(map[string]map[string]int),
+	}
+}
+
+// Handle records a miss. It matches the handler signature of
+// Translator.HandleMissingKeysUsing.
+func (l *MissingKeyLogger) Handle(key, locale string) {
+	l.mu.Lock()
+	if l.misses[locale] == nil {
+		l.misses[locale] = make(map[string]int)
+	}
+	l.misses[locale][key]++
+	first := l.misses[locale][key] == 1
+	l.mu.Unlock()
+
+	if first && l.logger != nil {
+		l.logger.WithFields(map[string]interface{}{
+			"key":    key,
+			"locale": locale,
+		}).Warn("Missing translation [%s] for locale [%s]", key, locale)
+	}
+}
+
+// Misses returns the recorded misses sorted by locale and key.
+func (l *MissingKeyLogger) Misses() []MissingKey {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var misses []MissingKey
+	for locale, keys := range l.misses {
+		for key, count := range keys {
+			misses = append(misses, MissingKey{Key: key, Locale: locale, Count: count})
+		}
+	}
+
+	sort.Slice(misses, func(i, j int) bool {
+		if misses[i].Locale != misses[j].Locale {
+			return misses[i].Locale < misses[j].Locale
+		}
+		return misses[i].Key < misses[j].Key
+	})
+	return misses
+}
+
+// Reset forgets the recorded misses; they are logged again on their next occurrence.
+func (l *MissingKeyLogger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.misses = make(map[string]map[string]int)
+}
@@ -0,0 +1,61 @@
+package translation
+
+import (
+	langInterfaces "govel/types/interfaces/lang"
+)
+
+// PotentiallyTranslatedString is a message that may be a translation key.
+// Validation rules use it so a failure message can be either a literal
+// string or a key translated on demand.
+//
+// Example:
+//
+//	message := translation.NewPotentiallyTranslatedString("validation.uppercase", translator)
+//	message.Translate(map[string]interface{}{"attribute": "name"})
+//	message.String() // "The name field must be uppercase." or the key itself
+type PotentiallyTranslatedString struct {
+	// original is the string given at construction
+	original string
+
+	// translation is the translated string, empty until translated
+	translation string
+
+	// translator resolves the string as a key
+	translator langInterfaces.LanguageInterface
+}
+
+// NewPotentiallyTranslatedString creates a potentially translated string.
+func NewPotentiallyTranslatedString(str string, translator langInterfaces.LanguageInterface) *PotentiallyTranslatedString {
+	return &PotentiallyTranslatedString{original: str, translator: translator}
+}
+
+// Translate translates the string as a key.
+func (s *PotentiallyTranslatedString) Translate(replace map[string]interface{}, locale ...string) *PotentiallyTranslatedString {
+	if s.translator != nil {
+		s.translation = s.translator.Trans(s.original, replace, locale...)
+	}
+	return s
+}
+
+// TranslateChoice translates the string as a key, selecting the plural form
+// matching number.
+func (s *PotentiallyTranslatedString) TranslateChoice(number int, replace map[string]interface{}, locale ...string) *PotentiallyTranslatedString {
+	if s.translator != nil {
+		s.translation = s.translator.Choice(s.original, number, replace, locale...)
+	}
+	return s
+}
+
+// Original returns the string given at construction.
+func (s *PotentiallyTranslatedString) Original() string {
+	return s.original
+}
+
+// String returns the translation, or the original string when it was never
+// translated.
+func (s *PotentiallyTranslatedString) String() string {
+	if s.translation != "" {
+		return s.translation
+	}
+	return s.original
+}
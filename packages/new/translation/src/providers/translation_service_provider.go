@@ -0,0 +1,104 @@
+// Package providers contains service provider implementations for the translation package.
+// Service providers are responsible for registering translation services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"govel/application/providers"
+	translation "govel/new/translation"
+	"govel/new/translation/lang/en"
+	"govel/new/translation/loaders"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	langInterfaces "govel/types/interfaces/lang"
+)
+
+// TranslationServiceProvider implements a Laravel-compatible service provider
+// for the translation package.
+//
+// Services registered:
+//   - LANG_LOADER_TOKEN: Singleton FileLoader reading {base path}/lang, with
+//     the framework's English defaults
+//   - LANG_TOKEN / LANG_INTERFACE_TOKEN: Singleton Translator whose locale and
+//     fallback locale are those of the application; misses are logged when
+//     app.log_missing_translations is enabled
+type TranslationServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewTranslationServiceProvider creates a new TranslationServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewTranslationServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register translation services:", err)
+//	}
+func NewTranslationServiceProvider() *TranslationServiceProvider {
+	return &TranslationServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all translation services in the dependency injection container.
+func (p *TranslationServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		loader     *loaders.FileLoader
+		loaderOnce sync.Once
+	)
+	loaderFactory := func() interface{} {
+		loaderOnce.Do(func() {
+			loader = loaders.NewFileLoader(filepath.Join(application.BasePath(), "lang"))
+			loader.AddDefaults("en", en.Groups())
+		})
+		return loader
+	}
+
+	if err := application.Singleton(langInterfaces.LANG_LOADER_TOKEN, loaderFactory); err != nil {
+		return fmt.Errorf("failed to bind translation loader: %w", err)
+	}
+
+	var (
+		translator     *translation.Translator
+		translatorOnce sync.Once
+	)
+	translatorFactory := func() interface{} {
+		translatorOnce.Do(func() {
+			translator = translation.NewTranslator(loaderFactory().(*loaders.FileLoader), application.GetLocale())
+			translator.UseLocaleOf(application)
+			if application.GetConfig().GetBool("app.log_missing_translations", false) {
+				translator.HandleMissingKeysUsing(translation.NewMissingKeyLogger(application.GetLogger()).Handle)
+			}
+		})
+		return translator
+	}
+
+	for _, token := range []interface{}{
+		langInterfaces.LANG_TOKEN,
+		langInterfaces.LANG_INTERFACE_TOKEN,
+	} {
+		if err := application.Singleton(token, translatorFactory); err != nil {
+			return fmt.Errorf("failed to bind translator: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *TranslationServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		langInterfaces.LANG_LOADER_TOKEN,
+		langInterfaces.LANG_TOKEN,
+		langInterfaces.LANG_INTERFACE_TOKEN,
+	}
+}
@@ -0,0 +1,34 @@
+package translation
+
+import (
+	webserverInterfaces "govel/new/webserver/interfaces"
+)
+
+// RequestContextKey is the request context key holding the request locale.
+const RequestContextKey = "__locale"
+
+// LocaleFromRequest returns the locale detected for the request by the
+// SetLocale middleware, or "" when it did not run.
+func LocaleFromRequest(req webserverInterfaces.RequestInterface) string {
+	locale, _ := req.GetContext(RequestContextKey).(string)
+	return locale
+}
+
+// SetRequestLocale sets the locale of a single request. The application
+// locale is left untouched.
+func SetRequestLocale(req webserverInterfaces.RequestInterface, locale string) {
+	req.SetContext(RequestContextKey, locale)
+}
+
+// ForRequest returns a view of the translator bound to the request locale,
+// or to the translator's locale when none was detected.
+//
+// Example:
+//
+//	lang := translator.ForRequest(req)
+//	lang.Trans("messages.welcome", nil)
+//	lang.Date(order.CreatedAt).DiffForHumans()
+//	lang.Money(order.Total).Format(true, ",")
+func (t *Translator) ForRequest(req webserverInterfaces.RequestInterface) *LocalizedTranslator {
+	return t.ForLocale(LocaleFromRequest(req))
+}
@@ -0,0 +1,186 @@
+package translation
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"govel/new/translation/loaders"
+)
+
+// groupKeyPattern matches keys addressing group files ("validation.required",
+// "courier::mail.sent"). Other keys are JSON string translations whose key
+// is the source text ("Log out").
+var groupKeyPattern = regexp.MustCompile(`^([\w-]+::)?[\w-]+(\.[\w-]+)+$`)
+
+// IsGroupKey reports whether key addresses a group file rather than a JSON
+// string translation.
+func IsGroupKey(key string) bool {
+	return groupKeyPattern.MatchString(key)
+}
+
+// TranslationAudit lists the translation problems of one locale.
+type TranslationAudit struct {
+	// Locale is the audited locale
+	Locale string
+
+	// Missing are keys used in source without a translation in Locale
+	Missing []string
+
+	// Unused are keys defined in the files of Locale but never used in source
+	Unused []string
+}
+
+// TranslationAuditor compares the keys used in source (see KeyScanner)
+// against the application's language files.
+//
+// Missing keys are checked without fallback, so a key only translated in
+// the fallback locale is reported for every other locale. JSON string keys
+// are their own source text and are never missing in the source locale.
+// Keys built at runtime cannot be found by the scanner; groups used that
+// way (e.g. "validation") should be ignored so they are not reported unused.
+type TranslationAuditor struct {
+	// translator resolves keys
+	translator *Translator
+
+	// loader lists the application's language files
+	loader *loaders.FileLoader
+
+	// source is the locale source texts are written in
+	source string
+
+	// ignore are key prefixes never reported unused
+	ignore []string
+}
+
+// NewTranslationAuditor creates an auditor.
+//
+// Parameters:
+//   - translator: Translator resolving keys
+//   - loader: Loader of the application's language files
+//   - sourceLocale: Locale source texts are written in, usually the fallback locale
+//
+// Example:
+//
+//	usages, _ := translation.NewKeyScanner().ScanDir(".")
+//	audits, err := translation.NewTranslationAuditor(translator, loader, "en").
+//		Ignore("validation", "passwords").
+//		Audit(translation.UsedKeys(usages), "fr", "de")
+func NewTranslationAuditor(translator *Translator, loader *loaders.FileLoader, sourceLocale string) *TranslationAuditor {
+	return &TranslationAuditor{
+		translator: translator,
+		loader:     loader,
+		source:     sourceLocale,
+	}
+}
+
+// Ignore excludes keys from the unused report: a prefix matches the key
+// itself and every key below it ("validation" matches "validation.required").
+func (a *TranslationAuditor) Ignore(prefixes ...string) *TranslationAuditor {
+	a.ignore = append(a.ignore, prefixes...)
+	return a
+}
+
+// Audit reports missing and unused keys of each locale. Without locales,
+// every locale having language files is audited.
+func (a *TranslationAuditor) Audit(used []string, locales ...string) ([]TranslationAudit, error) {
+	if len(locales) == 0 {
+		var err error
+		if locales, err = a.loader.Locales(); err != nil {
+			return nil, err
+		}
+	}
+
+	audits := make([]TranslationAudit, 0, len(locales))
+	for _, locale := range locales {
+		defined, err := a.DefinedKeys(locale)
+		if err != nil {
+			return nil, err
+		}
+
+		audit := TranslationAudit{Locale: locale}
+		for _, key := range used {
+			if !IsGroupKey(key) && locale == a.source {
+				continue
+			}
+			if !a.translator.HasForLocale(key, locale) {
+				audit.Missing = append(audit.Missing, key)
+			}
+		}
+		for _, key := range defined {
+			if !covers(used, key) && !covers(a.ignore, key) {
+				audit.Unused = append(audit.Unused, key)
+			}
+		}
+		audits = append(audits, audit)
+	}
+	return audits, nil
+}
+
+// DefinedKeys returns the keys defined by the application's files for
+// locale, sorted: JSON string keys as-is and group lines as dotted keys.
+func (a *TranslationAuditor) DefinedKeys(locale string) ([]string, error) {
+	lines, err := a.loader.LoadFiles(locale, "*")
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for key := range lines {
+		keys = append(keys, key)
+	}
+
+	groups, err := a.loader.Groups(locale)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		lines, err := a.loader.LoadFiles(locale, group)
+		if err != nil {
+			return nil, err
+		}
+		keys = flatten(keys, group, lines)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Entries returns the exchange entries of the missing keys of audit, with
+// the source text and any existing translation filled in.
+func (a *TranslationAuditor) Entries(audit TranslationAudit) []TranslationEntry {
+	entries := make([]TranslationEntry, 0, len(audit.Missing))
+	for _, key := range audit.Missing {
+		entry := TranslationEntry{Key: key, Source: key}
+		if line, ok := a.translator.resolve(key, nil, a.source, false, ""); ok {
+			if text, isText := line.(string); isText {
+				entry.Source = text
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// flatten appends the dotted keys of the string lines below prefix.
+func flatten(keys []string, prefix string, lines map[string]interface{}) []string {
+	for key, value := range lines {
+		switch line := value.(type) {
+		case string:
+			keys = append(keys, prefix+"."+key)
+		case map[string]interface{}:
+			keys = flatten(keys, prefix+"."+key, line)
+		}
+	}
+	return keys
+}
+
+// covers reports whether key equals one of prefixes or lies below one.
+func covers(prefixes []string, key string) bool {
+	for _, prefix := range prefixes {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
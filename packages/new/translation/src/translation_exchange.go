@@ -0,0 +1,349 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Exchange formats understood by TranslationExport.
+const (
+	// ExportFormatJSON is a JSON document keyed by translation key
+	ExportFormatJSON = "json"
+
+	// ExportFormatXLIFF is an XLIFF 1.2 document, read by most translation tools
+	ExportFormatXLIFF = "xliff"
+)
+
+// localePattern matches the locales language files may be written for, such
+// as "fr", "pt_BR" or "zh-Hant-TW"
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([_-][A-Za-z0-9]+)*$`)
+
+// TranslationEntry is a key handed to translators.
+type TranslationEntry struct {
+	// Key is the translation key
+	Key string
+
+	// Source is the text in the source locale
+	Source string
+
+	// Target is the translation, empty until translated
+	Target string
+}
+
+// TranslationExport is a set of entries exchanged with translators.
+//
+// The JSON format is:
+//
+//	{
+//	    "source_locale": "en",
+//	    "target_locale": "fr",
+//	    "translations": {
+//	        "messages.welcome": {"source": "Welcome, :name!", "target": ""}
+//	    }
+//	}
+//
+// A flat object mapping keys to translations is accepted on import as well.
+type TranslationExport struct {
+	// SourceLocale is the locale of the source texts
+	SourceLocale string
+
+	// TargetLocale is the locale being translated to
+	TargetLocale string
+
+	// Entries are the exchanged keys
+	Entries []TranslationEntry
+}
+
+// jsonExport is the JSON document of a TranslationExport.
+type jsonExport struct {
+	SourceLocale string               `json:"source_locale"`
+	TargetLocale string               `json:"target_locale"`
+	Translations map[string]jsonEntry `json:"translations"`
+}
+
+// jsonEntry is a translation of a JSON document.
+type jsonEntry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// xliffDocument is an XLIFF 1.2 document.
+type xliffDocument struct {
+	XMLName xml.Name  `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+// xliffFile is the file element of an XLIFF document.
+type xliffFile struct {
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Original       string      `xml:"original,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+// xliffUnit is a trans-unit element of an XLIFF document.
+type xliffUnit struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source"`
+	Target string `xml:"target"`
+}
+
+// FormatFromPath returns the exchange format matching the extension of path:
+// ".xlf" and ".xliff" are XLIFF, anything else JSON.
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlf", ".xliff":
+		return ExportFormatXLIFF
+	}
+	return ExportFormatJSON
+}
+
+// Write encodes the export in format.
+func (e *TranslationExport) Write(w io.Writer, format string) error {
+	switch format {
+	case ExportFormatJSON, "":
+		return e.WriteJSON(w)
+	case ExportFormatXLIFF:
+		return e.WriteXLIFF(w)
+	}
+	return fmt.Errorf("unsupported translation export format [%s]", format)
+}
+
+// WriteJSON encodes the export as JSON.
+func (e *TranslationExport) WriteJSON(w io.Writer) error {
+	document := jsonExport{
+		SourceLocale: e.SourceLocale,
+		TargetLocale: e.TargetLocale,
+		Translations: make(map[string]jsonEntry, len(e.Entries)),
+	}
+	for _, entry := range e.Entries {
+		document.Translations[entry.Key] = jsonEntry{Source: entry.Source, Target: entry.Target}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(document)
+}
+
+// WriteXLIFF encodes the export as XLIFF 1.2. Keys are the trans-unit ids.
+func (e *TranslationExport) WriteXLIFF(w io.Writer) error {
+	document := xliffDocument{
+		Version: "1.2",
+		File: xliffFile{
+			SourceLanguage: e.SourceLocale,
+			TargetLanguage: e.TargetLocale,
+			Datatype:       "plaintext",
+			Original:       "lang",
+		},
+	}
+	for _, entry := range e.Entries {
+		document.File.Units = append(document.File.Units, xliffUnit{ID: entry.Key, Source: entry.Source, Target: entry.Target})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadTranslationExport decodes an export written in format. Entries are
+// sorted by key.
+func ReadTranslationExport(r io.Reader, format string) (*TranslationExport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation export: %w", err)
+	}
+
+	var export *TranslationExport
+	switch format {
+	case ExportFormatJSON, "":
+		export, err = readJSONExport(data)
+	case ExportFormatXLIFF:
+		export, err = readXLIFFExport(data)
+	default:
+		return nil, fmt.Errorf("unsupported translation export format [%s]", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(export.Entries, func(i, j int) bool { return export.Entries[i].Key < export.Entries[j].Key })
+	return export, nil
+}
+
+// readJSONExport decodes the JSON format, or a flat object of translations.
+func readJSONExport(data []byte) (*TranslationExport, error) {
+	var document jsonExport
+	if err := json.Unmarshal(data, &document); err == nil && document.Translations != nil {
+		export := &TranslationExport{SourceLocale: document.SourceLocale, TargetLocale: document.TargetLocale}
+		for key, entry := range document.Translations {
+			export.Entries = append(export.Entries, TranslationEntry{Key: key, Source: entry.Source, Target: entry.Target})
+		}
+		return export, nil
+	}
+
+	var flat map[string]string
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, fmt.Errorf("translation export contains an invalid JSON structure: %w", err)
+	}
+
+	export := &TranslationExport{}
+	for key, target := range flat {
+		export.Entries = append(export.Entries, TranslationEntry{Key: key, Target: target})
+	}
+	return export, nil
+}
+
+// readXLIFFExport decodes an XLIFF 1.2 document.
+func readXLIFFExport(data []byte) (*TranslationExport, error) {
+	var document xliffDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("translation export contains an invalid XLIFF structure: %w", err)
+	}
+
+	export := &TranslationExport{
+		SourceLocale: document.File.SourceLanguage,
+		TargetLocale: document.File.TargetLanguage,
+	}
+	for _, unit := range document.File.Units {
+		export.Entries = append(export.Entries, TranslationEntry{Key: unit.ID, Source: unit.Source, Target: unit.Target})
+	}
+	return export, nil
+}
+
+// WriteTranslations stores the translated entries (those with a Target) in
+// the language files of locale below langPath, merging them into existing
+// files:
+//
+//	"Log out"            -> {langPath}/{locale}.json
+//	"messages.welcome"   -> {langPath}/{locale}/messages.json
+//	"courier::mail.sent" -> {langPath}/vendor/courier/{locale}/mail.json
+//
+// It returns the number of stored translations. Locales other than language
+// tags such as "fr" or "pt_BR" are rejected, so a locale read from an
+// imported file cannot point outside langPath.
+func WriteTranslations(langPath, locale string, entries []TranslationEntry) (int, error) {
+	if !localePattern.MatchString(locale) {
+		return 0, fmt.Errorf("invalid translation locale [%s]", locale)
+	}
+
+	files := make(map[string]map[string]interface{})
+	written := 0
+
+	for _, entry := range entries {
+		if entry.Target == "" {
+			continue
+		}
+
+		path, item := translationFile(langPath, locale, entry.Key)
+		lines, ok := files[path]
+		if !ok {
+			var err error
+			if lines, err = readTranslationFile(path); err != nil {
+				return 0, err
+			}
+			files[path] = lines
+		}
+
+		if IsGroupKey(entry.Key) {
+			setLine(lines, item, entry.Target)
+		} else {
+			lines[item] = entry.Target
+		}
+		written++
+	}
+
+	for path, lines := range files {
+		if err := writeTranslationFile(path, lines); err != nil {
+			return 0, err
+		}
+	}
+	return written, nil
+}
+
+// translationFile returns the file holding key and the item within it.
+func translationFile(langPath, locale, key string) (path, item string) {
+	if !IsGroupKey(key) {
+		return filepath.Join(langPath, locale+".json"), key
+	}
+
+	namespace := "*"
+	if ns, rest, ok := strings.Cut(key, "::"); ok {
+		namespace, key = ns, rest
+	}
+	group, item, _ := strings.Cut(key, ".")
+
+	if namespace == "*" {
+		return filepath.Join(langPath, locale, group+".json"), item
+	}
+	return filepath.Join(langPath, "vendor", namespace, locale, group+".json"), item
+}
+
+// setLine stores value at the dotted item, creating nested objects.
+func setLine(lines map[string]interface{}, item, value string) {
+	parts := strings.Split(item, ".")
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := lines[part].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			lines[part] = nested
+		}
+		lines = nested
+	}
+	lines[parts[len(parts)-1]] = value
+}
+
+// readTranslationFile decodes a language file; missing files yield no lines.
+func readTranslationFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation file [%s]: %w", path, err)
+	}
+
+	lines := make(map[string]interface{})
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return nil, fmt.Errorf("translation file [%s] contains an invalid JSON structure: %w", path, err)
+	}
+	return lines, nil
+}
+
+// writeTranslationFile encodes lines with sorted keys and unescaped HTML.
+func writeTranslationFile(path string, lines map[string]interface{}) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(lines); err != nil {
+		return fmt.Errorf("failed to encode translation file [%s]: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create language directory for [%s]: %w", path, err)
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write translation file [%s]: %w", path, err)
+	}
+	return nil
+}
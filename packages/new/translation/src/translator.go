@@ -0,0 +1,591 @@
+// Package translation provides the GoVel translator: Laravel-compatible
+// translation lookups with placeholder replacement, pluralization, JSON and
+// group files, package namespaces and fallback locale chains.
+package translation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"govel/new/translation/interfaces"
+	traitInterfaces "govel/types/interfaces/application/traits"
+)
+
+// Translator resolves translation lines through a loader.
+//
+// Keys come in three shapes:
+//   - "Welcome back!": JSON string translations from lang/{locale}.json
+//   - "validation.required": item "required" of group file lang/{locale}/validation.json
+//   - "courier::mail.sent": item "sent" of group "mail" in the "courier" namespace
+//
+// Lookups walk the locale chain of the requested locale: the locale itself,
+// its parent locales (pt_BR -> pt), then the fallback locale and its parents.
+// Loaded groups are cached for the lifetime of the translator.
+type Translator struct {
+	mu sync.RWMutex
+
+	// loader reads translation lines
+	loader interfaces.LoaderInterface
+
+	// selector picks plural forms
+	selector *MessageSelector
+
+	// locale is the default locale, used when no source is set
+	locale string
+
+	// fallback is the fallback locale, used when no source is set
+	fallback string
+
+	// source provides the locales when set, usually the application
+	source traitInterfaces.LocalizableInterface
+
+	// missing is called with the key and locale of failed lookups
+	missing func(key, locale string)
+
+	// loaded caches lines keyed by namespace, group and locale
+	loaded map[string]map[string]map[string]map[string]interface{}
+}
+
+// NewTranslator creates a translator.
+//
+// Parameters:
+//   - loader: Loader reading translation lines
+//   - locale: Default locale, e.g. "en"
+//
+// Example:
+//
+//	translator := translation.NewTranslator(loaders.NewFileLoader("lang"), "en")
+//	translator.SetFallback("en")
+//	translator.Trans("messages.welcome", map[string]interface{}{"name": "Taylor"})
+func NewTranslator(loader interfaces.LoaderInterface, locale string) *Translator {
+	return &Translator{
+		loader:   loader,
+		selector: NewMessageSelector(),
+		locale:   locale,
+		loaded:   make(map[string]map[string]map[string]map[string]interface{}),
+	}
+}
+
+// UseLocaleOf makes the translator read and write its locale and fallback
+// locale through source, usually the application, so App.SetLocale changes
+// the language of every subsequent translation.
+func (t *Translator) UseLocaleOf(source traitInterfaces.LocalizableInterface) *Translator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.source = source
+	return t
+}
+
+// Get returns the translation for the given key. See LanguageInterface.Get.
+func (t *Translator) Get(key string, replace map[string]interface{}, locale string, fallback bool) interface{} {
+	return t.get(key, replace, locale, fallback, t.GetFallback())
+}
+
+// get is Get falling back to fallbackLocale.
+func (t *Translator) get(key string, replace map[string]interface{}, locale string, fallback bool, fallbackLocale string) interface{} {
+	if locale == "" {
+		locale = t.GetLocale()
+	}
+
+	if line, ok := t.resolve(key, replace, locale, fallback, fallbackLocale); ok {
+		return line
+	}
+
+	t.mu.RLock()
+	handler := t.missing
+	t.mu.RUnlock()
+	if handler != nil {
+		handler(key, locale)
+	}
+
+	return t.MakeReplacements(key, replace)
+}
+
+// resolve looks the key up through the locale chain of locale, reporting
+// whether a line was found.
+func (t *Translator) resolve(key string, replace map[string]interface{}, locale string, fallback bool, fallbackLocale string) (interface{}, bool) {
+	locales := []string{locale}
+	if fallback {
+		locales = t.localeChain(locale, fallbackLocale)
+	}
+
+	namespace, group, item := t.ParseKey(key)
+
+	for _, candidate := range locales {
+		// JSON string translations take precedence, as in Laravel
+		if line, ok := t.lines("*", "*", candidate)[key].(string); ok {
+			return t.MakeReplacements(line, replace), true
+		}
+
+		if line := t.getLine(namespace, group, candidate, item, replace); line != nil {
+			return line, true
+		}
+	}
+
+	return nil, false
+}
+
+// HandleMissingKeysUsing registers a handler called with the key and the
+// requested locale whenever Get finds no translation. Has and HasForLocale
+// never trigger it. Passing nil removes the handler.
+//
+// Example:
+//
+//	translator.HandleMissingKeysUsing(translation.NewMissingKeyLogger(app.GetLogger()).Handle)
+func (t *Translator) HandleMissingKeysUsing(handler func(key, locale string)) *Translator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.missing = handler
+	return t
+}
+
+// Trans translates a key to a string, returning the key when missing or when
+// the key names a group of lines.
+func (t *Translator) Trans(key string, replace map[string]interface{}, locale ...string) string {
+	if line, ok := t.Get(key, replace, firstLocale(locale), true).(string); ok {
+		return line
+	}
+	return key
+}
+
+// Choice translates a key according to an integer value, selecting the
+// plural form with the rules of the locale the line was found in. The
+// ":count" placeholder is replaced with number.
+func (t *Translator) Choice(key string, number int, replace map[string]interface{}, locale ...string) string {
+	return t.choice(key, number, replace, firstLocale(locale), t.GetFallback())
+}
+
+// choice is Choice falling back to fallbackLocale.
+func (t *Translator) choice(key string, number int, replace map[string]interface{}, locale, fallbackLocale string) string {
+	selected := t.localeForChoice(key, locale, fallbackLocale)
+
+	line, ok := t.get(key, nil, selected, true, fallbackLocale).(string)
+	if !ok {
+		line = key
+	}
+
+	replacements := make(map[string]interface{}, len(replace)+1)
+	for name, value := range replace {
+		replacements[name] = value
+	}
+	replacements["count"] = number
+
+	return t.MakeReplacements(t.selector.Choose(line, number, selected), replacements)
+}
+
+// TransChoice translates a key according to an integer value in the current locale.
+func (t *Translator) TransChoice(key string, number int, replace ...map[string]interface{}) string {
+	var replacements map[string]interface{}
+	if len(replace) > 0 {
+		replacements = replace[0]
+	}
+	return t.Choice(key, number, replacements)
+}
+
+// Has reports whether a translation exists for the key, using the fallback
+// locale chain.
+func (t *Translator) Has(key string, locale ...string) bool {
+	return t.exists(key, firstLocale(locale), true, t.GetFallback())
+}
+
+// HasForLocale reports whether a translation exists for the key in the
+// given locale, without falling back.
+func (t *Translator) HasForLocale(key, locale string) bool {
+	return t.exists(key, locale, false, "")
+}
+
+// exists reports whether the key resolves to a translation.
+func (t *Translator) exists(key, locale string, fallback bool, fallbackLocale string) bool {
+	if locale == "" {
+		locale = t.GetLocale()
+	}
+	_, ok := t.resolve(key, nil, locale, fallback, fallbackLocale)
+	return ok
+}
+
+// localeForChoice returns the first locale of the chain holding the key,
+// so plural rules match the language of the selected line.
+func (t *Translator) localeForChoice(key, locale, fallbackLocale string) string {
+	if locale == "" {
+		locale = t.GetLocale()
+	}
+
+	for _, candidate := range t.localeChain(locale, fallbackLocale) {
+		if t.HasForLocale(key, candidate) {
+			return candidate
+		}
+	}
+	return locale
+}
+
+// getLine returns a group line, or a map of lines when item names a
+// sub-tree, with replacements applied. Missing lines yield nil.
+func (t *Translator) getLine(namespace, group, locale, item string, replace map[string]interface{}) interface{} {
+	lines := t.lines(namespace, group, locale)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	var line interface{} = lines
+	if item != "" {
+		line = lookup(lines, item)
+	}
+
+	switch value := line.(type) {
+	case string:
+		return t.MakeReplacements(value, replace)
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+		return t.replaceAll(value, replace)
+	default:
+		return nil
+	}
+}
+
+// replaceAll applies replacements to every string of a nested map.
+func (t *Translator) replaceAll(lines map[string]interface{}, replace map[string]interface{}) map[string]interface{} {
+	replaced := make(map[string]interface{}, len(lines))
+	for key, value := range lines {
+		switch value := value.(type) {
+		case string:
+			replaced[key] = t.MakeReplacements(value, replace)
+		case map[string]interface{}:
+			replaced[key] = t.replaceAll(value, replace)
+		default:
+			replaced[key] = value
+		}
+	}
+	return replaced
+}
+
+// lookup resolves a dotted item in nested lines. Literal keys containing
+// dots take precedence over nested lookups.
+func lookup(lines map[string]interface{}, item string) interface{} {
+	if value, ok := lines[item]; ok {
+		return value
+	}
+
+	var current interface{} = lines
+	for _, segment := range strings.Split(item, ".") {
+		nested, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if current, ok = nested[segment]; !ok {
+			return nil
+		}
+	}
+	return current
+}
+
+// tagPattern builds the pattern matching "<name>...</name>" wrappers.
+func tagPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`<` + regexp.QuoteMeta(name) + `>(.*?)</` + regexp.QuoteMeta(name) + `>`)
+}
+
+// MakeReplacements replaces ":name" placeholders in a line. Each placeholder
+// also matches ":Name" and ":NAME", replaced by the value with its first
+// letter or all letters upper-cased. A func(string) string value wraps the
+// text between "<name>" and "</name>" tags instead.
+//
+// Example:
+//
+//	translator.MakeReplacements("Hello :Name, <link>click</link>", map[string]interface{}{
+//		"name": "taylor",
+//		"link": func(text string) string { return `<a href="/">` + text + `</a>` },
+//	}) // Hello Taylor, <a href="/">click</a>
+func (t *Translator) MakeReplacements(line string, replace map[string]interface{}) string {
+	if len(replace) == 0 {
+		return line
+	}
+
+	replacements := make(map[string]string, len(replace)*3)
+	for key, value := range replace {
+		if wrap, ok := value.(func(string) string); ok {
+			pattern := tagPattern(key)
+			line = pattern.ReplaceAllStringFunc(line, func(match string) string {
+				return wrap(pattern.FindStringSubmatch(match)[1])
+			})
+			continue
+		}
+
+		text := ""
+		if value != nil {
+			text = fmt.Sprint(value)
+		}
+		replacements[":"+upperFirst(key)] = upperFirst(text)
+		replacements[":"+strings.ToUpper(key)] = strings.ToUpper(text)
+		replacements[":"+key] = text
+	}
+
+	// Longest placeholders first, so ":name" never shadows ":name_plural"
+	placeholders := make([]string, 0, len(replacements))
+	for placeholder := range replacements {
+		placeholders = append(placeholders, placeholder)
+	}
+	sort.Slice(placeholders, func(i, j int) bool {
+		if len(placeholders[i]) != len(placeholders[j]) {
+			return len(placeholders[i]) > len(placeholders[j])
+		}
+		return placeholders[i] < placeholders[j]
+	})
+
+	pairs := make([]string, 0, len(placeholders)*2)
+	for _, placeholder := range placeholders {
+		pairs = append(pairs, placeholder, replacements[placeholder])
+	}
+	return strings.NewReplacer(pairs...).Replace(line)
+}
+
+// upperFirst upper-cases the first letter of s.
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// lines returns the cached lines of a group, loading them on first use.
+// Loader errors leave the group empty; call Load to surface them.
+func (t *Translator) lines(namespace, group, locale string) map[string]interface{} {
+	t.mu.RLock()
+	lines, ok := t.loaded[namespace][group][locale]
+	t.mu.RUnlock()
+
+	if !ok {
+		_ = t.Load(namespace, group, locale)
+
+		t.mu.RLock()
+		lines = t.loaded[namespace][group][locale]
+		t.mu.RUnlock()
+	}
+	return lines
+}
+
+// Load loads a group of translations into memory. Already loaded groups
+// are left untouched.
+func (t *Translator) Load(namespace, group, locale string) error {
+	if t.isLoaded(namespace, group, locale) {
+		return nil
+	}
+
+	lines, err := t.loader.Load(locale, group, namespace)
+	if err != nil {
+		lines = nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.loaded[namespace][group][locale]; !ok {
+		t.store(namespace, group, locale, lines)
+	}
+	return err
+}
+
+// isLoaded reports whether a group has been loaded.
+func (t *Translator) isLoaded(namespace, group, locale string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.loaded[namespace][group][locale]
+	return ok
+}
+
+// store caches lines; the caller holds the write lock.
+func (t *Translator) store(namespace, group, locale string, lines map[string]interface{}) {
+	if t.loaded[namespace] == nil {
+		t.loaded[namespace] = make(map[string]map[string]map[string]interface{})
+	}
+	if t.loaded[namespace][group] == nil {
+		t.loaded[namespace][group] = make(map[string]map[string]interface{})
+	}
+	if lines == nil {
+		lines = make(map[string]interface{})
+	}
+	t.loaded[namespace][group][locale] = lines
+}
+
+// AddLines adds translation lines at runtime. Keys are "group.item" for
+// group translations, or the string itself for JSON translations when
+// namespace is "*" and the key has no group.
+//
+// Example:
+//
+//	translator.AddLines(map[string]string{"messages.welcome": "Bienvenue"}, "fr")
+func (t *Translator) AddLines(lines map[string]string, locale string, namespace ...string) {
+	ns := "*"
+	if len(namespace) > 0 && namespace[0] != "" {
+		ns = namespace[0]
+	}
+
+	for key, value := range lines {
+		group, item, ok := strings.Cut(key, ".")
+		if !ok {
+			group, item = "*", key
+		}
+
+		// Load first, so files never overwrite lines added at runtime
+		_ = t.Load(ns, group, locale)
+
+		t.mu.Lock()
+		copied := make(map[string]interface{}, len(t.loaded[ns][group][locale])+1)
+		for name, line := range t.loaded[ns][group][locale] {
+			copied[name] = line
+		}
+		copied[item] = value
+		t.store(ns, group, locale, copied)
+		t.mu.Unlock()
+	}
+}
+
+// AddNamespace registers a directory holding a package's translations.
+func (t *Translator) AddNamespace(namespace, hint string) {
+	t.loader.AddNamespace(namespace, hint)
+	t.forget(namespace)
+}
+
+// AddJSONPath registers an additional directory holding JSON translations.
+func (t *Translator) AddJSONPath(path string) {
+	t.loader.AddJSONPath(path)
+	t.forget("*")
+}
+
+// forget drops the cached groups of a namespace.
+func (t *Translator) forget(namespace string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.loaded, namespace)
+}
+
+// ParseKey splits a key into its namespace, group and item:
+//
+//	"courier::mail.sent" -> "courier", "mail", "sent"
+//	"validation.required" -> "*", "validation", "required"
+//	"validation" -> "*", "validation", ""
+func (t *Translator) ParseKey(key string) (namespace, group, item string) {
+	namespace = "*"
+	if ns, rest, ok := strings.Cut(key, "::"); ok {
+		namespace, key = ns, rest
+	}
+
+	group, item, _ = strings.Cut(key, ".")
+	return namespace, group, item
+}
+
+// LocaleChain returns the locales tried for a lookup, in order: the locale,
+// its parent locales, then the fallback locale and its parents.
+//
+// Example:
+//
+//	translator.SetFallback("en")
+//	translator.LocaleChain("pt_BR") // [pt_BR pt en]
+func (t *Translator) LocaleChain(locale string) []string {
+	return t.localeChain(locale, t.GetFallback())
+}
+
+// localeChain is LocaleChain falling back to fallbackLocale.
+func (t *Translator) localeChain(locale, fallbackLocale string) []string {
+	if locale == "" {
+		locale = t.GetLocale()
+	}
+
+	var chain []string
+	seen := make(map[string]bool)
+	for _, candidate := range []string{locale, fallbackLocale} {
+		for candidate != "" {
+			if !seen[candidate] {
+				seen[candidate] = true
+				chain = append(chain, candidate)
+			}
+			candidate = parentLocale(candidate)
+		}
+	}
+	return chain
+}
+
+// parentLocale strips the last subtag of a locale: "zh_Hant_TW" -> "zh_Hant" -> "zh".
+func parentLocale(locale string) string {
+	if i := strings.LastIndexAny(locale, "_-"); i > 0 {
+		return locale[:i]
+	}
+	return ""
+}
+
+// GetLocale returns the default locale being used.
+func (t *Translator) GetLocale() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.source != nil {
+		return t.source.GetLocaleWithFallback()
+	}
+	return t.locale
+}
+
+// SetLocale sets the default locale.
+func (t *Translator) SetLocale(locale string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.source != nil {
+		t.source.SetLocale(locale)
+		return
+	}
+	t.locale = locale
+}
+
+// GetFallback returns the fallback locale being used.
+func (t *Translator) GetFallback() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.source != nil {
+		return t.source.GetFallbackLocale()
+	}
+	return t.fallback
+}
+
+// SetFallback sets the fallback locale being used.
+func (t *Translator) SetFallback(fallback string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.source != nil {
+		t.source.SetFallbackLocale(fallback)
+		return
+	}
+	t.fallback = fallback
+}
+
+// GetLoader returns the loader used by the translator.
+func (t *Translator) GetLoader() interfaces.LoaderInterface {
+	return t.loader
+}
+
+// GetSelector returns the message selector used for plural forms.
+func (t *Translator) GetSelector() *MessageSelector {
+	return t.selector
+}
+
+// SetSelector replaces the message selector used for plural forms.
+func (t *Translator) SetSelector(selector *MessageSelector) {
+	t.selector = selector
+}
+
+// firstLocale returns the first optional locale argument, or "".
+func firstLocale(locale []string) string {
+	if len(locale) > 0 {
+		return locale[0]
+	}
+	return ""
+}
+
+// Ensure Translator implements the TranslatorInterface
+var _ interfaces.TranslatorInterface = (*Translator)(nil)
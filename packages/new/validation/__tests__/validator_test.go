@@ -0,0 +1,351 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	translation "govel/new/translation"
+	"govel/new/translation/loaders"
+	validation "govel/new/validation"
+)
+
+func TestValidator_BasicRulesAndMessages(t *testing.T) {
+	factory := validation.NewFactory(nil)
+
+	validator := factory.Validator(map[string]interface{}{
+		"name":                  "Al",
+		"email":                 "not-an-email",
+		"role":                  "guest",
+		"age":                   "17",
+		"password":              "secret123",
+		"password_confirmation": "secret124",
+		"tags":                  []interface{}{"a", "b", "c"},
+	}, map[string]interface{}{
+		"name":     "required|string|min:3|max:255",
+		"email":    "required|email",
+		"role":     validation.In("admin", "editor"),
+		"age":      "required|integer|min:18",
+		"password": "required|confirmed",
+		"tags":     "array|max:2",
+		"missing":  "required",
+	})
+
+	if validator.Passes() {
+		t.Fatal("expected validation to fail")
+	}
+
+	expected := map[string]string{
+		"name":     "The name field must be at least 3 characters.",
+		"email":    "The email field must be a valid email address.",
+		"role":     "The selected role is invalid.",
+		"age":      "The age field must be at least 18.",
+		"password": "The password field confirmation does not match.",
+		"tags":     "The tags field must not have more than 2 items.",
+		"missing":  "The missing field is required.",
+	}
+	errs := validator.Errors()
+	if len(errs) != len(expected) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	for attribute, message := range expected {
+		if len(errs[attribute]) != 1 || errs[attribute][0] != message {
+			t.Fatalf("expected %q for %s, got %v", message, attribute, errs[attribute])
+		}
+	}
+
+	var validationErr *validation.ValidationError
+	if err := validator.Validate(); !errors.As(err, &validationErr) || validationErr.StatusCode() != 422 {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+}
+
+func TestValidator_ImplicitBailNullableAndSometimes(t *testing.T) {
+	factory := validation.NewFactory(nil)
+
+	validator := factory.Validator(map[string]interface{}{
+		"title":    "",
+		"code":     "x",
+		"nickname": nil,
+	}, map[string]interface{}{
+		"title":    "required|min:3",
+		"code":     "bail|integer|min:5",
+		"nickname": "nullable|string|min:3",
+		"bio":      "sometimes|required|string",
+		"website":  "url",
+	})
+
+	errs := validator.Errors()
+	if len(errs["title"]) != 1 || len(errs["code"]) != 1 {
+		t.Fatalf("expected a single failure per attribute, got %v", errs)
+	}
+	if _, failed := errs["nickname"]; failed {
+		t.Fatalf("expected a nullable nil to pass, got %v", errs["nickname"])
+	}
+	if _, failed := errs["bio"]; failed {
+		t.Fatal("expected sometimes to skip the missing attribute")
+	}
+	if _, failed := errs["website"]; failed {
+		t.Fatal("expected non-implicit rules to skip missing attributes")
+	}
+}
+
+func TestValidator_NestedWildcardsAndValidated(t *testing.T) {
+	factory := validation.NewFactory(nil)
+	data := map[string]interface{}{
+		"team": "Core",
+		"users": []interface{}{
+			map[string]interface{}{"email": "ann@example.com", "roles": []interface{}{"admin"}, "admin": true},
+			map[string]interface{}{"email": "bob", "roles": []interface{}{"editor", "owner"}},
+		},
+		"ignored": "value",
+	}
+	rules := map[string]interface{}{
+		"team":            "required|string",
+		"users":           "required|array|min:1",
+		"users.*.email":   "required|email|distinct",
+		"users.*.roles.*": "in:admin,editor",
+	}
+
+	validator := factory.Validator(data, rules)
+	errs := validator.Errors()
+	if len(errs) != 2 || len(errs["users.1.email"]) != 1 || len(errs["users.1.roles.1"]) != 1 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if errs["users.1.email"][0] != "The users.1.email field must be a valid email address." {
+		t.Fatalf("unexpected message %q", errs["users.1.email"][0])
+	}
+	if !validator.MessageBag().Has("users.*.email") {
+		t.Fatal("expected wildcard lookups on the message bag")
+	}
+
+	data["users"].([]interface{})[1] = map[string]interface{}{"email": "bob@example.com", "roles": []interface{}{"editor"}}
+	rules["users"] = "required|min:1"
+	validated, err := factory.Validator(data, rules).Validated()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"team": "Core",
+		"users": []interface{}{
+			map[string]interface{}{"email": "ann@example.com", "roles": []interface{}{"admin"}, "admin": true},
+			map[string]interface{}{"email": "bob@example.com", "roles": []interface{}{"editor"}},
+		},
+	}
+	if !reflect.DeepEqual(validated, expected) {
+		t.Fatalf("unexpected validated data %#v", validated)
+	}
+
+	validated, err = factory.Validator(data, map[string]interface{}{"users.*.email": "required"}).Validated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"email": "ann@example.com"},
+			map[string]interface{}{"email": "bob@example.com"},
+		},
+	}
+	if !reflect.DeepEqual(validated, expected) {
+		t.Fatalf("expected only the validated keys, got %#v", validated)
+	}
+}
+
+func TestValidator_Dates(t *testing.T) {
+	factory := validation.NewFactory(nil)
+
+	validator := factory.Validator(map[string]interface{}{
+		"start":    "2024-05-10",
+		"end":      "2024-05-01",
+		"birthday": "10/05/2024",
+		"future":   "2000-01-01",
+		"when":     "not a date",
+	}, map[string]interface{}{
+		"start":    "date|date_format:Y-m-d",
+		"end":      "date|after:start",
+		"birthday": "date_format:Y-m-d",
+		"future":   "after:tomorrow",
+		"when":     "date",
+	})
+
+	errs := validator.Errors()
+	for _, attribute := range []string{"end", "birthday", "future", "when"} {
+		if len(errs[attribute]) != 1 {
+			t.Fatalf("expected %s to fail, got %v", attribute, errs)
+		}
+	}
+	if _, failed := errs["start"]; failed {
+		t.Fatalf("expected start to pass, got %v", errs["start"])
+	}
+	if errs["end"][0] != "The end field must be a date after start." {
+		t.Fatalf("unexpected message %q", errs["end"][0])
+	}
+}
+
+type fakeVerifier struct {
+	rows map[string][]map[string]string
+}
+
+func (f *fakeVerifier) GetCount(ctx context.Context, table string, column string, value interface{}, excludeID interface{}, idColumn string, extra map[string]string) (int64, error) {
+	var count int64
+	for _, row := range f.rows[table] {
+		if row[column] != fmt.Sprint(value) {
+			continue
+		}
+		if excludeID != nil && row[idColumn] == fmt.Sprint(excludeID) {
+			continue
+		}
+		if matchesExtra(row, extra) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeVerifier) GetMultiCount(ctx context.Context, table string, column string, values []interface{}, extra map[string]string) (int64, error) {
+	var count int64
+	for _, value := range values {
+		if found, _ := f.GetCount(ctx, table, column, value, nil, "", extra); found > 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matchesExtra(row map[string]string, extra map[string]string) bool {
+	for column, value := range extra {
+		if row[column] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func TestValidator_UniqueAndExists(t *testing.T) {
+	factory := validation.NewFactory(nil).SetPresenceVerifier(&fakeVerifier{rows: map[string][]map[string]string{
+		"users": {
+			{"id": "1", "email": "ann@example.com", "tenant_id": "1"},
+			{"id": "2", "email": "bob@example.com", "tenant_id": "2"},
+		},
+		"roles": {{"id": "1", "name": "admin"}, {"id": "2", "name": "editor"}},
+	}})
+
+	validator := factory.Validator(map[string]interface{}{
+		"email":       "ann@example.com",
+		"other_email": "bob@example.com",
+		"own_email":   "ann@example.com",
+		"role":        "owner",
+		"roles":       []interface{}{"admin", "editor"},
+	}, map[string]interface{}{
+		"email":       "unique:users",
+		"other_email": []interface{}{validation.Unique("users", "email").Where("tenant_id", 1)},
+		"own_email":   []interface{}{validation.Unique("users", "email").Ignore(1)},
+		"role":        "exists:roles,name",
+		"roles":       "array|exists:roles,name",
+	})
+
+	errs := validator.Errors()
+	if len(errs) != 2 || errs["email"][0] != "The email has already been taken." || errs["role"][0] != "The selected role is invalid." {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+
+	if err := validation.NewFactory(nil).Validator(map[string]interface{}{"email": "a@b.c"}, map[string]interface{}{"email": "unique:users"}).Validate(); err == nil || errors.As(err, new(*validation.ValidationError)) {
+		t.Fatalf("expected a missing verifier error, got %v", err)
+	}
+}
+
+type uppercase struct{}
+
+func (uppercase) Passes(attribute string, value interface{}) bool {
+	text, _ := value.(string)
+	return text == strings.ToUpper(text)
+}
+
+func (uppercase) Message() string {
+	return "The :attribute must be uppercase."
+}
+
+func TestValidator_CustomRulesMessagesAndTranslations(t *testing.T) {
+	loader := loaders.NewArrayLoader().AddMessages("es", "validation", map[string]interface{}{
+		"required": "El campo :attribute es obligatorio.",
+		"min": map[string]interface{}{
+			"string": "El campo :attribute debe tener al menos :min caracteres.",
+		},
+		"attributes": map[string]interface{}{"name": "nombre"},
+		"custom": map[string]interface{}{
+			"email": map[string]interface{}{"required": "Necesitamos tu correo."},
+		},
+	})
+	factory := validation.NewFactory(translation.NewTranslator(loader, "es"))
+	factory.Extend("phone", func(attribute string, value interface{}, parameters []string) bool {
+		text, _ := value.(string)
+		return strings.HasPrefix(text, "+")
+	}, "The :attribute must be a valid phone number.")
+
+	validator := factory.Validator(map[string]interface{}{
+		"name":  "Al",
+		"code":  "abc",
+		"phone": "555",
+		"slug":  "a b",
+		"zip":   "12",
+	}, map[string]interface{}{
+		"name":  "required|min:3",
+		"email": "required",
+		"title": "required",
+		"code":  []interface{}{"required", uppercase{}},
+		"phone": "phone",
+		"slug": []interface{}{validation.Func("The :attribute may not contain spaces.", func(attribute string, value interface{}) bool {
+			return !strings.Contains(value.(string), " ")
+		})},
+		"zip": "digits:5",
+	}, map[string]string{
+		"zip.digits": "Postal codes have :digits digits.",
+	})
+	validator.SetAttributeNames(map[string]string{"title": "título"})
+
+	expected := map[string]string{
+		"name":  "El campo nombre debe tener al menos 3 caracteres.",
+		"email": "Necesitamos tu correo.",
+		"title": "El campo título es obligatorio.",
+		"code":  "The code must be uppercase.",
+		"phone": "The phone must be a valid phone number.",
+		"slug":  "The slug may not contain spaces.",
+		"zip":   "Postal codes have 5 digits.",
+	}
+	errs := validator.Errors()
+	for attribute, message := range expected {
+		if len(errs[attribute]) != 1 || errs[attribute][0] != message {
+			t.Fatalf("expected %q for %s, got %v", message, attribute, errs[attribute])
+		}
+	}
+
+	if err := factory.Validator(map[string]interface{}{"a": 1}, map[string]interface{}{"a": "unknown_rule"}).Validate(); err == nil || !strings.Contains(err.Error(), "unknown_rule") {
+		t.Fatalf("expected an unknown rule error, got %v", err)
+	}
+}
+
+func TestValidator_AfterHooksAndSometimes(t *testing.T) {
+	factory := validation.NewFactory(nil)
+
+	validator := factory.Validator(map[string]interface{}{
+		"plan":  "premium",
+		"start": 5,
+		"end":   3,
+	}, map[string]interface{}{
+		"start": "integer",
+		"end":   "integer|gt:start",
+	}).Sometimes("card", "required", func(data map[string]interface{}) bool {
+		return data["plan"] == "premium"
+	}).After(func(v *validation.Validator) {
+		v.AddError("plan", "Premium plans are closed.")
+	})
+
+	errs := validator.Errors()
+	if errs["end"][0] != "The end field must be greater than 5." || errs["card"][0] != "The card field is required." || errs["plan"][0] != "Premium plans are closed." {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+}
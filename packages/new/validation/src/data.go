@@ -0,0 +1,216 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// getValue returns the value at a dotted path such as "users.0.email",
+// reporting whether it exists
+func getValue(data interface{}, path string) (interface{}, bool) {
+	current := data
+	for _, segment := range strings.Split(path, ".") {
+		child, ok := childOf(current, segment)
+		if !ok {
+			return nil, false
+		}
+		current = child
+	}
+	return current, true
+}
+
+// childOf returns the element of a map or slice by key or index
+func childOf(container interface{}, key string) (interface{}, bool) {
+	switch typed := container.(type) {
+	case map[string]interface{}:
+		value, ok := typed[key]
+		return value, ok
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(typed) {
+			return nil, false
+		}
+		return typed[index], true
+	}
+
+	value := reflect.ValueOf(container)
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		element := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+		if !element.IsValid() {
+			return nil, false
+		}
+		return element.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= value.Len() {
+			return nil, false
+		}
+		return value.Index(index).Interface(), true
+	}
+	return nil, false
+}
+
+// keysOf returns the keys of a map, sorted, or the indexes of a slice
+func keysOf(container interface{}) []string {
+	value := reflect.ValueOf(container)
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		keys := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		return keys
+	case reflect.Slice, reflect.Array:
+		keys := make([]string, value.Len())
+		for idx := range keys {
+			keys[idx] = strconv.Itoa(idx)
+		}
+		return keys
+	}
+	return nil
+}
+
+// expandWildcards replaces each "*" of an attribute with the keys present
+// in the data, e.g. "users.*.email" becomes "users.0.email" and
+// "users.1.email". Attributes without wildcards are returned unchanged.
+func expandWildcards(data interface{}, attribute string) []string {
+	if !strings.Contains(attribute, "*") {
+		return []string{attribute}
+	}
+
+	segments := strings.Split(attribute, ".")
+	var expanded []string
+	var walk func(current interface{}, prefix []string, rest []string)
+	walk = func(current interface{}, prefix []string, rest []string) {
+		if len(rest) == 0 {
+			expanded = append(expanded, strings.Join(prefix, "."))
+			return
+		}
+
+		segment := rest[0]
+		if segment != "*" {
+			child, _ := childOf(current, segment)
+			walk(child, append(prefix, segment), rest[1:])
+			return
+		}
+
+		for _, key := range keysOf(current) {
+			child, _ := childOf(current, key)
+			walk(child, append(append([]string(nil), prefix...), key), rest[1:])
+		}
+	}
+	walk(data, nil, segments)
+	return expanded
+}
+
+// matchesPattern reports whether an expanded attribute matches a pattern
+// with "*" wildcards
+func matchesPattern(pattern string, attribute string) bool {
+	if pattern == attribute {
+		return true
+	}
+	if !strings.Contains(pattern, "*") {
+		return false
+	}
+
+	patternSegments := strings.Split(pattern, ".")
+	segments := strings.Split(attribute, ".")
+	if len(patternSegments) != len(segments) {
+		return false
+	}
+	for idx, segment := range patternSegments {
+		if segment != "*" && segment != segments[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceWildcards fills the "*" segments of a related attribute with the
+// indexes of the attribute under validation, so "items.*.confirm" used
+// while validating "items.2.password" becomes "items.2.confirm"
+func replaceWildcards(related string, pattern string, attribute string) string {
+	if !strings.Contains(related, "*") {
+		return related
+	}
+
+	patternSegments := strings.Split(pattern, ".")
+	segments := strings.Split(attribute, ".")
+	var indexes []string
+	for idx, segment := range patternSegments {
+		if segment == "*" && idx < len(segments) {
+			indexes = append(indexes, segments[idx])
+		}
+	}
+
+	relatedSegments := strings.Split(related, ".")
+	for idx, segment := range relatedSegments {
+		if segment == "*" && len(indexes) > 0 {
+			relatedSegments[idx] = indexes[0]
+			indexes = indexes[1:]
+		}
+	}
+	return strings.Join(relatedSegments, ".")
+}
+
+// pathTree marks the validated paths; a nil subtree takes the whole value
+type pathTree map[string]pathTree
+
+// add marks a dotted path, keeping shorter paths that already take the
+// whole value
+func (t pathTree) add(path string) {
+	node := t
+	segments := strings.Split(path, ".")
+	for idx, segment := range segments {
+		child, exists := node[segment]
+		if exists && child == nil {
+			return
+		}
+		if idx == len(segments)-1 {
+			node[segment] = nil
+			return
+		}
+		if !exists {
+			child = pathTree{}
+			node[segment] = child
+		}
+		node = child
+	}
+}
+
+// extract copies the marked paths of a value, keeping slices as slices
+func (t pathTree) extract(value interface{}) interface{} {
+	if t == nil {
+		return value
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array {
+		items := make([]interface{}, 0, len(t))
+		for _, key := range keysOf(value) {
+			if subtree, marked := t[key]; marked {
+				child, _ := childOf(value, key)
+				items = append(items, subtree.extract(child))
+			}
+		}
+		return items
+	}
+
+	result := make(map[string]interface{}, len(t))
+	for key, subtree := range t {
+		if child, ok := childOf(value, key); ok {
+			result[key] = subtree.extract(child)
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// errNoPresenceVerifier is returned by the unique and exists rules when
+// the factory has no presence verifier
+var errNoPresenceVerifier = errors.New("validation: the unique and exists rules require a presence verifier")
+
+// validateUnique implements "unique:table,column,except,idColumn,col,value,..."
+func validateUnique(c *ruleContext, value interface{}) (bool, error) {
+	verifier := c.validator.factory.PresenceVerifier()
+	if verifier == nil {
+		return false, errNoPresenceVerifier
+	}
+
+	var excludeID interface{}
+	idColumn := "id"
+	if except := c.parameter(2); except != "" && except != "NULL" {
+		excludeID = except
+	}
+	if column := c.parameter(3); column != "" {
+		idColumn = column
+	}
+
+	count, err := verifier.GetCount(c.validator.ctx, c.parameter(0), c.column(), value, excludeID, idColumn, extraConditions(c.parameters, 4))
+	return count == 0, err
+}
+
+// validateExists implements "exists:table,column,col,value,..."; every
+// element of a slice value must exist
+func validateExists(c *ruleContext, value interface{}) (bool, error) {
+	verifier := c.validator.factory.PresenceVerifier()
+	if verifier == nil {
+		return false, errNoPresenceVerifier
+	}
+	extra := extraConditions(c.parameters, 2)
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Slice {
+		values := make([]interface{}, 0, reflected.Len())
+		seen := make(map[string]bool)
+		for idx := 0; idx < reflected.Len(); idx++ {
+			element := reflected.Index(idx).Interface()
+			if key := stringOf(element); !seen[key] {
+				seen[key] = true
+				values = append(values, element)
+			}
+		}
+		if len(values) == 0 {
+			return true, nil
+		}
+		count, err := verifier.GetMultiCount(c.validator.ctx, c.parameter(0), c.column(), values, extra)
+		return count >= int64(len(values)), err
+	}
+
+	count, err := verifier.GetCount(c.validator.ctx, c.parameter(0), c.column(), value, nil, "", extra)
+	return count > 0, err
+}
+
+// column returns the column of a database rule, defaulting to the last
+// segment of the attribute
+func (c *ruleContext) column() string {
+	if column := c.parameter(1); column != "" && column != "NULL" {
+		return column
+	}
+	segments := strings.Split(c.attribute, ".")
+	return segments[len(segments)-1]
+}
+
+// extraConditions reads column/value pairs from the parameters
+func extraConditions(parameters []string, offset int) map[string]string {
+	extra := make(map[string]string)
+	for idx := offset; idx+1 < len(parameters); idx += 2 {
+		extra[parameters[idx]] = parameters[idx+1]
+	}
+	return extra
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ValidationError is returned by Validate and Validated when a rule failed
+type ValidationError struct {
+	// Bag holds the failure messages
+	Bag *MessageBag
+}
+
+// Error returns the first message and the number of other failures
+func (e *ValidationError) Error() string {
+	count := e.Bag.Count()
+	switch count {
+	case 0:
+		return "The given data was invalid."
+	case 1:
+		return e.Bag.First()
+	case 2:
+		return fmt.Sprintf("%s (and 1 more error)", e.Bag.First())
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e.Bag.First(), count-1)
+}
+
+// Errors returns the failure messages keyed by attribute
+func (e *ValidationError) Errors() map[string][]string {
+	return e.Bag.Messages()
+}
+
+// StatusCode returns 422 Unprocessable Entity, the status of invalid input
+func (e *ValidationError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// UnknownRuleError is returned when a rule name is neither built in nor
+// registered with Extend
+type UnknownRuleError struct {
+	// Rule is the unknown rule name
+	Rule string
+}
+
+// Error implements error
+func (e *UnknownRuleError) Error() string {
+	return fmt.Sprintf("validation: unknown rule %q", e.Rule)
+}
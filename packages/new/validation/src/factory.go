@@ -0,0 +1,146 @@
+// Package validation validates data with Laravel-style rules:
+//
+//	validator := factory.Validator(data, map[string]interface{}{
+//		"email":         "required|email|max:255|unique:users,email",
+//		"password":      "required|min:8|confirmed",
+//		"role":          validation.In("admin", "editor"),
+//		"tags":          "array",
+//		"users.*.email": "required|email",
+//	})
+//	validated, err := validator.Validated()
+//
+// Failure messages come from the "validation" translation group, so
+// applications override and localize them in lang/{locale}/validation.json.
+package validation
+
+import (
+	"sync"
+
+	translation "govel/new/translation"
+	"govel/new/translation/lang/en"
+	"govel/new/translation/loaders"
+	langInterfaces "govel/types/interfaces/lang"
+	validationInterfaces "govel/types/interfaces/validation"
+)
+
+// extension is a rule registered with Extend
+type extension struct {
+	// rule reports whether the value passes
+	rule func(attribute string, value interface{}, parameters []string) bool
+
+	// message is the default failure message
+	message string
+
+	// implicit rules run for missing or empty attributes
+	implicit bool
+}
+
+// Factory creates validators sharing a translator, a presence verifier and
+// custom rules. It is safe for concurrent use; validators are not.
+type Factory struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// translator provides the failure messages
+	translator langInterfaces.LanguageInterface
+
+	// verifier backs the unique and exists rules
+	verifier PresenceVerifier
+
+	// extensions are the custom rules by name
+	extensions map[string]extension
+}
+
+// NewFactory creates a validator factory. A nil translator uses the
+// framework's English messages.
+//
+// Example:
+//
+//	factory := validation.NewFactory(translator)
+//	factory.SetPresenceVerifier(validation.NewDatabasePresenceVerifier(resolver))
+func NewFactory(translator langInterfaces.LanguageInterface) *Factory {
+	if translator == nil {
+		translator = defaultTranslator()
+	}
+	return &Factory{translator: translator, extensions: make(map[string]extension)}
+}
+
+// defaultTranslator returns a translator holding the English messages
+func defaultTranslator() langInterfaces.LanguageInterface {
+	loader := loaders.NewArrayLoader().AddMessages("en", "validation", en.Validation)
+	return translation.NewTranslator(loader, "en")
+}
+
+// Make implements ValidationInterface
+func (f *Factory) Make(data map[string]interface{}, rules map[string]interface{}, messages ...map[string]string) validationInterfaces.ValidatorInterface {
+	return f.Validator(data, rules, messages...)
+}
+
+// Validator creates a validator for the data. Rules are keyed by
+// attribute, with "*" matching every element of nested arrays; custom
+// messages are keyed by "attribute.rule", "pattern.rule" or "rule".
+func (f *Factory) Validator(data map[string]interface{}, rules map[string]interface{}, messages ...map[string]string) *Validator {
+	merged := make(map[string]string)
+	for _, custom := range messages {
+		for key, message := range custom {
+			merged[key] = message
+		}
+	}
+	return newValidator(f, data, rules, merged)
+}
+
+// Extend implements ValidationInterface
+func (f *Factory) Extend(name string, rule func(attribute string, value interface{}, parameters []string) bool, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extensions[name] = extension{rule: rule, message: message}
+}
+
+// ExtendImplicit registers a custom rule that also runs when the
+// attribute is missing or empty
+func (f *Factory) ExtendImplicit(name string, rule func(attribute string, value interface{}, parameters []string) bool, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extensions[name] = extension{rule: rule, message: message, implicit: true}
+}
+
+// extension returns a custom rule by name
+func (f *Factory) extension(name string) (extension, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	custom, ok := f.extensions[name]
+	return custom, ok
+}
+
+// Translator returns the translator of the failure messages
+func (f *Factory) Translator() langInterfaces.LanguageInterface {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.translator
+}
+
+// SetTranslator replaces the translator of the failure messages
+func (f *Factory) SetTranslator(translator langInterfaces.LanguageInterface) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.translator = translator
+	return f
+}
+
+// PresenceVerifier returns the verifier of the unique and exists rules
+func (f *Factory) PresenceVerifier() PresenceVerifier {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.verifier
+}
+
+// SetPresenceVerifier sets the verifier of the unique and exists rules
+func (f *Factory) SetPresenceVerifier(verifier PresenceVerifier) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.verifier = verifier
+	return f
+}
+
+// Compile-time interface compliance check
+var _ validationInterfaces.ValidationInterface = (*Factory)(nil)
@@ -0,0 +1,104 @@
+package validation
+
+// MessageBag holds messages keyed by attribute, keeping insertion order
+type MessageBag struct {
+	// messages are the messages by key
+	messages map[string][]string
+
+	// keys are the keys in insertion order
+	keys []string
+}
+
+// NewMessageBag creates an empty message bag
+func NewMessageBag() *MessageBag {
+	return &MessageBag{messages: make(map[string][]string)}
+}
+
+// Add adds a message to a key, ignoring duplicates
+func (b *MessageBag) Add(key string, message string) *MessageBag {
+	existing, exists := b.messages[key]
+	if !exists {
+		b.keys = append(b.keys, key)
+	}
+	for _, current := range existing {
+		if current == message {
+			return b
+		}
+	}
+	b.messages[key] = append(existing, message)
+	return b
+}
+
+// Has reports whether a key, or a key matching a wildcard pattern such
+// as "users.*.email", has messages
+func (b *MessageBag) Has(key string) bool {
+	return len(b.Get(key)) > 0
+}
+
+// Get returns the messages of a key or of the keys matching a wildcard
+// pattern
+func (b *MessageBag) Get(key string) []string {
+	if messages, exists := b.messages[key]; exists {
+		return messages
+	}
+
+	var messages []string
+	for _, candidate := range b.keys {
+		if matchesPattern(key, candidate) {
+			messages = append(messages, b.messages[candidate]...)
+		}
+	}
+	return messages
+}
+
+// First returns the first message of the key, or of the bag when no key
+// is given, or "" when there is none
+func (b *MessageBag) First(key ...string) string {
+	var messages []string
+	if len(key) > 0 {
+		messages = b.Get(key[0])
+	} else {
+		messages = b.All()
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[0]
+}
+
+// All returns every message in insertion order
+func (b *MessageBag) All() []string {
+	var messages []string
+	for _, key := range b.keys {
+		messages = append(messages, b.messages[key]...)
+	}
+	return messages
+}
+
+// Keys returns the keys with messages in insertion order
+func (b *MessageBag) Keys() []string {
+	return append([]string(nil), b.keys...)
+}
+
+// Messages returns a copy of the messages by key
+func (b *MessageBag) Messages() map[string][]string {
+	messages := make(map[string][]string, len(b.messages))
+	for key, values := range b.messages {
+		messages[key] = append([]string(nil), values...)
+	}
+	return messages
+}
+
+// Count returns the number of messages
+func (b *MessageBag) Count() int {
+	count := 0
+	for _, messages := range b.messages {
+		count += len(messages)
+	}
+	return count
+}
+
+// IsEmpty reports whether the bag has no messages
+func (b *MessageBag) IsEmpty() bool {
+	return len(b.keys) == 0
+}
@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sizeRules have a message per value type: "numeric", "string" or "array"
+var sizeRules = map[string]bool{
+	"between": true,
+	"gt":      true,
+	"gte":     true,
+	"lt":      true,
+	"lte":     true,
+	"max":     true,
+	"min":     true,
+	"size":    true,
+}
+
+// defaultMessage is used when no message is found for a rule
+const defaultMessage = "The :attribute field is invalid."
+
+// message returns the failure message of a rule, looked up in order from
+// the custom messages given to Make, "validation.custom.{attribute}.{rule}",
+// "validation.{rule}" (with the value type for size rules) and the
+// message of an extension
+func (v *Validator) message(pattern string, attribute string, value interface{}, rule parsedRule) string {
+	replace := v.replacements(pattern, attribute, rule)
+
+	if rule.object != nil {
+		line := rule.object.Message()
+		if translated, ok := v.translate(line); ok {
+			line = translated
+		}
+		return makeReplacements(line, replace)
+	}
+
+	for _, key := range []string{attribute + "." + rule.name, pattern + "." + rule.name, rule.name} {
+		if line, ok := v.messages[key]; ok {
+			return makeReplacements(line, replace)
+		}
+	}
+
+	for _, name := range []string{attribute, pattern} {
+		if line, ok := v.translate("validation.custom." + name + "." + rule.name); ok {
+			return makeReplacements(line, replace)
+		}
+	}
+
+	key := "validation." + rule.name
+	if sizeRules[rule.name] {
+		key += "." + v.sizeType(pattern, value)
+	}
+	if line, ok := v.translate(key); ok {
+		return makeReplacements(line, replace)
+	}
+
+	if extension, ok := v.factory.extension(rule.name); ok && extension.message != "" {
+		return makeReplacements(extension.message, replace)
+	}
+	return makeReplacements(defaultMessage, replace)
+}
+
+// translate returns the translated line of a key, when it exists and is a
+// single line
+func (v *Validator) translate(key string) (string, bool) {
+	translator := v.factory.Translator()
+	if key == "" || !translator.Has(key) {
+		return "", false
+	}
+	line, ok := translator.Get(key, nil, "", true).(string)
+	return line, ok
+}
+
+// replacements returns the placeholders of a rule message
+func (v *Validator) replacements(pattern string, attribute string, rule parsedRule) map[string]interface{} {
+	replace := map[string]interface{}{"attribute": v.displayName(pattern, attribute)}
+	parameters := rule.parameters
+	parameter := func(idx int) string {
+		if idx < len(parameters) {
+			return parameters[idx]
+		}
+		return ""
+	}
+
+	switch rule.name {
+	case "min":
+		replace["min"] = parameter(0)
+	case "max":
+		replace["max"] = parameter(0)
+	case "size":
+		replace["size"] = parameter(0)
+	case "between", "digits_between":
+		replace["min"], replace["max"] = parameter(0), parameter(1)
+	case "digits":
+		replace["digits"] = parameter(0)
+	case "decimal":
+		replace["decimal"] = strings.Join(parameters, "-")
+	case "date_format":
+		replace["format"] = parameter(0)
+	case "in", "not_in", "starts_with", "ends_with", "doesnt_start_with", "doesnt_end_with", "mimes", "extensions":
+		replace["values"] = strings.Join(parameters, ", ")
+	case "required_with", "required_with_all", "required_without", "required_without_all":
+		names := make([]string, len(parameters))
+		for idx, other := range parameters {
+			names[idx] = v.displayName(other, replaceWildcards(other, pattern, attribute))
+		}
+		replace["values"] = strings.Join(names, " / ")
+	case "required_if", "accepted_if", "declined_if":
+		replace["other"] = v.displayName(parameter(0), replaceWildcards(parameter(0), pattern, attribute))
+		replace["value"] = strings.Join(parameters[min(1, len(parameters)):], ", ")
+	case "required_unless":
+		replace["other"] = v.displayName(parameter(0), replaceWildcards(parameter(0), pattern, attribute))
+		replace["values"] = strings.Join(parameters[min(1, len(parameters)):], ", ")
+	case "same", "different":
+		replace["other"] = v.displayName(parameter(0), replaceWildcards(parameter(0), pattern, attribute))
+	case "gt", "gte", "lt", "lte":
+		other := replaceWildcards(parameter(0), pattern, attribute)
+		if value, ok := getValue(v.data, other); ok {
+			if size, _, sized := v.size(pattern, value); sized {
+				replace["value"] = formatNumber(size)
+				break
+			}
+		}
+		replace["value"] = parameter(0)
+	case "after", "after_or_equal", "before", "before_or_equal", "date_equals":
+		date := parameter(0)
+		if _, ok := getValue(v.data, replaceWildcards(date, pattern, attribute)); ok {
+			date = v.displayName(date, replaceWildcards(date, pattern, attribute))
+		}
+		replace["date"] = date
+	}
+	return replace
+}
+
+// displayName returns the name of an attribute used in messages: a name
+// given to SetAttributeNames, "validation.attributes.{attribute}", or
+// the attribute with underscores replaced by spaces
+func (v *Validator) displayName(pattern string, attribute string) string {
+	for _, name := range []string{attribute, pattern} {
+		if display, ok := v.attributeNames[name]; ok {
+			return display
+		}
+	}
+	for _, name := range []string{attribute, pattern} {
+		if display, ok := v.translate("validation.attributes." + name); ok {
+			return display
+		}
+	}
+	return strings.ReplaceAll(attribute, "_", " ")
+}
+
+// makeReplacements replaces ":name", ":Name" and ":NAME" placeholders,
+// longest first so ":min" never shadows ":minimum"
+func makeReplacements(line string, replace map[string]interface{}) string {
+	replacements := make(map[string]string, len(replace)*3)
+	for key, value := range replace {
+		text := fmt.Sprint(value)
+		replacements[":"+strings.ToUpper(key[:1])+key[1:]] = upperFirst(text)
+		replacements[":"+strings.ToUpper(key)] = strings.ToUpper(text)
+		replacements[":"+key] = text
+	}
+
+	placeholders := make([]string, 0, len(replacements))
+	for placeholder := range replacements {
+		placeholders = append(placeholders, placeholder)
+	}
+	sort.Slice(placeholders, func(i, j int) bool {
+		if len(placeholders[i]) != len(placeholders[j]) {
+			return len(placeholders[i]) > len(placeholders[j])
+		}
+		return placeholders[i] < placeholders[j]
+	})
+
+	pairs := make([]string, 0, len(placeholders)*2)
+	for _, placeholder := range placeholders {
+		pairs = append(pairs, placeholder, replacements[placeholder])
+	}
+	return strings.NewReplacer(pairs...).Replace(line)
+}
+
+// upperFirst upper-cases the first letter
+func upperFirst(text string) string {
+	for idx, char := range text {
+		return strings.ToUpper(string(char)) + text[idx+len(string(char)):]
+	}
+	return text
+}
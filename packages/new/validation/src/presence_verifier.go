@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"context"
+	"strings"
+
+	databaseInterfaces "govel/types/interfaces/database"
+)
+
+// PresenceVerifier counts rows for the "unique" and "exists" rules
+type PresenceVerifier interface {
+	// GetCount counts the rows whose column equals the value, excluding
+	// the row whose idColumn equals excludeID when it is not nil
+	GetCount(ctx context.Context, table string, column string, value interface{}, excludeID interface{}, idColumn string, extra map[string]string) (int64, error)
+
+	// GetMultiCount counts the distinct values of the column among values
+	GetMultiCount(ctx context.Context, table string, column string, values []interface{}, extra map[string]string) (int64, error)
+}
+
+// ConnectionResolver resolves a connection by name, the default for ""
+type ConnectionResolver func(name string) (databaseInterfaces.DatabaseInterface, error)
+
+// DatabasePresenceVerifier verifies presence with the query builder. A
+// table prefixed with a connection name ("mysql.users") is queried on
+// that connection.
+type DatabasePresenceVerifier struct {
+	// resolver resolves the queried connections
+	resolver ConnectionResolver
+}
+
+// NewDatabasePresenceVerifier creates a presence verifier over the
+// resolved connections
+func NewDatabasePresenceVerifier(resolver ConnectionResolver) *DatabasePresenceVerifier {
+	return &DatabasePresenceVerifier{resolver: resolver}
+}
+
+// GetCount implements PresenceVerifier
+func (v *DatabasePresenceVerifier) GetCount(ctx context.Context, table string, column string, value interface{}, excludeID interface{}, idColumn string, extra map[string]string) (int64, error) {
+	query, err := v.table(table)
+	if err != nil {
+		return 0, err
+	}
+
+	query = query.Where(column, "=", value)
+	if excludeID != nil {
+		query = query.Where(idColumn, "<>", excludeID)
+	}
+	return addConditions(query, extra).CountContext(ctx)
+}
+
+// GetMultiCount implements PresenceVerifier
+func (v *DatabasePresenceVerifier) GetMultiCount(ctx context.Context, table string, column string, values []interface{}, extra map[string]string) (int64, error) {
+	query, err := v.table(table)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := addConditions(query.Select(column).Distinct().WhereIn(column, values), extra).GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
+
+// table starts a query on the connection named by the table prefix
+func (v *DatabasePresenceVerifier) table(table string) (databaseInterfaces.QueryBuilderInterface, error) {
+	connectionName := ""
+	if name, rest, found := strings.Cut(table, "."); found {
+		connectionName, table = name, rest
+	}
+
+	connection, err := v.resolver(connectionName)
+	if err != nil {
+		return nil, err
+	}
+	return connection.Table(table), nil
+}
+
+// addConditions adds the extra column conditions of a rule
+func addConditions(query databaseInterfaces.QueryBuilderInterface, extra map[string]string) databaseInterfaces.QueryBuilderInterface {
+	for column, value := range extra {
+		switch {
+		case value == "NULL":
+			query = query.WhereNull(column)
+		case value == "NOT_NULL":
+			query = query.WhereNotNull(column)
+		case strings.HasPrefix(value, "!"):
+			query = query.Where(column, "<>", value[1:])
+		default:
+			query = query.Where(column, "=", value)
+		}
+	}
+	return query
+}
+
+// Compile-time interface compliance check
+var _ PresenceVerifier = (*DatabasePresenceVerifier)(nil)
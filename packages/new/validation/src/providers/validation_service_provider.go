@@ -0,0 +1,107 @@
+// Package providers contains service provider implementations for the validation package.
+// Service providers are responsible for registering validation services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"govel/application/providers"
+	validation "govel/new/validation"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	databaseInterfaces "govel/types/interfaces/database"
+	langInterfaces "govel/types/interfaces/lang"
+	validationInterfaces "govel/types/interfaces/validation"
+)
+
+// databaseResolver is implemented by the database manager
+type databaseResolver interface {
+	Database(name ...string) (databaseInterfaces.DatabaseInterface, error)
+}
+
+// ValidationServiceProvider implements a Laravel-compatible service provider
+// for the validation package.
+//
+// Services registered:
+//   - VALIDATION_TOKEN / VALIDATION_INTERFACE_TOKEN / VALIDATION_FACTORY_TOKEN:
+//     Singleton Factory translating messages with LANG_TOKEN when bound, and
+//     verifying unique/exists rules with DATABASE_MANAGER_TOKEN when bound
+type ValidationServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewValidationServiceProvider creates a new ValidationServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewValidationServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register validation services:", err)
+//	}
+func NewValidationServiceProvider() *ValidationServiceProvider {
+	return &ValidationServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all validation services in the dependency injection container.
+func (p *ValidationServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		factory     *validation.Factory
+		factoryOnce sync.Once
+	)
+	factoryFactory := func() interface{} {
+		factoryOnce.Do(func() {
+			var translator langInterfaces.LanguageInterface
+			if application.IsBound(langInterfaces.LANG_TOKEN) {
+				if resolved, err := application.Make(langInterfaces.LANG_TOKEN); err == nil {
+					translator, _ = resolved.(langInterfaces.LanguageInterface)
+				}
+			}
+			factory = validation.NewFactory(translator)
+
+			if application.IsBound(databaseInterfaces.DATABASE_MANAGER_TOKEN) {
+				factory.SetPresenceVerifier(validation.NewDatabasePresenceVerifier(func(name string) (databaseInterfaces.DatabaseInterface, error) {
+					manager, err := application.Make(databaseInterfaces.DATABASE_MANAGER_TOKEN)
+					if err != nil {
+						return nil, err
+					}
+					resolver, ok := manager.(databaseResolver)
+					if !ok {
+						return nil, fmt.Errorf("validation: database manager %T cannot resolve connections", manager)
+					}
+					return resolver.Database(name)
+				}))
+			}
+		})
+		return factory
+	}
+
+	for _, token := range []interface{}{
+		validationInterfaces.VALIDATION_TOKEN,
+		validationInterfaces.VALIDATION_INTERFACE_TOKEN,
+		validationInterfaces.VALIDATION_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, factoryFactory); err != nil {
+			return fmt.Errorf("failed to bind validation factory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *ValidationServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		validationInterfaces.VALIDATION_TOKEN,
+		validationInterfaces.VALIDATION_INTERFACE_TOKEN,
+		validationInterfaces.VALIDATION_FACTORY_TOKEN,
+	}
+}
@@ -0,0 +1,231 @@
+package validation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Rule is a custom rule object, used in the rule slice of an attribute:
+//
+//	rules := map[string]interface{}{
+//		"code": []interface{}{"required", Uppercase{}},
+//	}
+type Rule interface {
+	// Passes reports whether the value of the attribute is valid
+	Passes(attribute string, value interface{}) bool
+
+	// Message returns the failure message or a translation key; it may
+	// contain ":attribute"
+	Message() string
+}
+
+// ImplicitRule is a Rule that also runs when the attribute is missing or
+// empty, like "required"
+type ImplicitRule interface {
+	Rule
+
+	// Implicit reports whether the rule runs for missing attributes
+	Implicit() bool
+}
+
+// DataAwareRule is a Rule that receives all data under validation before
+// it runs
+type DataAwareRule interface {
+	Rule
+
+	// SetData receives the data under validation
+	SetData(data map[string]interface{})
+}
+
+// funcRule adapts a function to Rule
+type funcRule struct {
+	message string
+	passes  func(attribute string, value interface{}) bool
+}
+
+// Passes implements Rule
+func (r funcRule) Passes(attribute string, value interface{}) bool {
+	return r.passes(attribute, value)
+}
+
+// Message implements Rule
+func (r funcRule) Message() string {
+	return r.message
+}
+
+// Func creates a rule object from a function and its failure message
+func Func(message string, passes func(attribute string, value interface{}) bool) Rule {
+	return funcRule{message: message, passes: passes}
+}
+
+// parsedRule is a rule of an attribute, either by name or as an object
+type parsedRule struct {
+	// name is the lowercase rule name, empty for rule objects
+	name string
+
+	// parameters are the comma separated values after the colon
+	parameters []string
+
+	// object is the custom rule object
+	object Rule
+}
+
+// parseRules parses the rules of an attribute: a pipe-delimited string or
+// a slice of rule strings, fmt.Stringer builders and Rule objects
+func parseRules(rules interface{}) ([]parsedRule, error) {
+	switch typed := rules.(type) {
+	case string:
+		var parsed []parsedRule
+		for _, rule := range strings.Split(typed, "|") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				parsed = append(parsed, parseRule(rule))
+			}
+		}
+		return parsed, nil
+	case []string:
+		parsed := make([]parsedRule, 0, len(typed))
+		for _, rule := range typed {
+			parsed = append(parsed, parseRule(rule))
+		}
+		return parsed, nil
+	case []interface{}:
+		parsed := make([]parsedRule, 0, len(typed))
+		for _, rule := range typed {
+			switch item := rule.(type) {
+			case Rule:
+				parsed = append(parsed, parsedRule{object: item})
+			case string:
+				parsed = append(parsed, parseRule(item))
+			case fmt.Stringer:
+				parsed = append(parsed, parseRule(item.String()))
+			default:
+				return nil, fmt.Errorf("validation: unsupported rule %T", rule)
+			}
+		}
+		return parsed, nil
+	case Rule:
+		return []parsedRule{{object: typed}}, nil
+	case fmt.Stringer:
+		return []parsedRule{parseRule(typed.String())}, nil
+	}
+	return nil, fmt.Errorf("validation: unsupported rules %T", rules)
+}
+
+// parseRule parses "name:param1,param2"; regex parameters are kept whole
+func parseRule(rule string) parsedRule {
+	name, parameters, hasParameters := strings.Cut(strings.TrimSpace(rule), ":")
+	parsed := parsedRule{name: strings.ToLower(strings.TrimSpace(name))}
+	if !hasParameters {
+		return parsed
+	}
+
+	if parsed.name == "regex" || parsed.name == "not_regex" {
+		parsed.parameters = []string{parameters}
+		return parsed
+	}
+
+	reader := csv.NewReader(strings.NewReader(parameters))
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	if values, err := reader.Read(); err == nil {
+		parsed.parameters = values
+	} else {
+		parsed.parameters = strings.Split(parameters, ",")
+	}
+	return parsed
+}
+
+// In creates an "in" rule, quoting values that contain commas
+func In(values ...interface{}) string {
+	return "in:" + quoteParameters(values)
+}
+
+// NotIn creates a "not_in" rule, quoting values that contain commas
+func NotIn(values ...interface{}) string {
+	return "not_in:" + quoteParameters(values)
+}
+
+// quoteParameters joins values as CSV
+func quoteParameters(values []interface{}) string {
+	quoted := make([]string, len(values))
+	for idx, value := range values {
+		text := fmt.Sprint(value)
+		if strings.ContainsAny(text, ",\"") {
+			text = `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+		}
+		quoted[idx] = text
+	}
+	return strings.Join(quoted, ",")
+}
+
+// DatabaseRule builds the "unique" and "exists" rules
+type DatabaseRule struct {
+	name     string
+	table    string
+	column   string
+	ignore   interface{}
+	idColumn string
+	wheres   []string
+}
+
+// Unique creates a rule checking that no row of the table has the value.
+// The table may be prefixed with a connection name ("mysql.users"); the
+// column defaults to the attribute name.
+func Unique(table string, column ...string) *DatabaseRule {
+	return &DatabaseRule{name: "unique", table: table, column: firstOr(column, "NULL")}
+}
+
+// Exists creates a rule checking that a row of the table has the value
+func Exists(table string, column ...string) *DatabaseRule {
+	return &DatabaseRule{name: "exists", table: table, column: firstOr(column, "NULL")}
+}
+
+// Ignore excludes the row with the id from a unique rule, e.g. the model
+// being updated; the id column defaults to "id"
+func (r *DatabaseRule) Ignore(id interface{}, idColumn ...string) *DatabaseRule {
+	r.ignore = id
+	r.idColumn = firstOr(idColumn, "id")
+	return r
+}
+
+// Where adds a condition on another column. A value of "NULL" or
+// "NOT_NULL" checks for null, a value starting with "!" negates.
+func (r *DatabaseRule) Where(column string, value interface{}) *DatabaseRule {
+	r.wheres = append(r.wheres, column, fmt.Sprint(value))
+	return r
+}
+
+// WhereNull adds a condition that a column is null
+func (r *DatabaseRule) WhereNull(column string) *DatabaseRule {
+	return r.Where(column, "NULL")
+}
+
+// WhereNotNull adds a condition that a column is not null
+func (r *DatabaseRule) WhereNotNull(column string) *DatabaseRule {
+	return r.Where(column, "NOT_NULL")
+}
+
+// String renders the rule, e.g. "unique:users,email,5,id,tenant_id,1"
+func (r *DatabaseRule) String() string {
+	parameters := []interface{}{r.table, r.column}
+	if r.name == "unique" && (r.ignore != nil || len(r.wheres) > 0) {
+		ignore, idColumn := interface{}("NULL"), "id"
+		if r.ignore != nil {
+			ignore, idColumn = r.ignore, r.idColumn
+		}
+		parameters = append(parameters, ignore, idColumn)
+	}
+	for _, where := range r.wheres {
+		parameters = append(parameters, where)
+	}
+	return r.name + ":" + quoteParameters(parameters)
+}
+
+// firstOr returns the first value or the fallback
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
@@ -0,0 +1,676 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"govel/support/carbon"
+)
+
+// ruleContext is the attribute a built-in rule validates
+type ruleContext struct {
+	// validator runs the rule
+	validator *Validator
+
+	// pattern is the attribute as given, possibly with wildcards
+	pattern string
+
+	// attribute is the expanded attribute
+	attribute string
+
+	// parameters are the rule parameters
+	parameters []string
+}
+
+// parameter returns a parameter or ""
+func (c *ruleContext) parameter(idx int) string {
+	if idx < len(c.parameters) {
+		return c.parameters[idx]
+	}
+	return ""
+}
+
+// other returns the value of a related attribute, filling its wildcards
+// from the attribute under validation
+func (c *ruleContext) other(attribute string) (interface{}, bool) {
+	return getValue(c.validator.data, replaceWildcards(attribute, c.pattern, c.attribute))
+}
+
+// builtinRule reports whether a value passes a rule
+type builtinRule func(c *ruleContext, value interface{}) (bool, error)
+
+// builtinRules are the rules available by name
+var builtinRules map[string]builtinRule
+
+func init() {
+	builtinRules = map[string]builtinRule{
+		"accepted":          check(func(c *ruleContext, value interface{}) bool { return isAccepted(value) }),
+		"accepted_if":       check(validateAcceptedIf),
+		"after":             compareDates(func(value, other time.Time) bool { return value.After(other) }),
+		"after_or_equal":    compareDates(func(value, other time.Time) bool { return !value.Before(other) }),
+		"alpha":             check(matchRunes(unicode.IsLetter)),
+		"alpha_dash":        check(matchRunes(func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' || r == '_' })),
+		"alpha_num":         check(matchRunes(func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) })),
+		"array":             check(validateArray),
+		"before":            compareDates(func(value, other time.Time) bool { return value.Before(other) }),
+		"before_or_equal":   compareDates(func(value, other time.Time) bool { return !value.After(other) }),
+		"between":           check(validateBetween),
+		"boolean":           check(validateBoolean),
+		"confirmed":         check(validateConfirmed),
+		"date":              check(func(c *ruleContext, value interface{}) bool { _, ok := parseDate(value); return ok }),
+		"date_equals":       compareDates(func(value, other time.Time) bool { return value.Equal(other) }),
+		"date_format":       check(validateDateFormat),
+		"declined":          check(func(c *ruleContext, value interface{}) bool { return isDeclined(value) }),
+		"declined_if":       check(validateDeclinedIf),
+		"different":         check(func(c *ruleContext, value interface{}) bool { return !sameAs(c, value) }),
+		"digits":            check(validateDigits),
+		"digits_between":    check(validateDigitsBetween),
+		"distinct":          check(validateDistinct),
+		"doesnt_end_with":   check(func(c *ruleContext, value interface{}) bool { return !hasAffix(value, c.parameters, strings.HasSuffix) }),
+		"doesnt_start_with": check(func(c *ruleContext, value interface{}) bool { return !hasAffix(value, c.parameters, strings.HasPrefix) }),
+		"email":             check(validateEmail),
+		"ends_with":         check(func(c *ruleContext, value interface{}) bool { return hasAffix(value, c.parameters, strings.HasSuffix) }),
+		"exists":            validateExists,
+		"filled":            check(func(c *ruleContext, value interface{}) bool { return !isPresentButEmpty(c, value) }),
+		"gt":                compareSizes(func(size, other float64) bool { return size > other }),
+		"gte":               compareSizes(func(size, other float64) bool { return size >= other }),
+		"hex_color":         check(matchPattern(regexp.MustCompile(`^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`))),
+		"in":                check(func(c *ruleContext, value interface{}) bool { return inList(value, c.parameters) }),
+		"integer":           check(validateInteger),
+		"ip":                check(validateIP(func(ip net.IP) bool { return true })),
+		"ipv4":              check(validateIP(func(ip net.IP) bool { return ip.To4() != nil })),
+		"ipv6":              check(validateIP(func(ip net.IP) bool { return ip.To4() == nil })),
+		"json":              check(validateJSON),
+		"lowercase": check(func(c *ruleContext, value interface{}) bool {
+			return stringOf(value) == strings.ToLower(stringOf(value))
+		}),
+		"lt":  compareSizes(func(size, other float64) bool { return size < other }),
+		"lte": compareSizes(func(size, other float64) bool { return size <= other }),
+		"max": check(func(c *ruleContext, value interface{}) bool {
+			return compareSize(c, value, func(size, limit float64) bool { return size <= limit })
+		}),
+		"min": check(func(c *ruleContext, value interface{}) bool {
+			return compareSize(c, value, func(size, limit float64) bool { return size >= limit })
+		}),
+		"not_in":    check(func(c *ruleContext, value interface{}) bool { return !inAny(value, c.parameters) }),
+		"not_regex": check(func(c *ruleContext, value interface{}) bool { return !matchesRegex(c, value) }),
+		"numeric":   check(func(c *ruleContext, value interface{}) bool { _, ok := toFloat(value); return ok }),
+		"present": check(func(c *ruleContext, value interface{}) bool {
+			_, ok := getValue(c.validator.data, c.attribute)
+			return ok
+		}),
+		"regex":                check(matchesRegex),
+		"required":             check(func(c *ruleContext, value interface{}) bool { return !isEmpty(value) }),
+		"required_if":          check(validateRequiredIf),
+		"required_unless":      check(validateRequiredUnless),
+		"required_with":        check(requiredWhen(func(present, total int) bool { return present > 0 })),
+		"required_with_all":    check(requiredWhen(func(present, total int) bool { return present == total })),
+		"required_without":     check(requiredWhen(func(present, total int) bool { return present < total })),
+		"required_without_all": check(requiredWhen(func(present, total int) bool { return present == 0 })),
+		"same":                 check(sameAs),
+		"size": check(func(c *ruleContext, value interface{}) bool {
+			return compareSize(c, value, func(size, limit float64) bool { return size == limit })
+		}),
+		"starts_with": check(func(c *ruleContext, value interface{}) bool { return hasAffix(value, c.parameters, strings.HasPrefix) }),
+		"string":      check(func(c *ruleContext, value interface{}) bool { _, ok := value.(string); return ok }),
+		"timezone":    check(validateTimezone),
+		"unique":      validateUnique,
+		"uppercase": check(func(c *ruleContext, value interface{}) bool {
+			return stringOf(value) == strings.ToUpper(stringOf(value))
+		}),
+		"url":  check(validateURL),
+		"uuid": check(matchPattern(regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`))),
+	}
+}
+
+// check adapts a rule that cannot fail to run
+func check(rule func(c *ruleContext, value interface{}) bool) builtinRule {
+	return func(c *ruleContext, value interface{}) (bool, error) {
+		return rule(c, value), nil
+	}
+}
+
+// isEmpty reports whether a value is nil, a blank string or an empty
+// slice or map
+func isEmpty(value interface{}) bool {
+	if value == nil || isBlankString(value) {
+		return true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return reflected.Len() == 0
+	case reflect.Pointer:
+		return reflected.IsNil()
+	}
+	return false
+}
+
+// isPresentButEmpty reports whether the attribute exists with an empty value
+func isPresentButEmpty(c *ruleContext, value interface{}) bool {
+	_, present := getValue(c.validator.data, c.attribute)
+	return present && isEmpty(value)
+}
+
+// isAccepted reports whether a value is "yes", "on", 1 or true
+func isAccepted(value interface{}) bool {
+	return inList(value, []string{"yes", "on", "1", "true"})
+}
+
+// isDeclined reports whether a value is "no", "off", 0 or false
+func isDeclined(value interface{}) bool {
+	return inList(value, []string{"no", "off", "0", "false"})
+}
+
+// otherMatches reports whether the other attribute named by the first
+// parameter equals one of the remaining parameters
+func otherMatches(c *ruleContext) bool {
+	other, _ := c.other(c.parameter(0))
+	if len(c.parameters) < 2 {
+		return false
+	}
+	return inList(other, c.parameters[1:])
+}
+
+func validateAcceptedIf(c *ruleContext, value interface{}) bool {
+	return !otherMatches(c) || isAccepted(value)
+}
+
+func validateDeclinedIf(c *ruleContext, value interface{}) bool {
+	return !otherMatches(c) || isDeclined(value)
+}
+
+func validateRequiredIf(c *ruleContext, value interface{}) bool {
+	return !otherMatches(c) || !isEmpty(value)
+}
+
+func validateRequiredUnless(c *ruleContext, value interface{}) bool {
+	return otherMatches(c) || !isEmpty(value)
+}
+
+// requiredWhen requires the value depending on how many of the attributes
+// named by the parameters are filled
+func requiredWhen(required func(present, total int) bool) func(c *ruleContext, value interface{}) bool {
+	return func(c *ruleContext, value interface{}) bool {
+		present := 0
+		for _, attribute := range c.parameters {
+			if other, ok := c.other(attribute); ok && !isEmpty(other) {
+				present++
+			}
+		}
+		return !required(present, len(c.parameters)) || !isEmpty(value)
+	}
+}
+
+// validateArray accepts slices and maps; with parameters, map keys must
+// be among them
+func validateArray(c *ruleContext, value interface{}) bool {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	case reflect.Map:
+		if len(c.parameters) == 0 {
+			return true
+		}
+		for _, key := range keysOf(value) {
+			if !inList(key, c.parameters) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func validateBoolean(c *ruleContext, value interface{}) bool {
+	if _, ok := value.(bool); ok {
+		return true
+	}
+	return inList(value, []string{"0", "1", "true", "false"})
+}
+
+func validateInteger(c *ruleContext, value interface{}) bool {
+	switch typed := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	case float32:
+		return float64(typed) == math.Trunc(float64(typed))
+	case float64:
+		return typed == math.Trunc(typed)
+	case json.Number:
+		_, err := typed.Int64()
+		return err == nil
+	case string:
+		_, err := strconv.ParseInt(strings.TrimSpace(typed), 10, 64)
+		return err == nil
+	}
+	return false
+}
+
+func validateBetween(c *ruleContext, value interface{}) bool {
+	size, _, ok := c.validator.size(c.pattern, value)
+	low, lowOK := toFloat(c.parameter(0))
+	high, highOK := toFloat(c.parameter(1))
+	return ok && lowOK && highOK && size >= low && size <= high
+}
+
+// compareSize compares the size of a value with the first parameter
+func compareSize(c *ruleContext, value interface{}, compare func(size, limit float64) bool) bool {
+	size, _, ok := c.validator.size(c.pattern, value)
+	limit, limitOK := toFloat(c.parameter(0))
+	return ok && limitOK && compare(size, limit)
+}
+
+// compareSizes compares the size of a value with the size of another
+// attribute or with a number
+func compareSizes(compare func(size, other float64) bool) builtinRule {
+	return check(func(c *ruleContext, value interface{}) bool {
+		size, _, ok := c.validator.size(c.pattern, value)
+		if !ok {
+			return false
+		}
+		if other, exists := c.other(c.parameter(0)); exists {
+			otherSize, _, otherOK := c.validator.size(c.pattern, other)
+			return otherOK && compare(size, otherSize)
+		}
+		limit, limitOK := toFloat(c.parameter(0))
+		return limitOK && compare(size, limit)
+	})
+}
+
+func validateConfirmed(c *ruleContext, value interface{}) bool {
+	confirmation, ok := getValue(c.validator.data, c.attribute+"_confirmation")
+	return ok && equalValues(value, confirmation)
+}
+
+// sameAs reports whether the value equals the attribute named by the
+// first parameter
+func sameAs(c *ruleContext, value interface{}) bool {
+	other, ok := c.other(c.parameter(0))
+	return ok && equalValues(value, other)
+}
+
+func validateDigits(c *ruleContext, value interface{}) bool {
+	length, ok := digitsOf(value)
+	expected, err := strconv.Atoi(c.parameter(0))
+	return ok && err == nil && length == expected
+}
+
+func validateDigitsBetween(c *ruleContext, value interface{}) bool {
+	length, ok := digitsOf(value)
+	low, lowErr := strconv.Atoi(c.parameter(0))
+	high, highErr := strconv.Atoi(c.parameter(1))
+	return ok && lowErr == nil && highErr == nil && length >= low && length <= high
+}
+
+// digitsOf returns the number of digits of a value made only of digits
+func digitsOf(value interface{}) (int, bool) {
+	text := stringOf(value)
+	if text == "" {
+		return 0, false
+	}
+	for _, char := range text {
+		if char < '0' || char > '9' {
+			return 0, false
+		}
+	}
+	return len(text), true
+}
+
+// validateDistinct fails when another attribute matching the wildcard
+// pattern has the same value
+func validateDistinct(c *ruleContext, value interface{}) bool {
+	ignoreCase := inList("ignore_case", c.parameters)
+	for _, attribute := range expandWildcards(c.validator.data, c.pattern) {
+		if attribute == c.attribute {
+			continue
+		}
+		other, ok := getValue(c.validator.data, attribute)
+		if !ok {
+			continue
+		}
+		if ignoreCase && strings.EqualFold(stringOf(other), stringOf(value)) || equalValues(other, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateEmail(c *ruleContext, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	address, err := mail.ParseAddress(text)
+	if err != nil || address.Address != text {
+		return false
+	}
+	_, domain, _ := strings.Cut(text, "@")
+	return domain != "" && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+func validateURL(c *ruleContext, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	parsed, err := url.Parse(text)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return false
+	}
+	if len(c.parameters) > 0 {
+		return inList(parsed.Scheme, c.parameters)
+	}
+	return true
+}
+
+// validateIP accepts strings parsing as an IP the filter accepts
+func validateIP(filter func(ip net.IP) bool) func(c *ruleContext, value interface{}) bool {
+	return func(c *ruleContext, value interface{}) bool {
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		ip := net.ParseIP(text)
+		return ip != nil && filter(ip)
+	}
+}
+
+func validateJSON(c *ruleContext, value interface{}) bool {
+	text, ok := value.(string)
+	return ok && json.Valid([]byte(text))
+}
+
+func validateTimezone(c *ruleContext, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok || text == "" || text == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(text)
+	return err == nil
+}
+
+// matchRunes accepts strings whose characters all satisfy the predicate
+func matchRunes(predicate func(r rune) bool) func(c *ruleContext, value interface{}) bool {
+	return func(c *ruleContext, value interface{}) bool {
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, char := range text {
+			if !predicate(char) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// matchPattern accepts strings matching the expression
+func matchPattern(expression *regexp.Regexp) func(c *ruleContext, value interface{}) bool {
+	return func(c *ruleContext, value interface{}) bool {
+		text, ok := value.(string)
+		return ok && expression.MatchString(text)
+	}
+}
+
+// matchesRegex matches a value against a PCRE style parameter such as
+// "/^[a-z]+$/i"; an invalid expression never matches
+func matchesRegex(c *ruleContext, value interface{}) bool {
+	expression, err := compileRegex(c.parameter(0))
+	if err != nil {
+		return false
+	}
+	switch value.(type) {
+	case string, int, int64, float64:
+		return expression.MatchString(stringOf(value))
+	}
+	return false
+}
+
+// compileRegex compiles "/pattern/flags" or a bare pattern
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 1 && !unicode.IsLetter(rune(pattern[0])) && !unicode.IsNumber(rune(pattern[0])) && pattern[0] != '^' && pattern[0] != '\\' {
+		delimiter := pattern[:1]
+		if end := strings.LastIndex(pattern, delimiter); end > 0 {
+			flags := strings.Trim(pattern[end+1:], "u")
+			pattern = pattern[1:end]
+			if flags != "" {
+				pattern = "(?" + flags + ")" + pattern
+			}
+		}
+	}
+	return regexp.Compile(pattern)
+}
+
+func validateDateFormat(c *ruleContext, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, format := range c.parameters {
+		parsed := carbon.ParseByFormat(text, format)
+		if parsed.IsValid() && parsed.Format(format) == text {
+			return true
+		}
+	}
+	return false
+}
+
+// compareDates compares the date of a value with a date parameter, such as
+// "tomorrow" or "2024-01-01", or with the date of another attribute
+func compareDates(compare func(value, other time.Time) bool) builtinRule {
+	return check(func(c *ruleContext, value interface{}) bool {
+		date, ok := parseDate(value)
+		if !ok {
+			return false
+		}
+		var other time.Time
+		if related, exists := c.other(c.parameter(0)); exists {
+			other, ok = parseDate(related)
+		} else {
+			other, ok = parseDate(c.parameter(0))
+		}
+		return ok && compare(date, other)
+	})
+}
+
+// parseDate parses time values and date strings
+func parseDate(value interface{}) (time.Time, bool) {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed, !typed.IsZero()
+	case *time.Time:
+		if typed == nil {
+			return time.Time{}, false
+		}
+		return *typed, !typed.IsZero()
+	case *carbon.Carbon:
+		if typed == nil || !typed.IsValid() {
+			return time.Time{}, false
+		}
+		return typed.StdTime(), true
+	case string:
+		if strings.TrimSpace(typed) == "" {
+			return time.Time{}, false
+		}
+		parsed := carbon.Parse(typed)
+		if !parsed.IsValid() {
+			return time.Time{}, false
+		}
+		return parsed.StdTime(), true
+	}
+	return time.Time{}, false
+}
+
+// hasAffix reports whether the string value has one of the affixes
+func hasAffix(value interface{}, affixes []string, has func(s, affix string) bool) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, affix := range affixes {
+		if has(text, affix) {
+			return true
+		}
+	}
+	return false
+}
+
+// inList reports whether a scalar value, or every element of a slice
+// value, is one of the allowed values
+func inList(value interface{}, allowed []string) bool {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Slice && reflected.Type().Elem().Kind() != reflect.Uint8 {
+		for idx := 0; idx < reflected.Len(); idx++ {
+			if !inList(reflected.Index(idx).Interface(), allowed) {
+				return false
+			}
+		}
+		return true
+	}
+	if value == nil {
+		return false
+	}
+
+	text := stringOf(value)
+	for _, candidate := range allowed {
+		if candidate == text {
+			return true
+		}
+	}
+	return false
+}
+
+// inAny reports whether a scalar value, or any element of a slice value,
+// is one of the values
+func inAny(value interface{}, values []string) bool {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Slice && reflected.Type().Elem().Kind() != reflect.Uint8 {
+		for idx := 0; idx < reflected.Len(); idx++ {
+			if inList(reflected.Index(idx).Interface(), values) {
+				return true
+			}
+		}
+		return false
+	}
+	return inList(value, values)
+}
+
+// equalValues compares values, treating numbers and their string forms
+// as equal
+func equalValues(left interface{}, right interface{}) bool {
+	if reflect.DeepEqual(left, right) {
+		return true
+	}
+	if left == nil || right == nil {
+		return false
+	}
+	return stringOf(left) == stringOf(right)
+}
+
+// stringOf formats scalar values, rendering floats without trailing zeros
+func stringOf(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []byte:
+		return string(typed)
+	case bool:
+		if typed {
+			return "true"
+		}
+		return "false"
+	case float64:
+		return formatNumber(typed)
+	case float32:
+		return formatNumber(float64(typed))
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// formatNumber formats a float without trailing zeros
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// toFloat converts numbers and numeric strings to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed), true
+	case int8:
+		return float64(typed), true
+	case int16:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint8:
+		return float64(typed), true
+	case uint16:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case float32:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil && strings.TrimSpace(typed) != ""
+	}
+	return 0, false
+}
+
+// size returns the size of a value and its type for messages: the number
+// itself for numbers or numeric strings of numeric attributes, the number
+// of characters of strings and the number of elements of slices and maps
+func (v *Validator) size(pattern string, value interface{}) (float64, string, bool) {
+	if _, isString := value.(string); !isString || hasRule(v.rulesOf(pattern), "numeric", "integer") {
+		if number, ok := toFloat(value); ok {
+			return number, "numeric", true
+		}
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return float64(len([]rune(typed))), "string", true
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(reflected.Len()), "array", true
+	}
+	return 0, "", false
+}
+
+// sizeType returns the message variant of a size rule
+func (v *Validator) sizeType(pattern string, value interface{}) string {
+	if _, kind, ok := v.size(pattern, value); ok {
+		return kind
+	}
+	return "string"
+}
@@ -0,0 +1,318 @@
+package validation
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	validationInterfaces "govel/types/interfaces/validation"
+)
+
+// implicitRules run even when the attribute is missing or empty
+var implicitRules = map[string]bool{
+	"accepted":             true,
+	"accepted_if":          true,
+	"declined":             true,
+	"declined_if":          true,
+	"filled":               true,
+	"present":              true,
+	"required":             true,
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+}
+
+// modifierRules change how the other rules run and never fail
+var modifierRules = map[string]bool{
+	"bail":      true,
+	"nullable":  true,
+	"sometimes": true,
+}
+
+// Validator validates data against the rules of its attributes. Rules run
+// once, on the first call to Passes, Fails, Errors, Validate or Validated.
+type Validator struct {
+	// factory provides the translator, presence verifier and extensions
+	factory *Factory
+
+	// ctx is used by the presence verifier
+	ctx context.Context
+
+	// data is the data under validation
+	data map[string]interface{}
+
+	// patterns are the attributes as given, possibly with wildcards
+	patterns []string
+
+	// rules are the parsed rules by pattern
+	rules map[string][]parsedRule
+
+	// messages are the custom messages by "attribute.rule" or "rule"
+	messages map[string]string
+
+	// attributeNames are the custom display names by attribute
+	attributeNames map[string]string
+
+	// after hooks run once the rules ran
+	after []func(validator *Validator)
+
+	// errors holds the failure messages
+	errors *MessageBag
+
+	// validated marks the expanded attributes that had rules
+	validated pathTree
+
+	// err is a rule parsing or presence verifier error
+	err error
+
+	// ran reports whether the rules ran
+	ran bool
+}
+
+// newValidator creates a validator for the data
+func newValidator(factory *Factory, data map[string]interface{}, rules map[string]interface{}, messages map[string]string) *Validator {
+	validator := &Validator{
+		factory:        factory,
+		ctx:            context.Background(),
+		data:           data,
+		rules:          make(map[string][]parsedRule, len(rules)),
+		messages:       messages,
+		attributeNames: make(map[string]string),
+		errors:         NewMessageBag(),
+	}
+	if validator.data == nil {
+		validator.data = map[string]interface{}{}
+	}
+	if validator.messages == nil {
+		validator.messages = map[string]string{}
+	}
+
+	for attribute, attributeRules := range rules {
+		validator.addRules(attribute, attributeRules)
+	}
+	return validator
+}
+
+// addRules parses and appends rules of an attribute
+func (v *Validator) addRules(attribute string, rules interface{}) {
+	parsed, err := parseRules(rules)
+	if err != nil {
+		if v.err == nil {
+			v.err = err
+		}
+		return
+	}
+
+	if _, exists := v.rules[attribute]; !exists {
+		v.patterns = append(v.patterns, attribute)
+		sort.Strings(v.patterns)
+	}
+	v.rules[attribute] = append(v.rules[attribute], parsed...)
+}
+
+// WithContext sets the context used by the unique and exists rules
+func (v *Validator) WithContext(ctx context.Context) *Validator {
+	v.ctx = ctx
+	return v
+}
+
+// SetAttributeNames sets display names used for ":attribute", keyed by
+// attribute or wildcard pattern
+func (v *Validator) SetAttributeNames(names map[string]string) *Validator {
+	for attribute, name := range names {
+		v.attributeNames[attribute] = name
+	}
+	return v
+}
+
+// Sometimes adds rules to an attribute when the callback returns true
+// for the data
+func (v *Validator) Sometimes(attribute string, rules interface{}, callback func(data map[string]interface{}) bool) *Validator {
+	if callback(v.data) {
+		v.addRules(attribute, rules)
+	}
+	return v
+}
+
+// After registers a hook that runs once the rules ran, e.g. to add
+// failures spanning several attributes with AddError
+func (v *Validator) After(hook func(validator *Validator)) *Validator {
+	v.after = append(v.after, hook)
+	return v
+}
+
+// AddError adds a failure message to an attribute
+func (v *Validator) AddError(attribute string, message string) *Validator {
+	v.errors.Add(attribute, message)
+	return v
+}
+
+// Data returns the data under validation
+func (v *Validator) Data() map[string]interface{} {
+	return v.data
+}
+
+// Passes implements ValidatorInterface
+func (v *Validator) Passes() bool {
+	v.run()
+	return v.err == nil && v.errors.IsEmpty()
+}
+
+// Fails implements ValidatorInterface
+func (v *Validator) Fails() bool {
+	return !v.Passes()
+}
+
+// Errors implements ValidatorInterface
+func (v *Validator) Errors() map[string][]string {
+	v.run()
+	return v.errors.Messages()
+}
+
+// MessageBag returns the failure messages
+func (v *Validator) MessageBag() *MessageBag {
+	v.run()
+	return v.errors
+}
+
+// Validate implements ValidatorInterface. The error is a *ValidationError
+// unless a rule could not run, e.g. when the presence verifier failed.
+func (v *Validator) Validate() error {
+	v.run()
+	if v.err != nil {
+		return v.err
+	}
+	if !v.errors.IsEmpty() {
+		return &ValidationError{Bag: v.errors}
+	}
+	return nil
+}
+
+// Validated implements ValidatorInterface
+func (v *Validator) Validated() (map[string]interface{}, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return v.validated.extract(v.data).(map[string]interface{}), nil
+}
+
+// run validates every attribute once
+func (v *Validator) run() {
+	if v.ran {
+		return
+	}
+	v.ran = true
+	v.validated = pathTree{}
+
+	for _, pattern := range v.patterns {
+		for _, attribute := range expandWildcards(v.data, pattern) {
+			v.validateAttribute(pattern, attribute)
+		}
+	}
+
+	for _, hook := range v.after {
+		hook(v)
+	}
+}
+
+// validateAttribute runs the rules of an expanded attribute, stopping
+// after a failed implicit rule or, with "bail", after any failure
+func (v *Validator) validateAttribute(pattern string, attribute string) {
+	rules := v.rules[pattern]
+	value, present := getValue(v.data, attribute)
+	if hasRule(rules, "sometimes") && !present {
+		return
+	}
+	if present {
+		v.validated.add(attribute)
+	}
+
+	bail := hasRule(rules, "bail")
+	nullable := hasRule(rules, "nullable")
+	for _, rule := range rules {
+		if modifierRules[rule.name] {
+			continue
+		}
+
+		implicit := v.isImplicit(rule)
+		if !implicit && (!present || isBlankString(value) || value == nil && nullable) {
+			continue
+		}
+
+		passes, err := v.passes(pattern, attribute, value, rule)
+		if err != nil {
+			if v.err == nil {
+				v.err = err
+			}
+			return
+		}
+		if passes {
+			continue
+		}
+
+		v.errors.Add(attribute, v.message(pattern, attribute, value, rule))
+		if bail || implicit {
+			return
+		}
+	}
+}
+
+// passes runs a single rule
+func (v *Validator) passes(pattern string, attribute string, value interface{}, rule parsedRule) (bool, error) {
+	if rule.object != nil {
+		if aware, ok := rule.object.(DataAwareRule); ok {
+			aware.SetData(v.data)
+		}
+		return rule.object.Passes(attribute, value), nil
+	}
+
+	if builtin, ok := builtinRules[rule.name]; ok {
+		return builtin(&ruleContext{validator: v, pattern: pattern, attribute: attribute, parameters: rule.parameters}, value)
+	}
+	if extension, ok := v.factory.extension(rule.name); ok {
+		return extension.rule(attribute, value, rule.parameters), nil
+	}
+	return false, &UnknownRuleError{Rule: rule.name}
+}
+
+// isImplicit reports whether a rule runs for missing or empty attributes
+func (v *Validator) isImplicit(rule parsedRule) bool {
+	if rule.object != nil {
+		implicit, ok := rule.object.(ImplicitRule)
+		return ok && implicit.Implicit()
+	}
+	if implicitRules[rule.name] {
+		return true
+	}
+	extension, ok := v.factory.extension(rule.name)
+	return ok && extension.implicit
+}
+
+// rulesOf returns the rules of the pattern an attribute was expanded from
+func (v *Validator) rulesOf(pattern string) []parsedRule {
+	return v.rules[pattern]
+}
+
+// hasRule reports whether the rules contain a named rule
+func hasRule(rules []parsedRule, names ...string) bool {
+	for _, rule := range rules {
+		for _, name := range names {
+			if rule.name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isBlankString reports whether the value is a string of whitespace
+func isBlankString(value interface{}) bool {
+	text, ok := value.(string)
+	return ok && strings.TrimSpace(text) == ""
+}
+
+// Compile-time interface compliance check
+var _ validationInterfaces.ValidatorInterface = (*Validator)(nil)
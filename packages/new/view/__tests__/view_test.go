@@ -0,0 +1,257 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	view "govel/new/view"
+)
+
+// compact removes whitespace so assertions ignore template indentation
+func compact(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// viewFS returns an in-memory view directory
+func viewFS(files map[string]string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(files))
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content), ModTime: time.Unix(1, 0)}
+	}
+	return fsys
+}
+
+// render renders a view and fails the test on error
+func render(t *testing.T, factory *view.Factory, name string, data map[string]interface{}) string {
+	t.Helper()
+	html, err := factory.Render(name, data)
+	if err != nil {
+		t.Fatalf("Render(%s): %v", name, err)
+	}
+	return html
+}
+
+func TestFactory_LayoutsSectionsAndYieldDefaults(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"layouts/app.html": `<title>@yield("title", "Untitled")</title>` +
+			`<main>@yield("content")</main>` +
+			`<footer>@yield("footer", "default footer")</footer>`,
+		"users/profile.html": `@extends("layouts.app")` +
+			`@section("title", "Profile")` +
+			`@section("content")<p>{{.name}}</p>@endsection`,
+	}))
+
+	html := render(t, factory, "users.profile", map[string]interface{}{"name": "<Alice>"})
+	want := `<title>Profile</title><main><p>&lt;Alice&gt;</p></main><footer>default footer</footer>`
+	if compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+func TestFactory_NestedLayoutsAndShow(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"layouts/base.html": `<nav>@section("sidebar")base @show</nav><main>@yield("content")</main>`,
+		"layouts/admin.html": `@extends("layouts.base")` +
+			`@section("content")[admin]@yield("page") @endsection`,
+		"admin/dashboard.html": `@extends("layouts/admin")` +
+			`@section("sidebar")dashboard links @endsection ` +
+			`@section("page")stats @endsection`,
+	}))
+
+	html := render(t, factory, "admin.dashboard", nil)
+	if want := `<nav>dashboard links</nav><main>[admin]stats</main>`; compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+
+	html = render(t, factory, "layouts.base", nil)
+	if want := `<nav>base</nav><main></main>`; compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+func TestFactory_StacksCollectPushesFromEveryView(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"layouts/app.html": `<head>@stack("scripts")</head><body>@yield("content")</body>`,
+		"page.html": `@extends("layouts.app")` +
+			`@push("scripts")<script src="/page.js"></script>@endpush ` +
+			`@section("content")<x-chart></x-chart>@endsection`,
+		"components/chart.html": `<canvas></canvas>@push("scripts")<script src="/chart.js"></script>@endpush`,
+	}))
+
+	html := render(t, factory, "page", nil)
+	want := `<head><script src="/page.js"></script><script src="/chart.js"></script></head><body><canvas></canvas></body>`
+	if compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+func TestFactory_ComponentsWithAttributesAndSlots(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"components/card.html": `<div class="card {{.class}}"><h2>{{.title}}</h2>{{.slot}}<footer>{{.footer}}</footer></div>`,
+		"profile.html": `<x-card class="wide" :title=".user">` +
+			`<p>{{.user}} &amp; co</p>` +
+			`<x-slot name="footer"><a href="/logout">Log out</a></x-slot>` +
+			`</x-card>`,
+	}))
+
+	html := render(t, factory, "profile", map[string]interface{}{"user": "Alice"})
+	want := `<div class="card wide"><h2>Alice</h2><p>Alice &amp; co</p><footer><a href="/logout">Log out</a></footer></div>`
+	if compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+// alertComponent is a registered component preparing its view data
+type alertComponent struct{}
+
+func (alertComponent) View() string {
+	return "partials.alert"
+}
+
+func (alertComponent) Data(attributes map[string]interface{}) (map[string]interface{}, error) {
+	level, _ := attributes["type"].(string)
+	if level == "" {
+		return nil, errors.New("type is required")
+	}
+	return map[string]interface{}{"class": "alert-" + level}, nil
+}
+
+func TestFactory_RegisteredComponents(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"partials/alert.html": `<div class="{{.class}}">{{.slot}}</div>`,
+		"home.html":           `<x-alert type="danger">Saved failed</x-alert>`,
+		"broken.html":         `<x-alert>oops</x-alert>`,
+	}))
+	factory.RegisterComponent("alert", alertComponent{})
+
+	html := render(t, factory, "home", nil)
+	if want := `<div class="alert-danger">Saved failed</div>`; compact(html) != compact(want) {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+
+	if _, err := factory.Render("broken", nil); err == nil || !strings.Contains(err.Error(), "type is required") {
+		t.Fatalf("expected the component error, got %v", err)
+	}
+}
+
+func TestFactory_IncludesSharedDataAndComposers(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"layouts/app.html":    `{{.appName}} {{.year}} @yield("content")`,
+		"partials/greet.html": `Hello {{.name}} from {{.appName}}`,
+		"home.html": `@extends("layouts.app")` +
+			`@section("content"){{include "partials.greet" (dict "name" .user)}}|@include("partials.greet")@endsection`,
+	}))
+	factory.Share("appName", "GoVel")
+	factory.Share("name", "guest")
+	factory.Composer("layouts.*", func(v *view.View) {
+		v.With("year", 2026)
+	})
+
+	html := render(t, factory, "home", map[string]interface{}{"user": "Alice"})
+	if want := `GoVel 2026 Hello Alice from GoVel|Hello guest from GoVel`; html != want {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+func TestFactory_AddHelperAndEscapedDirectives(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"contact.html": `{{upper .name}} support@example.com @@section`,
+	}))
+	factory.AddHelper("upper", strings.ToUpper)
+
+	html := render(t, factory, "contact", map[string]interface{}{"name": "alice"})
+	if want := `ALICE support@example.com @section`; html != want {
+		t.Fatalf("unexpected output:\n%s", html)
+	}
+}
+
+func TestFactory_CacheAndReloadOnChange(t *testing.T) {
+	fsys := viewFS(map[string]string{"home.html": `v1`})
+	factory := view.NewFactory(fsys)
+
+	if html := render(t, factory, "home", nil); html != "v1" {
+		t.Fatalf("unexpected output %q", html)
+	}
+
+	fsys["home.html"] = &fstest.MapFile{Data: []byte(`v2`), ModTime: time.Unix(2, 0)}
+	if html := render(t, factory, "home", nil); html != "v1" {
+		t.Fatalf("expected the cached view, got %q", html)
+	}
+
+	factory.SetCache(false)
+	if html := render(t, factory, "home", nil); html != "v2" {
+		t.Fatalf("expected the changed view, got %q", html)
+	}
+
+	factory.SetCache(true)
+	fsys["home.html"] = &fstest.MapFile{Data: []byte(`v3`), ModTime: time.Unix(3, 0)}
+	factory.Flush()
+	if html := render(t, factory, "home", nil); html != "v3" {
+		t.Fatalf("expected the view after Flush, got %q", html)
+	}
+}
+
+func TestFactory_PathsAndExtension(t *testing.T) {
+	override := viewFS(map[string]string{"home.tmpl": `override`})
+	fallback := viewFS(map[string]string{"home.tmpl": `fallback`, "about.tmpl": `about`})
+	factory := view.NewFactory(override).AddPath(fallback).SetExtension(".tmpl")
+
+	if html := render(t, factory, "home", nil); html != "override" {
+		t.Fatalf("expected the first path to win, got %q", html)
+	}
+	if html := render(t, factory, "about", nil); html != "about" {
+		t.Fatalf("unexpected output %q", html)
+	}
+	if !factory.Exists("about") || factory.Exists("missing") {
+		t.Fatal("Exists reported the wrong views")
+	}
+}
+
+func TestFactory_Errors(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"child.html":     `@extends("missing")`,
+		"loop/a.html":    `@extends("loop.b")`,
+		"loop/b.html":    `@extends("loop.a")`,
+		"unclosed.html":  "<p>\n@section(\"content\")",
+		"stray.html":     `<x-slot name="footer">x</x-slot>`,
+		"recursive.html": `@include("recursive")`,
+	}))
+
+	var notFound *view.NotFoundError
+	if _, err := factory.Render("nope", nil); !errors.As(err, &notFound) || notFound.Name != "nope" {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if _, err := factory.Render("child", nil); !errors.As(err, &notFound) || notFound.Name != "missing" {
+		t.Fatalf("expected NotFoundError for the layout, got %v", err)
+	}
+
+	cases := map[string]string{
+		"loop.a":    "circular @extends",
+		"unclosed":  "line 2",
+		"stray":     "x-slot",
+		"recursive": "nested more than",
+	}
+	for name, want := range cases {
+		if _, err := factory.Render(name, nil); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Render(%s): expected an error containing %q, got %v", name, want, err)
+		}
+	}
+}
+
+func TestFactory_RenderToWriterWritesNothingOnError(t *testing.T) {
+	factory := view.NewFactory(viewFS(map[string]string{
+		"broken.html": `partial output {{index .items 5}}`,
+	}))
+
+	var buf bytes.Buffer
+	if err := factory.RenderToWriter(&buf, "broken", map[string]interface{}{"items": []int{1}}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output, got %q", buf.String())
+	}
+}
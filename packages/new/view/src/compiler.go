@@ -0,0 +1,462 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// directives are the names recognized after "@"; any other "@" is copied
+// as is, so email addresses need no escaping
+var directives = map[string]bool{
+	"extends":    true,
+	"section":    true,
+	"endsection": true,
+	"show":       true,
+	"yield":      true,
+	"include":    true,
+	"push":       true,
+	"endpush":    true,
+	"stack":      true,
+}
+
+// compiled is a view translated to html/template source
+type compiled struct {
+	// source is the html/template source
+	source string
+
+	// parent is the layout named by @extends, empty for none
+	parent string
+}
+
+// compiler translates Blade-like directives and component tags into
+// html/template actions and the helper functions of the render:
+//
+//	@extends("layouts.app")              the view fills sections of the layout
+//	@section("title", "Home")            inline section
+//	@section("content") ... @endsection  section body
+//	@section("sidebar") ... @show        section yielded in place, the body is its default
+//	@yield("content", "default")         section placeholder in layouts
+//	@include("partials.nav")             renders a view with the same data
+//	@push("scripts") ... @endpush        appends to a stack
+//	@stack("scripts")                    renders a stack
+//	<x-alert type="error" :count=".n">   renders components/alert with attributes
+//	<x-slot name="title"> ... </x-slot>  named slot of the enclosing component
+//
+// Template actions ("{{ ... }}") are copied verbatim and "@@" writes a
+// literal "@".
+type compiler struct {
+	// view is the name of the compiled view, used for errors and to keep
+	// generated template names unique across a layout chain
+	view string
+
+	// src is the view source and pos the current offset
+	src string
+	pos int
+
+	// parent is the layout named by @extends
+	parent string
+
+	// defines holds the generated {{define}} blocks, appended after the body
+	defines strings.Builder
+
+	// counter numbers generated templates
+	counter int
+
+	// yielded tracks the sections already yielded by this view
+	yielded map[string]bool
+
+	// slots collects named slots of the components being compiled
+	slots []map[string]string
+}
+
+// compile translates the source of a view
+func compile(view, src string) (*compiled, error) {
+	c := &compiler{view: view, src: src, yielded: make(map[string]bool)}
+	var body strings.Builder
+	if _, err := c.compileUntil(&body); err != nil {
+		return nil, err
+	}
+	return &compiled{source: body.String() + c.defines.String(), parent: c.parent}, nil
+}
+
+// compileUntil compiles into out until one of the terminators, returning
+// the terminator found. Without terminators it compiles to the end.
+func (c *compiler) compileUntil(out *strings.Builder, terminators ...string) (string, error) {
+	for c.pos < len(c.src) {
+		rest := c.src[c.pos:]
+		switch {
+		case strings.HasPrefix(rest, "{{"):
+			end := strings.Index(rest, "}}")
+			if end < 0 {
+				return "", c.errorf("unclosed action")
+			}
+			out.WriteString(rest[:end+2])
+			c.pos += end + 2
+		case strings.HasPrefix(rest, "@@"):
+			out.WriteByte('@')
+			c.pos += 2
+		case rest[0] == '@' && c.atDirective():
+			name := c.readIdentifier()
+			if terminator := "@" + name; contains(terminators, terminator) {
+				return terminator, nil
+			}
+			if err := c.directive(out, name); err != nil {
+				return "", err
+			}
+		case strings.HasPrefix(rest, "</x-"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return "", c.errorf("unclosed tag")
+			}
+			tag := rest[:end+1]
+			c.pos += end + 1
+			if contains(terminators, tag) {
+				return tag, nil
+			}
+			return "", c.errorf("unexpected %s", tag)
+		case strings.HasPrefix(rest, "<x-"):
+			if err := c.component(out); err != nil {
+				return "", err
+			}
+		default:
+			out.WriteByte(rest[0])
+			c.pos++
+		}
+	}
+	if len(terminators) > 0 {
+		return "", c.errorf("missing %s", strings.Join(terminators, " or "))
+	}
+	return "", nil
+}
+
+// atDirective reports whether the "@" at pos starts a known directive
+// rather than, say, an email address
+func (c *compiler) atDirective() bool {
+	if c.pos > 0 && isIdentifierByte(c.src[c.pos-1]) {
+		return false
+	}
+	end := c.pos + 1
+	for end < len(c.src) && isIdentifierByte(c.src[end]) {
+		end++
+	}
+	return directives[c.src[c.pos+1:end]]
+}
+
+// readIdentifier consumes "@name" and returns name
+func (c *compiler) readIdentifier() string {
+	c.pos++
+	start := c.pos
+	for c.pos < len(c.src) && isIdentifierByte(c.src[c.pos]) {
+		c.pos++
+	}
+	return c.src[start:c.pos]
+}
+
+// directive compiles a directive whose name has been consumed
+func (c *compiler) directive(out *strings.Builder, name string) error {
+	switch name {
+	case "endsection", "show", "endpush":
+		return c.errorf("unexpected @%s", name)
+	}
+
+	args, err := c.readArguments()
+	if err != nil {
+		return err
+	}
+	maxArgs := 1
+	if name == "section" || name == "yield" {
+		maxArgs = 2
+	}
+	if len(args) == 0 || len(args) > maxArgs {
+		return c.errorf("@%s takes up to %d quoted arguments, got %d", name, maxArgs, len(args))
+	}
+
+	switch name {
+	case "extends":
+		c.parent = args[0]
+	case "section":
+		template := "section:" + args[0]
+		if len(args) == 2 {
+			c.define(template, quote(args[1]))
+			return nil
+		}
+		var body strings.Builder
+		terminator, err := c.compileUntil(&body, "@endsection", "@show")
+		if err != nil {
+			return err
+		}
+		if terminator == "@show" {
+			c.yielded[args[0]] = true
+			fmt.Fprintf(out, "{{block %s $}}%s{{end}}", strconv.Quote(template), body.String())
+			return nil
+		}
+		c.define(template, body.String())
+	case "yield":
+		template := strconv.Quote("section:" + args[0])
+		if c.yielded[args[0]] {
+			fmt.Fprintf(out, "{{template %s $}}", template)
+			return nil
+		}
+		c.yielded[args[0]] = true
+		fallback := ""
+		if len(args) == 2 {
+			fallback = quote(args[1])
+		}
+		fmt.Fprintf(out, "{{block %s $}}%s{{end}}", template, fallback)
+	case "include":
+		fmt.Fprintf(out, "{{include %s $}}", strconv.Quote(args[0]))
+	case "push":
+		var body strings.Builder
+		if _, err := c.compileUntil(&body, "@endpush"); err != nil {
+			return err
+		}
+		template := c.generatedName("push")
+		c.define(template, body.String())
+		fmt.Fprintf(out, "{{push %s %s .}}", strconv.Quote(args[0]), strconv.Quote(template))
+	case "stack":
+		fmt.Fprintf(out, "{{stack %s}}", strconv.Quote(args[0]))
+	}
+	return nil
+}
+
+// component compiles a component tag and its body
+func (c *compiler) component(out *strings.Builder) error {
+	c.pos += len("<x-")
+	start := c.pos
+	for c.pos < len(c.src) && (isIdentifierByte(c.src[c.pos]) || c.src[c.pos] == '-' || c.src[c.pos] == '.') {
+		c.pos++
+	}
+	name := c.src[start:c.pos]
+	if name == "" {
+		return c.errorf("missing component name")
+	}
+
+	attributes, selfClosing, err := c.readAttributes()
+	if err != nil {
+		return err
+	}
+
+	if name == "slot" {
+		return c.slot(attributes, selfClosing)
+	}
+
+	slot := ""
+	slots := make(map[string]string)
+	if !selfClosing {
+		var body strings.Builder
+		c.slots = append(c.slots, slots)
+		_, err := c.compileUntil(&body, "</x-"+name+">")
+		c.slots = c.slots[:len(c.slots)-1]
+		if err != nil {
+			return err
+		}
+		slot = c.generatedName("slot")
+		c.define(slot, body.String())
+	}
+
+	fmt.Fprintf(out, "{{component %s . %s (dict", strconv.Quote(name), strconv.Quote(slot))
+	for _, attribute := range attributes {
+		if attribute.expression {
+			fmt.Fprintf(out, " %s (%s)", strconv.Quote(attribute.name), attribute.value)
+		} else {
+			fmt.Fprintf(out, " %s %s", strconv.Quote(attribute.name), strconv.Quote(attribute.value))
+		}
+	}
+	out.WriteString(") (dict")
+	for _, slotName := range sortedKeys(slots) {
+		fmt.Fprintf(out, " %s %s", strconv.Quote(slotName), strconv.Quote(slots[slotName]))
+	}
+	out.WriteString(")}}")
+	return nil
+}
+
+// slot compiles a named slot of the enclosing component
+func (c *compiler) slot(attributes []attribute, selfClosing bool) error {
+	if len(c.slots) == 0 {
+		return c.errorf("<x-slot> outside of a component")
+	}
+	name := ""
+	for _, attribute := range attributes {
+		if attribute.name == "name" && !attribute.expression {
+			name = attribute.value
+		}
+	}
+	if name == "" {
+		return c.errorf("<x-slot> needs a name")
+	}
+
+	body := ""
+	if !selfClosing {
+		var builder strings.Builder
+		if _, err := c.compileUntil(&builder, "</x-slot>"); err != nil {
+			return err
+		}
+		body = builder.String()
+	}
+	template := c.generatedName("slot")
+	c.define(template, body)
+	c.slots[len(c.slots)-1][name] = template
+	return nil
+}
+
+// attribute is an attribute of a component tag
+type attribute struct {
+	name       string
+	value      string
+	expression bool
+}
+
+// readAttributes reads the attributes of a tag up to its closing ">"
+func (c *compiler) readAttributes() ([]attribute, bool, error) {
+	var attributes []attribute
+	for {
+		c.skipSpace()
+		if c.pos >= len(c.src) {
+			return nil, false, c.errorf("unclosed tag")
+		}
+		if strings.HasPrefix(c.src[c.pos:], "/>") {
+			c.pos += 2
+			return attributes, true, nil
+		}
+		if c.src[c.pos] == '>' {
+			c.pos++
+			return attributes, false, nil
+		}
+
+		var attr attribute
+		if c.src[c.pos] == ':' {
+			attr.expression = true
+			c.pos++
+		}
+		start := c.pos
+		for c.pos < len(c.src) && !strings.ContainsRune(" \t\r\n=>/", rune(c.src[c.pos])) {
+			c.pos++
+		}
+		attr.name = c.src[start:c.pos]
+		if attr.name == "" {
+			return nil, false, c.errorf("invalid attribute")
+		}
+
+		c.skipSpace()
+		if c.pos < len(c.src) && c.src[c.pos] == '=' {
+			c.pos++
+			c.skipSpace()
+			value, err := c.readQuoted()
+			if err != nil {
+				return nil, false, err
+			}
+			attr.value = value
+		} else if attr.expression {
+			return nil, false, c.errorf("attribute :%s needs a value", attr.name)
+		} else {
+			attr.value, attr.expression = "true", true
+		}
+		attributes = append(attributes, attr)
+	}
+}
+
+// readArguments reads a parenthesized list of quoted strings; directives
+// without parentheses have no arguments
+func (c *compiler) readArguments() ([]string, error) {
+	if c.pos >= len(c.src) || c.src[c.pos] != '(' {
+		return nil, nil
+	}
+	c.pos++
+	var args []string
+	for {
+		c.skipSpace()
+		if c.pos < len(c.src) && c.src[c.pos] == ')' && len(args) == 0 {
+			c.pos++
+			return args, nil
+		}
+		value, err := c.readQuoted()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+		c.skipSpace()
+		if c.pos >= len(c.src) {
+			return nil, c.errorf("unclosed directive arguments")
+		}
+		switch c.src[c.pos] {
+		case ',':
+			c.pos++
+		case ')':
+			c.pos++
+			return args, nil
+		default:
+			return nil, c.errorf("expected , or ) in directive arguments")
+		}
+	}
+}
+
+// readQuoted reads a single or double quoted string
+func (c *compiler) readQuoted() (string, error) {
+	if c.pos >= len(c.src) || (c.src[c.pos] != '"' && c.src[c.pos] != '\'') {
+		return "", c.errorf("expected a quoted string")
+	}
+	quote := c.src[c.pos]
+	end := strings.IndexByte(c.src[c.pos+1:], quote)
+	if end < 0 {
+		return "", c.errorf("unterminated string")
+	}
+	value := c.src[c.pos+1 : c.pos+1+end]
+	c.pos += end + 2
+	return value, nil
+}
+
+// skipSpace skips white space
+func (c *compiler) skipSpace() {
+	for c.pos < len(c.src) && strings.ContainsRune(" \t\r\n", rune(c.src[c.pos])) {
+		c.pos++
+	}
+}
+
+// define adds a generated {{define}} block
+func (c *compiler) define(name, body string) {
+	fmt.Fprintf(&c.defines, "{{define %s}}%s{{end}}", strconv.Quote(name), body)
+}
+
+// generatedName returns a template name unique within the layout chain
+func (c *compiler) generatedName(kind string) string {
+	c.counter++
+	return fmt.Sprintf("%s:%s:%d", kind, c.view, c.counter)
+}
+
+// errorf returns an error with the view name and line
+func (c *compiler) errorf(format string, args ...interface{}) error {
+	line := strings.Count(c.src[:min(c.pos, len(c.src))], "\n") + 1
+	return fmt.Errorf("view [%s] line %d: %s", c.view, line, fmt.Sprintf(format, args...))
+}
+
+// quote returns a template action printing a literal, escaped on output
+func quote(value string) string {
+	return "{{" + strconv.Quote(value) + "}}"
+}
+
+// isIdentifierByte reports whether b can be part of a directive name
+func isIdentifierByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// contains reports whether list contains value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,436 @@
+// Package view renders HTML views for GoVel.
+//
+// Views are html/template files extended with Blade-like directives for
+// layouts, sections and stacks, and component tags with slots:
+//
+//	@extends("layouts.app")
+//
+//	@section("title", "Profile")
+//
+//	@section("content")
+//		<x-card :title=".user.Name">
+//			<p>{{.user.Email}}</p>
+//			<x-slot name="footer"><a href="/logout">Log out</a></x-slot>
+//		</x-card>
+//	@endsection
+//
+//	@push("scripts")
+//		<script src="/profile.js"></script>
+//	@endpush
+//
+// The Factory finds views in its paths, shares data and runs view
+// composers, and caches parsed templates; without caching it reparses
+// views whose files changed, so edits show up on the next request.
+package view
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	viewInterfaces "govel/types/interfaces/view"
+)
+
+// DefaultExtension is the file extension of views
+const DefaultExtension = ".html"
+
+// Composer adds data to the views it is registered for
+type Composer func(view *View)
+
+// View is a view about to be rendered, passed to composers
+type View struct {
+	// Name is the view name in dot notation
+	Name string
+
+	// Data is the data the view is rendered with
+	Data map[string]interface{}
+}
+
+// With sets a data value
+func (v *View) With(key string, value interface{}) *View {
+	v.Data[key] = value
+	return v
+}
+
+// Component prepares the data of a component view. Components without a
+// registered Component render "components.<name>" with their attributes.
+type Component interface {
+	// View returns the name of the view rendered for the component
+	View() string
+
+	// Data returns the view data for the component attributes; slots are
+	// added to the returned data
+	Data(attributes map[string]interface{}) (map[string]interface{}, error)
+}
+
+// NotFoundError is returned for views missing from every path
+type NotFoundError struct {
+	// Name is the view name
+	Name string
+}
+
+// Error implements error
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("view [%s] not found", e.Name)
+}
+
+// composerEntry is a composer and the view pattern it runs for
+type composerEntry struct {
+	pattern  string
+	composer Composer
+}
+
+// source is a view file and its modification time when it was parsed
+type source struct {
+	fsys    fs.FS
+	path    string
+	modTime time.Time
+}
+
+// entry is a parsed view with its layout chain
+type entry struct {
+	// template holds the view and its layouts; it is never executed, only
+	// cloned for each render
+	template *htmlTemplate.Template
+
+	// root is the name of the outermost layout, the template executed
+	root string
+
+	// chain holds the view and its layouts, innermost first
+	chain []string
+
+	// sources are the files parsed into template
+	sources []source
+}
+
+// Factory finds, parses, caches and renders views.
+//
+// Example:
+//
+//	factory := view.NewFactory(os.DirFS("resources/views"))
+//	factory.Share("appName", "GoVel")
+//	factory.Composer("layouts.*", func(v *view.View) {
+//		v.With("year", time.Now().Year())
+//	})
+//
+//	html, err := factory.Render("users.profile", map[string]interface{}{"user": user})
+type Factory struct {
+	// mu guards all fields below
+	mu sync.RWMutex
+
+	// paths are searched in order for view files
+	paths []fs.FS
+
+	// extension is the view file extension
+	extension string
+
+	// cache keeps parsed views without checking their files for changes
+	cache bool
+
+	// shared is available to every view
+	shared map[string]interface{}
+
+	// composers run before their views render
+	composers []composerEntry
+
+	// components holds registered component classes by name
+	components map[string]Component
+
+	// helpers are the functions added with AddHelper
+	helpers htmlTemplate.FuncMap
+
+	// entries holds parsed views by name
+	entries map[string]*entry
+}
+
+// NewFactory creates a factory finding views in paths, searched in order.
+// Caching is enabled; call SetCache(false) during development.
+func NewFactory(paths ...fs.FS) *Factory {
+	return &Factory{
+		paths:      paths,
+		extension:  DefaultExtension,
+		cache:      true,
+		shared:     make(map[string]interface{}),
+		components: make(map[string]Component),
+		helpers:    make(htmlTemplate.FuncMap),
+		entries:    make(map[string]*entry),
+	}
+}
+
+// AddPath adds a path searched after the existing ones
+func (f *Factory) AddPath(fsys fs.FS) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, fsys)
+	f.entries = make(map[string]*entry)
+	return f
+}
+
+// SetExtension sets the view file extension, ".html" by default
+func (f *Factory) SetExtension(extension string) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extension = extension
+	f.entries = make(map[string]*entry)
+	return f
+}
+
+// SetCache enables caching. Without it, views whose files changed are
+// reparsed before rendering.
+func (f *Factory) SetCache(cache bool) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cache = cache
+	return f
+}
+
+// Flush forgets the parsed views
+func (f *Factory) Flush() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = make(map[string]*entry)
+}
+
+// Share makes a value available to every view
+func (f *Factory) Share(key string, value interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.shared[key] = value
+}
+
+// Shared returns the shared data
+func (f *Factory) Shared() map[string]interface{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	shared := make(map[string]interface{}, len(f.shared))
+	for key, value := range f.shared {
+		shared[key] = value
+	}
+	return shared
+}
+
+// Composer registers a composer for views matching pattern, a view name
+// in dot notation where "*" matches any part such as "layouts.*"
+func (f *Factory) Composer(pattern string, composer Composer) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.composers = append(f.composers, composerEntry{pattern: normalize(pattern), composer: composer})
+	return f
+}
+
+// RegisterComponent registers a component used as <x-name>
+func (f *Factory) RegisterComponent(name string, component Component) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.components[name] = component
+	return f
+}
+
+// AddHelper adds a function callable from every view. Parsed views are
+// forgotten so they pick up the function.
+func (f *Factory) AddHelper(name string, fn interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.helpers[name] = fn
+	f.entries = make(map[string]*entry)
+}
+
+// Exists reports whether the named view exists
+func (f *Factory) Exists(name string) bool {
+	_, _, err := f.find(name)
+	return err == nil
+}
+
+// Render renders the named view with data
+func (f *Factory) Render(name string, data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := f.RenderToWriter(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderToWriter renders the named view into w. The view is rendered in
+// full before anything is written, so w receives nothing on error.
+func (f *Factory) RenderToWriter(w io.Writer, name string, data map[string]interface{}) error {
+	state := newRenderState(f)
+	var buf bytes.Buffer
+	if err := f.render(&buf, state, name, f.viewData(data)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, state.finish(buf.String()))
+	return err
+}
+
+// render renders a view and its layouts within a render
+func (f *Factory) render(w io.Writer, state *renderState, name string, data map[string]interface{}) error {
+	parsed, err := f.entry(name)
+	if err != nil {
+		return err
+	}
+	for _, viewName := range parsed.chain {
+		f.compose(viewName, data)
+	}
+
+	tmpl, err := parsed.template.Clone()
+	if err != nil {
+		return err
+	}
+	tmpl.Funcs(state.funcs(tmpl))
+
+	// Like Blade, inner views run before their layouts so pushes outside of
+	// sections reach the stacks; only the outermost layout produces output
+	for _, viewName := range parsed.chain[:len(parsed.chain)-1] {
+		if err := tmpl.ExecuteTemplate(io.Discard, viewName, data); err != nil {
+			return fmt.Errorf("view [%s]: %w", name, err)
+		}
+	}
+	if err := tmpl.ExecuteTemplate(w, parsed.root, data); err != nil {
+		return fmt.Errorf("view [%s]: %w", name, err)
+	}
+	return nil
+}
+
+// viewData merges the shared data with data, which takes precedence
+func (f *Factory) viewData(data map[string]interface{}) map[string]interface{} {
+	merged := f.Shared()
+	for key, value := range data {
+		merged[key] = value
+	}
+	return merged
+}
+
+// compose runs the composers matching the view
+func (f *Factory) compose(name string, data map[string]interface{}) {
+	f.mu.RLock()
+	composers := append([]composerEntry(nil), f.composers...)
+	f.mu.RUnlock()
+
+	for _, entry := range composers {
+		if matched, _ := path.Match(entry.pattern, normalize(name)); matched {
+			entry.composer(&View{Name: name, Data: data})
+		}
+	}
+}
+
+// entry returns the parsed view, parsing it when it is not cached or,
+// without caching, when one of its files changed
+func (f *Factory) entry(name string) (*entry, error) {
+	key := normalize(name)
+
+	f.mu.RLock()
+	cached, exists := f.entries[key]
+	cache := f.cache
+	f.mu.RUnlock()
+	if exists && (cache || !changed(cached.sources)) {
+		return cached, nil
+	}
+
+	parsed, err := f.parse(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[key] = parsed
+	return parsed, nil
+}
+
+// parse compiles the view and its layouts into a single template set, the
+// outermost layout first so the sections of inner views replace its
+// defaults
+func (f *Factory) parse(name string) (*entry, error) {
+	parsed := &entry{}
+	var sources []string
+	for current := name; current != ""; {
+		if contains(parsed.chain, current) {
+			return nil, fmt.Errorf("view [%s]: circular @extends of [%s]", name, current)
+		}
+		fsys, filePath, err := f.find(current)
+		if err != nil {
+			return nil, err
+		}
+		info, err := fs.Stat(fsys, filePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return nil, err
+		}
+		view, err := compile(current, string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		parsed.chain = append(parsed.chain, current)
+		parsed.sources = append(parsed.sources, source{fsys: fsys, path: filePath, modTime: info.ModTime()})
+		sources = append(sources, view.source)
+		current = view.parent
+	}
+
+	f.mu.RLock()
+	funcs := make(htmlTemplate.FuncMap)
+	for helper, fn := range f.helpers {
+		funcs[helper] = fn
+	}
+	f.mu.RUnlock()
+	for helper, fn := range placeholderFuncs() {
+		funcs[helper] = fn
+	}
+
+	last := len(parsed.chain) - 1
+	parsed.root = parsed.chain[last]
+	parsed.template = htmlTemplate.New(parsed.root).Funcs(funcs)
+	if _, err := parsed.template.Parse(sources[last]); err != nil {
+		return nil, fmt.Errorf("view [%s]: %w", parsed.root, err)
+	}
+	for i := last - 1; i >= 0; i-- {
+		if _, err := parsed.template.New(parsed.chain[i]).Parse(sources[i]); err != nil {
+			return nil, fmt.Errorf("view [%s]: %w", parsed.chain[i], err)
+		}
+	}
+	return parsed, nil
+}
+
+// find returns the file system and path of the named view
+func (f *Factory) find(name string) (fs.FS, string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	filePath := normalize(name) + f.extension
+	for _, fsys := range f.paths {
+		if info, err := fs.Stat(fsys, filePath); err == nil && !info.IsDir() {
+			return fsys, filePath, nil
+		}
+	}
+	return nil, "", &NotFoundError{Name: name}
+}
+
+// changed reports whether any of the files changed since they were parsed
+func changed(sources []source) bool {
+	for _, file := range sources {
+		info, err := fs.Stat(file.fsys, file.path)
+		if err != nil || !info.ModTime().Equal(file.modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize converts a view name to its slash separated path without
+// extension: "users.profile" and "users/profile.html" both become
+// "users/profile"
+func normalize(name string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(name, DefaultExtension), ".", "/")
+}
+
+// Compile-time interface compliance check
+var _ viewInterfaces.ViewInterface = (*Factory)(nil)
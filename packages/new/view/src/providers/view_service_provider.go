@@ -0,0 +1,118 @@
+// Package providers contains service provider implementations for the view package.
+// Service providers are responsible for registering view services in the
+// dependency injection container and configuring them for use throughout the application.
+package providers
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"govel/application/providers"
+	view "govel/new/view"
+	applicationInterfaces "govel/types/interfaces/application/base"
+	configInterfaces "govel/types/interfaces/config"
+	viewInterfaces "govel/types/interfaces/view"
+)
+
+// ViewServiceProvider implements a Laravel-compatible service provider
+// for the view package.
+//
+// Services registered:
+//   - VIEW_TOKEN / VIEW_INTERFACE_TOKEN / VIEW_MANAGER_TOKEN / VIEW_FACTORY_TOKEN:
+//     Singleton Factory reading views from view.paths with view.extension.
+//     Parsed views are cached when view.cache is true, which defaults to
+//     every app.env but "local"
+type ViewServiceProvider struct {
+	providers.ServiceProvider
+}
+
+// NewViewServiceProvider creates a new ViewServiceProvider instance.
+//
+// Example:
+//
+//	provider := NewViewServiceProvider()
+//	err := provider.Register(application)
+//	if err != nil {
+//		log.Fatal("Failed to register view services:", err)
+//	}
+func NewViewServiceProvider() *ViewServiceProvider {
+	return &ViewServiceProvider{
+		ServiceProvider: providers.ServiceProvider{},
+	}
+}
+
+// Register registers all view services in the dependency injection container.
+func (p *ViewServiceProvider) Register(application applicationInterfaces.ApplicationInterface) error {
+	// Call parent Register method to set the registered flag
+	if err := p.ServiceProvider.Register(application); err != nil {
+		return fmt.Errorf("failed to register base service provider: %w", err)
+	}
+
+	var (
+		factory      *view.Factory
+		factoryMutex sync.Mutex
+	)
+	factoryFunc := func() (interface{}, error) {
+		factoryMutex.Lock()
+		defer factoryMutex.Unlock()
+
+		if factory != nil {
+			return factory, nil
+		}
+
+		config, err := application.Make("config")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config from container: %w", err)
+		}
+		configInterface, ok := config.(configInterfaces.ConfigInterface)
+		if !ok {
+			return nil, fmt.Errorf("config service does not implement ConfigInterface, got %T", config)
+		}
+
+		paths := configInterface.GetStringSlice("view.paths")
+		if len(paths) == 0 {
+			paths = []string{"resources/views"}
+		}
+		systems := make([]fs.FS, len(paths))
+		for i, path := range paths {
+			systems[i] = os.DirFS(path)
+		}
+
+		cache := configInterface.GetString("app.env", "production") != "local"
+		if value, exists := configInterface.Get("view.cache"); exists {
+			if enabled, ok := value.(bool); ok {
+				cache = enabled
+			}
+		}
+
+		factory = view.NewFactory(systems...).
+			SetExtension(configInterface.GetString("view.extension", view.DefaultExtension)).
+			SetCache(cache)
+		return factory, nil
+	}
+
+	for _, token := range []interface{}{
+		viewInterfaces.VIEW_TOKEN,
+		viewInterfaces.VIEW_INTERFACE_TOKEN,
+		viewInterfaces.VIEW_MANAGER_TOKEN,
+		viewInterfaces.VIEW_FACTORY_TOKEN,
+	} {
+		if err := application.Singleton(token, factoryFunc); err != nil {
+			return fmt.Errorf("failed to bind view factory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Provides returns a list of service tokens that this provider offers.
+func (p *ViewServiceProvider) Provides() []interface{} {
+	return []interface{}{
+		viewInterfaces.VIEW_TOKEN,
+		viewInterfaces.VIEW_INTERFACE_TOKEN,
+		viewInterfaces.VIEW_MANAGER_TOKEN,
+		viewInterfaces.VIEW_FACTORY_TOKEN,
+	}
+}
@@ -0,0 +1,196 @@
+package view
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+)
+
+// maxDepth limits nested includes and components, catching views that
+// include themselves
+const maxDepth = 64
+
+// renderState holds what a single render collects across the views it
+// renders: pushed stacks and the nesting depth
+type renderState struct {
+	// factory renders included views and components
+	factory *Factory
+
+	// nonce makes stack placeholders unique to the render
+	nonce string
+
+	// stacks holds the rendered pushes by stack name
+	stacks map[string][]string
+
+	// referenced holds the stacks rendered by @stack
+	referenced []string
+
+	// depth is the current include and component nesting
+	depth int
+}
+
+// newRenderState creates the state of a render
+func newRenderState(factory *Factory) *renderState {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return &renderState{
+		factory: factory,
+		nonce:   hex.EncodeToString(buf),
+		stacks:  make(map[string][]string),
+	}
+}
+
+// funcs returns the helpers used by compiled directives, bound to the
+// render and to tmpl, the template set defining the slots and pushes of
+// the view being executed
+func (s *renderState) funcs(tmpl *htmlTemplate.Template) htmlTemplate.FuncMap {
+	return htmlTemplate.FuncMap{
+		"dict": dict,
+		"include": func(name string, data interface{}) (htmlTemplate.HTML, error) {
+			values, ok := data.(map[string]interface{})
+			if !ok && data != nil {
+				return "", fmt.Errorf("include [%s]: data must be a map, got %T", name, data)
+			}
+			return s.renderNested(name, s.factory.viewData(values))
+		},
+		"component": func(name string, dot interface{}, slot string, attributes map[string]interface{}, slots map[string]interface{}) (htmlTemplate.HTML, error) {
+			return s.component(tmpl, name, dot, slot, attributes, slots)
+		},
+		"push": func(stack, template string, dot interface{}) (string, error) {
+			content, err := execute(tmpl, template, dot)
+			if err != nil {
+				return "", err
+			}
+			s.stacks[stack] = append(s.stacks[stack], string(content))
+			return "", nil
+		},
+		"stack": func(name string) htmlTemplate.HTML {
+			s.referenced = append(s.referenced, name)
+			return htmlTemplate.HTML(s.placeholder(name))
+		},
+	}
+}
+
+// component renders a component with its attributes and slots
+func (s *renderState) component(tmpl *htmlTemplate.Template, name string, dot interface{}, slot string, attributes, slots map[string]interface{}) (htmlTemplate.HTML, error) {
+	viewName := "components." + name
+	data := make(map[string]interface{}, len(attributes)+len(slots)+1)
+	for key, value := range attributes {
+		data[key] = value
+	}
+
+	s.factory.mu.RLock()
+	registered, exists := s.factory.components[name]
+	s.factory.mu.RUnlock()
+	if exists {
+		prepared, err := registered.Data(attributes)
+		if err != nil {
+			return "", fmt.Errorf("component [%s]: %w", name, err)
+		}
+		viewName, data = registered.View(), prepared
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+	}
+
+	content, err := execute(tmpl, slot, dot)
+	if err != nil {
+		return "", err
+	}
+	data["slot"] = content
+	for slotName, template := range slots {
+		templateName, _ := template.(string)
+		content, err := execute(tmpl, templateName, dot)
+		if err != nil {
+			return "", err
+		}
+		data[slotName] = content
+	}
+
+	return s.renderNested(viewName, s.factory.viewData(data))
+}
+
+// renderNested renders an included view or component into HTML
+func (s *renderState) renderNested(name string, data map[string]interface{}) (htmlTemplate.HTML, error) {
+	if s.depth >= maxDepth {
+		return "", fmt.Errorf("view [%s]: includes and components nested more than %d levels", name, maxDepth)
+	}
+	s.depth++
+	defer func() { s.depth-- }()
+
+	var buf bytes.Buffer
+	if err := s.factory.render(&buf, s, name, data); err != nil {
+		return "", err
+	}
+	return htmlTemplate.HTML(buf.String()), nil
+}
+
+// placeholder returns the marker replaced by the stack once the render
+// has collected every push
+func (s *renderState) placeholder(name string) string {
+	return "<!--stack:" + s.nonce + ":" + name + "-->"
+}
+
+// finish replaces the stack placeholders of the rendered output
+func (s *renderState) finish(output string) string {
+	for _, name := range s.referenced {
+		output = strings.ReplaceAll(output, s.placeholder(name), strings.Join(s.stacks[name], ""))
+	}
+	return output
+}
+
+// execute renders a generated template of tmpl, "" renders nothing
+func execute(tmpl *htmlTemplate.Template, name string, dot interface{}) (htmlTemplate.HTML, error) {
+	if name == "" {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, dot); err != nil {
+		return "", err
+	}
+	return htmlTemplate.HTML(buf.String()), nil
+}
+
+// dict builds a map from key and value pairs, as in
+// {{include "partials.card" (dict "title" .Title)}}
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict: odd number of arguments")
+	}
+	values := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		values[key] = pairs[i+1]
+	}
+	return values, nil
+}
+
+// errOutsideRender is returned by the placeholder helpers
+var errOutsideRender = errors.New("view: helper called outside of a render")
+
+// placeholderFuncs declares the render helpers at parse time; each render
+// replaces them with functions bound to its state
+func placeholderFuncs() htmlTemplate.FuncMap {
+	return htmlTemplate.FuncMap{
+		"dict": dict,
+		"include": func(string, interface{}) (htmlTemplate.HTML, error) {
+			return "", errOutsideRender
+		},
+		"component": func(string, interface{}, string, map[string]interface{}, map[string]interface{}) (htmlTemplate.HTML, error) {
+			return "", errOutsideRender
+		},
+		"push": func(string, string, interface{}) (string, error) {
+			return "", errOutsideRender
+		},
+		"stack": func(string) htmlTemplate.HTML {
+			return ""
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	webserver "govel/new/webserver"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// streamed returns a 200 response streaming reader
+func streamed(reader io.Reader) *webserver.Response {
+	resp := webserver.NewResponse()
+	resp.Stream(reader, "text/plain")
+	return resp
+}
+
+func TestResolveRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{name: "no header", header: "", status: 200, body: alphabet},
+		{name: "closed range", header: "bytes=0-4", status: 206, body: "abcde", contentRange: "bytes 0-4/26"},
+		{name: "end past the stream", header: "bytes=20-100", status: 206, body: "uvwxyz", contentRange: "bytes 20-25/26"},
+		{name: "open end", header: "bytes=20-", status: 206, body: "uvwxyz", contentRange: "bytes 20-25/26"},
+		{name: "suffix", header: "bytes=-5", status: 206, body: "vwxyz", contentRange: "bytes 21-25/26"},
+		{name: "suffix longer than the stream", header: "bytes=-100", status: 200, body: alphabet},
+		{name: "whole stream", header: "bytes=0-", status: 200, body: alphabet},
+		{name: "start past the stream", header: "bytes=26-", status: 416, body: "", contentRange: "bytes */26"},
+		{name: "start far past the stream", header: "bytes=100-200", status: 416, body: "", contentRange: "bytes */26"},
+		{name: "multiple ranges", header: "bytes=0-1,4-5", status: 200, body: alphabet},
+		{name: "reversed range", header: "bytes=5-2", status: 200, body: alphabet},
+		{name: "zero suffix", header: "bytes=-0", status: 200, body: alphabet},
+		{name: "other unit", header: "items=0-4", status: 200, body: alphabet},
+		{name: "malformed", header: "bytes=abc", status: 200, body: alphabet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := streamed(strings.NewReader(alphabet))
+
+			reader := resp.ResolveRange(tt.header)
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("ReadAll failed: %v", err)
+			}
+
+			if resp.StatusCode() != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, resp.StatusCode())
+			}
+			if string(body) != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, body)
+			}
+			if got := resp.HeadersMap()["Content-Range"]; got != tt.contentRange {
+				t.Errorf("Expected Content-Range %q, got %q", tt.contentRange, got)
+			}
+			if tt.status == 206 {
+				if got := resp.HeadersMap()["Content-Length"]; got != strconv.Itoa(len(tt.body)) {
+					t.Errorf("Expected Content-Length %d, got %q", len(tt.body), got)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveRange_EmptyStream(t *testing.T) {
+	resp := streamed(strings.NewReader(""))
+
+	if _, err := io.ReadAll(resp.ResolveRange("bytes=-5")); err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if resp.StatusCode() != 416 || resp.HeadersMap()["Content-Range"] != "bytes */0" {
+		t.Errorf("Expected 416 for a suffix of an empty stream, got %d %v", resp.StatusCode(), resp.HeadersMap())
+	}
+}
+
+func TestResolveRange_IgnoresUnseekableStreams(t *testing.T) {
+	resp := streamed(io.MultiReader(strings.NewReader(alphabet)))
+
+	body, _ := io.ReadAll(resp.ResolveRange("bytes=0-4"))
+	if resp.StatusCode() != 200 || string(body) != alphabet {
+		t.Errorf("Expected the full body for unseekable streams, got %d %q", resp.StatusCode(), body)
+	}
+	if _, ok := resp.HeadersMap()["Accept-Ranges"]; ok {
+		t.Error("Expected unseekable streams not to advertise byte ranges")
+	}
+}
@@ -45,6 +45,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -764,6 +765,9 @@ func (a *GoFiberAdapter) responseToFiber(c *fiber.Ctx, resp interfaces.ResponseI
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "invalid response type"})
 	}
 	
+	// Resolve Range requests first: they may change the status and headers
+	stream := concrete.ResolveRange(c.Get(fiber.HeaderRange))
+
 	// Set status code
 	c.Status(concrete.StatusCode())
 	
@@ -796,5 +800,14 @@ func (a *GoFiberAdapter) responseToFiber(c *fiber.Ctx, resp interfaces.ResponseI
 		return c.SendStatus(concrete.StatusCode())
 	}
 	
+	// Stream bodies without buffering them; fasthttp closes the reader when done
+	if stream != nil {
+		size := -1
+		if length, err := strconv.Atoi(concrete.HeadersMap()["Content-Length"]); err == nil {
+			size = length
+		}
+		return c.SendStream(stream, size)
+	}
+
 	return c.Send(concrete.Body())
 }
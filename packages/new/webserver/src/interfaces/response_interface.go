@@ -76,6 +76,15 @@ type ResponseInterface interface {
 	//   html: The HTML body
 	HTML(html string) ResponseInterface
 	
+	// View renders a view and sends it as an HTML response.
+	// Rendering failures produce a 500 response.
+	// Returns the response for method chaining.
+	//
+	// Parameters:
+	//   name: The view name in dot notation, such as "users.profile"
+	//   data: Optional data maps, merged in order
+	View(name string, data ...map[string]interface{}) ResponseInterface
+	
 	// Send sends a custom body with a specified content type.
 	// Returns the response for method chaining.
 	//
@@ -10,6 +10,7 @@ func (ResponseMock) RemoveHeader(string) interface{} { return ResponseMock{} }
 func (ResponseMock) Json(interface{}) interface{} { return ResponseMock{} }
 func (ResponseMock) Text(string) interface{} { return ResponseMock{} }
 func (ResponseMock) HTML(string) interface{} { return ResponseMock{} }
+func (ResponseMock) View(string, ...map[string]interface{}) interface{} { return ResponseMock{} }
 func (ResponseMock) Send([]byte, string) interface{} { return ResponseMock{} }
 func (ResponseMock) Stream(interface{}, string) interface{} { return ResponseMock{} }
 func (ResponseMock) File(string) interface{} { return ResponseMock{} }
@@ -7,9 +7,11 @@ import (
 	"fmt"
 
 	"govel/application/providers"
+	webserver "govel/new/webserver"
 	"govel/new/webserver/enums"
 	"govel/new/webserver/factories"
 	applicationInterfaces "govel/types/interfaces/application/base"
+	viewInterfaces "govel/types/interfaces/view"
 )
 
 // WebserverServiceProvider provides webserver services through the container.
@@ -26,6 +28,9 @@ import (
 //   - "webserver.create": Function(engine string, config map[string]interface{}) (interfaces.WebserverInterface, error)
 //   - "webserver.default": Function() interfaces.WebserverInterface — creates a default-engine server
 //
+// Response.View renders with the view factory bound to VIEW_TOKEN, resolved
+// from the container when views are rendered.
+//
 // Usage:
 //
 //	webFactoryAny, _ := container.Make("webserver.factory")
@@ -124,6 +129,18 @@ func (p *WebserverServiceProvider) Register(application applicationInterfaces.Ap
 		return fmt.Errorf("failed to register webserver.default: %w", err)
 	}
 
+	webserver.SetViewResolver(func() (viewInterfaces.ViewInterface, error) {
+		resolved, err := application.Make(viewInterfaces.VIEW_TOKEN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve view factory: %w", err)
+		}
+		renderer, ok := resolved.(viewInterfaces.ViewInterface)
+		if !ok {
+			return nil, fmt.Errorf("view service does not implement ViewInterface, got %T", resolved)
+		}
+		return renderer, nil
+	})
+
 	return nil
 }
 
@@ -19,6 +19,8 @@ type Response struct {
 	contentType string
 	cookies     []*http.Cookie
 	noContent   bool
+	stream      io.Reader
+	viewErr     error
 }
 
 // NewResponse creates an empty response with defaults (status 200, no headers).
@@ -82,10 +84,15 @@ func (r *Response) Send(body []byte, contentType string) interfaces.ResponseInte
 	return r
 }
 
-// Stream is a placeholder (adapters may implement streaming separately).
+// Stream sets a streamed body. Seekable streams advertise byte ranges so
+// adapters can answer Range requests through ResolveRange.
 func (r *Response) Stream(reader io.Reader, contentType string) interfaces.ResponseInterface {
-	// Not storing reader here; adapters can provide streaming-specific responses if needed.
+	r.stream = reader
+	r.body = nil
 	r.contentType = contentType
+	if _, ok := reader.(io.ReadSeeker); ok {
+		r.headers["Accept-Ranges"] = "bytes"
+	}
 	return r
 }
 
@@ -141,6 +148,7 @@ func (r *Response) Body() []byte                  { return r.body }
 func (r *Response) ContentType() string           { return r.contentType }
 func (r *Response) Cookies() []*http.Cookie       { return r.cookies }
 func (r *Response) IsNoContent() bool             { return r.noContent }
+func (r *Response) StreamReader() io.Reader       { return r.stream }
 
 // Compile-time interface compliance check
 var _ interfaces.ResponseInterface = (*Response)(nil)
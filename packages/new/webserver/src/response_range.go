@@ -0,0 +1,134 @@
+// Package webserver - Range support for streamed responses
+// This file resolves HTTP Range requests against seekable response streams.
+package webserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// errRangeNotSatisfiable is returned when a Range header lies outside the stream.
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// ResolveRange applies a Range request header to the response stream and
+// returns the reader the adapter should send.
+//
+// Only single byte ranges on seekable streams are served as 206 Partial
+// Content; multi-range and malformed headers fall back to the full body,
+// and ranges beyond the end of the stream yield 416. Adapters call this
+// just before writing a streamed response:
+//
+//	reader := resp.ResolveRange(req.Header.Get("Range"))
+//	ctx.SendStream(reader, contentLength)
+func (r *Response) ResolveRange(header string) io.Reader {
+	if r.stream == nil {
+		return nil
+	}
+
+	seeker, ok := r.stream.(io.ReadSeeker)
+	if !ok || header == "" || r.status != 200 {
+		return r.stream
+	}
+
+	size, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return r.stream
+	}
+
+	start, length, err := parseRange(header, size)
+	switch {
+	case errors.Is(err, errRangeNotSatisfiable):
+		r.closeStream()
+		r.stream = nil
+		r.Status(416)
+		r.headers["Content-Range"] = fmt.Sprintf("bytes */%d", size)
+		delete(r.headers, "Content-Length")
+		return strings.NewReader("")
+	case err != nil || length == size:
+		start, length = 0, size
+	}
+
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return r.stream
+	}
+	if length == size {
+		return r.stream
+	}
+
+	r.Status(206)
+	r.headers["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size)
+	r.headers["Content-Length"] = strconv.FormatInt(length, 10)
+	return &limitedReadCloser{Reader: io.LimitReader(seeker, length), source: r.stream}
+}
+
+// closeStream closes the response stream if it is closable.
+func (r *Response) closeStream() {
+	if closer, ok := r.stream.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// limitedReadCloser limits a stream to a range while still closing the source.
+type limitedReadCloser struct {
+	io.Reader
+	source io.Reader
+}
+
+// Close closes the underlying stream.
+func (l *limitedReadCloser) Close() error {
+	if closer, ok := l.source.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// parseRange parses a single "bytes=" range against a stream size.
+// Supported forms are "bytes=a-b", "bytes=a-" and "bytes=-n".
+func parseRange(header string, size int64) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, errors.New("unsupported range")
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, errors.New("malformed range")
+	}
+
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, errors.New("malformed range")
+		}
+		if suffix > size {
+			suffix = size
+		}
+		if size == 0 {
+			return 0, 0, errRangeNotSatisfiable
+		}
+		return size - suffix, suffix, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, errors.New("malformed range")
+	}
+	if start >= size {
+		return 0, 0, errRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errors.New("malformed range")
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end - start + 1, nil
+}
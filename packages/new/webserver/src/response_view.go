@@ -0,0 +1,79 @@
+// Package webserver - View responses
+// This file renders views into HTML responses through the application's view factory.
+package webserver
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"govel/new/webserver/interfaces"
+	viewInterfaces "govel/types/interfaces/view"
+)
+
+// errNoViewRenderer is returned by Response.View before a renderer is set.
+var errNoViewRenderer = errors.New("webserver: no view renderer set")
+
+// viewResolver finds the renderer used by Response.View.
+var viewResolver struct {
+	sync.RWMutex
+	resolve func() (viewInterfaces.ViewInterface, error)
+}
+
+// SetViewResolver sets how Response.View finds the view renderer. The
+// webserver service provider resolves it from the container on each call,
+// so the view service may be registered after the webserver.
+func SetViewResolver(resolve func() (viewInterfaces.ViewInterface, error)) {
+	viewResolver.Lock()
+	defer viewResolver.Unlock()
+	viewResolver.resolve = resolve
+}
+
+// SetViewRenderer makes Response.View render with the given renderer.
+func SetViewRenderer(renderer viewInterfaces.ViewInterface) {
+	SetViewResolver(func() (viewInterfaces.ViewInterface, error) { return renderer, nil })
+}
+
+// View renders a view and sets it as the HTML body. Data maps are merged in
+// order. When rendering fails the response becomes a plain 500 so template
+// details do not leak; the error is available from ViewError.
+//
+// Example:
+//
+//	return res.View("users.profile", map[string]interface{}{"user": user})
+func (r *Response) View(name string, data ...map[string]interface{}) interfaces.ResponseInterface {
+	merged := make(map[string]interface{})
+	for _, values := range data {
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	html, err := renderView(name, merged)
+	if err != nil {
+		r.viewErr = err
+		r.status = http.StatusInternalServerError
+		return r.Text(http.StatusText(http.StatusInternalServerError))
+	}
+	r.viewErr = nil
+	return r.HTML(html)
+}
+
+// ViewError returns the error of the last View call, nil when it succeeded.
+func (r *Response) ViewError() error { return r.viewErr }
+
+// renderView renders a view with the configured renderer.
+func renderView(name string, data map[string]interface{}) (string, error) {
+	viewResolver.RLock()
+	resolve := viewResolver.resolve
+	viewResolver.RUnlock()
+	if resolve == nil {
+		return "", errNoViewRenderer
+	}
+
+	renderer, err := resolve()
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(name, data)
+}
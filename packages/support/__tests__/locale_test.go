@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"testing"
+
+	"govel/support/carbon"
+	"govel/support/money"
+)
+
+func TestCarbonResolveLocale(t *testing.T) {
+	tests := map[string]string{
+		"fr":    "fr",
+		"fr_CA": "fr",
+		"de-DE": "de",
+		"pt_BR": "pt",
+		"zh_TW": "zh-TW",
+		"xx_YY": "en",
+		"":      "en",
+	}
+
+	for locale, want := range tests {
+		if got := carbon.ResolveLocale(locale); got != want {
+			t.Errorf("ResolveLocale(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestCarbonHelperLocalizesOutput(t *testing.T) {
+	date := carbon.CreateFromDateTime(2024, 2, 5, 10, 0, 0, "UTC")
+	earlier := date.Copy().SubDays(3)
+
+	tests := []struct {
+		locale    string
+		formatted string
+		diff      string
+	}{
+		{locale: "fr", formatted: "Lundi 5 Février 2024, Lun Févr", diff: "avant 3 jours"},
+		{locale: "de_DE", formatted: "Montag 5 Februar 2024, Mo Feb", diff: "3 Tage davor"},
+		{locale: "es", formatted: "Lunes 5 Febrero 2024, Lun Feb", diff: "3 días antes"},
+		{locale: "xx_YY", formatted: "Monday 5 February 2024, Mon Feb", diff: "3 days before"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			helper := carbon.NewHelper().WithLocale(tt.locale)
+
+			if got := helper.FormatLocalized(date, "l j F Y, D M"); got != tt.formatted {
+				t.Errorf("FormatLocalized = %q, want %q", got, tt.formatted)
+			}
+			if got := helper.DiffForHumans(earlier, date); got != tt.diff {
+				t.Errorf("DiffForHumans = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestCarbonLocalizeLeavesTheOriginalUntouched(t *testing.T) {
+	date := carbon.CreateFromDateTime(2024, 2, 5, 10, 0, 0, "UTC")
+
+	if got := carbon.Localize(date, "fr").ToMonthString(); got != "Février" {
+		t.Errorf("Expected the French month name, got %q", got)
+	}
+	if got := date.ToMonthString(); got != "February" {
+		t.Errorf("Expected the original date to keep its locale, got %q", got)
+	}
+	if carbon.Localize(date, "") != date {
+		t.Error("Expected an empty locale to return the date as is")
+	}
+}
+
+func TestMoneyFormatForLocale(t *testing.T) {
+	price := money.NewMoneyFromInt(123456, money.EUR)
+
+	tests := []struct {
+		locale  string
+		grouped string
+		code    string
+	}{
+		{locale: "fr", grouped: "1\u202f234,56\u00a0€", code: "1234,56 EUR"},
+		{locale: "de", grouped: "1.234,56\u00a0€", code: "1234,56 EUR"},
+		{locale: "pt_BR", grouped: "€\u00a01.234,56", code: "1234,56 EUR"},
+		{locale: "de-CH", grouped: "€\u00a01\u2019234.56", code: "1234.56 EUR"},
+		{locale: "fr_CA", grouped: "1\u202f234,56\u00a0€", code: "1234,56 EUR"},
+		{locale: "xx", grouped: "€1,234.56", code: "1234.56 EUR"},
+		{locale: "", grouped: "€1,234.56", code: "1234.56 EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			localized := price.WithLocale(tt.locale)
+
+			if got := localized.Format(true, ","); got != tt.grouped {
+				t.Errorf("Format(true) = %q, want %q", got, tt.grouped)
+			}
+			if got := localized.Format(false, ""); got != tt.code {
+				t.Errorf("Format(false) = %q, want %q", got, tt.code)
+			}
+		})
+	}
+}
+
+func TestMoneyLocalizedNegativeAndZeroDecimalAmounts(t *testing.T) {
+	if got := money.NewMoneyFromInt(-123456, money.EUR).WithLocale("fr").Format(true, ","); got != "-1\u202f234,56\u00a0€" {
+		t.Errorf("Unexpected negative amount %q", got)
+	}
+	if got := money.NewMoneyFromInt(123456, money.JPY).WithLocale("fr").String(); got != "123456\u00a0¥" {
+		t.Errorf("Unexpected yen amount %q", got)
+	}
+}
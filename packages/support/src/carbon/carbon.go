@@ -596,9 +596,9 @@ func EpochValue() *Carbon {
 //   if helper.IsWeekend(someDate) {
 //       // Handle weekend logic
 //   }
-type CarbonHelper struct{
-	// This struct intentionally has no fields as it only provides methods
-	// that operate on Carbon instances passed as parameters
+type CarbonHelper struct {
+	// locale localizes human-readable output when set (see WithLocale)
+	locale string
 }
 
 // NewHelper returns a new CarbonHelper instance.
@@ -662,8 +662,16 @@ func (h *CarbonHelper) IsTomorrow(c *Carbon) bool {
 	return c.ToDateString() == tomorrow.ToDateString()
 }
 
+// WithLocale returns a helper whose human-readable output (DiffForHumans,
+// FormatLocalized) uses the given locale, e.g. the locale of the current
+// request, without changing the global locale.
+func (h *CarbonHelper) WithLocale(locale string) *CarbonHelper {
+	return &CarbonHelper{locale: locale}
+}
+
 // DiffForHumans returns a human-readable difference string
 func (h *CarbonHelper) DiffForHumans(c *Carbon, other ...*Carbon) string {
+	c = Localize(c, h.locale)
 	if len(other) > 0 {
 		return c.DiffForHumans(other[0])
 	}
@@ -677,11 +685,14 @@ func (h *CarbonHelper) Age(c *Carbon) int64 {
 
 // Format Helpers (PHP Carbon style)
 
-// FormatLocalized returns a localized formatted string
+// FormatLocalized returns a formatted string whose month and weekday names
+// ("F", "M", "l", "D") are in the helper's locale, or the locale of c.
+//
+// Example:
+//
+//	carbon.NewHelper().WithLocale("fr").FormatLocalized(date, "l j F Y") // "Lundi 5 Février 2024"
 func (h *CarbonHelper) FormatLocalized(c *Carbon, format string) string {
-	// This would require locale-specific formatting implementation
-	// For now, return the standard format
-	return c.Format(format)
+	return formatLocalized(Localize(c, h.locale), format)
 }
 
 // ToDateString returns date in Y-m-d format
@@ -0,0 +1,76 @@
+package carbon
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// resolvedLocales caches application locales resolved to carbon locales.
+var resolvedLocales sync.Map
+
+// ResolveLocale maps an application locale such as "pt_BR" or "fr-CA" to
+// the closest locale shipped with the underlying carbon library: the exact
+// locale ("zh-CN"), then its language ("fr"), then "en".
+//
+// Example:
+//
+//	carbon.ResolveLocale("fr_CA") // "fr"
+//	carbon.ResolveLocale("zh_TW") // "zh-TW"
+func ResolveLocale(locale string) string {
+	if resolved, ok := resolvedLocales.Load(locale); ok {
+		return resolved.(string)
+	}
+
+	resolved := carbon.DefaultLocale
+	normalized := strings.ReplaceAll(locale, "_", "-")
+	for _, candidate := range []string{normalized, strings.SplitN(normalized, "-", 2)[0]} {
+		if candidate != "" && carbon.NewLanguage().SetLocale(candidate).Error == nil {
+			resolved = candidate
+			break
+		}
+	}
+
+	resolvedLocales.Store(locale, resolved)
+	return resolved
+}
+
+// Localize returns a copy of c whose human-readable output (DiffForHumans,
+// month and weekday names) uses the given locale. The global locale set by
+// SetLocale is left untouched, so it is safe to call per request.
+//
+// Example:
+//
+//	carbon.Localize(post.CreatedAt, "fr").DiffForHumans() // "il y a 3 jours"
+func Localize(c *Carbon, locale string) *Carbon {
+	if c == nil || locale == "" {
+		return c
+	}
+	return c.Copy().SetLocale(ResolveLocale(locale))
+}
+
+// formatLocalized formats c with PHP-style format characters, emitting the
+// month and weekday names ("F", "M", "l", "D") in the locale of c.
+func formatLocalized(c *Carbon, format string) string {
+	var builder strings.Builder
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case '\\':
+			builder.WriteByte(format[i])
+			if i+1 < len(format) {
+				i++
+				builder.WriteByte(format[i])
+			}
+		case 'l':
+			// The underlying library does not translate full weekday names
+			for _, b := range []byte(c.ToWeekString()) {
+				builder.WriteByte('\\')
+				builder.WriteByte(b)
+			}
+		default:
+			builder.WriteByte(format[i])
+		}
+	}
+	return c.Format(builder.String())
+}
@@ -149,6 +149,34 @@ func (m *Metrics) GetCacheHitRate() float64 {
 	return float64(m.CacheHits) / float64(total) * 100.0
 }
 
+// GetStatistics returns a consistent snapshot of all metrics, keyed like
+// their JSON names, for exporters that must not read fields while a
+// compilation is being recorded.
+//
+// Returns:
+//
+//	map[string]interface{}: Metric values by name
+func (m *Metrics) GetStatistics() map[string]interface{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return map[string]interface{}{
+		"total_compilations":      m.TotalCompilations,
+		"successful_compilations": m.SuccessfulCompilations,
+		"failed_compilations":     m.FailedCompilations,
+		"cache_hits":              m.CacheHits,
+		"cache_misses":            m.CacheMisses,
+		"average_compile_time":    m.AverageCompileTime,
+		"average_execution_time":  m.AverageExecutionTime,
+		"total_compile_time":      m.TotalCompileTime,
+		"total_execution_time":    m.TotalExecutionTime,
+		"peak_memory_usage":       m.PeakMemoryUsage,
+		"average_memory_usage":    m.AverageMemoryUsage,
+		"last_compilation_time":   m.LastCompilationTime,
+		"start_time":              m.StartTime,
+	}
+}
+
 // GetUptime returns how long the compiler has been running.
 // Calculates duration since metrics collection started.
 //
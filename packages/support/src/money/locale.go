@@ -0,0 +1,147 @@
+package money
+
+import (
+	"strings"
+)
+
+// LocaleFormat describes how a locale writes monetary amounts.
+type LocaleFormat struct {
+	// DecimalSeparator separates the integer and fractional parts
+	DecimalSeparator string
+
+	// GroupSeparator separates groups of thousands
+	GroupSeparator string
+
+	// SymbolFirst places the currency symbol before the amount
+	SymbolFirst bool
+
+	// SymbolSpace separates the currency symbol and the amount with a no-break space
+	SymbolSpace bool
+}
+
+// localeFormats holds the conventions of common locales, keyed by locale or
+// language. Unknown locales fall back to their language, then to "en".
+var localeFormats = map[string]LocaleFormat{
+	"en":    {".", ",", true, false},
+	"de":    {",", ".", false, true},
+	"de_CH": {".", "\u2019", true, true},
+	"es":    {",", ".", false, true},
+	"fr":    {",", "\u202f", false, true},
+	"it":    {",", ".", false, true},
+	"ja":    {".", ",", true, false},
+	"nl":    {",", ".", true, true},
+	"pl":    {",", "\u00a0", false, true},
+	"pt":    {",", "\u00a0", false, true},
+	"pt_BR": {",", ".", true, true},
+	"ru":    {",", "\u00a0", false, true},
+	"sv":    {",", "\u00a0", false, true},
+	"tr":    {",", ".", true, false},
+	"zh":    {".", ",", true, false},
+}
+
+// FormatForLocale returns the formatting conventions of a locale such as
+// "fr", "pt_BR" or "de-CH".
+func FormatForLocale(locale string) LocaleFormat {
+	normalized := strings.ReplaceAll(locale, "-", "_")
+	if format, ok := localeFormats[normalized]; ok {
+		return format
+	}
+	if format, ok := localeFormats[strings.SplitN(normalized, "_", 2)[0]]; ok {
+		return format
+	}
+	return localeFormats["en"]
+}
+
+// LocalizedMoney renders a Money value with the conventions of a locale.
+// Arithmetic goes through the embedded Money; only formatting changes.
+type LocalizedMoney struct {
+	*Money
+
+	// locale is the locale used for formatting
+	locale string
+}
+
+// WithLocale returns a view of m that formats amounts for the given locale,
+// e.g. the locale of the current request.
+//
+// Example:
+//
+//	price := money.NewMoneyFromInt(123456, money.EUR)
+//	price.WithLocale("fr").Format(true, ",") // "1\u202f234,56\u00a0€"
+//	price.WithLocale("en").Format(true, ",") // "€1,234.56"
+//	price.WithLocale("de").Format(false, ",") // "1.234,56 EUR"
+func (m *Money) WithLocale(locale string) *LocalizedMoney {
+	return &LocalizedMoney{Money: m, locale: locale}
+}
+
+// Locale returns the locale used for formatting.
+func (m *LocalizedMoney) Locale() string {
+	return m.locale
+}
+
+// Format returns the amount written with the locale's decimal separator,
+// grouping separator and symbol placement, with all decimal places of the
+// currency. As with Money.Format, an empty thousandsSep disables grouping;
+// any other value enables the locale's grouping separator.
+func (m *LocalizedMoney) Format(useSymbol bool, thousandsSep string) string {
+	info, exists := currencyInfoMap[m.currency]
+	if !exists {
+		return m.Money.Format(useSymbol, thousandsSep)
+	}
+
+	format := FormatForLocale(m.locale)
+	amount := m.amount.StringFixed(int32(info.DecimalPlaces))
+
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+
+	integerPart, fractionPart, _ := strings.Cut(amount, ".")
+	if thousandsSep != "" {
+		integerPart = groupDigits(integerPart, format.GroupSeparator)
+	}
+
+	amount = integerPart
+	if fractionPart != "" {
+		amount += format.DecimalSeparator + fractionPart
+	}
+
+	if !useSymbol {
+		amount += " " + string(m.currency)
+	} else {
+		space := ""
+		if format.SymbolSpace {
+			space = "\u00a0"
+		}
+		if format.SymbolFirst {
+			amount = info.Symbol + space + amount
+		} else {
+			amount = amount + space + info.Symbol
+		}
+	}
+
+	if negative {
+		return "-" + amount
+	}
+	return amount
+}
+
+// String returns the amount formatted with its symbol and without grouping.
+func (m *LocalizedMoney) String() string {
+	return m.Format(true, "")
+}
+
+// groupDigits inserts sep between groups of three digits.
+func groupDigits(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var builder strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteString(sep)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
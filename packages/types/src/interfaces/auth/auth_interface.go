@@ -1,6 +1,19 @@
 package interfaces
 
-// authInterface defines the contract for auth interface functionality.
+// AuthInterface defines the contract of the authentication manager, the
+// service behind the Auth facade. It mirrors Laravel's
+// Illuminate\Contracts\Auth\Factory contract.
 type AuthInterface interface {
-	// TODO: Define methods for this interface
+	// Guard returns a guard by name, the default guard when omitted.
+	//
+	// Returns:
+	//   - GuardInterface: The guard; session guards also implement StatefulGuardInterface
+	//   - error: When the guard is not configured or cannot be created
+	Guard(name ...string) (GuardInterface, error)
+
+	// ShouldUse sets the default guard.
+	ShouldUse(name string)
+
+	// GetDefaultDriver returns the name of the default guard.
+	GetDefaultDriver() string
 }
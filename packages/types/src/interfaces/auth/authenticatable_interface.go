@@ -0,0 +1,24 @@
+package interfaces
+
+// AuthenticatableInterface is implemented by users that guards can
+// authenticate. It mirrors Laravel's
+// Illuminate\Contracts\Auth\Authenticatable contract.
+type AuthenticatableInterface interface {
+	// GetAuthIdentifierName returns the name of the identifier column, e.g. "id".
+	GetAuthIdentifierName() string
+
+	// GetAuthIdentifier returns the unique identifier of the user.
+	GetAuthIdentifier() interface{}
+
+	// GetAuthPassword returns the password hash of the user.
+	GetAuthPassword() string
+
+	// GetRememberToken returns the "remember me" token, "" when unset.
+	GetRememberToken() string
+
+	// SetRememberToken sets the "remember me" token.
+	SetRememberToken(token string)
+
+	// GetRememberTokenName returns the name of the remember token column.
+	GetRememberTokenName() string
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+// GateInterface authorizes abilities for a user. It mirrors Laravel's
+// Illuminate\Contracts\Auth\Access\Gate contract; a gate checks the
+// abilities of the user it was created for, the guest for the shared gate.
+// Implementations provide ForUser to create a gate for a user.
+type GateInterface interface {
+	// Has reports whether the ability is defined.
+	Has(ability string) bool
+
+	// Allows reports whether the user may perform the ability.
+	Allows(ability string, arguments ...interface{}) bool
+
+	// Denies reports whether the user may not perform the ability.
+	Denies(ability string, arguments ...interface{}) bool
+
+	// Authorize returns an HTTP exception carrying the denial message when
+	// the user may not perform the ability.
+	Authorize(ability string, arguments ...interface{}) error
+
+	// Any reports whether the user may perform any of the abilities.
+	Any(abilities []string, arguments ...interface{}) bool
+
+	// Check reports whether the user may perform all of the abilities.
+	Check(abilities []string, arguments ...interface{}) bool
+}
@@ -0,0 +1,76 @@
+package interfaces
+
+// RequestInterface is the view of an HTTP request guards need. The
+// webserver request satisfies it.
+type RequestInterface interface {
+	// GetContext returns a request-scoped value.
+	GetContext(key string) interface{}
+
+	// SetContext stores a request-scoped value.
+	SetContext(key string, value interface{})
+
+	// Cookie returns a request cookie.
+	Cookie(name string, defaultValue ...string) string
+
+	// Header returns a request header.
+	Header(key string, defaultValue ...string) string
+
+	// Bearer returns the bearer token of the Authorization header.
+	Bearer() string
+
+	// Input returns a query or body input value.
+	Input(key string, defaultValue ...string) string
+}
+
+// GuardInterface authenticates the user of a request. It mirrors
+// Laravel's Illuminate\Contracts\Auth\Guard contract; the state of a guard
+// is kept per request.
+type GuardInterface interface {
+	// GetName returns the guard name.
+	GetName() string
+
+	// Check reports whether the request has an authenticated user.
+	Check(req RequestInterface) bool
+
+	// Guest reports whether the request has no authenticated user.
+	Guest(req RequestInterface) bool
+
+	// User returns the authenticated user of the request, or nil.
+	User(req RequestInterface) AuthenticatableInterface
+
+	// ID returns the identifier of the authenticated user, or nil.
+	ID(req RequestInterface) interface{}
+
+	// Validate reports whether the credentials are valid without logging in.
+	Validate(req RequestInterface, credentials map[string]interface{}) bool
+
+	// SetUser sets the authenticated user of the request.
+	SetUser(req RequestInterface, user AuthenticatableInterface)
+}
+
+// StatefulGuardInterface is a guard remembering the user across requests,
+// such as the session guard. It mirrors Laravel's
+// Illuminate\Contracts\Auth\StatefulGuard contract.
+type StatefulGuardInterface interface {
+	GuardInterface
+
+	// Attempt logs in the user matching the credentials, reporting whether
+	// the credentials were valid.
+	Attempt(req RequestInterface, credentials map[string]interface{}, remember bool) (bool, error)
+
+	// Once authenticates the user for the current request only.
+	Once(req RequestInterface, credentials map[string]interface{}) bool
+
+	// Login logs in the user, setting a "remember me" cookie when asked.
+	Login(req RequestInterface, user AuthenticatableInterface, remember bool) error
+
+	// LoginUsingID logs in the user with the identifier.
+	LoginUsingID(req RequestInterface, id interface{}, remember bool) (AuthenticatableInterface, error)
+
+	// ViaRemember reports whether the user was authenticated by the
+	// "remember me" cookie.
+	ViaRemember(req RequestInterface) bool
+
+	// Logout logs the user out and rotates the "remember me" token.
+	Logout(req RequestInterface) error
+}
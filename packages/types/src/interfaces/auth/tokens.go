@@ -19,4 +19,7 @@ var (
 
 	// AUTH_CONFIG_TOKEN is the config token for auth
 	AUTH_CONFIG_TOKEN = symbol.For("govel.auth.config")
+
+	// GATE_TOKEN is the service token for the authorization gate
+	GATE_TOKEN = symbol.For("govel.auth.gate")
 )
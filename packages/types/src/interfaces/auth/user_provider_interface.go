@@ -0,0 +1,24 @@
+package interfaces
+
+import "context"
+
+// UserProviderInterface retrieves users for guards. It mirrors Laravel's
+// Illuminate\Contracts\Auth\UserProvider contract. Retrieval methods return
+// a nil user and a nil error when no user matches.
+type UserProviderInterface interface {
+	// RetrieveByID returns the user with the identifier.
+	RetrieveByID(ctx context.Context, identifier interface{}) (AuthenticatableInterface, error)
+
+	// RetrieveByToken returns the user with the identifier and "remember me" token.
+	RetrieveByToken(ctx context.Context, identifier interface{}, token string) (AuthenticatableInterface, error)
+
+	// UpdateRememberToken stores a new "remember me" token for the user.
+	UpdateRememberToken(ctx context.Context, user AuthenticatableInterface, token string) error
+
+	// RetrieveByCredentials returns the user matching the credentials,
+	// ignoring the password.
+	RetrieveByCredentials(ctx context.Context, credentials map[string]interface{}) (AuthenticatableInterface, error)
+
+	// ValidateCredentials checks the password of the credentials against the user.
+	ValidateCredentials(user AuthenticatableInterface, credentials map[string]interface{}) bool
+}
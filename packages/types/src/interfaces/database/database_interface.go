@@ -217,6 +217,13 @@ type QueryBuilderInterface interface {
 	Having(column string, operator string, value interface{}) QueryBuilderInterface
 	Limit(count int) QueryBuilderInterface
 	Offset(count int) QueryBuilderInterface
+	OrWhere(column string, operator string, value interface{}) QueryBuilderInterface
+	WhereNotIn(column string, values []interface{}) QueryBuilderInterface
+	WhereBetween(column string, from interface{}, to interface{}) QueryBuilderInterface
+	WhereRaw(sql string, bindings ...interface{}) QueryBuilderInterface
+	Distinct() QueryBuilderInterface
+	UseWriteConnection() QueryBuilderInterface
+	Clone() QueryBuilderInterface
 	
 	// Execution
 	Get() (*sql.Rows, error)
@@ -235,6 +242,20 @@ type QueryBuilderInterface interface {
 	UpdateContext(ctx context.Context, data map[string]interface{}) (sql.Result, error)
 	Delete() (sql.Result, error)
 	DeleteContext(ctx context.Context) (sql.Result, error)
+	InsertBatch(rows []map[string]interface{}) (sql.Result, error)
+	InsertBatchContext(ctx context.Context, rows []map[string]interface{}) (sql.Result, error)
+	InsertGetID(data map[string]interface{}) (int64, error)
+	InsertGetIDContext(ctx context.Context, data map[string]interface{}) (int64, error)
+	Upsert(rows []map[string]interface{}, uniqueBy []string, update []string) (sql.Result, error)
+	UpsertContext(ctx context.Context, rows []map[string]interface{}, uniqueBy []string, update []string) (sql.Result, error)
+	
+	// Returning (SQLite and PostgreSQL)
+	InsertReturning(data map[string]interface{}, columns ...string) (*sql.Rows, error)
+	InsertReturningContext(ctx context.Context, data map[string]interface{}, columns ...string) (*sql.Rows, error)
+	UpdateReturning(data map[string]interface{}, columns ...string) (*sql.Rows, error)
+	UpdateReturningContext(ctx context.Context, data map[string]interface{}, columns ...string) (*sql.Rows, error)
+	DeleteReturning(columns ...string) (*sql.Rows, error)
+	DeleteReturningContext(ctx context.Context, columns ...string) (*sql.Rows, error)
 	
 	// SQL Generation
 	ToSQL() (string, []interface{}, error)
@@ -1,6 +1,69 @@
 package interfaces
 
-// languageInterface defines the contract for language interface functionality.
+// LanguageInterface defines the contract for the translator. It mirrors
+// Laravel's Illuminate\Contracts\Translation\Translator contract and is the
+// type returned by the Lang facade.
+//
+// Key features:
+//   - Dotted keys resolved from group files ("validation.required")
+//   - JSON string keys ("Welcome back!")
+//   - Namespaced package keys ("courier::messages.sent")
+//   - ":name" placeholders with ":Name" and ":NAME" case variants
+//   - Laravel plural selectors ("{0} None|[1,19] Some|[20,*] Many")
+//   - Fallback locale chains (pt_BR -> pt -> fallback)
+//
+// Implementations must be safe for concurrent use.
 type LanguageInterface interface {
-	// TODO: Define methods for this interface
+	// Get returns the translation for the given key.
+	//
+	// Parameters:
+	//   - key: Translation key
+	//   - replace: Placeholder replacements, may be nil
+	//   - locale: Locale to translate to, the current locale when empty
+	//   - fallback: Whether to try the fallback locale chain
+	//
+	// Returns:
+	//   - interface{}: The translated string, a map of lines when the key
+	//     names a whole group or sub-tree, or the key itself when missing
+	Get(key string, replace map[string]interface{}, locale string, fallback bool) interface{}
+
+	// Trans translates a key to a string, returning the key when missing.
+	//
+	// Example:
+	//   lang.Trans("messages.welcome", map[string]interface{}{"name": "Taylor"})
+	Trans(key string, replace map[string]interface{}, locale ...string) string
+
+	// Choice translates a key according to an integer value, selecting the
+	// matching plural form.
+	//
+	// Example:
+	//   lang.Choice("messages.apples", 10, nil, "en")
+	Choice(key string, number int, replace map[string]interface{}, locale ...string) string
+
+	// TransChoice translates a key according to an integer value in the
+	// current locale. The ":count" placeholder is replaced with number.
+	//
+	// Example:
+	//   lang.TransChoice("messages.apples", 10)
+	TransChoice(key string, number int, replace ...map[string]interface{}) string
+
+	// Has reports whether a translation exists for the key, using the
+	// fallback locale chain.
+	Has(key string, locale ...string) bool
+
+	// HasForLocale reports whether a translation exists for the key in the
+	// given locale, without falling back.
+	HasForLocale(key, locale string) bool
+
+	// GetLocale returns the default locale being used.
+	GetLocale() string
+
+	// SetLocale sets the default locale.
+	SetLocale(locale string)
+
+	// GetFallback returns the fallback locale being used.
+	GetFallback() string
+
+	// SetFallback sets the fallback locale being used.
+	SetFallback(fallback string)
 }
@@ -17,6 +17,9 @@ var (
 	// LANG_INTERFACE_TOKEN is the interface token for lang
 	LANG_INTERFACE_TOKEN = symbol.For("govel.lang.interface")
 
+	// LANG_LOADER_TOKEN is the translation loader token for lang
+	LANG_LOADER_TOKEN = symbol.For("govel.lang.loader")
+
 	// LANG_CONFIG_TOKEN is the config token for lang
 	LANG_CONFIG_TOKEN = symbol.For("govel.lang.config")
 )
@@ -1,6 +1,25 @@
 package interfaces
 
-// mailInterface defines the contract for mail interface functionality.
+import "time"
+
+// MailInterface defines the contract for sending mail through the default
+// mailer. Recipients are plain addresses or "Name <address>" strings.
 type MailInterface interface {
-	// TODO: Define methods for this interface
+	// Send sends a plain text message to a single recipient
+	Send(to, subject, body string) error
+
+	// SendToMany sends a plain text message to every recipient
+	SendToMany(to []string, subject, body string) error
+
+	// SendTemplate sends a message rendered from the named template; the
+	// HTML view is "<template>.html" and the optional text alternative
+	// "<template>.txt"
+	SendTemplate(to, subject, template string, data map[string]interface{}) error
+
+	// Queue renders a template message and sends it in the background
+	// through the command bus
+	Queue(to, subject, template string, data map[string]interface{}) error
+
+	// Later queues a plain text message to be sent at the given time
+	Later(at time.Time, to, subject, body string) error
 }
@@ -1,6 +1,16 @@
 package interfaces
 
-// ormInterface defines the contract for orm interface functionality.
+import databaseInterfaces "govel/types/interfaces/database"
+
+// OrmInterface provides the connections and model events of the ORM and
+// is the service behind the Orm facade.
 type OrmInterface interface {
-	// TODO: Define methods for this interface
+	// Connection resolves the named connection, or the default one
+	Connection(name ...string) (databaseInterfaces.DatabaseInterface, error)
+
+	// Listen registers a model event listener. The pattern is a full event
+	// name such as "creating: users", an event for every model such as
+	// "creating", or "*". An error returned for one of the "-ing" events
+	// cancels the operation.
+	Listen(pattern string, listener func(event string, model interface{}) error)
 }
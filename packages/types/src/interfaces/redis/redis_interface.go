@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+// RedisInterface defines the contract for the Redis manager.
+type RedisInterface interface {
+	// Command runs a raw command on the default connection.
+	Command(ctx context.Context, method string, args ...interface{}) (interface{}, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import "govel/support/symbol"
+
+// Standard tokens for redis package
+var (
+	// REDIS_TOKEN is the main service token for redis
+	REDIS_TOKEN = symbol.For("govel.redis")
+
+	// REDIS_FACTORY_TOKEN is the factory token for redis
+	REDIS_FACTORY_TOKEN = symbol.For("govel.redis.factory")
+
+	// REDIS_MANAGER_TOKEN is the manager token for redis
+	REDIS_MANAGER_TOKEN = symbol.For("govel.redis.manager")
+
+	// REDIS_CONNECTION_TOKEN is the default connection token for redis
+	REDIS_CONNECTION_TOKEN = symbol.For("govel.redis.connection")
+)
@@ -1,6 +1,42 @@
 package interfaces
 
-// schemaInterface defines the contract for schema interface functionality.
+// SchemaInterface defines the blueprint independent operations of a
+// schema builder. Creating and altering tables takes dialect specific
+// blueprints and is provided by the database package's schema builder.
 type SchemaInterface interface {
-	// TODO: Define methods for this interface
+	// Drop drops a table
+	Drop(table string) error
+
+	// DropIfExists drops a table when it exists
+	DropIfExists(table string) error
+
+	// Rename renames a table
+	Rename(from string, to string) error
+
+	// DropAllTables drops every table of the database
+	DropAllTables() error
+
+	// HasTable reports whether the table exists
+	HasTable(table string) (bool, error)
+
+	// HasColumn reports whether the table has the column
+	HasColumn(table string, column string) (bool, error)
+
+	// HasColumns reports whether the table has all of the columns
+	HasColumns(table string, columns ...string) (bool, error)
+
+	// GetTables returns the table names of the database
+	GetTables() ([]string, error)
+
+	// GetColumnListing returns the column names of the table
+	GetColumnListing(table string) ([]string, error)
+
+	// EnableForeignKeyConstraints turns foreign key enforcement on
+	EnableForeignKeyConstraints() error
+
+	// DisableForeignKeyConstraints turns foreign key enforcement off
+	DisableForeignKeyConstraints() error
+
+	// Statement runs a raw schema statement
+	Statement(statement string, args ...interface{}) error
 }
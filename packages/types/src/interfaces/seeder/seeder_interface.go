@@ -1,6 +1,14 @@
 package interfaces
 
-// seederInterface defines the contract for seeder interface functionality.
+import "context"
+
+// SeederInterface runs database seeders and is the service behind the
+// Seeder facade.
 type SeederInterface interface {
-	// TODO: Define methods for this interface
+	// Call runs seeders in order. Each seeder is either a registered
+	// seeder name such as "DatabaseSeeder" or a seeder value.
+	Call(ctx context.Context, seeders ...interface{}) error
+
+	// Names returns the registered seeder names
+	Names() []string
 }
@@ -1,6 +1,168 @@
 package interfaces
 
-// sessionInterface defines the contract for session interface functionality.
+// SessionInterface defines the contract for a request-scoped session store.
+// It mirrors Laravel's Illuminate\Contracts\Session\Session contract and is the
+// type returned by the Session facade.
+//
+// Key features:
+//   - Attribute access with defaults, existence checks and removal
+//   - Flash data that survives exactly one subsequent request
+//   - CSRF token management
+//   - Session ID rotation (Regenerate/Migrate/Invalidate) for login and logout
+//   - Persistence through a pluggable storage handler
+//
+// A session instance belongs to a single request. Implementations must be safe
+// to persist concurrently with other requests that share the same session ID.
 type SessionInterface interface {
-	// TODO: Define methods for this interface
+	// GetName returns the name of the session (used as the cookie name).
+	GetName() string
+
+	// SetName sets the name of the session.
+	SetName(name string)
+
+	// GetID returns the current session ID.
+	GetID() string
+
+	// SetID sets the session ID. Invalid IDs are replaced with a fresh one.
+	SetID(id string)
+
+	// IsValidID reports whether the given string is a well-formed session ID.
+	IsValidID(id string) bool
+
+	// Start loads the session data from the handler.
+	//
+	// Returns:
+	//   - error: Any error raised while reading the session payload
+	Start() error
+
+	// Save persists the session data to the handler.
+	// Flash data is aged before the payload is written.
+	//
+	// Returns:
+	//   - error: Any error raised while writing the session payload
+	Save() error
+
+	// AgeFlashData removes the flash data of the previous request and
+	// marks the current flash data as old.
+	AgeFlashData()
+
+	// All returns a copy of all session attributes.
+	All() map[string]interface{}
+
+	// Only returns the subset of attributes matching the given keys.
+	Only(keys ...string) map[string]interface{}
+
+	// Except returns all attributes except the given keys.
+	Except(keys ...string) map[string]interface{}
+
+	// Exists reports whether the key is present, even when its value is nil.
+	Exists(key string) bool
+
+	// Missing reports whether the key is absent.
+	Missing(key string) bool
+
+	// Has reports whether the key is present and not nil.
+	Has(key string) bool
+
+	// Get returns the value of a key or the optional default.
+	Get(key string, defaultValue ...interface{}) interface{}
+
+	// GetString returns the value of a key as a string.
+	GetString(key string, defaultValue ...string) string
+
+	// GetInt returns the value of a key as an int.
+	GetInt(key string, defaultValue ...int) int
+
+	// GetBool returns the value of a key as a bool.
+	GetBool(key string, defaultValue ...bool) bool
+
+	// Pull returns the value of a key and removes it from the session.
+	Pull(key string, defaultValue ...interface{}) interface{}
+
+	// HasOldInput reports whether the session holds old input for the key.
+	HasOldInput(key ...string) bool
+
+	// GetOldInput returns old input flashed by the previous request.
+	GetOldInput(key string, defaultValue ...interface{}) interface{}
+
+	// Put stores a value in the session.
+	Put(key string, value interface{})
+
+	// PutMany stores multiple values in the session.
+	PutMany(values map[string]interface{})
+
+	// Remember returns the value of a key, storing the callback result when absent.
+	Remember(key string, callback func() interface{}) interface{}
+
+	// Push appends a value to an array attribute.
+	Push(key string, value interface{})
+
+	// Increment increments an integer attribute and returns the new value.
+	Increment(key string, amount ...int) int
+
+	// Decrement decrements an integer attribute and returns the new value.
+	Decrement(key string, amount ...int) int
+
+	// Flash stores a value for the next request only.
+	Flash(key string, value interface{})
+
+	// Now stores a value for the current request only.
+	Now(key string, value interface{})
+
+	// Reflash keeps all flash data for an additional request.
+	Reflash()
+
+	// Keep keeps the given flash keys for an additional request.
+	Keep(keys ...string)
+
+	// FlashInput flashes the given input for the next request.
+	FlashInput(input map[string]interface{})
+
+	// Remove removes a key and returns its previous value.
+	Remove(key string) interface{}
+
+	// Forget removes one or more keys.
+	Forget(keys ...string)
+
+	// Flush removes all attributes.
+	Flush()
+
+	// Invalidate flushes the session data and regenerates the ID.
+	//
+	// Returns:
+	//   - error: Any error raised while destroying the old session
+	Invalidate() error
+
+	// Regenerate generates a new session ID and a new CSRF token.
+	// When destroy is true the data stored under the old ID is removed.
+	// This must be called after login to prevent session fixation.
+	//
+	// Returns:
+	//   - error: Any error raised while destroying the old session
+	Regenerate(destroy bool) error
+
+	// Migrate generates a new session ID while keeping all attributes.
+	// When destroy is true the data stored under the old ID is removed.
+	//
+	// Returns:
+	//   - error: Any error raised while destroying the old session
+	Migrate(destroy bool) error
+
+	// IsStarted reports whether the session has been started.
+	IsStarted() bool
+
+	// Token returns the CSRF token value.
+	Token() string
+
+	// RegenerateToken generates a new CSRF token.
+	RegenerateToken()
+
+	// PreviousURL returns the previous URL stored in the session.
+	PreviousURL() string
+
+	// SetPreviousURL stores the previous URL in the session.
+	SetPreviousURL(url string)
+
+	// PasswordConfirmed records the time the user last confirmed their password.
+	PasswordConfirmed()
 }
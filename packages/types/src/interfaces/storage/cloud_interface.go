@@ -0,0 +1,23 @@
+package interfaces
+
+import "time"
+
+// CloudInterface extends FilesystemInterface with URL generation.
+type CloudInterface interface {
+	FilesystemInterface
+
+	// URL returns the public URL of a file.
+	URL(path string) (string, error)
+
+	// ProvidesTemporaryURLs reports whether the disk can generate temporary URLs.
+	ProvidesTemporaryURLs() bool
+
+	// TemporaryURL returns a signed URL granting read access to a file until
+	// the expiration. Cloud disks accept driver options such as
+	// "ResponseContentDisposition" or "ResponseContentType".
+	TemporaryURL(path string, expiration time.Time, options ...map[string]interface{}) (string, error)
+
+	// TemporaryUploadURL returns a signed URL accepting a PUT upload of a file
+	// until the expiration, together with the headers the client must send.
+	TemporaryUploadURL(path string, expiration time.Time, options ...map[string]interface{}) (string, map[string]string, error)
+}
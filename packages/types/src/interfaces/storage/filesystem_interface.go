@@ -0,0 +1,85 @@
+package interfaces
+
+import (
+	"io"
+	"time"
+)
+
+// Visibility values understood by every disk.
+const (
+	// VisibilityPublic marks a file as readable by everyone
+	VisibilityPublic = "public"
+
+	// VisibilityPrivate marks a file as readable by its owner only
+	VisibilityPrivate = "private"
+)
+
+// FilesystemInterface defines the contract for a single storage disk.
+//
+// Paths are always relative to the disk root and use forward slashes.
+// Write operations accept optional options maps understood by the driver:
+//   - "visibility": VisibilityPublic or VisibilityPrivate
+//   - "mimetype": Content type to store alongside the file
+//   - "cache_control": Cache-Control header for cloud disks
+//   - "metadata": map[string]string of custom metadata for cloud disks
+type FilesystemInterface interface {
+	// Exists reports whether a file or directory exists at the given path.
+	Exists(path string) bool
+
+	// Missing reports whether nothing exists at the given path.
+	Missing(path string) bool
+
+	// Get returns the contents of a file.
+	Get(path string) ([]byte, error)
+
+	// Put writes contents to a file, creating parent directories as needed.
+	Put(path string, contents []byte, options ...map[string]interface{}) error
+
+	// ReadStream opens a file for streamed reading. The caller must close it.
+	ReadStream(path string) (io.ReadCloser, error)
+
+	// WriteStream writes a file from a stream without buffering it in memory.
+	WriteStream(path string, stream io.Reader, options ...map[string]interface{}) error
+
+	// Delete removes one or more files. Missing files are ignored.
+	Delete(paths ...string) error
+
+	// Copy copies a file to a new location.
+	Copy(from, to string) error
+
+	// Move moves a file to a new location.
+	Move(from, to string) error
+
+	// Size returns the size of a file in bytes.
+	Size(path string) (int64, error)
+
+	// LastModified returns the last modification time of a file.
+	LastModified(path string) (time.Time, error)
+
+	// MimeType returns the content type of a file.
+	MimeType(path string) (string, error)
+
+	// GetVisibility returns the visibility of a file.
+	GetVisibility(path string) (string, error)
+
+	// SetVisibility changes the visibility of a file.
+	SetVisibility(path, visibility string) error
+
+	// Files lists the files directly inside a directory.
+	Files(directory string) ([]string, error)
+
+	// AllFiles lists the files inside a directory, recursively.
+	AllFiles(directory string) ([]string, error)
+
+	// Directories lists the directories directly inside a directory.
+	Directories(directory string) ([]string, error)
+
+	// AllDirectories lists the directories inside a directory, recursively.
+	AllDirectories(directory string) ([]string, error)
+
+	// MakeDirectory creates a directory and its parents.
+	MakeDirectory(path string) error
+
+	// DeleteDirectory removes a directory and everything inside it.
+	DeleteDirectory(directory string) error
+}
@@ -1,6 +1,24 @@
 package interfaces
 
-// storageInterface defines the contract for storage interface functionality.
+// StorageInterface defines the contract for the filesystem manager.
+//
+// The manager resolves named disks configured under filesystems.disks and
+// proxies every disk operation to the default disk, so
+// Storage().Put(...) and Storage().Disk().Put(...) are equivalent.
 type StorageInterface interface {
-	// TODO: Define methods for this interface
+	CloudInterface
+
+	// Disk returns the named disk, or the default disk when no name is given.
+	// It panics if the disk is not configured or cannot be built; use
+	// DiskWithError to handle misconfiguration gracefully.
+	Disk(name ...string) CloudInterface
+
+	// DiskWithError returns the named disk or the error raised while building it.
+	DiskWithError(name ...string) (CloudInterface, error)
+
+	// Build creates an on-demand disk from the given configuration without caching it.
+	Build(config map[string]interface{}) (CloudInterface, error)
+
+	// GetDefaultDriver returns the name of the default disk.
+	GetDefaultDriver() string
 }
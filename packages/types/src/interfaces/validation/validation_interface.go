@@ -1,6 +1,30 @@
 package interfaces
 
-// validationInterface defines the contract for validation interface functionality.
+// ValidationInterface defines the contract of the validation factory, the
+// service behind the Validation facade. It mirrors Laravel's
+// Illuminate\Contracts\Validation\Factory contract.
+//
+// Rules are given per attribute as a pipe-delimited string
+// ("required|email|max:255") or as a slice mixing rule strings and rule
+// objects. Attributes may use "*" wildcards to validate every element of
+// a nested array ("users.*.email").
 type ValidationInterface interface {
-	// TODO: Define methods for this interface
+	// Make creates a validator for the data.
+	//
+	// Parameters:
+	//   - data: The data under validation
+	//   - rules: Rules keyed by attribute
+	//   - messages: Optional custom messages keyed by "attribute.rule" or "rule"
+	//
+	// Returns:
+	//   - ValidatorInterface: The validator, run lazily on first use
+	Make(data map[string]interface{}, rules map[string]interface{}, messages ...map[string]string) ValidatorInterface
+
+	// Extend registers a custom rule usable by name in rule strings.
+	//
+	// Parameters:
+	//   - name: The rule name, e.g. "phone"
+	//   - rule: Reports whether the value passes, given the rule parameters
+	//   - message: The default message, may contain ":attribute"
+	Extend(name string, rule func(attribute string, value interface{}, parameters []string) bool, message string)
 }
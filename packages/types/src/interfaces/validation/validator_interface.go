@@ -0,0 +1,23 @@
+package interfaces
+
+// ValidatorInterface defines the contract of a validator created by
+// ValidationInterface.Make. It mirrors Laravel's
+// Illuminate\Contracts\Validation\Validator contract.
+type ValidatorInterface interface {
+	// Passes runs the rules and reports whether every rule passed.
+	Passes() bool
+
+	// Fails runs the rules and reports whether a rule failed.
+	Fails() bool
+
+	// Errors returns the failure messages keyed by attribute, in rule order.
+	Errors() map[string][]string
+
+	// Validate runs the rules and returns an error describing the failures,
+	// or nil when the data is valid.
+	Validate() error
+
+	// Validated returns only the validated attributes of the data, keeping
+	// nested structure, or the Validate error when the data is invalid.
+	Validated() (map[string]interface{}, error)
+}
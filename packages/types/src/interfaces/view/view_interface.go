@@ -1,6 +1,22 @@
 package interfaces
 
-// viewInterface defines the contract for view interface functionality.
+import "io"
+
+// ViewInterface defines the contract for rendering named views. Names use
+// dot or slash notation without the file extension, such as "users.profile".
 type ViewInterface interface {
-	// TODO: Define methods for this interface
+	// Render renders the named view with data
+	Render(name string, data map[string]interface{}) (string, error)
+
+	// RenderToWriter renders the named view into w
+	RenderToWriter(w io.Writer, name string, data map[string]interface{}) error
+
+	// Exists reports whether the named view exists
+	Exists(name string) bool
+
+	// Share makes a value available to every view
+	Share(key string, value interface{})
+
+	// AddHelper adds a function callable from every view
+	AddHelper(name string, fn interface{})
 }
@@ -62,6 +62,34 @@ func App() map[string]any {
 		// the language folders that are provided through your application.
 		"fallback_locale": Env("APP_FALLBACK_LOCALE", "en"),
 
+		// Available Locales
+		//
+		// The locales your application is translated into. Request locale
+		// detection only ever selects one of these locales.
+		"available_locales": []string{"en"},
+
+		// Locale Detection
+		//
+		// The SetLocale middleware tries these sources in order to pick the
+		// locale of each request: "route" (the {locale} parameter or first
+		// path segment), "query", "cookie", "session" and "header"
+		// (Accept-Language). The detected locale is stored on the request and
+		// never changes the application locale above.
+		"locale_detection": map[string]any{
+			"order":           []string{"route", "query", "cookie", "session", "header"},
+			"query":           "lang",
+			"cookie":          "locale",
+			"session":         "locale",
+			"route_parameter": "locale",
+		},
+
+		// Missing Translations
+		//
+		// When enabled, every translation key that cannot be resolved at
+		// runtime is logged once per locale as a warning, with the key and
+		// locale as context. Use "lang:missing" to audit keys ahead of time.
+		"log_missing_translations": Env("APP_LOG_MISSING_TRANSLATIONS", false),
+
 		// Application Cipher
 		//
 		// This cipher is used by the encryption services to encrypt data.
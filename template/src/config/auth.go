@@ -29,7 +29,7 @@ func Auth() map[string]any {
 		// Next, you may define every authentication guard for your application.
 		// Of course, a great default configuration has been defined for you
 		// which utilizes session storage plus the Eloquent user provider.
-		// Supported: "session"
+		// Supported: "session", "token"
 		"guards": map[string]any{
 			// Web Guard Configuration
 			//
@@ -57,7 +57,7 @@ func Auth() map[string]any {
 		// providers to represent the model / table. These providers may then
 		// be assigned to any extra authentication guards you have defined.
 		//
-		// Supported: "database", "eloquent"
+		// Supported: "database", "eloquent", "memory"
 		//
 		"providers": map[string]any{
 			// Users Provider Configuration
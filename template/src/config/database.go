@@ -282,9 +282,10 @@ func Database() map[string]any {
 
 			// Redis Client Type
 			//
-			// The Redis client library to use for connections. Different clients
-			// may have different performance characteristics and feature support.
-			"client": Env("REDIS_CLIENT", "phpredis"),
+			// The connector used to create connections. "native" uses the
+			// built-in RESP client ("phpredis" and "predis" are accepted as
+			// aliases); custom clients can be registered with RedisManager.Extend.
+			"client": Env("REDIS_CLIENT", "native"),
 
 			// Global Redis Options
 			//
@@ -23,7 +23,7 @@ func Filesystems() map[string]any {
 		// may even configure multiple disks for the same driver. Examples for
 		// most supported storage drivers are configured here for reference.
 		//
-		// Supported drivers: "local", "ftp", "sftp", "s3"
+		// Supported drivers: "local", "memory", "s3"
 		//
 		"disks": map[string]any{
 
@@ -38,9 +38,12 @@ func Filesystems() map[string]any {
 				// Root directory path for file storage
 				"root": StoragePath("app/private"),
 
-				// Whether to serve files directly (for private files)
+				// Whether to serve files through signed routes under /storage (enables TemporaryURL)
 				"serve": true,
 
+				// Whether to take file locks while reading and writing
+				"lock": false,
+
 				// Whether to throw exceptions on errors
 				"throw": false,
 
@@ -72,6 +75,18 @@ func Filesystems() map[string]any {
 				"report": false,
 			},
 
+			// In-Memory Storage Disk
+			//
+			// This disk keeps files in memory for the lifetime of the process.
+			// Useful for tests and for short-lived scratch files.
+			"memory": map[string]any{
+				// Storage driver type
+				"driver": "memory",
+
+				// File visibility for new files
+				"visibility": "private",
+			},
+
 			// Amazon S3 Cloud Storage Disk
 			//
 			// This disk stores files in Amazon S3 cloud storage.
@@ -17,9 +17,15 @@ func Jwt() map[string]any {
 		//
 		// The algorithm used to sign JWT tokens. Common algorithms include
 		// HS256 (HMAC with SHA-256) and RS256 (RSA Signature with SHA-256).
-		// Supported: "HS256", "HS384", "HS512", "RS256", "RS384", "RS512"
+		// Supported: "HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "EdDSA"
 		"algorithm": Env("JWT_ALGORITHM", "HS256"),
 
+		// Key ID
+		//
+		// The "kid" header of issued tokens. Give every signing key its own ID
+		// so tokens signed with previous keys keep verifying after a rotation.
+		"key_id": Env("JWT_KEY_ID", ""),
+
 		// Access Token TTL (Time To Live)
 		//
 		// How long access tokens remain valid (in minutes). Shorter lifetimes
@@ -57,6 +63,23 @@ func Jwt() map[string]any {
 			"passphrase":       Env("JWT_RSA_PASSPHRASE", ""),
 		},
 
+		// Ed25519 Keys (for EdDSA)
+		//
+		// PKCS#8 private and PKIX public key files in PEM format.
+		"eddsa": map[string]any{
+			"private_key_path": Env("JWT_EDDSA_PRIVATE_KEY_PATH", ""),
+			"public_key_path":  Env("JWT_EDDSA_PUBLIC_KEY_PATH", ""),
+		},
+
+		// Previous Keys
+		//
+		//
+		// Keys that no longer sign tokens but still verify them until they
+		// expire. Each entry holds "kid", "algorithm" and either "secret" or
+		// "public_key_path".
+		//
+		"previous_keys": []any{},
+
 		// Token Storage
 		//
 		//
@@ -66,6 +89,10 @@ func Jwt() map[string]any {
 		"storage": map[string]any{
 			"driver": Env("JWT_STORAGE_DRIVER", "memory"), // memory, redis, database
 			"prefix": Env("JWT_STORAGE_PREFIX", "jwt_tokens:"),
+			// Table and connection of the database driver; the table has a
+			// unique "token_key" string and an "expires_at" integer column
+			"table":      Env("JWT_STORAGE_TABLE", "jwt_tokens"),
+			"connection": Env("JWT_STORAGE_CONNECTION", ""),
 			"blacklist": map[string]any{
 				"enabled":          Env("JWT_BLACKLIST_ENABLED", true),
 				"grace_period":     Env("JWT_BLACKLIST_GRACE_PERIOD", 30),      // seconds
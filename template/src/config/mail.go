@@ -28,9 +28,9 @@ func Mail() map[string]any {
 		// when delivering an email. You may specify which one you're using for
 		// your mailers below. You may also add additional mailers if needed.
 		//
-		// Supported: "smtp", "sendmail", "mailgun", "ses", "ses-v2",
-		// "postmark", "resend", "log", "array",
-		// "failover", "roundrobin"
+		// Supported: "smtp", "log", "array", "failover"
+		// Other transports ("sendmail", "ses", "postmark", "resend",
+		// "roundrobin") must be registered with the mail manager's Extend
 		//
 		"mailers": map[string]any{
 
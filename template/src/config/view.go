@@ -0,0 +1,35 @@
+package config
+
+// View returns the view configuration map.
+// This matches Laravel's view.php configuration structure.
+// This configuration handles where views are loaded from and whether
+// parsed templates are cached between requests.
+func View() map[string]any {
+	return map[string]any{
+
+		// View Storage Paths
+		//
+		//
+		// Most templating systems load templates from disk. Here you may specify
+		// an array of paths that should be checked for your views. Paths are
+		// searched in order, so earlier paths override views of later ones.
+		//
+		"paths": []string{
+			"resources/views",
+		},
+
+		// View File Extension
+		//
+		// The extension of view files. Views are referenced without it,
+		// using dot notation such as "users.profile".
+		"extension": Env("VIEW_EXTENSION", ".html"),
+
+		// Template Caching
+		//
+		// When enabled, parsed templates are kept in memory for the life of
+		// the process. When disabled, views are reparsed whenever their files
+		// change so edits show up on the next request. Caching is disabled
+		// for the "local" environment by default.
+		"cache": Env("VIEW_CACHE", Env("APP_ENV", "production").(string) != "local"),
+	}
+}